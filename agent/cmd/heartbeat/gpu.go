@@ -0,0 +1,125 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// gpuResource is the extended resource for whole NVIDIA GPUs.
+	gpuResource = "nvidia.com/gpu"
+	// migResourcePrefix prefixes MIG slice resources advertised by the NVIDIA
+	// device plugin with the "mixed" strategy, e.g. nvidia.com/mig-1g.10gb.
+	migResourcePrefix = "nvidia.com/mig-"
+	// gpuProductLabel is set on GPU nodes by GPU feature discovery.
+	gpuProductLabel = "nvidia.com/gpu.product"
+)
+
+// gatherGPUCapacity sums whole-GPU and MIG slice capacity from nodes and the
+// amount allocated to scheduled, non-terminated pods. GPUTypes is keyed by
+// product for whole GPUs and by "<product>/<profile>" (e.g.
+// "NVIDIA-A100-SXM4-80GB/mig-1g.10gb") for MIG slices. Returns nil when the
+// cluster has no GPUs.
+func gatherGPUCapacity(nodes, pods []unstructured.Unstructured) *GPUCapacity {
+	gpu := &GPUCapacity{GPUTypes: make(map[string]int32)}
+	for _, node := range nodes {
+		capacity, _, _ := unstructured.NestedMap(node.Object, "status", "capacity")
+		product := node.GetLabels()[gpuProductLabel]
+		for name, val := range capacity {
+			count := parseCount(val)
+			if count <= 0 {
+				continue
+			}
+			switch {
+			case name == gpuResource:
+				gpu.TotalGPUs += count
+				if product != "" {
+					gpu.GPUTypes[product] += count
+				}
+			case strings.HasPrefix(name, migResourcePrefix):
+				gpu.TotalMIGSlices += count
+				gpu.GPUTypes[migTypeKey(product, strings.TrimPrefix(name, "nvidia.com/"))] += count
+			}
+		}
+	}
+	if gpu.TotalGPUs == 0 && gpu.TotalMIGSlices == 0 {
+		return nil
+	}
+
+	for _, pod := range pods {
+		if nodeName, _, _ := unstructured.NestedString(pod.Object, "spec", "nodeName"); nodeName == "" {
+			continue
+		}
+		if phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase"); phase == "Succeeded" || phase == "Failed" {
+			continue
+		}
+		for name, count := range podGPURequests(pod) {
+			if name == gpuResource {
+				gpu.AllocatedGPUs += count
+			} else {
+				gpu.AllocatedMIGSlices += count
+			}
+		}
+	}
+	return gpu
+}
+
+// podGPURequests returns a pod's effective whole-GPU and MIG slice requests
+// by resource name, using the scheduler's rule: the larger of the sum over
+// app containers and the largest single init container request.
+func podGPURequests(pod unstructured.Unstructured) map[string]int32 {
+	totals := make(map[string]int32)
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	for _, c := range containers {
+		for name, count := range containerGPURequests(c) {
+			totals[name] += count
+		}
+	}
+	initContainers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "initContainers")
+	for _, c := range initContainers {
+		for name, count := range containerGPURequests(c) {
+			totals[name] = max(totals[name], count)
+		}
+	}
+	return totals
+}
+
+// containerGPURequests returns a container's whole-GPU and MIG slice requests.
+func containerGPURequests(c any) map[string]int32 {
+	container, ok := c.(map[string]any)
+	if !ok {
+		return nil
+	}
+	requests, _, _ := unstructured.NestedMap(container, "resources", "requests")
+	counts := make(map[string]int32)
+	for name, val := range requests {
+		if name == gpuResource || strings.HasPrefix(name, migResourcePrefix) {
+			counts[name] = parseCount(val)
+		}
+	}
+	return counts
+}
+
+// migTypeKey builds the GPUTypes key for a MIG profile on a GPU product.
+func migTypeKey(product, profile string) string {
+	if product == "" {
+		return profile
+	}
+	return product + "/" + profile
+}
+
+// parseCount parses an integer resource quantity as found in node capacity
+// and container requests. Non-integer values count as zero.
+func parseCount(val any) int32 {
+	s, ok := val.(string)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return int32(n)
+}
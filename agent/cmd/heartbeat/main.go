@@ -3,6 +3,8 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -10,10 +12,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/discovery"
@@ -30,6 +33,9 @@ type HeartbeatPayload struct {
 	NGFVersion        string          `json:"ngfVersion"`
 	ResourceCounts    *ResourceCounts `json:"resourceCounts,omitempty"`
 	GPUCapacity       *GPUCapacity    `json:"gpuCapacity,omitempty"`
+	// Errors lists resource kinds that could not be discovered, with the
+	// error, so the hub can distinguish zero counts from failed lists.
+	Errors []string `json:"errors,omitempty"`
 }
 
 type ResourceCounts struct {
@@ -43,15 +49,20 @@ type ResourceCounts struct {
 }
 
 type GPUCapacity struct {
-	TotalGPUs     int32            `json:"totalGPUs"`
-	AllocatedGPUs int32            `json:"allocatedGPUs"`
-	GPUTypes      map[string]int32 `json:"gpuTypes,omitempty"`
+	TotalGPUs          int32            `json:"totalGPUs"`
+	AllocatedGPUs      int32            `json:"allocatedGPUs"`
+	TotalMIGSlices     int32            `json:"totalMIGSlices,omitempty"`
+	AllocatedMIGSlices int32            `json:"allocatedMIGSlices,omitempty"`
+	GPUTypes           map[string]int32 `json:"gpuTypes,omitempty"`
 }
 
 func main() {
 	clusterName := flag.String("cluster-name", os.Getenv("CLUSTER_NAME"), "Name of this cluster")
 	hubAPI := flag.String("hub-api", os.Getenv("HUB_API_ENDPOINT"), "Hub API endpoint URL")
 	authToken := flag.String("auth-token", os.Getenv("HUB_AUTH_TOKEN"), "Authentication token for hub API")
+	clientCert := flag.String("client-cert", os.Getenv("HUB_CLIENT_CERT"), "Path to client certificate for mTLS to the hub API")
+	clientKey := flag.String("client-key", os.Getenv("HUB_CLIENT_KEY"), "Path to client private key for mTLS to the hub API")
+	caCert := flag.String("ca-cert", os.Getenv("HUB_CA_CERT"), "Path to CA certificate used to verify the hub API (system roots if empty)")
 	interval := flag.Duration("interval", 30*time.Second, "Heartbeat interval")
 	kubeconfig := flag.String("kubeconfig", "", "Path to kubeconfig (in-cluster if empty)")
 	flag.Parse()
@@ -60,6 +71,10 @@ func main() {
 		fmt.Fprintln(os.Stderr, "cluster-name and hub-api are required")
 		os.Exit(1)
 	}
+	if (*clientCert == "") != (*clientKey == "") {
+		fmt.Fprintln(os.Stderr, "client-cert and client-key must be set together")
+		os.Exit(1)
+	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	slog.SetDefault(logger)
@@ -70,7 +85,7 @@ func main() {
 		"interval", interval.String(),
 	)
 
-	// Start health probe server.
+	// Start health probe and metrics server.
 	healthMux := http.NewServeMux()
 	healthMux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -78,6 +93,7 @@ func main() {
 	healthMux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	healthMux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
 	go func() {
 		if err := http.ListenAndServe(":8081", healthMux); err != nil {
 			slog.Error("health server failed", "error", err)
@@ -115,6 +131,17 @@ func main() {
 	}()
 
 	httpClient := &http.Client{Timeout: 10 * time.Second}
+	if *clientCert != "" || *caCert != "" {
+		tlsCfg, err := buildTLSConfig(*clientCert, *clientKey, *caCert)
+		if err != nil {
+			slog.Error("failed to build TLS config", "error", err)
+			os.Exit(1)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsCfg
+		httpClient.Transport = transport
+		slog.Info("mTLS enabled for hub API", "clientCert", *clientCert != "", "customCA", *caCert != "")
+	}
 	endpoint := fmt.Sprintf("%s/api/v1/clusters/%s/heartbeat", *hubAPI, *clusterName)
 
 	ticker := time.NewTicker(*interval)
@@ -135,16 +162,19 @@ func main() {
 }
 
 func sendHeartbeat(ctx context.Context, client *http.Client, endpoint, token string, dc dynamic.Interface, disco *discovery.DiscoveryClient) {
+	heartbeatAttempts.Inc()
 	payload := gatherPayload(ctx, dc, disco)
 
 	body, err := json.Marshal(payload)
 	if err != nil {
+		heartbeatFailures.Inc()
 		slog.Error("failed to marshal heartbeat", "error", err)
 		return
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
+		heartbeatFailures.Inc()
 		slog.Error("failed to create request", "error", err)
 		return
 	}
@@ -153,80 +183,133 @@ func sendHeartbeat(ctx context.Context, client *http.Client, endpoint, token str
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
+	start := time.Now()
 	resp, err := client.Do(req)
+	heartbeatLatency.Observe(time.Since(start).Seconds())
 	if err != nil {
+		heartbeatFailures.Inc()
 		slog.Warn("heartbeat failed", "error", err)
 		return
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
+		heartbeatFailures.Inc()
 		slog.Warn("heartbeat returned non-success", "status", resp.StatusCode)
 		return
 	}
 
+	heartbeatLastSuccess.SetToCurrentTime()
 	slog.Info("heartbeat sent", "status", resp.StatusCode)
 }
 
+// inferencePoolGVRs are the InferencePool group/versions the API server and
+// operator accept.
+var inferencePoolGVRs = []schema.GroupVersionResource{
+	{Group: "inference.networking.k8s.io", Version: "v1", Resource: "inferencepools"},
+	{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Resource: "inferencepools"},
+}
+
 func gatherPayload(ctx context.Context, dc dynamic.Interface, disco *discovery.DiscoveryClient) HeartbeatPayload {
 	payload := HeartbeatPayload{}
 
 	// K8s version.
 	if info, err := disco.ServerVersion(); err == nil {
 		payload.KubernetesVersion = info.GitVersion
+	} else {
+		slog.Warn("failed to get server version", "error", err)
+		payload.Errors = append(payload.Errors, fmt.Sprintf("version: %v", err))
 	}
 
-	// Resource counts.
+	// Resource counts. Failed lists leave the count at zero and are recorded
+	// in payload.Errors so the hub can tell "none" from "unknown".
 	counts := &ResourceCounts{}
-
-	gwGVR := schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}
-	if list, err := dc.Resource(gwGVR).List(ctx, metav1.ListOptions{}); err == nil {
-		counts.Gateways = int32(len(list.Items))
+	count := func(gvr schema.GroupVersionResource, crd bool) int32 {
+		n, err := countResources(ctx, dc, gvr, crd)
+		if err != nil {
+			slog.Warn("failed to list resources", "gvr", gvr.String(), "error", err)
+			payload.Errors = append(payload.Errors, fmt.Sprintf("%s: %v", gvr.String(), err))
+		}
+		return n
 	}
 
-	routeGVR := schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
-	if list, err := dc.Resource(routeGVR).List(ctx, metav1.ListOptions{}); err == nil {
-		counts.HTTPRoutes = int32(len(list.Items))
+	counts.Gateways = count(schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}, false)
+	counts.HTTPRoutes = count(schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}, false)
+	counts.Namespaces = count(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, false)
+	counts.Services = count(schema.GroupVersionResource{Version: "v1", Resource: "services"}, false)
+	// InferencePools are served from the GA group or the older x-k8s.io
+	// group depending on the installed inference extension; count both.
+	for _, gvr := range inferencePoolGVRs {
+		counts.InferencePools += count(gvr, true)
 	}
+	counts.InferenceStacks = count(schema.GroupVersionResource{Group: "ngf-console.f5.com", Version: "v1alpha1", Resource: "inferencestacks"}, true)
+	counts.GatewayBundles = count(schema.GroupVersionResource{Group: "ngf-console.f5.com", Version: "v1alpha1", Resource: "gatewaybundles"}, true)
+
+	payload.ResourceCounts = counts
 
-	nsGVR := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
-	if list, err := dc.Resource(nsGVR).List(ctx, metav1.ListOptions{}); err == nil {
-		counts.Namespaces = int32(len(list.Items))
+	// GPU capacity from nodes advertising nvidia.com/gpu or MIG slices, and
+	// allocation from pod requests.
+	nodeGVR := schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	nodes, err := dc.Resource(nodeGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.Warn("failed to list resources", "gvr", nodeGVR.String(), "error", err)
+		payload.Errors = append(payload.Errors, fmt.Sprintf("%s: %v", nodeGVR.String(), err))
+		return payload
+	}
+	var pods []unstructured.Unstructured
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	if list, err := dc.Resource(podGVR).List(ctx, metav1.ListOptions{}); err != nil {
+		slog.Warn("failed to list resources", "gvr", podGVR.String(), "error", err)
+		payload.Errors = append(payload.Errors, fmt.Sprintf("%s: %v", podGVR.String(), err))
+	} else {
+		pods = list.Items
 	}
+	payload.GPUCapacity = gatherGPUCapacity(nodes.Items, pods)
 
-	svcGVR := schema.GroupVersionResource{Version: "v1", Resource: "services"}
-	if list, err := dc.Resource(svcGVR).List(ctx, metav1.ListOptions{}); err == nil {
-		counts.Services = int32(len(list.Items))
+	return payload
+}
+
+// countResources returns the number of objects of gvr across all namespaces.
+// When crd is true, a resource that is not installed (NotFound) counts as
+// zero rather than an error.
+func countResources(ctx context.Context, dc dynamic.Interface, gvr schema.GroupVersionResource, crd bool) (int32, error) {
+	list, err := dc.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if crd && apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
 	}
+	return int32(len(list.Items)), nil
+}
 
-	payload.ResourceCounts = counts
+// buildTLSConfig builds the TLS config for hub API requests. The client
+// certificate is presented when certFile/keyFile are set; the hub is verified
+// against caFile, or the system roots when caFile is empty.
+func buildTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
 
-	// GPU capacity from nodes with nvidia.com/gpu.
-	nodeGVR := schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
-	if list, err := dc.Resource(nodeGVR).List(ctx, metav1.ListOptions{}); err == nil {
-		gpu := &GPUCapacity{GPUTypes: make(map[string]int32)}
-		for _, node := range list.Items {
-			capacity, _, _ := unstructured.NestedMap(node.Object, "status", "capacity")
-			if gpuStr, ok := capacity["nvidia.com/gpu"]; ok {
-				if gpuVal, ok := gpuStr.(string); ok && gpuVal != "0" {
-					count, err := strconv.ParseInt(gpuVal, 10, 32)
-					if err != nil || count <= 0 {
-						continue
-					}
-					gpu.TotalGPUs += int32(count)
-					labels := node.GetLabels()
-					if gpuType, ok := labels["nvidia.com/gpu.product"]; ok {
-						gpu.GPUTypes[gpuType] += int32(count)
-					}
-				}
-			}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate: %w", err)
 		}
-		if gpu.TotalGPUs > 0 {
-			payload.GPUCapacity = gpu
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid certificates found in %s", caFile)
 		}
+		tlsCfg.RootCAs = pool
 	}
 
-	return payload
+	return tlsCfg, nil
 }
 
 func buildConfig(kubeconfigPath string) (*rest.Config, error) {
@@ -241,4 +324,3 @@ func buildConfig(kubeconfigPath string) (*rest.Config, error) {
 	}
 	return cfg, nil
 }
-
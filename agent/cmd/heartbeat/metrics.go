@@ -0,0 +1,40 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Heartbeat metrics, registered on a dedicated registry and served at /metrics.
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	heartbeatAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "heartbeat_attempts_total",
+		Help: "Total number of heartbeat attempts.",
+	})
+	heartbeatFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "heartbeat_failures_total",
+		Help: "Total number of heartbeat attempts that did not succeed.",
+	})
+	heartbeatLastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "heartbeat_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful heartbeat.",
+	})
+	heartbeatLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "heartbeat_request_duration_seconds",
+		Help:    "Round-trip latency of the heartbeat POST to the hub API.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		heartbeatAttempts,
+		heartbeatFailures,
+		heartbeatLastSuccess,
+		heartbeatLatency,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
@@ -10,7 +10,10 @@ import (
 
 	"time"
 
+	"k8s.io/client-go/discovery"
+
 	"github.com/kubenetlabs/ngc/api/internal/alerting"
+	"github.com/kubenetlabs/ngc/api/internal/auth"
 	chprovider "github.com/kubenetlabs/ngc/api/internal/clickhouse"
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/database"
@@ -18,7 +21,9 @@ import (
 	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 	mc "github.com/kubenetlabs/ngc/api/internal/multicluster"
 	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
 	"github.com/kubenetlabs/ngc/api/internal/server"
+	"github.com/kubenetlabs/ngc/api/internal/xc"
 	"github.com/kubenetlabs/ngc/api/pkg/version"
 )
 
@@ -28,12 +33,38 @@ func main() {
 	clustersConfig := flag.String("clusters-config", "", "Path to clusters YAML config (enables multi-cluster)")
 	dbType := flag.String("db-type", "mock", "Metrics provider backend (mock, clickhouse)")
 	clickhouseURL := flag.String("clickhouse-url", "localhost:9000", "ClickHouse connection URL")
+	clickhouseMaxConns := flag.Int("clickhouse-max-conns", chprovider.DefaultOptions().MaxOpenConns, "Maximum open ClickHouse connections in the client pool")
+	clickhouseHealthInterval := flag.Duration("clickhouse-health-interval", 15*time.Second, "How often to ping ClickHouse; ClickHouse-backed endpoints return 503 while pings fail")
+	clickhouseRawRetention := flag.Duration("clickhouse-raw-retention", chprovider.DefaultRetention().Raw, "TTL for raw ClickHouse log and decision tables, in whole days (0 leaves the schema TTL unchanged)")
+	clickhouseRollupRetention := flag.Duration("clickhouse-rollup-retention", chprovider.DefaultRetention().Rollup, "TTL for ClickHouse per-minute rollup tables, in whole days (0 leaves the schema TTL unchanged)")
+	metricsRetention := flag.Duration("metrics-retention", chprovider.DefaultRetention().Metrics, "TTL for scraped inference metrics and their ClickHouse rollup views, in whole days (0 leaves the schema TTL unchanged)")
 	prometheusURL := flag.String("prometheus-url", "", "Prometheus server URL (e.g., http://prometheus:9090)")
+	prometheusMetricNames := flag.String("prometheus-metric-names", "", "Comma-separated NGF metric name overrides as key=value (keys: requests, duration, connections)")
+	configStore := flag.String("config-store", "sqlite", "Config store backend (sqlite, postgres)")
 	configDB := flag.String("config-db", "ngf-console.db", "Path to SQLite config database")
+	configDSN := flag.String("config-dsn", os.Getenv("CONFIG_DB_DSN"), "Postgres DSN for the config store (used with --config-store=postgres)")
+	configEncryptionKey := flag.String("config-encryption-key", os.Getenv("CONFIG_ENCRYPTION_KEY"), "32-byte key (base64 or hex) used to encrypt XC API tokens at rest")
+	configEncryptionKeyFile := flag.String("config-encryption-key-file", "", "Path to a file containing the config encryption key")
 	alertWebhooks := flag.String("alert-webhooks", "", "Comma-separated webhook URLs for alert notifications")
+	alertSlackWebhook := flag.String("alert-slack-webhook", os.Getenv("ALERT_SLACK_WEBHOOK"), "Slack incoming webhook URL for alert notifications")
+	alertSlackChannel := flag.String("alert-slack-channel", "", "Slack channel override for alert notifications (e.g. #alerts)")
+	alertPagerDutyKey := flag.String("alert-pagerduty-key", os.Getenv("ALERT_PAGERDUTY_KEY"), "PagerDuty Events API v2 integration (routing) key for alert notifications")
+	alertPagerDutySeverity := flag.String("alert-pagerduty-severity", "critical", "Minimum alert severity that pages PagerDuty (info, warning, critical)")
 	multicluster := flag.Bool("multicluster", false, "Enable CRD-based multi-cluster mode (reads ManagedCluster CRDs)")
 	multiclusterNS := flag.String("multicluster-namespace", "ngf-system", "Namespace for ManagedCluster CRDs")
 	multiclusterDefault := flag.String("multicluster-default", "", "Default cluster name in multi-cluster mode")
+	heartbeatStaleAfter := flag.Duration("heartbeat-stale-after", 90*time.Second, "Mark a cluster stale and alert when its agent has not heartbeated for this long, in multi-cluster mode (0 disables; agents heartbeat every 30s by default)")
+	requireHeartbeatToken := flag.Bool("require-heartbeat-token", false, "Reject agent heartbeats from clusters that have no heartbeat token issued (clusters with a token are always verified)")
+	multiclusterFailoverLabel := flag.String("multicluster-failover-label", "", "ManagedCluster label grouping replica clusters; reads for an unhealthy cluster are served by a healthy cluster with the same label value (empty disables failover)")
+	xcRetryMaxElapsed := flag.Duration("xc-retry-max-elapsed", xc.DefaultRetryPolicy().MaxElapsed, "Total time XC API requests are retried after throttling (429) or transient 5xx errors (0 disables retries)")
+	xcWAFPolicyCacheTTL := flag.Duration("xc-waf-policy-cache-ttl", time.Minute, "How long XC WAF policy listings are cached per tenant and namespace")
+	inferencePoolGV := flag.String("inference-pool-group-version", "", "Pin the InferencePool group/version (e.g. inference.networking.x-k8s.io/v1alpha2); auto-detected if empty")
+	excludeNamespaces := flag.String("exclude-namespaces", defaultExcludeNamespaces(), "Comma-separated namespaces left out of coexistence discovery and counts unless a request sets ?includeSystem=true")
+	authMode := flag.String("auth-mode", auth.ModeNone, "How API callers authenticate (none, token, jwt); health, readiness, version and agent heartbeat endpoints are always open")
+	authToken := flag.String("auth-token", os.Getenv("AUTH_TOKEN"), "Bearer token accepted with --auth-mode=token")
+	authTokenFile := flag.String("auth-token-file", "", "Path to a file containing the bearer token for --auth-mode=token")
+	authJWTSecret := flag.String("auth-jwt-secret", os.Getenv("AUTH_JWT_SECRET"), "HMAC secret verifying HS256 tokens with --auth-mode=jwt")
+	authJWTIssuer := flag.String("auth-jwt-issuer", "", "Required iss claim of tokens with --auth-mode=jwt (empty accepts any issuer)")
 	showVersion := flag.Bool("version", false, "Print version and exit")
 	flag.Parse()
 
@@ -42,11 +73,13 @@ func main() {
 		os.Exit(0)
 	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(requestid.NewLogHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
+	xc.SetRetryPolicy(xc.RetryPolicy{MaxElapsed: *xcRetryMaxElapsed})
+
 	slog.Info("starting NGF Console API server",
 		"port", *port,
 		"db_type", *dbType,
@@ -56,6 +89,7 @@ func main() {
 
 	var mgr cluster.Provider
 	var pool *mc.ClientPool
+	var clusterCheck func(context.Context) error
 	if *multicluster {
 		// CRD-based multi-cluster mode: read ManagedCluster CRDs from hub.
 		k8sClient, err := kubernetes.New(*kubeconfig)
@@ -75,8 +109,12 @@ func main() {
 				defaultName = names[0]
 			}
 		}
-		mgr = mc.NewPoolAdapter(pool, defaultName)
-		slog.Info("CRD-based multi-cluster mode enabled", "clusters", pool.Names(), "namespace", *multiclusterNS)
+		var adapterOpts []mc.AdapterOption
+		if *multiclusterFailoverLabel != "" {
+			adapterOpts = append(adapterOpts, mc.WithFailover(*multiclusterFailoverLabel))
+		}
+		mgr = mc.NewPoolAdapter(pool, defaultName, adapterOpts...)
+		slog.Info("CRD-based multi-cluster mode enabled", "clusters", pool.Names(), "namespace", *multiclusterNS, "failoverLabel", *multiclusterFailoverLabel)
 
 		// Start health checker.
 		go mc.RunHealthChecker(context.Background(), pool, 30*time.Second)
@@ -101,6 +139,34 @@ func main() {
 		}
 		mgr = cluster.NewSingleCluster(k8sClient)
 		slog.Info("single-cluster mode")
+
+		clusterCheck = func(ctx context.Context) error {
+			_, err := k8sClient.ServerVersion(ctx)
+			return err
+		}
+		preflightCluster(k8sClient)
+	}
+
+	// Resolve the InferencePool group/version against the default cluster.
+	if *inferencePoolGV != "" {
+		if err := inference.SetInferencePoolGroupVersion(*inferencePoolGV); err != nil {
+			slog.Error("invalid inference pool group/version", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	if defaultClient, err := mgr.Default(); err == nil {
+		disco, err := discovery.NewDiscoveryClientForConfig(defaultClient.RestConfig())
+		if err != nil {
+			slog.Warn("failed to create discovery client, skipping InferencePool group/version detection", "error", err)
+		} else if err := inference.ResolveInferencePoolGVR(disco); err != nil {
+			slog.Error("invalid inference pool group/version", "error", err)
+			os.Exit(1)
+		} else {
+			go inference.RunInferencePoolResolver(context.Background(), disco, 30*time.Second)
+		}
+	} else {
+		slog.Warn("no default cluster, skipping InferencePool group/version detection", "error", err)
 	}
 
 	// Initialize metrics provider (mock for dev, ClickHouse for prod)
@@ -108,11 +174,30 @@ func main() {
 	var chClient *chprovider.Client
 	if *dbType == "clickhouse" && *clickhouseURL != "" {
 		var err error
-		chClient, err = chprovider.New(*clickhouseURL)
+		chOpts := chprovider.DefaultOptions()
+		chOpts.MaxOpenConns = *clickhouseMaxConns
+		if chOpts.MaxIdleConns > chOpts.MaxOpenConns {
+			chOpts.MaxIdleConns = chOpts.MaxOpenConns
+		}
+		chClient, err = chprovider.New(*clickhouseURL, chOpts)
 		if err != nil {
 			slog.Error("failed to create clickhouse client (explicitly configured)", "error", err)
 			os.Exit(1)
 		}
+		go chClient.RunHealthCheck(context.Background(), *clickhouseHealthInterval)
+		retention := chprovider.RetentionConfig{Raw: *clickhouseRawRetention, Rollup: *clickhouseRollupRetention, Metrics: *metricsRetention}
+		if err := retention.Validate(); err != nil {
+			slog.Error("invalid clickhouse retention", "error", err)
+			os.Exit(1)
+		}
+		if err := chClient.Migrate(context.Background()); err != nil {
+			slog.Warn("failed to run clickhouse migrations, long-range inference series may be empty", "error", err)
+		}
+		if err := chClient.ApplyRetention(context.Background(), retention); err != nil {
+			slog.Warn("failed to apply clickhouse retention, keeping existing table TTLs", "error", err)
+		} else {
+			slog.Info("clickhouse retention applied", "raw", retention.Raw, "rollup", retention.Rollup, "metrics", retention.Metrics)
+		}
 		metricsProvider = chprovider.NewProvider(chClient)
 		slog.Info("using clickhouse metrics provider", "url", *clickhouseURL)
 	} else {
@@ -131,10 +216,14 @@ func main() {
 	}
 
 	// Initialize Prometheus client if configured.
+	metricNames, err := prom.ParseMetricNames(*prometheusMetricNames)
+	if err != nil {
+		slog.Error("invalid prometheus metric names", "error", err)
+		os.Exit(1)
+	}
 	var promClient *prom.Client
 	if *prometheusURL != "" {
-		var err error
-		promClient, err = prom.New(*prometheusURL)
+		promClient, err = prom.New(*prometheusURL, metricNames)
 		if err != nil {
 			slog.Error("failed to create prometheus client", "error", err, "url", *prometheusURL)
 			os.Exit(1)
@@ -144,22 +233,29 @@ func main() {
 		slog.Info("prometheus not configured, RED metrics endpoints will return 503")
 	}
 
-	// Initialize config database (SQLite).
-	store, err := database.NewSQLite(*configDB)
+	// Initialize config database.
+	store, err := openConfigStore(*configStore, *configDB, *configDSN)
 	if err != nil {
-		slog.Error("failed to open config database", "error", err, "path", *configDB)
+		slog.Error("failed to open config database", "error", err, "store", *configStore)
 		os.Exit(1)
 	}
 	defer store.Close()
 	if err := store.Migrate(context.Background()); err != nil {
-		slog.Error("failed to migrate config database", "error", err)
+		slog.Error("failed to migrate config database", "error", err, "store", *configStore)
+		os.Exit(1)
+	}
+	slog.Info("config database ready", "store", *configStore)
+
+	store, err = withTokenEncryption(store, *configEncryptionKey, *configEncryptionKeyFile)
+	if err != nil {
+		slog.Error("failed to configure config encryption", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("config database ready", "path", *configDB)
 
-	// Seed XC credentials from environment variables (if set and DB has none).
+	// Seed the global XC credentials from environment variables (if set and
+	// DB has none).
 	if xcTenant := os.Getenv("XC_TENANT"); xcTenant != "" {
-		existing, _ := store.GetXCCredentials(context.Background())
+		existing, _ := store.GetXCCredentials(context.Background(), "")
 		if existing == nil {
 			xcToken := os.Getenv("XC_API_TOKEN")
 			xcNs := os.Getenv("XC_NAMESPACE")
@@ -192,19 +288,170 @@ func main() {
 		slog.Info("alert webhooks configured", "count", len(webhooks))
 	}
 
+	var slackChannels []alerting.SlackConfig
+	if *alertSlackWebhook != "" {
+		slackChannels = append(slackChannels, alerting.SlackConfig{
+			WebhookURL: *alertSlackWebhook,
+			Channel:    *alertSlackChannel,
+		})
+		slog.Info("alert slack notifications configured", "channel", *alertSlackChannel)
+	}
+
+	var pagerDuty []alerting.PagerDutyConfig
+	if *alertPagerDutyKey != "" {
+		pagerDuty = append(pagerDuty, alerting.PagerDutyConfig{
+			RoutingKey:  *alertPagerDutyKey,
+			MinSeverity: *alertPagerDutySeverity,
+		})
+		slog.Info("alert pagerduty notifications configured", "min_severity", *alertPagerDutySeverity)
+	}
+
+	var excludedNamespaces []string
+	for _, ns := range strings.Split(*excludeNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			excludedNamespaces = append(excludedNamespaces, ns)
+		}
+	}
+
+	authProvider, err := newAuthProvider(*authMode, *authToken, *authTokenFile, *authJWTSecret, *authJWTIssuer)
+	if err != nil {
+		slog.Error("failed to configure authentication", "error", err, "mode", *authMode)
+		os.Exit(1)
+	}
+
 	srv := server.New(server.Config{
-		ClusterManager:  mgr,
-		MetricsProvider: metricsProvider,
-		Store:           store,
-		PromClient:      promClient,
-		CHClient:        chClient,
-		Webhooks:        webhooks,
-		Pool:            pool,
+		ClusterManager:    mgr,
+		MetricsProvider:   metricsProvider,
+		Store:             store,
+		PromClient:        promClient,
+		MetricNames:       metricNames,
+		CHClient:          chClient,
+		Webhooks:          webhooks,
+		SlackChannels:     slackChannels,
+		PagerDuty:         pagerDuty,
+		Pool:              pool,
+		ClusterCheck:      clusterCheck,
+		WAFPolicyTTL:      *xcWAFPolicyCacheTTL,
+		ExcludeNamespaces: excludedNamespaces,
+
+		RequireHeartbeatToken: *requireHeartbeatToken,
+		Auth:                  authProvider,
 	})
 
+	if pool != nil && *heartbeatStaleAfter > 0 {
+		lastStored := func(ctx context.Context, cluster string) (time.Time, error) {
+			records, err := store.ListHeartbeats(ctx, cluster, time.Time{}, 1)
+			if err != nil || len(records) == 0 {
+				return time.Time{}, err
+			}
+			return records[0].Timestamp, nil
+		}
+		go mc.RunStaleDetector(context.Background(), pool, *heartbeatStaleAfter, srv.Evaluator, lastStored)
+	}
+
 	addr := fmt.Sprintf(":%d", *port)
 	if err := srv.Run(addr); err != nil {
 		slog.Error("server failed", "error", err)
 		os.Exit(1)
 	}
 }
+
+// defaultExcludeNamespaces is the default of --exclude-namespaces: the system
+// namespaces and the console's own namespace, from POD_NAMESPACE.
+func defaultExcludeNamespaces() string {
+	namespaces := []string{"kube-system", "kube-public"}
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		namespaces = append(namespaces, ns)
+	}
+	return strings.Join(namespaces, ",")
+}
+
+// preflightCluster checks that the cluster API server is reachable at startup.
+// An unreachable cluster is logged as a warning rather than a fatal error, so
+// the console still starts and /readyz reports the problem until it resolves.
+func preflightCluster(k8sClient *kubernetes.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	serverVersion, err := k8sClient.ServerVersion(ctx)
+	if err != nil {
+		slog.Warn("kubernetes API server is unreachable, check the kubeconfig; requests will fail until it is reachable",
+			"host", k8sClient.RestConfig().Host,
+			"error", err,
+		)
+		return
+	}
+	slog.Info("kubernetes API server reachable", "host", k8sClient.RestConfig().Host, "version", serverVersion)
+}
+
+// openConfigStore opens the config store backend selected by --config-store.
+func openConfigStore(kind, sqlitePath, postgresDSN string) (database.Store, error) {
+	switch kind {
+	case "sqlite":
+		return database.NewSQLite(sqlitePath)
+	case "postgres":
+		if postgresDSN == "" {
+			return nil, fmt.Errorf("--config-dsn (or CONFIG_DB_DSN) is required for the postgres config store")
+		}
+		return database.NewPostgres(postgresDSN)
+	default:
+		return nil, fmt.Errorf("unknown config store %q (expected sqlite or postgres)", kind)
+	}
+}
+
+// newAuthProvider returns the provider for --auth-mode, or nil when
+// authentication is off.
+func newAuthProvider(mode, token, tokenFile, jwtSecret, jwtIssuer string) (auth.Provider, error) {
+	switch mode {
+	case auth.ModeNone:
+		slog.Warn("API authentication is disabled, any caller can read and change resources; set --auth-mode")
+		return nil, nil
+	case auth.ModeToken:
+		if tokenFile != "" {
+			data, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading auth token file: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		if token == "" {
+			return nil, fmt.Errorf("--auth-token, AUTH_TOKEN or --auth-token-file is required with --auth-mode=token")
+		}
+		slog.Info("API authentication enabled", "mode", mode)
+		return auth.NewStaticToken(token), nil
+	case auth.ModeJWT:
+		if jwtSecret == "" {
+			return nil, fmt.Errorf("--auth-jwt-secret (or AUTH_JWT_SECRET) is required with --auth-mode=jwt")
+		}
+		slog.Info("API authentication enabled", "mode", mode, "issuer", jwtIssuer)
+		return &auth.JWT{Secret: jwtSecret, Issuer: jwtIssuer}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q (expected none, token or jwt)", mode)
+	}
+}
+
+// withTokenEncryption wraps store so XC API tokens are encrypted at rest when a
+// key is configured. Without a key, tokens are stored in plaintext.
+func withTokenEncryption(store database.Store, key, keyFile string) (database.Store, error) {
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading encryption key file: %w", err)
+		}
+		key = string(data)
+	}
+	if key == "" {
+		slog.Warn("no config encryption key set, XC API tokens are stored in plaintext")
+		return store, nil
+	}
+	parsed, err := database.ParseEncryptionKey(key)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := database.NewEncryptedStore(store, parsed)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("XC API token encryption enabled")
+	return encrypted, nil
+}
@@ -14,12 +14,15 @@ import (
 // Evaluator periodically evaluates alert rules against metric values
 // and sends webhook notifications when alert state changes.
 type Evaluator struct {
-	store    database.Store
-	interval time.Duration
-	mu       sync.Mutex
-	firing   map[string]*FiringAlert // ruleID -> alert
-	webhooks []WebhookConfig
-	cancel   context.CancelFunc
+	store     database.Store
+	interval  time.Duration
+	mu        sync.Mutex
+	firing    map[string]*FiringAlert // ruleID -> alert
+	system    map[string]*FiringAlert // alerts raised by the server itself, not by a rule
+	webhooks  []WebhookConfig
+	slack     []SlackConfig
+	pagerduty []PagerDutyConfig
+	cancel    context.CancelFunc
 }
 
 // FiringAlert represents an alert that is currently in the firing state.
@@ -41,14 +44,17 @@ type WebhookConfig struct {
 	Headers map[string]string `json:"headers,omitempty"`
 }
 
-// New creates a new Evaluator with the given store and webhook configs.
-// The evaluation interval is fixed at 60 seconds.
-func New(store database.Store, webhooks []WebhookConfig) *Evaluator {
+// New creates a new Evaluator with the given store, webhook, Slack, and
+// PagerDuty configs. The evaluation interval is fixed at 60 seconds.
+func New(store database.Store, webhooks []WebhookConfig, slack []SlackConfig, pagerduty []PagerDutyConfig) *Evaluator {
 	return &Evaluator{
-		store:    store,
-		interval: 60 * time.Second,
-		firing:   make(map[string]*FiringAlert),
-		webhooks: webhooks,
+		store:     store,
+		interval:  60 * time.Second,
+		firing:    make(map[string]*FiringAlert),
+		system:    make(map[string]*FiringAlert),
+		webhooks:  webhooks,
+		slack:     slack,
+		pagerduty: pagerduty,
 	}
 }
 
@@ -57,7 +63,7 @@ func New(store database.Store, webhooks []WebhookConfig) *Evaluator {
 func (e *Evaluator) Start(ctx context.Context) {
 	ctx, e.cancel = context.WithCancel(ctx)
 
-	slog.Info("alert evaluator starting", "interval", e.interval, "webhooks", len(e.webhooks))
+	slog.Info("alert evaluator starting", "interval", e.interval, "webhooks", len(e.webhooks), "slack", len(e.slack), "pagerduty", len(e.pagerduty))
 
 	go func() {
 		// Run an initial evaluation immediately.
@@ -90,13 +96,61 @@ func (e *Evaluator) GetFiring() []FiringAlert {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	alerts := make([]FiringAlert, 0, len(e.firing))
+	alerts := make([]FiringAlert, 0, len(e.firing)+len(e.system))
 	for _, a := range e.firing {
 		alerts = append(alerts, *a)
 	}
+	for _, a := range e.system {
+		alerts = append(alerts, *a)
+	}
 	return alerts
 }
 
+// staleClusterAlertID is the rule ID of the alert for a cluster whose agent
+// stopped heartbeating.
+func staleClusterAlertID(name string) string {
+	return "cluster-stale:" + name
+}
+
+// ClusterStale fires a critical alert for a cluster whose agent has not
+// heartbeated for staleAfter. It is a no-op if the alert is already firing.
+func (e *Evaluator) ClusterStale(name string, lastHeartbeat time.Time, staleAfter time.Duration) {
+	id := staleClusterAlertID(name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.system[id]; ok {
+		return
+	}
+	alert := &FiringAlert{
+		RuleID:    id,
+		RuleName:  "Cluster " + name + " heartbeats stopped",
+		Severity:  "critical",
+		Resource:  "cluster",
+		Metric:    "heartbeat_age_seconds",
+		Value:     time.Since(lastHeartbeat).Seconds(),
+		Threshold: staleAfter.Seconds(),
+		Operator:  "gt",
+		FiredAt:   time.Now().UTC(),
+	}
+	e.system[id] = alert
+	go e.sendWebhook(*alert, false)
+}
+
+// ClusterRecovered resolves the stale alert for a cluster, if it is firing.
+func (e *Evaluator) ClusterRecovered(name string) {
+	id := staleClusterAlertID(name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	alert, ok := e.system[id]
+	if !ok {
+		return
+	}
+	delete(e.system, id)
+	go e.sendWebhook(*alert, true)
+}
+
 // evaluate fetches all enabled alert rules from the store and evaluates each one.
 // If a rule's threshold is exceeded and it is not already firing, the alert is added
 // to the firing map and a webhook notification is sent. If a rule was firing but is
@@ -0,0 +1,33 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluator_ClusterStale(t *testing.T) {
+	e := New(nil, nil, nil, nil)
+
+	e.ClusterStale("west", time.Now().Add(-5*time.Minute), 90*time.Second)
+	e.ClusterStale("west", time.Now().Add(-6*time.Minute), 90*time.Second) // already firing
+
+	firing := e.GetFiring()
+	if len(firing) != 1 {
+		t.Fatalf("expected 1 firing alert, got %+v", firing)
+	}
+	alert := firing[0]
+	if alert.RuleID != "cluster-stale:west" || alert.Severity != "critical" || alert.Threshold != 90 || alert.Value < 300 {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+
+	// Rule evaluation must not resolve alerts that did not come from a rule.
+	e.evaluate(t.Context())
+	if len(e.GetFiring()) != 1 {
+		t.Error("rule evaluation resolved the stale cluster alert")
+	}
+
+	e.ClusterRecovered("west")
+	if firing := e.GetFiring(); len(firing) != 0 {
+		t.Errorf("expected no firing alerts after recovery, got %+v", firing)
+	}
+}
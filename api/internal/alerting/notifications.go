@@ -17,10 +17,12 @@ type NotificationPayload struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
-// sendWebhook POSTs a NotificationPayload to each configured webhook URL.
+// sendWebhook POSTs a NotificationPayload to each configured webhook URL,
+// a Slack-formatted message to each Slack target, and a PagerDuty event to
+// each PagerDuty target whose minimum severity the alert meets.
 // Errors are logged but do not propagate — alerting notifications are best-effort.
 func (e *Evaluator) sendWebhook(alert FiringAlert, resolved bool) {
-	if len(e.webhooks) == 0 {
+	if len(e.webhooks) == 0 && len(e.slack) == 0 && len(e.pagerduty) == 0 {
 		return
 	}
 
@@ -60,6 +62,53 @@ func (e *Evaluator) sendWebhook(alert FiringAlert, resolved bool) {
 			)
 		}
 	}
+
+	for _, sc := range e.slack {
+		slackBody, err := renderSlack(sc, payload)
+		if err != nil {
+			slog.Error("alert slack: failed to render message", "error", err)
+			continue
+		}
+		if err := postWebhook(client, WebhookConfig{URL: sc.WebhookURL}, slackBody); err != nil {
+			slog.Error("alert slack: delivery failed",
+				"channel", sc.Channel,
+				"status", status,
+				"rule_id", alert.RuleID,
+				"error", err,
+			)
+		} else {
+			slog.Info("alert slack: delivered",
+				"channel", sc.Channel,
+				"status", status,
+				"rule_id", alert.RuleID,
+				"rule_name", alert.RuleName,
+			)
+		}
+	}
+
+	for _, pd := range e.pagerduty {
+		if !pd.pages(alert.Severity) {
+			continue
+		}
+		pdBody, err := renderPagerDuty(pd, payload)
+		if err != nil {
+			slog.Error("alert pagerduty: failed to render event", "error", err)
+			continue
+		}
+		if err := postWebhook(client, WebhookConfig{URL: pd.eventsURL()}, pdBody); err != nil {
+			slog.Error("alert pagerduty: delivery failed",
+				"status", status,
+				"rule_id", alert.RuleID,
+				"error", err,
+			)
+		} else {
+			slog.Info("alert pagerduty: delivered",
+				"status", status,
+				"rule_id", alert.RuleID,
+				"rule_name", alert.RuleName,
+			)
+		}
+	}
 }
 
 // postWebhook sends the JSON body to a single webhook endpoint.
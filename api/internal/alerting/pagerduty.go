@@ -0,0 +1,118 @@
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pagerDutyEventsURL is the default PagerDuty Events API v2 endpoint.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyConfig defines a PagerDuty Events API v2 notification target.
+type PagerDutyConfig struct {
+	RoutingKey  string `json:"routingKey"`            // integration key of the PagerDuty service
+	MinSeverity string `json:"minSeverity,omitempty"` // lowest severity that pages; defaults to critical
+	EventsURL   string `json:"eventsUrl,omitempty"`   // overrides the Events API endpoint (e.g. EU region)
+}
+
+// severityRank orders alert severities so they can be compared against a
+// PagerDuty target's minimum severity. Unknown severities rank as warning.
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "info":
+		return 0
+	case "critical":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// pages reports whether an alert of the given severity should be sent to this
+// PagerDuty target.
+func (c PagerDutyConfig) pages(severity string) bool {
+	min := c.MinSeverity
+	if min == "" {
+		min = "critical"
+	}
+	return severityRank(severity) >= severityRank(min)
+}
+
+// eventsURL returns the Events API endpoint for this target.
+func (c PagerDutyConfig) eventsURL() string {
+	if c.EventsURL != "" {
+		return c.EventsURL
+	}
+	return pagerDutyEventsURL
+}
+
+// pagerDutyEvent is the body of an Events API v2 request.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // "trigger" or "resolve"
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     string         `json:"timestamp,omitempty"`
+	Component     string         `json:"component,omitempty"`
+	Class         string         `json:"class,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// pagerDutyDedupKey derives the incident key from the alert's rule and
+// resource, so repeated firings of the same alert group into one incident
+// and the matching resolve closes it.
+func pagerDutyDedupKey(alert FiringAlert) string {
+	return fmt.Sprintf("ngf-console/%s/%s", alert.Resource, alert.RuleID)
+}
+
+// pagerDutySeverity maps an alert severity to a PagerDuty event severity.
+func pagerDutySeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "info":
+		return strings.ToLower(severity)
+	default:
+		return "warning"
+	}
+}
+
+// renderPagerDuty renders an alert notification as a PagerDuty trigger event,
+// or a resolve event when the alert has cleared.
+func renderPagerDuty(cfg PagerDutyConfig, payload NotificationPayload) ([]byte, error) {
+	alert := payload.Alert
+	event := pagerDutyEvent{
+		RoutingKey: cfg.RoutingKey,
+		DedupKey:   pagerDutyDedupKey(alert),
+	}
+
+	if payload.Status == "resolved" {
+		event.EventAction = "resolve"
+		return json.Marshal(event)
+	}
+
+	event.EventAction = "trigger"
+	event.Payload = &pagerDutyPayload{
+		Summary:   fmt.Sprintf("%s: %s %g %s %g", alert.RuleName, alert.Metric, alert.Value, alert.Operator, alert.Threshold),
+		Source:    alert.Resource,
+		Severity:  pagerDutySeverity(alert.Severity),
+		Timestamp: alert.FiredAt.UTC().Format(time.RFC3339),
+		Component: "ngf-console",
+		Class:     alert.Metric,
+		CustomDetails: map[string]any{
+			"ruleId":    alert.RuleID,
+			"ruleName":  alert.RuleName,
+			"metric":    alert.Metric,
+			"value":     alert.Value,
+			"threshold": alert.Threshold,
+			"operator":  alert.Operator,
+		},
+	}
+	return json.Marshal(event)
+}
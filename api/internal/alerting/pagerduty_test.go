@@ -0,0 +1,73 @@
+package alerting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPagerDutyConfig_Pages(t *testing.T) {
+	tests := []struct {
+		min      string
+		severity string
+		want     bool
+	}{
+		{"", "critical", true},
+		{"", "warning", false},
+		{"critical", "CRITICAL", true},
+		{"warning", "critical", true},
+		{"warning", "warning", true},
+		{"warning", "info", false},
+		{"info", "info", true},
+	}
+	for _, tt := range tests {
+		cfg := PagerDutyConfig{MinSeverity: tt.min}
+		if got := cfg.pages(tt.severity); got != tt.want {
+			t.Errorf("pages(min=%q, severity=%q) = %v, want %v", tt.min, tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestRenderPagerDuty(t *testing.T) {
+	cfg := PagerDutyConfig{RoutingKey: "R0UT1NGK3Y"}
+	alert := FiringAlert{
+		RuleID: "rule-1", RuleName: "High error rate", Severity: "critical", Resource: "default/main-gateway",
+		Metric: "error_rate", Value: 12.5, Threshold: 5, Operator: "gt",
+		FiredAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	body, err := renderPagerDuty(cfg, NotificationPayload{Status: "firing", Alert: alert})
+	if err != nil {
+		t.Fatalf("renderPagerDuty returned error: %v", err)
+	}
+	var trigger pagerDutyEvent
+	if err := json.Unmarshal(body, &trigger); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if trigger.EventAction != "trigger" || trigger.RoutingKey != "R0UT1NGK3Y" {
+		t.Errorf("unexpected trigger event: %+v", trigger)
+	}
+	if trigger.Payload == nil || trigger.Payload.Severity != "critical" || trigger.Payload.Source != "default/main-gateway" {
+		t.Errorf("unexpected trigger payload: %+v", trigger.Payload)
+	}
+
+	// A later firing of the same alert must share the dedup key, and the
+	// resolve must carry it too.
+	refired := alert
+	refired.FiredAt = alert.FiredAt.Add(time.Hour)
+	refired.Value = 20
+	body, err = renderPagerDuty(cfg, NotificationPayload{Status: "resolved", Alert: refired})
+	if err != nil {
+		t.Fatalf("renderPagerDuty returned error: %v", err)
+	}
+	var resolve pagerDutyEvent
+	if err := json.Unmarshal(body, &resolve); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resolve.EventAction != "resolve" || resolve.Payload != nil {
+		t.Errorf("unexpected resolve event: %+v", resolve)
+	}
+	if resolve.DedupKey != trigger.DedupKey {
+		t.Errorf("dedup key changed between firings: %q vs %q", trigger.DedupKey, resolve.DedupKey)
+	}
+}
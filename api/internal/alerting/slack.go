@@ -0,0 +1,86 @@
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SlackConfig defines a Slack incoming-webhook notification target.
+type SlackConfig struct {
+	WebhookURL string `json:"webhookUrl"`
+	Channel    string `json:"channel,omitempty"` // overrides the webhook's default channel
+}
+
+// Slack attachment colors by severity.
+const (
+	slackColorCritical = "#d93025" // red
+	slackColorWarning  = "#f9ab00" // yellow
+	slackColorOK       = "#1e8e3e" // green
+)
+
+// slackMessage is the body of a Slack incoming-webhook request.
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// slackColor returns the attachment color for an alert. Resolved alerts are
+// always green; firing alerts are red for critical and yellow otherwise.
+func slackColor(severity string, resolved bool) string {
+	if resolved {
+		return slackColorOK
+	}
+	if strings.EqualFold(severity, "critical") {
+		return slackColorCritical
+	}
+	return slackColorWarning
+}
+
+// renderSlack renders an alert notification as a Slack message with a
+// severity-colored attachment.
+func renderSlack(cfg SlackConfig, payload NotificationPayload) ([]byte, error) {
+	alert := payload.Alert
+	resolved := payload.Status == "resolved"
+
+	title := fmt.Sprintf("[%s] %s", strings.ToUpper(payload.Status), alert.RuleName)
+	msg := slackMessage{
+		Channel: cfg.Channel,
+		Text:    title,
+		Attachments: []slackAttachment{{
+			Color: slackColor(alert.Severity, resolved),
+			Blocks: []slackBlock{
+				{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + title + "*"}},
+				{Type: "section", Fields: []slackText{
+					{Type: "mrkdwn", Text: "*Severity*\n" + alert.Severity},
+					{Type: "mrkdwn", Text: "*Resource*\n" + alert.Resource},
+					{Type: "mrkdwn", Text: "*Metric*\n" + alert.Metric},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Value*\n%g %s %g", alert.Value, alert.Operator, alert.Threshold)},
+				}},
+				{Type: "context", Elements: []slackText{
+					{Type: "mrkdwn", Text: "Fired at " + alert.FiredAt.UTC().Format(time.RFC3339)},
+				}},
+			},
+		}},
+	}
+	return json.Marshal(msg)
+}
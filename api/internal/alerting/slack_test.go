@@ -0,0 +1,54 @@
+package alerting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSlackColor(t *testing.T) {
+	tests := []struct {
+		severity string
+		resolved bool
+		want     string
+	}{
+		{"critical", false, slackColorCritical},
+		{"CRITICAL", false, slackColorCritical},
+		{"warning", false, slackColorWarning},
+		{"info", false, slackColorWarning},
+		{"critical", true, slackColorOK},
+	}
+	for _, tt := range tests {
+		if got := slackColor(tt.severity, tt.resolved); got != tt.want {
+			t.Errorf("slackColor(%q, %v) = %s, want %s", tt.severity, tt.resolved, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSlack(t *testing.T) {
+	body, err := renderSlack(SlackConfig{Channel: "#alerts"}, NotificationPayload{
+		Status: "firing",
+		Alert: FiringAlert{
+			RuleName: "High error rate", Severity: "critical", Resource: "gateway",
+			Metric: "error_rate", Value: 0.2, Threshold: 0.05, Operator: "gt",
+			FiredAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatalf("renderSlack returned error: %v", err)
+	}
+
+	var msg slackMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if msg.Channel != "#alerts" {
+		t.Errorf("expected channel override, got %q", msg.Channel)
+	}
+	if msg.Text != "[FIRING] High error rate" {
+		t.Errorf("unexpected text: %q", msg.Text)
+	}
+	if len(msg.Attachments) != 1 || msg.Attachments[0].Color != slackColorCritical {
+		t.Errorf("expected one red attachment, got %+v", msg.Attachments)
+	}
+}
@@ -0,0 +1,74 @@
+// Package audit records who changed what through the API.
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kubenetlabs/ngc/api/internal/auth"
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
+)
+
+// Anonymous is recorded as the user of requests that were not authenticated.
+const Anonymous = "anonymous"
+
+type contextKey struct{}
+
+// Tracker notes whether a request's handler recorded its own entry, so the
+// server's fallback does not record the operation twice.
+type Tracker struct {
+	recorded atomic.Bool
+}
+
+// Recorded reports whether an entry was recorded for the request.
+func (t *Tracker) Recorded() bool {
+	return t.recorded.Load()
+}
+
+// WithTracker returns a context that tracks whether an entry is recorded.
+func WithTracker(ctx context.Context) (context.Context, *Tracker) {
+	t := &Tracker{}
+	return context.WithValue(ctx, contextKey{}, t), t
+}
+
+// NewEntry returns a success entry for an operation in the request behind
+// ctx, with the caller, cluster and request ID filled in.
+func NewEntry(ctx context.Context, action, resource, name, namespace string) database.AuditEntry {
+	user := Anonymous
+	if u := auth.UserFromContext(ctx); u != nil && u.Name() != "" {
+		user = u.Name()
+	}
+	return database.AuditEntry{
+		ID:        uuid.NewString(),
+		Timestamp: time.Now().UTC(),
+		User:      user,
+		Cluster:   cluster.ClusterNameFromContext(ctx),
+		Action:    action,
+		Resource:  resource,
+		Name:      name,
+		Namespace: namespace,
+		Outcome:   database.AuditSuccess,
+		RequestID: requestid.FromContext(ctx),
+	}
+}
+
+// Record stores entry. Errors are logged but never returned, so auditing
+// cannot break the primary request flow.
+func Record(ctx context.Context, store database.Store, entry database.AuditEntry) {
+	if t, ok := ctx.Value(contextKey{}).(*Tracker); ok {
+		t.recorded.Store(true)
+	}
+	if store == nil {
+		return
+	}
+	if err := store.InsertAuditEntry(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "failed to insert audit entry", "error", err,
+			"action", entry.Action, "resource", entry.Resource, "name", entry.Name)
+	}
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubenetlabs/ngc/api/internal/auth"
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
+)
+
+func TestNewEntry(t *testing.T) {
+	ctx := context.Background()
+	if e := NewEntry(ctx, "create", "Gateway", "gw", "default"); e.User != Anonymous || e.Outcome != database.AuditSuccess {
+		t.Errorf("expected an anonymous success, got %+v", e)
+	}
+
+	ctx = auth.WithUser(ctx, &auth.User{Subject: "u-1"})
+	ctx = cluster.WithClusterName(ctx, "west")
+	ctx = requestid.WithID(ctx, "req-1")
+	e := NewEntry(ctx, "delete", "Gateway", "gw", "default")
+	if e.User != "u-1" || e.Cluster != "west" || e.RequestID != "req-1" || e.ID == "" || e.Timestamp.IsZero() {
+		t.Errorf("expected the request's identity and context, got %+v", e)
+	}
+}
+
+func TestRecord_MarksTracker(t *testing.T) {
+	ctx, tracker := WithTracker(context.Background())
+	if tracker.Recorded() {
+		t.Fatal("tracker starts recorded")
+	}
+	Record(ctx, nil, NewEntry(ctx, "create", "Gateway", "gw", "default"))
+	if !tracker.Recorded() {
+		t.Error("expected Record to mark the tracker")
+	}
+}
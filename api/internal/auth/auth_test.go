@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func requestWith(authorization string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/gateways", nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	return r
+}
+
+func TestStaticToken(t *testing.T) {
+	p := NewStaticToken("s3cret")
+
+	user, err := p.Authenticate(requestWith("Bearer s3cret"))
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if user.Subject != StaticTokenSubject || user.Role != RoleAdmin {
+		t.Errorf("user = %+v, want the static Admin identity", user)
+	}
+
+	if _, err := p.Authenticate(requestWith("")); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("no header: err = %v, want ErrNoCredentials", err)
+	}
+	for _, header := range []string{"Bearer wrong", "Bearer s3cret2", "Basic s3cret", "Bearer "} {
+		if _, err := p.Authenticate(requestWith(header)); err == nil || errors.Is(err, ErrNoCredentials) {
+			t.Errorf("%q: err = %v, want an invalid credential error", header, err)
+		}
+	}
+}
+
+func signHS256(t *testing.T, secret, claims string) string {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	input := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWT(t *testing.T) {
+	p := &JWT{Secret: "key", Issuer: "https://idp.example.com"}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	token := signHS256(t, "key", fmt.Sprintf(`{"sub":"u-1","email":"alice@example.com","iss":"https://idp.example.com","exp":%d}`, exp))
+	user, err := p.Authenticate(requestWith("Bearer " + token))
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if user.Name() != "alice@example.com" || user.Role != RoleViewer {
+		t.Errorf("user = %+v, want alice as a Viewer", user)
+	}
+
+	for name, token := range map[string]string{
+		"wrong secret": signHS256(t, "other", `{"sub":"u-1","iss":"https://idp.example.com"}`),
+		"wrong issuer": signHS256(t, "key", `{"sub":"u-1","iss":"https://evil.example.com"}`),
+		"expired":      signHS256(t, "key", `{"sub":"u-1","iss":"https://idp.example.com","exp":1}`),
+		"malformed":    "not-a-jwt",
+	} {
+		if _, err := p.Authenticate(requestWith("Bearer " + token)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWT authenticates HS256-signed bearer tokens. The token's sub, email and
+// role claims become the caller's identity; a token without a role is a
+// Viewer.
+type JWT struct {
+	Secret string
+	Issuer string // required iss claim; "" accepts any issuer
+}
+
+// Authenticate implements Provider.
+func (p *JWT) Authenticate(r *http.Request) (*User, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+	user, err := validateJWT(token, p.Secret, p.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	return user, nil
+}
+
+// jwtHeader is the decoded JOSE header of a JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+// jwtClaims is the decoded payload of a JWT.
+type jwtClaims struct {
+	Sub   string `json:"sub"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	Iss   string `json:"iss"`
+	Exp   int64  `json:"exp"`
+	Iat   int64  `json:"iat"`
+}
+
+// validateJWT verifies an HS256 JWT and returns the extracted user info.
+func validateJWT(token, secret, expectedIssuer string) (*User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token: expected 3 parts, got %d", len(parts))
+	}
+
+	// Decode header
+	headerBytes, err := base64URLDecode(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid header encoding: %w", err)
+	}
+
+	var header jwtHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("invalid header JSON: %w", err)
+	}
+
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported algorithm: %s", header.Alg)
+	}
+
+	// Verify signature
+	signingInput := parts[0] + "." + parts[1]
+	expectedSig, err := base64URLDecode(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	computedSig := mac.Sum(nil)
+
+	if !hmac.Equal(computedSig, expectedSig) {
+		return nil, fmt.Errorf("signature verification failed")
+	}
+
+	// Decode claims
+	claimsBytes, err := base64URLDecode(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid claims encoding: %w", err)
+	}
+
+	var claims jwtClaims
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		return nil, fmt.Errorf("invalid claims JSON: %w", err)
+	}
+
+	// Check expiration
+	if claims.Exp > 0 && time.Now().Unix() > claims.Exp {
+		return nil, fmt.Errorf("token expired")
+	}
+
+	// Check issuer
+	if expectedIssuer != "" && claims.Iss != expectedIssuer {
+		return nil, fmt.Errorf("invalid issuer: expected %q, got %q", expectedIssuer, claims.Iss)
+	}
+
+	// Default role to Viewer if not specified
+	role := claims.Role
+	if role == "" {
+		role = RoleViewer
+	}
+
+	return &User{
+		Subject: claims.Sub,
+		Email:   claims.Email,
+		Role:    role,
+		Issuer:  claims.Iss,
+	}, nil
+}
+
+// base64URLDecode decodes a base64url-encoded string (no padding).
+func base64URLDecode(s string) ([]byte, error) {
+	// Add padding if necessary
+	switch len(s) % 4 {
+	case 2:
+		s += "=="
+	case 3:
+		s += "="
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
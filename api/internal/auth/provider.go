@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Modes accepted by --auth-mode.
+const (
+	ModeNone  = "none"
+	ModeToken = "token"
+	ModeJWT   = "jwt"
+)
+
+// Roles, from least to most privileged.
+const (
+	RoleViewer   = "Viewer"
+	RoleOperator = "Operator"
+	RoleAdmin    = "Admin"
+)
+
+// ErrNoCredentials is returned by a Provider when the request carries no
+// credentials at all, as opposed to credentials that are invalid.
+var ErrNoCredentials = errors.New("missing Authorization header")
+
+// Provider authenticates API requests. Implementations verify whatever
+// credential the request carries and return the caller's identity.
+type Provider interface {
+	Authenticate(r *http.Request) (*User, error)
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", ErrNoCredentials
+	}
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", errors.New("invalid Authorization header format")
+	}
+	return token, nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import "context"
+
+// User holds the identity of an authenticated caller.
+type User struct {
+	Subject string
+	Email   string
+	Role    string // Admin, Operator, Viewer
+	Issuer  string
+}
+
+// Name returns how the user is identified in logs and audit entries: the
+// email when the token carries one, else the subject.
+func (u *User) Name() string {
+	if u.Email != "" {
+		return u.Email
+	}
+	return u.Subject
+}
+
+type contextKey struct{}
+
+// WithUser stores the authenticated user in the context.
+func WithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// UserFromContext returns the authenticated user, or nil when the request
+// was not authenticated.
+func UserFromContext(ctx context.Context) *User {
+	u, _ := ctx.Value(contextKey{}).(*User)
+	return u
+}
@@ -4,19 +4,40 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	ch "github.com/ClickHouse/clickhouse-go/v2"
 )
 
-// Client wraps a ClickHouse database connection.
+// healthCheckTimeout bounds each health check ping.
+const healthCheckTimeout = 5 * time.Second
+
+// Options configures the client's connection pool.
+type Options struct {
+	MaxOpenConns    int           // connections open at once, including in-use ones
+	MaxIdleConns    int           // idle connections kept for reuse
+	ConnMaxLifetime time.Duration // connections older than this are closed and redialled
+}
+
+// DefaultOptions returns the pool settings used when no flags override them.
+func DefaultOptions() Options {
+	return Options{MaxOpenConns: 10, MaxIdleConns: 5, ConnMaxLifetime: time.Hour}
+}
+
+// Client wraps a pool of ClickHouse connections. Connections that break,
+// for example when ClickHouse restarts, are redialled on next use.
 type Client struct {
-	conn ch.Conn
-	dsn  string
+	conn      ch.Conn
+	dsn       string
+	retention RetentionConfig
+	healthy   atomic.Bool
 }
 
-// New creates a new ClickHouse client with the given DSN.
-func New(dsn string) (*Client, error) {
+// New creates a new ClickHouse client with the given DSN and pool options.
+// ClickHouse being unreachable is not an error: the client starts unhealthy
+// and RunHealthCheck marks it healthy once a ping succeeds.
+func New(dsn string, opts Options) (*Client, error) {
 	conn, err := ch.Open(&ch.Options{
 		Addr: []string{dsn},
 		Settings: ch.Settings{
@@ -26,15 +47,21 @@ func New(dsn string) (*Client, error) {
 		Compression: &ch.Compression{
 			Method: ch.CompressionLZ4,
 		},
+		MaxOpenConns:    opts.MaxOpenConns,
+		MaxIdleConns:    opts.MaxIdleConns,
+		ConnMaxLifetime: opts.ConnMaxLifetime,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("clickhouse open: %w", err)
 	}
+	c := &Client{conn: conn, dsn: dsn}
 	if err := conn.Ping(context.Background()); err != nil {
 		slog.Warn("clickhouse ping failed, continuing", "error", err)
+	} else {
+		c.healthy.Store(true)
+		slog.Info("clickhouse client connected", "dsn", dsn, "maxOpenConns", opts.MaxOpenConns)
 	}
-	slog.Info("clickhouse client connected", "dsn", dsn)
-	return &Client{conn: conn, dsn: dsn}, nil
+	return c, nil
 }
 
 // Conn returns the underlying ClickHouse connection for query execution.
@@ -47,6 +74,45 @@ func (c *Client) Ping(ctx context.Context) error {
 	return c.conn.Ping(ctx)
 }
 
+// Healthy reports whether the most recent health check reached ClickHouse.
+func (c *Client) Healthy() bool {
+	return c.healthy.Load()
+}
+
+// RunHealthCheck pings ClickHouse every interval until ctx is cancelled,
+// updating Healthy and logging each transition.
+func (c *Client) RunHealthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		}
+	}
+}
+
+// checkHealth pings ClickHouse once and records the result.
+func (c *Client) checkHealth(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := c.conn.Ping(pingCtx)
+	c.setHealthy(err == nil, err)
+}
+
+func (c *Client) setHealthy(healthy bool, err error) {
+	if c.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		slog.Info("clickhouse reachable again", "dsn", c.dsn)
+	} else {
+		slog.Warn("clickhouse unreachable, marking unhealthy", "dsn", c.dsn, "error", err)
+	}
+}
+
 // Close closes the ClickHouse connection.
 func (c *Client) Close() error {
 	return c.conn.Close()
@@ -0,0 +1,23 @@
+package clickhouse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClient_SetHealthy(t *testing.T) {
+	c := NewForTest(true)
+
+	c.setHealthy(false, errors.New("connection refused"))
+	if c.Healthy() {
+		t.Fatal("expected client to be unhealthy after a failed check")
+	}
+	c.setHealthy(false, errors.New("connection refused"))
+	if c.Healthy() {
+		t.Fatal("expected client to stay unhealthy")
+	}
+	c.setHealthy(true, nil)
+	if !c.Healthy() {
+		t.Fatal("expected client to recover after a successful check")
+	}
+}
@@ -1,5 +1,12 @@
 package clickhouse
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubenetlabs/ngc/api/internal/inference"
+)
+
 // SQL queries for inference metrics.
 // These target ClickHouse MergeTree tables populated by the OTel Collector.
 // All queries include an optional cluster_name filter: pass '' to match all clusters.
@@ -42,6 +49,23 @@ WHERE timestamp >= now() - INTERVAL 60 MINUTE
   AND (? = '' OR cluster_name = ?)
 `
 
+// queryLiveMetrics averages the rows of the pool's latest scrape, one per
+// model, within the last five minutes.
+const queryLiveMetrics = `
+SELECT
+    timestamp,
+    avg(queue_depth) AS queue_depth,
+    avg(kv_cache_pct) AS kv_cache_pct,
+    avg(tps) AS tps
+FROM ngf_inference_metrics_1m
+WHERE pool_name = ?
+  AND (? = '' OR cluster_name = ?)
+  AND timestamp >= now() - INTERVAL 5 MINUTE
+GROUP BY timestamp
+ORDER BY timestamp DESC
+LIMIT 1
+`
+
 const queryPodMetrics = `
 SELECT
     pod_name, node_name, gpu_id, gpu_type, queue_depth,
@@ -79,62 +103,83 @@ GROUP BY range_start, range_end
 ORDER BY range_start
 `
 
-const queryTPSThroughput = `
-SELECT
-    toStartOfMinute(timestamp) AS ts,
-    avg(tps) AS value
-FROM ngf_inference_metrics_1m
-WHERE pool_name = ?
-  AND (? = '' OR cluster_name = ?)
-  AND timestamp >= now() - INTERVAL 60 MINUTE
-GROUP BY ts
-ORDER BY ts
+const queryUpsertPool = `
+INSERT INTO ngf_inference_pools (
+    name, namespace, model_name, model_version, serving_backend,
+    gpu_type, gpu_count, replicas, ready_replicas, min_replicas, max_replicas,
+    status, cluster_name, created_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-const queryQueueDepthSeries = `
+const queryDeletePool = `
+ALTER TABLE ngf_inference_pools DELETE WHERE name = ? AND namespace = ?
+`
+
+// seriesMetric names a pool-level metric in both the raw scrape table and
+// the rollup views.
+type seriesMetric struct {
+	raw    string // column in ngf_inference_metrics_1m
+	rollup string // avgState column in the rollup views
+}
+
+var (
+	metricTPS        = seriesMetric{raw: "tps", rollup: "avg_tps"}
+	metricQueueDepth = seriesMetric{raw: "queue_depth", rollup: "avg_queue_depth"}
+	metricGPUUtil    = seriesMetric{raw: "gpu_util_pct", rollup: "avg_gpu_util"}
+	metricKVCache    = seriesMetric{raw: "kv_cache_pct", rollup: "avg_kv_cache_pct"}
+)
+
+// timeseriesQuery builds the per-pool series query for m over window. Windows
+// up to inference.DefaultSeriesWindow are aggregated from raw samples; longer
+// windows read the per-minute or per-hour rollup chosen by
+// inference.SeriesResolution. Parameters: pool, cluster, cluster, window seconds.
+func timeseriesQuery(m seriesMetric, window time.Duration) string {
+	if window <= inference.DefaultSeriesWindow {
+		return fmt.Sprintf(`
 SELECT
     toStartOfMinute(timestamp) AS ts,
-    avg(queue_depth) AS value
+    avg(%s) AS value
 FROM ngf_inference_metrics_1m
 WHERE pool_name = ?
   AND (? = '' OR cluster_name = ?)
-  AND timestamp >= now() - INTERVAL 60 MINUTE
+  AND timestamp >= now() - toIntervalSecond(?)
 GROUP BY ts
 ORDER BY ts
-`
+`, m.raw)
+	}
 
-const queryGPUUtilSeries = `
+	table := tableInferenceRollup1m
+	if inference.SeriesResolution(window) == time.Hour {
+		table = tableInferenceRollup1h
+	}
+	return fmt.Sprintf(`
 SELECT
-    toStartOfMinute(timestamp) AS ts,
-    avg(gpu_util_pct) AS value
-FROM ngf_inference_metrics_1m
+    window_start AS ts,
+    avgMerge(%s) AS value
+FROM %s
 WHERE pool_name = ?
   AND (? = '' OR cluster_name = ?)
-  AND timestamp >= now() - INTERVAL 60 MINUTE
+  AND window_start >= now() - toIntervalSecond(?)
 GROUP BY ts
 ORDER BY ts
-`
+`, m.rollup, table)
+}
 
-const queryUpsertPool = `
-INSERT INTO ngf_inference_pools (
-    name, namespace, model_name, model_version, serving_backend,
-    gpu_type, gpu_count, replicas, ready_replicas, min_replicas, max_replicas,
-    status, cluster_name, created_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-`
-
-const queryDeletePool = `
-ALTER TABLE ngf_inference_pools DELETE WHERE name = ? AND namespace = ?
-`
-
-const queryKVCacheSeries = `
+// modelTimeseriesQuery builds the series query for m over window restricted
+// to one model of a pool. The rollup views don't keep model_name, so every
+// window is aggregated from raw samples at inference.SeriesResolution.
+// Parameters: pool, model, cluster, cluster, window seconds.
+func modelTimeseriesQuery(m seriesMetric, window time.Duration) string {
+	return fmt.Sprintf(`
 SELECT
-    toStartOfMinute(timestamp) AS ts,
-    avg(kv_cache_pct) AS value
+    toStartOfInterval(timestamp, INTERVAL %d SECOND) AS ts,
+    avg(%s) AS value
 FROM ngf_inference_metrics_1m
 WHERE pool_name = ?
+  AND model_name = ?
   AND (? = '' OR cluster_name = ?)
-  AND timestamp >= now() - INTERVAL 60 MINUTE
+  AND timestamp >= now() - toIntervalSecond(?)
 GROUP BY ts
 ORDER BY ts
-`
+`, int64(inference.SeriesResolution(window).Seconds()), m.raw)
+}
@@ -0,0 +1,53 @@
+package clickhouse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeseriesQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   time.Duration
+		wantFrom string
+		wantExpr string
+	}{
+		{name: "default window uses raw samples", window: time.Hour, wantFrom: "FROM ngf_inference_metrics_1m\n", wantExpr: "avg(tps)"},
+		{name: "day uses minute rollup", window: 24 * time.Hour, wantFrom: "FROM " + tableInferenceRollup1m + "\n", wantExpr: "avgMerge(avg_tps)"},
+		{name: "week uses hour rollup", window: 7 * 24 * time.Hour, wantFrom: "FROM " + tableInferenceRollup1h + "\n", wantExpr: "avgMerge(avg_tps)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := timeseriesQuery(metricTPS, tt.window)
+			if !strings.Contains(q, tt.wantFrom) || !strings.Contains(q, tt.wantExpr) {
+				t.Errorf("query for %s window does not read %q with %q:\n%s", tt.window, strings.TrimSpace(tt.wantFrom), tt.wantExpr, q)
+			}
+			if got := strings.Count(q, "?"); got != 4 {
+				t.Errorf("expected 4 placeholders, got %d", got)
+			}
+		})
+	}
+}
+
+func TestModelTimeseriesQuery(t *testing.T) {
+	tests := []struct {
+		window     time.Duration
+		wantBucket string
+	}{
+		{window: time.Hour, wantBucket: "INTERVAL 60 SECOND"},
+		{window: 7 * 24 * time.Hour, wantBucket: "INTERVAL 3600 SECOND"},
+	}
+	for _, tt := range tests {
+		q := modelTimeseriesQuery(metricTPS, tt.window)
+		if !strings.Contains(q, "FROM ngf_inference_metrics_1m\n") || !strings.Contains(q, "model_name = ?") {
+			t.Errorf("query for %s window does not filter raw samples by model:\n%s", tt.window, q)
+		}
+		if !strings.Contains(q, tt.wantBucket) {
+			t.Errorf("query for %s window does not bucket by %q:\n%s", tt.window, tt.wantBucket, q)
+		}
+		if got := strings.Count(q, "?"); got != 5 {
+			t.Errorf("expected 5 placeholders, got %d", got)
+		}
+	}
+}
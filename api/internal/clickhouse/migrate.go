@@ -0,0 +1,77 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+)
+
+// Rollup views over the scraped pool metrics in ngf_inference_metrics_1m.
+// They keep avgState aggregates so that long-range series queries read one
+// row per pool per bucket instead of every scrape sample.
+const (
+	tableInferenceRollup1m = "ngf_inference_metrics_rollup_1m"
+	tableInferenceRollup1h = "ngf_inference_metrics_rollup_1h"
+)
+
+const createInferenceRollup1m = `
+CREATE MATERIALIZED VIEW IF NOT EXISTS ngf_inference_metrics_rollup_1m
+ENGINE = AggregatingMergeTree()
+PARTITION BY toYYYYMMDD(window_start)
+ORDER BY (cluster_name, pool_name, window_start)
+TTL window_start + INTERVAL 90 DAY
+AS SELECT
+    toDateTime(toStartOfMinute(timestamp)) AS window_start,
+    cluster_name,
+    pool_name,
+    avgState(ttft_ms) AS avg_ttft,
+    avgState(tps) AS avg_tps,
+    sumState(total_tokens) AS total_tokens,
+    avgState(queue_depth) AS avg_queue_depth,
+    avgState(kv_cache_pct) AS avg_kv_cache_pct,
+    avgState(gpu_util_pct) AS avg_gpu_util
+FROM ngf_inference_metrics_1m
+GROUP BY window_start, cluster_name, pool_name`
+
+const createInferenceRollup1h = `
+CREATE MATERIALIZED VIEW IF NOT EXISTS ngf_inference_metrics_rollup_1h
+ENGINE = AggregatingMergeTree()
+PARTITION BY toYYYYMM(window_start)
+ORDER BY (cluster_name, pool_name, window_start)
+TTL window_start + INTERVAL 90 DAY
+AS SELECT
+    toDateTime(toStartOfHour(timestamp)) AS window_start,
+    cluster_name,
+    pool_name,
+    avgState(ttft_ms) AS avg_ttft,
+    avgState(tps) AS avg_tps,
+    sumState(total_tokens) AS total_tokens,
+    avgState(queue_depth) AS avg_queue_depth,
+    avgState(kv_cache_pct) AS avg_kv_cache_pct,
+    avgState(gpu_util_pct) AS avg_gpu_util
+FROM ngf_inference_metrics_1m
+GROUP BY window_start, cluster_name, pool_name`
+
+// addInferenceModelName adds the vLLM model_name label to the scraped pool
+// metrics, so a pool serving several models is recorded one row per model.
+const addInferenceModelName = `
+ALTER TABLE ngf_inference_metrics_1m
+ADD COLUMN IF NOT EXISTS model_name LowCardinality(String) DEFAULT '' AFTER pool_name`
+
+// migrations are applied in order by Migrate. Each must be idempotent.
+var migrations = []string{
+	addInferenceModelName,
+	createInferenceRollup1m,
+	createInferenceRollup1h,
+}
+
+// Migrate adds the columns and creates the rollup views the API depends on. It runs at startup,
+// after the base schema has been created, and is safe to run repeatedly.
+// Views only aggregate rows inserted after they are created.
+func (c *Client) Migrate(ctx context.Context) error {
+	for i, stmt := range migrations {
+		if err := c.conn.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("clickhouse migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
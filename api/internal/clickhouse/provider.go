@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/inference"
@@ -22,8 +23,11 @@ type Provider struct {
 	client *Client
 }
 
-// compile-time interface check
-var _ inference.MetricsProvider = (*Provider)(nil)
+// compile-time interface checks
+var (
+	_ inference.MetricsProvider     = (*Provider)(nil)
+	_ inference.LiveMetricsProvider = (*Provider)(nil)
+)
 
 // NewProvider creates a ClickHouse-backed metrics provider.
 func NewProvider(client *Client) *Provider {
@@ -140,6 +144,30 @@ func (p *Provider) GetMetricsSummary(ctx context.Context, pool string) (*inferen
 	return &ms, nil
 }
 
+// GetLiveMetrics returns the pool's latest scraped metrics, or nil if the
+// pool has not been scraped in the last five minutes.
+func (p *Provider) GetLiveMetrics(ctx context.Context, pool string) (*inference.LiveMetrics, error) {
+	cn := clusterFilter(ctx)
+	rows, err := p.client.Conn().Query(ctx, queryLiveMetrics, pool, cn, cn)
+	if err != nil {
+		return nil, fmt.Errorf("GetLiveMetrics query: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("GetLiveMetrics rows: %w", err)
+		}
+		return nil, nil
+	}
+
+	var lm inference.LiveMetrics
+	if err := rows.Scan(&lm.Timestamp, &lm.QueueDepth, &lm.KVCachePct, &lm.TPS); err != nil {
+		return nil, fmt.Errorf("GetLiveMetrics scan: %w", err)
+	}
+	return &lm, nil
+}
+
 func (p *Provider) GetPodMetrics(ctx context.Context, pool string) ([]inference.PodMetrics, error) {
 	cn := clusterFilter(ctx)
 	rows, err := p.client.Conn().Query(ctx, queryPodMetrics, pool, pool, cn, cn)
@@ -215,29 +243,36 @@ func (p *Provider) GetTTFTHistogram(ctx context.Context, pool string) ([]inferen
 	return buckets, nil
 }
 
-func (p *Provider) GetTPSThroughput(ctx context.Context, pool string) ([]inference.TimeseriesPoint, error) {
+func (p *Provider) GetTPSThroughput(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error) {
+	cn := clusterFilter(ctx)
+	return p.queryTimeseries(ctx, timeseriesQuery(metricTPS, window), "GetTPSThroughput", pool, cn, cn, int64(window.Seconds()))
+}
+
+// GetModelTPSThroughput returns the tokens-per-second series of one model
+// served by pool.
+func (p *Provider) GetModelTPSThroughput(ctx context.Context, pool, model string, window time.Duration) ([]inference.TimeseriesPoint, error) {
 	cn := clusterFilter(ctx)
-	return p.queryTimeseries(ctx, queryTPSThroughput, pool, cn, "GetTPSThroughput")
+	return p.queryTimeseries(ctx, modelTimeseriesQuery(metricTPS, window), "GetModelTPSThroughput", pool, model, cn, cn, int64(window.Seconds()))
 }
 
-func (p *Provider) GetQueueDepthSeries(ctx context.Context, pool string) ([]inference.TimeseriesPoint, error) {
+func (p *Provider) GetQueueDepthSeries(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error) {
 	cn := clusterFilter(ctx)
-	return p.queryTimeseries(ctx, queryQueueDepthSeries, pool, cn, "GetQueueDepthSeries")
+	return p.queryTimeseries(ctx, timeseriesQuery(metricQueueDepth, window), "GetQueueDepthSeries", pool, cn, cn, int64(window.Seconds()))
 }
 
-func (p *Provider) GetGPUUtilSeries(ctx context.Context, pool string) ([]inference.TimeseriesPoint, error) {
+func (p *Provider) GetGPUUtilSeries(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error) {
 	cn := clusterFilter(ctx)
-	return p.queryTimeseries(ctx, queryGPUUtilSeries, pool, cn, "GetGPUUtilSeries")
+	return p.queryTimeseries(ctx, timeseriesQuery(metricGPUUtil, window), "GetGPUUtilSeries", pool, cn, cn, int64(window.Seconds()))
 }
 
-func (p *Provider) GetKVCacheSeries(ctx context.Context, pool string) ([]inference.TimeseriesPoint, error) {
+func (p *Provider) GetKVCacheSeries(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error) {
 	cn := clusterFilter(ctx)
-	return p.queryTimeseries(ctx, queryKVCacheSeries, pool, cn, "GetKVCacheSeries")
+	return p.queryTimeseries(ctx, timeseriesQuery(metricKVCache, window), "GetKVCacheSeries", pool, cn, cn, int64(window.Seconds()))
 }
 
 // queryTimeseries is a helper for all timeseries queries that return (timestamp, value) rows.
-func (p *Provider) queryTimeseries(ctx context.Context, query, pool, clusterName, label string) ([]inference.TimeseriesPoint, error) {
-	rows, err := p.client.Conn().Query(ctx, query, pool, clusterName, clusterName)
+func (p *Provider) queryTimeseries(ctx context.Context, query, label string, args ...any) ([]inference.TimeseriesPoint, error) {
+	rows, err := p.client.Conn().Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s query: %w", label, err)
 	}
@@ -0,0 +1,200 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Retention classes for managed tables.
+const (
+	RetentionRaw     = "raw"
+	RetentionRollup  = "rollup"
+	RetentionMetrics = "metrics"
+)
+
+// RetentionConfig sets how long data is kept in ClickHouse. A zero duration
+// leaves the TTL of that class of tables unchanged.
+type RetentionConfig struct {
+	Raw     time.Duration // raw log and decision tables
+	Rollup  time.Duration // per-minute and per-hour rollup tables
+	Metrics time.Duration // scraped inference metrics and their rollup views
+}
+
+// DefaultRetention keeps raw rows for 7 days and rollups and inference
+// metrics for 90 days.
+func DefaultRetention() RetentionConfig {
+	return RetentionConfig{Raw: 7 * 24 * time.Hour, Rollup: 90 * 24 * time.Hour, Metrics: 90 * 24 * time.Hour}
+}
+
+// duration returns the retention configured for class.
+func (c RetentionConfig) duration(class string) time.Duration {
+	switch class {
+	case RetentionRollup:
+		return c.Rollup
+	case RetentionMetrics:
+		return c.Metrics
+	default:
+		return c.Raw
+	}
+}
+
+// Validate checks that each retention is zero or a positive whole number of days.
+func (c RetentionConfig) Validate() error {
+	for class, d := range map[string]time.Duration{RetentionRaw: c.Raw, RetentionRollup: c.Rollup, RetentionMetrics: c.Metrics} {
+		if d < 0 || d%(24*time.Hour) != 0 {
+			return fmt.Errorf("%s retention %s must be a whole number of days", class, d)
+		}
+	}
+	return nil
+}
+
+// retentionTable describes a table whose TTL is managed by ApplyRetention.
+type retentionTable struct {
+	Name      string
+	Class     string
+	TTLColumn string // DateTime expression the TTL is computed from
+}
+
+// retentionTables lists the time-series tables that grow with traffic.
+// ngf_pod_metrics and ngf_inference_pools hold current state and keep the
+// TTLs from their schema.
+var retentionTables = []retentionTable{
+	{Name: "ngf_access_logs", Class: RetentionRaw, TTLColumn: "toDateTime(timestamp)"},
+	{Name: "ngf_inference_logs", Class: RetentionRaw, TTLColumn: "toDateTime(timestamp)"},
+	{Name: "ngf_epp_decisions", Class: RetentionRaw, TTLColumn: "toDateTime(timestamp)"},
+	{Name: "ngf_metrics_1m", Class: RetentionRollup, TTLColumn: "window_start"},
+	{Name: "ngf_inference_metrics_1m", Class: RetentionMetrics, TTLColumn: "toDateTime(timestamp)"},
+	{Name: tableInferenceRollup1m, Class: RetentionMetrics, TTLColumn: "window_start"},
+	{Name: tableInferenceRollup1h, Class: RetentionMetrics, TTLColumn: "window_start"},
+}
+
+// retentionStatements returns the ALTER TABLE statements that apply cfg.
+// Tables whose TTL in current, keyed by table name, already matches are
+// skipped; a nil current alters every table.
+func retentionStatements(cfg RetentionConfig, current map[string]int) []string {
+	var stmts []string
+	for _, t := range retentionTables {
+		d := cfg.duration(t.Class)
+		if d == 0 {
+			continue
+		}
+		days := int(d / (24 * time.Hour))
+		if current[t.Name] == days {
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY TTL %s + INTERVAL %d DAY",
+			t.Name, t.TTLColumn, days))
+	}
+	return stmts
+}
+
+// ApplyRetention sets the table TTLs described by cfg. It is run during
+// startup migration and only alters tables whose TTL differs from cfg, since
+// MODIFY TTL rewrites existing parts. If the current TTLs cannot be read,
+// every table is altered.
+func (c *Client) ApplyRetention(ctx context.Context, cfg RetentionConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	var current map[string]int
+	if tables, err := c.TableRetentions(ctx); err != nil {
+		slog.Warn("failed to read clickhouse table TTLs, applying retention to every table", "error", err)
+	} else {
+		current = make(map[string]int, len(tables))
+		for _, t := range tables {
+			current[t.Table] = t.Days
+		}
+	}
+	for _, stmt := range retentionStatements(cfg, current) {
+		if err := c.conn.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("apply retention %q: %w", stmt, err)
+		}
+	}
+	c.retention = cfg
+	return nil
+}
+
+// RetentionSettings returns the retention configuration last applied by
+// ApplyRetention.
+func (c *Client) RetentionSettings() RetentionConfig {
+	return c.retention
+}
+
+// TableRetention is the TTL currently set on a managed table.
+type TableRetention struct {
+	Table string
+	Class string
+	TTL   string // TTL expression as stored by ClickHouse; empty if none
+	Days  int    // retention in days parsed from TTL; 0 if unknown
+}
+
+const queryTableTTLs = `
+SELECT name, create_table_query
+FROM system.tables
+WHERE database = currentDatabase() AND name IN (?)`
+
+// TableRetentions reads the current TTL of each managed table from
+// system.tables. Tables that do not exist are omitted.
+func (c *Client) TableRetentions(ctx context.Context) ([]TableRetention, error) {
+	names := make([]string, 0, len(retentionTables))
+	for _, t := range retentionTables {
+		names = append(names, t.Name)
+	}
+	rows, err := c.conn.Query(ctx, queryTableTTLs, names)
+	if err != nil {
+		return nil, fmt.Errorf("TableRetentions query: %w", err)
+	}
+	defer rows.Close()
+
+	ddl := make(map[string]string, len(names))
+	for rows.Next() {
+		var name, query string
+		if err := rows.Scan(&name, &query); err != nil {
+			return nil, fmt.Errorf("TableRetentions scan: %w", err)
+		}
+		ddl[name] = query
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TableRetentions rows: %w", err)
+	}
+
+	result := make([]TableRetention, 0, len(ddl))
+	for _, t := range retentionTables {
+		query, ok := ddl[t.Name]
+		if !ok {
+			continue
+		}
+		ttl, days := parseTTL(query)
+		result = append(result, TableRetention{Table: t.Name, Class: t.Class, TTL: ttl, Days: days})
+	}
+	return result, nil
+}
+
+var (
+	ttlClauseRe = regexp.MustCompile(`(?s)\bTTL\s+(.+?)(?:\s+SETTINGS\b|\s+AS\s+SELECT\b|\s*$)`)
+	ttlDaysRe   = regexp.MustCompile(`(?i)toIntervalDay\((\d+)\)|INTERVAL\s+(\d+)\s+DAY`)
+)
+
+// parseTTL extracts the TTL expression and its retention in days from a
+// CREATE TABLE or CREATE MATERIALIZED VIEW statement.
+func parseTTL(createQuery string) (string, int) {
+	m := ttlClauseRe.FindStringSubmatch(createQuery)
+	if m == nil {
+		return "", 0
+	}
+	ttl := m[1]
+	d := ttlDaysRe.FindStringSubmatch(ttl)
+	if d == nil {
+		return ttl, 0
+	}
+	n := d[1]
+	if n == "" {
+		n = d[2]
+	}
+	days, _ := strconv.Atoi(n)
+	return ttl, days
+}
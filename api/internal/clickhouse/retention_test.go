@@ -0,0 +1,114 @@
+package clickhouse
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRetentionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RetentionConfig
+		wantErr bool
+	}{
+		{name: "defaults", cfg: DefaultRetention()},
+		{name: "disabled", cfg: RetentionConfig{}},
+		{name: "partial day", cfg: RetentionConfig{Raw: 36 * time.Hour}, wantErr: true},
+		{name: "negative", cfg: RetentionConfig{Rollup: -24 * time.Hour}, wantErr: true},
+		{name: "partial day metrics", cfg: RetentionConfig{Metrics: 12 * time.Hour}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetentionStatements(t *testing.T) {
+	stmts := retentionStatements(RetentionConfig{Raw: 7 * 24 * time.Hour, Rollup: 90 * 24 * time.Hour, Metrics: 30 * 24 * time.Hour}, nil)
+	if len(stmts) != len(retentionTables) {
+		t.Fatalf("expected %d statements, got %d", len(retentionTables), len(stmts))
+	}
+	if want := "ALTER TABLE ngf_access_logs MODIFY TTL toDateTime(timestamp) + INTERVAL 7 DAY"; stmts[0] != want {
+		t.Errorf("stmts[0] = %q, want %q", stmts[0], want)
+	}
+	if want := "ALTER TABLE ngf_metrics_1m MODIFY TTL window_start + INTERVAL 90 DAY"; stmts[3] != want {
+		t.Errorf("stmts[3] = %q, want %q", stmts[3], want)
+	}
+	if want := "ALTER TABLE ngf_inference_metrics_1m MODIFY TTL toDateTime(timestamp) + INTERVAL 30 DAY"; stmts[4] != want {
+		t.Errorf("stmts[4] = %q, want %q", stmts[4], want)
+	}
+
+	// A zero rollup and metrics retention leaves those tables untouched.
+	stmts = retentionStatements(RetentionConfig{Raw: 24 * time.Hour}, nil)
+	if len(stmts) != 3 {
+		t.Errorf("expected 3 raw statements, got %d: %v", len(stmts), stmts)
+	}
+
+	// Tables already at the configured TTL are skipped.
+	current := map[string]int{
+		"ngf_access_logs":          7,
+		"ngf_inference_logs":       7,
+		"ngf_epp_decisions":        7,
+		"ngf_metrics_1m":           90,
+		"ngf_inference_metrics_1m": 90,
+		tableInferenceRollup1m:     90,
+		tableInferenceRollup1h:     90,
+	}
+	stmts = retentionStatements(DefaultRetention(), current)
+	if len(stmts) != 0 {
+		t.Errorf("expected no statements when TTLs match, got %v", stmts)
+	}
+	stmts = retentionStatements(RetentionConfig{Metrics: 14 * 24 * time.Hour}, current)
+	want := []string{
+		"ALTER TABLE ngf_inference_metrics_1m MODIFY TTL toDateTime(timestamp) + INTERVAL 14 DAY",
+		"ALTER TABLE ngf_inference_metrics_rollup_1m MODIFY TTL window_start + INTERVAL 14 DAY",
+		"ALTER TABLE ngf_inference_metrics_rollup_1h MODIFY TTL window_start + INTERVAL 14 DAY",
+	}
+	if !slices.Equal(stmts, want) {
+		t.Errorf("retentionStatements() = %v, want %v", stmts, want)
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantTTL  string
+		wantDays int
+	}{
+		{
+			name:     "table with settings",
+			query:    "CREATE TABLE default.ngf_access_logs (`timestamp` DateTime64(3)) ENGINE = MergeTree ORDER BY timestamp TTL toDateTime(timestamp) + toIntervalDay(7) SETTINGS index_granularity = 8192",
+			wantTTL:  "toDateTime(timestamp) + toIntervalDay(7)",
+			wantDays: 7,
+		},
+		{
+			name:     "materialized view",
+			query:    "CREATE MATERIALIZED VIEW default.ngf_metrics_1m ENGINE = AggregatingMergeTree ORDER BY window_start TTL window_start + toIntervalDay(90) SETTINGS index_granularity = 8192 AS SELECT toStartOfMinute(timestamp) AS window_start FROM default.ngf_access_logs",
+			wantTTL:  "window_start + toIntervalDay(90)",
+			wantDays: 90,
+		},
+		{
+			name:     "interval syntax at end",
+			query:    "CREATE TABLE t (ts DateTime) ENGINE = MergeTree ORDER BY ts TTL ts + INTERVAL 14 DAY",
+			wantTTL:  "ts + INTERVAL 14 DAY",
+			wantDays: 14,
+		},
+		{
+			name:  "no ttl",
+			query: "CREATE TABLE t (ts DateTime) ENGINE = MergeTree ORDER BY ts SETTINGS index_granularity = 8192",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, days := parseTTL(tt.query)
+			if ttl != tt.wantTTL || days != tt.wantDays {
+				t.Errorf("parseTTL() = (%q, %d), want (%q, %d)", ttl, days, tt.wantTTL, tt.wantDays)
+			}
+		})
+	}
+}
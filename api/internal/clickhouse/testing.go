@@ -0,0 +1,9 @@
+package clickhouse
+
+// NewForTest returns a Client without a connection whose Healthy reports
+// healthy. Only the health accessors may be used on it.
+func NewForTest(healthy bool) *Client {
+	c := &Client{}
+	c.healthy.Store(healthy)
+	return c
+}
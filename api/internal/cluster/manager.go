@@ -20,6 +20,24 @@ type Provider interface {
 	Names() []string
 }
 
+// LabelSelector is implemented by providers whose clusters carry labels, so
+// callers can target a labeled subset of the fleet instead of one cluster or
+// all of them.
+type LabelSelector interface {
+	// SelectNames returns the names of the clusters matching a Kubernetes
+	// label selector string.
+	SelectNames(selector string) ([]string, error)
+}
+
+// Failover is implemented by providers that track cluster health and can
+// serve read-only requests for an unhealthy cluster from a healthy replica.
+type Failover interface {
+	// Unhealthy reports whether the named cluster is marked unhealthy.
+	Unhealthy(name string) bool
+	// Replica returns a healthy cluster that can stand in for the named one.
+	Replica(name string) (replica string, client *kubernetes.Client, ok bool)
+}
+
 // ClusterInfo represents a cluster's status as returned to API consumers.
 type ClusterInfo struct {
 	Name        string             `json:"name"`
@@ -29,10 +29,31 @@ type Store interface {
 	CreateSavedView(ctx context.Context, view SavedView) error
 	DeleteSavedView(ctx context.Context, id string) error
 
-	// XC credentials
-	GetXCCredentials(ctx context.Context) (*XCCredentials, error)
+	// XC credentials. scope is the Kubernetes namespace a credential set
+	// applies to; the empty scope is the global set.
+	GetXCCredentials(ctx context.Context, scope string) (*XCCredentials, error)
+	ListXCCredentials(ctx context.Context) ([]XCCredentials, error)
 	SaveXCCredentials(ctx context.Context, creds XCCredentials) error
-	DeleteXCCredentials(ctx context.Context) error
+	DeleteXCCredentials(ctx context.Context, scope string) error
+
+	// Migration imports
+	SaveMigrationImport(ctx context.Context, imp MigrationImport) error
+	GetMigrationImport(ctx context.Context, id string) (*MigrationImport, error)
+	DeleteMigrationImportsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// Migration apply progress
+	SaveMigrationApplyRecord(ctx context.Context, rec MigrationApplyRecord) error
+	ListMigrationApplyRecords(ctx context.Context, importID string) ([]MigrationApplyRecord, error)
+
+	// Cluster heartbeats
+	InsertHeartbeat(ctx context.Context, hb HeartbeatRecord) error
+	ListHeartbeats(ctx context.Context, cluster string, since time.Time, limit int) ([]HeartbeatRecord, error)
+	DeleteHeartbeatsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+
+	// Cluster heartbeat tokens
+	GetClusterToken(ctx context.Context, cluster string) (*ClusterToken, error)
+	SaveClusterToken(ctx context.Context, token ClusterToken) error
+	DeleteClusterToken(ctx context.Context, cluster string) error
 }
 
 // AuditEntry represents a single audit log record.
@@ -47,8 +68,17 @@ type AuditEntry struct {
 	Cluster    string    `json:"cluster"`    // cluster context
 	BeforeJSON string    `json:"beforeJson"` // JSON snapshot before change
 	AfterJSON  string    `json:"afterJson"`  // JSON snapshot after change
+	Outcome    string    `json:"outcome"`    // success or failure
+	Error      string    `json:"error,omitempty"`
+	RequestID  string    `json:"requestId,omitempty"` // X-Request-ID of the request
 }
 
+// Audit entry outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
 // AuditListOptions controls pagination and filtering for audit queries.
 type AuditListOptions struct {
 	Offset    int
@@ -57,7 +87,10 @@ type AuditListOptions struct {
 	Action    string
 	User      string
 	Namespace string
+	Cluster   string
+	Outcome   string
 	Since     *time.Time
+	Until     *time.Time
 }
 
 // AlertRule defines a threshold-based alert.
@@ -90,7 +123,51 @@ type XCCredentials struct {
 	ID        string    `json:"id"`
 	Tenant    string    `json:"tenant"`
 	APIToken  string    `json:"apiToken"`
-	Namespace string    `json:"namespace"` // default XC namespace
+	Namespace string    `json:"namespace"`       // default XC namespace
+	Scope     string    `json:"scope,omitempty"` // Kubernetes namespace the set applies to; empty for the global set
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// MigrationImport is a source configuration imported for migration, with the
+// resources discovered in it.
+type MigrationImport struct {
+	ID            string    `json:"id"`
+	Format        string    `json:"format"`        // nginx-conf, ingress-yaml, virtualserver-yaml
+	Content       string    `json:"content"`       // raw imported content
+	ResourcesJSON string    `json:"resourcesJson"` // JSON array of discovered resources
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// MigrationApplyRecord is the outcome of applying one generated resource of a
+// migration import. Apply records one per resource as it goes, so a re-invoked
+// Apply can skip the resources already applied.
+type MigrationApplyRecord struct {
+	ImportID  string    `json:"importId"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"` // applied, exists, failed
+	Error     string    `json:"error"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// HeartbeatRecord is one agent heartbeat received from a managed cluster.
+type HeartbeatRecord struct {
+	ID                 string    `json:"id"`
+	Cluster            string    `json:"cluster"`
+	Timestamp          time.Time `json:"timestamp"`
+	Phase              string    `json:"phase"` // Ready, or Degraded if the agent reported discovery errors
+	KubernetesVersion  string    `json:"kubernetesVersion"`
+	NGFVersion         string    `json:"ngfVersion"`
+	ResourceCountsJSON string    `json:"resourceCountsJson"` // JSON object, empty if not reported
+	GPUCapacityJSON    string    `json:"gpuCapacityJson"`    // JSON object, empty if not reported
+}
+
+// ClusterToken is the token a managed cluster's agent authenticates its
+// heartbeats with. Only a SHA-256 hash of the token is stored.
+type ClusterToken struct {
+	Cluster   string    `json:"cluster"`
+	TokenHash string    `json:"-"` // hex-encoded SHA-256 of the token
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks token values encrypted by EncryptedStore. Values
+// without it are treated as legacy plaintext.
+const encryptedPrefix = "enc:v1:"
+
+// EncryptedStore wraps a Store and AES-GCM encrypts the XC API token at rest.
+// All other operations pass through to the underlying store.
+type EncryptedStore struct {
+	Store
+	aead cipher.AEAD
+}
+
+// NewEncryptedStore wraps store so XC tokens are encrypted with key, which
+// must be 32 bytes (AES-256).
+func NewEncryptedStore(store Store, key []byte) (*EncryptedStore, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+	return &EncryptedStore{Store: store, aead: aead}, nil
+}
+
+// ParseEncryptionKey decodes a 32-byte key given as base64 or hex.
+func ParseEncryptionKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(s); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key must be 32 bytes encoded as base64 or hex")
+}
+
+// GetXCCredentials returns the XC credentials stored for scope with the token
+// decrypted. Plaintext tokens written before encryption was enabled are
+// returned as-is.
+func (s *EncryptedStore) GetXCCredentials(ctx context.Context, scope string) (*XCCredentials, error) {
+	creds, err := s.Store.GetXCCredentials(ctx, scope)
+	if err != nil || creds == nil {
+		return creds, err
+	}
+	token, err := s.decrypt(creds.APIToken)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting XC API token: %w", err)
+	}
+	creds.APIToken = token
+	return creds, nil
+}
+
+// ListXCCredentials returns every stored credential set with the tokens
+// decrypted.
+func (s *EncryptedStore) ListXCCredentials(ctx context.Context) ([]XCCredentials, error) {
+	creds, err := s.Store.ListXCCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range creds {
+		token, err := s.decrypt(creds[i].APIToken)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting XC API token for scope %q: %w", creds[i].Scope, err)
+		}
+		creds[i].APIToken = token
+	}
+	return creds, nil
+}
+
+// SaveXCCredentials encrypts the token before storing the credentials.
+func (s *EncryptedStore) SaveXCCredentials(ctx context.Context, creds XCCredentials) error {
+	token, err := s.encrypt(creds.APIToken)
+	if err != nil {
+		return fmt.Errorf("encrypting XC API token: %w", err)
+	}
+	creds.APIToken = token
+	return s.Store.SaveXCCredentials(ctx, creds)
+}
+
+func (s *EncryptedStore) encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *EncryptedStore) decrypt(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, encryptedPrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decoding ciphertext: %w", err)
+	}
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	plaintext, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestSQLite(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if err := store.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return store
+}
+
+func TestEncryptedStore_XCCredentials(t *testing.T) {
+	ctx := context.Background()
+	base := newTestSQLite(t)
+
+	store, err := NewEncryptedStore(base, []byte(strings.Repeat("k", 32)))
+	if err != nil {
+		t.Fatalf("NewEncryptedStore: %v", err)
+	}
+
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "acme", APIToken: "secret-token", Namespace: "default"}); err != nil {
+		t.Fatalf("SaveXCCredentials: %v", err)
+	}
+
+	raw, err := base.GetXCCredentials(ctx, "")
+	if err != nil {
+		t.Fatalf("reading raw credentials: %v", err)
+	}
+	if !strings.HasPrefix(raw.APIToken, encryptedPrefix) || strings.Contains(raw.APIToken, "secret-token") {
+		t.Errorf("expected token to be encrypted at rest, got %q", raw.APIToken)
+	}
+
+	creds, err := store.GetXCCredentials(ctx, "")
+	if err != nil {
+		t.Fatalf("GetXCCredentials: %v", err)
+	}
+	if creds.APIToken != "secret-token" {
+		t.Errorf("expected decrypted token, got %q", creds.APIToken)
+	}
+}
+
+func TestEncryptedStore_LegacyPlaintext(t *testing.T) {
+	ctx := context.Background()
+	base := newTestSQLite(t)
+
+	if err := base.SaveXCCredentials(ctx, XCCredentials{Tenant: "acme", APIToken: "plain-token"}); err != nil {
+		t.Fatalf("SaveXCCredentials: %v", err)
+	}
+
+	store, err := NewEncryptedStore(base, []byte(strings.Repeat("k", 32)))
+	if err != nil {
+		t.Fatalf("NewEncryptedStore: %v", err)
+	}
+	creds, err := store.GetXCCredentials(ctx, "")
+	if err != nil {
+		t.Fatalf("GetXCCredentials: %v", err)
+	}
+	if creds.APIToken != "plain-token" {
+		t.Errorf("expected legacy plaintext token, got %q", creds.APIToken)
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	if _, err := ParseEncryptionKey("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="); err != nil {
+		t.Errorf("expected base64 key to parse: %v", err)
+	}
+	if _, err := ParseEncryptionKey(strings.Repeat("ab", 32)); err != nil {
+		t.Errorf("expected hex key to parse: %v", err)
+	}
+	if _, err := ParseEncryptionKey("too-short"); err == nil {
+		t.Error("expected error for short key")
+	}
+}
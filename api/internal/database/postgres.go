@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -47,11 +48,22 @@ func (s *PostgresStore) InsertAuditEntry(ctx context.Context, entry AuditEntry)
 	if entry.Timestamp.IsZero() {
 		entry.Timestamp = time.Now().UTC()
 	}
+	// JSONB columns reject empty strings; store an empty object instead.
+	if entry.BeforeJSON == "" {
+		entry.BeforeJSON = "{}"
+	}
+	if entry.AfterJSON == "" {
+		entry.AfterJSON = "{}"
+	}
+	if entry.Outcome == "" {
+		entry.Outcome = AuditSuccess
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO audit_log (id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		`INSERT INTO audit_log (id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
 		entry.ID, entry.Timestamp, entry.User, entry.Action, entry.Resource,
 		entry.Name, entry.Namespace, entry.Cluster, entry.BeforeJSON, entry.AfterJSON,
+		entry.Outcome, entry.Error, entry.RequestID,
 	)
 	return err
 }
@@ -82,11 +94,26 @@ func (s *PostgresStore) ListAuditEntries(ctx context.Context, opts AuditListOpti
 		args = append(args, opts.Namespace)
 		argIdx++
 	}
+	if opts.Cluster != "" {
+		conditions = append(conditions, fmt.Sprintf("cluster = $%d", argIdx))
+		args = append(args, opts.Cluster)
+		argIdx++
+	}
+	if opts.Outcome != "" {
+		conditions = append(conditions, fmt.Sprintf("outcome = $%d", argIdx))
+		args = append(args, opts.Outcome)
+		argIdx++
+	}
 	if opts.Since != nil {
 		conditions = append(conditions, fmt.Sprintf("timestamp >= $%d", argIdx))
 		args = append(args, *opts.Since)
 		argIdx++
 	}
+	if opts.Until != nil {
+		conditions = append(conditions, fmt.Sprintf("timestamp < $%d", argIdx))
+		args = append(args, *opts.Until)
+		argIdx++
+	}
 
 	where := ""
 	if len(conditions) > 0 {
@@ -108,7 +135,7 @@ func (s *PostgresStore) ListAuditEntries(ctx context.Context, opts AuditListOpti
 	offset := opts.Offset
 
 	query := fmt.Sprintf(
-		`SELECT id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json FROM audit_log %s ORDER BY timestamp DESC LIMIT $%d OFFSET $%d`,
+		`SELECT id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id FROM audit_log %s ORDER BY timestamp DESC LIMIT $%d OFFSET $%d`,
 		where, argIdx, argIdx+1,
 	)
 	args = append(args, limit, offset)
@@ -122,7 +149,7 @@ func (s *PostgresStore) ListAuditEntries(ctx context.Context, opts AuditListOpti
 	var entries []AuditEntry
 	for rows.Next() {
 		var e AuditEntry
-		if err := rows.Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON); err != nil {
+		if err := rows.Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON, &e.Outcome, &e.Error, &e.RequestID); err != nil {
 			return nil, 0, err
 		}
 		entries = append(entries, e)
@@ -134,9 +161,9 @@ func (s *PostgresStore) ListAuditEntries(ctx context.Context, opts AuditListOpti
 func (s *PostgresStore) GetAuditEntry(ctx context.Context, id string) (*AuditEntry, error) {
 	var e AuditEntry
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json FROM audit_log WHERE id = $1`,
+		`SELECT id, timestamp, "user", action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id FROM audit_log WHERE id = $1`,
 		id,
-	).Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON)
+	).Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON, &e.Outcome, &e.Error, &e.RequestID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -246,6 +273,243 @@ func (s *PostgresStore) DeleteSavedView(ctx context.Context, id string) error {
 	return err
 }
 
+// GetXCCredentials returns the XC credentials stored for scope, or nil if
+// there are none. It does not fall back to the global set.
+func (s *PostgresStore) GetXCCredentials(ctx context.Context, scope string) (*XCCredentials, error) {
+	var c XCCredentials
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, tenant, api_token, namespace, scope, created_at, updated_at FROM xc_credentials WHERE scope = $1",
+		scope,
+	).Scan(&c.ID, &c.Tenant, &c.APIToken, &c.Namespace, &c.Scope, &c.CreatedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+// ListXCCredentials returns every stored credential set, the global set first.
+func (s *PostgresStore) ListXCCredentials(ctx context.Context) ([]XCCredentials, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, tenant, api_token, namespace, scope, created_at, updated_at FROM xc_credentials ORDER BY scope",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var creds []XCCredentials
+	for rows.Next() {
+		var c XCCredentials
+		if err := rows.Scan(&c.ID, &c.Tenant, &c.APIToken, &c.Namespace, &c.Scope, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		creds = append(creds, c)
+	}
+	return creds, rows.Err()
+}
+
+// SaveXCCredentials upserts XC credentials, replacing the set with the same
+// scope. The delete and insert run in one transaction so concurrent replicas never
+// observe an empty table.
+func (s *PostgresStore) SaveXCCredentials(ctx context.Context, creds XCCredentials) error {
+	if creds.ID == "" {
+		creds.ID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM xc_credentials WHERE scope = $1", creds.Scope); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO xc_credentials (id, tenant, api_token, namespace, scope, created_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		creds.ID, creds.Tenant, creds.APIToken, creds.Namespace, creds.Scope, now, now,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteXCCredentials removes the XC credentials stored for scope.
+func (s *PostgresStore) DeleteXCCredentials(ctx context.Context, scope string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM xc_credentials WHERE scope = $1", scope)
+	return err
+}
+
+// SaveMigrationImport stores an imported migration source.
+func (s *PostgresStore) SaveMigrationImport(ctx context.Context, imp MigrationImport) error {
+	if imp.ID == "" {
+		imp.ID = uuid.NewString()
+	}
+	if imp.ResourcesJSON == "" {
+		imp.ResourcesJSON = "[]"
+	}
+	if imp.CreatedAt.IsZero() {
+		imp.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO migration_imports (id, format, content, resources_json, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		imp.ID, imp.Format, imp.Content, imp.ResourcesJSON, imp.CreatedAt,
+	)
+	return err
+}
+
+// GetMigrationImport returns an imported migration source by ID.
+func (s *PostgresStore) GetMigrationImport(ctx context.Context, id string) (*MigrationImport, error) {
+	var imp MigrationImport
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, format, content, resources_json, created_at FROM migration_imports WHERE id = $1",
+		id,
+	).Scan(&imp.ID, &imp.Format, &imp.Content, &imp.ResourcesJSON, &imp.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &imp, err
+}
+
+// DeleteMigrationImportsBefore deletes imports created before cutoff, with
+// their apply progress, and returns how many imports were removed.
+func (s *PostgresStore) DeleteMigrationImportsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM migration_apply_progress WHERE import_id IN (SELECT id FROM migration_imports WHERE created_at < $1)", cutoff.UTC()); err != nil {
+		return 0, err
+	}
+	res, err := s.db.ExecContext(ctx, "DELETE FROM migration_imports WHERE created_at < $1", cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// SaveMigrationApplyRecord inserts or replaces the apply outcome of one
+// resource of an import.
+func (s *PostgresStore) SaveMigrationApplyRecord(ctx context.Context, rec MigrationApplyRecord) error {
+	if rec.UpdatedAt.IsZero() {
+		rec.UpdatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO migration_apply_progress (import_id, kind, namespace, name, status, error, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (import_id, kind, namespace, name)
+		 DO UPDATE SET status = excluded.status, error = excluded.error, updated_at = excluded.updated_at`,
+		rec.ImportID, rec.Kind, rec.Namespace, rec.Name, rec.Status, rec.Error, rec.UpdatedAt,
+	)
+	return err
+}
+
+// ListMigrationApplyRecords returns the recorded apply outcomes of an import,
+// ordered by resource.
+func (s *PostgresStore) ListMigrationApplyRecords(ctx context.Context, importID string) ([]MigrationApplyRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT import_id, kind, namespace, name, status, error, updated_at FROM migration_apply_progress
+		 WHERE import_id = $1 ORDER BY kind, namespace, name`,
+		importID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []MigrationApplyRecord
+	for rows.Next() {
+		var rec MigrationApplyRecord
+		if err := rows.Scan(&rec.ImportID, &rec.Kind, &rec.Namespace, &rec.Name, &rec.Status, &rec.Error, &rec.UpdatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// InsertHeartbeat records an agent heartbeat.
+func (s *PostgresStore) InsertHeartbeat(ctx context.Context, hb HeartbeatRecord) error {
+	if hb.ID == "" {
+		hb.ID = uuid.NewString()
+	}
+	if hb.Timestamp.IsZero() {
+		hb.Timestamp = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO cluster_heartbeats (id, cluster, timestamp, phase, kubernetes_version, ngf_version, resource_counts_json, gpu_capacity_json)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		hb.ID, hb.Cluster, hb.Timestamp.UTC(), hb.Phase, hb.KubernetesVersion, hb.NGFVersion, hb.ResourceCountsJSON, hb.GPUCapacityJSON,
+	)
+	return err
+}
+
+// ListHeartbeats returns up to limit of a cluster's most recent heartbeats
+// received at or after since, oldest first.
+func (s *PostgresStore) ListHeartbeats(ctx context.Context, cluster string, since time.Time, limit int) ([]HeartbeatRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, cluster, timestamp, phase, kubernetes_version, ngf_version, resource_counts_json, gpu_capacity_json FROM cluster_heartbeats
+		 WHERE cluster = $1 AND timestamp >= $2 ORDER BY timestamp DESC LIMIT $3`,
+		cluster, since.UTC(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []HeartbeatRecord
+	for rows.Next() {
+		var hb HeartbeatRecord
+		if err := rows.Scan(&hb.ID, &hb.Cluster, &hb.Timestamp, &hb.Phase, &hb.KubernetesVersion, &hb.NGFVersion, &hb.ResourceCountsJSON, &hb.GPUCapacityJSON); err != nil {
+			return nil, err
+		}
+		records = append(records, hb)
+	}
+	slices.Reverse(records)
+	return records, rows.Err()
+}
+
+// DeleteHeartbeatsBefore deletes heartbeats received before cutoff and returns
+// how many were removed.
+func (s *PostgresStore) DeleteHeartbeatsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM cluster_heartbeats WHERE timestamp < $1", cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// GetClusterToken returns a cluster's heartbeat token, or nil if it has none.
+func (s *PostgresStore) GetClusterToken(ctx context.Context, cluster string) (*ClusterToken, error) {
+	var t ClusterToken
+	err := s.db.QueryRowContext(ctx,
+		"SELECT cluster, token_hash, created_at FROM cluster_tokens WHERE cluster = $1",
+		cluster,
+	).Scan(&t.Cluster, &t.TokenHash, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &t, err
+}
+
+// SaveClusterToken sets a cluster's heartbeat token, replacing any previous
+// one.
+func (s *PostgresStore) SaveClusterToken(ctx context.Context, token ClusterToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO cluster_tokens (cluster, token_hash, created_at) VALUES ($1, $2, $3)
+		 ON CONFLICT (cluster) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at`,
+		token.Cluster, token.TokenHash, token.CreatedAt,
+	)
+	return err
+}
+
+// DeleteClusterToken removes a cluster's heartbeat token.
+func (s *PostgresStore) DeleteClusterToken(ctx context.Context, cluster string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM cluster_tokens WHERE cluster = $1", cluster)
+	return err
+}
+
 const postgresSchema = `
 CREATE TABLE IF NOT EXISTS audit_log (
 	id UUID PRIMARY KEY,
@@ -257,9 +521,17 @@ CREATE TABLE IF NOT EXISTS audit_log (
 	namespace TEXT NOT NULL DEFAULT '',
 	cluster TEXT NOT NULL DEFAULT '',
 	before_json JSONB NOT NULL DEFAULT '{}',
-	after_json JSONB NOT NULL DEFAULT '{}'
+	after_json JSONB NOT NULL DEFAULT '{}',
+	outcome TEXT NOT NULL DEFAULT 'success',
+	error TEXT NOT NULL DEFAULT '',
+	request_id TEXT NOT NULL DEFAULT ''
 );
 
+-- outcome, error and request_id were added to audit failed operations too.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS outcome TEXT NOT NULL DEFAULT 'success';
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS error TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS request_id TEXT NOT NULL DEFAULT '';
+
 CREATE INDEX IF NOT EXISTS idx_audit_timestamp ON audit_log(timestamp);
 CREATE INDEX IF NOT EXISTS idx_audit_resource ON audit_log(resource);
 CREATE INDEX IF NOT EXISTS idx_audit_action ON audit_log(action);
@@ -288,4 +560,81 @@ CREATE TABLE IF NOT EXISTS saved_views (
 );
 
 CREATE INDEX IF NOT EXISTS idx_saved_views_user ON saved_views(user_id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_cluster ON audit_log(cluster);
+
+CREATE TABLE IF NOT EXISTS managed_clusters (
+	name TEXT PRIMARY KEY,
+	display_name TEXT NOT NULL,
+	region TEXT NOT NULL DEFAULT '',
+	environment TEXT NOT NULL DEFAULT '',
+	phase TEXT NOT NULL DEFAULT 'Pending',
+	kubernetes_version TEXT NOT NULL DEFAULT '',
+	ngf_version TEXT NOT NULL DEFAULT '',
+	agent_installed BOOLEAN NOT NULL DEFAULT FALSE,
+	last_heartbeat TIMESTAMPTZ,
+	total_gpus INTEGER NOT NULL DEFAULT 0,
+	registered_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cluster_groups (
+	id UUID PRIMARY KEY,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	cluster_names JSONB NOT NULL DEFAULT '[]',
+	created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS xc_credentials (
+	id UUID PRIMARY KEY,
+	tenant TEXT NOT NULL,
+	api_token TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT 'default',
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- scope was added for per-namespace credentials; '' is the global set.
+ALTER TABLE xc_credentials ADD COLUMN IF NOT EXISTS scope TEXT NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_xc_credentials_scope ON xc_credentials(scope);
+
+CREATE TABLE IF NOT EXISTS migration_imports (
+	id TEXT PRIMARY KEY,
+	format TEXT NOT NULL,
+	content TEXT NOT NULL,
+	resources_json JSONB NOT NULL DEFAULT '[]',
+	created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_migration_imports_created ON migration_imports(created_at);
+
+CREATE TABLE IF NOT EXISTS migration_apply_progress (
+	import_id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	updated_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (import_id, kind, namespace, name)
+);
+
+CREATE TABLE IF NOT EXISTS cluster_heartbeats (
+	id TEXT PRIMARY KEY,
+	cluster TEXT NOT NULL,
+	timestamp TIMESTAMPTZ NOT NULL,
+	phase TEXT NOT NULL DEFAULT '',
+	kubernetes_version TEXT NOT NULL DEFAULT '',
+	ngf_version TEXT NOT NULL DEFAULT '',
+	resource_counts_json TEXT NOT NULL DEFAULT '',
+	gpu_capacity_json TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_cluster_heartbeats_cluster_time ON cluster_heartbeats(cluster, timestamp);
+
+CREATE TABLE IF NOT EXISTS cluster_tokens (
+	cluster TEXT PRIMARY KEY,
+	token_hash TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
 `
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+// postgresDSNEnv names the environment variable with a DSN for the Postgres
+// store tests. They are skipped when it is unset.
+const postgresDSNEnv = "NGC_TEST_POSTGRES_DSN"
+
+// newTestPostgres returns a migrated store in a fresh schema that is dropped
+// when the test ends.
+func newTestPostgres(t *testing.T) *PostgresStore {
+	t.Helper()
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", postgresDSNEnv)
+	}
+	ctx := context.Background()
+
+	admin, err := NewPostgres(dsn)
+	if err != nil {
+		t.Fatalf("NewPostgres: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+	schema := fmt.Sprintf("ngc_test_%d", time.Now().UnixNano())
+	if _, err := admin.db.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+	t.Cleanup(func() { _, _ = admin.db.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	store, err := NewPostgres(withSearchPath(dsn, schema))
+	if err != nil {
+		t.Fatalf("NewPostgres: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return store
+}
+
+// withSearchPath points a URL or keyword/value DSN at schema.
+func withSearchPath(dsn, schema string) string {
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		q := u.Query()
+		q.Set("search_path", schema)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	return dsn + " search_path=" + schema
+}
+
+func TestPostgresStore_XCCredentialScopes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPostgres(t)
+
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "shared", APIToken: "t1", Namespace: "default"}); err != nil {
+		t.Fatalf("save global: %v", err)
+	}
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "team-a", APIToken: "t2", Namespace: "a", Scope: "team-a"}); err != nil {
+		t.Fatalf("save scoped: %v", err)
+	}
+	// Saving a scope again replaces only that scope's set.
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "team-a2", APIToken: "t3", Namespace: "a", Scope: "team-a"}); err != nil {
+		t.Fatalf("replace scoped: %v", err)
+	}
+
+	global, err := store.GetXCCredentials(ctx, "")
+	if err != nil || global == nil || global.Tenant != "shared" || global.APIToken != "t1" {
+		t.Fatalf("global credentials = %+v, %v", global, err)
+	}
+	scoped, err := store.GetXCCredentials(ctx, "team-a")
+	if err != nil || scoped == nil || scoped.Tenant != "team-a2" || scoped.Scope != "team-a" || scoped.Namespace != "a" {
+		t.Fatalf("scoped credentials = %+v, %v", scoped, err)
+	}
+	if missing, err := store.GetXCCredentials(ctx, "team-b"); err != nil || missing != nil {
+		t.Errorf("expected no credentials for team-b, got %+v, %v", missing, err)
+	}
+
+	list, err := store.ListXCCredentials(ctx)
+	if err != nil {
+		t.Fatalf("ListXCCredentials: %v", err)
+	}
+	if len(list) != 2 || list[0].Scope != "" || list[1].Scope != "team-a" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	if err := store.DeleteXCCredentials(ctx, "team-a"); err != nil {
+		t.Fatalf("DeleteXCCredentials: %v", err)
+	}
+	if global, _ := store.GetXCCredentials(ctx, ""); global == nil {
+		t.Error("deleting a scope removed the global set")
+	}
+}
+
+func TestPostgresStore_MigrateAddsScope(t *testing.T) {
+	ctx := context.Background()
+	store := newTestPostgres(t)
+
+	// Recreate xc_credentials as it was before scope was added.
+	if _, err := store.db.ExecContext(ctx, `DROP TABLE xc_credentials;
+CREATE TABLE xc_credentials (
+	id UUID PRIMARY KEY,
+	tenant TEXT NOT NULL,
+	api_token TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT 'default',
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+INSERT INTO xc_credentials VALUES ('00000000-0000-0000-0000-000000000001', 'acme', 'token', 'default', '2024-01-01', '2024-01-01');`); err != nil {
+		t.Fatalf("creating legacy table: %v", err)
+	}
+
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	creds, err := store.GetXCCredentials(ctx, "")
+	if err != nil || creds == nil || creds.Tenant != "acme" {
+		t.Errorf("expected the existing row to become the global set, got %+v, %v", creds, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,12 +35,48 @@ func NewSQLite(path string) (*SQLiteStore, error) {
 	return &SQLiteStore{db: db}, nil
 }
 
-// Migrate creates tables if they don't exist.
+// Migrate creates tables if they don't exist and adds columns introduced
+// after a table was first created.
 func (s *SQLiteStore) Migrate(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, sqliteSchema)
+	if _, err := s.db.ExecContext(ctx, sqliteSchema); err != nil {
+		return err
+	}
+
+	// xc_credentials.scope was added for per-namespace credentials, and the
+	// audit_log outcome columns for failed operations. Rows written before
+	// then were all successes.
+	for _, c := range []struct{ table, column, def string }{
+		{"xc_credentials", "scope", "TEXT NOT NULL DEFAULT ''"},
+		{"audit_log", "outcome", "TEXT NOT NULL DEFAULT 'success'"},
+		{"audit_log", "error", "TEXT NOT NULL DEFAULT ''"},
+		{"audit_log", "request_id", "TEXT NOT NULL DEFAULT ''"},
+	} {
+		if err := s.addColumnIfMissing(ctx, c.table, c.column, c.def); err != nil {
+			return err
+		}
+	}
+	_, err := s.db.ExecContext(ctx, "CREATE UNIQUE INDEX IF NOT EXISTS idx_xc_credentials_scope ON xc_credentials(scope)")
 	return err
 }
 
+// addColumnIfMissing adds a column to an existing table. SQLite has no ADD
+// COLUMN IF NOT EXISTS, so check the table first.
+func (s *SQLiteStore) addColumnIfMissing(ctx context.Context, table, column, def string) error {
+	var has int
+	if err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column,
+	).Scan(&has); err != nil {
+		return fmt.Errorf("inspect %s: %w", table, err)
+	}
+	if has > 0 {
+		return nil
+	}
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, def)); err != nil {
+		return fmt.Errorf("add %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
 // Close closes the database connection.
 func (s *SQLiteStore) Close() error {
 	return s.db.Close()
@@ -53,11 +90,15 @@ func (s *SQLiteStore) InsertAuditEntry(ctx context.Context, entry AuditEntry) er
 	if entry.Timestamp.IsZero() {
 		entry.Timestamp = time.Now().UTC()
 	}
+	if entry.Outcome == "" {
+		entry.Outcome = AuditSuccess
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO audit_log (id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO audit_log (id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		entry.ID, entry.Timestamp, entry.User, entry.Action, entry.Resource,
 		entry.Name, entry.Namespace, entry.Cluster, entry.BeforeJSON, entry.AfterJSON,
+		entry.Outcome, entry.Error, entry.RequestID,
 	)
 	return err
 }
@@ -83,10 +124,22 @@ func (s *SQLiteStore) ListAuditEntries(ctx context.Context, opts AuditListOption
 		conditions = append(conditions, "namespace = ?")
 		args = append(args, opts.Namespace)
 	}
+	if opts.Cluster != "" {
+		conditions = append(conditions, "cluster = ?")
+		args = append(args, opts.Cluster)
+	}
+	if opts.Outcome != "" {
+		conditions = append(conditions, "outcome = ?")
+		args = append(args, opts.Outcome)
+	}
 	if opts.Since != nil {
 		conditions = append(conditions, "timestamp >= ?")
 		args = append(args, *opts.Since)
 	}
+	if opts.Until != nil {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, *opts.Until)
+	}
 
 	where := ""
 	if len(conditions) > 0 {
@@ -108,7 +161,7 @@ func (s *SQLiteStore) ListAuditEntries(ctx context.Context, opts AuditListOption
 	offset := opts.Offset
 
 	query := fmt.Sprintf(
-		"SELECT id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json FROM audit_log %s ORDER BY timestamp DESC LIMIT ? OFFSET ?",
+		"SELECT id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id FROM audit_log %s ORDER BY timestamp DESC LIMIT ? OFFSET ?",
 		where,
 	)
 	args = append(args, limit, offset)
@@ -122,7 +175,7 @@ func (s *SQLiteStore) ListAuditEntries(ctx context.Context, opts AuditListOption
 	var entries []AuditEntry
 	for rows.Next() {
 		var e AuditEntry
-		if err := rows.Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON); err != nil {
+		if err := rows.Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON, &e.Outcome, &e.Error, &e.RequestID); err != nil {
 			return nil, 0, err
 		}
 		entries = append(entries, e)
@@ -134,9 +187,9 @@ func (s *SQLiteStore) ListAuditEntries(ctx context.Context, opts AuditListOption
 func (s *SQLiteStore) GetAuditEntry(ctx context.Context, id string) (*AuditEntry, error) {
 	var e AuditEntry
 	err := s.db.QueryRowContext(ctx,
-		"SELECT id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json FROM audit_log WHERE id = ?",
+		"SELECT id, timestamp, user, action, resource, name, namespace, cluster, before_json, after_json, outcome, error, request_id FROM audit_log WHERE id = ?",
 		id,
-	).Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON)
+	).Scan(&e.ID, &e.Timestamp, &e.User, &e.Action, &e.Resource, &e.Name, &e.Namespace, &e.Cluster, &e.BeforeJSON, &e.AfterJSON, &e.Outcome, &e.Error, &e.RequestID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -246,38 +299,230 @@ func (s *SQLiteStore) DeleteSavedView(ctx context.Context, id string) error {
 	return err
 }
 
-// GetXCCredentials returns the stored XC credentials (there is at most one row).
-func (s *SQLiteStore) GetXCCredentials(ctx context.Context) (*XCCredentials, error) {
+// GetXCCredentials returns the XC credentials stored for scope, or nil if
+// there are none. It does not fall back to the global set.
+func (s *SQLiteStore) GetXCCredentials(ctx context.Context, scope string) (*XCCredentials, error) {
 	var c XCCredentials
 	err := s.db.QueryRowContext(ctx,
-		"SELECT id, tenant, api_token, namespace, created_at, updated_at FROM xc_credentials LIMIT 1",
-	).Scan(&c.ID, &c.Tenant, &c.APIToken, &c.Namespace, &c.CreatedAt, &c.UpdatedAt)
+		"SELECT id, tenant, api_token, namespace, scope, created_at, updated_at FROM xc_credentials WHERE scope = ?",
+		scope,
+	).Scan(&c.ID, &c.Tenant, &c.APIToken, &c.Namespace, &c.Scope, &c.CreatedAt, &c.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return &c, err
 }
 
-// SaveXCCredentials upserts XC credentials (replaces any existing row).
+// ListXCCredentials returns every stored credential set, the global set first.
+func (s *SQLiteStore) ListXCCredentials(ctx context.Context) ([]XCCredentials, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, tenant, api_token, namespace, scope, created_at, updated_at FROM xc_credentials ORDER BY scope",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var creds []XCCredentials
+	for rows.Next() {
+		var c XCCredentials
+		if err := rows.Scan(&c.ID, &c.Tenant, &c.APIToken, &c.Namespace, &c.Scope, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		creds = append(creds, c)
+	}
+	return creds, rows.Err()
+}
+
+// SaveXCCredentials upserts XC credentials, replacing the set with the same
+// scope.
 func (s *SQLiteStore) SaveXCCredentials(ctx context.Context, creds XCCredentials) error {
 	if creds.ID == "" {
 		creds.ID = uuid.NewString()
 	}
 	now := time.Now().UTC()
 
-	// Delete any existing row then insert (upsert).
-	_, _ = s.db.ExecContext(ctx, "DELETE FROM xc_credentials")
+	// Delete any existing row for the scope then insert (upsert).
+	_, _ = s.db.ExecContext(ctx, "DELETE FROM xc_credentials WHERE scope = ?", creds.Scope)
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO xc_credentials (id, tenant, api_token, namespace, scope, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		creds.ID, creds.Tenant, creds.APIToken, creds.Namespace, creds.Scope, now, now,
+	)
+	return err
+}
+
+// DeleteXCCredentials removes the XC credentials stored for scope.
+func (s *SQLiteStore) DeleteXCCredentials(ctx context.Context, scope string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM xc_credentials WHERE scope = ?", scope)
+	return err
+}
+
+// SaveMigrationImport stores an imported migration source.
+func (s *SQLiteStore) SaveMigrationImport(ctx context.Context, imp MigrationImport) error {
+	if imp.ID == "" {
+		imp.ID = uuid.NewString()
+	}
+	if imp.ResourcesJSON == "" {
+		imp.ResourcesJSON = "[]"
+	}
+	if imp.CreatedAt.IsZero() {
+		imp.CreatedAt = time.Now().UTC()
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO xc_credentials (id, tenant, api_token, namespace, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?)`,
-		creds.ID, creds.Tenant, creds.APIToken, creds.Namespace, now, now,
+		`INSERT INTO migration_imports (id, format, content, resources_json, created_at) VALUES (?, ?, ?, ?, ?)`,
+		imp.ID, imp.Format, imp.Content, imp.ResourcesJSON, imp.CreatedAt,
 	)
 	return err
 }
 
-// DeleteXCCredentials removes stored XC credentials.
-func (s *SQLiteStore) DeleteXCCredentials(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, "DELETE FROM xc_credentials")
+// GetMigrationImport returns an imported migration source by ID.
+func (s *SQLiteStore) GetMigrationImport(ctx context.Context, id string) (*MigrationImport, error) {
+	var imp MigrationImport
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, format, content, resources_json, created_at FROM migration_imports WHERE id = ?",
+		id,
+	).Scan(&imp.ID, &imp.Format, &imp.Content, &imp.ResourcesJSON, &imp.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &imp, err
+}
+
+// DeleteMigrationImportsBefore deletes imports created before cutoff, with
+// their apply progress, and returns how many imports were removed.
+func (s *SQLiteStore) DeleteMigrationImportsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM migration_apply_progress WHERE import_id IN (SELECT id FROM migration_imports WHERE created_at < ?)", cutoff.UTC()); err != nil {
+		return 0, err
+	}
+	res, err := s.db.ExecContext(ctx, "DELETE FROM migration_imports WHERE created_at < ?", cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// SaveMigrationApplyRecord inserts or replaces the apply outcome of one
+// resource of an import.
+func (s *SQLiteStore) SaveMigrationApplyRecord(ctx context.Context, rec MigrationApplyRecord) error {
+	if rec.UpdatedAt.IsZero() {
+		rec.UpdatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO migration_apply_progress (import_id, kind, namespace, name, status, error, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (import_id, kind, namespace, name)
+		 DO UPDATE SET status = excluded.status, error = excluded.error, updated_at = excluded.updated_at`,
+		rec.ImportID, rec.Kind, rec.Namespace, rec.Name, rec.Status, rec.Error, rec.UpdatedAt,
+	)
+	return err
+}
+
+// ListMigrationApplyRecords returns the recorded apply outcomes of an import,
+// ordered by resource.
+func (s *SQLiteStore) ListMigrationApplyRecords(ctx context.Context, importID string) ([]MigrationApplyRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT import_id, kind, namespace, name, status, error, updated_at FROM migration_apply_progress
+		 WHERE import_id = ? ORDER BY kind, namespace, name`,
+		importID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []MigrationApplyRecord
+	for rows.Next() {
+		var rec MigrationApplyRecord
+		if err := rows.Scan(&rec.ImportID, &rec.Kind, &rec.Namespace, &rec.Name, &rec.Status, &rec.Error, &rec.UpdatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// InsertHeartbeat records an agent heartbeat.
+func (s *SQLiteStore) InsertHeartbeat(ctx context.Context, hb HeartbeatRecord) error {
+	if hb.ID == "" {
+		hb.ID = uuid.NewString()
+	}
+	if hb.Timestamp.IsZero() {
+		hb.Timestamp = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO cluster_heartbeats (id, cluster, timestamp, phase, kubernetes_version, ngf_version, resource_counts_json, gpu_capacity_json)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		hb.ID, hb.Cluster, hb.Timestamp.UTC(), hb.Phase, hb.KubernetesVersion, hb.NGFVersion, hb.ResourceCountsJSON, hb.GPUCapacityJSON,
+	)
+	return err
+}
+
+// ListHeartbeats returns up to limit of a cluster's most recent heartbeats
+// received at or after since, oldest first.
+func (s *SQLiteStore) ListHeartbeats(ctx context.Context, cluster string, since time.Time, limit int) ([]HeartbeatRecord, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, cluster, timestamp, phase, kubernetes_version, ngf_version, resource_counts_json, gpu_capacity_json FROM cluster_heartbeats
+		 WHERE cluster = ? AND timestamp >= ? ORDER BY timestamp DESC LIMIT ?`,
+		cluster, since.UTC(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []HeartbeatRecord
+	for rows.Next() {
+		var hb HeartbeatRecord
+		if err := rows.Scan(&hb.ID, &hb.Cluster, &hb.Timestamp, &hb.Phase, &hb.KubernetesVersion, &hb.NGFVersion, &hb.ResourceCountsJSON, &hb.GPUCapacityJSON); err != nil {
+			return nil, err
+		}
+		records = append(records, hb)
+	}
+	slices.Reverse(records)
+	return records, rows.Err()
+}
+
+// DeleteHeartbeatsBefore deletes heartbeats received before cutoff and returns
+// how many were removed.
+func (s *SQLiteStore) DeleteHeartbeatsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM cluster_heartbeats WHERE timestamp < ?", cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// GetClusterToken returns a cluster's heartbeat token, or nil if it has none.
+func (s *SQLiteStore) GetClusterToken(ctx context.Context, cluster string) (*ClusterToken, error) {
+	var t ClusterToken
+	err := s.db.QueryRowContext(ctx,
+		"SELECT cluster, token_hash, created_at FROM cluster_tokens WHERE cluster = ?",
+		cluster,
+	).Scan(&t.Cluster, &t.TokenHash, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &t, err
+}
+
+// SaveClusterToken sets a cluster's heartbeat token, replacing any previous
+// one.
+func (s *SQLiteStore) SaveClusterToken(ctx context.Context, token ClusterToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO cluster_tokens (cluster, token_hash, created_at) VALUES (?, ?, ?)
+		 ON CONFLICT (cluster) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at`,
+		token.Cluster, token.TokenHash, token.CreatedAt,
+	)
+	return err
+}
+
+// DeleteClusterToken removes a cluster's heartbeat token.
+func (s *SQLiteStore) DeleteClusterToken(ctx context.Context, cluster string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM cluster_tokens WHERE cluster = ?", cluster)
 	return err
 }
 
@@ -292,7 +537,10 @@ CREATE TABLE IF NOT EXISTS audit_log (
 	namespace TEXT NOT NULL DEFAULT '',
 	cluster TEXT NOT NULL DEFAULT '',
 	before_json TEXT NOT NULL DEFAULT '',
-	after_json TEXT NOT NULL DEFAULT ''
+	after_json TEXT NOT NULL DEFAULT '',
+	outcome TEXT NOT NULL DEFAULT 'success',
+	error TEXT NOT NULL DEFAULT '',
+	request_id TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_audit_timestamp ON audit_log(timestamp);
@@ -353,7 +601,48 @@ CREATE TABLE IF NOT EXISTS xc_credentials (
 	tenant TEXT NOT NULL,
 	api_token TEXT NOT NULL,
 	namespace TEXT NOT NULL DEFAULT 'default',
+	scope TEXT NOT NULL DEFAULT '',
 	created_at DATETIME NOT NULL,
 	updated_at DATETIME NOT NULL
 );
+
+CREATE TABLE IF NOT EXISTS migration_imports (
+	id TEXT PRIMARY KEY,
+	format TEXT NOT NULL,
+	content TEXT NOT NULL,
+	resources_json TEXT NOT NULL DEFAULT '[]',
+	created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_migration_imports_created ON migration_imports(created_at);
+
+CREATE TABLE IF NOT EXISTS migration_apply_progress (
+	import_id TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	updated_at DATETIME NOT NULL,
+	PRIMARY KEY (import_id, kind, namespace, name)
+);
+
+CREATE TABLE IF NOT EXISTS cluster_heartbeats (
+	id TEXT PRIMARY KEY,
+	cluster TEXT NOT NULL,
+	timestamp DATETIME NOT NULL,
+	phase TEXT NOT NULL DEFAULT '',
+	kubernetes_version TEXT NOT NULL DEFAULT '',
+	ngf_version TEXT NOT NULL DEFAULT '',
+	resource_counts_json TEXT NOT NULL DEFAULT '',
+	gpu_capacity_json TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_cluster_heartbeats_cluster_time ON cluster_heartbeats(cluster, timestamp);
+
+CREATE TABLE IF NOT EXISTS cluster_tokens (
+	cluster TEXT PRIMARY KEY,
+	token_hash TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
 `
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStore_XCCredentialScopes(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLite(t)
+
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "shared", APIToken: "t1", Namespace: "default"}); err != nil {
+		t.Fatalf("save global: %v", err)
+	}
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "team-a", APIToken: "t2", Namespace: "a", Scope: "team-a"}); err != nil {
+		t.Fatalf("save scoped: %v", err)
+	}
+	// Saving a scope again replaces only that scope's set.
+	if err := store.SaveXCCredentials(ctx, XCCredentials{Tenant: "team-a2", APIToken: "t3", Namespace: "a", Scope: "team-a"}); err != nil {
+		t.Fatalf("replace scoped: %v", err)
+	}
+
+	global, err := store.GetXCCredentials(ctx, "")
+	if err != nil || global == nil || global.Tenant != "shared" {
+		t.Fatalf("global credentials = %+v, %v", global, err)
+	}
+	scoped, err := store.GetXCCredentials(ctx, "team-a")
+	if err != nil || scoped == nil || scoped.Tenant != "team-a2" || scoped.Scope != "team-a" {
+		t.Fatalf("scoped credentials = %+v, %v", scoped, err)
+	}
+	if missing, err := store.GetXCCredentials(ctx, "team-b"); err != nil || missing != nil {
+		t.Errorf("expected no credentials for team-b, got %+v, %v", missing, err)
+	}
+
+	list, err := store.ListXCCredentials(ctx)
+	if err != nil {
+		t.Fatalf("ListXCCredentials: %v", err)
+	}
+	if len(list) != 2 || list[0].Scope != "" || list[1].Scope != "team-a" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+
+	if err := store.DeleteXCCredentials(ctx, "team-a"); err != nil {
+		t.Fatalf("DeleteXCCredentials: %v", err)
+	}
+	if global, _ := store.GetXCCredentials(ctx, ""); global == nil {
+		t.Error("deleting a scope removed the global set")
+	}
+}
+
+func TestSQLiteStore_MigrateAddsScope(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLite(t)
+
+	// Recreate xc_credentials as it was before scope was added.
+	if _, err := store.db.ExecContext(ctx, `DROP TABLE xc_credentials;
+CREATE TABLE xc_credentials (
+	id TEXT PRIMARY KEY,
+	tenant TEXT NOT NULL,
+	api_token TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT 'default',
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+INSERT INTO xc_credentials VALUES ('1', 'acme', 'token', 'default', '2024-01-01', '2024-01-01');`); err != nil {
+		t.Fatalf("creating legacy table: %v", err)
+	}
+
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	creds, err := store.GetXCCredentials(ctx, "")
+	if err != nil || creds == nil || creds.Tenant != "acme" {
+		t.Errorf("expected the existing row to become the global set, got %+v, %v", creds, err)
+	}
+}
+
+func TestSQLiteStore_AuditEntries(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLite(t)
+
+	// Recreate audit_log as it was before outcomes were recorded.
+	if _, err := store.db.ExecContext(ctx, `DROP TABLE audit_log;
+CREATE TABLE audit_log (
+	id TEXT PRIMARY KEY,
+	timestamp DATETIME NOT NULL,
+	user TEXT NOT NULL DEFAULT '',
+	action TEXT NOT NULL,
+	resource TEXT NOT NULL,
+	name TEXT NOT NULL,
+	namespace TEXT NOT NULL DEFAULT '',
+	cluster TEXT NOT NULL DEFAULT '',
+	before_json TEXT NOT NULL DEFAULT '',
+	after_json TEXT NOT NULL DEFAULT ''
+);
+INSERT INTO audit_log (id, timestamp, action, resource, name) VALUES ('old', '2026-01-15 09:00:00+00:00', 'create', 'Gateway', 'gw');`); err != nil {
+		t.Fatalf("creating legacy table: %v", err)
+	}
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	for _, e := range []AuditEntry{
+		{ID: "a", Timestamp: base, User: "alice", Cluster: "west", Action: "create", Resource: "Gateway", Name: "gw", RequestID: "req-a"},
+		{ID: "b", Timestamp: base.Add(time.Minute), User: "bob", Cluster: "west", Action: "delete", Resource: "Gateway", Name: "gw",
+			Outcome: AuditFailure, Error: "role \"Operator\" required"},
+		{ID: "c", Timestamp: base.Add(2 * time.Minute), User: "alice", Cluster: "east", Action: "create", Resource: "HTTPRoute", Name: "rt"},
+	} {
+		if err := store.InsertAuditEntry(ctx, e); err != nil {
+			t.Fatalf("InsertAuditEntry: %v", err)
+		}
+	}
+
+	old, err := store.GetAuditEntry(ctx, "old")
+	if err != nil || old == nil || old.Outcome != AuditSuccess {
+		t.Errorf("expected the legacy row to be a success, got %+v, %v", old, err)
+	}
+	a, err := store.GetAuditEntry(ctx, "a")
+	if err != nil || a == nil || a.Outcome != AuditSuccess || a.RequestID != "req-a" {
+		t.Errorf("expected entry a to default to success and keep its request ID, got %+v, %v", a, err)
+	}
+
+	failed, total, err := store.ListAuditEntries(ctx, AuditListOptions{Outcome: AuditFailure})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if total != 1 || len(failed) != 1 || failed[0].ID != "b" || failed[0].Error == "" {
+		t.Errorf("expected only the failed entry b with its error, got %d %+v", total, failed)
+	}
+
+	until := base.Add(2 * time.Minute)
+	west, total, err := store.ListAuditEntries(ctx, AuditListOptions{Cluster: "west", Since: &base, Until: &until})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if total != 2 || len(west) != 2 || west[0].ID != "b" || west[1].ID != "a" {
+		t.Errorf("expected west entries b then a, got %d %+v", total, west)
+	}
+}
+
+func TestSQLiteStore_Heartbeats(t *testing.T) {
+	ctx := context.Background()
+	store := newTestSQLite(t)
+
+	base := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		if err := store.InsertHeartbeat(ctx, HeartbeatRecord{
+			Cluster: "west", Timestamp: base.Add(time.Duration(i) * time.Minute), Phase: "Ready", KubernetesVersion: "v1.31.2",
+			ResourceCountsJSON: fmt.Sprintf(`{"gateways":%d}`, i),
+		}); err != nil {
+			t.Fatalf("InsertHeartbeat: %v", err)
+		}
+	}
+	if err := store.InsertHeartbeat(ctx, HeartbeatRecord{Cluster: "east", Timestamp: base, Phase: "Ready"}); err != nil {
+		t.Fatalf("InsertHeartbeat: %v", err)
+	}
+
+	got, err := store.ListHeartbeats(ctx, "west", base.Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ListHeartbeats: %v", err)
+	}
+	if len(got) != 3 || !got[0].Timestamp.Equal(base.Add(time.Minute)) || got[2].ResourceCountsJSON != `{"gateways":3}` {
+		t.Fatalf("expected the last 3 west heartbeats oldest first, got %+v", got)
+	}
+
+	// The limit keeps the most recent records.
+	got, err = store.ListHeartbeats(ctx, "west", base, 2)
+	if err != nil {
+		t.Fatalf("ListHeartbeats: %v", err)
+	}
+	if len(got) != 2 || !got[0].Timestamp.Equal(base.Add(2*time.Minute)) || !got[1].Timestamp.Equal(base.Add(3*time.Minute)) {
+		t.Fatalf("expected the 2 most recent heartbeats, got %+v", got)
+	}
+
+	n, err := store.DeleteHeartbeatsBefore(ctx, base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("DeleteHeartbeatsBefore: %v", err)
+	}
+	if n != 3 { // two west and one east
+		t.Errorf("expected 3 heartbeats deleted, got %d", n)
+	}
+}
@@ -15,7 +15,7 @@ type AuditHandler struct {
 	Store database.Store
 }
 
-// List returns paginated audit log entries.
+// List returns paginated audit log entries, newest first.
 func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
 	if h.Store == nil {
 		writeError(w, http.StatusServiceUnavailable, "audit store not configured")
@@ -27,6 +27,12 @@ func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
 		Action:    r.URL.Query().Get("action"),
 		User:      r.URL.Query().Get("user"),
 		Namespace: r.URL.Query().Get("namespace"),
+		Cluster:   r.URL.Query().Get("cluster"),
+		Outcome:   r.URL.Query().Get("outcome"),
+	}
+	if opts.Outcome != "" && opts.Outcome != database.AuditSuccess && opts.Outcome != database.AuditFailure {
+		writeError(w, http.StatusBadRequest, "outcome must be success or failure")
+		return
 	}
 
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
@@ -39,10 +45,21 @@ func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
 			opts.Offset = v
 		}
 	}
-	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
-		if t, err := time.Parse(time.RFC3339, sinceStr); err == nil {
-			opts.Since = &t
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "since must be an RFC 3339 time")
+			return
+		}
+		opts.Since = &t
+	}
+	if v := r.URL.Query().Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "until must be an RFC 3339 time")
+			return
 		}
+		opts.Until = &t
 	}
 
 	entries, total, err := h.Store.ListAuditEntries(r.Context(), opts)
@@ -3,17 +3,15 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
-	"time"
-
-	"github.com/google/uuid"
 
+	"github.com/kubenetlabs/ngc/api/internal/audit"
 	"github.com/kubenetlabs/ngc/api/internal/database"
 )
 
-// auditLog records an audit entry for a CRUD operation. It is fire-and-forget:
-// errors are logged but never returned to the caller, so audit logging cannot
-// break the primary request flow.
+// auditLog records a successful CRUD operation with its before/after state.
+// It is fire-and-forget: errors are logged but never returned to the caller,
+// so audit logging cannot break the primary request flow. The caller, cluster
+// and request ID come from ctx.
 func auditLog(store database.Store, ctx context.Context, action, resource, name, namespace string, before, after any) {
 	if store == nil {
 		return
@@ -31,18 +29,8 @@ func auditLog(store database.Store, ctx context.Context, action, resource, name,
 		}
 	}
 
-	entry := database.AuditEntry{
-		ID:         uuid.NewString(),
-		Timestamp:  time.Now().UTC(),
-		Action:     action,
-		Resource:   resource,
-		Name:       name,
-		Namespace:  namespace,
-		BeforeJSON: beforeJSON,
-		AfterJSON:  afterJSON,
-	}
-
-	if err := store.InsertAuditEntry(ctx, entry); err != nil {
-		slog.Error("failed to insert audit entry", "error", err, "action", action, "resource", resource, "name", name)
-	}
+	entry := audit.NewEntry(ctx, action, resource, name, namespace)
+	entry.BeforeJSON = beforeJSON
+	entry.AfterJSON = afterJSON
+	audit.Record(ctx, store, entry)
 }
@@ -148,6 +148,19 @@ func (h *CertificateHandler) Expiring(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, expiring)
 }
 
+// parseLeafCertificate decodes the first certificate in a TLS Secret's tls.crt.
+func parseLeafCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	certData, ok := secret.Data["tls.crt"]
+	if !ok {
+		return nil, fmt.Errorf("secret has no tls.crt")
+	}
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return nil, fmt.Errorf("tls.crt is not PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
 func parseTLSSecret(secret *corev1.Secret) (CertificateResponse, bool) {
 	resp := CertificateResponse{
 		Name:      secret.Name,
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// clusterTokenBytes is the length of a generated heartbeat token before
+// encoding.
+const clusterTokenBytes = 32
+
+// ClusterTokenResponse is returned when a heartbeat token is issued. The
+// token is only ever shown here; the store keeps a hash.
+type ClusterTokenResponse struct {
+	Cluster string `json:"cluster"`
+	Token   string `json:"token"`
+}
+
+// hashClusterToken returns the hex-encoded SHA-256 the store keeps for token.
+func hashClusterToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueClusterToken generates a heartbeat token for a cluster, replacing any
+// previous one, and returns it.
+func (h *ClusterHandler) issueClusterToken(ctx context.Context, cluster string) (string, error) {
+	b := make([]byte, clusterTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := h.Store.SaveClusterToken(ctx, database.ClusterToken{Cluster: cluster, TokenHash: hashClusterToken(token)}); err != nil {
+		return "", fmt.Errorf("saving token: %w", err)
+	}
+	return token, nil
+}
+
+// RotateToken issues a new heartbeat token for a cluster. The old token stops
+// working at once, so the agent must be reconfigured with the new one.
+func (h *ClusterHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	if h.Store == nil {
+		writeError(w, http.StatusServiceUnavailable, "cluster token store not configured")
+		return
+	}
+
+	name := chi.URLParam(r, "cluster")
+	if !validClusterName.MatchString(name) {
+		writeError(w, http.StatusBadRequest, "invalid cluster name")
+		return
+	}
+	if h.Pool != nil {
+		if _, ok := h.Pool.Lookup(name); !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("cluster %q not found", name))
+			return
+		}
+	}
+
+	token, err := h.issueClusterToken(r.Context(), name)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to rotate cluster token", "cluster", name, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to rotate cluster token")
+		return
+	}
+	slog.InfoContext(r.Context(), "cluster heartbeat token rotated", "cluster", name)
+
+	writeJSON(w, http.StatusOK, ClusterTokenResponse{Cluster: name, Token: token})
+}
+
+// authenticateHeartbeat checks the request's bearer token against the
+// cluster's stored token and writes a 401 if it does not match. A cluster
+// without a stored token is accepted unless RequireHeartbeatToken is set, so
+// agents registered before tokens existed keep working until one is issued.
+func (h *ClusterHandler) authenticateHeartbeat(w http.ResponseWriter, r *http.Request, cluster string) bool {
+	if h.Store == nil {
+		return true
+	}
+
+	stored, err := h.Store.GetClusterToken(r.Context(), cluster)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "failed to read cluster token", "cluster", cluster, "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to verify heartbeat token")
+		return false
+	}
+	if stored == nil {
+		if h.RequireHeartbeatToken {
+			writeError(w, http.StatusUnauthorized, "no heartbeat token issued for cluster")
+			return false
+		}
+		return true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeError(w, http.StatusUnauthorized, "missing bearer token")
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(hashClusterToken(token)), []byte(stored.TokenHash)) != 1 {
+		slog.WarnContext(r.Context(), "heartbeat rejected: invalid token", "cluster", cluster)
+		writeError(w, http.StatusUnauthorized, "invalid heartbeat token")
+		return false
+	}
+	return true
+}
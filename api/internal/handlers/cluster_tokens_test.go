@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kubenetlabs/ngc/api/internal/multicluster"
+)
+
+func TestClusterHandler_RotateTokenAndAuthenticate(t *testing.T) {
+	store := newMigrationTestStore(t)
+	handler := &ClusterHandler{
+		Store: store,
+		Pool:  multicluster.NewPoolForTest(&multicluster.ClusterClient{Name: "west"}, &multicluster.ClusterClient{Name: "legacy"}),
+	}
+
+	r := chi.NewRouter()
+	r.Post("/clusters/{cluster}/rotate-token", handler.RotateToken)
+	rotate := func(cluster string) (*httptest.ResponseRecorder, string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/clusters/"+cluster+"/rotate-token", nil))
+		var resp ClusterTokenResponse
+		_ = json.NewDecoder(w.Body).Decode(&resp)
+		return w, resp.Token
+	}
+
+	if w, _ := rotate("missing"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unregistered cluster, got %d", w.Code)
+	}
+	w, oldToken := rotate("west")
+	if w.Code != http.StatusOK || oldToken == "" {
+		t.Fatalf("expected a token, got %d: %s", w.Code, w.Body.String())
+	}
+	_, newToken := rotate("west")
+	if newToken == "" || newToken == oldToken {
+		t.Fatalf("expected rotation to issue a different token, got %q", newToken)
+	}
+
+	tests := []struct {
+		name    string
+		cluster string
+		header  string
+		require bool
+		want    bool
+	}{
+		{name: "current token", cluster: "west", header: "Bearer " + newToken, want: true},
+		{name: "rotated-out token", cluster: "west", header: "Bearer " + oldToken},
+		{name: "missing header", cluster: "west"},
+		{name: "not a bearer token", cluster: "west", header: newToken},
+		{name: "no token issued, header ignored", cluster: "legacy", header: "Bearer " + newToken, want: true},
+		{name: "no token issued", cluster: "legacy", want: true},
+		{name: "no token issued, required", cluster: "legacy", require: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.RequireHeartbeatToken = tt.require
+			req := httptest.NewRequest(http.MethodPost, "/clusters/"+tt.cluster+"/heartbeat", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			if got := handler.authenticateHeartbeat(w, req, tt.cluster); got != tt.want {
+				t.Fatalf("authenticateHeartbeat = %v, want %v", got, tt.want)
+			}
+			if !tt.want && w.Code != http.StatusUnauthorized {
+				t.Errorf("expected status 401, got %d", w.Code)
+			}
+		})
+	}
+}
@@ -1,27 +1,52 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"regexp"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
 	"github.com/kubenetlabs/ngc/api/internal/multicluster"
 )
 
 // validClusterName matches valid Kubernetes resource names (RFC 1123 DNS subdomain).
 var validClusterName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
 
+// heartbeatHistoryTTL is how long heartbeat records are kept for trends.
+const heartbeatHistoryTTL = 7 * 24 * time.Hour
+
+// heartbeatPurgeInterval is the least time between heartbeat history purges,
+// so a fleet of agents heartbeating every 30s doesn't purge on every request.
+const heartbeatPurgeInterval = time.Hour
+
+// Limits for GET /clusters/{cluster}/heartbeats.
+const (
+	defaultHeartbeatWindow = 24 * time.Hour
+	defaultHeartbeatLimit  = 500
+	maxHeartbeatLimit      = 5000
+)
+
 // ClusterHandler handles cluster management API requests.
 type ClusterHandler struct {
 	Manager cluster.Provider
 	Pool    *multicluster.ClientPool // non-nil only in CRD-based multi-cluster mode
+	Store   database.Store           // heartbeat history and tokens; nil disables both
+
+	// RequireHeartbeatToken rejects heartbeats from clusters that have no
+	// token issued, instead of accepting them unauthenticated.
+	RequireHeartbeatToken bool
+
+	lastHeartbeatPurge atomic.Int64 // unix nanoseconds
 }
 
 // ClusterResponse represents a cluster in the API response.
@@ -49,6 +74,30 @@ type ClusterDetailResponse struct {
 	ResourceCounts    *multicluster.ResourceCounts  `json:"resourceCounts,omitempty"`
 	GPUCapacity       *multicluster.GPUCapacitySummary `json:"gpuCapacity,omitempty"`
 	IsLocal           bool                   `json:"isLocal"`
+
+	// Status and LastHealthCheck come from the hub's health checker. Stale
+	// is set once the agent has stopped heartbeating.
+	Status          string  `json:"status"`
+	LastHealthCheck *string `json:"lastHealthCheck,omitempty"`
+	Stale           bool    `json:"stale"`
+}
+
+// Cluster health statuses.
+const (
+	ClusterReachable     = "reachable"
+	ClusterUnreachable   = "unreachable"
+	ClusterHealthUnknown = "unknown" // not health-checked yet
+)
+
+// ClusterHealthResponse is the body returned by GET /clusters/{cluster}/health.
+type ClusterHealthResponse struct {
+	Name              string  `json:"name"`
+	Status            string  `json:"status"` // reachable, unreachable, or unknown
+	LastHealthCheck   *string `json:"lastHealthCheck,omitempty"`
+	LastHeartbeat     *string `json:"lastHeartbeat,omitempty"`
+	Stale             bool    `json:"stale"` // the agent stopped heartbeating
+	KubernetesVersion string  `json:"kubernetesVersion,omitempty"`
+	CircuitBreaker    string  `json:"circuitBreaker"` // closed, open, or half-open
 }
 
 // RegisterClusterRequest is the payload for registering a new cluster.
@@ -67,6 +116,20 @@ type HeartbeatRequest struct {
 	NGFVersion        string                          `json:"ngfVersion"`
 	ResourceCounts    *multicluster.ResourceCounts     `json:"resourceCounts,omitempty"`
 	GPUCapacity       *multicluster.GPUCapacitySummary `json:"gpuCapacity,omitempty"`
+	// Errors lists resource kinds the agent could not discover. A non-empty
+	// list marks the cluster Degraded rather than Ready.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// HeartbeatRecordResponse is one stored heartbeat returned by
+// GET /clusters/{cluster}/heartbeats.
+type HeartbeatRecordResponse struct {
+	Timestamp         time.Time                        `json:"timestamp"`
+	Phase             string                           `json:"phase"`
+	KubernetesVersion string                           `json:"kubernetesVersion,omitempty"`
+	NGFVersion        string                           `json:"ngfVersion,omitempty"`
+	ResourceCounts    *multicluster.ResourceCounts     `json:"resourceCounts,omitempty"`
+	GPUCapacity       *multicluster.GPUCapacitySummary `json:"gpuCapacity,omitempty"`
 }
 
 // ClusterSummaryResponse provides a global summary across all clusters.
@@ -120,6 +183,9 @@ func (h *ClusterHandler) listFromPool(w http.ResponseWriter, r *http.Request) {
 			GPUCapacity:       cc.GPUCapacity,
 			IsLocal:           cc.IsLocal,
 		}
+		health := cc.Health()
+		detail.Status, detail.LastHealthCheck = clusterHealth(health)
+		detail.LastHeartbeat, detail.Stale = rfc3339OrNil(health.LastHeartbeat), health.Stale
 		if cc.K8sClient != nil {
 			detail.Edition = string(cc.K8sClient.DetectEdition(r.Context()))
 		}
@@ -158,6 +224,9 @@ func (h *ClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 		GPUCapacity:       cc.GPUCapacity,
 		IsLocal:           cc.IsLocal,
 	}
+	health := cc.Health()
+	detail.Status, detail.LastHealthCheck = clusterHealth(health)
+	detail.LastHeartbeat, detail.Stale = rfc3339OrNil(health.LastHeartbeat), health.Stale
 	if cc.K8sClient != nil {
 		detail.Edition = string(cc.K8sClient.DetectEdition(r.Context()))
 	}
@@ -165,6 +234,59 @@ func (h *ClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, detail)
 }
 
+// Health reports the hub health checker's view of a single cluster. Unlike
+// the other cluster endpoints it answers for clusters whose circuit breaker
+// is open, since those are the ones worth asking about.
+func (h *ClusterHandler) Health(w http.ResponseWriter, r *http.Request) {
+	if h.Pool == nil {
+		writeError(w, http.StatusNotImplemented, "cluster health requires CRD-based multi-cluster mode")
+		return
+	}
+
+	name := chi.URLParam(r, "cluster")
+	cc, ok := h.Pool.Lookup(name)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("cluster %q not found", name))
+		return
+	}
+
+	health := cc.Health()
+	resp := ClusterHealthResponse{
+		Name:              cc.Name,
+		LastHeartbeat:     rfc3339OrNil(health.LastHeartbeat),
+		Stale:             health.Stale,
+		KubernetesVersion: health.K8sVersion,
+		CircuitBreaker:    multicluster.StateClosed.String(),
+	}
+	resp.Status, resp.LastHealthCheck = clusterHealth(health)
+	if cc.CircuitBreaker != nil {
+		resp.CircuitBreaker = cc.CircuitBreaker.State().String()
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// clusterHealth converts a health snapshot to its API status and RFC 3339
+// check time.
+func clusterHealth(h multicluster.HealthSnapshot) (status string, lastCheck *string) {
+	if h.LastHealthCheck.IsZero() {
+		return ClusterHealthUnknown, nil
+	}
+	if h.Healthy {
+		return ClusterReachable, rfc3339OrNil(h.LastHealthCheck)
+	}
+	return ClusterUnreachable, rfc3339OrNil(h.LastHealthCheck)
+}
+
+// rfc3339OrNil formats t in UTC, or returns nil for the zero time.
+func rfc3339OrNil(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	ts := t.UTC().Format(time.RFC3339)
+	return &ts
+}
+
 // Register creates a new ManagedCluster CRD and kubeconfig Secret.
 func (h *ClusterHandler) Register(w http.ResponseWriter, r *http.Request) {
 	if h.Pool == nil {
@@ -218,7 +340,7 @@ func (h *ClusterHandler) Register(w http.ResponseWriter, r *http.Request) {
 			},
 		}
 		if err := h.Pool.CreateOrUpdateRaw(r.Context(), "v1", "secrets", secretObj); err != nil {
-			slog.Error("failed to create kubeconfig secret", "cluster", req.Name, "error", err)
+			slog.ErrorContext(r.Context(), "failed to create kubeconfig secret", "cluster", req.Name, "error", err)
 			writeError(w, http.StatusInternalServerError, "failed to create kubeconfig secret")
 			return
 		}
@@ -243,22 +365,33 @@ func (h *ClusterHandler) Register(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 	if err := h.Pool.CreateManagedCluster(r.Context(), mcObj); err != nil {
-		slog.Error("failed to create ManagedCluster", "cluster", req.Name, "error", err)
+		slog.ErrorContext(r.Context(), "failed to create ManagedCluster", "cluster", req.Name, "error", err)
 		writeError(w, http.StatusInternalServerError, "failed to register cluster")
 		return
 	}
 
 	// Trigger pool sync to pick up the new cluster.
 	if err := h.Pool.Sync(r.Context()); err != nil {
-		slog.Error("failed to sync pool after registration", "cluster", req.Name, "error", err)
+		slog.ErrorContext(r.Context(), "failed to sync pool after registration", "cluster", req.Name, "error", err)
 		writeError(w, http.StatusInternalServerError, "cluster registered but sync failed")
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, map[string]string{
+	resp := map[string]string{
 		"message": "cluster registered",
 		"name":    req.Name,
-	})
+	}
+	if h.Store != nil {
+		token, err := h.issueClusterToken(r.Context(), req.Name)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "failed to issue heartbeat token after registration", "cluster", req.Name, "error", err)
+			writeError(w, http.StatusInternalServerError, "cluster registered but issuing its heartbeat token failed")
+			return
+		}
+		resp["token"] = token
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
 }
 
 // Unregister removes a ManagedCluster CRD and its kubeconfig Secret.
@@ -276,7 +409,7 @@ func (h *ClusterHandler) Unregister(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Pool.DeleteManagedCluster(r.Context(), name); err != nil {
-		slog.Error("failed to delete ManagedCluster", "cluster", name, "error", err)
+		slog.ErrorContext(r.Context(), "failed to delete ManagedCluster", "cluster", name, "error", err)
 		writeError(w, http.StatusInternalServerError, "failed to unregister cluster")
 		return
 	}
@@ -284,6 +417,13 @@ func (h *ClusterHandler) Unregister(w http.ResponseWriter, r *http.Request) {
 	// Also delete the kubeconfig secret.
 	_ = h.Pool.DeleteRaw(r.Context(), "v1", "secrets", name+"-kubeconfig")
 
+	// And its heartbeat token, so a re-registered cluster gets a new one.
+	if h.Store != nil {
+		if err := h.Store.DeleteClusterToken(r.Context(), name); err != nil {
+			slog.WarnContext(r.Context(), "failed to delete cluster token", "cluster", name, "error", err)
+		}
+	}
+
 	// Re-sync pool to remove the client.
 	_ = h.Pool.Sync(r.Context())
 
@@ -356,6 +496,9 @@ func (h *ClusterHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 	}
 
 	name := chi.URLParam(r, "cluster")
+	if !h.authenticateHeartbeat(w, r, name) {
+		return
+	}
 
 	var req HeartbeatRequest
 	decoder := json.NewDecoder(io.LimitReader(r.Body, 64*1024)) // 64KB limit for heartbeat
@@ -373,9 +516,15 @@ func (h *ClusterHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 	// Update in-memory state (protected by ClusterClient mutex).
 	cc.SetHeartbeat(req.KubernetesVersion, req.NGFVersion, req.ResourceCounts, req.GPUCapacity)
 
+	phase := multicluster.ClusterPhaseReady
+	if len(req.Errors) > 0 {
+		phase = multicluster.ClusterPhaseDegraded
+		slog.WarnContext(r.Context(), "heartbeat reported degraded discovery", "cluster", name, "errors", req.Errors)
+	}
+
 	// Update CRD status on hub.
 	status := map[string]interface{}{
-		"phase":             "Ready",
+		"phase":             string(phase),
 		"kubernetesVersion": req.KubernetesVersion,
 		"ngfVersion":        req.NGFVersion,
 		"agentInstalled":    true,
@@ -388,8 +537,10 @@ func (h *ClusterHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 		status["gpuCapacity"] = req.GPUCapacity
 	}
 
+	h.recordHeartbeat(r.Context(), name, phase, req)
+
 	if err := h.Pool.UpdateStatus(r.Context(), name, status); err != nil {
-		slog.Error("failed to update cluster status", "cluster", name, "error", err)
+		slog.ErrorContext(r.Context(), "failed to update cluster status", "cluster", name, "error", err)
 		writeError(w, http.StatusInternalServerError, "failed to update cluster status")
 		return
 	}
@@ -397,6 +548,113 @@ func (h *ClusterHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+// recordHeartbeat stores a heartbeat in the history and purges expired
+// records. Failures are logged and do not fail the heartbeat, since the
+// in-memory and ManagedCluster state are already updated.
+func (h *ClusterHandler) recordHeartbeat(ctx context.Context, name string, phase multicluster.ManagedClusterPhase, req HeartbeatRequest) {
+	if h.Store == nil {
+		return
+	}
+
+	rec := database.HeartbeatRecord{
+		Cluster:           name,
+		Timestamp:         time.Now().UTC(),
+		Phase:             string(phase),
+		KubernetesVersion: req.KubernetesVersion,
+		NGFVersion:        req.NGFVersion,
+	}
+	if req.ResourceCounts != nil {
+		b, _ := json.Marshal(req.ResourceCounts)
+		rec.ResourceCountsJSON = string(b)
+	}
+	if req.GPUCapacity != nil {
+		b, _ := json.Marshal(req.GPUCapacity)
+		rec.GPUCapacityJSON = string(b)
+	}
+	if err := h.Store.InsertHeartbeat(ctx, rec); err != nil {
+		slog.WarnContext(ctx, "failed to record heartbeat", "cluster", name, "error", err)
+	}
+
+	last := h.lastHeartbeatPurge.Load()
+	if time.Since(time.Unix(0, last)) < heartbeatPurgeInterval || !h.lastHeartbeatPurge.CompareAndSwap(last, time.Now().UnixNano()) {
+		return
+	}
+	n, err := h.Store.DeleteHeartbeatsBefore(ctx, time.Now().Add(-heartbeatHistoryTTL))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to purge expired heartbeats", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.InfoContext(ctx, "purged expired heartbeats", "count", n)
+	}
+}
+
+// Heartbeats returns a cluster's stored heartbeats, oldest first, for trend
+// charts. ?since= is an RFC 3339 time (default 24 hours ago) and ?limit= caps
+// the records returned, keeping the most recent.
+func (h *ClusterHandler) Heartbeats(w http.ResponseWriter, r *http.Request) {
+	if h.Store == nil {
+		writeError(w, http.StatusServiceUnavailable, "heartbeat history store not configured")
+		return
+	}
+
+	name := chi.URLParam(r, "cluster")
+	if !validClusterName.MatchString(name) {
+		writeError(w, http.StatusBadRequest, "invalid cluster name")
+		return
+	}
+
+	since := time.Now().Add(-defaultHeartbeatWindow)
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "since must be an RFC 3339 time")
+			return
+		}
+		since = t
+	}
+	limit := defaultHeartbeatLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxHeartbeatLimit {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxHeartbeatLimit))
+			return
+		}
+		limit = n
+	}
+
+	records, err := h.Store.ListHeartbeats(r.Context(), name, since, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := make([]HeartbeatRecordResponse, 0, len(records))
+	for _, rec := range records {
+		item := HeartbeatRecordResponse{
+			Timestamp:         rec.Timestamp.UTC(),
+			Phase:             rec.Phase,
+			KubernetesVersion: rec.KubernetesVersion,
+			NGFVersion:        rec.NGFVersion,
+		}
+		if rec.ResourceCountsJSON != "" {
+			item.ResourceCounts = &multicluster.ResourceCounts{}
+			if err := json.Unmarshal([]byte(rec.ResourceCountsJSON), item.ResourceCounts); err != nil {
+				item.ResourceCounts = nil
+			}
+		}
+		if rec.GPUCapacityJSON != "" {
+			item.GPUCapacity = &multicluster.GPUCapacitySummary{}
+			if err := json.Unmarshal([]byte(rec.GPUCapacityJSON), item.GPUCapacity); err != nil {
+				item.GPUCapacity = nil
+			}
+		}
+		resp = append(resp, item)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
 // Summary returns a global summary across all clusters.
 func (h *ClusterHandler) Summary(w http.ResponseWriter, r *http.Request) {
 	if h.Pool == nil {
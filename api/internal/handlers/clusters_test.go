@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kubenetlabs/ngc/api/internal/multicluster"
+)
+
+func TestClusterHandler_Health(t *testing.T) {
+	checked := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	openBreaker := multicluster.NewCircuitBreaker(1, time.Hour)
+	openBreaker.RecordFailure()
+
+	pool := multicluster.NewPoolForTest(
+		&multicluster.ClusterClient{Name: "east", Healthy: true, LastHealthCheck: checked, K8sVersion: "v1.31.2", CircuitBreaker: multicluster.NewCircuitBreaker(3, time.Hour)},
+		&multicluster.ClusterClient{Name: "west", LastHealthCheck: checked, K8sVersion: "v1.30.4", CircuitBreaker: openBreaker},
+		&multicluster.ClusterClient{Name: "new"},
+	)
+	handler := &ClusterHandler{Pool: pool}
+
+	r := chi.NewRouter()
+	r.Get("/clusters/{cluster}/health", handler.Health)
+
+	tests := []struct {
+		cluster    string
+		wantStatus int
+		want       ClusterHealthResponse
+	}{
+		{cluster: "east", wantStatus: http.StatusOK, want: ClusterHealthResponse{Name: "east", Status: ClusterReachable, KubernetesVersion: "v1.31.2", CircuitBreaker: "closed"}},
+		// An open circuit breaker still reports, rather than 404ing.
+		{cluster: "west", wantStatus: http.StatusOK, want: ClusterHealthResponse{Name: "west", Status: ClusterUnreachable, KubernetesVersion: "v1.30.4", CircuitBreaker: "open"}},
+		{cluster: "new", wantStatus: http.StatusOK, want: ClusterHealthResponse{Name: "new", Status: ClusterHealthUnknown, CircuitBreaker: "closed"}},
+		{cluster: "missing", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cluster, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/"+tt.cluster+"/health", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got ClusterHealthResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			wantCheck := ""
+			if tt.want.Status != ClusterHealthUnknown {
+				wantCheck = "2026-01-15T10:30:00Z"
+			}
+			gotCheck := ""
+			if got.LastHealthCheck != nil {
+				gotCheck = *got.LastHealthCheck
+			}
+			if gotCheck != wantCheck {
+				t.Errorf("expected lastHealthCheck %q, got %q", wantCheck, gotCheck)
+			}
+			got.LastHealthCheck = nil
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterHandler_HealthSingleCluster(t *testing.T) {
+	handler := &ClusterHandler{}
+	w := httptest.NewRecorder()
+	handler.Health(w, httptest.NewRequest(http.MethodGet, "/clusters/default/health", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected status 501, got %d", w.Code)
+	}
+}
+
+func TestClusterHandler_Heartbeats(t *testing.T) {
+	store := newMigrationTestStore(t)
+	handler := &ClusterHandler{Store: store}
+
+	ctx := context.Background()
+	handler.recordHeartbeat(ctx, "west", multicluster.ClusterPhaseReady, HeartbeatRequest{
+		KubernetesVersion: "v1.31.2",
+		ResourceCounts:    &multicluster.ResourceCounts{Gateways: 2, HTTPRoutes: 5},
+		GPUCapacity:       &multicluster.GPUCapacitySummary{TotalGPUs: 8, AllocatedGPUs: 3},
+	})
+	handler.recordHeartbeat(ctx, "west", multicluster.ClusterPhaseDegraded, HeartbeatRequest{KubernetesVersion: "v1.31.2"})
+	handler.recordHeartbeat(ctx, "east", multicluster.ClusterPhaseReady, HeartbeatRequest{})
+
+	r := chi.NewRouter()
+	r.Get("/clusters/{cluster}/heartbeats", handler.Heartbeats)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/west/heartbeats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got []HeartbeatRecordResponse
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 west heartbeats, got %+v", got)
+	}
+	if got[0].Phase != "Ready" || got[0].ResourceCounts == nil || got[0].ResourceCounts.HTTPRoutes != 5 || got[0].GPUCapacity == nil || got[0].GPUCapacity.AllocatedGPUs != 3 {
+		t.Errorf("unexpected first heartbeat: %+v", got[0])
+	}
+	if got[1].Phase != "Degraded" || got[1].ResourceCounts != nil || got[1].GPUCapacity != nil {
+		t.Errorf("unexpected second heartbeat: %+v", got[1])
+	}
+
+	// A since after every record returns an empty list.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/west/heartbeats?since="+time.Now().Add(time.Hour).UTC().Format(time.RFC3339), nil))
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("expected an empty list, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=abc"} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/west/heartbeats?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
@@ -2,36 +2,66 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 )
 
 // CoexistenceHandler handles NGINX Ingress Controller / NGF coexistence API requests.
-type CoexistenceHandler struct{}
+type CoexistenceHandler struct {
+	// ExcludeNamespaces are left out of cluster-wide discovery and counts
+	// unless the request sets ?includeSystem=true. A request scoped with
+	// ?namespace= is never filtered.
+	ExcludeNamespaces []string
+	// Manager lists the clusters ?allClusters=true fans out to.
+	Manager cluster.Provider
+}
 
 // CoexistenceOverview represents the coexistence status of KIC and NGF in a cluster.
 type CoexistenceOverview struct {
-	KIC             ControllerSummary `json:"kic"`
-	NGF             ControllerSummary `json:"ngf"`
-	SharedResources []SharedResource  `json:"sharedResources"`
-	Conflicts       []Conflict        `json:"conflicts"`
+	// Namespace is set when the overview is scoped to one namespace with
+	// ?namespace=; it is empty for the cluster-wide default.
+	Namespace string `json:"namespace,omitempty"`
+	// ExcludedNamespaces lists the namespaces left out of the counts.
+	ExcludedNamespaces []string          `json:"excludedNamespaces,omitempty"`
+	KIC                ControllerSummary `json:"kic"`
+	NGF                ControllerSummary `json:"ngf"`
+	// OtherControllers lists Gateway API implementations other than NGF
+	// (istio, envoy, traefik, other) that own at least one GatewayClass,
+	// Gateway, or HTTPRoute.
+	OtherControllers []ControllerSummary `json:"otherControllers"`
+	SharedResources  []SharedResource    `json:"sharedResources"`
+	Conflicts        []Conflict          `json:"conflicts"`
+}
+
+// ClusterCoexistenceOverview is one cluster's entry in the fleet-wide
+// overview returned with ?allClusters=true. Exactly one field is set.
+type ClusterCoexistenceOverview struct {
+	Overview *CoexistenceOverview `json:"overview,omitempty"`
+	Error    string               `json:"error,omitempty"` // why the cluster could not be discovered
 }
 
 // ControllerSummary summarizes a controller's presence and resources.
 type ControllerSummary struct {
+	Name          string          `json:"name,omitempty"` // set for OtherControllers entries
 	Installed     bool            `json:"installed"`
 	Version       string          `json:"version,omitempty"`
 	ResourceCount int             `json:"resourceCount"`
 	Namespaces    []string        `json:"namespaces"`
 	Resources     []ResourceCount `json:"resources"`
+	// ControllerNames are the GatewayClass controllerName values attributed
+	// to this controller.
+	ControllerNames []string `json:"controllerNames,omitempty"`
 }
 
 // ResourceCount tracks the count of a specific resource kind.
@@ -58,11 +88,13 @@ type Conflict struct {
 
 // MigrationReadinessResponse represents the readiness assessment for migrating from KIC to NGF.
 type MigrationReadinessResponse struct {
-	Score           float64             `json:"score"` // 0-100
-	Status          string              `json:"status"`
-	Categories      []ReadinessCategory `json:"categories"`
-	Blockers        []string            `json:"blockers"`
-	Recommendations []string            `json:"recommendations"`
+	Namespace          string              `json:"namespace,omitempty"` // set when scoped with ?namespace=
+	ExcludedNamespaces []string            `json:"excludedNamespaces,omitempty"`
+	Score              float64             `json:"score"` // 0-100
+	Status             string              `json:"status"`
+	Categories         []ReadinessCategory `json:"categories"`
+	Blockers           []string            `json:"blockers"`
+	Recommendations    []string            `json:"recommendations"`
 }
 
 // ReadinessCategory represents a scored category in the migration readiness assessment.
@@ -75,47 +107,113 @@ type ReadinessCategory struct {
 
 // GVRs for KIC resources.
 var (
-	ingressGVR         = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
-	virtualServerGVR   = schema.GroupVersionResource{Group: "k8s.nginx.org", Version: "v1", Resource: "virtualservers"}
+	ingressGVR            = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	virtualServerGVR      = schema.GroupVersionResource{Group: "k8s.nginx.org", Version: "v1", Resource: "virtualservers"}
 	virtualServerRouteGVR = schema.GroupVersionResource{Group: "k8s.nginx.org", Version: "v1", Resource: "virtualserverroutes"}
-	transportServerGVR = schema.GroupVersionResource{Group: "k8s.nginx.org", Version: "v1", Resource: "transportservers"}
+	transportServerGVR    = schema.GroupVersionResource{Group: "k8s.nginx.org", Version: "v1", Resource: "transportservers"}
 )
 
 // coexistenceData holds discovered data used by both Overview and MigrationReadiness.
 type coexistenceData struct {
+	namespace string          // scope of the lists; empty for cluster-wide
+	excluded  map[string]bool // namespaces whose resources are dropped from the lists
+
 	// KIC resources
-	ingresses          *unstructured.UnstructuredList
-	virtualServers     *unstructured.UnstructuredList
+	ingresses           *unstructured.UnstructuredList
+	virtualServers      *unstructured.UnstructuredList
 	virtualServerRoutes *unstructured.UnstructuredList
-	transportServers   *unstructured.UnstructuredList
+	transportServers    *unstructured.UnstructuredList
+	kicVersion          string // image tag of the controller Deployment, if found
+
+	// Gateway API v1alpha2 L4 route kinds whose CRDs are installed
+	l4RouteKinds map[string]bool
 
-	// NGF resources
+	// Gateway API resources across all implementations
 	gatewayClasses []string // controller names
 	gatewayCount   int
 	httpRouteCount int
-	ngfNamespaces  map[string]bool
+
+	// Gateway API resources grouped by controller family (see
+	// classifyGatewayController). NGF is the "nginx" family.
+	controllers map[string]*gatewayControllerData
 
 	// Computed
 	kicNamespaces map[string]bool
 
 	// Backend references keyed by "namespace/name"
-	ingressBackends  map[string][]int32 // service key -> ports
+	ingressBackends   map[string][]int32 // service key -> ports
 	httpRouteBackends map[string][]int32 // service key -> ports
 
 	// Hostnames
-	ingressHostnames  map[string]string // hostname -> "namespace/name"
+	ingressHostnames   map[string]string // hostname -> "namespace/name"
 	httpRouteHostnames map[string]string // hostname -> "namespace/name"
 }
 
+// Gateway API controller families distinguished in the overview.
+const (
+	controllerNGINX   = "nginx"
+	controllerIstio   = "istio"
+	controllerEnvoy   = "envoy"
+	controllerTraefik = "traefik"
+	controllerOther   = "other"
+)
+
+// otherControllerFamilies is the order of OtherControllers in the overview.
+var otherControllerFamilies = []string{controllerIstio, controllerEnvoy, controllerTraefik, controllerOther}
+
+// gatewayControllerData holds the Gateway API resources owned by one
+// controller family.
+type gatewayControllerData struct {
+	controllerNames map[string]bool
+	classCount      int
+	gatewayCount    int
+	httpRouteCount  int
+	namespaces      map[string]bool
+}
+
+// controller returns the data for a controller family, creating it if needed.
+func (d *coexistenceData) controller(family string) *gatewayControllerData {
+	c, ok := d.controllers[family]
+	if !ok {
+		c = &gatewayControllerData{controllerNames: make(map[string]bool), namespaces: make(map[string]bool)}
+		d.controllers[family] = c
+	}
+	return c
+}
+
+// classifyGatewayController maps a GatewayClass controllerName to a
+// controller family.
+func classifyGatewayController(controllerName string) string {
+	cn := strings.ToLower(controllerName)
+	switch {
+	case strings.Contains(cn, "nginx"):
+		return controllerNGINX
+	case strings.Contains(cn, "istio"):
+		return controllerIstio
+	case strings.Contains(cn, "contour"), strings.Contains(cn, "envoy"):
+		return controllerEnvoy
+	case strings.Contains(cn, "traefik"):
+		return controllerTraefik
+	default:
+		return controllerOther
+	}
+}
+
 // Overview returns the coexistence status overview.
 func (h *CoexistenceHandler) Overview(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("allClusters") == "true" {
+		h.overviewAllClusters(w, r)
+		return
+	}
+
 	k8s := cluster.ClientFromContext(r.Context())
 	if k8s == nil {
 		writeError(w, http.StatusServiceUnavailable, "no cluster context")
 		return
 	}
 
-	data, err := h.discover(r.Context(), k8s)
+	namespace := r.URL.Query().Get("namespace")
+	data, err := h.discover(r.Context(), k8s, namespace, h.excludedNamespaces(r, namespace))
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("discovering resources: %v", err))
 		return
@@ -125,6 +223,73 @@ func (h *CoexistenceHandler) Overview(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, overview)
 }
 
+// overviewAllClusters builds the overview of every registered cluster, or of
+// those matching ?clusterSelector=, concurrently and returns them keyed by
+// cluster name. A cluster that cannot
+// be reached gets an error entry instead of failing the request.
+func (h *CoexistenceHandler) overviewAllClusters(w http.ResponseWriter, r *http.Request) {
+	if h.Manager == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster manager configured")
+		return
+	}
+
+	names, err := selectClusterNames(h.Manager, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	namespace := r.URL.Query().Get("namespace")
+	excluded := h.excludedNamespaces(r, namespace)
+
+	results := make([]ClusterCoexistenceOverview, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), globalQueryTimeout)
+			defer cancel()
+			overview, err := h.clusterOverview(ctx, name, namespace, excluded)
+			if err != nil {
+				results[idx] = ClusterCoexistenceOverview{Error: err.Error()}
+				return
+			}
+			results[idx] = ClusterCoexistenceOverview{Overview: overview}
+		}(i, name)
+	}
+	wg.Wait()
+
+	resp := make(map[string]ClusterCoexistenceOverview, len(names))
+	for i, name := range names {
+		resp[name] = results[i]
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// clusterOverview runs discovery against one named cluster. discover
+// tolerates list errors, so the cluster is probed first; otherwise an
+// unreachable cluster would be reported as empty.
+func (h *CoexistenceHandler) clusterOverview(ctx context.Context, name, namespace string, excluded []string) (*CoexistenceOverview, error) {
+	k8s, err := h.Manager.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	dc := k8s.DynamicClient()
+	if dc == nil {
+		return nil, errors.New("no dynamic client for cluster")
+	}
+	if _, err := dc.Resource(ingressGVR).Namespace(namespace).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, fmt.Errorf("cluster unreachable: %w", err)
+	}
+
+	data, err := h.discover(ctx, k8s, namespace, excluded)
+	if err != nil {
+		return nil, fmt.Errorf("discovering resources: %w", err)
+	}
+	overview := h.buildOverview(data)
+	return &overview, nil
+}
+
 // MigrationReadiness returns the readiness assessment for migration.
 func (h *CoexistenceHandler) MigrationReadiness(w http.ResponseWriter, r *http.Request) {
 	k8s := cluster.ClientFromContext(r.Context())
@@ -133,7 +298,8 @@ func (h *CoexistenceHandler) MigrationReadiness(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	data, err := h.discover(r.Context(), k8s)
+	namespace := r.URL.Query().Get("namespace")
+	data, err := h.discover(r.Context(), k8s, namespace, h.excludedNamespaces(r, namespace))
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("discovering resources: %v", err))
 		return
@@ -144,47 +310,69 @@ func (h *CoexistenceHandler) MigrationReadiness(w http.ResponseWriter, r *http.R
 	writeJSON(w, http.StatusOK, readiness)
 }
 
-// discover gathers all KIC and NGF resources from the cluster.
-func (h *CoexistenceHandler) discover(ctx context.Context, k8s *kubernetes.Client) (*coexistenceData, error) {
+// excludedNamespaces returns the namespaces to leave out of a request's
+// discovery: none when it is scoped to a namespace or sets
+// ?includeSystem=true, otherwise the handler's ExcludeNamespaces.
+func (h *CoexistenceHandler) excludedNamespaces(r *http.Request, namespace string) []string {
+	if namespace != "" || r.URL.Query().Get("includeSystem") == "true" {
+		return nil
+	}
+	return h.ExcludeNamespaces
+}
+
+// discover gathers KIC and NGF resources from the cluster, or from a single
+// namespace when namespace is set. Resources in the excluded namespaces are
+// dropped. GatewayClasses are cluster-scoped and are always listed, since
+// Gateways are attributed through them.
+func (h *CoexistenceHandler) discover(ctx context.Context, k8s *kubernetes.Client, namespace string, excluded []string) (*coexistenceData, error) {
 	data := &coexistenceData{
+		namespace:          namespace,
+		excluded:           make(map[string]bool, len(excluded)),
 		kicNamespaces:      make(map[string]bool),
-		ngfNamespaces:      make(map[string]bool),
+		controllers:        make(map[string]*gatewayControllerData),
 		ingressBackends:    make(map[string][]int32),
 		httpRouteBackends:  make(map[string][]int32),
 		ingressHostnames:   make(map[string]string),
 		httpRouteHostnames: make(map[string]string),
 	}
 
+	for _, ns := range excluded {
+		data.excluded[ns] = true
+	}
+
 	dc := k8s.DynamicClient()
 
 	// Detect KIC: Ingress resources
-	ingresses, err := dc.Resource(ingressGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	ingresses, err := dc.Resource(ingressGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		// Ingress API should always be available, but handle gracefully
 		ingresses = &unstructured.UnstructuredList{}
 	}
-	data.ingresses = ingresses
+	data.ingresses = data.dropExcluded(ingresses)
 
 	// Detect KIC: VirtualServer CRDs (may not exist)
-	vs, err := dc.Resource(virtualServerGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	vs, err := dc.Resource(virtualServerGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		vs = &unstructured.UnstructuredList{}
 	}
-	data.virtualServers = vs
+	data.virtualServers = data.dropExcluded(vs)
 
 	// Detect KIC: VirtualServerRoute CRDs (may not exist)
-	vsr, err := dc.Resource(virtualServerRouteGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	vsr, err := dc.Resource(virtualServerRouteGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		vsr = &unstructured.UnstructuredList{}
 	}
-	data.virtualServerRoutes = vsr
+	data.virtualServerRoutes = data.dropExcluded(vsr)
 
 	// Detect KIC: TransportServer CRDs (may not exist)
-	ts, err := dc.Resource(transportServerGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	ts, err := dc.Resource(transportServerGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		ts = &unstructured.UnstructuredList{}
 	}
-	data.transportServers = ts
+	data.transportServers = data.dropExcluded(ts)
+
+	// Detect KIC version from the controller Deployment image
+	data.kicVersion = findKICControllerVersion(ctx, dc)
 
 	// Collect KIC namespaces and backends from Ingresses
 	for _, ing := range ingresses.Items {
@@ -204,23 +392,97 @@ func (h *CoexistenceHandler) discover(ctx context.Context, k8s *kubernetes.Clien
 		data.kicNamespaces[t.GetNamespace()] = true
 	}
 
-	// Detect NGF: Gateway API resources using typed client
-	gateways, err := k8s.ListGateways(ctx, "")
+	// Detect L4 route CRDs that TransportServers convert to
+	data.l4RouteKinds = detectL4RouteKinds(ctx, k8s, namespace)
+
+	// Detect Gateway API controllers: classify GatewayClasses by
+	// controllerName, then attribute Gateways by class and HTTPRoutes by
+	// parent Gateway. Anything that cannot be resolved counts as "other".
+	classes, err := k8s.ListGatewayClasses(ctx)
+	if err != nil {
+		classes = nil
+	}
+	data.gatewayClasses = make([]string, 0, len(classes))
+	classFamily := make(map[string]string, len(classes))
+	for _, gc := range classes {
+		cn := string(gc.Spec.ControllerName)
+		data.gatewayClasses = append(data.gatewayClasses, cn)
+		family := classifyGatewayController(cn)
+		classFamily[gc.Name] = family
+		c := data.controller(family)
+		c.classCount++
+		c.controllerNames[cn] = true
+	}
+
+	gateways, err := k8s.ListGateways(ctx, namespace)
 	if err != nil {
 		gateways = nil
 	}
-	data.gatewayCount = len(gateways)
+	gatewayFamily := make(map[string]string, len(gateways))
 	for _, gw := range gateways {
-		data.ngfNamespaces[gw.Namespace] = true
+		family, ok := classFamily[string(gw.Spec.GatewayClassName)]
+		if !ok {
+			family = controllerOther
+		}
+		// Excluded Gateways still attribute the routes attached to them.
+		gatewayFamily[gw.Namespace+"/"+gw.Name] = family
+		if data.excluded[gw.Namespace] {
+			continue
+		}
+		data.gatewayCount++
+		c := data.controller(family)
+		c.gatewayCount++
+		c.namespaces[gw.Namespace] = true
 	}
 
-	httpRoutes, err := k8s.ListHTTPRoutes(ctx, "")
+	// When scoped, a route may attach to a Gateway in another namespace;
+	// look it up so the route is still attributed to its controller.
+	parentFamily := func(ns, name string) (string, bool) {
+		key := ns + "/" + name
+		if f, ok := gatewayFamily[key]; ok {
+			return f, true
+		}
+		if namespace == "" || ns == namespace {
+			return "", false
+		}
+		gw, err := k8s.GetGateway(ctx, ns, name)
+		if err != nil {
+			return "", false
+		}
+		f, ok := classFamily[string(gw.Spec.GatewayClassName)]
+		if !ok {
+			f = controllerOther
+		}
+		gatewayFamily[key] = f
+		return f, true
+	}
+
+	httpRoutes, err := k8s.ListHTTPRoutes(ctx, namespace)
 	if err != nil {
 		httpRoutes = nil
 	}
-	data.httpRouteCount = len(httpRoutes)
 	for _, hr := range httpRoutes {
-		data.ngfNamespaces[hr.Namespace] = true
+		if data.excluded[hr.Namespace] {
+			continue
+		}
+		data.httpRouteCount++
+		family := controllerOther
+		for _, ref := range hr.Spec.ParentRefs {
+			refNS := hr.Namespace
+			if ref.Namespace != nil {
+				refNS = string(*ref.Namespace)
+			}
+			if f, ok := parentFamily(refNS, string(ref.Name)); ok {
+				family = f
+				break
+			}
+		}
+		c := data.controller(family)
+		c.httpRouteCount++
+		c.namespaces[hr.Namespace] = true
+		if family != controllerNGINX {
+			continue
+		}
 		// Extract HTTPRoute backends
 		for _, rule := range hr.Spec.Rules {
 			for _, br := range rule.BackendRefs {
@@ -242,23 +504,31 @@ func (h *CoexistenceHandler) discover(ctx context.Context, k8s *kubernetes.Clien
 		}
 	}
 
-	classes, err := k8s.ListGatewayClasses(ctx)
-	if err != nil {
-		classes = nil
+	return data, nil
+}
+
+// dropExcluded removes the items in excluded namespaces from list.
+func (d *coexistenceData) dropExcluded(list *unstructured.UnstructuredList) *unstructured.UnstructuredList {
+	if len(d.excluded) == 0 {
+		return list
 	}
-	data.gatewayClasses = make([]string, 0, len(classes))
-	for _, gc := range classes {
-		data.gatewayClasses = append(data.gatewayClasses, string(gc.Spec.ControllerName))
+	items := list.Items[:0]
+	for _, item := range list.Items {
+		if !d.excluded[item.GetNamespace()] {
+			items = append(items, item)
+		}
 	}
-
-	return data, nil
+	list.Items = items
+	return list
 }
 
 // buildOverview constructs the CoexistenceOverview from discovered data.
 func (h *CoexistenceHandler) buildOverview(data *coexistenceData) CoexistenceOverview {
 	overview := CoexistenceOverview{
-		SharedResources: make([]SharedResource, 0),
-		Conflicts:       make([]Conflict, 0),
+		Namespace:          data.namespace,
+		ExcludedNamespaces: mapKeys(data.excluded),
+		SharedResources:    make([]SharedResource, 0),
+		Conflicts:          make([]Conflict, 0),
 	}
 
 	// Build KIC summary
@@ -292,31 +562,18 @@ func (h *CoexistenceHandler) buildOverview(data *coexistenceData) CoexistenceOve
 	overview.KIC.Version = h.detectKICVersion(data)
 
 	// Build NGF summary
-	ngfTotal := data.gatewayCount + data.httpRouteCount + len(data.gatewayClasses)
+	overview.NGF = gatewayControllerSummary("", data.controllers[controllerNGINX])
 
-	overview.NGF = ControllerSummary{
-		Installed:     ngfTotal > 0,
-		ResourceCount: ngfTotal,
-		Namespaces:    mapKeys(data.ngfNamespaces),
-		Resources:     make([]ResourceCount, 0),
-	}
-
-	if len(data.gatewayClasses) > 0 {
-		overview.NGF.Resources = append(overview.NGF.Resources, ResourceCount{Kind: "GatewayClass", Count: len(data.gatewayClasses)})
-	}
-	if data.gatewayCount > 0 {
-		overview.NGF.Resources = append(overview.NGF.Resources, ResourceCount{Kind: "Gateway", Count: data.gatewayCount})
-	}
-	if data.httpRouteCount > 0 {
-		overview.NGF.Resources = append(overview.NGF.Resources, ResourceCount{Kind: "HTTPRoute", Count: data.httpRouteCount})
+	// Detect NGF version from GatewayClass controller names
+	if len(overview.NGF.ControllerNames) > 0 {
+		overview.NGF.Version = overview.NGF.ControllerNames[0]
 	}
 
-	// Detect NGF version from GatewayClass controller names
-	for _, cn := range data.gatewayClasses {
-		if strings.Contains(strings.ToLower(cn), "nginx") {
-			// Extract version hint from controller name if present
-			overview.NGF.Version = cn
-			break
+	// Other Gateway API implementations
+	overview.OtherControllers = make([]ControllerSummary, 0)
+	for _, family := range otherControllerFamilies {
+		if c, ok := data.controllers[family]; ok {
+			overview.OtherControllers = append(overview.OtherControllers, gatewayControllerSummary(family, c))
 		}
 	}
 
@@ -360,6 +617,33 @@ func (h *CoexistenceHandler) buildOverview(data *coexistenceData) CoexistenceOve
 	return overview
 }
 
+// gatewayControllerSummary builds the summary for one Gateway API controller
+// family. A nil c yields an empty, not-installed summary.
+func gatewayControllerSummary(name string, c *gatewayControllerData) ControllerSummary {
+	summary := ControllerSummary{
+		Name:       name,
+		Namespaces: make([]string, 0),
+		Resources:  make([]ResourceCount, 0),
+	}
+	if c == nil {
+		return summary
+	}
+	summary.ResourceCount = c.classCount + c.gatewayCount + c.httpRouteCount
+	summary.Installed = summary.ResourceCount > 0
+	summary.Namespaces = mapKeys(c.namespaces)
+	summary.ControllerNames = mapKeys(c.controllerNames)
+	if c.classCount > 0 {
+		summary.Resources = append(summary.Resources, ResourceCount{Kind: "GatewayClass", Count: c.classCount})
+	}
+	if c.gatewayCount > 0 {
+		summary.Resources = append(summary.Resources, ResourceCount{Kind: "Gateway", Count: c.gatewayCount})
+	}
+	if c.httpRouteCount > 0 {
+		summary.Resources = append(summary.Resources, ResourceCount{Kind: "HTTPRoute", Count: c.httpRouteCount})
+	}
+	return summary
+}
+
 // assessReadiness scores migration readiness from KIC to NGF.
 func (h *CoexistenceHandler) assessReadiness(data *coexistenceData, overview CoexistenceOverview) MigrationReadinessResponse {
 	categories := make([]ReadinessCategory, 0, 4)
@@ -391,14 +675,7 @@ func (h *CoexistenceHandler) assessReadiness(data *coexistenceData, overview Coe
 		Name:  "NGF Controller Running",
 		Score: 0,
 	}
-	ngfControllerFound := false
-	for _, cn := range data.gatewayClasses {
-		if strings.Contains(strings.ToLower(cn), "nginx") {
-			ngfControllerFound = true
-			break
-		}
-	}
-	if ngfControllerFound {
+	if ngf, ok := data.controllers[controllerNGINX]; ok && ngf.classCount > 0 {
 		ngfCategory.Score = 25
 		ngfCategory.Status = "pass"
 		ngfCategory.Details = "NGINX Gateway Fabric controller is running with a registered GatewayClass"
@@ -431,8 +708,21 @@ func (h *CoexistenceHandler) assessReadiness(data *coexistenceData, overview Coe
 		compatCategory.Status = "pass"
 		compatCategory.Details = "No KIC resources to migrate"
 	} else {
-		// Ingress = easy (1.0 convertibility), VirtualServer = medium (0.5), TransportServer = hard (0.2)
-		convertibleScore := float64(ingressCount)*1.0 + float64(vsCount)*0.5 + float64(tsCount)*0.2
+		// Ingress = easy (1.0 convertibility), VirtualServer = medium (0.5),
+		// TransportServer = medium (0.6) when its target L4 route CRD is
+		// installed, hard (0.2) otherwise
+		tsSupported := 0
+		missingKinds := make(map[string]bool)
+		for _, t := range data.transportServers.Items {
+			kind := transportServerRouteKind(&t)
+			if data.l4RouteKinds[kind] {
+				tsSupported++
+			} else {
+				missingKinds[kind] = true
+			}
+		}
+		tsUnsupported := tsCount - tsSupported
+		convertibleScore := float64(ingressCount)*1.0 + float64(vsCount)*0.5 + float64(tsSupported)*0.6 + float64(tsUnsupported)*0.2
 		maxScore := float64(totalKICResources) * 1.0
 		ratio := convertibleScore / maxScore
 		compatCategory.Score = ratio * 25
@@ -453,9 +743,15 @@ func (h *CoexistenceHandler) assessReadiness(data *coexistenceData, overview Coe
 			details = append(details, fmt.Sprintf("%d VirtualServer resources (medium complexity)", vsCount))
 			recommendations = append(recommendations, "VirtualServer resources require manual conversion to HTTPRoute; review each for advanced features")
 		}
-		if tsCount > 0 {
-			details = append(details, fmt.Sprintf("%d TransportServer resources (hard to convert)", tsCount))
-			recommendations = append(recommendations, "TransportServer resources may require TCPRoute/TLSRoute; verify Gateway API support for your use case")
+		if tsSupported > 0 {
+			details = append(details, fmt.Sprintf("%d TransportServer resources (medium complexity, L4 route CRDs installed)", tsSupported))
+			recommendations = append(recommendations, "Convert TransportServer resources to TCPRoute/TLSRoute/UDPRoute; review session persistence and other KIC-specific settings manually")
+		}
+		if tsUnsupported > 0 {
+			kinds := mapKeys(missingKinds)
+			details = append(details, fmt.Sprintf("%d TransportServer resources (hard to convert, %s CRDs not installed)", tsUnsupported, strings.Join(kinds, "/")))
+			blockers = append(blockers, fmt.Sprintf("%d TransportServer resources need %s, but the CRDs are not installed", tsUnsupported, strings.Join(kinds, "/")))
+			recommendations = append(recommendations, "Install the Gateway API experimental channel CRDs for L4 routes: kubectl apply -f https://github.com/kubernetes-sigs/gateway-api/releases/latest/download/experimental-install.yaml")
 		}
 		compatCategory.Details = strings.Join(details, "; ")
 	}
@@ -527,11 +823,13 @@ func (h *CoexistenceHandler) assessReadiness(data *coexistenceData, overview Coe
 	}
 
 	return MigrationReadinessResponse{
-		Score:           totalScore,
-		Status:          status,
-		Categories:      categories,
-		Blockers:        blockers,
-		Recommendations: recommendations,
+		Namespace:          data.namespace,
+		ExcludedNamespaces: mapKeys(data.excluded),
+		Score:              totalScore,
+		Status:             status,
+		Categories:         categories,
+		Blockers:           blockers,
+		Recommendations:    recommendations,
 	}
 }
 
@@ -622,8 +920,14 @@ func (h *CoexistenceHandler) extractVirtualServerHostnames(vs *unstructured.Unst
 	}
 }
 
-// detectKICVersion attempts to determine KIC version from resource annotations.
+// detectKICVersion returns the KIC version from the controller Deployment
+// image. When the Deployment can't be found, it falls back to guessing from
+// resource annotations, which can only identify KIC, not its version.
 func (h *CoexistenceHandler) detectKICVersion(data *coexistenceData) string {
+	if data.kicVersion != "" {
+		return data.kicVersion
+	}
+
 	// Check Ingress annotations for KIC class indicator
 	for _, ing := range data.ingresses.Items {
 		annotations := ing.GetAnnotations()
@@ -646,6 +950,90 @@ func (h *CoexistenceHandler) detectKICVersion(data *coexistenceData) string {
 	return ""
 }
 
+// Namespaces and labels used by the ingress-nginx and NGINX Ingress
+// Controller install manifests and Helm charts.
+var (
+	kicControllerNamespaces = []string{"ingress-nginx", "nginx-ingress"}
+	kicControllerLabels     = map[string][]string{
+		"app.kubernetes.io/name": {"ingress-nginx", "nginx-ingress"},
+		"app":                    {"ingress-nginx", "nginx-ingress"},
+	}
+)
+
+// findKICControllerVersion looks up the ingress controller Deployment in the
+// common KIC namespaces and returns the tag of its controller image, or "" if
+// no labeled Deployment with a tagged image is found.
+func findKICControllerVersion(ctx context.Context, dc dynamic.Interface) string {
+	for _, ns := range kicControllerNamespaces {
+		list, err := dc.Resource(deploymentGVR).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+		for _, item := range list.Items {
+			if !isKICControllerDeployment(item.GetLabels()) {
+				continue
+			}
+			containers, _, _ := unstructured.NestedSlice(item.Object, "spec", "template", "spec", "containers")
+			for _, c := range containers {
+				cm, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				image, _ := cm["image"].(string)
+				if _, tag := splitImage(image); tag != "" {
+					return tag
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isKICControllerDeployment reports whether labels carry one of the common
+// ingress controller app labels.
+func isKICControllerDeployment(labels map[string]string) bool {
+	for key, values := range kicControllerLabels {
+		for _, v := range values {
+			if labels[key] == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// detectL4RouteKinds returns the v1alpha2 L4 route kinds the cluster serves.
+// A kind counts as installed when its routes can be listed.
+func detectL4RouteKinds(ctx context.Context, k8s *kubernetes.Client, namespace string) map[string]bool {
+	kinds := make(map[string]bool, 3)
+	if _, err := k8s.ListTCPRoutes(ctx, namespace); err == nil {
+		kinds["TCPRoute"] = true
+	}
+	if _, err := k8s.ListTLSRoutes(ctx, namespace); err == nil {
+		kinds["TLSRoute"] = true
+	}
+	if _, err := k8s.ListUDPRoutes(ctx, namespace); err == nil {
+		kinds["UDPRoute"] = true
+	}
+	return kinds
+}
+
+// transportServerRouteKind returns the Gateway API route kind a
+// TransportServer converts to: TLSRoute for TLS passthrough, UDPRoute for UDP
+// listeners, and TCPRoute otherwise.
+func transportServerRouteKind(ts *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(ts.Object, "spec", "listener", "name")
+	protocol, _, _ := unstructured.NestedString(ts.Object, "spec", "listener", "protocol")
+	switch {
+	case name == "tls-passthrough" || strings.EqualFold(protocol, "TLS_PASSTHROUGH"):
+		return "TLSRoute"
+	case strings.EqualFold(protocol, "UDP"):
+		return "UDPRoute"
+	default:
+		return "TCPRoute"
+	}
+}
+
 // findPortConflicts returns ports that appear in both slices.
 func findPortConflicts(a, b []int32) []int32 {
 	set := make(map[int32]bool, len(a))
@@ -0,0 +1,581 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func TestClassifyGatewayController(t *testing.T) {
+	tests := map[string]string{
+		"gateway.nginx.org/nginx-gateway-controller":    controllerNGINX,
+		"istio.io/gateway-controller":                   controllerIstio,
+		"istio.io/unmanaged-gateway":                    controllerIstio,
+		"projectcontour.io/gateway-controller":          controllerEnvoy,
+		"gateway.envoyproxy.io/gatewayclass-controller": controllerEnvoy,
+		"traefik.io/gateway-controller":                 controllerTraefik,
+		"example.com/custom-controller":                 controllerOther,
+	}
+	for controllerName, want := range tests {
+		if got := classifyGatewayController(controllerName); got != want {
+			t.Errorf("classifyGatewayController(%q) = %q, want %q", controllerName, got, want)
+		}
+	}
+}
+
+func TestCoexistenceHandler_OverviewControllers(t *testing.T) {
+	gatewayClass := func(name, controller string) *gatewayv1.GatewayClass {
+		return &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController(controller)},
+		}
+	}
+	gateway := func(name, ns, class string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(class),
+				Listeners:        []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+			},
+		}
+	}
+	route := func(name, ns, parentNS, parent string) *gatewayv1.HTTPRoute {
+		ref := gatewayv1.ParentReference{Name: gatewayv1.ObjectName(parent)}
+		if parentNS != ns {
+			refNS := gatewayv1.Namespace(parentNS)
+			ref.Namespace = &refNS
+		}
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{ref}},
+			},
+		}
+	}
+
+	objs := []client.Object{
+		gatewayClass("nginx", "gateway.nginx.org/nginx-gateway-controller"),
+		gatewayClass("istio", "istio.io/gateway-controller"),
+		gatewayClass("traefik", "traefik.io/gateway-controller"),
+		gateway("web", "apps", "nginx"),
+		gateway("mesh", "istio-system", "istio"),
+		gateway("orphan", "apps", "missing-class"),
+		route("web-route", "apps", "apps", "web"),
+		route("mesh-route", "bookinfo", "istio-system", "mesh"),
+		route("dangling", "apps", "apps", "nonexistent"),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(objs...).Build()
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ingressGVR:            "IngressList",
+		virtualServerGVR:      "VirtualServerList",
+		virtualServerRouteGVR: "VirtualServerRouteList",
+		transportServerGVR:    "TransportServerList",
+		deploymentGVR:         "DeploymentList",
+	})
+	handler := &CoexistenceHandler{}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(kubernetes.NewForTestWithDynamic(fakeClient, dc)))
+	r.Get("/coexistence/overview", handler.Overview)
+
+	req := httptest.NewRequest(http.MethodGet, "/coexistence/overview", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var overview CoexistenceOverview
+	if err := json.NewDecoder(w.Body).Decode(&overview); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// NGF owns only the nginx class, its gateway, and the route attached to it.
+	if overview.NGF.ResourceCount != 3 || len(overview.NGF.Namespaces) != 1 || overview.NGF.Namespaces[0] != "apps" {
+		t.Errorf("ngf = %+v, want 3 resources in namespace apps", overview.NGF)
+	}
+
+	got := make(map[string]ControllerSummary, len(overview.OtherControllers))
+	for _, c := range overview.OtherControllers {
+		got[c.Name] = c
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected istio, traefik, and other controllers, got %+v", overview.OtherControllers)
+	}
+	if istio := got[controllerIstio]; istio.ResourceCount != 3 || len(istio.ControllerNames) != 1 {
+		t.Errorf("istio = %+v, want 3 resources", istio)
+	}
+	if traefik := got[controllerTraefik]; !traefik.Installed || traefik.ResourceCount != 1 {
+		t.Errorf("traefik = %+v, want installed with 1 GatewayClass", traefik)
+	}
+	// The gateway with an unknown class and the route with an unknown parent.
+	if other := got[controllerOther]; other.ResourceCount != 2 {
+		t.Errorf("other = %+v, want 2 resources", other)
+	}
+	if _, ok := got[controllerEnvoy]; ok {
+		t.Error("expected no envoy entry without an envoy GatewayClass")
+	}
+}
+
+func TestCoexistenceHandler_KICVersion(t *testing.T) {
+	deployment := func(name, ns string, labels map[string]string, image string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+				"containers": []any{map[string]any{"name": "controller", "image": image}},
+			}}},
+		}}
+		obj.SetName(name)
+		obj.SetNamespace(ns)
+		obj.SetLabels(labels)
+		return obj
+	}
+	ingress := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+	}}
+	ingress.SetName("web")
+	ingress.SetNamespace("apps")
+	ingress.SetAnnotations(map[string]string{"kubernetes.io/ingress.class": "nginx"})
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    string
+	}{
+		{
+			name: "community controller",
+			objects: []runtime.Object{
+				ingress,
+				deployment("ingress-nginx-controller", "ingress-nginx", map[string]string{"app.kubernetes.io/name": "ingress-nginx"}, "registry.k8s.io/ingress-nginx/controller:v1.11.2@sha256:abc"),
+			},
+			want: "v1.11.2",
+		},
+		{
+			name: "nginx ingress controller",
+			objects: []runtime.Object{
+				ingress,
+				deployment("nginx-ingress", "nginx-ingress", map[string]string{"app": "nginx-ingress"}, "nginx/nginx-ingress:3.6.1"),
+			},
+			want: "3.6.1",
+		},
+		{
+			name: "unlabeled deployment falls back to annotations",
+			objects: []runtime.Object{
+				ingress,
+				deployment("other", "ingress-nginx", map[string]string{"app": "other"}, "example.com/other:1.0"),
+			},
+			want: "nginx-ingress-controller",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).Build()
+			dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				ingressGVR:            "IngressList",
+				virtualServerGVR:      "VirtualServerList",
+				virtualServerRouteGVR: "VirtualServerRouteList",
+				transportServerGVR:    "TransportServerList",
+				deploymentGVR:         "DeploymentList",
+			}, tt.objects...)
+			handler := &CoexistenceHandler{}
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(kubernetes.NewForTestWithDynamic(fakeClient, dc)))
+			r.Get("/coexistence/overview", handler.Overview)
+
+			req := httptest.NewRequest(http.MethodGet, "/coexistence/overview", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var overview CoexistenceOverview
+			if err := json.NewDecoder(w.Body).Decode(&overview); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if overview.KIC.Version != tt.want {
+				t.Errorf("kic version = %q, want %q", overview.KIC.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoexistenceHandler_ReadinessL4Routes(t *testing.T) {
+	transportServer := func(name, listener, protocol string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "k8s.nginx.org/v1",
+			"kind":       "TransportServer",
+			"spec":       map[string]any{"listener": map[string]any{"name": listener, "protocol": protocol}},
+		}}
+		obj.SetName(name)
+		obj.SetNamespace("apps")
+		return obj
+	}
+	// A scheme without v1alpha2 makes the fake client fail to list L4
+	// routes, as a cluster without the CRDs does.
+	withoutL4 := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(withoutL4); err != nil {
+		t.Fatalf("failed to add client-go scheme: %v", err)
+	}
+	if err := gatewayv1.Install(withoutL4); err != nil {
+		t.Fatalf("failed to add gateway-api scheme: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		scheme      *runtime.Scheme
+		wantScore   float64
+		wantBlocker bool
+	}{
+		{name: "L4 route CRDs installed", scheme: setupScheme(t), wantScore: 15},
+		{name: "L4 route CRDs missing", scheme: withoutL4, wantScore: 5, wantBlocker: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(tt.scheme).Build()
+			dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				ingressGVR:            "IngressList",
+				virtualServerGVR:      "VirtualServerList",
+				virtualServerRouteGVR: "VirtualServerRouteList",
+				transportServerGVR:    "TransportServerList",
+				deploymentGVR:         "DeploymentList",
+			}, transportServer("db", "mysql", "TCP"), transportServer("secure", "tls-passthrough", "TLS_PASSTHROUGH"))
+			handler := &CoexistenceHandler{}
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(kubernetes.NewForTestWithDynamic(fakeClient, dc)))
+			r.Get("/coexistence/migration-readiness", handler.MigrationReadiness)
+
+			req := httptest.NewRequest(http.MethodGet, "/coexistence/migration-readiness", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var readiness MigrationReadinessResponse
+			if err := json.NewDecoder(w.Body).Decode(&readiness); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			var compat ReadinessCategory
+			for _, c := range readiness.Categories {
+				if c.Name == "Resource Compatibility" {
+					compat = c
+				}
+			}
+			if compat.Score < tt.wantScore-0.01 || compat.Score > tt.wantScore+0.01 {
+				t.Errorf("compatibility score = %v, want %v (%s)", compat.Score, tt.wantScore, compat.Details)
+			}
+			hasBlocker := false
+			for _, b := range readiness.Blockers {
+				if strings.Contains(b, "TCPRoute/TLSRoute, but the CRDs are not installed") {
+					hasBlocker = true
+				}
+			}
+			if hasBlocker != tt.wantBlocker {
+				t.Errorf("L4 CRD blocker = %v, want %v: %v", hasBlocker, tt.wantBlocker, readiness.Blockers)
+			}
+		})
+	}
+}
+
+func TestTransportServerRouteKind(t *testing.T) {
+	tests := []struct {
+		listener, protocol, want string
+	}{
+		{"mysql", "TCP", "TCPRoute"},
+		{"dns", "UDP", "UDPRoute"},
+		{"tls-passthrough", "TLS_PASSTHROUGH", "TLSRoute"},
+		{"", "", "TCPRoute"},
+	}
+	for _, tt := range tests {
+		ts := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"listener": map[string]any{"name": tt.listener, "protocol": tt.protocol}},
+		}}
+		if got := transportServerRouteKind(ts); got != tt.want {
+			t.Errorf("transportServerRouteKind(%s/%s) = %q, want %q", tt.listener, tt.protocol, got, tt.want)
+		}
+	}
+}
+
+func TestCoexistenceHandler_OverviewNamespace(t *testing.T) {
+	istioNS := gatewayv1.Namespace("istio-system")
+	objs := []client.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.nginx.org/nginx-gateway-controller"},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "nginx"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "istio"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-web", Namespace: "shop"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "nginx"},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-route", Namespace: "apps"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "web"}}},
+			},
+		},
+		// Attached to a Gateway outside the requested namespace.
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "cross", Namespace: "apps"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "mesh", Namespace: &istioNS}}},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop-route", Namespace: "shop"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "other-web"}}},
+			},
+		},
+	}
+	ingress := func(name, ns string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress"}}
+		obj.SetName(name)
+		obj.SetNamespace(ns)
+		return obj
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(objs...).Build()
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ingressGVR:            "IngressList",
+		virtualServerGVR:      "VirtualServerList",
+		virtualServerRouteGVR: "VirtualServerRouteList",
+		transportServerGVR:    "TransportServerList",
+		deploymentGVR:         "DeploymentList",
+	}, ingress("legacy", "apps"), ingress("shop-legacy", "shop"))
+	handler := &CoexistenceHandler{}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(kubernetes.NewForTestWithDynamic(fakeClient, dc)))
+	r.Get("/coexistence/overview", handler.Overview)
+
+	req := httptest.NewRequest(http.MethodGet, "/coexistence/overview?namespace=apps", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var overview CoexistenceOverview
+	if err := json.NewDecoder(w.Body).Decode(&overview); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if overview.Namespace != "apps" {
+		t.Errorf("namespace = %q, want apps", overview.Namespace)
+	}
+	if overview.KIC.ResourceCount != 1 {
+		t.Errorf("kic = %+v, want only the Ingress in apps", overview.KIC)
+	}
+	// The nginx GatewayClass, the web Gateway, and web-route.
+	if overview.NGF.ResourceCount != 3 {
+		t.Errorf("ngf = %+v, want 3 resources", overview.NGF)
+	}
+	if len(overview.OtherControllers) != 1 || overview.OtherControllers[0].Name != controllerIstio || overview.OtherControllers[0].ResourceCount != 2 {
+		t.Errorf("otherControllers = %+v, want istio with its class and the cross-namespace route", overview.OtherControllers)
+	}
+}
+
+func TestCoexistenceHandler_ExcludesSystemNamespaces(t *testing.T) {
+	consoleNS := gatewayv1.Namespace("ngf-system")
+	objs := []client.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.nginx.org/nginx-gateway-controller"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "ngf-system"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "nginx"},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-route", Namespace: "apps"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "shared", Namespace: &consoleNS}}},
+			},
+		},
+	}
+	ingress := func(name, ns string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress"}}
+		obj.SetName(name)
+		obj.SetNamespace(ns)
+		return obj
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(objs...).Build()
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ingressGVR:            "IngressList",
+		virtualServerGVR:      "VirtualServerList",
+		virtualServerRouteGVR: "VirtualServerRouteList",
+		transportServerGVR:    "TransportServerList",
+		deploymentGVR:         "DeploymentList",
+	}, ingress("legacy", "apps"), ingress("dashboard", "kube-system"))
+	handler := &CoexistenceHandler{ExcludeNamespaces: []string{"kube-system", "kube-public", "ngf-system"}}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(kubernetes.NewForTestWithDynamic(fakeClient, dc)))
+	r.Get("/coexistence/overview", handler.Overview)
+
+	overview := func(query string) CoexistenceOverview {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/coexistence/overview"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp CoexistenceOverview
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	got := overview("")
+	if len(got.ExcludedNamespaces) != 3 {
+		t.Errorf("excludedNamespaces = %v, want the 3 configured", got.ExcludedNamespaces)
+	}
+	if got.KIC.ResourceCount != 1 || len(got.KIC.Namespaces) != 1 || got.KIC.Namespaces[0] != "apps" {
+		t.Errorf("kic = %+v, want only the Ingress in apps", got.KIC)
+	}
+	// The class and the route, which is still attributed through the
+	// excluded Gateway.
+	if got.NGF.ResourceCount != 2 || len(got.NGF.Namespaces) != 1 || got.NGF.Namespaces[0] != "apps" {
+		t.Errorf("ngf = %+v, want the class and the route in apps", got.NGF)
+	}
+	if len(got.OtherControllers) != 0 {
+		t.Errorf("otherControllers = %+v, want none", got.OtherControllers)
+	}
+
+	got = overview("?includeSystem=true")
+	if len(got.ExcludedNamespaces) != 0 || got.KIC.ResourceCount != 2 || got.NGF.ResourceCount != 3 {
+		t.Errorf("includeSystem: excluded = %v, kic = %d, ngf = %d; want none excluded, 2 and 3",
+			got.ExcludedNamespaces, got.KIC.ResourceCount, got.NGF.ResourceCount)
+	}
+
+	got = overview("?namespace=kube-system")
+	if len(got.ExcludedNamespaces) != 0 || got.KIC.ResourceCount != 1 {
+		t.Errorf("scoped: excluded = %v, kic = %+v; want the kube-system Ingress", got.ExcludedNamespaces, got.KIC)
+	}
+}
+
+func TestCoexistenceHandler_OverviewAllClusters(t *testing.T) {
+	listKinds := map[schema.GroupVersionResource]string{
+		ingressGVR:            "IngressList",
+		virtualServerGVR:      "VirtualServerList",
+		virtualServerRouteGVR: "VirtualServerRouteList",
+		transportServerGVR:    "TransportServerList",
+		deploymentGVR:         "DeploymentList",
+	}
+	ingress := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "networking.k8s.io/v1", "kind": "Ingress"}}
+	ingress.SetName("legacy")
+	ingress.SetNamespace("apps")
+
+	east := kubernetes.NewForTestWithDynamic(
+		fake.NewClientBuilder().WithScheme(setupScheme(t)).Build(),
+		fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, ingress),
+	)
+	westDC := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	westDC.PrependReactor("list", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("dial tcp 10.0.0.2:6443: connect: connection refused")
+	})
+	west := kubernetes.NewForTestWithDynamic(fake.NewClientBuilder().WithScheme(setupScheme(t)).Build(), westDC)
+
+	handler := &CoexistenceHandler{Manager: cluster.NewForTest(map[string]*kubernetes.Client{"east": east, "west": west}, "east")}
+	req := httptest.NewRequest(http.MethodGet, "/coexistence/overview?allClusters=true", nil)
+	w := httptest.NewRecorder()
+	handler.Overview(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]ClusterCoexistenceOverview
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("expected entries for east and west, got %+v", resp)
+	}
+	if e := resp["east"]; e.Error != "" || e.Overview == nil || e.Overview.KIC.ResourceCount != 1 {
+		t.Errorf("east = %+v, want an overview with 1 KIC resource", e)
+	}
+	if wst := resp["west"]; wst.Overview != nil || !strings.Contains(wst.Error, "connection refused") {
+		t.Errorf("west = %+v, want an unreachable error", wst)
+	}
+}
+
+// labeledProvider adds label selection to a test cluster.Manager.
+type labeledProvider struct {
+	*cluster.Manager
+	selected []string
+}
+
+func (p labeledProvider) SelectNames(string) ([]string, error) { return p.selected, nil }
+
+func TestCoexistenceHandler_OverviewClusterSelector(t *testing.T) {
+	newClient := func() *kubernetes.Client {
+		return kubernetes.NewForTestWithDynamic(
+			fake.NewClientBuilder().WithScheme(setupScheme(t)).Build(),
+			fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				ingressGVR:            "IngressList",
+				virtualServerGVR:      "VirtualServerList",
+				virtualServerRouteGVR: "VirtualServerRouteList",
+				transportServerGVR:    "TransportServerList",
+				deploymentGVR:         "DeploymentList",
+			}),
+		)
+	}
+	mgr := cluster.NewForTest(map[string]*kubernetes.Client{"us-east": newClient(), "eu-west": newClient()}, "us-east")
+
+	// A provider without labels cannot select.
+	handler := &CoexistenceHandler{Manager: mgr}
+	w := httptest.NewRecorder()
+	handler.Overview(w, httptest.NewRequest(http.MethodGet, "/coexistence/overview?allClusters=true&clusterSelector=region%3Dus", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without label support, got %d: %s", w.Code, w.Body.String())
+	}
+
+	handler = &CoexistenceHandler{Manager: labeledProvider{Manager: mgr, selected: []string{"us-east"}}}
+	w = httptest.NewRecorder()
+	handler.Overview(w, httptest.NewRequest(http.MethodGet, "/coexistence/overview?allClusters=true&clusterSelector=region%3Dus", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]ClusterCoexistenceOverview
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := resp["us-east"]; !ok || len(resp) != 1 {
+		t.Errorf("expected only us-east, got %+v", resp)
+	}
+}
@@ -1,15 +1,22 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 )
 
 // DiagnosticsHandler handles diagnostic API requests.
@@ -40,10 +47,11 @@ type DiagnosticCheck struct {
 // Trace types
 
 type TraceRequest struct {
-	Method  string            `json:"method"`
-	Host    string            `json:"host"`
-	Path    string            `json:"path"`
-	Headers map[string]string `json:"headers,omitempty"`
+	Method      string            `json:"method"`
+	Host        string            `json:"host"`
+	Path        string            `json:"path"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryParams map[string]string `json:"queryParams,omitempty"`
 }
 
 type TraceResponse struct {
@@ -86,8 +94,8 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	checks := make([]DiagnosticCheck, 0, 6)
 
-	// Currently only HTTPRoute is supported for detailed checks.
-	if req.RouteKind != "HTTPRoute" {
+	// Detailed checks are supported for HTTPRoute and GRPCRoute.
+	if req.RouteKind != "HTTPRoute" && req.RouteKind != "GRPCRoute" {
 		checks = append(checks, DiagnosticCheck{
 			Name:    "Route Exists",
 			Status:  "skip",
@@ -103,16 +111,16 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Check 1: Route Exists
-	route, err := k8s.GetHTTPRoute(ctx, req.Namespace, req.RouteName)
+	route, err := getDiagnosticRoute(ctx, k8s, req.RouteKind, req.Namespace, req.RouteName)
 	if err != nil {
 		checks = append(checks, DiagnosticCheck{
 			Name:    "Route Exists",
 			Status:  "fail",
-			Message: fmt.Sprintf("HTTPRoute %s/%s not found", req.Namespace, req.RouteName),
+			Message: fmt.Sprintf("%s %s/%s not found", req.RouteKind, req.Namespace, req.RouteName),
 			Details: err.Error(),
 		})
 		// All remaining checks are skip
-		for _, name := range []string{"Parent Gateway Attached", "Listener Match", "Backend Health", "Route Accepted", "Route Resolved"} {
+		for _, name := range []string{"Parent Gateway Attached", "Listener Match", "Certificate Expiry", "Backend Health", "Route Accepted", "Route Resolved"} {
 			checks = append(checks, DiagnosticCheck{
 				Name:    name,
 				Status:  "skip",
@@ -131,7 +139,7 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 	checks = append(checks, DiagnosticCheck{
 		Name:    "Route Exists",
 		Status:  "pass",
-		Message: fmt.Sprintf("HTTPRoute %s/%s exists", req.Namespace, req.RouteName),
+		Message: fmt.Sprintf("%s %s/%s exists", req.RouteKind, req.Namespace, req.RouteName),
 	})
 
 	// Check 2: Parent Gateway Attached
@@ -139,13 +147,13 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 		Name: "Parent Gateway Attached",
 	}
 	var parentGateway *gatewayv1.Gateway
-	if len(route.Spec.ParentRefs) == 0 {
+	if len(route.parentRefs) == 0 {
 		parentGatewayCheck.Status = "fail"
 		parentGatewayCheck.Message = "No parentRefs defined on route"
 	} else {
 		allFound := true
 		var details []string
-		for _, ref := range route.Spec.ParentRefs {
+		for _, ref := range route.parentRefs {
 			gwName := string(ref.Name)
 			gwNamespace := req.Namespace
 			if ref.Namespace != nil {
@@ -161,7 +169,7 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 		}
 		if allFound {
 			parentGatewayCheck.Status = "pass"
-			parentGatewayCheck.Message = fmt.Sprintf("All %d parent gateway(s) found", len(route.Spec.ParentRefs))
+			parentGatewayCheck.Message = fmt.Sprintf("All %d parent gateway(s) found", len(route.parentRefs))
 		} else {
 			parentGatewayCheck.Status = "fail"
 			parentGatewayCheck.Message = "One or more parent gateways not found"
@@ -180,11 +188,11 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 	} else {
 		matched := false
 		for _, listener := range parentGateway.Spec.Listeners {
-			// Check protocol compatibility (HTTP/HTTPS for HTTPRoute)
+			// Check protocol compatibility (HTTP/HTTPS for HTTPRoute and GRPCRoute)
 			if listener.Protocol == gatewayv1.HTTPProtocolType || listener.Protocol == gatewayv1.HTTPSProtocolType {
 				// Check hostname overlap if both specify hostnames
-				if listener.Hostname != nil && len(route.Spec.Hostnames) > 0 {
-					for _, routeHostname := range route.Spec.Hostnames {
+				if listener.Hostname != nil && len(route.hostnames) > 0 {
+					for _, routeHostname := range route.hostnames {
 						if hostnamesMatch(string(*listener.Hostname), string(routeHostname)) {
 							matched = true
 							break
@@ -207,16 +215,27 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 			listenerCheck.Message = "No gateway listener matches the route's protocol/hostname"
 			listenerCheck.Details = fmt.Sprintf("Gateway %s/%s has %d listener(s), route has %d hostname(s)",
 				parentGateway.Namespace, parentGateway.Name,
-				len(parentGateway.Spec.Listeners), len(route.Spec.Hostnames))
+				len(parentGateway.Spec.Listeners), len(route.hostnames))
 		}
 	}
 	checks = append(checks, listenerCheck)
 
-	// Check 4: Backend Health
+	// Check 4: Certificate Expiry
+	if parentGateway == nil {
+		checks = append(checks, DiagnosticCheck{
+			Name:    "Certificate Expiry",
+			Status:  "skip",
+			Message: "Skipped because no parent gateway was found",
+		})
+	} else {
+		checks = append(checks, certificateExpiryCheck(ctx, k8s, parentGateway, time.Now()))
+	}
+
+	// Check 5: Backend Health
 	backendCheck := DiagnosticCheck{
 		Name: "Backend Health",
 	}
-	backendNames := collectBackendNames(route)
+	backendNames := route.backendNames
 	if len(backendNames) == 0 {
 		backendCheck.Status = "warn"
 		backendCheck.Message = "No backendRefs defined in route rules"
@@ -231,29 +250,51 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 			for _, svc := range services {
 				svcSet[svc.Name] = true
 			}
-			var missing []string
+			slices, sliceErr := k8s.ListEndpointSlices(ctx, req.Namespace)
+			counts := countServiceEndpoints(slices)
+
+			var missing, notReady, details []string
 			for _, name := range backendNames {
 				if !svcSet[name] {
 					missing = append(missing, name)
+					continue
+				}
+				if sliceErr != nil {
+					continue
 				}
+				c := counts[name]
+				if c.ready == 0 {
+					notReady = append(notReady, name)
+				}
+				details = append(details, fmt.Sprintf("%s: %d/%d endpoints ready", name, c.ready, c.total))
 			}
-			if len(missing) == 0 {
-				backendCheck.Status = "pass"
-				backendCheck.Message = fmt.Sprintf("All %d backend service(s) found", len(backendNames))
-			} else {
+
+			switch {
+			case len(missing) > 0:
 				backendCheck.Status = "fail"
 				backendCheck.Message = fmt.Sprintf("%d of %d backend service(s) missing", len(missing), len(backendNames))
-				backendCheck.Details = "Missing: " + strings.Join(missing, ", ")
+				details = append([]string{"Missing: " + strings.Join(missing, ", ")}, details...)
+			case sliceErr != nil:
+				backendCheck.Status = "warn"
+				backendCheck.Message = fmt.Sprintf("All %d backend service(s) found, endpoint readiness unknown", len(backendNames))
+				details = []string{sliceErr.Error()}
+			case len(notReady) > 0:
+				backendCheck.Status = "warn"
+				backendCheck.Message = fmt.Sprintf("%d of %d backend service(s) have no ready endpoints", len(notReady), len(backendNames))
+			default:
+				backendCheck.Status = "pass"
+				backendCheck.Message = fmt.Sprintf("All %d backend service(s) have ready endpoints", len(backendNames))
 			}
+			backendCheck.Details = strings.Join(details, "; ")
 		}
 	}
 	checks = append(checks, backendCheck)
 
-	// Check 5: Route Accepted
+	// Check 6: Route Accepted
 	acceptedCheck := DiagnosticCheck{
 		Name: "Route Accepted",
 	}
-	acceptedStatus := findRouteCondition(route, "Accepted")
+	acceptedStatus := findRouteCondition(route.status, "Accepted")
 	if acceptedStatus == "" {
 		acceptedCheck.Status = "warn"
 		acceptedCheck.Message = "No Accepted condition found in route status"
@@ -266,11 +307,11 @@ func (h *DiagnosticsHandler) RouteCheck(w http.ResponseWriter, r *http.Request)
 	}
 	checks = append(checks, acceptedCheck)
 
-	// Check 6: Route Resolved
+	// Check 7: Route Resolved
 	resolvedCheck := DiagnosticCheck{
 		Name: "Route Resolved",
 	}
-	resolvedStatus := findRouteCondition(route, "ResolvedRefs")
+	resolvedStatus := findRouteCondition(route.status, "ResolvedRefs")
 	if resolvedStatus == "" {
 		resolvedCheck.Status = "warn"
 		resolvedCheck.Message = "No ResolvedRefs condition found in route status"
@@ -481,22 +522,24 @@ func (h *DiagnosticsHandler) Trace(w http.ResponseWriter, r *http.Request) {
 		DurationMs: 0.2,
 	}
 
-	type ruleMatch struct {
-		routeKey string
-		rule     gatewayv1.HTTPRouteRule
-	}
 	var ruleMatches []ruleMatch
-
 	for _, route := range matchedRoutes {
-		for _, rule := range route.Spec.Rules {
-			if ruleMatchesRequest(rule, req) {
+		for i, rule := range route.Spec.Rules {
+			if rank, ok := ruleMatchesRequest(rule, req); ok {
 				ruleMatches = append(ruleMatches, ruleMatch{
-					routeKey: route.Namespace + "/" + route.Name,
-					rule:     rule,
+					routeKey:  route.Namespace + "/" + route.Name,
+					created:   route.CreationTimestamp.Time,
+					ruleIndex: i,
+					rule:      rule,
+					rank:      rank,
 				})
 			}
 		}
 	}
+	sort.SliceStable(ruleMatches, func(i, j int) bool {
+		less, _ := ruleMatches[i].precedes(ruleMatches[j])
+		return less
+	})
 
 	if len(ruleMatches) == 0 {
 		step3.Status = "fail"
@@ -513,8 +556,15 @@ func (h *DiagnosticsHandler) Trace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bestMatch := ruleMatches[0]
+	reason := "only matching rule"
+	if len(ruleMatches) > 1 {
+		_, reason = bestMatch.precedes(ruleMatches[1])
+		reason = fmt.Sprintf("wins over %s rule %d: %s", ruleMatches[1].routeKey, ruleMatches[1].ruleIndex, reason)
+	}
 	step3.Status = "pass"
-	step3.Message = fmt.Sprintf("Matched %d rule(s) in route %s for %s %s", len(ruleMatches), ruleMatches[0].routeKey, req.Method, req.Path)
+	step3.Message = fmt.Sprintf("Matched %d rule(s) for %s %s; selected %s rule %d (%s)",
+		len(ruleMatches), req.Method, req.Path, bestMatch.routeKey, bestMatch.ruleIndex, reason)
 	steps = append(steps, step3)
 
 	// Step 4: Backend Selection
@@ -523,7 +573,6 @@ func (h *DiagnosticsHandler) Trace(w http.ResponseWriter, r *http.Request) {
 		DurationMs: 0.1,
 	}
 
-	bestMatch := ruleMatches[0]
 	if len(bestMatch.rule.BackendRefs) == 0 {
 		step4.Status = "fail"
 		step4.Message = "Matched rule has no backendRefs"
@@ -587,17 +636,63 @@ func hostnamesMatch(pattern, hostname string) bool {
 	return false
 }
 
-// collectBackendNames extracts unique backend service names from all route rules.
-func collectBackendNames(route *gatewayv1.HTTPRoute) []string {
+// diagnosticRoute is the kind-independent view of a route that RouteCheck inspects.
+type diagnosticRoute struct {
+	parentRefs   []gatewayv1.ParentReference
+	hostnames    []gatewayv1.Hostname
+	backendNames []string
+	status       gatewayv1.RouteStatus
+}
+
+// getDiagnosticRoute fetches an HTTPRoute or GRPCRoute and reduces it to a diagnosticRoute.
+func getDiagnosticRoute(ctx context.Context, k8s *kubernetes.Client, kind, namespace, name string) (*diagnosticRoute, error) {
+	switch kind {
+	case "GRPCRoute":
+		gr, err := k8s.GetGRPCRoute(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		var refs []gatewayv1.BackendRef
+		for _, rule := range gr.Spec.Rules {
+			for _, br := range rule.BackendRefs {
+				refs = append(refs, br.BackendRef)
+			}
+		}
+		return &diagnosticRoute{
+			parentRefs:   gr.Spec.ParentRefs,
+			hostnames:    gr.Spec.Hostnames,
+			backendNames: collectBackendNames(refs),
+			status:       gr.Status.RouteStatus,
+		}, nil
+	default:
+		hr, err := k8s.GetHTTPRoute(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		var refs []gatewayv1.BackendRef
+		for _, rule := range hr.Spec.Rules {
+			for _, br := range rule.BackendRefs {
+				refs = append(refs, br.BackendRef)
+			}
+		}
+		return &diagnosticRoute{
+			parentRefs:   hr.Spec.ParentRefs,
+			hostnames:    hr.Spec.Hostnames,
+			backendNames: collectBackendNames(refs),
+			status:       hr.Status.RouteStatus,
+		}, nil
+	}
+}
+
+// collectBackendNames extracts unique backend service names from backend refs.
+func collectBackendNames(refs []gatewayv1.BackendRef) []string {
 	seen := make(map[string]bool)
 	var names []string
-	for _, rule := range route.Spec.Rules {
-		for _, br := range rule.BackendRefs {
-			name := string(br.Name)
-			if !seen[name] {
-				seen[name] = true
-				names = append(names, name)
-			}
+	for _, br := range refs {
+		name := string(br.Name)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
 		}
 	}
 	return names
@@ -605,8 +700,8 @@ func collectBackendNames(route *gatewayv1.HTTPRoute) []string {
 
 // findRouteCondition looks through route status parents for a condition of the given type
 // and returns its status string. Returns empty string if not found.
-func findRouteCondition(route *gatewayv1.HTTPRoute, condType string) string {
-	for _, parent := range route.Status.Parents {
+func findRouteCondition(status gatewayv1.RouteStatus, condType string) string {
+	for _, parent := range status.Parents {
 		for _, cond := range parent.Conditions {
 			if cond.Type == condType {
 				return string(cond.Status)
@@ -616,19 +711,222 @@ func findRouteCondition(route *gatewayv1.HTTPRoute, condType string) string {
 	return ""
 }
 
-// ruleMatchesRequest checks whether an HTTPRouteRule matches a trace request.
-func ruleMatchesRequest(rule gatewayv1.HTTPRouteRule, req TraceRequest) bool {
-	// If the rule has no matches, it matches everything (catch-all).
+// certExpiryWarnDays is how close to expiry a listener certificate must be
+// before the Certificate Expiry check warns.
+const certExpiryWarnDays = 30
+
+// certificateExpiryCheck loads the certificateRefs of gw's HTTPS and TLS
+// listeners and reports the soonest-expiring leaf certificate. Secrets that
+// cannot be read or are not kubernetes.io/tls are skipped and noted in Details.
+func certificateExpiryCheck(ctx context.Context, k8s *kubernetes.Client, gw *gatewayv1.Gateway, now time.Time) DiagnosticCheck {
+	check := DiagnosticCheck{Name: "Certificate Expiry"}
+	var details []string
+	checked, expiring, expired := 0, 0, 0
+	for _, listener := range gw.Spec.Listeners {
+		if listener.Protocol != gatewayv1.HTTPSProtocolType && listener.Protocol != gatewayv1.TLSProtocolType {
+			continue
+		}
+		if listener.TLS == nil {
+			continue
+		}
+		for _, ref := range listener.TLS.CertificateRefs {
+			if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Secret") {
+				continue
+			}
+			ns := gw.Namespace
+			if ref.Namespace != nil {
+				ns = string(*ref.Namespace)
+			}
+			id := fmt.Sprintf("%s/%s (listener %s)", ns, ref.Name, listener.Name)
+
+			secret, err := k8s.GetSecret(ctx, ns, string(ref.Name))
+			if err != nil {
+				details = append(details, fmt.Sprintf("%s: skipped, secret not readable", id))
+				continue
+			}
+			if secret.Type != corev1.SecretTypeTLS {
+				details = append(details, fmt.Sprintf("%s: skipped, secret type is %s", id, secret.Type))
+				continue
+			}
+			cert, err := parseLeafCertificate(secret)
+			if err != nil {
+				details = append(details, fmt.Sprintf("%s: skipped, %v", id, err))
+				continue
+			}
+
+			checked++
+			daysLeft := int(cert.NotAfter.Sub(now).Hours() / 24)
+			switch {
+			case !now.Before(cert.NotAfter):
+				expired++
+				details = append(details, fmt.Sprintf("%s: expired %s", id, cert.NotAfter.UTC().Format(time.RFC3339)))
+			case daysLeft < certExpiryWarnDays:
+				expiring++
+				details = append(details, fmt.Sprintf("%s: expires in %d days", id, daysLeft))
+			default:
+				details = append(details, fmt.Sprintf("%s: expires in %d days", id, daysLeft))
+			}
+		}
+	}
+	check.Details = strings.Join(details, "; ")
+
+	switch {
+	case expired > 0:
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("%d of %d listener certificate(s) expired", expired, checked)
+	case expiring > 0:
+		check.Status = "warn"
+		check.Message = fmt.Sprintf("%d of %d listener certificate(s) expire within %d days", expiring, checked, certExpiryWarnDays)
+	case checked > 0:
+		check.Status = "pass"
+		check.Message = fmt.Sprintf("All %d listener certificate(s) valid for at least %d days", checked, certExpiryWarnDays)
+	default:
+		check.Status = "skip"
+		check.Message = "No readable TLS certificates on HTTPS/TLS listeners"
+	}
+	return check
+}
+
+// endpointCounts is the number of ready and total endpoint addresses backing a Service.
+type endpointCounts struct {
+	ready, total int
+}
+
+// countServiceEndpoints tallies endpoint addresses per Service name from
+// EndpointSlices. An endpoint with no Ready condition is counted as ready,
+// matching EndpointSlice semantics.
+func countServiceEndpoints(slices []discoveryv1.EndpointSlice) map[string]endpointCounts {
+	counts := make(map[string]endpointCounts)
+	for _, slice := range slices {
+		svc := slice.Labels[discoveryv1.LabelServiceName]
+		if svc == "" {
+			continue
+		}
+		c := counts[svc]
+		for _, ep := range slice.Endpoints {
+			n := len(ep.Addresses)
+			c.total += n
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				c.ready += n
+			}
+		}
+		counts[svc] = c
+	}
+	return counts
+}
+
+// ruleMatchesRequest checks whether an HTTPRouteRule matches a trace request
+// and returns the precedence rank of its most specific matching match.
+func ruleMatchesRequest(rule gatewayv1.HTTPRouteRule, req TraceRequest) (matchRank, bool) {
+	// If the rule has no matches, it matches everything (catch-all) like the
+	// default PathPrefix "/" match.
 	if len(rule.Matches) == 0 {
-		return true
+		return rankMatch(gatewayv1.HTTPRouteMatch{}), true
 	}
 
+	var best matchRank
+	found := false
 	for _, match := range rule.Matches {
-		if singleMatchMatches(match, req) {
-			return true
+		if !singleMatchMatches(match, req) {
+			continue
+		}
+		rank := rankMatch(match)
+		if !found {
+			best, found = rank, true
+			continue
+		}
+		if c, _ := rank.compare(best); c > 0 {
+			best = rank
 		}
 	}
-	return false
+	return best, found
+}
+
+// matchRank holds the HTTPRouteMatch properties that Gateway API uses to
+// order matches, from most to least significant.
+type matchRank struct {
+	exactPath   bool
+	prefixLen   int // characters in a PathPrefix match; 0 for other path types
+	method      bool
+	headers     int
+	queryParams int
+}
+
+// rankMatch computes the precedence rank of a match. A match without a path
+// is treated as PathPrefix "/".
+func rankMatch(match gatewayv1.HTTPRouteMatch) matchRank {
+	rank := matchRank{
+		prefixLen:   1,
+		method:      match.Method != nil,
+		headers:     len(match.Headers),
+		queryParams: len(match.QueryParams),
+	}
+	if match.Path != nil && match.Path.Value != nil {
+		pathType := gatewayv1.PathMatchPathPrefix
+		if match.Path.Type != nil {
+			pathType = *match.Path.Type
+		}
+		switch pathType {
+		case gatewayv1.PathMatchExact:
+			rank.exactPath, rank.prefixLen = true, 0
+		case gatewayv1.PathMatchPathPrefix:
+			rank.prefixLen = len(*match.Path.Value)
+		default:
+			rank.prefixLen = 0
+		}
+	}
+	return rank
+}
+
+// compare orders two ranks per Gateway API precedence: exact path, then
+// longest path prefix, then method, then header and query param match
+// counts. It returns >0 if r takes precedence over o, <0 if o does, and 0 on
+// a tie, along with the criterion that decided.
+func (r matchRank) compare(o matchRank) (int, string) {
+	switch {
+	case r.exactPath != o.exactPath:
+		if r.exactPath {
+			return 1, "exact path match"
+		}
+		return -1, "exact path match"
+	case r.prefixLen != o.prefixLen:
+		return r.prefixLen - o.prefixLen, fmt.Sprintf("longer path prefix (%d vs %d chars)", max(r.prefixLen, o.prefixLen), min(r.prefixLen, o.prefixLen))
+	case r.method != o.method:
+		if r.method {
+			return 1, "method match"
+		}
+		return -1, "method match"
+	case r.headers != o.headers:
+		return r.headers - o.headers, fmt.Sprintf("more header matches (%d vs %d)", max(r.headers, o.headers), min(r.headers, o.headers))
+	case r.queryParams != o.queryParams:
+		return r.queryParams - o.queryParams, fmt.Sprintf("more query param matches (%d vs %d)", max(r.queryParams, o.queryParams), min(r.queryParams, o.queryParams))
+	}
+	return 0, ""
+}
+
+// ruleMatch is an HTTPRoute rule that matched a trace request.
+type ruleMatch struct {
+	routeKey  string
+	created   time.Time
+	ruleIndex int
+	rule      gatewayv1.HTTPRouteRule
+	rank      matchRank
+}
+
+// precedes reports whether m takes precedence over o and why. Ties on the
+// match rank go to the oldest route, then the route first in
+// "{namespace}/{name}" order, then the rule listed first.
+func (m ruleMatch) precedes(o ruleMatch) (bool, string) {
+	if c, reason := m.rank.compare(o.rank); c != 0 {
+		return c > 0, reason
+	}
+	if !m.created.Equal(o.created) {
+		return m.created.Before(o.created), "tie on match specificity; older route"
+	}
+	if m.routeKey != o.routeKey {
+		return m.routeKey < o.routeKey, "tie on match specificity and age; route name order"
+	}
+	return m.ruleIndex < o.ruleIndex, "tie on match specificity; earlier rule in route"
 }
 
 // singleMatchMatches checks a single HTTPRouteMatch against the trace request.
@@ -663,27 +961,62 @@ func singleMatchMatches(match gatewayv1.HTTPRouteMatch, req TraceRequest) bool {
 		}
 	}
 
-	// Check headers
+	// Check headers (names are case-insensitive)
 	for _, headerMatch := range match.Headers {
 		headerType := gatewayv1.HeaderMatchExact
 		if headerMatch.Type != nil {
 			headerType = *headerMatch.Type
 		}
-		reqValue, exists := req.Headers[string(headerMatch.Name)]
+		reqValue, exists := lookupHeader(req.Headers, string(headerMatch.Name))
 		if !exists {
 			return false
 		}
-		switch headerType {
-		case gatewayv1.HeaderMatchExact:
-			if reqValue != headerMatch.Value {
-				return false
-			}
-		case gatewayv1.HeaderMatchRegularExpression:
-			if !strings.Contains(reqValue, headerMatch.Value) {
-				return false
-			}
+		if !valueMatches(headerType == gatewayv1.HeaderMatchRegularExpression, headerMatch.Value, reqValue) {
+			return false
+		}
+	}
+
+	// Check query params (names are case-sensitive)
+	for _, queryMatch := range match.QueryParams {
+		queryType := gatewayv1.QueryParamMatchExact
+		if queryMatch.Type != nil {
+			queryType = *queryMatch.Type
+		}
+		reqValue, exists := req.QueryParams[string(queryMatch.Name)]
+		if !exists {
+			return false
+		}
+		if !valueMatches(queryType == gatewayv1.QueryParamMatchRegularExpression, queryMatch.Value, reqValue) {
+			return false
 		}
 	}
 
 	return true
 }
+
+// lookupHeader finds a header value by case-insensitive name.
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	if v, ok := headers[name]; ok {
+		return v, true
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// valueMatches compares a header or query param value against a match
+// value, either exactly or as an RE2 regular expression. An invalid
+// expression never matches.
+func valueMatches(isRegex bool, pattern, value string) bool {
+	if !isRegex {
+		return value == pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
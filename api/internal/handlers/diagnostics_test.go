@@ -0,0 +1,536 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func TestDiagnosticsHandler_RouteCheckGRPCRoute(t *testing.T) {
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc-gw", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners:        []gatewayv1.Listener{{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType}},
+		},
+	}
+	route := testGRPCRoute("echo", "default")
+	route.Spec.ParentRefs = []gatewayv1.ParentReference{{Name: "grpc-gw"}}
+	route.Status.Parents = []gatewayv1.RouteParentStatus{{
+		ParentRef:      gatewayv1.ParentReference{Name: "grpc-gw"},
+		ControllerName: "gateway.nginx.org/nginx-gateway-controller",
+		Conditions: []metav1.Condition{
+			{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: "ResolvedRefs", Status: metav1.ConditionTrue, Reason: "ResolvedRefs"},
+		},
+	}}
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"}}
+	eps := testEndpointSlice("echo", "default", true)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "healthy grpc route",
+			body:       `{"namespace": "default", "routeName": "echo", "routeKind": "GRPCRoute"}`,
+			wantStatus: "healthy",
+			wantChecks: map[string]string{
+				"Route Exists":            "pass",
+				"Parent Gateway Attached": "pass",
+				"Listener Match":          "pass",
+				"Backend Health":          "pass",
+				"Route Accepted":          "pass",
+				"Route Resolved":          "pass",
+			},
+		},
+		{
+			name:       "missing grpc route",
+			body:       `{"namespace": "default", "routeName": "missing", "routeKind": "GRPCRoute"}`,
+			wantStatus: "unhealthy",
+			wantChecks: map[string]string{"Route Exists": "fail", "Backend Health": "skip"},
+		},
+		{
+			name:       "unsupported kind",
+			body:       `{"namespace": "default", "routeName": "echo", "routeKind": "TCPRoute"}`,
+			wantStatus: "unhealthy",
+			wantChecks: map[string]string{"Route Exists": "skip"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).
+				WithObjects(gw, route, svc, eps).Build()
+			handler := &DiagnosticsHandler{}
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(kubernetes.NewForTest(fakeClient)))
+			r.Post("/diagnostics/route-check", handler.RouteCheck)
+
+			req := httptest.NewRequest(http.MethodPost, "/diagnostics/route-check", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp RouteCheckResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q (checks: %+v)", resp.Status, tt.wantStatus, resp.Checks)
+			}
+			got := make(map[string]string, len(resp.Checks))
+			for _, c := range resp.Checks {
+				got[c.Name] = c.Status
+			}
+			for name, want := range tt.wantChecks {
+				if got[name] != want {
+					t.Errorf("check %q = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+// testEndpointSlice returns an EndpointSlice for service with one endpoint
+// address in the given readiness state.
+func testEndpointSlice(service, namespace string, ready bool) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service + "-abc12",
+			Namespace: namespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		}},
+	}
+}
+
+func TestDiagnosticsHandler_RouteCheckBackendReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		backends    []string
+		wantStatus  string
+		wantDetails string
+	}{
+		{
+			name:        "ready endpoints",
+			backends:    []string{"ready"},
+			wantStatus:  "pass",
+			wantDetails: "ready: 1/1 endpoints ready",
+		},
+		{
+			name:        "no ready endpoints",
+			backends:    []string{"ready", "idle"},
+			wantStatus:  "warn",
+			wantDetails: "ready: 1/1 endpoints ready; idle: 0/1 endpoints ready",
+		},
+		{
+			name:        "no endpoint slices",
+			backends:    []string{"empty"},
+			wantStatus:  "warn",
+			wantDetails: "empty: 0/0 endpoints ready",
+		},
+		{
+			name:        "missing service",
+			backends:    []string{"idle", "gone"},
+			wantStatus:  "fail",
+			wantDetails: "Missing: gone; idle: 0/1 endpoints ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := testGRPCRoute("echo", "default")
+			refs := make([]gatewayv1.GRPCBackendRef, 0, len(tt.backends))
+			for _, b := range tt.backends {
+				refs = append(refs, gatewayv1.GRPCBackendRef{BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(b)},
+				}})
+			}
+			route.Spec.Rules[0].BackendRefs = refs
+
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(
+				route,
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "default"}},
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"}},
+				testEndpointSlice("ready", "default", true),
+				testEndpointSlice("idle", "default", false),
+			).Build()
+			handler := &DiagnosticsHandler{}
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(kubernetes.NewForTest(fakeClient)))
+			r.Post("/diagnostics/route-check", handler.RouteCheck)
+
+			body := `{"namespace": "default", "routeName": "echo", "routeKind": "GRPCRoute"}`
+			req := httptest.NewRequest(http.MethodPost, "/diagnostics/route-check", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var resp RouteCheckResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			for _, c := range resp.Checks {
+				if c.Name != "Backend Health" {
+					continue
+				}
+				if c.Status != tt.wantStatus {
+					t.Errorf("status = %q, want %q (%s)", c.Status, tt.wantStatus, c.Message)
+				}
+				if c.Details != tt.wantDetails {
+					t.Errorf("details = %q, want %q", c.Details, tt.wantDetails)
+				}
+				return
+			}
+			t.Fatal("Backend Health check not found")
+		})
+	}
+}
+
+// testTLSSecret returns a kubernetes.io/tls Secret holding a self-signed
+// certificate that expires at notAfter.
+func testTLSSecret(t *testing.T, name, namespace string, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			"tls.key": []byte("unused"),
+		},
+	}
+}
+
+func TestCertificateExpiryCheck(t *testing.T) {
+	now := time.Now()
+	opaque := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "default"},
+		Type:       corev1.SecretTypeOpaque,
+	}
+
+	tests := []struct {
+		name        string
+		certRefs    []string
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			name:        "valid certificate",
+			certRefs:    []string{"valid"},
+			wantStatus:  "pass",
+			wantMessage: "All 1 listener certificate(s) valid for at least 30 days",
+		},
+		{
+			name:        "expiring certificate",
+			certRefs:    []string{"valid", "expiring"},
+			wantStatus:  "warn",
+			wantMessage: "1 of 2 listener certificate(s) expire within 30 days",
+		},
+		{
+			name:        "expired certificate",
+			certRefs:    []string{"expiring", "expired"},
+			wantStatus:  "fail",
+			wantMessage: "1 of 2 listener certificate(s) expired",
+		},
+		{
+			name:        "unreadable and non-TLS secrets",
+			certRefs:    []string{"missing", "opaque"},
+			wantStatus:  "skip",
+			wantMessage: "No readable TLS certificates on HTTPS/TLS listeners",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := make([]gatewayv1.SecretObjectReference, 0, len(tt.certRefs))
+			for _, name := range tt.certRefs {
+				refs = append(refs, gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(name)})
+			}
+			gw := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+				Spec: gatewayv1.GatewaySpec{
+					GatewayClassName: "nginx",
+					Listeners: []gatewayv1.Listener{
+						{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+						{
+							Name:     "https",
+							Port:     443,
+							Protocol: gatewayv1.HTTPSProtocolType,
+							TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: refs},
+						},
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(
+				testTLSSecret(t, "valid", "default", now.Add(90*24*time.Hour)),
+				testTLSSecret(t, "expiring", "default", now.Add(10*24*time.Hour)),
+				testTLSSecret(t, "expired", "default", now.Add(-24*time.Hour)),
+				opaque,
+			).Build()
+
+			got := certificateExpiryCheck(context.Background(), kubernetes.NewForTest(fakeClient), gw, now)
+			if got.Status != tt.wantStatus || got.Message != tt.wantMessage {
+				t.Errorf("check = (%q, %q), want (%q, %q); details: %s",
+					got.Status, got.Message, tt.wantStatus, tt.wantMessage, got.Details)
+			}
+		})
+	}
+}
+
+func TestSingleMatchMatches_HeadersAndQueryParams(t *testing.T) {
+	exact := gatewayv1.QueryParamMatchExact
+	queryRegex := gatewayv1.QueryParamMatchRegularExpression
+	headerRegex := gatewayv1.HeaderMatchRegularExpression
+
+	tests := []struct {
+		name  string
+		match gatewayv1.HTTPRouteMatch
+		req   TraceRequest
+		want  bool
+	}{
+		{
+			name: "exact query param",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+				{Type: &exact, Name: "version", Value: "v2"},
+			}},
+			req:  TraceRequest{Path: "/", QueryParams: map[string]string{"version": "v2"}},
+			want: true,
+		},
+		{
+			name: "query param defaults to exact",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+				{Name: "version", Value: "v2"},
+			}},
+			req:  TraceRequest{Path: "/", QueryParams: map[string]string{"version": "v20"}},
+			want: false,
+		},
+		{
+			name: "missing query param",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+				{Name: "version", Value: "v2"},
+			}},
+			req:  TraceRequest{Path: "/"},
+			want: false,
+		},
+		{
+			name: "query param names are case-sensitive",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+				{Name: "version", Value: "v2"},
+			}},
+			req:  TraceRequest{Path: "/", QueryParams: map[string]string{"Version": "v2"}},
+			want: false,
+		},
+		{
+			name: "regex query param",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+				{Type: &queryRegex, Name: "user", Value: "^[0-9]+$"},
+			}},
+			req:  TraceRequest{Path: "/", QueryParams: map[string]string{"user": "12345"}},
+			want: true,
+		},
+		{
+			name: "regex header is a real expression",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{
+				{Type: &headerRegex, Name: "x-canary", Value: "^(true|yes)$"},
+			}},
+			req:  TraceRequest{Path: "/", Headers: map[string]string{"x-canary": "yes"}},
+			want: true,
+		},
+		{
+			name: "regex header anchored mismatch",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{
+				{Type: &headerRegex, Name: "x-canary", Value: "^(true|yes)$"},
+			}},
+			req:  TraceRequest{Path: "/", Headers: map[string]string{"x-canary": "not-yes"}},
+			want: false,
+		},
+		{
+			name: "invalid regex never matches",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{
+				{Type: &headerRegex, Name: "x-canary", Value: "(["},
+			}},
+			req:  TraceRequest{Path: "/", Headers: map[string]string{"x-canary": "(["}},
+			want: false,
+		},
+		{
+			name: "header names are case-insensitive",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{
+				{Name: "X-Canary", Value: "true"},
+			}},
+			req:  TraceRequest{Path: "/", Headers: map[string]string{"x-canary": "true"}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := singleMatchMatches(tt.match, tt.req); got != tt.want {
+				t.Errorf("singleMatchMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsHandler_TracePrecedence(t *testing.T) {
+	prefix := gatewayv1.PathMatchPathPrefix
+	exactPath := gatewayv1.PathMatchExact
+	get := gatewayv1.HTTPMethodGet
+	pathMatch := func(typ *gatewayv1.PathMatchType, value string) gatewayv1.HTTPRouteMatch {
+		return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: typ, Value: &value}}
+	}
+	rule := func(backend string, matches ...gatewayv1.HTTPRouteMatch) gatewayv1.HTTPRouteRule {
+		return gatewayv1.HTTPRouteRule{
+			Matches:     matches,
+			BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(backend)}}}},
+		}
+	}
+	methodMatch := pathMatch(&prefix, "/api")
+	methodMatch.Method = &get
+
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners:        []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+	older := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "catch-all", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "web"}}},
+			Rules: []gatewayv1.HTTPRouteRule{
+				rule("frontend"),
+				rule("users", pathMatch(&exactPath, "/api/users")),
+				rule("api-old", pathMatch(&prefix, "/api")),
+			},
+		},
+	}
+	newer := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now())},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "web"}}},
+			Rules: []gatewayv1.HTTPRouteRule{
+				rule("api-new", pathMatch(&prefix, "/api")),
+				rule("api-get", methodMatch),
+				rule("orders", pathMatch(&prefix, "/api/orders")),
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantRoute   string
+		wantBackend string
+		wantReason  string
+	}{
+		{
+			name:        "exact path beats longer prefix",
+			body:        `{"host": "example.com", "path": "/api/users"}`,
+			wantRoute:   "default/catch-all",
+			wantBackend: "users",
+			wantReason:  "exact path match",
+		},
+		{
+			name:        "longest prefix wins across routes",
+			body:        `{"host": "example.com", "path": "/api/orders/7", "method": "POST"}`,
+			wantRoute:   "default/api",
+			wantBackend: "orders",
+			wantReason:  "longer path prefix (11 vs 4 chars)",
+		},
+		{
+			name:        "method match breaks prefix tie",
+			body:        `{"host": "example.com", "path": "/api/items"}`,
+			wantRoute:   "default/api",
+			wantBackend: "api-get",
+			wantReason:  "method match",
+		},
+		{
+			name:        "older route wins a full tie",
+			body:        `{"host": "example.com", "path": "/api/items", "method": "DELETE"}`,
+			wantRoute:   "default/catch-all",
+			wantBackend: "api-old",
+			wantReason:  "older route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).
+				WithObjects(gw, older, newer).Build()
+			handler := &DiagnosticsHandler{}
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(kubernetes.NewForTest(fakeClient)))
+			r.Post("/diagnostics/trace", handler.Trace)
+
+			req := httptest.NewRequest(http.MethodPost, "/diagnostics/trace", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp TraceResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !resp.Matched || resp.MatchedRoute == nil || *resp.MatchedRoute != tt.wantRoute {
+				t.Fatalf("matched route = %v, want %s (steps: %+v)", resp.MatchedRoute, tt.wantRoute, resp.Steps)
+			}
+			if len(resp.Steps) != 4 {
+				t.Fatalf("expected 4 steps, got %d", len(resp.Steps))
+			}
+			if msg := resp.Steps[2].Message; !strings.Contains(msg, tt.wantReason) {
+				t.Errorf("rule matching message %q does not explain %q", msg, tt.wantReason)
+			}
+			if msg := resp.Steps[3].Message; !strings.Contains(msg, tt.wantBackend) {
+				t.Errorf("backend selection message %q does not mention %q", msg, tt.wantBackend)
+			}
+		})
+	}
+}
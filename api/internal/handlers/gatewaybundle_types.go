@@ -53,6 +53,7 @@ type CertRefReq struct {
 // AllowedRoutesReq restricts which routes can attach.
 type AllowedRoutesReq struct {
 	Namespaces *RouteNamespacesReq `json:"namespaces,omitempty"`
+	Kinds      []RouteGroupKind    `json:"kinds,omitempty"`
 }
 
 // RouteNamespacesReq defines namespace selection for routes.
@@ -130,6 +131,14 @@ type GatewayBundleResponse struct {
 	ObservedSpecHash string                          `json:"observedSpecHash,omitempty"`
 	LastReconciledAt string                          `json:"lastReconciledAt,omitempty"`
 	CreatedAt        string                          `json:"createdAt"`
+	ResourceVersion  string                          `json:"resourceVersion,omitempty"`
+}
+
+// GatewayBundleListResponse is one page of GatewayBundles. Continue is set
+// when more results remain; pass it back as ?continue= to get the next page.
+type GatewayBundleListResponse struct {
+	Items    []GatewayBundleResponse `json:"items"`
+	Continue string                  `json:"continue,omitempty"`
 }
 
 // GatewayBundleListenerResp represents a listener in a GatewayBundle response.
@@ -201,3 +210,18 @@ type TLSSecretRefResp struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
 }
+
+// GatewayBundleValidationResponse is the result of a GatewayBundle dry run.
+type GatewayBundleValidationResponse struct {
+	Valid    bool                   `json:"valid"`
+	Problems []GatewayBundleProblem `json:"problems"`
+}
+
+// GatewayBundleProblem is a single reason a GatewayBundle would be rejected.
+// Source is "request" for console-side checks and "admission" for errors
+// returned by the API server's dry run.
+type GatewayBundleProblem struct {
+	Source  string `json:"source"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
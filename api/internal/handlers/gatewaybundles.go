@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
@@ -40,7 +47,8 @@ func (h *GatewayBundleHandler) getDynamicClient(r *http.Request) dynamic.Interfa
 	return k8s.DynamicClient()
 }
 
-// List returns all GatewayBundles across all namespaces.
+// List returns GatewayBundles across all namespaces. ?limit= and ?continue=
+// page through the results, and ?labelSelector= filters them.
 func (h *GatewayBundleHandler) List(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
 	if dc == nil {
@@ -48,19 +56,55 @@ func (h *GatewayBundleHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	list, err := dc.Resource(gatewayBundleGVR).Namespace("").List(r.Context(), metav1.ListOptions{})
+	opts, err := gatewayBundleListOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, err := dc.Resource(gatewayBundleGVR).Namespace("").List(r.Context(), opts)
+	if k8serrors.IsResourceExpired(err) {
+		writeError(w, http.StatusGone, "continue token has expired; restart the list without it")
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing gatewaybundles: %v", err))
 		return
 	}
 
-	resp := make([]GatewayBundleResponse, 0, len(list.Items))
+	resp := GatewayBundleListResponse{
+		Items:    make([]GatewayBundleResponse, 0, len(list.Items)),
+		Continue: list.GetContinue(),
+	}
 	for i := range list.Items {
-		resp = append(resp, toGatewayBundleResponse(&list.Items[i]))
+		resp.Items = append(resp.Items, toGatewayBundleResponse(&list.Items[i]))
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// gatewayBundleListOptions builds list options from the ?limit=, ?continue=,
+// and ?labelSelector= query parameters.
+func gatewayBundleListOptions(r *http.Request) (metav1.ListOptions, error) {
+	q := r.URL.Query()
+	opts := metav1.ListOptions{Continue: q.Get("continue")}
+
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || limit < 1 {
+			return opts, fmt.Errorf("limit must be a positive integer, got %q", raw)
+		}
+		opts.Limit = limit
+	}
+	if raw := q.Get("labelSelector"); raw != "" {
+		selector, err := labels.Parse(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		opts.LabelSelector = selector.String()
+	}
+	return opts, nil
+}
+
 // Get returns a single GatewayBundle by namespace and name.
 func (h *GatewayBundleHandler) Get(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
@@ -99,6 +143,14 @@ func (h *GatewayBundleHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := validateListeners(req.Listeners); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if writeListenerConflicts(w, req.Listeners) {
+		return
+	}
+
 	obj := toGatewayBundleUnstructured(req)
 	created, err := dc.Resource(gatewayBundleGVR).Namespace(req.Namespace).Create(r.Context(), obj, metav1.CreateOptions{})
 	if err != nil {
@@ -110,6 +162,94 @@ func (h *GatewayBundleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, resp)
 }
 
+// Validate runs Create's checks and a server-side dry-run create against the
+// cluster, so admission webhooks and CRD schema validation also run. Nothing is
+// persisted. Problems are returned with a 200; only failures to reach the
+// cluster are reported as errors.
+func (h *GatewayBundleHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	var req CreateGatewayBundleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	problems := gatewayBundleRequestProblems(req)
+	if len(problems) == 0 {
+		obj := toGatewayBundleUnstructured(req)
+		_, err := dc.Resource(gatewayBundleGVR).Namespace(req.Namespace).Create(r.Context(), obj, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		if err != nil {
+			admission, ok := admissionProblems(err)
+			if !ok {
+				writeError(w, http.StatusInternalServerError, fmt.Sprintf("dry-run creating gatewaybundle: %v", err))
+				return
+			}
+			problems = admission
+		}
+	}
+
+	writeJSON(w, http.StatusOK, GatewayBundleValidationResponse{
+		Valid:    len(problems) == 0,
+		Problems: problems,
+	})
+}
+
+// gatewayBundleRequestProblems applies the same checks as Create and returns
+// each failure as a problem instead of stopping at the first.
+func gatewayBundleRequestProblems(req CreateGatewayBundleRequest) []GatewayBundleProblem {
+	problems := make([]GatewayBundleProblem, 0)
+	required := []struct {
+		field   string
+		missing bool
+	}{
+		{"name", req.Name == ""},
+		{"namespace", req.Namespace == ""},
+		{"gatewayClassName", req.GatewayClassName == ""},
+		{"listeners", len(req.Listeners) == 0},
+	}
+	for _, f := range required {
+		if f.missing {
+			problems = append(problems, GatewayBundleProblem{Source: "request", Field: f.field, Message: f.field + " is required"})
+		}
+	}
+
+	for _, msg := range findListenerProblems(req.Listeners) {
+		problems = append(problems, GatewayBundleProblem{Source: "request", Field: "listeners", Message: msg})
+	}
+	for _, c := range findListenerConflicts(req.Listeners) {
+		problems = append(problems, GatewayBundleProblem{Source: "request", Field: "listeners", Message: c.Reason})
+	}
+	return problems
+}
+
+// admissionProblems converts a rejected dry-run into problems, one per status
+// cause when the API server provides them. It returns false for errors that
+// are not client-side rejections, such as an unreachable API server.
+func admissionProblems(err error) ([]GatewayBundleProblem, bool) {
+	var apiStatus k8serrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return nil, false
+	}
+	status := apiStatus.Status()
+	if status.Code < 400 || status.Code >= 500 {
+		return nil, false
+	}
+
+	if status.Details == nil || len(status.Details.Causes) == 0 {
+		return []GatewayBundleProblem{{Source: "admission", Message: status.Message}}, true
+	}
+	problems := make([]GatewayBundleProblem, 0, len(status.Details.Causes))
+	for _, cause := range status.Details.Causes {
+		problems = append(problems, GatewayBundleProblem{Source: "admission", Field: cause.Field, Message: cause.Message})
+	}
+	return problems, true
+}
+
 // Update modifies an existing GatewayBundle by namespace and name.
 func (h *GatewayBundleHandler) Update(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
@@ -148,6 +288,14 @@ func (h *GatewayBundleHandler) Update(w http.ResponseWriter, r *http.Request) {
 		TLS:              req.TLS,
 	}
 
+	if err := validateListeners(createReq.Listeners); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if writeListenerConflicts(w, createReq.Listeners) {
+		return
+	}
+
 	beforeResp := toGatewayBundleResponse(existing)
 	updated := toGatewayBundleUnstructured(createReq)
 	updated.SetNamespace(ns)
@@ -166,6 +314,122 @@ func (h *GatewayBundleHandler) Update(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, afterResp)
 }
 
+// Patch applies a JSON merge patch (RFC 7386) to a GatewayBundle's spec.
+// Fields the patch omits are kept, and null removes a field. Send the
+// resourceVersion from a previous response in If-Match to reject the patch
+// with a 409 when the bundle changed in the meantime; the update itself is
+// also conditional on the version that was read.
+func (h *GatewayBundleHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	// utiljson decodes whole numbers as int64, which unstructured helpers
+	// such as NestedInt64 expect.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "reading request body: "+err.Error())
+		return
+	}
+	var patch map[string]any
+	if err := utiljson.Unmarshal(body, &patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON merge patch: "+err.Error())
+		return
+	}
+
+	existing, err := dc.Resource(gatewayBundleGVR).Namespace(ns).Get(r.Context(), name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("gatewaybundle %s/%s not found", ns, name))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("getting gatewaybundle %s/%s: %v", ns, name, err))
+		return
+	}
+	if rv := strings.Trim(r.Header.Get("If-Match"), `"`); rv != "" && rv != existing.GetResourceVersion() {
+		writeError(w, http.StatusConflict, fmt.Sprintf("gatewaybundle %s/%s has changed: resourceVersion is %s, not %s", ns, name, existing.GetResourceVersion(), rv))
+		return
+	}
+
+	spec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	patched, _ := mergePatch(spec, patch).(map[string]any)
+
+	var check CreateGatewayBundleRequest
+	if err := remarshal(patched, &check); err != nil {
+		writeError(w, http.StatusBadRequest, "patched spec is invalid: "+err.Error())
+		return
+	}
+	if check.GatewayClassName == "" || len(check.Listeners) == 0 {
+		writeError(w, http.StatusBadRequest, "gatewayClassName and at least one listener are required")
+		return
+	}
+	if err := validateListeners(check.Listeners); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if writeListenerConflicts(w, check.Listeners) {
+		return
+	}
+
+	updated := existing.DeepCopy()
+	if err := unstructured.SetNestedMap(updated.Object, patched, "spec"); err != nil {
+		writeError(w, http.StatusBadRequest, "patched spec is invalid: "+err.Error())
+		return
+	}
+	result, err := dc.Resource(gatewayBundleGVR).Namespace(ns).Update(r.Context(), updated, metav1.UpdateOptions{})
+	if k8serrors.IsConflict(err) {
+		writeError(w, http.StatusConflict, fmt.Sprintf("gatewaybundle %s/%s was modified concurrently; reload and retry", ns, name))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("patching gatewaybundle %s/%s: %v", ns, name, err))
+		return
+	}
+	afterResp := toGatewayBundleResponse(result)
+	auditLog(h.Store, r.Context(), "patch", "GatewayBundle", name, ns, toGatewayBundleResponse(existing), afterResp)
+	writeJSON(w, http.StatusOK, afterResp)
+}
+
+// mergePatch applies an RFC 7386 JSON merge patch to target and returns the
+// result. Objects are merged recursively, null deletes a key, and any other
+// value replaces the target outright. target is not modified.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	out := make(map[string]any, len(t))
+	for k, v := range t {
+		out[k] = v
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		out[k] = mergePatch(out[k], v)
+	}
+	return out
+}
+
+// remarshal converts v into out by round-tripping it through JSON.
+func remarshal(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
 // Delete removes a GatewayBundle by namespace and name.
 func (h *GatewayBundleHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
@@ -221,13 +485,46 @@ func (h *GatewayBundleHandler) GetStatus(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, statusResp)
 }
 
+// History returns the reconcile history of a GatewayBundle: the operator's
+// child events, condition transitions, and current child states, oldest
+// first. ?limit= bounds the entries returned.
+func (h *GatewayBundleHandler) History(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+	limit, err := parseHistoryLimit(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	obj, err := dc.Resource(gatewayBundleGVR).Namespace(ns).Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("getting gatewaybundle %s/%s: %v", ns, name, err))
+		return
+	}
+
+	events, err := listObjectEvents(r.Context(), dc, obj)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, buildReconcileHistory(obj, events, limit))
+}
+
 // toGatewayBundleResponse converts an unstructured GatewayBundle to a response type.
 func toGatewayBundleResponse(obj *unstructured.Unstructured) GatewayBundleResponse {
 	resp := GatewayBundleResponse{
-		Name:      obj.GetName(),
-		Namespace: obj.GetNamespace(),
-		Labels:    obj.GetLabels(),
-		CreatedAt: obj.GetCreationTimestamp().UTC().Format("2006-01-02T15:04:05Z"),
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		Labels:          obj.GetLabels(),
+		CreatedAt:       obj.GetCreationTimestamp().UTC().Format("2006-01-02T15:04:05Z"),
+		ResourceVersion: obj.GetResourceVersion(),
 	}
 
 	// Annotations (filter out kubectl.kubernetes.io managed fields)
@@ -287,6 +584,17 @@ func toGatewayBundleResponse(obj *unstructured.Unstructured) GatewayBundleRespon
 					}
 					ar.Namespaces = ns
 				}
+				kinds, _, _ := unstructured.NestedSlice(arMap, "kinds")
+				for _, k := range kinds {
+					kMap, ok := k.(map[string]any)
+					if !ok {
+						continue
+					}
+					rgk := RouteGroupKind{}
+					rgk.Group, _, _ = unstructured.NestedString(kMap, "group")
+					rgk.Kind, _, _ = unstructured.NestedString(kMap, "kind")
+					ar.Kinds = append(ar.Kinds, rgk)
+				}
 				lr.AllowedRoutes = ar
 			}
 
@@ -380,6 +688,7 @@ func toGatewayBundleResponse(obj *unstructured.Unstructured) GatewayBundleRespon
 			cs.Kind, _, _ = unstructured.NestedString(childMap, "kind")
 			cs.Name, _, _ = unstructured.NestedString(childMap, "name")
 			cs.Ready, _, _ = unstructured.NestedBool(childMap, "ready")
+			cs.Reason, _, _ = unstructured.NestedString(childMap, "reason")
 			cs.Message, _, _ = unstructured.NestedString(childMap, "message")
 			resp.Children = append(resp.Children, cs)
 		}
@@ -448,6 +757,18 @@ func toGatewayBundleUnstructured(req CreateGatewayBundleRequest) *unstructured.U
 			}
 			lMap["allowedRoutes"] = arMap
 		}
+		if kinds := listenerRouteKinds(l); len(kinds) > 0 {
+			arMap, _ := lMap["allowedRoutes"].(map[string]any)
+			if arMap == nil {
+				arMap = map[string]any{}
+			}
+			kindList := make([]any, 0, len(kinds))
+			for _, k := range kinds {
+				kindList = append(kindList, map[string]any{"group": k.Group, "kind": k.Kind})
+			}
+			arMap["kinds"] = kindList
+			lMap["allowedRoutes"] = arMap
+		}
 		listeners = append(listeners, lMap)
 	}
 
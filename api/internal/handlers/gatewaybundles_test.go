@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGatewayBundleHandler_List(t *testing.T) {
+	newClient := func() *fakedynamic.FakeDynamicClient {
+		return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			gatewayBundleGVR: "GatewayBundleList",
+		})
+	}
+	list := func(handler *GatewayBundleHandler, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/gatewaybundles"+query, nil)
+		w := httptest.NewRecorder()
+		handler.List(w, req)
+		return w
+	}
+
+	t.Run("returns the continue token", func(t *testing.T) {
+		dc := newClient()
+		var selector string
+		dc.PrependReactor("list", "gatewaybundles", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			selector = action.(k8stesting.ListActionImpl).ListOptions.LabelSelector
+			bundle := toGatewayBundleUnstructured(CreateGatewayBundleRequest{Name: "edge", Namespace: "default", GatewayClassName: "nginx", Labels: map[string]string{"team": "payments"}})
+			page := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*bundle}}
+			page.SetContinue("next-page")
+			return true, page, nil
+		})
+
+		w := list(&GatewayBundleHandler{DynamicClient: dc}, "?limit=1&labelSelector=team%3Dpayments")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if selector != "team=payments" {
+			t.Errorf("labelSelector = %q, want team=payments", selector)
+		}
+		var resp GatewayBundleListResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if resp.Continue != "next-page" || len(resp.Items) != 1 || resp.Items[0].Name != "edge" {
+			t.Errorf("unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("empty list is an empty page", func(t *testing.T) {
+		w := list(&GatewayBundleHandler{DynamicClient: newClient()}, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if body := strings.TrimSpace(w.Body.String()); body != `{"items":[]}` {
+			t.Errorf("unexpected body %s", body)
+		}
+	})
+
+	t.Run("expired continue token is gone", func(t *testing.T) {
+		dc := newClient()
+		dc.PrependReactor("list", "gatewaybundles", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewResourceExpired("continue token too old")
+		})
+		if w := list(&GatewayBundleHandler{DynamicClient: dc}, "?continue=stale"); w.Code != http.StatusGone {
+			t.Errorf("expected status 410, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	for _, query := range []string{"?limit=0", "?limit=ten", "?labelSelector=team%3D%3D%3D"} {
+		t.Run("rejects "+query, func(t *testing.T) {
+			if w := list(&GatewayBundleHandler{DynamicClient: newClient()}, query); w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGatewayBundleListOptions(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/gatewaybundles?limit=50&continue=abc&labelSelector=team+in+(a,b)", nil)
+	opts, err := gatewayBundleListOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := metav1.ListOptions{Limit: 50, Continue: "abc", LabelSelector: "team in (a,b)"}
+	if opts != want {
+		t.Errorf("gatewayBundleListOptions = %+v, want %+v", opts, want)
+	}
+}
+
+func TestGatewayBundleHandler_Validate(t *testing.T) {
+	newClient := func() *fakedynamic.FakeDynamicClient {
+		return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			gatewayBundleGVR: "GatewayBundleList",
+		})
+	}
+	validate := func(t *testing.T, handler *GatewayBundleHandler, body string) GatewayBundleValidationResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/gatewaybundles/validate", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.Validate(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp GatewayBundleValidationResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return resp
+	}
+	const validBundle = `{"name":"edge","namespace":"default","gatewayClassName":"nginx","listeners":[{"name":"http","port":80,"protocol":"HTTP"}]}`
+
+	t.Run("valid bundle is dry-run only", func(t *testing.T) {
+		dc := newClient()
+		resp := validate(t, &GatewayBundleHandler{DynamicClient: dc}, validBundle)
+		if !resp.Valid || len(resp.Problems) != 0 {
+			t.Fatalf("expected valid bundle, got %+v", resp)
+		}
+
+		var dryRun []string
+		for _, action := range dc.Actions() {
+			if create, ok := action.(k8stesting.CreateActionImpl); ok {
+				dryRun = create.CreateOptions.DryRun
+			}
+		}
+		if !slices.Equal(dryRun, []string{metav1.DryRunAll}) {
+			t.Errorf("expected create with DryRun=All, got %v", dryRun)
+		}
+	})
+
+	t.Run("request problems skip the dry run", func(t *testing.T) {
+		dc := newClient()
+		resp := validate(t, &GatewayBundleHandler{DynamicClient: dc}, `{"name":"edge","namespace":"default","listeners":[{"name":"a","port":80,"protocol":"HTTP"},{"name":"a","port":0,"protocol":"HTTP"}]}`)
+		if resp.Valid {
+			t.Fatal("expected invalid bundle")
+		}
+		var fields []string
+		for _, p := range resp.Problems {
+			if p.Source != "request" {
+				t.Errorf("expected request problem, got %+v", p)
+			}
+			fields = append(fields, p.Field)
+		}
+		if !slices.Contains(fields, "gatewayClassName") || !slices.Contains(fields, "listeners") {
+			t.Errorf("expected gatewayClassName and listeners problems, got %+v", resp.Problems)
+		}
+		if len(dc.Actions()) != 0 {
+			t.Errorf("expected no API calls, got %d", len(dc.Actions()))
+		}
+	})
+
+	t.Run("admission causes are reported per field", func(t *testing.T) {
+		dc := newClient()
+		dc.PrependReactor("create", "gatewaybundles", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewInvalid(schema.GroupKind{Group: gatewayBundleGVR.Group, Kind: "GatewayBundle"}, "edge", field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "gatewayClassName"), "nginx", []string{"nginx-plus"}),
+			})
+		})
+		resp := validate(t, &GatewayBundleHandler{DynamicClient: dc}, validBundle)
+		if resp.Valid || len(resp.Problems) != 1 {
+			t.Fatalf("expected one admission problem, got %+v", resp)
+		}
+		if p := resp.Problems[0]; p.Source != "admission" || p.Field != "spec.gatewayClassName" {
+			t.Errorf("unexpected problem: %+v", p)
+		}
+	})
+}
+
+func TestGatewayBundleHandler_Patch(t *testing.T) {
+	existing := toGatewayBundleUnstructured(CreateGatewayBundleRequest{
+		Name:             "edge",
+		Namespace:        "default",
+		GatewayClassName: "nginx",
+		Listeners: []GatewayBundleListenerReq{
+			{Name: "http", Port: 80, Protocol: "HTTP", Hostname: "example.com"},
+		},
+		WAF: &WAFReq{Enabled: true, PolicyRef: "strict"},
+	})
+	existing.SetResourceVersion("7")
+
+	newHandler := func() (*GatewayBundleHandler, *fakedynamic.FakeDynamicClient) {
+		dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			gatewayBundleGVR: "GatewayBundleList",
+		}, existing.DeepCopy())
+		return &GatewayBundleHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}, dc
+	}
+	patch := func(handler *GatewayBundleHandler, body, ifMatch string) *httptest.ResponseRecorder {
+		r := chi.NewRouter()
+		r.Patch("/gatewaybundles/{namespace}/{name}", handler.Patch)
+		req := httptest.NewRequest(http.MethodPatch, "/gatewaybundles/default/edge", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("merges into the existing spec", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := patch(handler, `{"gatewayClassName":"nginx-plus","waf":null}`, `"7"`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp GatewayBundleResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if resp.GatewayClassName != "nginx-plus" {
+			t.Errorf("gatewayClassName = %q, want nginx-plus", resp.GatewayClassName)
+		}
+		if len(resp.Listeners) != 1 || resp.Listeners[0].Port != 80 || resp.Listeners[0].Hostname != "example.com" {
+			t.Errorf("expected listeners to be preserved, got %+v", resp.Listeners)
+		}
+		if resp.WAF != nil {
+			t.Errorf("expected null to remove waf, got %+v", resp.WAF)
+		}
+	})
+
+	t.Run("stale If-Match is a conflict", func(t *testing.T) {
+		handler, _ := newHandler()
+		if w := patch(handler, `{"gatewayClassName":"nginx-plus"}`, "6"); w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("concurrent update is a conflict", func(t *testing.T) {
+		handler, dc := newHandler()
+		dc.PrependReactor("update", "gatewaybundles", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewConflict(gatewayBundleGVR.GroupResource(), "edge", nil)
+		})
+		if w := patch(handler, `{"gatewayClassName":"nginx-plus"}`, ""); w.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("patched listeners are validated", func(t *testing.T) {
+		handler, _ := newHandler()
+		w := patch(handler, `{"listeners":[{"name":"https","port":443,"protocol":"HTTPS"}]}`, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestMergePatch(t *testing.T) {
+	target := map[string]any{"a": "b", "c": map[string]any{"d": "e", "f": "g"}, "list": []any{"x"}}
+	got := mergePatch(target, map[string]any{"a": "z", "c": map[string]any{"f": nil}, "list": []any{"y"}, "new": int64(1)})
+	want := map[string]any{"a": "z", "c": map[string]any{"d": "e"}, "list": []any{"y"}, "new": int64(1)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePatch = %v, want %v", got, want)
+	}
+	if target["a"] != "b" {
+		t.Errorf("target was modified: %v", target)
+	}
+}
@@ -130,6 +130,9 @@ func (h *GatewayHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Convert the gateway request to a GatewayBundle create request.
 	bundleReq := gatewayReqToBundle(req)
+	if writeListenerConflicts(w, bundleReq.Listeners) {
+		return
+	}
 	obj := toGatewayBundleUnstructured(bundleReq)
 
 	created, err := dc.Resource(gatewayBundleGVR).Namespace(req.Namespace).Create(r.Context(), obj, metav1.CreateOptions{})
@@ -168,6 +171,9 @@ func (h *GatewayHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Convert to GatewayBundle create request for the unstructured builder.
 	bundleReq := gatewayUpdateReqToBundle(name, ns, req)
+	if writeListenerConflicts(w, bundleReq.Listeners) {
+		return
+	}
 	updated := toGatewayBundleUnstructured(bundleReq)
 	updated.SetNamespace(ns)
 	updated.SetName(name)
@@ -214,7 +220,7 @@ func (h *GatewayHandler) Deploy(w http.ResponseWriter, r *http.Request) {
 func writeNotImplemented(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusNotImplemented)
-	json.NewEncoder(w).Encode(map[string]string{"error": "not implemented"})
+	json.NewEncoder(w).Encode(errorBody(w, "not implemented"))
 }
 
 // gatewayReqToBundle converts a CreateGatewayRequest to a CreateGatewayBundleRequest.
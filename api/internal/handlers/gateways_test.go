@@ -15,6 +15,7 @@ import (
 	fakedynamic "k8s.io/client-go/dynamic/fake"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
@@ -29,6 +30,9 @@ func setupScheme(t *testing.T) *runtime.Scheme {
 	if err := gatewayv1.Install(scheme); err != nil {
 		t.Fatalf("failed to add gateway-api scheme: %v", err)
 	}
+	if err := gatewayv1alpha2.Install(scheme); err != nil {
+		t.Fatalf("failed to add gateway-api v1alpha2 scheme: %v", err)
+	}
 	return scheme
 }
 
@@ -409,6 +413,43 @@ func TestGatewayHandler_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("conflicting listeners", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		dc := newFakeDynamicClient()
+		k8sClient := kubernetes.NewForTestWithDynamic(fakeClient, dc)
+
+		r := chi.NewRouter()
+		r.Use(contextMiddleware(k8sClient))
+		r.Post("/api/v1/gateways", handler.Create)
+
+		body := `{
+			"name": "my-gateway",
+			"namespace": "default",
+			"gatewayClassName": "nginx",
+			"listeners": [
+				{"name": "http", "port": 80, "protocol": "HTTP"},
+				{"name": "tcp", "port": 80, "protocol": "TCP"}
+			]
+		}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/gateways", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var resp listenerConflictResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Conflicts) != 1 || resp.Conflicts[0].Port != 80 {
+			t.Errorf("expected one conflict on port 80, got %+v", resp.Conflicts)
+		}
+	})
+
 	t.Run("invalid JSON", func(t *testing.T) {
 		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 		dc := newFakeDynamicClient()
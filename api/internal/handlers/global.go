@@ -2,10 +2,16 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	mc "github.com/kubenetlabs/ngc/api/internal/multicluster"
 )
@@ -34,23 +40,66 @@ type clusterRoute struct {
 
 // gpuClusterCapacity represents GPU capacity for a single cluster.
 type gpuClusterCapacity struct {
-	ClusterName   string         `json:"clusterName"`
-	ClusterRegion string         `json:"clusterRegion"`
-	TotalGPUs     int            `json:"totalGPUs"`
-	AllocatedGPUs int            `json:"allocatedGPUs"`
-	GPUTypes      map[string]int `json:"gpuTypes,omitempty"`
+	ClusterName        string         `json:"clusterName"`
+	ClusterRegion      string         `json:"clusterRegion"`
+	Reported           bool           `json:"reported"` // false until the cluster's agent sends GPU capacity
+	TotalGPUs          int            `json:"totalGPUs"`
+	AllocatedGPUs      int            `json:"allocatedGPUs"`
+	TotalMIGSlices     int            `json:"totalMIGSlices,omitempty"`
+	AllocatedMIGSlices int            `json:"allocatedMIGSlices,omitempty"`
+	GPUTypes           map[string]int `json:"gpuTypes,omitempty"`
 }
 
 // globalGPUCapacity is the aggregated GPU capacity across all clusters.
 type globalGPUCapacity struct {
-	TotalGPUs     int                  `json:"totalGPUs"`
-	AllocatedGPUs int                  `json:"allocatedGPUs"`
-	Clusters      []gpuClusterCapacity `json:"clusters"`
+	TotalGPUs          int                  `json:"totalGPUs"`
+	AllocatedGPUs      int                  `json:"allocatedGPUs"`
+	TotalMIGSlices     int                  `json:"totalMIGSlices,omitempty"`
+	AllocatedMIGSlices int                  `json:"allocatedMIGSlices,omitempty"`
+	AllocatedPercent   float64              `json:"allocatedPercent"` // allocatedGPUs / totalGPUs, 0 with no GPUs
+	GPUTypes           map[string]int       `json:"gpuTypes,omitempty"`
+	Clusters           []gpuClusterCapacity `json:"clusters"`
+}
+
+// selectClusterNames returns the clusters a fan-out request targets: those
+// matching its ?clusterSelector= label selector, or every cluster when it is
+// not set. A selector needs a provider that implements cluster.LabelSelector,
+// which CRD-based multi-cluster mode does.
+func selectClusterNames(mgr cluster.Provider, r *http.Request) ([]string, error) {
+	selector := r.URL.Query().Get("clusterSelector")
+	if selector == "" {
+		return mgr.Names(), nil
+	}
+	ls, ok := mgr.(cluster.LabelSelector)
+	if !ok {
+		return nil, errors.New("clusterSelector requires multi-cluster mode")
+	}
+	return ls.SelectNames(selector)
+}
+
+// selectClusters filters mgr.List to the clusters selectClusterNames targets.
+func selectClusters(mgr cluster.Provider, r *http.Request) ([]cluster.ClusterInfo, error) {
+	clusters := mgr.List(r.Context())
+	if r.URL.Query().Get("clusterSelector") == "" {
+		return clusters, nil
+	}
+	names, err := selectClusterNames(mgr, r)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(clusters, func(ci cluster.ClusterInfo) bool {
+		return !slices.Contains(names, ci.Name)
+	}), nil
 }
 
-// Gateways lists gateways from all clusters in parallel.
+// Gateways lists gateways from all clusters, or those matching
+// ?clusterSelector=, in parallel.
 func (h *GlobalHandler) Gateways(w http.ResponseWriter, r *http.Request) {
-	clusters := h.Manager.List(r.Context())
+	clusters, err := selectClusters(h.Manager, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	ns := r.URL.Query().Get("namespace")
 
 	type result struct {
@@ -107,9 +156,14 @@ func (h *GlobalHandler) Gateways(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, allGateways)
 }
 
-// Routes lists HTTP routes from all clusters in parallel.
+// Routes lists HTTP routes from all clusters, or those matching
+// ?clusterSelector=, in parallel.
 func (h *GlobalHandler) Routes(w http.ResponseWriter, r *http.Request) {
-	clusters := h.Manager.List(r.Context())
+	clusters, err := selectClusters(h.Manager, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	type result struct {
 		clusterName   string
@@ -165,7 +219,8 @@ func (h *GlobalHandler) Routes(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, allRoutes)
 }
 
-// GPUCapacity aggregates GPU capacity across all clusters.
+// GPUCapacity aggregates GPU capacity across all clusters, or those matching
+// ?clusterSelector=.
 func (h *GlobalHandler) GPUCapacity(w http.ResponseWriter, r *http.Request) {
 	if h.Pool == nil {
 		writeJSON(w, http.StatusOK, globalGPUCapacity{Clusters: []gpuClusterCapacity{}})
@@ -173,29 +228,64 @@ func (h *GlobalHandler) GPUCapacity(w http.ResponseWriter, r *http.Request) {
 	}
 
 	clusterClients := h.Pool.List()
-	var totalGPUs, allocatedGPUs int
-	clusterCapacities := make([]gpuClusterCapacity, 0, len(clusterClients))
+	if selector := r.URL.Query().Get("clusterSelector"); selector != "" {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid cluster selector %q: %v", selector, err))
+			return
+		}
+		clusterClients = h.Pool.Select(sel)
+	}
+	slices.SortFunc(clusterClients, func(a, b *mc.ClusterClient) int { return strings.Compare(a.Name, b.Name) })
 
+	writeJSON(w, http.StatusOK, sumGPUCapacity(clusterClients))
+}
+
+// sumGPUCapacity totals the GPU capacity the clusters' agents last reported.
+// Clusters without a report count as zero and are marked reported: false.
+func sumGPUCapacity(clusterClients []*mc.ClusterClient) globalGPUCapacity {
+	fleet := globalGPUCapacity{Clusters: make([]gpuClusterCapacity, 0, len(clusterClients))}
 	for _, cc := range clusterClients {
-		cap := queryGPUCapacity(r.Context(), cc)
-		totalGPUs += cap.TotalGPUs
-		allocatedGPUs += cap.AllocatedGPUs
-		clusterCapacities = append(clusterCapacities, cap)
+		cap := clusterGPUCapacity(cc)
+		fleet.TotalGPUs += cap.TotalGPUs
+		fleet.AllocatedGPUs += cap.AllocatedGPUs
+		fleet.TotalMIGSlices += cap.TotalMIGSlices
+		fleet.AllocatedMIGSlices += cap.AllocatedMIGSlices
+		for gpuType, n := range cap.GPUTypes {
+			if fleet.GPUTypes == nil {
+				fleet.GPUTypes = make(map[string]int)
+			}
+			fleet.GPUTypes[gpuType] += n
+		}
+		fleet.Clusters = append(fleet.Clusters, cap)
 	}
-
-	writeJSON(w, http.StatusOK, globalGPUCapacity{
-		TotalGPUs:     totalGPUs,
-		AllocatedGPUs: allocatedGPUs,
-		Clusters:      clusterCapacities,
-	})
+	if fleet.TotalGPUs > 0 {
+		fleet.AllocatedPercent = float64(fleet.AllocatedGPUs) / float64(fleet.TotalGPUs) * 100
+	}
+	return fleet
 }
 
-// queryGPUCapacity queries a single cluster for GPU capacity.
-func queryGPUCapacity(_ context.Context, cc *mc.ClusterClient) gpuClusterCapacity {
-	return gpuClusterCapacity{
+// clusterGPUCapacity returns the GPU capacity from a cluster's last agent
+// heartbeat.
+func clusterGPUCapacity(cc *mc.ClusterClient) gpuClusterCapacity {
+	cap := gpuClusterCapacity{
 		ClusterName:   cc.Name,
 		ClusterRegion: cc.Region,
-		TotalGPUs:     0,
-		AllocatedGPUs: 0,
 	}
+	gpu := cc.GPU()
+	if gpu == nil {
+		return cap
+	}
+	cap.Reported = true
+	cap.TotalGPUs = int(gpu.TotalGPUs)
+	cap.AllocatedGPUs = int(gpu.AllocatedGPUs)
+	cap.TotalMIGSlices = int(gpu.TotalMIGSlices)
+	cap.AllocatedMIGSlices = int(gpu.AllocatedMIGSlices)
+	if len(gpu.GPUTypes) > 0 {
+		cap.GPUTypes = make(map[string]int, len(gpu.GPUTypes))
+		for gpuType, n := range gpu.GPUTypes {
+			cap.GPUTypes[gpuType] = int(n)
+		}
+	}
+	return cap
 }
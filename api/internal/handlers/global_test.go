@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubenetlabs/ngc/api/internal/multicluster"
+)
+
+func TestGlobalHandler_GPUCapacity(t *testing.T) {
+	pool := multicluster.NewPoolForTest(
+		&multicluster.ClusterClient{Name: "west", Region: "us-west-2", Labels: map[string]string{"env": "prod"}, GPUCapacity: &multicluster.GPUCapacitySummary{
+			TotalGPUs: 8, AllocatedGPUs: 6, GPUTypes: map[string]int32{"H100": 8},
+		}},
+		&multicluster.ClusterClient{Name: "east", Region: "us-east-1", Labels: map[string]string{"env": "prod"}, GPUCapacity: &multicluster.GPUCapacitySummary{
+			TotalGPUs: 8, AllocatedGPUs: 2, TotalMIGSlices: 7, AllocatedMIGSlices: 3,
+			GPUTypes: map[string]int32{"H100": 4, "A100": 4, "A100/mig-1g.10gb": 7},
+		}},
+		// No heartbeat yet.
+		&multicluster.ClusterClient{Name: "dev", Labels: map[string]string{"env": "dev"}},
+	)
+	handler := &GlobalHandler{Pool: pool}
+
+	w := httptest.NewRecorder()
+	handler.GPUCapacity(w, httptest.NewRequest(http.MethodGet, "/global/gpu-capacity", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got globalGPUCapacity
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if got.TotalGPUs != 16 || got.AllocatedGPUs != 8 || got.AllocatedPercent != 50 {
+		t.Errorf("expected 8/16 GPUs allocated (50%%), got %d/%d (%v%%)", got.AllocatedGPUs, got.TotalGPUs, got.AllocatedPercent)
+	}
+	if got.TotalMIGSlices != 7 || got.AllocatedMIGSlices != 3 {
+		t.Errorf("expected 3/7 MIG slices allocated, got %d/%d", got.AllocatedMIGSlices, got.TotalMIGSlices)
+	}
+	wantTypes := map[string]int{"H100": 12, "A100": 4, "A100/mig-1g.10gb": 7}
+	if !reflect.DeepEqual(got.GPUTypes, wantTypes) {
+		t.Errorf("expected gpuTypes %v, got %v", wantTypes, got.GPUTypes)
+	}
+
+	var names []string
+	for _, c := range got.Clusters {
+		names = append(names, c.ClusterName)
+		if c.Reported != (c.ClusterName != "dev") {
+			t.Errorf("cluster %s: unexpected reported=%v", c.ClusterName, c.Reported)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"dev", "east", "west"}) {
+		t.Errorf("expected clusters sorted by name, got %v", names)
+	}
+
+	w = httptest.NewRecorder()
+	handler.GPUCapacity(w, httptest.NewRequest(http.MethodGet, "/global/gpu-capacity?clusterSelector=env%3Ddev", nil))
+	got = globalGPUCapacity{}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Clusters) != 1 || got.TotalGPUs != 0 || got.AllocatedPercent != 0 {
+		t.Errorf("expected only the dev cluster with no GPUs, got %+v", got)
+	}
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+)
+
+const (
+	// gpuResource is the extended resource for whole NVIDIA GPUs.
+	gpuResource corev1.ResourceName = "nvidia.com/gpu"
+	// migResourcePrefix prefixes MIG slice resources, e.g. nvidia.com/mig-1g.10gb.
+	migResourcePrefix = "nvidia.com/mig-"
+	// gpuProductLabel is set on GPU nodes by GPU feature discovery.
+	gpuProductLabel = "nvidia.com/gpu.product"
+)
+
+// GPUHandler handles GPU inventory API requests.
+type GPUHandler struct{}
+
+// GPUSliceCountResponse is the capacity and allocation of one MIG profile on a node.
+type GPUSliceCountResponse struct {
+	Capacity    int64 `json:"capacity"`
+	Allocatable int64 `json:"allocatable"`
+	Allocated   int64 `json:"allocated"`
+}
+
+// GPUConsumerResponse is a pod requesting GPUs or MIG slices on a node.
+type GPUConsumerResponse struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Pool      string `json:"pool,omitempty"`
+	Resource  string `json:"resource"` // "nvidia.com/gpu" or "nvidia.com/mig-<profile>"
+	Count     int64  `json:"count"`
+}
+
+// GPUNodeResponse is one GPU node with its capacity, allocation, and consumers.
+type GPUNodeResponse struct {
+	Name        string                           `json:"name"`
+	Product     string                           `json:"product,omitempty"`
+	Capacity    int64                            `json:"capacity"`
+	Allocatable int64                            `json:"allocatable"`
+	Allocated   int64                            `json:"allocated"`
+	Available   int64                            `json:"available"`
+	MIG         map[string]GPUSliceCountResponse `json:"mig,omitempty"` // keyed by profile, e.g. "mig-1g.10gb"
+	Consumers   []GPUConsumerResponse            `json:"consumers"`
+}
+
+// ListNodes returns every node advertising GPUs or MIG slices, with the
+// amount allocated to scheduled, non-terminated pods and the pools those pods
+// belong to.
+func (h *GPUHandler) ListNodes(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ctx := r.Context()
+	nodes, err := k8s.ListNodes(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing nodes: %v", err))
+		return
+	}
+	pods, err := k8s.ListPods(ctx, "")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing pods: %v", err))
+		return
+	}
+
+	// Pool attribution is best-effort: without InferenceStacks, consumers are
+	// still reported without a pool.
+	var stacks []unstructured.Unstructured
+	if dc := k8s.DynamicClient(); dc != nil {
+		list, err := dc.Resource(inferenceStackGVR).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			slog.WarnContext(ctx, "listing inferencestacks for gpu pool attribution", "error", err)
+		} else {
+			stacks = list.Items
+		}
+	}
+
+	writeJSON(w, http.StatusOK, gpuNodeInventory(nodes, pods, stacks))
+}
+
+// gpuNodeInventory builds the GPU node list from node capacity and pod GPU
+// requests. Nodes without GPUs or MIG slices are omitted.
+func gpuNodeInventory(nodes []corev1.Node, pods []corev1.Pod, stacks []unstructured.Unstructured) []GPUNodeResponse {
+	byName := make(map[string]*GPUNodeResponse, len(nodes))
+	for _, node := range nodes {
+		resp := &GPUNodeResponse{
+			Name:      node.Name,
+			Product:   node.Labels[gpuProductLabel],
+			Consumers: []GPUConsumerResponse{},
+		}
+		isGPUNode := false
+		for name, q := range node.Status.Capacity {
+			if q.Value() <= 0 {
+				continue
+			}
+			allocatable := node.Status.Allocatable[name]
+			switch {
+			case name == gpuResource:
+				resp.Capacity = q.Value()
+				resp.Allocatable = allocatable.Value()
+				isGPUNode = true
+			case strings.HasPrefix(string(name), migResourcePrefix):
+				if resp.MIG == nil {
+					resp.MIG = make(map[string]GPUSliceCountResponse)
+				}
+				resp.MIG[migProfileName(name)] = GPUSliceCountResponse{Capacity: q.Value(), Allocatable: allocatable.Value()}
+				isGPUNode = true
+			}
+		}
+		if isGPUNode {
+			byName[node.Name] = resp
+		}
+	}
+
+	for _, pod := range pods {
+		resp, ok := byName[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for name, count := range podGPURequests(pod) {
+			if name == gpuResource {
+				resp.Allocated += count
+			} else {
+				if resp.MIG == nil {
+					resp.MIG = make(map[string]GPUSliceCountResponse)
+				}
+				profile := migProfileName(name)
+				slices := resp.MIG[profile]
+				slices.Allocated += count
+				resp.MIG[profile] = slices
+			}
+			resp.Consumers = append(resp.Consumers, GPUConsumerResponse{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Pool:      podPool(pod, stacks),
+				Resource:  string(name),
+				Count:     count,
+			})
+		}
+	}
+
+	result := make([]GPUNodeResponse, 0, len(byName))
+	for _, resp := range byName {
+		resp.Available = max(resp.Allocatable-resp.Allocated, 0)
+		sort.Slice(resp.Consumers, func(i, j int) bool {
+			a, b := resp.Consumers[i], resp.Consumers[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			if a.Pod != b.Pod {
+				return a.Pod < b.Pod
+			}
+			return a.Resource < b.Resource
+		})
+		result = append(result, *resp)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// podGPURequests sums the GPU and MIG slice requests of a pod's containers.
+// Init containers run before the app containers, so the pod holds the larger
+// of the two totals for each resource, as the scheduler computes it.
+func podGPURequests(pod corev1.Pod) map[corev1.ResourceName]int64 {
+	totals := make(map[corev1.ResourceName]int64)
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			if isGPUResource(name) {
+				totals[name] += q.Value()
+			}
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if isGPUResource(name) && q.Value() > totals[name] {
+				totals[name] = q.Value()
+			}
+		}
+	}
+	for name, count := range totals {
+		if count <= 0 {
+			delete(totals, name)
+		}
+	}
+	return totals
+}
+
+// isGPUResource reports whether name is a whole-GPU or MIG slice resource.
+func isGPUResource(name corev1.ResourceName) bool {
+	return name == gpuResource || strings.HasPrefix(string(name), migResourcePrefix)
+}
+
+// migProfileName strips the vendor prefix from a MIG resource name,
+// e.g. "nvidia.com/mig-1g.10gb" becomes "mig-1g.10gb".
+func migProfileName(name corev1.ResourceName) string {
+	return strings.TrimPrefix(string(name), "nvidia.com/")
+}
+
+// podPool returns the name of the InferenceStack whose pool selector matches
+// the pod, or "" if none does. Stacks without a selector select app=<name>,
+// as the operator does for the InferencePool it creates.
+func podPool(pod corev1.Pod, stacks []unstructured.Unstructured) string {
+	for _, stack := range stacks {
+		if stack.GetNamespace() != pod.Namespace {
+			continue
+		}
+		selector, _, _ := unstructured.NestedStringMap(stack.Object, "spec", "pool", "selector")
+		if len(selector) == 0 {
+			selector = map[string]string{"app": stack.GetName()}
+		}
+		matches := true
+		for k, v := range selector {
+			if pod.Labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return stack.GetName()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func testGPUNode(name, product string, resources map[corev1.ResourceName]string) *corev1.Node {
+	list := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}
+	for n, q := range resources {
+		list[n] = resource.MustParse(q)
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{gpuProductLabel: product}},
+		Status:     corev1.NodeStatus{Capacity: list, Allocatable: list},
+	}
+}
+
+func testGPUPod(name, node string, phase corev1.PodPhase, labels map[string]string, requests map[corev1.ResourceName]string) *corev1.Pod {
+	list := corev1.ResourceList{}
+	for n, q := range requests {
+		list[n] = resource.MustParse(q)
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "inference", Labels: labels},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Name: "server", Resources: corev1.ResourceRequirements{Requests: list}}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGPUHandler_ListNodes(t *testing.T) {
+	objs := []client.Object{
+		testGPUNode("gpu-a", "NVIDIA-H100-80GB-HBM3", map[corev1.ResourceName]string{gpuResource: "8"}),
+		testGPUNode("gpu-b", "NVIDIA-A100-SXM4-80GB", map[corev1.ResourceName]string{"nvidia.com/mig-1g.10gb": "14"}),
+		testGPUNode("cpu-only", "", nil),
+		testGPUPod("llama-0", "gpu-a", corev1.PodRunning, nil, map[corev1.ResourceName]string{gpuResource: "4"}),
+		testGPUPod("llama-1", "gpu-a", corev1.PodPending, nil, map[corev1.ResourceName]string{gpuResource: "2"}),
+		testGPUPod("done", "gpu-a", corev1.PodSucceeded, nil, map[corev1.ResourceName]string{gpuResource: "2"}),
+		testGPUPod("unscheduled", "", corev1.PodPending, nil, map[corev1.ResourceName]string{gpuResource: "1"}),
+		testGPUPod("phi-0", "gpu-b", corev1.PodRunning, nil, map[corev1.ResourceName]string{"nvidia.com/mig-1g.10gb": "3"}),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).WithObjects(objs...).Build()
+	handler := &GPUHandler{}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(kubernetes.NewForTest(fakeClient)))
+	r.Get("/gpu/nodes", handler.ListNodes)
+
+	req := httptest.NewRequest(http.MethodGet, "/gpu/nodes", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var nodes []GPUNodeResponse
+	if err := json.NewDecoder(w.Body).Decode(&nodes); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 GPU nodes, got %d: %+v", len(nodes), nodes)
+	}
+
+	a := nodes[0]
+	if a.Name != "gpu-a" || a.Capacity != 8 || a.Allocatable != 8 || a.Allocated != 6 || a.Available != 2 {
+		t.Errorf("gpu-a = %+v, want capacity 8, allocated 6, available 2", a)
+	}
+	if len(a.Consumers) != 2 || a.Consumers[0].Pod != "llama-0" || a.Consumers[0].Count != 4 {
+		t.Errorf("gpu-a consumers = %+v, want llama-0 and llama-1", a.Consumers)
+	}
+
+	b := nodes[1]
+	slices, ok := b.MIG["mig-1g.10gb"]
+	if b.Name != "gpu-b" || !ok || slices.Capacity != 14 || slices.Allocated != 3 {
+		t.Errorf("gpu-b = %+v, want 3 of 14 mig-1g.10gb slices allocated", b)
+	}
+	if b.Capacity != 0 || len(b.Consumers) != 1 || b.Consumers[0].Resource != "nvidia.com/mig-1g.10gb" {
+		t.Errorf("gpu-b consumers = %+v, want one MIG consumer", b.Consumers)
+	}
+}
+
+func TestGPUHandler_NoClusterContext(t *testing.T) {
+	handler := &GPUHandler{}
+	req := httptest.NewRequest(http.MethodGet, "/gpu/nodes", nil)
+	w := httptest.NewRecorder()
+	handler.ListNodes(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+func TestGPUNodeInventory_PoolAttribution(t *testing.T) {
+	stack := func(name string, selector map[string]any) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"pool": map[string]any{}}}}
+		if selector != nil {
+			obj.Object["spec"].(map[string]any)["pool"].(map[string]any)["selector"] = selector
+		}
+		obj.SetName(name)
+		obj.SetNamespace("inference")
+		return obj
+	}
+	stacks := []unstructured.Unstructured{
+		stack("llama3", nil),
+		stack("mixtral", map[string]any{"model": "mixtral"}),
+	}
+	nodes := []corev1.Node{*testGPUNode("gpu-a", "NVIDIA-H100-80GB-HBM3", map[corev1.ResourceName]string{gpuResource: "8"})}
+	pods := []corev1.Pod{
+		*testGPUPod("llama3-0", "gpu-a", corev1.PodRunning, map[string]string{"app": "llama3"}, map[corev1.ResourceName]string{gpuResource: "2"}),
+		*testGPUPod("mixtral-0", "gpu-a", corev1.PodRunning, map[string]string{"model": "mixtral"}, map[corev1.ResourceName]string{gpuResource: "2"}),
+		*testGPUPod("adhoc", "gpu-a", corev1.PodRunning, nil, map[corev1.ResourceName]string{gpuResource: "1"}),
+	}
+
+	got := gpuNodeInventory(nodes, pods, stacks)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(got))
+	}
+	want := map[string]string{"llama3-0": "llama3", "mixtral-0": "mixtral", "adhoc": ""}
+	for _, c := range got[0].Consumers {
+		if c.Pool != want[c.Pod] {
+			t.Errorf("pod %s pool = %q, want %q", c.Pod, c.Pool, want[c.Pod])
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// GRPCRouteHandler handles GRPCRoute API requests.
+type GRPCRouteHandler struct {
+	Store database.Store
+}
+
+// List returns all GRPCRoutes, optionally filtered by ?namespace= query param.
+func (h *GRPCRouteHandler) List(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := r.URL.Query().Get("namespace")
+	routes, err := k8s.ListGRPCRoutes(r.Context(), ns)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := make([]GRPCRouteResponse, 0, len(routes))
+	for i := range routes {
+		resp = append(resp, toGRPCRouteResponse(&routes[i]))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Get returns a single GRPCRoute by namespace and name.
+func (h *GRPCRouteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	gr, err := k8s.GetGRPCRoute(r.Context(), ns, name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toGRPCRouteResponse(gr))
+}
+
+// Create creates a new GRPCRoute.
+func (h *GRPCRouteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	var req CreateGRPCRouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	if req.Name == "" || req.Namespace == "" || len(req.ParentRefs) == 0 {
+		writeError(w, http.StatusBadRequest, "name, namespace, and at least one parentRef are required")
+		return
+	}
+	if err := validateGRPCRouteRules(req.Rules); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var refs []BackendRefRequest
+	for _, rule := range req.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	warnings, ok := validateRouteBackends(w, r, k8s, req.Namespace, refs)
+	if !ok {
+		return
+	}
+
+	gr := toGRPCRouteObject(req)
+	created, err := k8s.CreateGRPCRoute(r.Context(), gr)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resp := toGRPCRouteResponse(created)
+	auditLog(h.Store, r.Context(), "create", "GRPCRoute", req.Name, req.Namespace, nil, resp)
+	resp.Warnings = warnings
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// Update modifies an existing GRPCRoute.
+func (h *GRPCRouteHandler) Update(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	var req UpdateGRPCRouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	if len(req.ParentRefs) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one parentRef is required")
+		return
+	}
+	if err := validateGRPCRouteRules(req.Rules); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	existing, err := k8s.GetGRPCRoute(r.Context(), ns, name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	beforeResp := toGRPCRouteResponse(existing)
+	applyUpdateToGRPCRoute(existing, req)
+
+	updated, err := k8s.UpdateGRPCRoute(r.Context(), existing)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	afterResp := toGRPCRouteResponse(updated)
+	auditLog(h.Store, r.Context(), "update", "GRPCRoute", name, ns, beforeResp, afterResp)
+	writeJSON(w, http.StatusOK, afterResp)
+}
+
+// Delete removes a GRPCRoute.
+func (h *GRPCRouteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	if err := k8s.DeleteGRPCRoute(r.Context(), ns, name); err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	auditLog(h.Store, r.Context(), "delete", "GRPCRoute", name, ns, map[string]string{"name": name, "namespace": ns}, nil)
+	writeJSON(w, http.StatusOK, map[string]string{"message": "grpcroute deleted", "name": name, "namespace": ns})
+}
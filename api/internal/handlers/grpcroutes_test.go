@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func testGRPCRoute(name, namespace string) *gatewayv1.GRPCRoute {
+	service := "echo.EchoService"
+	return &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "my-gateway"}},
+			},
+			Rules: []gatewayv1.GRPCRouteRule{
+				{
+					Matches: []gatewayv1.GRPCRouteMatch{
+						{Method: &gatewayv1.GRPCMethodMatch{Service: &service}},
+					},
+					BackendRefs: []gatewayv1.GRPCBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: "echo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGRPCRouteHandler_List(t *testing.T) {
+	scheme := setupScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(testGRPCRoute("route-1", "ns1"), testGRPCRoute("route-2", "ns2")).Build()
+	k8sClient := kubernetes.NewForTest(fakeClient)
+	handler := &GRPCRouteHandler{}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(k8sClient))
+	r.Get("/api/v1/grpcroutes", handler.List)
+
+	t.Run("all namespaces", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/grpcroutes", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp []GRPCRouteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 2 {
+			t.Errorf("expected 2 routes, got %d", len(resp))
+		}
+	})
+
+	t.Run("filtered by namespace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/grpcroutes?namespace=ns1", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var resp []GRPCRouteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp) != 1 || resp[0].Name != "route-1" {
+			t.Errorf("expected only route-1, got %+v", resp)
+		}
+	})
+}
+
+func TestGRPCRouteHandler_Get(t *testing.T) {
+	scheme := setupScheme(t)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testGRPCRoute("echo", "default")).Build()
+	k8sClient := kubernetes.NewForTest(fakeClient)
+	handler := &GRPCRouteHandler{}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(k8sClient))
+	r.Get("/{namespace}/{name}", handler.Get)
+
+	t.Run("existing route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/default/echo", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp GRPCRouteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Rules) != 1 || resp.Rules[0].Matches[0].Method == nil ||
+			resp.Rules[0].Matches[0].Method.Service != "echo.EchoService" {
+			t.Errorf("unexpected rules: %+v", resp.Rules)
+		}
+		if len(resp.ParentRefs) != 1 || resp.ParentRefs[0].Name != "my-gateway" {
+			t.Errorf("unexpected parentRefs: %+v", resp.ParentRefs)
+		}
+	})
+
+	t.Run("nonexistent route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/default/missing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestGRPCRouteHandler_Create(t *testing.T) {
+	scheme := setupScheme(t)
+	handler := &GRPCRouteHandler{}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name: "successful create",
+			body: `{
+				"name": "echo",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"hostnames": ["grpc.example.com"],
+				"rules": [{
+					"matches": [{"method": {"service": "echo.EchoService", "method": "Echo"}}],
+					"backendRefs": [{"name": "echo", "port": 9000}]
+				}]
+			}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "missing parentRefs",
+			body:       `{"name": "echo", "namespace": "default"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid method match type",
+			body: `{
+				"name": "echo",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"matches": [{"method": {"type": "Prefix", "service": "echo.EchoService"}}]}]
+			}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "empty method match",
+			body: `{
+				"name": "echo",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"matches": [{"method": {}}]}]
+			}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid JSON",
+			body:       "{invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			k8sClient := kubernetes.NewForTest(fakeClient)
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(k8sClient))
+			r.Post("/api/v1/grpcroutes", handler.Create)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/grpcroutes", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var resp GRPCRouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Name != "echo" || len(resp.Rules) != 1 {
+				t.Fatalf("unexpected response: %+v", resp)
+			}
+			method := resp.Rules[0].Matches[0].Method
+			if method == nil || method.Type != "Exact" || method.Method != "Echo" {
+				t.Errorf("expected Exact match on Echo, got %+v", method)
+			}
+		})
+	}
+}
+
+func TestGRPCRouteHandler_Update(t *testing.T) {
+	scheme := setupScheme(t)
+	handler := &GRPCRouteHandler{}
+
+	t.Run("successful update", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testGRPCRoute("echo", "default")).Build()
+		k8sClient := kubernetes.NewForTest(fakeClient)
+
+		r := chi.NewRouter()
+		r.Use(contextMiddleware(k8sClient))
+		r.Put("/{namespace}/{name}", handler.Update)
+
+		body := `{
+			"parentRefs": [{"name": "other-gateway"}],
+			"rules": [{"backendRefs": [{"name": "echo-v2", "port": 9000}]}]
+		}`
+		req := httptest.NewRequest(http.MethodPut, "/default/echo", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp GRPCRouteResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.ParentRefs[0].Name != "other-gateway" || resp.Rules[0].BackendRefs[0].Name != "echo-v2" {
+			t.Errorf("update not applied: %+v", resp)
+		}
+	})
+
+	t.Run("nonexistent route", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		k8sClient := kubernetes.NewForTest(fakeClient)
+
+		r := chi.NewRouter()
+		r.Use(contextMiddleware(k8sClient))
+		r.Put("/{namespace}/{name}", handler.Update)
+
+		body := `{"parentRefs": [{"name": "my-gateway"}]}`
+		req := httptest.NewRequest(http.MethodPut, "/default/missing", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestGRPCRouteHandler_Delete(t *testing.T) {
+	scheme := setupScheme(t)
+	handler := &GRPCRouteHandler{}
+
+	t.Run("successful delete", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(testGRPCRoute("echo", "default")).Build()
+		k8sClient := kubernetes.NewForTest(fakeClient)
+
+		r := chi.NewRouter()
+		r.Use(contextMiddleware(k8sClient))
+		r.Delete("/{namespace}/{name}", handler.Delete)
+
+		req := httptest.NewRequest(http.MethodDelete, "/default/echo", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp map[string]string
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp["message"] != "grpcroute deleted" {
+			t.Errorf("expected 'grpcroute deleted' message, got %s", resp["message"])
+		}
+	})
+
+	t.Run("nonexistent route", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		k8sClient := kubernetes.NewForTest(fakeClient)
+
+		r := chi.NewRouter()
+		r.Use(contextMiddleware(k8sClient))
+		r.Delete("/{namespace}/{name}", handler.Delete)
+
+		req := httptest.NewRequest(http.MethodDelete, "/default/missing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -1,8 +1,65 @@
 package handlers
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
 
 // HealthCheck returns a simple 200 OK for liveness/readiness probes.
 func HealthCheck(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
+
+// readinessCacheTTL bounds how often /readyz re-checks the cluster, so
+// frequent probes don't each hit the API server.
+const readinessCacheTTL = 10 * time.Second
+
+// ReadinessHandler serves /readyz. It reports not ready while the default
+// cluster's API server is unreachable.
+type ReadinessHandler struct {
+	// Check verifies cluster connectivity. A nil Check is always ready.
+	Check func(ctx context.Context) error
+
+	mu        sync.Mutex
+	lastErr   error
+	checkedAt time.Time
+}
+
+// ReadinessResponse is the body returned by /readyz.
+type ReadinessResponse struct {
+	Status string `json:"status"` // "ok" or "unavailable"
+	Error  string `json:"error,omitempty"`
+}
+
+// Readyz returns 200 when the cluster is reachable and 503 otherwise.
+func (h *ReadinessHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if err := h.check(r.Context()); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, ReadinessResponse{
+			Status: "unavailable",
+			Error:  "kubernetes API server unreachable: " + err.Error(),
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, ReadinessResponse{Status: "ok"})
+}
+
+// check runs Check, reusing the previous result within readinessCacheTTL.
+func (h *ReadinessHandler) check(ctx context.Context) error {
+	if h.Check == nil {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.checkedAt.IsZero() && time.Since(h.checkedAt) < readinessCacheTTL {
+		return h.lastErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	h.lastErr = h.Check(ctx)
+	h.checkedAt = time.Now()
+	return h.lastErr
+}
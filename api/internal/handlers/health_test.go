@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadinessHandler_Readyz(t *testing.T) {
+	t.Run("no check is ready", func(t *testing.T) {
+		h := &ReadinessHandler{}
+		w := httptest.NewRecorder()
+		h.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+	})
+
+	t.Run("unreachable cluster is not ready and cached", func(t *testing.T) {
+		calls := 0
+		h := &ReadinessHandler{Check: func(context.Context) error {
+			calls++
+			return errors.New("connection refused")
+		}}
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			h.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if w.Code != http.StatusServiceUnavailable {
+				t.Fatalf("expected 503, got %d", w.Code)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("expected the check to be cached, ran %d times", calls)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -84,6 +85,145 @@ func (h *InferenceHandler) GetPool(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toInferencePoolResponse(*pool))
 }
 
+// Pool wait bounds. poolWaitInterval is a var so tests can shorten it.
+const (
+	defaultPoolWaitTimeout = 5 * time.Minute
+	maxPoolWaitTimeout     = 30 * time.Minute
+)
+
+var poolWaitInterval = 2 * time.Second
+
+// InferencePoolWaitResponse is the final state returned by WaitPool.
+type InferencePoolWaitResponse struct {
+	Ready    bool                   `json:"ready"`
+	TimedOut bool                   `json:"timedOut"`
+	Waited   string                 `json:"waited"`
+	Pool     *InferencePoolResponse `json:"pool"`
+}
+
+// WaitPool long-polls until the pool's Ready condition is true or the
+// ?timeout= duration (default 5m, max 30m) elapses, then returns the final
+// state. A pool that has not been synced yet is waited for as well; if it
+// never appears the response is 404. Returns early if the client disconnects.
+func (h *InferenceHandler) WaitPool(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	timeout := defaultPoolWaitTimeout
+	if v := r.URL.Query().Get("timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxPoolWaitTimeout {
+			writeError(w, http.StatusBadRequest, "timeout must be a positive duration of at most 30m")
+			return
+		}
+		timeout = d
+	}
+
+	start := time.Now()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(poolWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		pool, err := h.Provider.GetPool(r.Context(), name)
+		ready := err == nil && pool.Status == "Ready"
+		if ready {
+			resp := toInferencePoolResponse(*pool)
+			writeJSON(w, http.StatusOK, InferencePoolWaitResponse{
+				Ready:  true,
+				Waited: time.Since(start).Round(time.Millisecond).String(),
+				Pool:   &resp,
+			})
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-deadline.C:
+			if err != nil {
+				writeError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			resp := toInferencePoolResponse(*pool)
+			writeJSON(w, http.StatusOK, InferencePoolWaitResponse{
+				TimedOut: true,
+				Waited:   time.Since(start).Round(time.Millisecond).String(),
+				Pool:     &resp,
+			})
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poolMetricsStreamInterval is how often StreamPoolMetrics pushes an event.
+// It is a var so tests can shorten it.
+var poolMetricsStreamInterval = 5 * time.Second
+
+// StreamPoolMetrics streams the pool's latest queue depth, KV-cache usage and
+// tokens/sec as server-sent events, one "metrics" event every
+// poolMetricsStreamInterval, until the client disconnects. A provider error
+// is sent as an "error" event and the stream continues. Returns 501 when the
+// metrics provider cannot report live metrics.
+func (h *InferenceHandler) StreamPoolMetrics(w http.ResponseWriter, r *http.Request) {
+	live, ok := h.Provider.(inference.LiveMetricsProvider)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "the metrics provider does not support live metrics")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported by this connection")
+		return
+	}
+	name := chi.URLParam(r, "name")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // stop nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(poolMetricsStreamInterval)
+	defer ticker.Stop()
+
+	for {
+		m, err := live.GetLiveMetrics(r.Context(), name)
+		switch {
+		case r.Context().Err() != nil:
+			return
+		case err != nil:
+			writeSSE(w, "error", map[string]string{"error": err.Error()})
+		case m != nil:
+			writeSSE(w, "metrics", PoolLiveMetricsResponse{
+				Timestamp:  formatTime(m.Timestamp),
+				QueueDepth: m.QueueDepth,
+				KVCachePct: m.KVCachePct,
+				TPS:        m.TPS,
+			})
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeSSE writes one server-sent event with a JSON data line.
+func writeSSE(w http.ResponseWriter, event string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("failed to encode event", "event", event, "error", err)
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
+
 // CreatePoolRequest is the request body for creating a pool via the pool-oriented API.
 type CreatePoolRequest struct {
 	Name           string            `json:"name"`
@@ -93,6 +233,7 @@ type CreatePoolRequest struct {
 	ServingBackend string            `json:"servingBackend"`
 	GPUType        string            `json:"gpuType"`
 	GPUCount       int               `json:"gpuCount"`
+	MIGProfile     string            `json:"migProfile,omitempty"`
 	Replicas       int               `json:"replicas"`
 	MinReplicas    int               `json:"minReplicas,omitempty"`
 	MaxReplicas    int               `json:"maxReplicas,omitempty"`
@@ -114,11 +255,6 @@ func (h *InferenceHandler) CreatePool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Name == "" || req.Namespace == "" || req.ModelName == "" || req.ServingBackend == "" {
-		writeError(w, http.StatusBadRequest, "name, namespace, modelName, and servingBackend are required")
-		return
-	}
-
 	// Convert pool request to InferenceStack request.
 	stackReq := CreateInferenceStackRequest{
 		Name:           req.Name,
@@ -129,6 +265,7 @@ func (h *InferenceHandler) CreatePool(w http.ResponseWriter, r *http.Request) {
 		Pool: CreateInferenceStackPoolReq{
 			GPUType:     req.GPUType,
 			GPUCount:    req.GPUCount,
+			MIGProfile:  req.MIGProfile,
 			Replicas:    req.Replicas,
 			MinReplicas: req.MinReplicas,
 			MaxReplicas: req.MaxReplicas,
@@ -136,6 +273,10 @@ func (h *InferenceHandler) CreatePool(w http.ResponseWriter, r *http.Request) {
 		},
 		EPP: req.EPP,
 	}
+	if fields := validateInferenceStackRequest(stackReq); len(fields) > 0 {
+		writeValidationError(w, fields)
+		return
+	}
 
 	obj := toInferenceStackUnstructured(stackReq)
 	created, err := dc.Resource(inferenceStackGVR).Namespace(req.Namespace).Create(r.Context(), obj, metav1.CreateOptions{})
@@ -166,6 +307,36 @@ func (h *InferenceHandler) CreatePool(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, resp)
 }
 
+// PoolHistory returns the reconcile history of a pool's InferenceStack: the
+// operator's child events, condition transitions, and current child states,
+// oldest first. ?limit= bounds the entries returned.
+func (h *InferenceHandler) PoolHistory(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+	limit, err := parseHistoryLimit(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	stack, err := h.findInferenceStackByName(r, name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	events, err := listObjectEvents(r.Context(), dc, stack)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, buildReconcileHistory(stack, events, limit))
+}
+
 // UpdatePoolRequest is the request body for updating a pool.
 type UpdatePoolRequest struct {
 	ModelName      string            `json:"modelName,omitempty"`
@@ -173,6 +344,9 @@ type UpdatePoolRequest struct {
 	ServingBackend string            `json:"servingBackend,omitempty"`
 	GPUType        string            `json:"gpuType,omitempty"`
 	GPUCount       *int              `json:"gpuCount,omitempty"`
+	// MIGProfile switches the pool to MIG slices; an empty string switches
+	// it back to whole GPUs.
+	MIGProfile     *string           `json:"migProfile,omitempty"`
 	Replicas       *int              `json:"replicas,omitempty"`
 	MinReplicas    *int              `json:"minReplicas,omitempty"`
 	MaxReplicas    *int              `json:"maxReplicas,omitempty"`
@@ -201,6 +375,22 @@ func (h *InferenceHandler) UpdatePool(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 		return
 	}
+	if req.MIGProfile != nil {
+		if err := validateMIGProfile(*req.MIGProfile); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if req.EPP != nil {
+		if err := validateEPPStrategy(req.EPP.Strategy); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := validateEPPWeights(req.EPP.Weights); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
 	// Read current spec and apply updates.
 	spec, _, _ := unstructured.NestedMap(existing.Object, "spec")
@@ -228,6 +418,13 @@ func (h *InferenceHandler) UpdatePool(w http.ResponseWriter, r *http.Request) {
 	if req.GPUCount != nil {
 		pool["gpuCount"] = int64(*req.GPUCount)
 	}
+	if req.MIGProfile != nil {
+		if *req.MIGProfile == "" {
+			delete(pool, "migProfile")
+		} else {
+			pool["migProfile"] = *req.MIGProfile
+		}
+	}
 	if req.Replicas != nil {
 		pool["replicas"] = int64(*req.Replicas)
 	}
@@ -320,7 +517,7 @@ func (h *InferenceHandler) DeployPool(w http.ResponseWriter, r *http.Request) {
 	if annotations == nil {
 		annotations = map[string]string{}
 	}
-	annotations["ngf-console.f5.com/reconcile-requested"] = time.Now().UTC().Format(time.RFC3339)
+	annotations[reconcileRequestedAnnotation] = time.Now().UTC().Format(time.RFC3339)
 	existing.SetAnnotations(annotations)
 
 	result, err := dc.Resource(inferenceStackGVR).Namespace(existing.GetNamespace()).Update(r.Context(), existing, metav1.UpdateOptions{})
@@ -402,6 +599,14 @@ func (h *InferenceHandler) UpdateEPP(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "pool and strategy are required")
 		return
 	}
+	if err := validateEPPStrategy(req.Strategy); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateEPPWeights(req.Weights); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	existing, err := h.findInferenceStackByName(r, req.Pool)
 	if err != nil {
@@ -525,6 +730,16 @@ func (h *InferenceHandler) UpdateAutoscaling(w http.ResponseWriter, r *http.Requ
 		pool["maxReplicas"] = int64(*req.MaxReplicas)
 	}
 
+	// Validate the merged counts, so a partial update is checked against the
+	// values it leaves in place.
+	replicas, _, _ := unstructured.NestedInt64(pool, "replicas")
+	minReplicas, _, _ := unstructured.NestedInt64(pool, "minReplicas")
+	maxReplicas, _, _ := unstructured.NestedInt64(pool, "maxReplicas")
+	if fields := validateReplicaCounts("", int(replicas), int(minReplicas), int(maxReplicas)); len(fields) > 0 {
+		writeValidationError(w, fields)
+		return
+	}
+
 	if err := unstructured.SetNestedField(existing.Object, pool, "spec", "pool"); err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("setting pool: %v", err))
 		return
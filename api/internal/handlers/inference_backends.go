@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenetlabs/ngc/api/internal/inference"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// ListBackends returns the serving backends an InferenceStack can use and
+// the model server image the operator deploys for each when the stack does
+// not set serving.image. Images come from the ConfigMap the cluster's
+// operator publishes; they are empty until it has done so.
+func (h *InferenceHandler) ListBackends(w http.ResponseWriter, r *http.Request) {
+	backends := inference.ServingBackends()
+	if dc := h.getDynamicClient(r); dc != nil {
+		list, err := dc.Resource(configMapGVR).Namespace("").List(r.Context(), metav1.ListOptions{
+			LabelSelector: inference.ServingDefaultsLabel + "=true",
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing serving defaults: %v", err))
+			return
+		}
+		if len(list.Items) > 0 {
+			data, _, _ := unstructured.NestedStringMap(list.Items[0].Object, "data")
+			backends = inference.WithServingDefaults(backends, data)
+		}
+	}
+
+	resp := make([]ServingBackendResponse, 0, len(backends))
+	for _, b := range backends {
+		resp = append(resp, ServingBackendResponse{
+			Name:         b.Name,
+			DefaultImage: b.DefaultImage,
+			Port:         b.Port,
+			Overridden:   b.Overridden,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
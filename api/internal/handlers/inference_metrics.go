@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -148,9 +149,16 @@ func (h *InferenceMetricsHandler) TTFTHistogram(w http.ResponseWriter, r *http.R
 	writeJSON(w, http.StatusOK, resp)
 }
 
-// TPSThroughput returns tokens-per-second timeseries for a pool.
+// TPSThroughput returns tokens-per-second timeseries for a pool. With
+// ?model= it returns the series of that model only.
 func (h *InferenceMetricsHandler) TPSThroughput(w http.ResponseWriter, r *http.Request) {
 	pool := chi.URLParam(r, "pool")
+	if model := r.URL.Query().Get("model"); model != "" {
+		h.writeTimeseries(w, r, pool, func(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error) {
+			return h.Provider.GetModelTPSThroughput(ctx, pool, model, window)
+		})
+		return
+	}
 	h.writeTimeseries(w, r, pool, h.Provider.GetTPSThroughput)
 }
 
@@ -176,9 +184,18 @@ func (h *InferenceMetricsHandler) writeTimeseries(
 	w http.ResponseWriter,
 	r *http.Request,
 	pool string,
-	fn func(ctx context.Context, pool string) ([]inference.TimeseriesPoint, error),
+	fn func(ctx context.Context, pool string, window time.Duration) ([]inference.TimeseriesPoint, error),
 ) {
-	points, err := fn(r.Context(), pool)
+	window := inference.DefaultSeriesWindow
+	if v := r.URL.Query().Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Minute || d > inference.MaxSeriesWindow {
+			writeError(w, http.StatusBadRequest, "window must be a duration between 1m and 2160h (90 days)")
+			return
+		}
+		window = d
+	}
+	points, err := fn(r.Context(), pool, window)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
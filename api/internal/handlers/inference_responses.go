@@ -21,6 +21,15 @@ type InferencePoolResponse struct {
 	CreatedAt      string            `json:"createdAt"`
 }
 
+// PoolLiveMetricsResponse is the data of a "metrics" event on the pool
+// metrics stream.
+type PoolLiveMetricsResponse struct {
+	Timestamp  string  `json:"timestamp"`
+	QueueDepth float64 `json:"queueDepth"`
+	KVCachePct float64 `json:"kvCachePct"`
+	TPS        float64 `json:"tps"`
+}
+
 type InferencePoolStatusResponse struct {
 	ReadyReplicas  int                 `json:"readyReplicas"`
 	TotalReplicas  int                 `json:"totalReplicas"`
@@ -85,6 +94,13 @@ type CostEstimateResponse struct {
 	MonthlyCost  float64 `json:"monthlyCost"`
 }
 
+type ServingBackendResponse struct {
+	Name         string `json:"name"`
+	DefaultImage string `json:"defaultImage"`
+	Port         int32  `json:"port"`
+	Overridden   bool   `json:"overridden"`
+}
+
 // Conversion helpers from domain types to response types
 
 func formatTime(t time.Time) string {
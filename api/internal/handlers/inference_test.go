@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
 
 	"github.com/kubenetlabs/ngc/api/internal/inference"
 )
@@ -351,3 +360,439 @@ func TestInferenceMetricsHandler_Timeseries(t *testing.T) {
 		})
 	}
 }
+
+func TestInferenceMetricsHandler_TimeseriesWindow(t *testing.T) {
+	handler := newInferenceMetricsHandler()
+	r := chi.NewRouter()
+	r.Get("/inference/metrics/tps-throughput/{pool}", handler.TPSThroughput)
+
+	for _, tc := range []struct {
+		window     string
+		wantStatus int
+		wantPoints int
+	}{
+		{window: "6h", wantStatus: http.StatusOK, wantPoints: 360},
+		{window: "168h", wantStatus: http.StatusOK, wantPoints: 168},
+		{window: "30s", wantStatus: http.StatusBadRequest},
+		{window: "2400h", wantStatus: http.StatusBadRequest},
+		{window: "week", wantStatus: http.StatusBadRequest},
+	} {
+		t.Run(tc.window, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/inference/metrics/tps-throughput/llama3-70b-prod?window="+tc.window, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, w.Code, w.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var resp []TimeseriesPointResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp) != tc.wantPoints {
+				t.Errorf("expected %d points, got %d", tc.wantPoints, len(resp))
+			}
+		})
+	}
+}
+
+func TestInferenceHandler_WaitPool(t *testing.T) {
+	orig := poolWaitInterval
+	poolWaitInterval = 10 * time.Millisecond
+	t.Cleanup(func() { poolWaitInterval = orig })
+
+	handler := newInferenceHandler()
+	r := chi.NewRouter()
+	r.Get("/inference/pools/{name}/wait", handler.WaitPool)
+
+	tests := []struct {
+		name         string
+		url          string
+		wantStatus   int
+		wantReady    bool
+		wantTimedOut bool
+	}{
+		{name: "ready pool", url: "/inference/pools/llama3-70b-prod/wait", wantStatus: http.StatusOK, wantReady: true},
+		{name: "degraded pool times out", url: "/inference/pools/phi3-mini-dev/wait?timeout=50ms", wantStatus: http.StatusOK, wantTimedOut: true},
+		{name: "pool never appears", url: "/inference/pools/nonexistent/wait?timeout=50ms", wantStatus: http.StatusNotFound},
+		{name: "invalid timeout", url: "/inference/pools/llama3-70b-prod/wait?timeout=forever", wantStatus: http.StatusBadRequest},
+		{name: "timeout too long", url: "/inference/pools/llama3-70b-prod/wait?timeout=2h", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp InferencePoolWaitResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Ready != tt.wantReady || resp.TimedOut != tt.wantTimedOut {
+				t.Errorf("ready=%v timedOut=%v, want ready=%v timedOut=%v", resp.Ready, resp.TimedOut, tt.wantReady, tt.wantTimedOut)
+			}
+			if resp.Pool == nil || resp.Pool.Status == nil {
+				t.Error("expected final pool state in response")
+			}
+		})
+	}
+
+	t.Run("client disconnect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/inference/pools/phi3-mini-dev/wait?timeout=30m", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		done := make(chan struct{})
+		go func() {
+			r.ServeHTTP(w, req)
+			close(done)
+		}()
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("WaitPool did not return after client disconnect")
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("expected no response body after disconnect, got %s", w.Body.String())
+		}
+	})
+}
+
+func TestInferenceHandler_StreamPoolMetrics(t *testing.T) {
+	orig := poolMetricsStreamInterval
+	poolMetricsStreamInterval = 10 * time.Millisecond
+	t.Cleanup(func() { poolMetricsStreamInterval = orig })
+
+	stream := func(t *testing.T, handler *InferenceHandler, pool string) *httptest.ResponseRecorder {
+		t.Helper()
+		r := chi.NewRouter()
+		r.Get("/inference/pools/{name}/metrics/stream", handler.StreamPoolMetrics)
+
+		// The handler returns when the client disconnects, here after a few intervals.
+		ctx, cancel := context.WithTimeout(context.Background(), 45*time.Millisecond)
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/inference/pools/"+pool+"/metrics/stream", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("streams metrics events", func(t *testing.T) {
+		w := stream(t, newInferenceHandler(), "llama3-70b-prod")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Errorf("expected text/event-stream, got %q", ct)
+		}
+
+		events := strings.Split(strings.TrimSpace(w.Body.String()), "\n\n")
+		if len(events) < 2 {
+			t.Fatalf("expected at least 2 events, got %d: %s", len(events), w.Body.String())
+		}
+		for _, ev := range events {
+			lines := strings.Split(ev, "\n")
+			if len(lines) != 2 || lines[0] != "event: metrics" || !strings.HasPrefix(lines[1], "data: ") {
+				t.Fatalf("malformed event %q", ev)
+			}
+			var m PoolLiveMetricsResponse
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &m); err != nil {
+				t.Fatalf("failed to decode event data: %v", err)
+			}
+			if m.Timestamp == "" || m.TPS <= 0 {
+				t.Errorf("expected a timestamp and tokens/sec, got %+v", m)
+			}
+		}
+	})
+
+	t.Run("provider error is sent as an event", func(t *testing.T) {
+		w := stream(t, newInferenceHandler(), "nonexistent")
+		if !strings.HasPrefix(w.Body.String(), "event: error\ndata: {\"error\":") {
+			t.Errorf("expected an error event, got %q", w.Body.String())
+		}
+	})
+
+	t.Run("provider without live metrics", func(t *testing.T) {
+		// Embedding the interface hides the mock's GetLiveMetrics.
+		handler := &InferenceHandler{Provider: struct{ inference.MetricsProvider }{inference.NewMockProvider()}}
+		w := stream(t, handler, "llama3-70b-prod")
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status 501, got %d", w.Code)
+		}
+	})
+}
+
+func TestInferenceHandler_EPPValidation(t *testing.T) {
+	stack := toInferenceStackUnstructured(CreateInferenceStackRequest{
+		Name:           "llama3",
+		Namespace:      "default",
+		ModelName:      "meta-llama/Llama-3-8B",
+		ServingBackend: "vllm",
+		Pool:           CreateInferenceStackPoolReq{GPUType: "H100", GPUCount: 1, Replicas: 1},
+	})
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		inferenceStackGVR: "InferenceStackList",
+	}, stack)
+	handler := &InferenceHandler{Provider: inference.NewMockProvider(), DynamicClient: dc}
+	r := chi.NewRouter()
+	r.Put("/inference/epp", handler.UpdateEPP)
+	r.Put("/inference/pools/{name}", handler.UpdatePool)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantErr    string
+	}{
+		{name: "UpdateEPP unknown strategy", path: "/inference/epp", body: `{"pool":"llama3","strategy":"least-laod"}`, wantStatus: http.StatusBadRequest, wantErr: "unsupported epp strategy"},
+		{name: "UpdateEPP weights sum to 73", path: "/inference/epp", body: `{"pool":"llama3","strategy":"composite","weights":{"queueDepth":40,"kvCache":20,"prefixAffinity":13}}`, wantStatus: http.StatusBadRequest, wantErr: "must sum to 100"},
+		{name: "UpdateEPP negative weight", path: "/inference/epp", body: `{"pool":"llama3","strategy":"composite","weights":{"queueDepth":110,"kvCache":-10,"prefixAffinity":0}}`, wantStatus: http.StatusBadRequest, wantErr: "must not be negative"},
+		{name: "UpdateEPP without weights", path: "/inference/epp", body: `{"pool":"llama3","strategy":"least_queue"}`, wantStatus: http.StatusOK},
+		{name: "UpdateEPP weights sum to 100", path: "/inference/epp", body: `{"pool":"llama3","strategy":"composite","weights":{"queueDepth":40,"kvCache":35,"prefixAffinity":25}}`, wantStatus: http.StatusOK},
+		{name: "UpdatePool weights sum to 90", path: "/inference/pools/llama3", body: `{"epp":{"strategy":"composite","weights":{"queueDepth":30,"kvCache":30,"prefixAffinity":30}}}`, wantStatus: http.StatusBadRequest, wantErr: "must sum to 100"},
+		{name: "UpdatePool unknown strategy", path: "/inference/pools/llama3", body: `{"epp":{"strategy":"round-robin"}}`, wantStatus: http.StatusBadRequest, wantErr: "unsupported epp strategy"},
+		{name: "UpdatePool weights sum to 100", path: "/inference/pools/llama3", body: `{"epp":{"strategy":"composite","weights":{"queueDepth":50,"kvCache":50,"prefixAffinity":0}}}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantErr != "" && !strings.Contains(w.Body.String(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %s", tt.wantErr, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestInferenceHandler_UpdateAutoscalingValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantField  string
+	}{
+		{name: "min above max", body: `{"pool":"llama3","minReplicas":10,"maxReplicas":2}`, wantStatus: http.StatusBadRequest, wantField: "minReplicas"},
+		{name: "negative replicas", body: `{"pool":"llama3","replicas":-1}`, wantStatus: http.StatusBadRequest, wantField: "replicas"},
+		{name: "partial max below existing replicas", body: `{"pool":"llama3","maxReplicas":1}`, wantStatus: http.StatusBadRequest, wantField: "maxReplicas"},
+		{name: "partial min above existing replicas", body: `{"pool":"llama3","minReplicas":3}`, wantStatus: http.StatusBadRequest, wantField: "minReplicas"},
+		{name: "partial within existing bounds", body: `{"pool":"llama3","replicas":3}`, wantStatus: http.StatusOK},
+		{name: "all three consistent", body: `{"pool":"llama3","minReplicas":2,"replicas":5,"maxReplicas":8}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Existing pool: minReplicas 1, replicas 2, maxReplicas 4.
+			stack := toInferenceStackUnstructured(CreateInferenceStackRequest{
+				Name:           "llama3",
+				Namespace:      "default",
+				ModelName:      "meta-llama/Llama-3-8B",
+				ServingBackend: "vllm",
+				Pool:           CreateInferenceStackPoolReq{GPUType: "H100", GPUCount: 1, Replicas: 2, MinReplicas: 1, MaxReplicas: 4},
+			})
+			dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				inferenceStackGVR: "InferenceStackList",
+			}, stack)
+			handler := &InferenceHandler{Provider: inference.NewMockProvider(), DynamicClient: dc}
+
+			req := httptest.NewRequest(http.MethodPut, "/inference/autoscaling", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.UpdateAutoscaling(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantField == "" {
+				return
+			}
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !slices.ContainsFunc(resp.Fields, func(f FieldError) bool { return f.Field == tt.wantField }) {
+				t.Errorf("expected field %q in %+v", tt.wantField, resp.Fields)
+			}
+		})
+	}
+}
+
+func TestInferenceStack_MIGProfile(t *testing.T) {
+	for profile, wantErr := range map[string]bool{
+		"":               false,
+		"mig-1g.10gb":    false,
+		"mig-3g.40gb":    false,
+		"mig-1g.10gb+me": false,
+		"mig-1c.3g.20gb": false,
+		"mig-1g.10gb+":   true,
+		"1g.10gb":        true,
+		"mig-1g.10GB":    true,
+		"nvidia.com/gpu": true,
+	} {
+		if err := validateMIGProfile(profile); (err != nil) != wantErr {
+			t.Errorf("validateMIGProfile(%q) error = %v, wantErr %v", profile, err, wantErr)
+		}
+	}
+
+	obj := toInferenceStackUnstructured(CreateInferenceStackRequest{
+		Name:           "llama3-mig",
+		Namespace:      "default",
+		ModelName:      "meta-llama/Llama-3-8B",
+		ServingBackend: "vllm",
+		Pool:           CreateInferenceStackPoolReq{GPUType: "A100", GPUCount: 2, MIGProfile: "mig-1g.10gb", Replicas: 1},
+	})
+	if got := toInferenceStackResponse(obj).Pool.MIGProfile; got != "mig-1g.10gb" {
+		t.Errorf("migProfile = %q, want mig-1g.10gb", got)
+	}
+
+	obj = toInferenceStackUnstructured(CreateInferenceStackRequest{Pool: CreateInferenceStackPoolReq{GPUType: "A100", GPUCount: 1}})
+	if _, found, _ := unstructured.NestedString(obj.Object, "spec", "pool", "migProfile"); found {
+		t.Error("expected migProfile to be omitted for whole-GPU pools")
+	}
+}
+
+func TestValidateInferenceStackRequest(t *testing.T) {
+	valid := func() CreateInferenceStackRequest {
+		return CreateInferenceStackRequest{
+			Name:           "llama3",
+			Namespace:      "default",
+			ModelName:      "meta-llama/Llama-3-8B",
+			ServingBackend: "vllm",
+			Pool:           CreateInferenceStackPoolReq{GPUType: "H100", GPUCount: 1, Replicas: 2, MinReplicas: 1, MaxReplicas: 4},
+			EPP:            &CreateInferenceStackEPPReq{Strategy: "least_queue"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(*CreateInferenceStackRequest)
+		wantFields []string
+	}{
+		{name: "valid", mutate: func(*CreateInferenceStackRequest) {}},
+		{name: "unset bounds", mutate: func(r *CreateInferenceStackRequest) { r.Pool.MinReplicas, r.Pool.MaxReplicas = 0, 0 }},
+		{name: "missing required", mutate: func(r *CreateInferenceStackRequest) { r.Name, r.ServingBackend = "", "" }, wantFields: []string{"name", "servingBackend"}},
+		{name: "unknown backend", mutate: func(r *CreateInferenceStackRequest) { r.ServingBackend = "sglang" }, wantFields: []string{"servingBackend"}},
+		{name: "negative gpu count", mutate: func(r *CreateInferenceStackRequest) { r.Pool.GPUCount = -1 }, wantFields: []string{"pool.gpuCount"}},
+		{name: "min above replicas", mutate: func(r *CreateInferenceStackRequest) { r.Pool.MinReplicas = 3 }, wantFields: []string{"pool.minReplicas"}},
+		{name: "replicas above max", mutate: func(r *CreateInferenceStackRequest) { r.Pool.Replicas = 5 }, wantFields: []string{"pool.maxReplicas"}},
+		{name: "invalid mig profile", mutate: func(r *CreateInferenceStackRequest) { r.Pool.MIGProfile = "1g.10gb" }, wantFields: []string{"pool.migProfile"}},
+		{name: "unknown strategy", mutate: func(r *CreateInferenceStackRequest) { r.EPP.Strategy = "round_robin" }, wantFields: []string{"epp.strategy"}},
+		{name: "weights sum to 100", mutate: func(r *CreateInferenceStackRequest) { r.EPP.Weights = &InferenceStackWeightsResp{40, 35, 25} }},
+		{name: "weights sum to 73", mutate: func(r *CreateInferenceStackRequest) { r.EPP.Weights = &InferenceStackWeightsResp{40, 20, 13} }, wantFields: []string{"epp.weights"}},
+		{name: "negative weight", mutate: func(r *CreateInferenceStackRequest) { r.EPP.Weights = &InferenceStackWeightsResp{110, -10, 0} }, wantFields: []string{"epp.weights"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(&req)
+			var got []string
+			for _, f := range validateInferenceStackRequest(req) {
+				got = append(got, f.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("invalid fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestInferenceStackHandler_CreateValidation(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		inferenceStackGVR: "InferenceStackList",
+	})
+	handler := &InferenceStackHandler{DynamicClient: dc}
+	r := chi.NewRouter()
+	r.Post("/inference/stacks", handler.Create)
+
+	body := `{"name":"llama3","namespace":"default","modelName":"meta-llama/Llama-3-8B","servingBackend":"vllm",` +
+		`"pool":{"gpuType":"H100","gpuCount":-2,"replicas":1,"minReplicas":2,"maxReplicas":4}}`
+	req := httptest.NewRequest(http.MethodPost, "/inference/stacks", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ValidationErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Fields) != 2 || resp.Fields[0].Field != "pool.gpuCount" || resp.Fields[1].Field != "pool.minReplicas" {
+		t.Errorf("fields = %+v, want pool.gpuCount and pool.minReplicas", resp.Fields)
+	}
+
+	list, err := dc.Resource(inferenceStackGVR).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("listing inferencestacks: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("expected no InferenceStack to be created, got %d", len(list.Items))
+	}
+}
+
+func TestInferenceHandler_ListBackends(t *testing.T) {
+	defaults := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "ngf-console-serving-defaults",
+			"namespace": "ngf-console",
+			"labels":    map[string]interface{}{inference.ServingDefaultsLabel: "true"},
+		},
+		"data": map[string]interface{}{
+			"vllm":       "vllm/vllm-openai:v0.6.3",
+			"tgi":        "registry.example.com/tgi:2.4.0",
+			"triton":     "nvcr.io/nvidia/tritonserver:24.10-py3",
+			"ollama":     "ollama/ollama:0.3.14",
+			"overridden": "tgi",
+		},
+	}}
+	listKinds := map[schema.GroupVersionResource]string{configMapGVR: "ConfigMapList"}
+
+	for name, handler := range map[string]*InferenceHandler{
+		"unpublished": {DynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)},
+		"published":   {DynamicClient: fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, defaults)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/inference/backends", nil)
+			w := httptest.NewRecorder()
+			handler.ListBackends(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var resp []ServingBackendResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp) != len(validServingBackends) {
+				t.Fatalf("expected %d backends, got %d", len(validServingBackends), len(resp))
+			}
+			for _, b := range resp {
+				if !slices.Contains(validServingBackends, b.Name) {
+					t.Errorf("backend %q is not accepted by stack validation", b.Name)
+				}
+				published := name == "published"
+				wantOverride := published && b.Name == "tgi"
+				if b.Overridden != wantOverride || (b.DefaultImage != "") != published || b.Port == 0 {
+					t.Errorf("unexpected backend %+v", b)
+				}
+				if wantOverride && b.DefaultImage != "registry.example.com/tgi:2.4.0" {
+					t.Errorf("tgi image = %q, want the operator's default", b.DefaultImage)
+				}
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ type InferenceStackResponse struct {
 type InferenceStackPoolResponse struct {
 	GPUType     string            `json:"gpuType"`
 	GPUCount    int               `json:"gpuCount"`
+	MIGProfile  string            `json:"migProfile,omitempty"`
 	Replicas    int               `json:"replicas"`
 	MinReplicas int               `json:"minReplicas"`
 	MaxReplicas int               `json:"maxReplicas"`
@@ -46,6 +47,7 @@ type ChildStatusResponse struct {
 	Kind    string `json:"kind"`
 	Name    string `json:"name"`
 	Ready   bool   `json:"ready"`
+	Reason  string `json:"reason,omitempty"`
 	Message string `json:"message,omitempty"`
 }
 
@@ -66,6 +68,7 @@ type CreateInferenceStackRequest struct {
 type CreateInferenceStackPoolReq struct {
 	GPUType     string            `json:"gpuType"`
 	GPUCount    int               `json:"gpuCount"`
+	MIGProfile  string            `json:"migProfile,omitempty"`
 	Replicas    int               `json:"replicas"`
 	MinReplicas int               `json:"minReplicas"`
 	MaxReplicas int               `json:"maxReplicas"`
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -97,8 +100,8 @@ func (h *InferenceStackHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Name == "" || req.Namespace == "" || req.ModelName == "" || req.ServingBackend == "" {
-		writeError(w, http.StatusBadRequest, "name, namespace, modelName, and servingBackend are required")
+	if fields := validateInferenceStackRequest(req); len(fields) > 0 {
+		writeValidationError(w, fields)
 		return
 	}
 
@@ -155,6 +158,20 @@ func (h *InferenceStackHandler) Update(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
 		return
 	}
+	if err := validateMIGProfile(req.Pool.MIGProfile); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.EPP != nil {
+		if err := validateEPPStrategy(req.EPP.Strategy); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := validateEPPWeights(req.EPP.Weights); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
 	// Build the updated object, preserving metadata from existing.
 	beforeResp := toInferenceStackResponse(existing)
@@ -255,6 +272,7 @@ func toInferenceStackResponse(obj *unstructured.Unstructured) InferenceStackResp
 			resp.Pool.GPUType, _, _ = unstructured.NestedString(pool, "gpuType")
 			gpuCount, _, _ := unstructured.NestedInt64(pool, "gpuCount")
 			resp.Pool.GPUCount = int(gpuCount)
+			resp.Pool.MIGProfile, _, _ = unstructured.NestedString(pool, "migProfile")
 			replicas, _, _ := unstructured.NestedInt64(pool, "replicas")
 			resp.Pool.Replicas = int(replicas)
 			minReplicas, _, _ := unstructured.NestedInt64(pool, "minReplicas")
@@ -303,6 +321,7 @@ func toInferenceStackResponse(obj *unstructured.Unstructured) InferenceStackResp
 			cs.Kind, _, _ = unstructured.NestedString(childMap, "kind")
 			cs.Name, _, _ = unstructured.NestedString(childMap, "name")
 			cs.Ready, _, _ = unstructured.NestedBool(childMap, "ready")
+			cs.Reason, _, _ = unstructured.NestedString(childMap, "reason")
 			cs.Message, _, _ = unstructured.NestedString(childMap, "message")
 			resp.Children = append(resp.Children, cs)
 		}
@@ -329,6 +348,11 @@ func toInferenceStackUnstructured(req CreateInferenceStackRequest) *unstructured
 		spec["modelVersion"] = req.ModelVersion
 	}
 
+	if req.Pool.MIGProfile != "" {
+		poolMap := spec["pool"].(map[string]any)
+		poolMap["migProfile"] = req.Pool.MIGProfile
+	}
+
 	if req.Pool.Selector != nil {
 		poolMap := spec["pool"].(map[string]any)
 		poolMap["selector"] = req.Pool.Selector
@@ -362,3 +386,143 @@ func toInferenceStackUnstructured(req CreateInferenceStackRequest) *unstructured
 
 	return obj
 }
+
+// migProfileRe matches NVIDIA MIG profile names such as "mig-1g.10gb", with
+// an optional compute instance prefix ("mig-1c.3g.20gb") and attribute
+// suffix ("mig-1g.10gb+me", "mig-1g.24gb+me.all").
+var migProfileRe = regexp.MustCompile(`^mig-([0-9]+c\.)?[0-9]+g\.[0-9]+gb([+-][a-z]+(\.[a-z]+)*)?$`)
+
+// validateMIGProfile checks that profile is empty or a MIG profile name.
+func validateMIGProfile(profile string) error {
+	if profile != "" && !migProfileRe.MatchString(profile) {
+		return fmt.Errorf("invalid migProfile %q: expected a MIG profile such as mig-1g.10gb", profile)
+	}
+	return nil
+}
+
+// eppWeightsTotal is the sum the EPP scoring weights must add up to, so each
+// weight reads as a percentage.
+const eppWeightsTotal = 100
+
+// validateEPPWeights checks that weights, when given, are non-negative and
+// sum to eppWeightsTotal.
+func validateEPPWeights(weights *InferenceStackWeightsResp) error {
+	if weights == nil {
+		return nil
+	}
+	if weights.QueueDepth < 0 || weights.KVCache < 0 || weights.PrefixAffinity < 0 {
+		return fmt.Errorf("epp weights must not be negative, got queueDepth=%d kvCache=%d prefixAffinity=%d",
+			weights.QueueDepth, weights.KVCache, weights.PrefixAffinity)
+	}
+	if sum := weights.QueueDepth + weights.KVCache + weights.PrefixAffinity; sum != eppWeightsTotal {
+		return fmt.Errorf("epp weights must sum to %d, got %d (queueDepth=%d + kvCache=%d + prefixAffinity=%d)",
+			eppWeightsTotal, sum, weights.QueueDepth, weights.KVCache, weights.PrefixAffinity)
+	}
+	return nil
+}
+
+// Values accepted by the InferenceStack CRD enums. validEPPStrategies is the
+// canonical set of EPP routing strategies; the operator defaults to
+// least_queue when none is set.
+var (
+	validServingBackends = []string{"vllm", "triton", "tgi", "ollama"}
+	validEPPStrategies   = []string{"least_queue", "kv_cache", "prefix_affinity", "composite"}
+)
+
+// validateEPPStrategy checks that strategy is one of validEPPStrategies.
+func validateEPPStrategy(strategy string) error {
+	if !slices.Contains(validEPPStrategies, strategy) {
+		return fmt.Errorf("unsupported epp strategy %q: must be one of %s", strategy, strings.Join(validEPPStrategies, ", "))
+	}
+	return nil
+}
+
+// FieldError describes why one request field is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse is the 400 response for a request with invalid fields.
+type ValidationErrorResponse struct {
+	Error     string       `json:"error"`
+	Fields    []FieldError `json:"fields"`
+	RequestID string       `json:"requestId,omitempty"`
+}
+
+// writeValidationError writes a 400 listing every invalid field.
+func writeValidationError(w http.ResponseWriter, fields []FieldError) {
+	writeJSON(w, http.StatusBadRequest, ValidationErrorResponse{Error: "invalid inferencestack spec", Fields: fields, RequestID: responseRequestID(w)})
+}
+
+// validateReplicaCounts checks that no replica count is negative and that
+// minReplicas <= replicas <= maxReplicas, where a maxReplicas of zero means
+// unset. prefix is prepended to each field name, e.g. "pool.".
+func validateReplicaCounts(prefix string, replicas, minReplicas, maxReplicas int) []FieldError {
+	var fields []FieldError
+	add := func(field, format string, args ...any) {
+		fields = append(fields, FieldError{Field: prefix + field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if replicas < 0 {
+		add("replicas", "replicas must be at least 0, got %d", replicas)
+	}
+	if minReplicas < 0 {
+		add("minReplicas", "minReplicas must be at least 0, got %d", minReplicas)
+	}
+	if maxReplicas < 0 {
+		add("maxReplicas", "maxReplicas must be at least 0, got %d", maxReplicas)
+	}
+	if minReplicas > replicas {
+		add("minReplicas", "minReplicas (%d) must not exceed replicas (%d)", minReplicas, replicas)
+	}
+	if maxReplicas > 0 && replicas > maxReplicas {
+		add("maxReplicas", "maxReplicas (%d) must be at least replicas (%d)", maxReplicas, replicas)
+	}
+	return fields
+}
+
+// validateInferenceStackRequest checks a create request against the rules the
+// InferenceStack CRD and operator enforce, so invalid stacks are rejected
+// before they are created rather than failing at reconcile. A maxReplicas of
+// zero means unset.
+func validateInferenceStackRequest(req CreateInferenceStackRequest) []FieldError {
+	var fields []FieldError
+	add := func(field, format string, args ...any) {
+		fields = append(fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if req.Name == "" {
+		add("name", "name is required")
+	}
+	if req.Namespace == "" {
+		add("namespace", "namespace is required")
+	}
+	if req.ModelName == "" {
+		add("modelName", "modelName is required")
+	}
+	if req.ServingBackend == "" {
+		add("servingBackend", "servingBackend is required")
+	} else if !slices.Contains(validServingBackends, req.ServingBackend) {
+		add("servingBackend", "unsupported servingBackend %q: must be one of %s", req.ServingBackend, strings.Join(validServingBackends, ", "))
+	}
+
+	pool := req.Pool
+	if pool.GPUCount < 0 {
+		add("pool.gpuCount", "gpuCount must be at least 0, got %d", pool.GPUCount)
+	}
+	if err := validateMIGProfile(pool.MIGProfile); err != nil {
+		add("pool.migProfile", "%v", err)
+	}
+	fields = append(fields, validateReplicaCounts("pool.", pool.Replicas, pool.MinReplicas, pool.MaxReplicas)...)
+
+	if req.EPP != nil {
+		if err := validateEPPStrategy(req.EPP.Strategy); err != nil {
+			add("epp.strategy", "%v", err)
+		}
+		if err := validateEPPWeights(req.EPP.Weights); err != nil {
+			add("epp.weights", "%v", err)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+// L4RouteHandler handles TCPRoute, TLSRoute, and UDPRoute API requests.
+// Kind selects which v1alpha2 route type the handler serves.
+type L4RouteHandler struct {
+	Store database.Store
+	Kind  string // "TCPRoute", "TLSRoute", or "UDPRoute"
+}
+
+// List returns all routes of the handler's kind, optionally filtered by ?namespace= query param.
+func (h *L4RouteHandler) List(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	resp, err := h.list(r.Context(), k8s, r.URL.Query().Get("namespace"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Get returns a single route by namespace and name.
+func (h *L4RouteHandler) Get(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	resp, err := h.get(r.Context(), k8s, ns, name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Create creates a new route.
+func (h *L4RouteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	var req CreateL4RouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	if req.Name == "" || req.Namespace == "" {
+		writeError(w, http.StatusBadRequest, "name and namespace are required")
+		return
+	}
+	if err := validateL4Route(h.Kind, req.ParentRefs, req.Hostnames, req.Rules); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var refs []BackendRefRequest
+	for _, rule := range req.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	warnings, ok := validateRouteBackends(w, r, k8s, req.Namespace, refs)
+	if !ok {
+		return
+	}
+
+	resp, err := h.create(r.Context(), k8s, req)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	auditLog(h.Store, r.Context(), "create", h.Kind, req.Name, req.Namespace, nil, resp)
+	resp.Warnings = warnings
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// Update modifies an existing route.
+func (h *L4RouteHandler) Update(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	var req UpdateL4RouteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if err := validateL4Route(h.Kind, req.ParentRefs, req.Hostnames, req.Rules); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before, after, err := h.update(r.Context(), k8s, ns, name, req)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	auditLog(h.Store, r.Context(), "update", h.Kind, name, ns, before, after)
+	writeJSON(w, http.StatusOK, after)
+}
+
+// Delete removes a route.
+func (h *L4RouteHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ns := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	if err := h.delete(r.Context(), k8s, ns, name); err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	auditLog(h.Store, r.Context(), "delete", h.Kind, name, ns, map[string]string{"name": name, "namespace": ns}, nil)
+	writeJSON(w, http.StatusOK, map[string]string{"message": strings.ToLower(h.Kind) + " deleted", "name": name, "namespace": ns})
+}
+
+func (h *L4RouteHandler) list(ctx context.Context, k8s *kubernetes.Client, ns string) ([]L4RouteResponse, error) {
+	var resp []L4RouteResponse
+	switch h.Kind {
+	case "TCPRoute":
+		routes, err := k8s.ListTCPRoutes(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		resp = make([]L4RouteResponse, 0, len(routes))
+		for i := range routes {
+			resp = append(resp, toTCPRouteResponse(&routes[i]))
+		}
+	case "TLSRoute":
+		routes, err := k8s.ListTLSRoutes(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		resp = make([]L4RouteResponse, 0, len(routes))
+		for i := range routes {
+			resp = append(resp, toTLSRouteResponse(&routes[i]))
+		}
+	case "UDPRoute":
+		routes, err := k8s.ListUDPRoutes(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		resp = make([]L4RouteResponse, 0, len(routes))
+		for i := range routes {
+			resp = append(resp, toUDPRouteResponse(&routes[i]))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported route kind %q", h.Kind)
+	}
+	return resp, nil
+}
+
+func (h *L4RouteHandler) get(ctx context.Context, k8s *kubernetes.Client, ns, name string) (L4RouteResponse, error) {
+	switch h.Kind {
+	case "TCPRoute":
+		tr, err := k8s.GetTCPRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toTCPRouteResponse(tr), nil
+	case "TLSRoute":
+		tr, err := k8s.GetTLSRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toTLSRouteResponse(tr), nil
+	case "UDPRoute":
+		ur, err := k8s.GetUDPRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toUDPRouteResponse(ur), nil
+	}
+	return L4RouteResponse{}, fmt.Errorf("unsupported route kind %q", h.Kind)
+}
+
+func (h *L4RouteHandler) create(ctx context.Context, k8s *kubernetes.Client, req CreateL4RouteRequest) (L4RouteResponse, error) {
+	switch h.Kind {
+	case "TCPRoute":
+		created, err := k8s.CreateTCPRoute(ctx, toTCPRouteObject(req))
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toTCPRouteResponse(created), nil
+	case "TLSRoute":
+		created, err := k8s.CreateTLSRoute(ctx, toTLSRouteObject(req))
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toTLSRouteResponse(created), nil
+	case "UDPRoute":
+		created, err := k8s.CreateUDPRoute(ctx, toUDPRouteObject(req))
+		if err != nil {
+			return L4RouteResponse{}, err
+		}
+		return toUDPRouteResponse(created), nil
+	}
+	return L4RouteResponse{}, fmt.Errorf("unsupported route kind %q", h.Kind)
+}
+
+// update applies req to the existing route and returns its state before and
+// after the change. A missing route surfaces as a NotFound error.
+func (h *L4RouteHandler) update(ctx context.Context, k8s *kubernetes.Client, ns, name string, req UpdateL4RouteRequest) (L4RouteResponse, L4RouteResponse, error) {
+	switch h.Kind {
+	case "TCPRoute":
+		existing, err := k8s.GetTCPRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		before := toTCPRouteResponse(existing)
+		applyUpdateToTCPRoute(existing, req)
+		updated, err := k8s.UpdateTCPRoute(ctx, existing)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		return before, toTCPRouteResponse(updated), nil
+	case "TLSRoute":
+		existing, err := k8s.GetTLSRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		before := toTLSRouteResponse(existing)
+		applyUpdateToTLSRoute(existing, req)
+		updated, err := k8s.UpdateTLSRoute(ctx, existing)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		return before, toTLSRouteResponse(updated), nil
+	case "UDPRoute":
+		existing, err := k8s.GetUDPRoute(ctx, ns, name)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		before := toUDPRouteResponse(existing)
+		applyUpdateToUDPRoute(existing, req)
+		updated, err := k8s.UpdateUDPRoute(ctx, existing)
+		if err != nil {
+			return L4RouteResponse{}, L4RouteResponse{}, err
+		}
+		return before, toUDPRouteResponse(updated), nil
+	}
+	return L4RouteResponse{}, L4RouteResponse{}, fmt.Errorf("unsupported route kind %q", h.Kind)
+}
+
+func (h *L4RouteHandler) delete(ctx context.Context, k8s *kubernetes.Client, ns, name string) error {
+	switch h.Kind {
+	case "TCPRoute":
+		return k8s.DeleteTCPRoute(ctx, ns, name)
+	case "TLSRoute":
+		return k8s.DeleteTLSRoute(ctx, ns, name)
+	case "UDPRoute":
+		return k8s.DeleteUDPRoute(ctx, ns, name)
+	}
+	return fmt.Errorf("unsupported route kind %q", h.Kind)
+}
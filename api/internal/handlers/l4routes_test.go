@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func newL4RouteRouter(t *testing.T, kind string) http.Handler {
+	t.Helper()
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).Build()
+	handler := &L4RouteHandler{Kind: kind}
+
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(kubernetes.NewForTest(fakeClient)))
+	r.Get("/", handler.List)
+	r.Post("/", handler.Create)
+	r.Get("/{namespace}/{name}", handler.Get)
+	r.Put("/{namespace}/{name}", handler.Update)
+	r.Delete("/{namespace}/{name}", handler.Delete)
+	return r
+}
+
+func serveL4(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestL4RouteHandler_CRUD(t *testing.T) {
+	for _, kind := range []string{"TCPRoute", "TLSRoute", "UDPRoute"} {
+		t.Run(kind, func(t *testing.T) {
+			r := newL4RouteRouter(t, kind)
+
+			body := `{
+				"name": "db",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"backendRefs": [{"name": "postgres", "port": 5432}]}]
+			}`
+			w := serveL4(r, http.MethodPost, "/", body)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("create: expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			var created L4RouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if created.Kind != kind || created.Name != "db" || len(created.Rules) != 1 ||
+				created.Rules[0].BackendRefs[0].Name != "postgres" {
+				t.Errorf("unexpected create response: %+v", created)
+			}
+
+			w = serveL4(r, http.MethodGet, "/?namespace=default", "")
+			var list []L4RouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(list) != 1 {
+				t.Errorf("expected 1 route, got %d", len(list))
+			}
+
+			update := `{
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"backendRefs": [{"name": "postgres-v2", "port": 5432}]}]
+			}`
+			w = serveL4(r, http.MethodPut, "/default/db", update)
+			if w.Code != http.StatusOK {
+				t.Fatalf("update: expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			w = serveL4(r, http.MethodGet, "/default/db", "")
+			var got L4RouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.Rules[0].BackendRefs[0].Name != "postgres-v2" {
+				t.Errorf("update not applied: %+v", got.Rules)
+			}
+
+			w = serveL4(r, http.MethodDelete, "/default/db", "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("delete: expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if w = serveL4(r, http.MethodGet, "/default/db", ""); w.Code != http.StatusNotFound {
+				t.Errorf("expected 404 after delete, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestL4RouteHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "invalid JSON",
+			kind:       "TCPRoute",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       "{invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing parentRefs",
+			kind:       "TCPRoute",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"name": "db", "namespace": "default", "rules": [{"backendRefs": [{"name": "postgres", "port": 5432}]}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "backendRef without port",
+			kind:       "UDPRoute",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"name": "dns", "namespace": "default", "parentRefs": [{"name": "gw"}], "rules": [{"backendRefs": [{"name": "coredns"}]}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "hostnames on TCPRoute",
+			kind:       "TCPRoute",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"name": "db", "namespace": "default", "parentRefs": [{"name": "gw"}], "hostnames": ["db.example.com"], "rules": [{"backendRefs": [{"name": "postgres", "port": 5432}]}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "hostnames on TLSRoute",
+			kind:       "TLSRoute",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       `{"name": "db", "namespace": "default", "parentRefs": [{"name": "gw"}], "hostnames": ["db.example.com"], "rules": [{"backendRefs": [{"name": "postgres", "port": 5432}]}]}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "update nonexistent route",
+			kind:       "TLSRoute",
+			method:     http.MethodPut,
+			path:       "/default/missing",
+			body:       `{"parentRefs": [{"name": "gw"}], "rules": [{"backendRefs": [{"name": "postgres", "port": 5432}]}]}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "delete nonexistent route",
+			kind:       "UDPRoute",
+			method:     http.MethodDelete,
+			path:       "/default/missing",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveL4(newL4RouteRouter(t, tt.kind), tt.method, tt.path, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
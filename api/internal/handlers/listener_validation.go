@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	tlsModeTerminate   = "Terminate"
+	tlsModePassthrough = "Passthrough"
+)
+
+// ListenerConflict describes an invalid combination of listeners within a single Gateway.
+type ListenerConflict struct {
+	Port      int32    `json:"port"`
+	Listeners []string `json:"listeners"`
+	Reason    string   `json:"reason"`
+}
+
+// listenerConflictResponse is the 400 body returned when listeners conflict.
+type listenerConflictResponse struct {
+	Error     string             `json:"error"`
+	Conflicts []ListenerConflict `json:"conflicts"`
+	RequestID string             `json:"requestId,omitempty"`
+}
+
+// protocolFamily groups listener protocols that may share a port. HTTPS and
+// TLS both select by SNI and can coexist; every other protocol needs its own port.
+func protocolFamily(protocol string) string {
+	switch strings.ToUpper(protocol) {
+	case "HTTPS", "TLS":
+		return "TLS"
+	default:
+		return strings.ToUpper(protocol)
+	}
+}
+
+// findListenerConflicts detects within-gateway listener conflicts: listeners
+// on the same port with incompatible protocols, and listeners on the same port
+// and protocol family whose hostnames overlap (including both being empty).
+func findListenerConflicts(listeners []GatewayBundleListenerReq) []ListenerConflict {
+	conflicts := make([]ListenerConflict, 0)
+
+	// Incompatible protocols sharing a port.
+	portsByFamily := make(map[string][]int32)
+	var families []string
+	for _, l := range listeners {
+		f := protocolFamily(l.Protocol)
+		if _, ok := portsByFamily[f]; !ok {
+			families = append(families, f)
+		}
+		portsByFamily[f] = appendUniquePort(portsByFamily[f], l.Port)
+	}
+	for i := 0; i < len(families); i++ {
+		for j := i + 1; j < len(families); j++ {
+			for _, port := range findPortConflicts(portsByFamily[families[i]], portsByFamily[families[j]]) {
+				var names, protocols []string
+				for _, l := range listeners {
+					if l.Port == port {
+						names = append(names, l.Name)
+						protocols = append(protocols, strings.ToUpper(l.Protocol))
+					}
+				}
+				conflicts = append(conflicts, ListenerConflict{
+					Port:      port,
+					Listeners: names,
+					Reason:    fmt.Sprintf("incompatible protocols on port %d: %s", port, strings.Join(protocols, ", ")),
+				})
+			}
+		}
+	}
+
+	// Duplicate hostnames on the same port and protocol family.
+	for i := 0; i < len(listeners); i++ {
+		for j := i + 1; j < len(listeners); j++ {
+			a, b := listeners[i], listeners[j]
+			if a.Port != b.Port || protocolFamily(a.Protocol) != protocolFamily(b.Protocol) {
+				continue
+			}
+			if !listenerHostnamesConflict(a.Hostname, b.Hostname) {
+				continue
+			}
+			conflicts = append(conflicts, ListenerConflict{
+				Port:      a.Port,
+				Listeners: []string{a.Name, b.Name},
+				Reason:    fmt.Sprintf("duplicate hostname %q on port %d", displayHostname(a.Hostname), a.Port),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// listenerHostnamesConflict reports whether two listeners on the same port
+// cannot be told apart by hostname. Gateway API only requires hostnames to be
+// distinct: a wildcard or empty hostname may sit beside a more specific one,
+// which wins for the requests it matches.
+func listenerHostnamesConflict(a, b string) bool {
+	return a == b
+}
+
+func displayHostname(h string) string {
+	if h == "" {
+		return "*"
+	}
+	return h
+}
+
+// writeListenerConflicts validates listeners and, if any conflict, writes a
+// 400 response describing each one. Returns true if a response was written.
+func writeListenerConflicts(w http.ResponseWriter, listeners []GatewayBundleListenerReq) bool {
+	conflicts := findListenerConflicts(listeners)
+	if len(conflicts) == 0 {
+		return false
+	}
+	msgs := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		msgs = append(msgs, c.Reason)
+	}
+	writeJSON(w, http.StatusBadRequest, listenerConflictResponse{
+		Error:     "listener conflicts: " + strings.Join(msgs, "; "),
+		Conflicts: conflicts,
+		RequestID: responseRequestID(w),
+	})
+	return true
+}
+
+// listenerProtocols are the protocols accepted by the GatewayBundle CRD.
+var listenerProtocols = []string{"HTTP", "HTTPS", "TLS", "TCP", "UDP"}
+
+// validateListeners checks each listener on its own before the bundle is
+// written. All problems are reported together so the caller can fix them in
+// one pass.
+func validateListeners(listeners []GatewayBundleListenerReq) error {
+	if problems := findListenerProblems(listeners); len(problems) > 0 {
+		return fmt.Errorf("invalid listeners: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// findListenerProblems returns one message per invalid listener setting:
+// names must be unique, ports in range, protocols supported, listeners that
+// terminate TLS must reference a certificate, and passthrough listeners must
+// pass validateListenerTLS.
+func findListenerProblems(listeners []GatewayBundleListenerReq) []string {
+	var problems []string
+	seen := make(map[string]bool, len(listeners))
+	for _, l := range listeners {
+		if l.Name == "" {
+			problems = append(problems, "listener name is required")
+		} else if seen[l.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate listener name %q", l.Name))
+		}
+		seen[l.Name] = true
+
+		if l.Port < 1 || l.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("listener %q: port %d out of range (1-65535)", l.Name, l.Port))
+		}
+		if !slices.Contains(listenerProtocols, l.Protocol) {
+			problems = append(problems, fmt.Sprintf("listener %q: unsupported protocol %q (must be one of %s)", l.Name, l.Protocol, strings.Join(listenerProtocols, ", ")))
+			continue
+		}
+
+		terminates := l.Protocol == "HTTPS" || (l.Protocol == "TLS" && (l.TLS == nil || l.TLS.Mode != tlsModePassthrough))
+		if terminates && (l.TLS == nil || len(l.TLS.CertificateRefs) == 0) {
+			problems = append(problems, fmt.Sprintf("listener %q: %s listeners require at least one tls.certificateRefs entry", l.Name, l.Protocol))
+			continue
+		}
+		if err := validateListenerTLS([]GatewayBundleListenerReq{l}); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
+
+// validateListenerTLS checks listener TLS settings. Passthrough listeners hand
+// the raw TLS stream to the backend selected by SNI, so they must use the TLS
+// protocol, carry no certificates, and only admit TLSRoutes.
+func validateListenerTLS(listeners []GatewayBundleListenerReq) error {
+	for _, l := range listeners {
+		if l.TLS == nil {
+			continue
+		}
+		switch l.TLS.Mode {
+		case "", tlsModeTerminate:
+			continue
+		case tlsModePassthrough:
+		default:
+			return fmt.Errorf("listener %q: unsupported tls.mode %q (must be %s or %s)", l.Name, l.TLS.Mode, tlsModeTerminate, tlsModePassthrough)
+		}
+
+		if !strings.EqualFold(l.Protocol, "TLS") {
+			return fmt.Errorf("listener %q: tls.mode %s requires protocol TLS, got %s", l.Name, tlsModePassthrough, l.Protocol)
+		}
+		if len(l.TLS.CertificateRefs) > 0 {
+			return fmt.Errorf("listener %q: certificateRefs are not allowed with tls.mode %s", l.Name, tlsModePassthrough)
+		}
+		if l.AllowedRoutes != nil {
+			for _, k := range l.AllowedRoutes.Kinds {
+				if k.Kind != "TLSRoute" || (k.Group != "" && k.Group != gatewayv1.GroupName) {
+					return fmt.Errorf("listener %q: %s listeners only accept TLSRoute, got %s", l.Name, tlsModePassthrough, k.Kind)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// listenerRouteKinds returns the route kinds to allow on a listener, with the
+// group filled in. Passthrough listeners default to TLSRoute when no kinds
+// are given.
+func listenerRouteKinds(l GatewayBundleListenerReq) []RouteGroupKind {
+	var kinds []RouteGroupKind
+	if l.AllowedRoutes != nil {
+		kinds = l.AllowedRoutes.Kinds
+	}
+	if len(kinds) == 0 && l.TLS != nil && l.TLS.Mode == tlsModePassthrough {
+		kinds = []RouteGroupKind{{Kind: "TLSRoute"}}
+	}
+
+	out := make([]RouteGroupKind, 0, len(kinds))
+	for _, k := range kinds {
+		if k.Group == "" {
+			k.Group = gatewayv1.GroupName
+		}
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindListenerConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		listeners []GatewayBundleListenerReq
+		want      int
+	}{
+		{
+			name: "distinct ports",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+				{Name: "https", Port: 443, Protocol: "HTTPS"},
+			},
+			want: 0,
+		},
+		{
+			name: "HTTPS and TLS share a port by SNI",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "https", Port: 443, Protocol: "HTTPS", Hostname: "a.example.com"},
+				{Name: "tls", Port: 443, Protocol: "TLS", Hostname: "b.example.com"},
+			},
+			want: 0,
+		},
+		{
+			name: "HTTP and TCP on the same port",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+				{Name: "tcp", Port: 80, Protocol: "TCP"},
+			},
+			want: 1,
+		},
+		{
+			name: "both listeners without hostname",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "a", Port: 80, Protocol: "HTTP"},
+				{Name: "b", Port: 80, Protocol: "HTTP"},
+			},
+			want: 1,
+		},
+		{
+			name: "wildcard alongside specific hostname",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "wild", Port: 80, Protocol: "HTTP", Hostname: "*.example.com"},
+				{Name: "api", Port: 80, Protocol: "HTTP", Hostname: "api.example.com"},
+			},
+			want: 0,
+		},
+		{
+			name: "same wildcard twice",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "a", Port: 443, Protocol: "HTTPS", Hostname: "*.example.com"},
+				{Name: "b", Port: 443, Protocol: "TLS", Hostname: "*.example.com"},
+			},
+			want: 1,
+		},
+		{
+			name: "catch-all alongside specific hostname",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "all", Port: 80, Protocol: "HTTP"},
+				{Name: "api", Port: 80, Protocol: "HTTP", Hostname: "api.example.com"},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findListenerConflicts(tt.listeners)
+			if len(got) != tt.want {
+				t.Errorf("expected %d conflicts, got %d: %+v", tt.want, len(got), got)
+			}
+		})
+	}
+}
+
+func TestValidateListeners(t *testing.T) {
+	cert := &ListenerTLSReq{CertificateRefs: []CertRefReq{{Name: "cert"}}}
+	tests := []struct {
+		name      string
+		listeners []GatewayBundleListenerReq
+		wantErr   string
+	}{
+		{
+			name: "valid HTTP and HTTPS",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+				{Name: "https", Port: 443, Protocol: "HTTPS", TLS: cert},
+			},
+		},
+		{
+			name: "duplicate names",
+			listeners: []GatewayBundleListenerReq{
+				{Name: "web", Port: 80, Protocol: "HTTP"},
+				{Name: "web", Port: 8080, Protocol: "HTTP"},
+			},
+			wantErr: `duplicate listener name "web"`,
+		},
+		{
+			name:      "missing name",
+			listeners: []GatewayBundleListenerReq{{Port: 80, Protocol: "HTTP"}},
+			wantErr:   "listener name is required",
+		},
+		{
+			name:      "port out of range",
+			listeners: []GatewayBundleListenerReq{{Name: "http", Port: 70000, Protocol: "HTTP"}},
+			wantErr:   "port 70000 out of range",
+		},
+		{
+			name:      "unsupported protocol",
+			listeners: []GatewayBundleListenerReq{{Name: "web", Port: 80, Protocol: "http"}},
+			wantErr:   `unsupported protocol "http"`,
+		},
+		{
+			name:      "HTTPS without certificate",
+			listeners: []GatewayBundleListenerReq{{Name: "https", Port: 443, Protocol: "HTTPS"}},
+			wantErr:   "HTTPS listeners require at least one tls.certificateRefs entry",
+		},
+		{
+			name:      "TLS terminate without certificate",
+			listeners: []GatewayBundleListenerReq{{Name: "tls", Port: 443, Protocol: "TLS", TLS: &ListenerTLSReq{Mode: "Terminate"}}},
+			wantErr:   "TLS listeners require at least one tls.certificateRefs entry",
+		},
+		{
+			name:      "TLS passthrough needs no certificate",
+			listeners: []GatewayBundleListenerReq{{Name: "tls", Port: 443, Protocol: "TLS", TLS: &ListenerTLSReq{Mode: "Passthrough"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListeners(tt.listeners)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateListenerTLS(t *testing.T) {
+	passthrough := &ListenerTLSReq{Mode: "Passthrough"}
+	tests := []struct {
+		name      string
+		listener  GatewayBundleListenerReq
+		wantError bool
+	}{
+		{
+			name:     "terminate with certificate",
+			listener: GatewayBundleListenerReq{Name: "https", Port: 443, Protocol: "HTTPS", TLS: &ListenerTLSReq{Mode: "Terminate", CertificateRefs: []CertRefReq{{Name: "cert"}}}},
+		},
+		{
+			name:     "passthrough without certificates",
+			listener: GatewayBundleListenerReq{Name: "tls", Port: 443, Protocol: "TLS", TLS: passthrough},
+		},
+		{
+			name:     "passthrough with explicit TLSRoute kind",
+			listener: GatewayBundleListenerReq{Name: "tls", Port: 443, Protocol: "TLS", TLS: passthrough, AllowedRoutes: &AllowedRoutesReq{Kinds: []RouteGroupKind{{Kind: "TLSRoute"}}}},
+		},
+		{
+			name:      "unknown mode",
+			listener:  GatewayBundleListenerReq{Name: "tls", Port: 443, Protocol: "TLS", TLS: &ListenerTLSReq{Mode: "Reencrypt"}},
+			wantError: true,
+		},
+		{
+			name:      "passthrough on HTTPS",
+			listener:  GatewayBundleListenerReq{Name: "https", Port: 443, Protocol: "HTTPS", TLS: passthrough},
+			wantError: true,
+		},
+		{
+			name:      "passthrough with certificate",
+			listener:  GatewayBundleListenerReq{Name: "tls", Port: 443, Protocol: "TLS", TLS: &ListenerTLSReq{Mode: "Passthrough", CertificateRefs: []CertRefReq{{Name: "cert"}}}},
+			wantError: true,
+		},
+		{
+			name:      "passthrough with HTTPRoute kind",
+			listener:  GatewayBundleListenerReq{Name: "tls", Port: 443, Protocol: "TLS", TLS: passthrough, AllowedRoutes: &AllowedRoutesReq{Kinds: []RouteGroupKind{{Kind: "HTTPRoute"}}}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListenerTLS([]GatewayBundleListenerReq{tt.listener})
+			if (err != nil) != tt.wantError {
+				t.Errorf("expected error=%v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestToGatewayBundleUnstructured_Passthrough(t *testing.T) {
+	obj := toGatewayBundleUnstructured(CreateGatewayBundleRequest{
+		Name:             "edge",
+		Namespace:        "default",
+		GatewayClassName: "nginx",
+		Listeners: []GatewayBundleListenerReq{
+			{Name: "tls", Port: 443, Protocol: "TLS", TLS: &ListenerTLSReq{Mode: "Passthrough"}},
+		},
+	})
+
+	resp := toGatewayBundleResponse(obj)
+	if len(resp.Listeners) != 1 {
+		t.Fatalf("expected 1 listener, got %d", len(resp.Listeners))
+	}
+	l := resp.Listeners[0]
+	if l.TLS == nil || l.TLS.Mode != "Passthrough" || len(l.TLS.CertificateRefs) != 0 {
+		t.Errorf("expected passthrough TLS without certificates, got %+v", l.TLS)
+	}
+	if l.AllowedRoutes == nil || len(l.AllowedRoutes.Kinds) != 1 {
+		t.Fatalf("expected one allowed route kind, got %+v", l.AllowedRoutes)
+	}
+	if k := l.AllowedRoutes.Kinds[0]; k.Kind != "TLSRoute" || k.Group != "gateway.networking.k8s.io" {
+		t.Errorf("expected TLSRoute kind, got %+v", k)
+	}
+}
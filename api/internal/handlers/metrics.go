@@ -1,27 +1,66 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
 )
 
 // MetricsHandler handles metrics API requests using Prometheus.
 type MetricsHandler struct {
-	Prom *prom.Client
+	Prom  *prom.Client
+	Names prom.MetricNames
+}
+
+// errPrometheusNotConfigured is the error body of every Prometheus-backed
+// endpoint when --prometheus-url is not set.
+const errPrometheusNotConfigured = "prometheus not configured"
+
+// requirePrometheus writes 503 {"error": "prometheus not configured"} and
+// returns false when no Prometheus client is configured.
+func (h *MetricsHandler) requirePrometheus(w http.ResponseWriter) bool {
+	if h.Prom == nil {
+		writeError(w, http.StatusServiceUnavailable, errPrometheusNotConfigured)
+		return false
+	}
+	return true
+}
+
+// parseREDWindow reads the optional window query parameter, the rate()
+// window of the RED queries. It must be one of prom.REDWindows and defaults
+// to prom.DefaultREDWindow.
+func parseREDWindow(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get("window")
+	if raw == "" {
+		return prom.DefaultREDWindow, nil
+	}
+	if !slices.Contains(prom.REDWindows, raw) {
+		return 0, fmt.Errorf("unsupported window %q: must be one of %s", raw, strings.Join(prom.REDWindows, ", "))
+	}
+	return time.ParseDuration(raw)
 }
 
 // Summary returns an aggregated RED metrics summary.
 func (h *MetricsHandler) Summary(w http.ResponseWriter, r *http.Request) {
-	if h.Prom == nil {
-		writeError(w, http.StatusServiceUnavailable, "prometheus not configured")
+	if !h.requirePrometheus(w) {
+		return
+	}
+
+	window, err := parseREDWindow(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	cn := cluster.ClusterNameFromContext(r.Context())
-	summary, err := h.Prom.Summary(r.Context(), time.Now(), cn)
+	summary, err := h.Prom.Summary(r.Context(), time.Now(), window, cn)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -31,13 +70,18 @@ func (h *MetricsHandler) Summary(w http.ResponseWriter, r *http.Request) {
 
 // ByRoute returns metrics grouped by HTTPRoute.
 func (h *MetricsHandler) ByRoute(w http.ResponseWriter, r *http.Request) {
-	if h.Prom == nil {
-		writeError(w, http.StatusServiceUnavailable, "prometheus not configured")
+	if !h.requirePrometheus(w) {
+		return
+	}
+
+	window, err := parseREDWindow(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	cn := cluster.ClusterNameFromContext(r.Context())
-	routes, err := h.Prom.ByRoute(r.Context(), time.Now(), cn)
+	routes, err := h.Prom.ByRoute(r.Context(), time.Now(), window, cn)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -50,13 +94,18 @@ func (h *MetricsHandler) ByRoute(w http.ResponseWriter, r *http.Request) {
 
 // ByGateway returns metrics grouped by gateway.
 func (h *MetricsHandler) ByGateway(w http.ResponseWriter, r *http.Request) {
-	if h.Prom == nil {
-		writeError(w, http.StatusServiceUnavailable, "prometheus not configured")
+	if !h.requirePrometheus(w) {
+		return
+	}
+
+	window, err := parseREDWindow(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	cn := cluster.ClusterNameFromContext(r.Context())
-	gateways, err := h.Prom.ByGateway(r.Context(), time.Now(), cn)
+	gateways, err := h.Prom.ByGateway(r.Context(), time.Now(), window, cn)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -66,3 +115,31 @@ func (h *MetricsHandler) ByGateway(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, gateways)
 }
+
+// RecordingRules returns Prometheus recording rules that precompute the RED
+// series for NGF. The rule file is YAML by default, ready to load into
+// Prometheus; pass format=json for JSON and alerts=true to include alerting
+// rules. Prometheus does not need to be configured.
+func (h *MetricsHandler) RecordingRules(w http.ResponseWriter, r *http.Request) {
+	names := h.Names
+	if names == (prom.MetricNames{}) {
+		names = prom.DefaultMetricNames()
+	}
+	rules := prom.RecordingRules(names, r.URL.Query().Get("alerts") == "true")
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "yaml":
+		data, err := yaml.Marshal(rules)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	case "json":
+		writeJSON(w, http.StatusOK, rules)
+	default:
+		writeError(w, http.StatusBadRequest, "format must be yaml or json")
+	}
+}
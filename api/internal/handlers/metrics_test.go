@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
+)
+
+func TestMetricsHandler_NotConfigured(t *testing.T) {
+	handler := &MetricsHandler{}
+	for name, fn := range map[string]http.HandlerFunc{
+		"summary":    handler.Summary,
+		"by-route":   handler.ByRoute,
+		"by-gateway": handler.ByGateway,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics/"+name, nil)
+			w := httptest.NewRecorder()
+			fn(w, req)
+
+			if w.Code != http.StatusServiceUnavailable {
+				t.Fatalf("expected status 503, got %d", w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %q", ct)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["error"] != "prometheus not configured" {
+				t.Errorf("expected error %q, got %q", "prometheus not configured", body["error"])
+			}
+		})
+	}
+}
+
+func TestParseREDWindow(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{query: "", want: prom.DefaultREDWindow},
+		{query: "?window=5m", want: 5 * time.Minute},
+		{query: "?window=1h", want: time.Hour},
+		{query: "?window=24h", want: 24 * time.Hour},
+		{query: "?window=2m", wantErr: true},
+		{query: "?window=60m", wantErr: true},
+		{query: "?window=soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics/summary"+tt.query, nil)
+			got, err := parseREDWindow(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseREDWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseREDWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsHandler_UnsupportedWindow(t *testing.T) {
+	client, err := prom.New("http://prometheus.invalid:9090", prom.DefaultMetricNames())
+	if err != nil {
+		t.Fatalf("prom.New: %v", err)
+	}
+	handler := &MetricsHandler{Prom: client}
+	for name, fn := range map[string]http.HandlerFunc{
+		"summary":    handler.Summary,
+		"by-route":   handler.ByRoute,
+		"by-gateway": handler.ByGateway,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics/"+name+"?window=7m", nil)
+			w := httptest.NewRecorder()
+			fn(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), "5m, 15m, 1h") {
+				t.Errorf("expected the allowed windows in the error, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMetricsHandler_RecordingRules(t *testing.T) {
+	names, err := prom.ParseMetricNames("requests=ngf_requests_total")
+	if err != nil {
+		t.Fatalf("ParseMetricNames: %v", err)
+	}
+	handler := &MetricsHandler{Names: names}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics/recording-rules", nil)
+	w := httptest.NewRecorder()
+	handler.RecordingRules(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Errorf("expected application/yaml, got %q", ct)
+	}
+	var file prom.RuleFile
+	if err := yaml.Unmarshal(w.Body.Bytes(), &file); err != nil {
+		t.Fatalf("failed to decode rules: %v", err)
+	}
+	if len(file.Groups) != 1 || len(file.Groups[0].Rules) != 4 {
+		t.Fatalf("expected one group of 4 recording rules, got %+v", file.Groups)
+	}
+	if expr := file.Groups[0].Rules[0].Expr; !strings.Contains(expr, "ngf_requests_total") {
+		t.Errorf("expected overridden requests metric in %q", expr)
+	}
+	if expr := file.Groups[0].Rules[2].Expr; !strings.Contains(expr, "nginx_gateway_fabric_http_request_duration_seconds_bucket") {
+		t.Errorf("expected default duration metric in %q", expr)
+	}
+}
+
+func TestMetricsHandler_RecordingRulesJSONWithAlerts(t *testing.T) {
+	handler := &MetricsHandler{}
+	req := httptest.NewRequest(http.MethodGet, "/metrics/recording-rules?format=json&alerts=true", nil)
+	w := httptest.NewRecorder()
+	handler.RecordingRules(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var file prom.RuleFile
+	if err := json.NewDecoder(w.Body).Decode(&file); err != nil {
+		t.Fatalf("failed to decode rules: %v", err)
+	}
+	if len(file.Groups) != 2 || file.Groups[1].Rules[0].Alert != "NGFHighErrorRate" {
+		t.Errorf("expected recording and alert groups, got %+v", file.Groups)
+	}
+}
+
+func TestMetricsHandler_RecordingRulesInvalidFormat(t *testing.T) {
+	handler := &MetricsHandler{}
+	req := httptest.NewRequest(http.MethodGet, "/metrics/recording-rules?format=xml", nil)
+	w := httptest.NewRecorder()
+	handler.RecordingRules(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
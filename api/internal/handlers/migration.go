@@ -1,16 +1,46 @@
 package handlers
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
 )
 
+// migrationImportTTL is how long an import is kept for analysis and
+// generation. Older imports are treated as missing and purged.
+const migrationImportTTL = 24 * time.Hour
+
 // MigrationHandler handles NGINX config migration API requests.
-type MigrationHandler struct{}
+type MigrationHandler struct {
+	DynamicClient dynamic.Interface
+	Store         database.Store
+}
+
+// getDynamicClient returns the dynamic client from the handler field or falls back
+// to the cluster context's dynamic client.
+func (h *MigrationHandler) getDynamicClient(r *http.Request) dynamic.Interface {
+	if h.DynamicClient != nil {
+		return h.DynamicClient
+	}
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		return nil
+	}
+	return k8s.DynamicClient()
+}
 
 // --- Request / Response types ---
 
@@ -34,6 +64,11 @@ type DiscoveredResource struct {
 	Name       string `json:"name"`
 	Namespace  string `json:"namespace"`
 	APIVersion string `json:"apiVersion"`
+
+	// Topology extracted from NGINX configs; empty for other formats.
+	Hostnames []string             `json:"hostnames,omitempty"`
+	Listeners []DiscoveredListener `json:"listeners,omitempty"`
+	Routes    []DiscoveredRoute    `json:"routes,omitempty"`
 }
 
 // AnalysisRequest asks for analysis of a previous import.
@@ -71,15 +106,17 @@ type GenerateResponse struct {
 	ImportID  string              `json:"importId"`
 	Resources []GeneratedResource `json:"resources"`
 	YAML      string              `json:"yaml"`
+	Warnings  []string            `json:"warnings,omitempty"` // imported resources that could not be converted
 }
 
 // GeneratedResource is a single generated Gateway API resource.
 type GeneratedResource struct {
-	Kind       string `json:"kind"`
-	Name       string `json:"name"`
-	Namespace  string `json:"namespace"`
-	APIVersion string `json:"apiVersion"`
-	YAML       string `json:"yaml"`
+	Kind       string              `json:"kind"`
+	Name       string              `json:"name"`
+	Namespace  string              `json:"namespace"`
+	APIVersion string              `json:"apiVersion"`
+	YAML       string              `json:"yaml"`
+	Source     *DiscoveredResource `json:"source,omitempty"` // resource this was converted from
 }
 
 // ApplyRequest asks to apply generated resources to a cluster.
@@ -93,6 +130,7 @@ type ApplyRequest struct {
 type ApplyResponse struct {
 	Applied int      `json:"applied"`
 	Skipped int      `json:"skipped"`
+	Resumed int      `json:"resumed"` // applied by an earlier Apply of the import and not retried
 	Errors  []string `json:"errors"`
 	DryRun  bool     `json:"dryRun"`
 }
@@ -123,7 +161,9 @@ func generateID() string {
 	return hex.EncodeToString(b)
 }
 
-// Import imports an existing NGINX configuration.
+// Import imports an existing NGINX configuration. The content and the
+// resources discovered in it are stored under the returned ID for Analysis
+// and Generate.
 func (h *MigrationHandler) Import(w http.ResponseWriter, r *http.Request) {
 	var req ImportRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -143,6 +183,24 @@ func (h *MigrationHandler) Import(w http.ResponseWriter, r *http.Request) {
 	id := generateID()
 	resources := discoverResources(req.Content, req.Format)
 
+	if h.Store != nil {
+		resourcesJSON, err := json.Marshal(resources)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "encoding discovered resources: "+err.Error())
+			return
+		}
+		if err := h.Store.SaveMigrationImport(r.Context(), database.MigrationImport{
+			ID:            id,
+			Format:        req.Format,
+			Content:       req.Content,
+			ResourcesJSON: string(resourcesJSON),
+		}); err != nil {
+			writeError(w, http.StatusInternalServerError, "saving import: "+err.Error())
+			return
+		}
+		h.purgeExpiredImports(r.Context())
+	}
+
 	writeJSON(w, http.StatusOK, ImportResponse{
 		ID:            id,
 		ResourceCount: len(resources),
@@ -150,6 +208,43 @@ func (h *MigrationHandler) Import(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// purgeExpiredImports deletes imports older than migrationImportTTL. Failures
+// are logged; expired imports are still ignored by loadImport.
+func (h *MigrationHandler) purgeExpiredImports(ctx context.Context) {
+	n, err := h.Store.DeleteMigrationImportsBefore(ctx, time.Now().Add(-migrationImportTTL))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to purge expired migration imports", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.InfoContext(ctx, "purged expired migration imports", "count", n)
+	}
+}
+
+// loadImport returns the discovered resources of a stored, unexpired import.
+// It writes an error response and returns false if the import is unavailable.
+func (h *MigrationHandler) loadImport(w http.ResponseWriter, r *http.Request, id string) ([]DiscoveredResource, bool) {
+	if h.Store == nil {
+		writeError(w, http.StatusServiceUnavailable, "migration store not configured")
+		return nil, false
+	}
+	imp, err := h.Store.GetMigrationImport(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "loading import: "+err.Error())
+		return nil, false
+	}
+	if imp == nil || time.Since(imp.CreatedAt) > migrationImportTTL {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("import %q not found or expired", id))
+		return nil, false
+	}
+	var resources []DiscoveredResource
+	if err := json.Unmarshal([]byte(imp.ResourcesJSON), &resources); err != nil {
+		writeError(w, http.StatusInternalServerError, "decoding imported resources: "+err.Error())
+		return nil, false
+	}
+	return resources, true
+}
+
 // discoverResources parses the supplied content and returns discovered resources.
 func discoverResources(content, format string) []DiscoveredResource {
 	var resources []DiscoveredResource
@@ -169,84 +264,6 @@ func discoverResources(content, format string) []DiscoveredResource {
 	return resources
 }
 
-// parseNginxConf extracts mock resources from NGINX config by counting
-// server and location blocks.
-func parseNginxConf(content string) []DiscoveredResource {
-	var resources []DiscoveredResource
-
-	// Count server blocks → each becomes a Gateway candidate.
-	serverCount := strings.Count(content, "server {") + strings.Count(content, "server{")
-	if serverCount == 0 {
-		serverCount = 1 // at minimum one gateway from any config
-	}
-	for i := 0; i < serverCount; i++ {
-		resources = append(resources, DiscoveredResource{
-			Kind:       "Gateway",
-			Name:       fmt.Sprintf("nginx-gateway-%d", i+1),
-			Namespace:  "default",
-			APIVersion: "gateway.networking.k8s.io/v1",
-		})
-	}
-
-	// Count location blocks → each becomes an HTTPRoute candidate.
-	locationCount := strings.Count(content, "location ") + strings.Count(content, "location\t")
-	if locationCount == 0 {
-		locationCount = 1
-	}
-	for i := 0; i < locationCount; i++ {
-		resources = append(resources, DiscoveredResource{
-			Kind:       "HTTPRoute",
-			Name:       fmt.Sprintf("nginx-route-%d", i+1),
-			Namespace:  "default",
-			APIVersion: "gateway.networking.k8s.io/v1",
-		})
-	}
-
-	return resources
-}
-
-// parseIngressYAML counts YAML documents with kind: Ingress.
-func parseIngressYAML(content string) []DiscoveredResource {
-	var resources []DiscoveredResource
-
-	docs := strings.Split(content, "---")
-	idx := 0
-	for _, doc := range docs {
-		doc = strings.TrimSpace(doc)
-		if doc == "" {
-			continue
-		}
-		if strings.Contains(doc, "kind: Ingress") || strings.Contains(doc, "kind:Ingress") {
-			name := extractYAMLField(doc, "name")
-			ns := extractYAMLField(doc, "namespace")
-			if name == "" {
-				name = fmt.Sprintf("ingress-%d", idx+1)
-			}
-			if ns == "" {
-				ns = "default"
-			}
-			resources = append(resources, DiscoveredResource{
-				Kind:       "Ingress",
-				Name:       name,
-				Namespace:  ns,
-				APIVersion: "networking.k8s.io/v1",
-			})
-			idx++
-		}
-	}
-
-	if len(resources) == 0 {
-		resources = append(resources, DiscoveredResource{
-			Kind:       "Ingress",
-			Name:       "ingress-1",
-			Namespace:  "default",
-			APIVersion: "networking.k8s.io/v1",
-		})
-	}
-
-	return resources
-}
-
 // parseVirtualServerYAML counts YAML documents with kind: VirtualServer.
 func parseVirtualServerYAML(content string) []DiscoveredResource {
 	var resources []DiscoveredResource
@@ -342,48 +359,14 @@ func (h *MigrationHandler) Analysis(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Build mock analysis items representing a realistic migration scenario.
-	items := []AnalysisItem{
-		{
-			Source: DiscoveredResource{
-				Kind: "Ingress", Name: "web-ingress", Namespace: "default",
-				APIVersion: "networking.k8s.io/v1",
-			},
-			Target:     "HTTPRoute",
-			Confidence: "high",
-			Issues:     []string{},
-			Notes:      []string{"Direct mapping available for path-based routing rules"},
-		},
-		{
-			Source: DiscoveredResource{
-				Kind: "Ingress", Name: "api-ingress", Namespace: "default",
-				APIVersion: "networking.k8s.io/v1",
-			},
-			Target:     "HTTPRoute",
-			Confidence: "high",
-			Issues:     []string{},
-			Notes:      []string{"TLS termination will be moved to Gateway listener"},
-		},
-		{
-			Source: DiscoveredResource{
-				Kind: "VirtualServer", Name: "app-vs", Namespace: "default",
-				APIVersion: "k8s.nginx.org/v1",
-			},
-			Target:     "HTTPRoute",
-			Confidence: "medium",
-			Issues:     []string{"Rate limiting annotations require policy attachment"},
-			Notes:      []string{"Most routing rules map to HTTPRoute matches"},
-		},
-		{
-			Source: DiscoveredResource{
-				Kind: "TransportServer", Name: "tcp-ts", Namespace: "default",
-				APIVersion: "k8s.nginx.org/v1alpha1",
-			},
-			Target:     "TCPRoute",
-			Confidence: "low",
-			Issues:     []string{"TCPRoute support varies by implementation", "Session persistence not directly supported"},
-			Notes:      []string{"Manual review recommended"},
-		},
+	resources, ok := h.loadImport(w, r, req.ImportID)
+	if !ok {
+		return
+	}
+
+	items := make([]AnalysisItem, 0, len(resources))
+	for _, res := range resources {
+		items = append(items, analyzeResource(res))
 	}
 
 	convertible := 0
@@ -430,71 +413,34 @@ func (h *MigrationHandler) Generate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gatewayYAML := `apiVersion: gateway.networking.k8s.io/v1
-kind: Gateway
-metadata:
-  name: migrated-gateway
-  namespace: default
-spec:
-  gatewayClassName: nginx
-  listeners:
-  - name: http
-    port: 80
-    protocol: HTTP
-  - name: https
-    port: 443
-    protocol: HTTPS
-    tls:
-      mode: Terminate
-      certificateRefs:
-      - name: tls-secret`
-
-	routeYAML := `apiVersion: gateway.networking.k8s.io/v1
-kind: HTTPRoute
-metadata:
-  name: migrated-route
-  namespace: default
-spec:
-  parentRefs:
-  - name: migrated-gateway
-  hostnames:
-  - "app.example.com"
-  rules:
-  - matches:
-    - path:
-        type: PathPrefix
-        value: /
-    backendRefs:
-    - name: app-service
-      port: 80`
-
-	combinedYAML := gatewayYAML + "\n---\n" + routeYAML
-
-	resources := []GeneratedResource{
-		{
-			Kind:       "Gateway",
-			Name:       "migrated-gateway",
-			Namespace:  "default",
-			APIVersion: "gateway.networking.k8s.io/v1",
-			YAML:       gatewayYAML,
-		},
-		{
-			Kind:       "HTTPRoute",
-			Name:       "migrated-route",
-			Namespace:  "default",
-			APIVersion: "gateway.networking.k8s.io/v1",
-			YAML:       routeYAML,
-		},
+	resources, ok := h.loadImport(w, r, req.ImportID)
+	if !ok {
+		return
+	}
+
+	generated, warnings, err := generateGatewayAPIResources(resources, req.ImportID, time.Now())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "generating resources: "+err.Error())
+		return
+	}
+
+	docs := make([]string, 0, len(generated))
+	for _, g := range generated {
+		docs = append(docs, g.YAML)
 	}
 
 	writeJSON(w, http.StatusOK, GenerateResponse{
 		ImportID:  req.ImportID,
-		Resources: resources,
-		YAML:      combinedYAML,
+		Resources: generated,
+		YAML:      strings.Join(docs, "\n---\n"),
+		Warnings:  warnings,
 	})
 }
 
-// Apply applies generated Gateway API resources to the cluster.
+// Apply applies generated Gateway API resources to the cluster. The outcome of
+// each resource is recorded under the import ID as it is applied, so
+// re-invoking Apply after an interruption skips the resources already applied
+// and retries the rest.
 func (h *MigrationHandler) Apply(w http.ResponseWriter, r *http.Request) {
 	var req ApplyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -508,7 +454,7 @@ func (h *MigrationHandler) Apply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resourceCount := len(req.Resources)
-	if resourceCount == 0 {
+	if resourceCount == 0 && req.DryRun {
 		// Default to a mock resource count when none are provided.
 		resourceCount = 2
 	}
@@ -523,9 +469,50 @@ func (h *MigrationHandler) Apply(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Real implementation requires dynamic client to apply unstructured resources.
-	// Return 501 until cluster-backed apply is implemented.
-	writeError(w, http.StatusNotImplemented, "cluster-backed apply is not yet implemented; use dry-run mode to preview")
+	if resourceCount == 0 {
+		writeError(w, http.StatusBadRequest, "resources are required")
+		return
+	}
+
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	now := time.Now()
+	completed := h.completedApplies(r.Context(), req.ImportID)
+	resp := ApplyResponse{Errors: []string{}}
+	for _, res := range req.Resources {
+		if completed[applyRecordKey(res.Kind, res.Namespace, res.Name)] {
+			resp.Resumed++
+			continue
+		}
+
+		obj, gvr, err := decodeGeneratedResource(res)
+		if err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s %s/%s: %s", res.Kind, res.Namespace, res.Name, err))
+			h.recordApply(r.Context(), req.ImportID, res, applyStatusFailed, err)
+			continue
+		}
+		stampProvenance(obj, provenanceAnnotations(res.Source, req.ImportID, now))
+
+		_, err = dc.Resource(gvr).Namespace(obj.GetNamespace()).Create(r.Context(), obj, metav1.CreateOptions{})
+		if err != nil {
+			if k8serrors.IsAlreadyExists(err) {
+				resp.Skipped++
+				h.recordApply(r.Context(), req.ImportID, res, applyStatusExists, nil)
+				continue
+			}
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s %s/%s: %s", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err))
+			h.recordApply(r.Context(), req.ImportID, res, applyStatusFailed, err)
+			continue
+		}
+		resp.Applied++
+		h.recordApply(r.Context(), req.ImportID, res, applyStatusApplied, nil)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
 }
 
 // Validate validates migrated resources against the running gateway.
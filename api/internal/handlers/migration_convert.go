@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMigrationGateway is the name of the Gateway generated when the
+// import did not describe one (Ingress and VirtualServer imports).
+const defaultMigrationGateway = "migrated-gateway"
+
+// analyzeResource assesses how well a single imported resource maps to
+// Gateway API.
+func analyzeResource(res DiscoveredResource) AnalysisItem {
+	item := AnalysisItem{Source: res, Issues: []string{}, Notes: []string{}}
+
+	switch res.Kind {
+	case "Ingress":
+		item.Target = "HTTPRoute"
+		item.Confidence = "high"
+		item.Notes = append(item.Notes, "Direct mapping available for path-based routing rules")
+		for _, l := range res.Listeners {
+			if l.Protocol == "HTTPS" {
+				item.Notes = append(item.Notes, "TLS termination will be moved to Gateway listener")
+				break
+			}
+		}
+		if groups := splitByHost(&res); len(groups) > 1 {
+			item.Notes = append(item.Notes, fmt.Sprintf("Converted into %d HTTPRoutes, one per host", len(groups)))
+		}
+		for _, route := range res.Routes {
+			if len(route.Backends) == 0 {
+				item.Confidence = "medium"
+				item.Issues = append(item.Issues, fmt.Sprintf("Path %q has no Service backend and will not be converted", route.Path))
+			}
+			for _, b := range route.Backends {
+				if b.Port == 0 && b.PortName != "" {
+					item.Confidence = "medium"
+					item.Issues = append(item.Issues, fmt.Sprintf("Backend %q uses named port %q; backendRefs require a port number", b.Name, b.PortName))
+				}
+			}
+		}
+	case "VirtualServer", "VirtualServerRoute":
+		item.Target = "HTTPRoute"
+		item.Confidence = "medium"
+		item.Issues = append(item.Issues, "Policies and advanced routing (actions, splits, error pages) require policy attachment or manual review")
+		item.Notes = append(item.Notes, "Most routing rules map to HTTPRoute matches")
+	case "TransportServer":
+		item.Target = "TCPRoute"
+		item.Confidence = "low"
+		item.Issues = append(item.Issues, "TCPRoute support varies by implementation", "Session persistence not directly supported")
+		item.Notes = append(item.Notes, "Manual review recommended")
+	case "Gateway":
+		item.Target = "Gateway"
+		item.Confidence = "high"
+		for _, l := range res.Listeners {
+			if l.Protocol == "HTTPS" {
+				item.Notes = append(item.Notes, fmt.Sprintf("Listener on port %d needs a TLS Secret for certificateRefs", l.Port))
+			}
+		}
+	case "HTTPRoute":
+		item.Target = "HTTPRoute"
+		item.Confidence = "high"
+		for _, route := range res.Routes {
+			switch {
+			case route.MatchType == "RegularExpression":
+				item.Confidence = "medium"
+				item.Issues = append(item.Issues, fmt.Sprintf("Regex location %q uses implementation-specific matching", route.Path))
+			case len(route.Backends) == 0:
+				item.Confidence = "medium"
+				item.Issues = append(item.Issues, fmt.Sprintf("Location %q has no proxy_pass and will not be converted", route.Path))
+			}
+			item.Notes = append(item.Notes, upstreamNotes(route.Backends)...)
+		}
+	case "TCPRoute", "UDPRoute":
+		item.Target = res.Kind
+		item.Confidence = "medium"
+		item.Issues = append(item.Issues, res.Kind+" is part of the experimental Gateway API channel and requires its CRD to be installed")
+		for _, route := range res.Routes {
+			item.Notes = append(item.Notes, upstreamNotes(route.Backends)...)
+		}
+	default:
+		item.Target = "Unknown"
+		item.Confidence = "low"
+		item.Issues = append(item.Issues, fmt.Sprintf("No Gateway API mapping for %s", res.Kind))
+	}
+	return item
+}
+
+// upstreamNotes returns a note for every backend that is an NGINX upstream
+// group, since those have to be replaced by a Kubernetes Service.
+func upstreamNotes(backends []DiscoveredBackend) []string {
+	var notes []string
+	for _, b := range backends {
+		if len(b.Servers) > 0 {
+			notes = append(notes, fmt.Sprintf("Upstream %q (%s) needs a Service named %q", b.Name, strings.Join(b.Servers, ", "), sanitizeResourceName(b.Name)))
+		}
+	}
+	return notes
+}
+
+// generateGatewayAPIResources converts imported resources into a Gateway and
+// routes, stamped with provenance annotations. Resources whose routes carry
+// their own hosts (Ingress) produce one HTTPRoute per host. Resources that
+// cannot be converted are reported as warnings.
+func generateGatewayAPIResources(resources []DiscoveredResource, importID string, now time.Time) ([]GeneratedResource, []string, error) {
+	var gatewaySource *DiscoveredResource
+	for i := range resources {
+		if resources[i].Kind == "Gateway" {
+			gatewaySource = &resources[i]
+			break
+		}
+	}
+
+	gatewayName, gatewayNamespace := defaultMigrationGateway, "default"
+	if gatewaySource != nil {
+		gatewayName, gatewayNamespace = gatewaySource.Name, gatewaySource.Namespace
+	} else if len(resources) > 0 && resources[0].Namespace != "" {
+		gatewayNamespace = resources[0].Namespace
+	}
+
+	// Routes in other namespaces can only attach if the listeners allow it.
+	crossNamespace := false
+	for _, res := range resources {
+		if res.Kind != "Gateway" && res.Namespace != "" && res.Namespace != gatewayNamespace {
+			crossNamespace = true
+		}
+	}
+
+	listeners, warnings := gatewayListeners(resources, gatewaySource, gatewayNamespace)
+	gateway, err := newGeneratedResource("Gateway", gatewayName, gatewayNamespace, gatewaySource,
+		gatewaySpec(gatewayName, listeners, crossNamespace), importID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	generated := []GeneratedResource{gateway}
+
+	parentRefs := []any{map[string]any{"name": gatewayName, "namespace": gatewayNamespace}}
+	for i := range resources {
+		res := &resources[i]
+		if res.Kind == "Gateway" {
+			continue
+		}
+
+		ns := res.Namespace
+		if ns == "" {
+			ns = gatewayNamespace
+		}
+		for _, group := range splitByHost(res) {
+			kind, spec, warns := routeSpec(&group.resource)
+			warnings = append(warnings, warns...)
+			if spec == nil {
+				continue
+			}
+			spec["parentRefs"] = parentRefs
+
+			route, err := newGeneratedResource(kind, sanitizeResourceName(res.Name+group.suffix), ns, res, spec, importID, now)
+			if err != nil {
+				return nil, nil, err
+			}
+			generated = append(generated, route)
+		}
+	}
+	return generated, warnings, nil
+}
+
+// hostGroup is the part of an imported resource served by one host.
+type hostGroup struct {
+	suffix   string // appended to the route name when the resource is split
+	resource DiscoveredResource
+}
+
+// splitByHost groups the routes of a resource by their Host. Resources whose
+// routes have no hosts (NGINX servers) and single-host resources yield one
+// group; otherwise each host gets a group named after it, and host-less routes
+// (an Ingress defaultBackend) a "-default" group.
+func splitByHost(res *DiscoveredResource) []hostGroup {
+	var hosts []string
+	byHost := make(map[string][]DiscoveredRoute)
+	anyHost := false
+	for _, route := range res.Routes {
+		if _, ok := byHost[route.Host]; !ok {
+			hosts = append(hosts, route.Host)
+		}
+		byHost[route.Host] = append(byHost[route.Host], route)
+		anyHost = anyHost || route.Host != ""
+	}
+	if !anyHost {
+		return []hostGroup{{resource: *res}}
+	}
+
+	groups := make([]hostGroup, 0, len(hosts))
+	for _, host := range hosts {
+		part := *res
+		part.Routes = byHost[host]
+		part.Hostnames = nil
+		suffix := "-default"
+		if host != "" {
+			part.Hostnames = []string{host}
+			suffix = "-" + strings.Replace(host, "*.", "wildcard.", 1)
+		}
+		groups = append(groups, hostGroup{suffix: suffix, resource: part})
+	}
+	if len(groups) == 1 {
+		groups[0].suffix = ""
+	}
+	return groups
+}
+
+// gatewayListener is a Gateway listener with its resolved certificateRefs.
+type gatewayListener struct {
+	DiscoveredListener
+	certRefs []any
+}
+
+// gatewayListeners returns the listeners of a discovered Gateway, or else the
+// union of the listeners of all imported resources, or else HTTP on port 80
+// and HTTPS on port 443. Certificate Secrets outside the Gateway's namespace
+// are referenced with their namespace and reported as warnings.
+func gatewayListeners(resources []DiscoveredResource, gatewaySource *DiscoveredResource, gatewayNamespace string) ([]gatewayListener, []string) {
+	sources := resources
+	if gatewaySource != nil {
+		sources = []DiscoveredResource{*gatewaySource}
+	}
+
+	var listeners []gatewayListener
+	var warnings []string
+	index := make(map[string]int)
+	seenRefs := make(map[string]bool)
+	for _, res := range sources {
+		for _, l := range res.Listeners {
+			key := fmt.Sprintf("%s/%d/%s", l.Protocol, l.Port, l.Hostname)
+			i, ok := index[key]
+			if !ok {
+				i = len(listeners)
+				index[key] = i
+				listeners = append(listeners, gatewayListener{
+					DiscoveredListener: DiscoveredListener{Port: l.Port, Protocol: l.Protocol, Hostname: l.Hostname},
+				})
+			}
+			for _, secret := range l.CertificateRefs {
+				if seenRefs[key+"/"+res.Namespace+"/"+secret] {
+					continue
+				}
+				seenRefs[key+"/"+res.Namespace+"/"+secret] = true
+				ref := map[string]any{"name": secret}
+				if res.Namespace != "" && res.Namespace != gatewayNamespace {
+					ref["namespace"] = res.Namespace
+					warnings = append(warnings, fmt.Sprintf("Secret %s/%s is outside the Gateway namespace %q; create a ReferenceGrant for it", res.Namespace, secret, gatewayNamespace))
+				}
+				listeners[i].certRefs = append(listeners[i].certRefs, ref)
+			}
+		}
+	}
+
+	if len(listeners) == 0 {
+		listeners = []gatewayListener{
+			{DiscoveredListener: DiscoveredListener{Port: 80, Protocol: "HTTP"}},
+			{DiscoveredListener: DiscoveredListener{Port: 443, Protocol: "HTTPS"}},
+		}
+	}
+	return listeners, warnings
+}
+
+// gatewaySpec builds a Gateway spec from the listeners. HTTPS listeners
+// without known certificates terminate TLS with a Secret named "<gateway>-tls".
+// With allNamespaces, listeners accept routes from every namespace.
+func gatewaySpec(gatewayName string, listeners []gatewayListener, allNamespaces bool) map[string]any {
+	specListeners := make([]any, 0, len(listeners))
+	for _, l := range listeners {
+		listener := map[string]any{
+			"name":     listenerName(l.DiscoveredListener),
+			"port":     l.Port,
+			"protocol": l.Protocol,
+		}
+		if l.Hostname != "" {
+			listener["hostname"] = l.Hostname
+		}
+		if allNamespaces {
+			listener["allowedRoutes"] = map[string]any{"namespaces": map[string]any{"from": "All"}}
+		}
+		if l.Protocol == "HTTPS" {
+			certRefs := l.certRefs
+			if len(certRefs) == 0 {
+				certRefs = []any{map[string]any{"name": gatewayName + "-tls"}}
+			}
+			listener["tls"] = map[string]any{
+				"mode":            "Terminate",
+				"certificateRefs": certRefs,
+			}
+		}
+		specListeners = append(specListeners, listener)
+	}
+	return map[string]any{
+		"gatewayClassName": "nginx",
+		"listeners":        specListeners,
+	}
+}
+
+// listenerName names HTTP and HTTPS listeners on their default ports "http"
+// and "https", and every other listener "<protocol>-<port>". Listeners with a
+// hostname get it appended.
+func listenerName(l DiscoveredListener) string {
+	var name string
+	switch {
+	case l.Protocol == "HTTP" && l.Port == 80:
+		name = "http"
+	case l.Protocol == "HTTPS" && l.Port == 443:
+		name = "https"
+	default:
+		name = fmt.Sprintf("%s-%d", strings.ToLower(l.Protocol), l.Port)
+	}
+	if l.Hostname != "" {
+		name += "-" + sanitizeResourceName(strings.Replace(l.Hostname, "*.", "wildcard.", 1))
+	}
+	return name
+}
+
+// routeSpec returns the route kind and spec (without parentRefs) for an
+// imported resource. A nil spec means the resource was skipped; the returned
+// warnings explain why or flag parts that were dropped.
+func routeSpec(res *DiscoveredResource) (string, map[string]any, []string) {
+	switch res.Kind {
+	case "Ingress", "VirtualServer", "VirtualServerRoute", "HTTPRoute":
+		return httpRouteSpec(res)
+	case "TransportServer", "TCPRoute", "UDPRoute":
+		kind := res.Kind
+		if kind == "TransportServer" {
+			kind = "TCPRoute"
+		}
+		var backendRefs []any
+		for _, route := range res.Routes {
+			backendRefs = append(backendRefs, backendRefsFor(route.Backends)...)
+		}
+		if len(backendRefs) == 0 {
+			return "", nil, []string{fmt.Sprintf("%s %s/%s: no backends found; convert to %s manually", res.Kind, res.Namespace, res.Name, kind)}
+		}
+		return kind, map[string]any{"rules": []any{map[string]any{"backendRefs": backendRefs}}}, nil
+	default:
+		return "", nil, []string{fmt.Sprintf("%s %s/%s: no Gateway API equivalent; skipped", res.Kind, res.Namespace, res.Name)}
+	}
+}
+
+// httpRouteSpec converts the locations of an imported server, or the paths of
+// an Ingress, into HTTPRoute rules. Resources without extracted topology get a single catch-all rule.
+func httpRouteSpec(res *DiscoveredResource) (string, map[string]any, []string) {
+	ref := fmt.Sprintf("%s %s/%s", res.Kind, res.Namespace, res.Name)
+	spec := map[string]any{}
+	var warnings []string
+
+	var hostnames []any
+	for _, h := range res.Hostnames {
+		if h == "" || h == "_" || strings.HasPrefix(h, "~") || strings.Contains(h, ":") || net.ParseIP(h) != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %q is not a valid Gateway API hostname; dropped", ref, h))
+			continue
+		}
+		hostnames = append(hostnames, h)
+	}
+	if len(hostnames) > 0 {
+		spec["hostnames"] = hostnames
+	}
+
+	if len(res.Routes) == 0 {
+		spec["rules"] = []any{map[string]any{
+			"matches": []any{pathMatch("PathPrefix", "/")},
+		}}
+		warnings = append(warnings, fmt.Sprintf("%s: routing rules were not extracted; review the generated rule and add backendRefs", ref))
+		return "HTTPRoute", spec, warnings
+	}
+
+	var rules []any
+	for _, route := range res.Routes {
+		if len(route.Backends) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: path %q has no backend; skipped", ref, route.Path))
+			continue
+		}
+		for _, b := range route.Backends {
+			if b.Port == 0 && b.PortName != "" {
+				warnings = append(warnings, fmt.Sprintf("%s: backend %q uses named port %q; replace the generated port 80 with its number", ref, b.Name, b.PortName))
+			}
+		}
+		rules = append(rules, map[string]any{
+			"matches":     []any{pathMatch(route.MatchType, route.Path)},
+			"backendRefs": backendRefsFor(route.Backends),
+		})
+	}
+	if len(rules) == 0 {
+		return "", nil, append(warnings, ref+": no paths with backends; skipped")
+	}
+	spec["rules"] = rules
+	return "HTTPRoute", spec, warnings
+}
+
+// pathMatch builds an HTTPRoute path match.
+func pathMatch(matchType, path string) map[string]any {
+	if matchType == "" {
+		matchType = "PathPrefix"
+	}
+	return map[string]any{"path": map[string]any{"type": matchType, "value": path}}
+}
+
+// backendRefsFor converts NGINX or Ingress backends to Service backendRefs.
+// The port is taken from the backend, then from its first upstream server,
+// then 80.
+func backendRefsFor(backends []DiscoveredBackend) []any {
+	refs := make([]any, 0, len(backends))
+	for _, b := range backends {
+		port := b.Port
+		if port == 0 && len(b.Servers) > 0 {
+			if _, p, err := net.SplitHostPort(b.Servers[0]); err == nil {
+				port, _ = strconv.Atoi(p)
+			}
+		}
+		if port == 0 {
+			port = 80
+		}
+		refs = append(refs, map[string]any{"name": sanitizeResourceName(b.Name), "port": port})
+	}
+	return refs
+}
+
+// newGeneratedResource renders a Gateway API object as YAML with provenance
+// annotations for source.
+func newGeneratedResource(kind, name, namespace string, source *DiscoveredResource, spec map[string]any, importID string, now time.Time) (GeneratedResource, error) {
+	apiVersion := gatewayAPIGVR(kind).GroupVersion().String()
+
+	annotations := map[string]any{}
+	for k, v := range provenanceAnnotations(source, importID, now) {
+		annotations[k] = v
+	}
+	obj := map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":        name,
+			"namespace":   namespace,
+			"annotations": annotations,
+		},
+		"spec": spec,
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(obj); err != nil {
+		return GeneratedResource{}, fmt.Errorf("encoding %s %s/%s: %w", kind, namespace, name, err)
+	}
+	if err := enc.Close(); err != nil {
+		return GeneratedResource{}, fmt.Errorf("encoding %s %s/%s: %w", kind, namespace, name, err)
+	}
+
+	return GeneratedResource{
+		Kind:       kind,
+		Name:       name,
+		Namespace:  namespace,
+		APIVersion: apiVersion,
+		YAML:       strings.TrimSuffix(buf.String(), "\n"),
+		Source:     source,
+	}, nil
+}
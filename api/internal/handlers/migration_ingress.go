@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ingressManifest is the subset of a networking.k8s.io/v1 Ingress that the
+// migration converts.
+type ingressManifest struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		DefaultBackend *ingressBackend `yaml:"defaultBackend"`
+		TLS            []struct {
+			Hosts      []string `yaml:"hosts"`
+			SecretName string   `yaml:"secretName"`
+		} `yaml:"tls"`
+		Rules []struct {
+			Host string `yaml:"host"`
+			HTTP *struct {
+				Paths []struct {
+					Path     string         `yaml:"path"`
+					PathType string         `yaml:"pathType"`
+					Backend  ingressBackend `yaml:"backend"`
+				} `yaml:"paths"`
+			} `yaml:"http"`
+		} `yaml:"rules"`
+	} `yaml:"spec"`
+}
+
+// ingressBackend is an Ingress backend. Resource backends are not converted.
+type ingressBackend struct {
+	Service *struct {
+		Name string `yaml:"name"`
+		Port struct {
+			Number int    `yaml:"number"`
+			Name   string `yaml:"name"`
+		} `yaml:"port"`
+	} `yaml:"service"`
+}
+
+// parseIngressYAML decodes the Ingress documents in content, extracting their
+// hosts, paths, backends and TLS configuration. Decoding stops at the first
+// malformed document.
+func parseIngressYAML(content string) []DiscoveredResource {
+	var resources []DiscoveredResource
+
+	dec := yaml.NewDecoder(strings.NewReader(content))
+	for {
+		var ing ingressManifest
+		if err := dec.Decode(&ing); err != nil {
+			if !errors.Is(err, io.EOF) {
+				slog.Warn("stopped parsing Ingress YAML at malformed document", "error", err)
+			}
+			break
+		}
+		if ing.Kind != "Ingress" {
+			continue
+		}
+		resources = append(resources, ingressResource(&ing, len(resources)))
+	}
+
+	if len(resources) == 0 {
+		resources = append(resources, DiscoveredResource{
+			Kind:       "Ingress",
+			Name:       "ingress-1",
+			Namespace:  "default",
+			APIVersion: "networking.k8s.io/v1",
+		})
+	}
+
+	return resources
+}
+
+// ingressResource converts a decoded Ingress into a DiscoveredResource. Every
+// Ingress listens on HTTP port 80; each TLS entry adds an HTTPS listener per
+// host terminating with the entry's Secret.
+func ingressResource(ing *ingressManifest, index int) DiscoveredResource {
+	res := DiscoveredResource{
+		Kind:       "Ingress",
+		Name:       ing.Metadata.Name,
+		Namespace:  ing.Metadata.Namespace,
+		APIVersion: "networking.k8s.io/v1",
+		Listeners:  []DiscoveredListener{{Port: 80, Protocol: "HTTP"}},
+	}
+	if res.Name == "" {
+		res.Name = fmt.Sprintf("ingress-%d", index+1)
+	}
+	if res.Namespace == "" {
+		res.Namespace = "default"
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		var refs []string
+		if tls.SecretName != "" {
+			refs = []string{tls.SecretName}
+		}
+		if len(tls.Hosts) == 0 {
+			res.Listeners = append(res.Listeners, DiscoveredListener{Port: 443, Protocol: "HTTPS", CertificateRefs: refs})
+		}
+		for _, host := range tls.Hosts {
+			res.Listeners = append(res.Listeners, DiscoveredListener{Port: 443, Protocol: "HTTPS", Hostname: host, CertificateRefs: refs})
+		}
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" && !containsString(res.Hostnames, rule.Host) {
+			res.Hostnames = append(res.Hostnames, rule.Host)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			path := p.Path
+			if path == "" {
+				path = "/"
+			}
+			matchType := "PathPrefix"
+			if p.PathType == "Exact" {
+				matchType = "Exact"
+			}
+			res.Routes = append(res.Routes, DiscoveredRoute{
+				Host:      rule.Host,
+				Path:      path,
+				MatchType: matchType,
+				Backends:  ingressBackends(p.Backend),
+			})
+		}
+	}
+
+	if ing.Spec.DefaultBackend != nil {
+		res.Routes = append(res.Routes, DiscoveredRoute{
+			Path:      "/",
+			MatchType: "PathPrefix",
+			Backends:  ingressBackends(*ing.Spec.DefaultBackend),
+		})
+	}
+
+	return res
+}
+
+// ingressBackends converts an Ingress service backend. Resource backends have
+// no Gateway API equivalent and yield no backends.
+func ingressBackends(b ingressBackend) []DiscoveredBackend {
+	if b.Service == nil || b.Service.Name == "" {
+		return nil
+	}
+	return []DiscoveredBackend{{
+		Name:     b.Service.Name,
+		Port:     b.Service.Port.Number,
+		PortName: b.Service.Port.Name,
+	}}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testCafeIngressYAML = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: cafe
+  namespace: shop
+spec:
+  tls:
+  - hosts: [cafe.example.com]
+    secretName: cafe-tls
+  defaultBackend:
+    service: {name: fallback, port: {number: 8080}}
+  rules:
+  - host: cafe.example.com
+    http:
+      paths:
+      - path: /coffee
+        pathType: Prefix
+        backend:
+          service: {name: coffee, port: {number: 80}}
+      - path: /tea
+        pathType: Exact
+        backend:
+          service: {name: tea, port: {name: http}}
+  - host: api.example.com
+    http:
+      paths:
+      - backend:
+          service: {name: api, port: {number: 9000}}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: coffee
+`
+
+func TestParseIngressYAML(t *testing.T) {
+	resources := parseIngressYAML(testCafeIngressYAML)
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 Ingress, got %+v", resources)
+	}
+	ing := resources[0]
+	if ing.Name != "cafe" || ing.Namespace != "shop" {
+		t.Errorf("unexpected identity %s/%s", ing.Namespace, ing.Name)
+	}
+
+	wantListeners := []DiscoveredListener{
+		{Port: 80, Protocol: "HTTP"},
+		{Port: 443, Protocol: "HTTPS", Hostname: "cafe.example.com", CertificateRefs: []string{"cafe-tls"}},
+	}
+	if !reflect.DeepEqual(ing.Listeners, wantListeners) {
+		t.Errorf("listeners = %+v, want %+v", ing.Listeners, wantListeners)
+	}
+
+	wantRoutes := []DiscoveredRoute{
+		{Host: "cafe.example.com", Path: "/coffee", MatchType: "PathPrefix", Backends: []DiscoveredBackend{{Name: "coffee", Port: 80}}},
+		{Host: "cafe.example.com", Path: "/tea", MatchType: "Exact", Backends: []DiscoveredBackend{{Name: "tea", PortName: "http"}}},
+		{Host: "api.example.com", Path: "/", MatchType: "PathPrefix", Backends: []DiscoveredBackend{{Name: "api", Port: 9000}}},
+		{Path: "/", MatchType: "PathPrefix", Backends: []DiscoveredBackend{{Name: "fallback", Port: 8080}}},
+	}
+	if !reflect.DeepEqual(ing.Routes, wantRoutes) {
+		t.Errorf("routes = %+v, want %+v", ing.Routes, wantRoutes)
+	}
+}
+
+func TestGenerateGatewayAPIResources_Ingress(t *testing.T) {
+	generated, warnings, err := generateGatewayAPIResources(parseIngressYAML(testCafeIngressYAML), "imp-1", time.Now())
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	var names []string
+	for _, g := range generated {
+		names = append(names, g.Kind+" "+g.Namespace+"/"+g.Name)
+	}
+	wantNames := []string{
+		"Gateway shop/migrated-gateway",
+		"HTTPRoute shop/cafe-cafe-example-com",
+		"HTTPRoute shop/cafe-api-example-com",
+		"HTTPRoute shop/cafe-default",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("generated %v, want %v", names, wantNames)
+	}
+
+	var gw struct {
+		Spec struct {
+			Listeners []struct {
+				Name     string `yaml:"name"`
+				Hostname string `yaml:"hostname"`
+				TLS      struct {
+					CertificateRefs []struct {
+						Name string `yaml:"name"`
+					} `yaml:"certificateRefs"`
+				} `yaml:"tls"`
+			} `yaml:"listeners"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(generated[0].YAML), &gw); err != nil {
+		t.Fatalf("decoding gateway: %v", err)
+	}
+	if len(gw.Spec.Listeners) != 2 || gw.Spec.Listeners[1].Name != "https-cafe-example-com" ||
+		gw.Spec.Listeners[1].Hostname != "cafe.example.com" || gw.Spec.Listeners[1].TLS.CertificateRefs[0].Name != "cafe-tls" {
+		t.Errorf("unexpected listeners: %+v", gw.Spec.Listeners)
+	}
+
+	var route struct {
+		Spec struct {
+			Hostnames []string `yaml:"hostnames"`
+			Rules     []struct {
+				Matches []struct {
+					Path struct {
+						Type  string `yaml:"type"`
+						Value string `yaml:"value"`
+					} `yaml:"path"`
+				} `yaml:"matches"`
+				BackendRefs []struct {
+					Name string `yaml:"name"`
+					Port int    `yaml:"port"`
+				} `yaml:"backendRefs"`
+			} `yaml:"rules"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(generated[1].YAML), &route); err != nil {
+		t.Fatalf("decoding route: %v", err)
+	}
+	if !reflect.DeepEqual(route.Spec.Hostnames, []string{"cafe.example.com"}) || len(route.Spec.Rules) != 2 {
+		t.Fatalf("unexpected route: %+v", route.Spec)
+	}
+	if r := route.Spec.Rules[0]; r.Matches[0].Path.Value != "/coffee" || r.BackendRefs[0].Name != "coffee" || r.BackendRefs[0].Port != 80 {
+		t.Errorf("unexpected first rule: %+v", r)
+	}
+	if r := route.Spec.Rules[1]; r.Matches[0].Path.Type != "Exact" || r.BackendRefs[0].Name != "tea" {
+		t.Errorf("unexpected second rule: %+v", r)
+	}
+	if generated[1].Source == nil || generated[1].Source.Name != "cafe" {
+		t.Errorf("expected source to be the cafe Ingress, got %+v", generated[1].Source)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `named port "http"`) {
+		t.Errorf("expected a named port warning, got %v", warnings)
+	}
+}
+
+func TestGenerateGatewayAPIResources_CrossNamespace(t *testing.T) {
+	resources := parseIngressYAML(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: {name: a, namespace: one}
+spec:
+  rules:
+  - http: {paths: [{path: /, pathType: Prefix, backend: {service: {name: a, port: {number: 80}}}}]}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: {name: b, namespace: two}
+spec:
+  tls: [{secretName: b-tls}]
+  rules:
+  - http: {paths: [{path: /b, pathType: Prefix, backend: {service: {name: b, port: {number: 80}}}}]}
+`)
+	generated, warnings, err := generateGatewayAPIResources(resources, "imp-1", time.Now())
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(generated[0].YAML, "from: All") {
+		t.Errorf("expected listeners to allow routes from all namespaces:\n%s", generated[0].YAML)
+	}
+	if !strings.Contains(generated[0].YAML, "namespace: two") {
+		t.Errorf("expected cross-namespace certificateRef:\n%s", generated[0].YAML)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "ReferenceGrant") {
+		t.Errorf("expected a ReferenceGrant warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// Apply outcomes recorded per resource of an import.
+const (
+	applyStatusApplied = "applied"
+	applyStatusExists  = "exists"
+	applyStatusFailed  = "failed"
+)
+
+// ApplyStatusResponse reports the apply progress recorded for an import.
+type ApplyStatusResponse struct {
+	ImportID  string            `json:"importId"`
+	Applied   int               `json:"applied"`
+	Exists    int               `json:"exists"`
+	Failed    int               `json:"failed"`
+	Resources []ApplyStatusItem `json:"resources"`
+}
+
+// ApplyStatusItem is the recorded apply outcome of one generated resource.
+type ApplyStatusItem struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"` // applied, exists, failed
+	Error     string `json:"error,omitempty"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// applyRecordKey identifies a generated resource within an import's progress.
+func applyRecordKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// completedApplies returns the keys of the import's resources that an earlier
+// Apply created or found already present. Without a store, or if progress
+// cannot be read, nothing is skipped; re-creating an applied resource is
+// reported as already existing.
+func (h *MigrationHandler) completedApplies(ctx context.Context, importID string) map[string]bool {
+	if h.Store == nil {
+		return nil
+	}
+	records, err := h.Store.ListMigrationApplyRecords(ctx, importID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load migration apply progress", "importId", importID, "error", err)
+		return nil
+	}
+	done := make(map[string]bool, len(records))
+	for _, rec := range records {
+		if rec.Status != applyStatusFailed {
+			done[applyRecordKey(rec.Kind, rec.Namespace, rec.Name)] = true
+		}
+	}
+	return done
+}
+
+// recordApply persists the apply outcome of one resource so that a re-invoked
+// Apply resumes after it. Failures are logged; the resource is then retried
+// by the next Apply.
+func (h *MigrationHandler) recordApply(ctx context.Context, importID string, res GeneratedResource, status string, applyErr error) {
+	if h.Store == nil {
+		return
+	}
+	rec := database.MigrationApplyRecord{
+		ImportID:  importID,
+		Kind:      res.Kind,
+		Namespace: res.Namespace,
+		Name:      res.Name,
+		Status:    status,
+	}
+	if applyErr != nil {
+		rec.Error = applyErr.Error()
+	}
+	if err := h.Store.SaveMigrationApplyRecord(ctx, rec); err != nil {
+		slog.WarnContext(ctx, "failed to record migration apply progress", "importId", importID, "resource", applyRecordKey(res.Kind, res.Namespace, res.Name), "error", err)
+	}
+}
+
+// ApplyStatus returns the per-resource apply progress recorded for the import
+// named by the importId query parameter.
+func (h *MigrationHandler) ApplyStatus(w http.ResponseWriter, r *http.Request) {
+	importID := r.URL.Query().Get("importId")
+	if importID == "" {
+		writeError(w, http.StatusBadRequest, "importId is required")
+		return
+	}
+	if h.Store == nil {
+		writeError(w, http.StatusServiceUnavailable, "migration store not configured")
+		return
+	}
+
+	records, err := h.Store.ListMigrationApplyRecords(r.Context(), importID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "loading apply progress: "+err.Error())
+		return
+	}
+	if len(records) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no apply progress recorded for import %q", importID))
+		return
+	}
+
+	resp := ApplyStatusResponse{ImportID: importID, Resources: make([]ApplyStatusItem, 0, len(records))}
+	for _, rec := range records {
+		switch rec.Status {
+		case applyStatusApplied:
+			resp.Applied++
+		case applyStatusExists:
+			resp.Exists++
+		case applyStatusFailed:
+			resp.Failed++
+		}
+		resp.Resources = append(resp.Resources, ApplyStatusItem{
+			Kind:      rec.Kind,
+			Name:      rec.Name,
+			Namespace: rec.Namespace,
+			Status:    rec.Status,
+			Error:     rec.Error,
+			UpdatedAt: rec.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Annotations stamped on resources created by a migration, recording where
+// they were converted from.
+const (
+	provenanceSourceKindAnnotation  = "ngf-console.f5.com/migration-source-kind"
+	provenanceSourceAnnotation      = "ngf-console.f5.com/migration-source"
+	provenanceImportIDAnnotation    = "ngf-console.f5.com/migration-import-id"
+	provenanceConvertedAtAnnotation = "ngf-console.f5.com/migration-converted-at"
+)
+
+// migrationTargetResources maps the Gateway API kinds a migration can generate
+// to their resource names.
+var migrationTargetResources = map[string]string{
+	"Gateway":   "gateways",
+	"HTTPRoute": "httproutes",
+	"GRPCRoute": "grpcroutes",
+	"TCPRoute":  "tcproutes",
+	"TLSRoute":  "tlsroutes",
+	"UDPRoute":  "udproutes",
+}
+
+// migrationTargetKinds is the lookup order used when a provenance query does
+// not specify a kind.
+var migrationTargetKinds = []string{"HTTPRoute", "Gateway", "GRPCRoute", "TCPRoute", "TLSRoute", "UDPRoute"}
+
+// ProvenanceResponse describes the migration origin of a generated resource.
+type ProvenanceResponse struct {
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	SourceKind      string `json:"sourceKind,omitempty"`
+	SourceName      string `json:"sourceName,omitempty"`
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+	ImportID        string `json:"importId"`
+	ConvertedAt     string `json:"convertedAt,omitempty"`
+}
+
+// provenanceAnnotations builds the provenance annotations for a resource
+// converted from source (which may be nil) as part of importID.
+func provenanceAnnotations(source *DiscoveredResource, importID string, convertedAt time.Time) map[string]string {
+	annotations := map[string]string{
+		provenanceImportIDAnnotation:    importID,
+		provenanceConvertedAtAnnotation: convertedAt.UTC().Format(time.RFC3339),
+	}
+	if source != nil {
+		annotations[provenanceSourceKindAnnotation] = source.Kind
+		annotations[provenanceSourceAnnotation] = source.Namespace + "/" + source.Name
+	}
+	return annotations
+}
+
+// stampProvenance merges provenance annotations into obj. An existing
+// conversion timestamp is preserved so re-applying keeps the original time.
+func stampProvenance(obj *unstructured.Unstructured, provenance map[string]string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for k, v := range provenance {
+		if k == provenanceConvertedAtAnnotation && annotations[k] != "" {
+			continue
+		}
+		annotations[k] = v
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// provenanceFromObject extracts the provenance recorded on obj. Returns false
+// if the object was not created by a migration.
+func provenanceFromObject(obj *unstructured.Unstructured) (ProvenanceResponse, bool) {
+	annotations := obj.GetAnnotations()
+	importID := annotations[provenanceImportIDAnnotation]
+	if importID == "" {
+		return ProvenanceResponse{}, false
+	}
+	resp := ProvenanceResponse{
+		Kind:        obj.GetKind(),
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		SourceKind:  annotations[provenanceSourceKindAnnotation],
+		ImportID:    importID,
+		ConvertedAt: annotations[provenanceConvertedAtAnnotation],
+	}
+	if src := annotations[provenanceSourceAnnotation]; src != "" {
+		if ns, name, ok := strings.Cut(src, "/"); ok {
+			resp.SourceNamespace, resp.SourceName = ns, name
+		} else {
+			resp.SourceName = src
+		}
+	}
+	return resp, true
+}
+
+// decodeGeneratedResource parses the YAML of a generated resource into an
+// unstructured object and resolves its GroupVersionResource.
+func decodeGeneratedResource(res GeneratedResource) (*unstructured.Unstructured, schema.GroupVersionResource, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal([]byte(res.YAML), &raw); err != nil {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("parsing YAML: %w", err)
+	}
+	// Round-trip through JSON so numeric values have types unstructured supports.
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("encoding resource: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("decoding resource: %w", err)
+	}
+
+	resource, ok := migrationTargetResources[obj.GetKind()]
+	if !ok {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("unsupported kind %q", obj.GetKind())
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace("default")
+	}
+	return obj, obj.GroupVersionKind().GroupVersion().WithResource(resource), nil
+}
+
+// gatewayAPIGVR returns the GVR for a migration target kind, using v1 for
+// Gateway/HTTPRoute/GRPCRoute and v1alpha2 for the L4 routes.
+func gatewayAPIGVR(kind string) schema.GroupVersionResource {
+	version := "v1"
+	switch kind {
+	case "TCPRoute", "TLSRoute", "UDPRoute":
+		version = "v1alpha2"
+	}
+	return schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: version, Resource: migrationTargetResources[kind]}
+}
+
+// Provenance returns the migration provenance of a generated resource.
+// Query parameters: resource=<namespace>/<name> (required), kind (optional;
+// when omitted every migration target kind is searched).
+func (h *MigrationHandler) Provenance(w http.ResponseWriter, r *http.Request) {
+	ns, name, ok := strings.Cut(r.URL.Query().Get("resource"), "/")
+	if !ok || ns == "" || name == "" {
+		writeError(w, http.StatusBadRequest, "resource must be of the form <namespace>/<name>")
+		return
+	}
+
+	kinds := migrationTargetKinds
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		if _, ok := migrationTargetResources[kind]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported kind %q", kind))
+			return
+		}
+		kinds = []string{kind}
+	}
+
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	for _, kind := range kinds {
+		obj, err := dc.Resource(gatewayAPIGVR(kind)).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("getting %s %s/%s: %s", kind, ns, name, err))
+			return
+		}
+		if obj.GetKind() == "" {
+			obj.SetKind(kind)
+		}
+		prov, ok := provenanceFromObject(obj)
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s/%s has no migration provenance", kind, ns, name))
+			return
+		}
+		writeJSON(w, http.StatusOK, prov)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("resource %s/%s not found", ns, name))
+}
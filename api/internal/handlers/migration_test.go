@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+const testIngressYAML = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web-ingress
+  namespace: default
+spec:
+  rules:
+  - host: app.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: app-service
+            port:
+              number: 80
+`
+
+func newMigrationTestStore(t *testing.T) database.Store {
+	t.Helper()
+	store, err := database.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if err := store.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	return store
+}
+
+// importConfig posts content to the import endpoint and returns the import ID.
+func importConfig(t *testing.T, h http.Handler, format, content string) string {
+	t.Helper()
+	body, _ := json.Marshal(ImportRequest{Source: "file", Format: format, Content: content})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/import", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("import: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ImportResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding import response: %v", err)
+	}
+	return resp.ID
+}
+
+func newMigrationFakeDynamicClient() *fakedynamic.FakeDynamicClient {
+	listKinds := make(map[schema.GroupVersionResource]string)
+	for kind := range migrationTargetResources {
+		listKinds[gatewayAPIGVR(kind)] = kind + "List"
+	}
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+}
+
+func TestMigrationHandler_ApplyStampsProvenance(t *testing.T) {
+	handler := &MigrationHandler{DynamicClient: newMigrationFakeDynamicClient(), Store: newMigrationTestStore(t)}
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/migration/import", handler.Import)
+	r.Post("/api/v1/migration/generate", handler.Generate)
+	r.Post("/api/v1/migration/apply", handler.Apply)
+	r.Get("/api/v1/migration/provenance", handler.Provenance)
+
+	importID := importConfig(t, r, "ingress-yaml", testIngressYAML)
+
+	// Generate resources for the import.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/generate",
+		bytes.NewBufferString(`{"importId":"`+importID+`"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("generate: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var gen GenerateResponse
+	if err := json.NewDecoder(w.Body).Decode(&gen); err != nil {
+		t.Fatalf("decoding generate response: %v", err)
+	}
+
+	// Apply them to the cluster.
+	body, _ := json.Marshal(ApplyRequest{ImportID: importID, Resources: gen.Resources})
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/apply", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("apply: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var applied ApplyResponse
+	if err := json.NewDecoder(w.Body).Decode(&applied); err != nil {
+		t.Fatalf("decoding apply response: %v", err)
+	}
+	if applied.Applied != len(gen.Resources) || len(applied.Errors) != 0 {
+		t.Fatalf("expected %d applied with no errors, got %+v", len(gen.Resources), applied)
+	}
+
+	// Query provenance of the generated route.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/migration/provenance?resource=default/web-ingress", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("provenance: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var prov ProvenanceResponse
+	if err := json.NewDecoder(w.Body).Decode(&prov); err != nil {
+		t.Fatalf("decoding provenance response: %v", err)
+	}
+	if prov.ImportID != importID {
+		t.Errorf("expected importId %s, got %q", importID, prov.ImportID)
+	}
+	if prov.Kind != "HTTPRoute" || prov.SourceKind != "Ingress" || prov.SourceName != "web-ingress" {
+		t.Errorf("unexpected provenance: %+v", prov)
+	}
+	if prov.ConvertedAt == "" {
+		t.Error("expected convertedAt to be set")
+	}
+}
+
+func TestMigrationHandler_ApplyResumes(t *testing.T) {
+	store := newMigrationTestStore(t)
+	dc := newMigrationFakeDynamicClient()
+	handler := &MigrationHandler{DynamicClient: dc, Store: store}
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/migration/import", handler.Import)
+	r.Post("/api/v1/migration/generate", handler.Generate)
+	r.Post("/api/v1/migration/apply", handler.Apply)
+	r.Get("/api/v1/migration/apply-status", handler.ApplyStatus)
+
+	importID := importConfig(t, r, "ingress-yaml", testIngressYAML)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/generate",
+		bytes.NewBufferString(`{"importId":"`+importID+`"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("generate: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var gen GenerateResponse
+	if err := json.NewDecoder(w.Body).Decode(&gen); err != nil {
+		t.Fatalf("decoding generate response: %v", err)
+	}
+	if len(gen.Resources) < 2 {
+		t.Fatalf("expected at least 2 generated resources, got %+v", gen.Resources)
+	}
+
+	// No progress is recorded before the first Apply.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/migration/apply-status?importId="+importID, nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("apply-status: expected 404 before apply, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Simulate an Apply that stopped after the first resource.
+	first := gen.Resources[0]
+	if err := store.SaveMigrationApplyRecord(context.Background(), database.MigrationApplyRecord{
+		ImportID:  importID,
+		Kind:      first.Kind,
+		Namespace: first.Namespace,
+		Name:      first.Name,
+		Status:    applyStatusApplied,
+	}); err != nil {
+		t.Fatalf("SaveMigrationApplyRecord: %v", err)
+	}
+
+	body, _ := json.Marshal(ApplyRequest{ImportID: importID, Resources: gen.Resources})
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/apply", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("apply: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var applied ApplyResponse
+	if err := json.NewDecoder(w.Body).Decode(&applied); err != nil {
+		t.Fatalf("decoding apply response: %v", err)
+	}
+	if applied.Resumed != 1 || applied.Applied != len(gen.Resources)-1 || len(applied.Errors) != 0 {
+		t.Fatalf("expected 1 resumed and %d applied, got %+v", len(gen.Resources)-1, applied)
+	}
+	// The recorded resource was not created again.
+	_, err := dc.Resource(gatewayAPIGVR(first.Kind)).Namespace(first.Namespace).Get(context.Background(), first.Name, metav1.GetOptions{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected %s %s to be skipped, got %v", first.Kind, first.Name, err)
+	}
+
+	// Re-invoking Apply is a no-op.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/apply", bytes.NewReader(body)))
+	applied = ApplyResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&applied); err != nil {
+		t.Fatalf("decoding apply response: %v", err)
+	}
+	if applied.Resumed != len(gen.Resources) || applied.Applied != 0 {
+		t.Errorf("expected all %d resources resumed, got %+v", len(gen.Resources), applied)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/migration/apply-status?importId="+importID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("apply-status: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var status ApplyStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("decoding apply-status response: %v", err)
+	}
+	if status.ImportID != importID || status.Applied != len(gen.Resources) || status.Failed != 0 || len(status.Resources) != len(gen.Resources) {
+		t.Errorf("unexpected apply status: %+v", status)
+	}
+}
+
+func TestMigrationHandler_Provenance(t *testing.T) {
+	handler := &MigrationHandler{DynamicClient: newMigrationFakeDynamicClient()}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{name: "missing resource", query: "", wantStatus: http.StatusBadRequest},
+		{name: "malformed resource", query: "?resource=only-name", wantStatus: http.StatusBadRequest},
+		{name: "unsupported kind", query: "?resource=default/x&kind=Ingress", wantStatus: http.StatusBadRequest},
+		{name: "not found", query: "?resource=default/missing", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.Provenance(w, httptest.NewRequest(http.MethodGet, "/api/v1/migration/provenance"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMigrationHandler_AnalysisUsesImport(t *testing.T) {
+	handler := &MigrationHandler{Store: newMigrationTestStore(t)}
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/migration/import", handler.Import)
+	r.Post("/api/v1/migration/analysis", handler.Analysis)
+
+	importID := importConfig(t, r, "nginx-conf", `
+stream {
+    server {
+        listen 5432;
+        proxy_pass db:5432;
+    }
+}`)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/analysis",
+		bytes.NewBufferString(`{"importId":"`+importID+`"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("analysis: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AnalysisResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding analysis response: %v", err)
+	}
+	if resp.TotalResources != 2 {
+		t.Fatalf("expected gateway + TCP route, got %+v", resp.Items)
+	}
+	if resp.Items[0].Target != "Gateway" || resp.Items[1].Target != "TCPRoute" || resp.Items[1].Confidence != "medium" {
+		t.Errorf("unexpected analysis items: %+v", resp.Items)
+	}
+}
+
+func TestMigrationHandler_GenerateExpiredImport(t *testing.T) {
+	store := newMigrationTestStore(t)
+	handler := &MigrationHandler{Store: store}
+
+	err := store.SaveMigrationImport(context.Background(), database.MigrationImport{
+		ID:        "stale",
+		Format:    "ingress-yaml",
+		Content:   testIngressYAML,
+		CreatedAt: time.Now().Add(-2 * migrationImportTTL),
+	})
+	if err != nil {
+		t.Fatalf("SaveMigrationImport: %v", err)
+	}
+
+	for _, id := range []string{"stale", "missing"} {
+		w := httptest.NewRecorder()
+		handler.Generate(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/generate",
+			bytes.NewBufferString(`{"importId":"`+id+`"}`)))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d: %s", id, w.Code, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	(&MigrationHandler{}).Generate(w, httptest.NewRequest(http.MethodPost, "/api/v1/migration/generate",
+		bytes.NewBufferString(`{"importId":"stale"}`)))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without a store, got %d", w.Code)
+	}
+}
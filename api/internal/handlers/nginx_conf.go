@@ -0,0 +1,482 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// nginxDirective is a parsed NGINX directive. Block directives (http, server,
+// location, upstream, ...) carry their nested directives in Block.
+type nginxDirective struct {
+	Name  string
+	Args  []string
+	Block []nginxDirective
+}
+
+// nginxToken is a lexical token of an NGINX config. Quoted tokens are never
+// treated as syntax, so a quoted "{" is a plain argument.
+type nginxToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeNginxConf splits an NGINX config into words, quoted strings, and the
+// syntax characters '{', '}' and ';'. Comments run from '#' at the start of a
+// token to the end of the line.
+func tokenizeNginxConf(content string) []nginxToken {
+	var tokens []nginxToken
+	var word strings.Builder
+
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, nginxToken{text: word.String()})
+			word.Reset()
+		}
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '#' && word.Len() == 0:
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		case c == '{' && strings.HasSuffix(word.String(), "$"):
+			// ${variable} inside a word.
+			for i < len(content) && content[i] != '}' {
+				word.WriteByte(content[i])
+				i++
+			}
+			if i < len(content) {
+				word.WriteByte('}')
+			}
+		case c == '{' || c == '}' || c == ';':
+			flush()
+			tokens = append(tokens, nginxToken{text: string(c)})
+		case (c == '"' || c == '\'') && word.Len() == 0:
+			quote := c
+			var s strings.Builder
+			for i++; i < len(content) && content[i] != quote; i++ {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
+				s.WriteByte(content[i])
+			}
+			tokens = append(tokens, nginxToken{text: s.String(), quoted: true})
+		default:
+			word.WriteByte(c)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parseNginxDirectives parses an NGINX config into a directive tree. Parsing
+// is lenient: stray closing braces are ignored and unterminated blocks are
+// closed at end of input, so partial configs still yield their directives.
+func parseNginxDirectives(content string) []nginxDirective {
+	tokens := tokenizeNginxConf(content)
+	pos := 0
+	var directives []nginxDirective
+	for pos < len(tokens) {
+		directives = append(directives, parseNginxBlock(tokens, &pos, false)...)
+		pos++ // skip a stray '}' at top level
+	}
+	return directives
+}
+
+func parseNginxBlock(tokens []nginxToken, pos *int, nested bool) []nginxDirective {
+	var directives []nginxDirective
+	var words []string
+
+	for *pos < len(tokens) {
+		tok := tokens[*pos]
+		switch {
+		case !tok.quoted && tok.text == "}":
+			if len(words) > 0 {
+				directives = append(directives, nginxDirective{Name: words[0], Args: words[1:]})
+			}
+			if nested {
+				*pos++
+			}
+			return directives
+		case !tok.quoted && tok.text == ";":
+			*pos++
+			if len(words) > 0 {
+				directives = append(directives, nginxDirective{Name: words[0], Args: words[1:]})
+			}
+			words = nil
+		case !tok.quoted && tok.text == "{":
+			*pos++
+			d := nginxDirective{Block: parseNginxBlock(tokens, pos, true)}
+			if d.Block == nil {
+				d.Block = []nginxDirective{}
+			}
+			if len(words) > 0 {
+				d.Name, d.Args = words[0], words[1:]
+			}
+			directives = append(directives, d)
+			words = nil
+		default:
+			*pos++
+			words = append(words, tok.text)
+		}
+	}
+	if len(words) > 0 {
+		directives = append(directives, nginxDirective{Name: words[0], Args: words[1:]})
+	}
+	return directives
+}
+
+// DiscoveredListener is a port a server block or Ingress listens on.
+type DiscoveredListener struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"` // HTTP, HTTPS, TCP, UDP
+	Hostname string `json:"hostname,omitempty"`
+
+	// CertificateRefs names the TLS Secrets (in the resource's namespace)
+	// for HTTPS listeners, when known.
+	CertificateRefs []string `json:"certificateRefs,omitempty"`
+}
+
+// DiscoveredRoute is a location (HTTP) or stream server path with its backends.
+// Host is set for Ingress rules, which each carry their own host.
+type DiscoveredRoute struct {
+	Host      string              `json:"host,omitempty"`
+	Path      string              `json:"path,omitempty"`
+	MatchType string              `json:"matchType,omitempty"` // Exact, PathPrefix, RegularExpression
+	Backends  []DiscoveredBackend `json:"backends,omitempty"`
+}
+
+// DiscoveredBackend is a proxy_pass/grpc_pass target. When it names an
+// upstream block, Servers lists the upstream's members.
+type DiscoveredBackend struct {
+	Name     string   `json:"name"`
+	Port     int      `json:"port,omitempty"`
+	PortName string   `json:"portName,omitempty"` // named Service port (Ingress)
+	Protocol string   `json:"protocol,omitempty"` // http, https, grpc, grpcs; empty for stream
+	Servers  []string `json:"servers,omitempty"`
+}
+
+// nginxUpstreams maps upstream names to their member server addresses.
+type nginxUpstreams map[string][]string
+
+// parseNginxConf parses an NGINX config and returns one route resource per
+// server block (HTTPRoute for http servers, TCPRoute/UDPRoute for stream
+// servers) plus a Gateway carrying the union of their listeners. Hostnames
+// come from server_name, listeners from listen, routes from location blocks,
+// and backends from proxy_pass/grpc_pass resolved against upstream blocks.
+func parseNginxConf(content string) []DiscoveredResource {
+	directives := parseNginxDirectives(content)
+
+	// Configs may be full nginx.conf files or bare server blocks. Collect
+	// upstreams and servers from the top level and the http/stream contexts.
+	httpUpstreams, streamUpstreams := nginxUpstreams{}, nginxUpstreams{}
+	var httpServers, streamServers []nginxDirective
+	for _, d := range directives {
+		switch d.Name {
+		case "http":
+			collectNginxServers(d.Block, httpUpstreams, &httpServers)
+		case "stream":
+			collectNginxServers(d.Block, streamUpstreams, &streamServers)
+		}
+	}
+	collectNginxServers(directives, httpUpstreams, &httpServers)
+
+	var routes []DiscoveredResource
+	names := make(map[string]int)
+	for i, s := range httpServers {
+		routes = append(routes, httpServerResource(s, httpUpstreams, uniqueNginxName(names, s, i)))
+	}
+	for i, s := range streamServers {
+		routes = append(routes, streamServerResource(s, streamUpstreams, uniqueNginxName(names, s, len(httpServers)+i)))
+	}
+	if len(routes) == 0 {
+		return nil
+	}
+
+	// One Gateway carries every distinct listener across server blocks.
+	seen := make(map[string]bool)
+	var listeners []DiscoveredListener
+	for _, r := range routes {
+		for _, l := range r.Listeners {
+			if key := fmt.Sprintf("%s/%d", l.Protocol, l.Port); !seen[key] {
+				seen[key] = true
+				listeners = append(listeners, l)
+			}
+		}
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		if listeners[i].Port != listeners[j].Port {
+			return listeners[i].Port < listeners[j].Port
+		}
+		return listeners[i].Protocol < listeners[j].Protocol
+	})
+
+	gateway := DiscoveredResource{
+		Kind:       "Gateway",
+		Name:       "nginx-gateway",
+		Namespace:  "default",
+		APIVersion: "gateway.networking.k8s.io/v1",
+		Listeners:  listeners,
+	}
+	return append([]DiscoveredResource{gateway}, routes...)
+}
+
+// collectNginxServers appends the server blocks in directives to servers and
+// records upstream blocks in upstreams.
+func collectNginxServers(directives []nginxDirective, upstreams nginxUpstreams, servers *[]nginxDirective) {
+	for _, d := range directives {
+		switch {
+		case d.Name == "server" && d.Block != nil:
+			*servers = append(*servers, d)
+		case d.Name == "upstream" && d.Block != nil && len(d.Args) > 0:
+			var members []string
+			for _, m := range d.Block {
+				if m.Name == "server" && len(m.Args) > 0 {
+					members = append(members, m.Args[0])
+				}
+			}
+			upstreams[d.Args[0]] = members
+		}
+	}
+}
+
+// httpServerResource converts an http server block to an HTTPRoute candidate.
+func httpServerResource(server nginxDirective, upstreams nginxUpstreams, name string) DiscoveredResource {
+	res := DiscoveredResource{
+		Kind:       "HTTPRoute",
+		Name:       name,
+		Namespace:  "default",
+		APIVersion: "gateway.networking.k8s.io/v1",
+		Hostnames:  nginxServerNames(server),
+	}
+
+	sslOn := false
+	for _, d := range server.Block {
+		if d.Name == "ssl" && len(d.Args) > 0 && d.Args[0] == "on" {
+			sslOn = true
+		}
+	}
+	for _, d := range server.Block {
+		if d.Name != "listen" || len(d.Args) == 0 {
+			continue
+		}
+		port, ok := nginxListenPort(d.Args[0])
+		if !ok {
+			continue
+		}
+		protocol := "HTTP"
+		if sslOn || containsString(d.Args[1:], "ssl") {
+			protocol = "HTTPS"
+		}
+		res.Listeners = append(res.Listeners, DiscoveredListener{Port: port, Protocol: protocol})
+	}
+	if len(res.Listeners) == 0 {
+		// NGINX listens on port 80 when a server has no listen directive.
+		res.Listeners = []DiscoveredListener{{Port: 80, Protocol: "HTTP"}}
+	}
+
+	// A server-level proxy_pass is not valid NGINX, so routes come from
+	// locations only (including nested ones).
+	res.Routes = nginxLocationRoutes(server.Block, upstreams)
+	return res
+}
+
+// streamServerResource converts a stream server block to a TCPRoute or
+// UDPRoute candidate.
+func streamServerResource(server nginxDirective, upstreams nginxUpstreams, name string) DiscoveredResource {
+	res := DiscoveredResource{
+		Kind:       "TCPRoute",
+		Name:       name,
+		Namespace:  "default",
+		APIVersion: "gateway.networking.k8s.io/v1alpha2",
+	}
+
+	for _, d := range server.Block {
+		switch d.Name {
+		case "listen":
+			if len(d.Args) == 0 {
+				continue
+			}
+			port, ok := nginxListenPort(d.Args[0])
+			if !ok {
+				continue
+			}
+			protocol := "TCP"
+			if containsString(d.Args[1:], "udp") {
+				protocol = "UDP"
+				res.Kind = "UDPRoute"
+			}
+			res.Listeners = append(res.Listeners, DiscoveredListener{Port: port, Protocol: protocol})
+		case "proxy_pass":
+			if len(d.Args) > 0 {
+				res.Routes = append(res.Routes, DiscoveredRoute{
+					Backends: []DiscoveredBackend{nginxBackend(d.Args[0], "", upstreams)},
+				})
+			}
+		}
+	}
+	return res
+}
+
+// nginxLocationRoutes returns a route for each location block in directives,
+// recursing into nested locations. Named locations (@name) are internal and
+// skipped.
+func nginxLocationRoutes(directives []nginxDirective, upstreams nginxUpstreams) []DiscoveredRoute {
+	var routes []DiscoveredRoute
+	for _, d := range directives {
+		if d.Name != "location" || d.Block == nil || len(d.Args) == 0 {
+			continue
+		}
+
+		route := DiscoveredRoute{MatchType: "PathPrefix", Path: d.Args[len(d.Args)-1]}
+		if len(d.Args) > 1 {
+			switch d.Args[0] {
+			case "=":
+				route.MatchType = "Exact"
+			case "~", "~*":
+				route.MatchType = "RegularExpression"
+			}
+		}
+		if strings.HasPrefix(route.Path, "@") {
+			continue
+		}
+
+		for _, inner := range d.Block {
+			if len(inner.Args) == 0 {
+				continue
+			}
+			switch inner.Name {
+			case "proxy_pass":
+				route.Backends = append(route.Backends, nginxBackend(inner.Args[0], "http", upstreams))
+			case "grpc_pass":
+				route.Backends = append(route.Backends, nginxBackend(inner.Args[0], "grpc", upstreams))
+			}
+		}
+
+		routes = append(routes, route)
+		routes = append(routes, nginxLocationRoutes(d.Block, upstreams)...)
+	}
+	return routes
+}
+
+// nginxBackend resolves a proxy_pass/grpc_pass target. HTTP targets are URLs
+// (http://host:port/path); stream and grpc targets may omit the scheme.
+// Targets naming an upstream block expand to the upstream's servers.
+func nginxBackend(target, defaultScheme string, upstreams nginxUpstreams) DiscoveredBackend {
+	backend := DiscoveredBackend{Protocol: defaultScheme}
+	hostport := target
+	if scheme, rest, ok := strings.Cut(target, "://"); ok {
+		backend.Protocol = strings.ToLower(scheme)
+		hostport = rest
+	}
+	if i := strings.IndexByte(hostport, '/'); i >= 0 {
+		hostport = hostport[:i]
+	}
+
+	if servers, ok := upstreams[hostport]; ok {
+		backend.Name = hostport
+		backend.Servers = servers
+		return backend
+	}
+
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	backend.Name = host
+	if port, err := strconv.Atoi(portStr); err == nil {
+		backend.Port = port
+	} else {
+		switch backend.Protocol {
+		case "http":
+			backend.Port = 80
+		case "https":
+			backend.Port = 443
+		}
+	}
+	return backend
+}
+
+// nginxListenPort extracts the port from a listen address such as "80",
+// "443", "127.0.0.1:8080", "[::]:443", or "*:80". Unix sockets have no port.
+func nginxListenPort(addr string) (int, bool) {
+	if strings.HasPrefix(addr, "unix:") {
+		return 0, false
+	}
+	if port, err := strconv.Atoi(addr); err == nil {
+		return port, port > 0 && port <= 65535
+	}
+	_, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		// A bare address listens on port 80.
+		return 80, true
+	}
+	port, err := strconv.Atoi(portStr)
+	return port, err == nil && port > 0 && port <= 65535
+}
+
+// nginxServerNames returns the server_name values of a server block, skipping
+// the catch-all "_" and empty names. Regex names (~...) are not hostnames and
+// are skipped too.
+func nginxServerNames(server nginxDirective) []string {
+	var names []string
+	for _, d := range server.Block {
+		if d.Name != "server_name" {
+			continue
+		}
+		for _, n := range d.Args {
+			if n == "_" || n == "" || n == `""` || strings.HasPrefix(n, "~") {
+				continue
+			}
+			names = append(names, strings.ToLower(n))
+		}
+	}
+	return names
+}
+
+var nginxNameInvalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// uniqueNginxName derives a Kubernetes resource name for a server block from
+// its first server_name, falling back to nginx-server-N.
+func uniqueNginxName(used map[string]int, server nginxDirective, index int) string {
+	name := fmt.Sprintf("nginx-server-%d", index+1)
+	if names := nginxServerNames(server); len(names) > 0 {
+		if n := sanitizeResourceName(strings.Replace(names[0], "*.", "wildcard.", 1)); n != "" {
+			name = n
+		}
+	}
+
+	used[name]++
+	if count := used[name]; count > 1 {
+		name = fmt.Sprintf("%s-%d", name, count)
+	}
+	return name
+}
+
+// sanitizeResourceName turns an NGINX name (server_name, upstream, host)
+// into a valid Kubernetes resource name, or "" if nothing usable remains.
+func sanitizeResourceName(name string) string {
+	n := strings.ToLower(strings.ReplaceAll(name, ".", "-"))
+	n = strings.Trim(nginxNameInvalidChars.ReplaceAllString(n, "-"), "-")
+	if len(n) > 63 {
+		n = strings.TrimRight(n[:63], "-")
+	}
+	return n
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testNginxConf = `
+# Main site
+http {
+    upstream app_backend {
+        server 10.0.0.1:8080 weight=3;
+        server 10.0.0.2:8080;
+    }
+
+    server {
+        listen 80;
+        listen 443 ssl http2;
+        server_name shop.example.com www.shop.example.com;  # primary hostnames
+
+        ssl_certificate     /etc/nginx/certs/shop.crt;
+        add_header X-Frame-Options "SAMEORIGIN; always";
+
+        location / {
+            proxy_pass http://app_backend;
+
+            location ~* \.(png|jpg)$ {
+                expires 30d;
+            }
+        }
+
+        location = /healthz {
+            return 200 "ok";
+        }
+
+        location /api/ {
+            proxy_pass http://api.internal:9000/v1/;
+        }
+
+        location /grpc {
+            grpc_pass grpc://grpc-svc:50051;
+        }
+
+        location @fallback {
+            proxy_pass http://app_backend;
+        }
+    }
+
+    server {
+        listen [::]:8080;
+        server_name _;
+        location / {
+            proxy_pass http://default-svc;
+        }
+    }
+}
+
+stream {
+    upstream pg {
+        server db-1:5432;
+    }
+    server {
+        listen 5432;
+        proxy_pass pg;
+    }
+    server {
+        listen 53 udp;
+        proxy_pass 10.0.0.53:53;
+    }
+}
+`
+
+func TestParseNginxConf(t *testing.T) {
+	resources := parseNginxConf(testNginxConf)
+	if len(resources) != 5 {
+		t.Fatalf("expected 5 resources (gateway + 4 servers), got %d: %+v", len(resources), resources)
+	}
+
+	gw := resources[0]
+	if gw.Kind != "Gateway" {
+		t.Fatalf("expected Gateway first, got %s", gw.Kind)
+	}
+	wantListeners := []DiscoveredListener{
+		{Port: 53, Protocol: "UDP"},
+		{Port: 80, Protocol: "HTTP"},
+		{Port: 443, Protocol: "HTTPS"},
+		{Port: 5432, Protocol: "TCP"},
+		{Port: 8080, Protocol: "HTTP"},
+	}
+	if !reflect.DeepEqual(gw.Listeners, wantListeners) {
+		t.Errorf("gateway listeners = %+v, want %+v", gw.Listeners, wantListeners)
+	}
+
+	shop := resources[1]
+	if shop.Kind != "HTTPRoute" || shop.Name != "shop-example-com" {
+		t.Errorf("unexpected shop route identity: %s/%s", shop.Kind, shop.Name)
+	}
+	if !reflect.DeepEqual(shop.Hostnames, []string{"shop.example.com", "www.shop.example.com"}) {
+		t.Errorf("unexpected hostnames: %v", shop.Hostnames)
+	}
+	wantRoutes := []DiscoveredRoute{
+		{Path: "/", MatchType: "PathPrefix", Backends: []DiscoveredBackend{
+			{Name: "app_backend", Protocol: "http", Servers: []string{"10.0.0.1:8080", "10.0.0.2:8080"}},
+		}},
+		{Path: `\.(png|jpg)$`, MatchType: "RegularExpression"},
+		{Path: "/healthz", MatchType: "Exact"},
+		{Path: "/api/", MatchType: "PathPrefix", Backends: []DiscoveredBackend{
+			{Name: "api.internal", Port: 9000, Protocol: "http"},
+		}},
+		{Path: "/grpc", MatchType: "PathPrefix", Backends: []DiscoveredBackend{
+			{Name: "grpc-svc", Port: 50051, Protocol: "grpc"},
+		}},
+	}
+	if !reflect.DeepEqual(shop.Routes, wantRoutes) {
+		t.Errorf("shop routes = %+v, want %+v", shop.Routes, wantRoutes)
+	}
+
+	catchAll := resources[2]
+	if catchAll.Name != "nginx-server-2" || len(catchAll.Hostnames) != 0 {
+		t.Errorf("unexpected catch-all server: %+v", catchAll)
+	}
+	if len(catchAll.Routes) != 1 || catchAll.Routes[0].Backends[0].Port != 80 {
+		t.Errorf("expected default port 80 for scheme-only proxy_pass, got %+v", catchAll.Routes)
+	}
+
+	tcp := resources[3]
+	if tcp.Kind != "TCPRoute" || len(tcp.Routes) != 1 ||
+		!reflect.DeepEqual(tcp.Routes[0].Backends[0].Servers, []string{"db-1:5432"}) {
+		t.Errorf("unexpected TCP route: %+v", tcp)
+	}
+
+	udp := resources[4]
+	if udp.Kind != "UDPRoute" || udp.Routes[0].Backends[0].Name != "10.0.0.53" || udp.Routes[0].Backends[0].Port != 53 {
+		t.Errorf("unexpected UDP route: %+v", udp)
+	}
+}
+
+func TestParseNginxConf_BareServerBlocks(t *testing.T) {
+	resources := parseNginxConf(`
+server {
+    server_name *.example.com;
+    location / { proxy_pass http://web; }
+}
+server {
+    server_name *.example.com;
+}`)
+	if len(resources) != 3 {
+		t.Fatalf("expected gateway + 2 routes, got %+v", resources)
+	}
+	if resources[1].Name != "wildcard-example-com" || resources[2].Name != "wildcard-example-com-2" {
+		t.Errorf("expected unique names, got %q and %q", resources[1].Name, resources[2].Name)
+	}
+	if !reflect.DeepEqual(resources[0].Listeners, []DiscoveredListener{{Port: 80, Protocol: "HTTP"}}) {
+		t.Errorf("expected implicit port 80 listener, got %+v", resources[0].Listeners)
+	}
+}
+
+func TestParseNginxConf_Empty(t *testing.T) {
+	if resources := parseNginxConf("# nothing here\nworker_processes auto;\n"); len(resources) != 0 {
+		t.Errorf("expected no resources, got %+v", resources)
+	}
+}
+
+func TestParseNginxDirectives_Unbalanced(t *testing.T) {
+	directives := parseNginxDirectives("} server { listen 80; location / { proxy_pass http://a;")
+	if len(directives) != 1 || directives[0].Name != "server" {
+		t.Fatalf("expected one server directive, got %+v", directives)
+	}
+	if len(directives[0].Block) != 2 || directives[0].Block[1].Name != "location" {
+		t.Errorf("expected listen and location inside server, got %+v", directives[0].Block)
+	}
+}
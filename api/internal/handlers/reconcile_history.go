@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var eventGVR = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"}
+
+// History length bounds for the reconcile history endpoints.
+const (
+	defaultReconcileHistory = 50
+	maxReconcileHistory     = 200
+)
+
+// Annotations the operator sets on child reconcile events.
+const (
+	eventAnnotationChildKind = "ngf-console.f5.com/child-kind"
+	eventAnnotationChildName = "ngf-console.f5.com/child-name"
+)
+
+// Reconcile outcomes reported per child.
+const (
+	outcomeCreated = "created"
+	outcomeUpdated = "updated"
+	outcomeInSync  = "in-sync"
+	outcomeFailed  = "failed"
+	outcomePending = "pending"
+)
+
+// ReconcileHistoryEntry is one point in an object's reconcile timeline.
+type ReconcileHistoryEntry struct {
+	Time      string `json:"time"`
+	Source    string `json:"source"`              // "event", "condition", or "status"
+	Outcome   string `json:"outcome"`             // created, updated, in-sync, failed, pending; condition status for conditions
+	Kind      string `json:"kind,omitempty"`      // child kind
+	Name      string `json:"name,omitempty"`      // child name
+	Condition string `json:"condition,omitempty"` // condition type
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Count     int32  `json:"count,omitempty"` // occurrences of a repeated event
+}
+
+// ReconcileHistoryResponse is the reconcile timeline of an operator-managed object.
+type ReconcileHistoryResponse struct {
+	Kind      string                  `json:"kind"`
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace"`
+	Phase     string                  `json:"phase,omitempty"`
+	Entries   []ReconcileHistoryEntry `json:"entries"`
+	Truncated bool                    `json:"truncated"`
+}
+
+// parseHistoryLimit reads the limit query parameter, defaulting to
+// defaultReconcileHistory and capped at maxReconcileHistory.
+func parseHistoryLimit(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return defaultReconcileHistory, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("invalid limit %q: must be a positive integer", raw)
+	}
+	return min(limit, maxReconcileHistory), nil
+}
+
+// listObjectEvents returns the events recorded on obj. Events are matched by
+// kind and name, and by UID when both sides have one, so events from a
+// deleted object of the same name are excluded.
+func listObjectEvents(ctx context.Context, dc dynamic.Interface, obj *unstructured.Unstructured) ([]corev1.Event, error) {
+	list, err := dc.Resource(eventGVR).Namespace(obj.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing events: %w", err)
+	}
+	var events []corev1.Event
+	for _, item := range list.Items {
+		var ev corev1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &ev); err != nil {
+			continue
+		}
+		ref := ev.InvolvedObject
+		if ref.Kind != obj.GetKind() || ref.Name != obj.GetName() {
+			continue
+		}
+		if ref.UID != "" && obj.GetUID() != "" && ref.UID != obj.GetUID() {
+			continue
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// buildReconcileHistory merges the operator's events, the object's status
+// conditions, and the current child statuses into a chronological timeline,
+// keeping only the most recent limit entries. Events expire from the API
+// server after about an hour, so children whose latest outcome is only in
+// status are reported from status at the last reconcile time.
+func buildReconcileHistory(obj *unstructured.Unstructured, events []corev1.Event, limit int) ReconcileHistoryResponse {
+	resp := ReconcileHistoryResponse{
+		Kind:      obj.GetKind(),
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Entries:   []ReconcileHistoryEntry{},
+	}
+	resp.Phase, _, _ = unstructured.NestedString(obj.Object, "status", "phase")
+
+	type timed struct {
+		at    time.Time
+		entry ReconcileHistoryEntry
+	}
+	var all []timed
+	recorded := make(map[string]bool) // kind/name/outcome with an event
+
+	for _, ev := range events {
+		outcome := eventOutcome(ev)
+		if outcome == "" {
+			continue
+		}
+		at := eventTime(ev)
+		kind := ev.Annotations[eventAnnotationChildKind]
+		name := ev.Annotations[eventAnnotationChildName]
+		recorded[kind+"/"+name+"/"+outcome] = true
+		all = append(all, timed{at: at, entry: ReconcileHistoryEntry{
+			Time:    at.UTC().Format(time.RFC3339),
+			Source:  "event",
+			Outcome: outcome,
+			Kind:    kind,
+			Name:    name,
+			Reason:  ev.Reason,
+			Message: ev.Message,
+			Count:   ev.Count,
+		}})
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		raw, _ := cm["lastTransitionTime"].(string)
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			continue
+		}
+		condType, _ := cm["type"].(string)
+		status, _ := cm["status"].(string)
+		reason, _ := cm["reason"].(string)
+		message, _ := cm["message"].(string)
+		all = append(all, timed{at: at, entry: ReconcileHistoryEntry{
+			Time:      at.UTC().Format(time.RFC3339),
+			Source:    "condition",
+			Outcome:   status,
+			Condition: condType,
+			Reason:    reason,
+			Message:   message,
+		}})
+	}
+
+	lastRaw, _, _ := unstructured.NestedString(obj.Object, "status", "lastReconciledAt")
+	if lastReconciled, err := time.Parse(time.RFC3339, lastRaw); err == nil {
+		children, _, _ := unstructured.NestedSlice(obj.Object, "status", "children")
+		for _, c := range children {
+			cm, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			kind, _ := cm["kind"].(string)
+			name, _ := cm["name"].(string)
+			ready, _ := cm["ready"].(bool)
+			reason, _ := cm["reason"].(string)
+			message, _ := cm["message"].(string)
+			outcome := childOutcome(ready, reason, message)
+			if outcome == "" || recorded[kind+"/"+name+"/"+outcome] {
+				continue
+			}
+			all = append(all, timed{at: lastReconciled, entry: ReconcileHistoryEntry{
+				Time:    lastReconciled.UTC().Format(time.RFC3339),
+				Source:  "status",
+				Outcome: outcome,
+				Kind:    kind,
+				Name:    name,
+				Reason:  reason,
+				Message: message,
+			}})
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].at.Before(all[j].at) })
+	if len(all) > limit {
+		all = all[len(all)-limit:]
+		resp.Truncated = true
+	}
+	for _, t := range all {
+		resp.Entries = append(resp.Entries, t.entry)
+	}
+	return resp
+}
+
+// eventOutcome maps an operator event reason to a reconcile outcome. Events
+// with other reasons are not part of the reconcile history.
+func eventOutcome(ev corev1.Event) string {
+	switch ev.Reason {
+	case "ChildCreated":
+		return outcomeCreated
+	case "ChildUpdated":
+		return outcomeUpdated
+	case "ChildFailed":
+		return outcomeFailed
+	default:
+		return ""
+	}
+}
+
+// eventTime returns when an event last occurred.
+func eventTime(ev corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	case !ev.FirstTimestamp.IsZero():
+		return ev.FirstTimestamp.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// childOutcome classifies a child status set by the operator, using its
+// reason code when present. Operators that predate reason codes only set the
+// message, so that is matched as a fallback. A child that is not ready
+// without a failure, such as a DaemonSet waiting for pods, is pending.
+// Disabled and unconfigured children have no outcome.
+func childOutcome(ready bool, reason, message string) string {
+	switch reason {
+	case "Created":
+		return outcomeCreated
+	case "Updated":
+		return outcomeUpdated
+	case "InSync":
+		return outcomeInSync
+	case "CreateFailed", "UpdateFailed", "GetFailed":
+		return outcomeFailed
+	case "":
+		return childOutcomeFromMessage(ready, message)
+	}
+	if !ready {
+		return outcomePending
+	}
+	return ""
+}
+
+// childOutcomeFromMessage classifies a child status that has no reason code.
+func childOutcomeFromMessage(ready bool, message string) string {
+	switch {
+	case message == "created":
+		return outcomeCreated
+	case message == "updated":
+		return outcomeUpdated
+	case message == "in sync":
+		return outcomeInSync
+	case !ready && strings.Contains(message, "failed: "):
+		return outcomeFailed
+	case !ready:
+		return outcomePending
+	default:
+		return ""
+	}
+}
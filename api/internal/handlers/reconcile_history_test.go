@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+var historyBase = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func historyStack() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "ngf-console.f5.com/v1alpha1",
+		"kind":       "InferenceStack",
+		"status": map[string]any{
+			"phase":            "Degraded",
+			"lastReconciledAt": historyBase.Add(10 * time.Minute).Format(time.RFC3339),
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "reason": "ChildrenNotReady", "lastTransitionTime": historyBase.Add(5 * time.Minute).Format(time.RFC3339)},
+			},
+			"children": []any{
+				map[string]any{"kind": "InferencePool", "name": "llama3", "ready": true, "message": "in sync"},
+				map[string]any{"kind": "ConfigMap", "name": "llama3-epp-config", "ready": false, "message": "update failed: conflict"},
+				map[string]any{"kind": "ScaledObject", "name": "llama3-scaler", "ready": true, "message": "not configured"},
+			},
+		},
+	}}
+	obj.SetName("llama3")
+	obj.SetNamespace("default")
+	obj.SetUID(types.UID("stack-uid"))
+	return obj
+}
+
+func historyEvent(name, reason, childKind, childName string, at time.Time, uid types.UID) corev1.Event {
+	return corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				eventAnnotationChildKind: childKind,
+				eventAnnotationChildName: childName,
+			},
+		},
+		InvolvedObject: corev1.ObjectReference{Kind: "InferenceStack", Name: "llama3", Namespace: "default", UID: uid},
+		Reason:         reason,
+		Message:        reason + " " + childKind + " " + childName,
+		LastTimestamp:  metav1.NewTime(at),
+		Count:          1,
+	}
+}
+
+func TestBuildReconcileHistory(t *testing.T) {
+	events := []corev1.Event{
+		historyEvent("e2", "ChildFailed", "ConfigMap", "llama3-epp-config", historyBase.Add(8*time.Minute), "stack-uid"),
+		historyEvent("e1", "ChildCreated", "InferencePool", "llama3", historyBase, "stack-uid"),
+		historyEvent("e3", "Scheduled", "", "", historyBase, "stack-uid"),
+	}
+
+	got := buildReconcileHistory(historyStack(), events, 10)
+
+	want := []struct{ source, outcome, kind string }{
+		{"event", outcomeCreated, "InferencePool"},
+		{"condition", "False", ""},
+		{"event", outcomeFailed, "ConfigMap"},
+		// The failed ConfigMap is already covered by its event.
+		{"status", outcomeInSync, "InferencePool"},
+	}
+	if len(got.Entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), got.Entries)
+	}
+	for i, w := range want {
+		e := got.Entries[i]
+		if e.Source != w.source || e.Outcome != w.outcome || e.Kind != w.kind {
+			t.Errorf("entry %d = %+v, want %s/%s/%s", i, e, w.source, w.outcome, w.kind)
+		}
+	}
+	if got.Phase != "Degraded" || got.Truncated {
+		t.Errorf("phase = %q, truncated = %v", got.Phase, got.Truncated)
+	}
+
+	// Only the most recent entries are kept.
+	got = buildReconcileHistory(historyStack(), events, 2)
+	if len(got.Entries) != 2 || !got.Truncated || got.Entries[0].Outcome != outcomeFailed {
+		t.Errorf("expected the 2 newest entries and truncated, got %+v", got)
+	}
+}
+
+func TestChildOutcome(t *testing.T) {
+	tests := []struct {
+		ready           bool
+		reason, message string
+		want            string
+	}{
+		{true, "Created", "created", outcomeCreated},
+		{true, "InSync", "in sync", outcomeInSync},
+		{false, "GetFailed", "get failed: forbidden", outcomeFailed},
+		{false, "WaitingForPods", "waiting for pods (0/1 available)", outcomePending},
+		{true, "NotConfigured", "not configured", ""},
+		{true, "Disabled", "disabled", ""},
+		// Statuses written before reason codes fall back to the message.
+		{true, "", "updated", outcomeUpdated},
+		{false, "", "update failed: conflict", outcomeFailed},
+		{false, "", "waiting for gateway controller", outcomePending},
+	}
+	for _, tt := range tests {
+		if got := childOutcome(tt.ready, tt.reason, tt.message); got != tt.want {
+			t.Errorf("childOutcome(%v, %q, %q) = %q, want %q", tt.ready, tt.reason, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestInferenceHandler_PoolHistory(t *testing.T) {
+	toUnstructured := func(ev corev1.Event) *unstructured.Unstructured {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ev)
+		if err != nil {
+			t.Fatalf("converting event: %v", err)
+		}
+		obj := &unstructured.Unstructured{Object: m}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("Event")
+		return obj
+	}
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		inferenceStackGVR: "InferenceStackList",
+		eventGVR:          "EventList",
+	},
+		historyStack(),
+		toUnstructured(historyEvent("current", "ChildCreated", "InferencePool", "llama3", historyBase, "stack-uid")),
+		// An event from an earlier stack with the same name.
+		toUnstructured(historyEvent("stale", "ChildCreated", "InferencePool", "llama3", historyBase.Add(-time.Hour), "old-uid")),
+	)
+	handler := &InferenceHandler{DynamicClient: dc}
+
+	r := chi.NewRouter()
+	r.Get("/inference/pools/{name}/history", handler.PoolHistory)
+
+	tests := []struct {
+		name        string
+		url         string
+		wantStatus  int
+		wantEntries int
+	}{
+		{name: "history", url: "/inference/pools/llama3/history", wantStatus: http.StatusOK, wantEntries: 4},
+		{name: "limited", url: "/inference/pools/llama3/history?limit=1", wantStatus: http.StatusOK, wantEntries: 1},
+		{name: "invalid limit", url: "/inference/pools/llama3/history?limit=0", wantStatus: http.StatusBadRequest},
+		{name: "unknown pool", url: "/inference/pools/missing/history", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp ReconcileHistoryResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Entries) != tt.wantEntries {
+				t.Errorf("expected %d entries, got %+v", tt.wantEntries, resp.Entries)
+			}
+		})
+	}
+}
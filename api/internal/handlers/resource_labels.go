@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// ResourceHandler handles bulk operations across Gateway API resources.
+type ResourceHandler struct {
+	DynamicClient dynamic.Interface
+	Store         database.Store
+}
+
+// getDynamicClient returns the dynamic client from the handler field or falls back
+// to the cluster context's dynamic client.
+func (h *ResourceHandler) getDynamicClient(r *http.Request) dynamic.Interface {
+	if h.DynamicClient != nil {
+		return h.DynamicClient
+	}
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		return nil
+	}
+	return k8s.DynamicClient()
+}
+
+// BulkLabelRequest selects resources of one kind and the label changes to
+// apply to each of them.
+type BulkLabelRequest struct {
+	Kind      string            `json:"kind"`                // Gateway, HTTPRoute, GRPCRoute, TCPRoute, TLSRoute, or UDPRoute
+	Namespace string            `json:"namespace,omitempty"` // empty selects all namespaces
+	Selector  string            `json:"selector,omitempty"`  // label selector, e.g. "app=web,tier!=canary"
+	Names     []string          `json:"names,omitempty"`     // restricts the selection to these names
+	Add       map[string]string `json:"add,omitempty"`       // labels to set
+	Remove    []string          `json:"remove,omitempty"`    // label keys to delete
+}
+
+// BulkLabelResult is the outcome of labeling one resource.
+type BulkLabelResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"` // "updated", "unchanged", or "failed"
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkLabelResponse summarizes a bulk label operation.
+type BulkLabelResponse struct {
+	Kind      string            `json:"kind"`
+	Matched   int               `json:"matched"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Results   []BulkLabelResult `json:"results"`
+}
+
+// validateBulkLabelRequest checks the request and returns the parsed selector.
+func validateBulkLabelRequest(req BulkLabelRequest) (labels.Selector, error) {
+	if _, ok := migrationTargetResources[req.Kind]; !ok {
+		return nil, fmt.Errorf("unsupported kind %q: must be one of %s", req.Kind, strings.Join(migrationTargetKinds, ", "))
+	}
+	if req.Selector == "" && len(req.Names) == 0 {
+		return nil, fmt.Errorf("selector or names is required")
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return nil, fmt.Errorf("add or remove is required")
+	}
+	selector, err := labels.Parse(req.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	for _, key := range slices.Sorted(maps.Keys(req.Add)) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(req.Add[key]); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid value for label %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for _, key := range req.Remove {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if _, ok := req.Add[key]; ok {
+			return nil, fmt.Errorf("label %q is both added and removed", key)
+		}
+	}
+	return selector, nil
+}
+
+// labelPatch returns the JSON merge patch that applies the request's label
+// changes to current, or nil when current already matches.
+func labelPatch(current map[string]string, add map[string]string, remove []string) []byte {
+	changes := make(map[string]any)
+	for k, v := range add {
+		if cur, ok := current[k]; !ok || cur != v {
+			changes[k] = v
+		}
+	}
+	for _, k := range remove {
+		if _, ok := current[k]; ok {
+			changes[k] = nil // null deletes the key in a merge patch
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	patch, _ := json.Marshal(map[string]any{"metadata": map[string]any{"labels": changes}})
+	return patch
+}
+
+// Label adds and removes labels across the resources of one kind matching a
+// selector and/or names. Each resource is patched independently; the
+// response reports a result per resource, and failures do not stop the rest.
+func (h *ResourceHandler) Label(w http.ResponseWriter, r *http.Request) {
+	var req BulkLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	selector, err := validateBulkLabelRequest(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	gvr := gatewayAPIGVR(req.Kind)
+	list, err := dc.Resource(gvr).Namespace(req.Namespace).List(r.Context(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing %s: %v", gvr.Resource, err))
+		return
+	}
+
+	var names map[string]bool
+	if len(req.Names) > 0 {
+		names = make(map[string]bool, len(req.Names))
+		for _, n := range req.Names {
+			names[n] = true
+		}
+	}
+
+	resp := BulkLabelResponse{Kind: req.Kind, Results: []BulkLabelResult{}}
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	for i := range items {
+		obj := &items[i]
+		if names != nil && !names[obj.GetName()] {
+			continue
+		}
+		resp.Matched++
+		result := BulkLabelResult{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+
+		before := obj.GetLabels()
+		patch := labelPatch(before, req.Add, req.Remove)
+		if patch == nil {
+			result.Status = "unchanged"
+			resp.Unchanged++
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		patched, err := dc.Resource(gvr).Namespace(obj.GetNamespace()).Patch(r.Context(), obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			resp.Failed++
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		result.Status = "updated"
+		resp.Updated++
+		resp.Results = append(resp.Results, result)
+		auditLog(h.Store, r.Context(), "label", req.Kind, obj.GetName(), obj.GetNamespace(),
+			map[string]any{"labels": before}, map[string]any{"labels": patched.GetLabels()})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func labelTestRoute(ns, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+	}}
+	obj.SetNamespace(ns)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestValidateBulkLabelRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     BulkLabelRequest
+		wantErr bool
+	}{
+		{name: "valid", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web", Add: map[string]string{"team": "payments"}, Remove: []string{"legacy"}}},
+		{name: "names only", req: BulkLabelRequest{Kind: "Gateway", Names: []string{"gw"}, Add: map[string]string{"example.com/env": "prod"}}},
+		{name: "unsupported kind", req: BulkLabelRequest{Kind: "Service", Selector: "app=web", Add: map[string]string{"team": "a"}}, wantErr: true},
+		{name: "no selection", req: BulkLabelRequest{Kind: "HTTPRoute", Add: map[string]string{"team": "a"}}, wantErr: true},
+		{name: "no changes", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web"}, wantErr: true},
+		{name: "invalid selector", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app in (web", Add: map[string]string{"team": "a"}}, wantErr: true},
+		{name: "invalid key", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web", Add: map[string]string{"bad key": "a"}}, wantErr: true},
+		{name: "invalid value", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web", Add: map[string]string{"team": "not valid!"}}, wantErr: true},
+		{name: "invalid remove key", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web", Remove: []string{"-bad"}}, wantErr: true},
+		{name: "add and remove same key", req: BulkLabelRequest{Kind: "HTTPRoute", Selector: "app=web", Add: map[string]string{"team": "a"}, Remove: []string{"team"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateBulkLabelRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBulkLabelRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceHandler_Label(t *testing.T) {
+	gvr := gatewayAPIGVR("HTTPRoute")
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "HTTPRouteList",
+	},
+		labelTestRoute("default", "web", map[string]string{"app": "web", "legacy": "true"}),
+		labelTestRoute("default", "web-done", map[string]string{"app": "web", "team": "payments"}),
+		labelTestRoute("shop", "web", map[string]string{"app": "web"}),
+		labelTestRoute("default", "api", map[string]string{"app": "api", "legacy": "true"}),
+	)
+	handler := &ResourceHandler{DynamicClient: dc}
+
+	body, _ := json.Marshal(BulkLabelRequest{
+		Kind:      "HTTPRoute",
+		Namespace: "default",
+		Selector:  "app=web",
+		Add:       map[string]string{"team": "payments"},
+		Remove:    []string{"legacy"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/resources/label", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.Label(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp BulkLabelResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Matched != 2 || resp.Updated != 1 || resp.Unchanged != 1 || resp.Failed != 0 {
+		t.Fatalf("unexpected summary: %+v", resp)
+	}
+	if resp.Results[0].Name != "web" || resp.Results[0].Status != "updated" ||
+		resp.Results[1].Name != "web-done" || resp.Results[1].Status != "unchanged" {
+		t.Errorf("unexpected results: %+v", resp.Results)
+	}
+
+	got, err := dc.Resource(gvr).Namespace("default").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get route: %v", err)
+	}
+	if labels := got.GetLabels(); labels["team"] != "payments" || labels["app"] != "web" || labels["legacy"] != "" {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+
+	// Resources outside the selection are untouched.
+	for _, key := range []struct{ ns, name string }{{"shop", "web"}, {"default", "api"}} {
+		obj, err := dc.Resource(gvr).Namespace(key.ns).Get(context.Background(), key.name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get route: %v", err)
+		}
+		if _, ok := obj.GetLabels()["team"]; ok {
+			t.Errorf("%s/%s should not have been labeled", key.ns, key.name)
+		}
+	}
+
+	// Names narrow the selection across namespaces.
+	body, _ = json.Marshal(BulkLabelRequest{Kind: "HTTPRoute", Names: []string{"api"}, Add: map[string]string{"team": "core"}})
+	req = httptest.NewRequest(http.MethodPost, "/resources/label", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handler.Label(w, req)
+	resp = BulkLabelResponse{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Matched != 1 || resp.Updated != 1 || resp.Results[0].Namespace != "default" {
+		t.Errorf("unexpected names-only result: %+v", resp)
+	}
+
+	// Invalid requests are rejected before anything is listed.
+	req = httptest.NewRequest(http.MethodPost, "/resources/label", bytes.NewReader([]byte(`{"kind":"HTTPRoute","selector":"app=web","add":{"bad key":"x"}}`)))
+	w = httptest.NewRecorder()
+	handler.Label(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
 )
 
 // Response types matching frontend/src/types/*.ts
@@ -158,13 +160,15 @@ type HTTPRouteStatusResponse struct {
 }
 
 type HTTPRouteResponse struct {
-	Name      string                    `json:"name"`
-	Namespace string                    `json:"namespace"`
-	ParentRefs []ParentRefResponse      `json:"parentRefs"`
-	Hostnames  []string                `json:"hostnames,omitempty"`
-	Rules      []HTTPRouteRuleResponse  `json:"rules"`
-	Status     *HTTPRouteStatusResponse `json:"status,omitempty"`
-	CreatedAt  string                   `json:"createdAt"`
+	Name        string                    `json:"name"`
+	Namespace   string                    `json:"namespace"`
+	ParentRefs  []ParentRefResponse       `json:"parentRefs"`
+	Hostnames   []string                  `json:"hostnames,omitempty"`
+	Rules       []HTTPRouteRuleResponse   `json:"rules"`
+	Status      *HTTPRouteStatusResponse  `json:"status,omitempty"`
+	Attachments *RouteAttachmentsResponse `json:"attachments,omitempty"`
+	CreatedAt   string                    `json:"createdAt"`
+	Warnings    []string                  `json:"warnings,omitempty"` // set on create when backend checks find problems
 }
 
 // Conversion functions
@@ -336,26 +340,7 @@ func toHTTPRouteResponse(hr *gatewayv1.HTTPRoute) HTTPRouteResponse {
 			rr.Matches = append(rr.Matches, mr)
 		}
 		for _, br := range rule.BackendRefs {
-			brr := BackendRefResponse{Name: string(br.Name)}
-			if br.Group != nil {
-				brr.Group = string(*br.Group)
-			}
-			if br.Kind != nil {
-				brr.Kind = string(*br.Kind)
-			}
-			if br.Namespace != nil {
-				ns := string(*br.Namespace)
-				brr.Namespace = &ns
-			}
-			if br.Port != nil {
-				p := int32(*br.Port)
-				brr.Port = &p
-			}
-			if br.Weight != nil {
-				w := *br.Weight
-				brr.Weight = &w
-			}
-			rr.BackendRefs = append(rr.BackendRefs, brr)
+			rr.BackendRefs = append(rr.BackendRefs, toBackendRefResponse(br.BackendRef))
 		}
 		resp.Rules = append(resp.Rules, rr)
 	}
@@ -522,6 +507,10 @@ type HeaderMatchRequest struct {
 }
 
 type BackendRefRequest struct {
+	// Group and Kind select the backend resource. They default to a core
+	// Service. Kind InferencePool defaults Group to the InferencePool API group.
+	Group     string  `json:"group,omitempty"`
+	Kind      string  `json:"kind,omitempty"`
 	Name      string  `json:"name"`
 	Namespace *string `json:"namespace,omitempty"`
 	Port      *int32  `json:"port,omitempty"`
@@ -604,23 +593,7 @@ func convertHTTPRouteRuleRequests(rules []HTTPRouteRuleReq) []gatewayv1.HTTPRout
 			r.Matches = append(r.Matches, match)
 		}
 		for _, br := range rule.BackendRefs {
-			backendRef := gatewayv1.HTTPBackendRef{
-				BackendRef: gatewayv1.BackendRef{
-					BackendObjectReference: gatewayv1.BackendObjectReference{
-						Name: gatewayv1.ObjectName(br.Name),
-					},
-					Weight: br.Weight,
-				},
-			}
-			if br.Namespace != nil {
-				ns := gatewayv1.Namespace(*br.Namespace)
-				backendRef.BackendRef.Namespace = &ns
-			}
-			if br.Port != nil {
-				port := gatewayv1.PortNumber(*br.Port)
-				backendRef.BackendRef.Port = &port
-			}
-			r.BackendRefs = append(r.BackendRefs, backendRef)
+			r.BackendRefs = append(r.BackendRefs, gatewayv1.HTTPBackendRef{BackendRef: toBackendRef(br)})
 		}
 		result = append(result, r)
 	}
@@ -638,5 +611,20 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 }
 
 func writeError(w http.ResponseWriter, status int, msg string) {
-	writeJSON(w, status, map[string]string{"error": msg})
+	writeJSON(w, status, errorBody(w, msg))
+}
+
+// errorBody is the body of an error response. It carries the request ID the
+// middleware set on the response, so support can find the request's logs.
+func errorBody(w http.ResponseWriter, msg string) map[string]string {
+	body := map[string]string{"error": msg}
+	if id := responseRequestID(w); id != "" {
+		body["requestId"] = id
+	}
+	return body
+}
+
+// responseRequestID returns the X-Request-ID set on the response, or "".
+func responseRequestID(w http.ResponseWriter) string {
+	return w.Header().Get(requestid.Header)
 }
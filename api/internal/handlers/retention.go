@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	ch "github.com/kubenetlabs/ngc/api/internal/clickhouse"
+)
+
+// RetentionHandler reports ClickHouse data retention settings.
+type RetentionHandler struct {
+	CH *ch.Client
+}
+
+// RetentionConfigResponse is the retention configured via server flags.
+// A zero value means the TTLs of that class are left as defined by the schema.
+type RetentionConfigResponse struct {
+	RawDays     int `json:"rawDays"`
+	RollupDays  int `json:"rollupDays"`
+	MetricsDays int `json:"metricsDays"`
+}
+
+// TableRetentionResponse is the TTL currently set on one ClickHouse table.
+type TableRetentionResponse struct {
+	Table string `json:"table"`
+	Class string `json:"class"` // "raw", "rollup", or "metrics"
+	TTL   string `json:"ttl,omitempty"`
+	Days  int    `json:"days,omitempty"`
+}
+
+// RetentionResponse is the API response for GET /retention.
+type RetentionResponse struct {
+	Configured RetentionConfigResponse  `json:"configured"`
+	Tables     []TableRetentionResponse `json:"tables"`
+}
+
+// Get returns the configured retention and the TTLs currently applied to
+// each managed ClickHouse table.
+func (h *RetentionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if h.CH == nil {
+		writeError(w, http.StatusServiceUnavailable, "clickhouse not configured")
+		return
+	}
+
+	tables, err := h.CH.TableRetentions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	cfg := h.CH.RetentionSettings()
+	resp := RetentionResponse{
+		Configured: RetentionConfigResponse{
+			RawDays:     int(cfg.Raw / (24 * time.Hour)),
+			RollupDays:  int(cfg.Rollup / (24 * time.Hour)),
+			MetricsDays: int(cfg.Metrics / (24 * time.Hour)),
+		},
+		Tables: make([]TableRetentionResponse, 0, len(tables)),
+	}
+	for _, t := range tables {
+		resp.Tables = append(resp.Tables, TableRetentionResponse{Table: t.Table, Class: t.Class, TTL: t.TTL, Days: t.Days})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
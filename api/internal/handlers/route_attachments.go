@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+// RouteAttachmentResponse reports the health of a route's attachment to one
+// parent Gateway.
+type RouteAttachmentResponse struct {
+	Gateway           string `json:"gateway"` // namespace/name
+	SectionName       string `json:"sectionName,omitempty"`
+	GatewayProgrammed bool   `json:"gatewayProgrammed"`
+	RouteAccepted     bool   `json:"routeAccepted"`
+	Ready             bool   `json:"ready"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// RouteAttachmentsResponse rolls up a route's Gateway attachments, e.g.
+// "attached to 2 gateways, 1 not ready".
+type RouteAttachmentsResponse struct {
+	Total    int                       `json:"total"`
+	NotReady int                       `json:"notReady"`
+	Summary  string                    `json:"summary"`
+	Parents  []RouteAttachmentResponse `json:"parents"`
+}
+
+// gatewayGetter fetches a Gateway by namespace and name.
+type gatewayGetter func(namespace, name string) (*gatewayv1.Gateway, error)
+
+// cachedGatewayGetter returns a gatewayGetter that fetches each Gateway at
+// most once, so listing many routes attached to the same Gateway stays cheap.
+func cachedGatewayGetter(ctx context.Context, k8s *kubernetes.Client) gatewayGetter {
+	type result struct {
+		gw  *gatewayv1.Gateway
+		err error
+	}
+	cache := map[string]result{}
+	return func(namespace, name string) (*gatewayv1.Gateway, error) {
+		key := namespace + "/" + name
+		if r, ok := cache[key]; ok {
+			return r.gw, r.err
+		}
+		gw, err := k8s.GetGateway(ctx, namespace, name)
+		cache[key] = result{gw, err}
+		return gw, err
+	}
+}
+
+// routeAttachments derives the attachment rollup for hr from the status
+// conditions of its parent Gateways and its own per-parent status. Parent
+// references that are not Gateways are skipped.
+func routeAttachments(hr *gatewayv1.HTTPRoute, getGateway gatewayGetter) *RouteAttachmentsResponse {
+	resp := &RouteAttachmentsResponse{Parents: []RouteAttachmentResponse{}}
+	for _, ref := range hr.Spec.ParentRefs {
+		if (ref.Group != nil && *ref.Group != gatewayv1.GroupName) || (ref.Kind != nil && *ref.Kind != "Gateway") {
+			continue
+		}
+		ns := hr.Namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+		a := RouteAttachmentResponse{Gateway: ns + "/" + string(ref.Name)}
+		if ref.SectionName != nil {
+			a.SectionName = string(*ref.SectionName)
+		}
+
+		var gwReason string
+		if gw, err := getGateway(ns, string(ref.Name)); err != nil {
+			gwReason = "gateway not found"
+		} else if cond := meta.FindStatusCondition(gw.Status.Conditions, string(gatewayv1.GatewayConditionProgrammed)); cond == nil {
+			gwReason = "gateway has not reported Programmed status"
+		} else if cond.Status != metav1.ConditionTrue {
+			gwReason = fmt.Sprintf("gateway not programmed: %s", cond.Reason)
+		} else {
+			a.GatewayProgrammed = true
+		}
+
+		var routeReason string
+		if ps := findRouteParentStatus(hr, ref); ps == nil {
+			routeReason = "route status not reported by gateway"
+		} else if cond := meta.FindStatusCondition(ps.Conditions, string(gatewayv1.RouteConditionAccepted)); cond == nil || cond.Status != metav1.ConditionTrue {
+			routeReason = "route not accepted"
+			if cond != nil {
+				routeReason += ": " + cond.Reason
+			}
+		} else {
+			a.RouteAccepted = true
+		}
+
+		a.Ready = a.GatewayProgrammed && a.RouteAccepted
+		if gwReason != "" {
+			a.Reason = gwReason
+		} else {
+			a.Reason = routeReason
+		}
+		if !a.Ready {
+			resp.NotReady++
+		}
+		resp.Parents = append(resp.Parents, a)
+	}
+	resp.Total = len(resp.Parents)
+
+	noun := "gateways"
+	if resp.Total == 1 {
+		noun = "gateway"
+	}
+	switch {
+	case resp.Total == 0:
+		resp.Summary = "not attached to any gateway"
+	case resp.NotReady == 0:
+		resp.Summary = fmt.Sprintf("attached to %d %s, all ready", resp.Total, noun)
+	default:
+		resp.Summary = fmt.Sprintf("attached to %d %s, %d not ready", resp.Total, noun, resp.NotReady)
+	}
+	return resp
+}
+
+// findRouteParentStatus returns the route status entry written for ref, if any.
+func findRouteParentStatus(hr *gatewayv1.HTTPRoute, ref gatewayv1.ParentReference) *gatewayv1.RouteParentStatus {
+	ns := hr.Namespace
+	if ref.Namespace != nil {
+		ns = string(*ref.Namespace)
+	}
+	for i, ps := range hr.Status.Parents {
+		psNS := hr.Namespace
+		if ps.ParentRef.Namespace != nil {
+			psNS = string(*ps.ParentRef.Namespace)
+		}
+		if ps.ParentRef.Name != ref.Name || psNS != ns {
+			continue
+		}
+		if ref.SectionName != nil && (ps.ParentRef.SectionName == nil || *ps.ParentRef.SectionName != *ref.SectionName) {
+			continue
+		}
+		return &hr.Status.Parents[i]
+	}
+	return nil
+}
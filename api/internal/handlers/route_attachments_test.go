@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestRouteAttachments(t *testing.T) {
+	otherNS := gatewayv1.Namespace("infra")
+	hr := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: "public"},
+					{Name: "internal", Namespace: &otherNS},
+					{Name: "missing"},
+				},
+			},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{
+					{
+						ParentRef:  gatewayv1.ParentReference{Name: "public"},
+						Conditions: []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue}},
+					},
+					{
+						ParentRef:  gatewayv1.ParentReference{Name: "internal", Namespace: &otherNS},
+						Conditions: []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue}},
+					},
+				},
+			},
+		},
+	}
+
+	gateways := map[string]*gatewayv1.Gateway{
+		"apps/public": {Status: gatewayv1.GatewayStatus{
+			Conditions: []metav1.Condition{{Type: "Programmed", Status: metav1.ConditionTrue}},
+		}},
+		"infra/internal": {Status: gatewayv1.GatewayStatus{
+			Conditions: []metav1.Condition{{Type: "Programmed", Status: metav1.ConditionFalse, Reason: "AddressNotAssigned"}},
+		}},
+	}
+	getGateway := func(namespace, name string) (*gatewayv1.Gateway, error) {
+		if gw, ok := gateways[namespace+"/"+name]; ok {
+			return gw, nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	got := routeAttachments(hr, getGateway)
+	if got.Total != 3 || got.NotReady != 2 {
+		t.Fatalf("expected 3 attachments with 2 not ready, got %+v", got)
+	}
+	if got.Summary != "attached to 3 gateways, 2 not ready" {
+		t.Errorf("unexpected summary %q", got.Summary)
+	}
+
+	want := []RouteAttachmentResponse{
+		{Gateway: "apps/public", GatewayProgrammed: true, RouteAccepted: true, Ready: true},
+		{Gateway: "infra/internal", RouteAccepted: true, Reason: "gateway not programmed: AddressNotAssigned"},
+		{Gateway: "apps/missing", Reason: "gateway not found"},
+	}
+	for i, w := range want {
+		if got.Parents[i] != w {
+			t.Errorf("parent %d = %+v, want %+v", i, got.Parents[i], w)
+		}
+	}
+}
+
+func TestRouteAttachments_SkipsNonGatewayParents(t *testing.T) {
+	kind := gatewayv1.Kind("Service")
+	group := gatewayv1.Group("")
+	hr := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "apps"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "backend", Kind: &kind, Group: &group}},
+			},
+		},
+	}
+
+	got := routeAttachments(hr, func(string, string) (*gatewayv1.Gateway, error) {
+		t.Fatal("gateway lookup should not happen for non-Gateway parents")
+		return nil, nil
+	})
+	if got.Total != 0 || got.Summary != "not attached to any gateway" {
+		t.Errorf("unexpected rollup: %+v", got)
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/inference"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+// inferencePoolKind is the backendRef kind that routes to an InferencePool.
+const inferencePoolKind = "InferencePool"
+
+// isService reports whether the backendRef targets a core Service, the
+// Gateway API default.
+func (br BackendRefRequest) isService() bool {
+	return br.Group == "" && (br.Kind == "" || br.Kind == "Service")
+}
+
+// toBackendRef converts a backendRef request into a Gateway API BackendRef.
+// An InferencePool ref without a group gets the InferencePool API group.
+func toBackendRef(br BackendRefRequest) gatewayv1.BackendRef {
+	backendRef := gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(br.Name),
+		},
+		Weight: br.Weight,
+	}
+	group := br.Group
+	if group == "" && br.Kind == inferencePoolKind {
+		group = inference.InferencePoolGVR().Group
+	}
+	if group != "" {
+		g := gatewayv1.Group(group)
+		backendRef.Group = &g
+	}
+	if br.Kind != "" {
+		kind := gatewayv1.Kind(br.Kind)
+		backendRef.Kind = &kind
+	}
+	if br.Namespace != nil {
+		ns := gatewayv1.Namespace(*br.Namespace)
+		backendRef.Namespace = &ns
+	}
+	if br.Port != nil {
+		port := gatewayv1.PortNumber(*br.Port)
+		backendRef.Port = &port
+	}
+	return backendRef
+}
+
+// backendValidationMode selects how route create treats backendRefs whose
+// Service or port does not exist.
+type backendValidationMode string
+
+const (
+	backendValidationWarn   backendValidationMode = "warn"   // create the route and return warnings
+	backendValidationStrict backendValidationMode = "strict" // reject the route
+	backendValidationOff    backendValidationMode = "off"    // skip the check
+)
+
+// parseBackendValidationMode reads ?validateBackends=. It defaults to warn.
+func parseBackendValidationMode(r *http.Request) (backendValidationMode, error) {
+	switch mode := backendValidationMode(r.URL.Query().Get("validateBackends")); mode {
+	case "":
+		return backendValidationWarn, nil
+	case backendValidationWarn, backendValidationStrict, backendValidationOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("validateBackends must be warn, strict, or off, got %q", mode)
+	}
+}
+
+// validateRouteBackends checks a new route's backendRefs as selected by
+// ?validateBackends= and returns the warnings to include in the create
+// response. When the route must not be created it writes the error response
+// and returns false.
+func validateRouteBackends(w http.ResponseWriter, r *http.Request, k8s *kubernetes.Client, ns string, refs []BackendRefRequest) ([]string, bool) {
+	mode, err := parseBackendValidationMode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	if mode == backendValidationOff || len(refs) == 0 {
+		return nil, true
+	}
+
+	problems, err := checkRouteBackends(r.Context(), k8s, ns, refs)
+	if err != nil {
+		if mode == backendValidationStrict {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("verifying backends: %v", err))
+			return nil, false
+		}
+		return []string{fmt.Sprintf("backends were not verified: %v", err)}, true
+	}
+	if mode == backendValidationStrict && len(problems) > 0 {
+		writeError(w, http.StatusBadRequest, "invalid backendRefs: "+strings.Join(problems, "; "))
+		return nil, false
+	}
+	return problems, true
+}
+
+// checkRouteBackends returns a problem for each backendRef whose Service
+// does not exist, or whose Service has no port matching the ref's port, and
+// for each InferencePool ref whose pool does not exist. Refs to other kinds
+// are not checked. Refs without a namespace resolve in ns, the route's
+// namespace.
+func checkRouteBackends(ctx context.Context, k8s *kubernetes.Client, ns string, refs []BackendRefRequest) ([]string, error) {
+	services := make(map[string]map[string]corev1.Service) // namespace -> name -> Service
+	var problems []string
+	for _, ref := range refs {
+		refNS := ns
+		if ref.Namespace != nil && *ref.Namespace != "" {
+			refNS = *ref.Namespace
+		}
+
+		if !ref.isService() {
+			missing, err := inferencePoolMissing(ctx, k8s, refNS, ref)
+			if err != nil {
+				return nil, err
+			}
+			problem := fmt.Sprintf("InferencePool %s/%s does not exist", refNS, ref.Name)
+			if missing && !slices.Contains(problems, problem) {
+				problems = append(problems, problem)
+			}
+			continue
+		}
+
+		byName, ok := services[refNS]
+		if !ok {
+			list, err := k8s.ListServices(ctx, refNS)
+			if err != nil {
+				return nil, err
+			}
+			byName = make(map[string]corev1.Service, len(list))
+			for _, svc := range list {
+				byName[svc.Name] = svc
+			}
+			services[refNS] = byName
+		}
+
+		var problem string
+		svc, ok := byName[ref.Name]
+		switch {
+		case !ok:
+			problem = fmt.Sprintf("Service %s/%s does not exist", refNS, ref.Name)
+		case ref.Port != nil && svc.Spec.Type != corev1.ServiceTypeExternalName && !serviceHasPort(svc, *ref.Port):
+			problem = fmt.Sprintf("Service %s/%s has no port %d (ports: %s)", refNS, ref.Name, *ref.Port, servicePorts(svc))
+		}
+		if problem != "" && !slices.Contains(problems, problem) {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// inferencePoolMissing reports whether ref is an InferencePool ref whose pool
+// does not exist in ns. Refs to other kinds are never missing.
+func inferencePoolMissing(ctx context.Context, k8s *kubernetes.Client, ns string, ref BackendRefRequest) (bool, error) {
+	gvr := inference.InferencePoolGVR()
+	if ref.Kind != inferencePoolKind || (ref.Group != "" && ref.Group != gvr.Group) {
+		return false, nil
+	}
+	_, err := k8s.DynamicClient().Resource(gvr).Namespace(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+func serviceHasPort(svc corev1.Service, port int32) bool {
+	for _, p := range svc.Spec.Ports {
+		if p.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+// servicePorts formats a Service's ports for a problem message.
+func servicePorts(svc corev1.Service) string {
+	if len(svc.Spec.Ports) == 0 {
+		return "none"
+	}
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, strconv.Itoa(int(p.Port)))
+	}
+	return strings.Join(ports, ", ")
+}
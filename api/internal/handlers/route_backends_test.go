@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/inference"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func TestRouteHandler_CreateValidateBackends(t *testing.T) {
+	scheme := setupScheme(t)
+	web := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		backend      string
+		wantStatus   int
+		wantWarnings []string
+	}{
+		{
+			name:       "existing service and port",
+			backend:    `{"name": "web", "port": 80}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:         "missing port warns",
+			backend:      `{"name": "web", "port": 8080}`,
+			wantStatus:   http.StatusCreated,
+			wantWarnings: []string{"Service default/web has no port 8080 (ports: 80)"},
+		},
+		{
+			name:         "missing service warns",
+			backend:      `{"name": "wbe", "port": 80}`,
+			wantStatus:   http.StatusCreated,
+			wantWarnings: []string{"Service default/wbe does not exist"},
+		},
+		{
+			name:       "strict rejects missing service",
+			query:      "?validateBackends=strict",
+			backend:    `{"name": "wbe", "port": 80}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "off skips the check",
+			query:      "?validateBackends=off",
+			backend:    `{"name": "wbe", "port": 80}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "unknown mode",
+			query:      "?validateBackends=loud",
+			backend:    `{"name": "web", "port": 80}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(web.DeepCopy()).Build()
+			k8sClient := kubernetes.NewForTest(fakeClient)
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(k8sClient))
+			r.Post("/api/v1/httproutes", (&RouteHandler{}).Create)
+
+			body := `{
+				"name": "my-route",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"backendRefs": [` + tt.backend + `]}]
+			}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/httproutes"+tt.query, strings.NewReader(body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusCreated {
+				if _, err := k8sClient.GetHTTPRoute(req.Context(), "default", "my-route"); err == nil {
+					t.Error("expected the route not to be created")
+				}
+				return
+			}
+			var resp HTTPRouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !slices.Equal(resp.Warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", resp.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRouteHandler_CreateInferencePoolBackend(t *testing.T) {
+	gvr := inference.InferencePoolGVR()
+	pool := &unstructured.Unstructured{}
+	pool.SetAPIVersion(gvr.GroupVersion().String())
+	pool.SetKind("InferencePool")
+	pool.SetName("llama-pool")
+	pool.SetNamespace("default")
+
+	tests := []struct {
+		name         string
+		backend      string
+		wantWarnings []string
+	}{
+		{
+			name:    "existing pool",
+			backend: `{"kind": "InferencePool", "name": "llama-pool"}`,
+		},
+		{
+			name:         "missing pool warns",
+			backend:      `{"kind": "InferencePool", "name": "mistral-pool"}`,
+			wantWarnings: []string{"InferencePool default/mistral-pool does not exist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(t)).Build()
+			dc := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), pool.DeepCopy())
+			k8sClient := kubernetes.NewForTestWithDynamic(fakeClient, dc)
+
+			r := chi.NewRouter()
+			r.Use(contextMiddleware(k8sClient))
+			r.Post("/api/v1/httproutes", (&RouteHandler{}).Create)
+
+			body := `{
+				"name": "llama-route",
+				"namespace": "default",
+				"parentRefs": [{"name": "my-gateway"}],
+				"rules": [{"backendRefs": [` + tt.backend + `]}]
+			}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/httproutes", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp HTTPRouteResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !slices.Equal(resp.Warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %q, want %q", resp.Warnings, tt.wantWarnings)
+			}
+			// The group defaults to the InferencePool API group.
+			ref := resp.Rules[0].BackendRefs[0]
+			if ref.Group != gvr.Group || ref.Kind != "InferencePool" || ref.Port != nil {
+				t.Errorf("backendRef = %+v, want an InferencePool in %s without a port", ref, gvr.Group)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// GRPCRoute response types
+
+type GRPCMethodMatchResp struct {
+	Type    string `json:"type,omitempty"`
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type GRPCRouteMatchResponse struct {
+	Method  *GRPCMethodMatchResp `json:"method,omitempty"`
+	Headers []HeaderMatchResp    `json:"headers,omitempty"`
+}
+
+type GRPCRouteRuleResponse struct {
+	Matches     []GRPCRouteMatchResponse `json:"matches,omitempty"`
+	BackendRefs []BackendRefResponse     `json:"backendRefs,omitempty"`
+}
+
+type GRPCRouteStatusResponse struct {
+	Parents []RouteParentStatusResponse `json:"parents"`
+}
+
+type GRPCRouteResponse struct {
+	Name       string                   `json:"name"`
+	Namespace  string                   `json:"namespace"`
+	ParentRefs []ParentRefResponse      `json:"parentRefs"`
+	Hostnames  []string                 `json:"hostnames,omitempty"`
+	Rules      []GRPCRouteRuleResponse  `json:"rules"`
+	Status     *GRPCRouteStatusResponse `json:"status,omitempty"`
+	CreatedAt  string                   `json:"createdAt"`
+	Warnings   []string                 `json:"warnings,omitempty"`
+}
+
+// Request types for GRPCRoute CRUD
+
+type CreateGRPCRouteRequest struct {
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace"`
+	ParentRefs []ParentRefRequest `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []GRPCRouteRuleReq `json:"rules,omitempty"`
+}
+
+type UpdateGRPCRouteRequest struct {
+	ParentRefs []ParentRefRequest `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []GRPCRouteRuleReq `json:"rules,omitempty"`
+}
+
+type GRPCRouteRuleReq struct {
+	Matches     []GRPCRouteMatchRequest `json:"matches,omitempty"`
+	BackendRefs []BackendRefRequest     `json:"backendRefs,omitempty"`
+}
+
+type GRPCRouteMatchRequest struct {
+	Method  *GRPCMethodMatchRequest `json:"method,omitempty"`
+	Headers []HeaderMatchRequest    `json:"headers,omitempty"`
+}
+
+type GRPCMethodMatchRequest struct {
+	Type    string `json:"type,omitempty"` // Exact (default) or RegularExpression
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+// validateGRPCRouteRules checks the parts of GRPCRoute rules that the API
+// server would otherwise reject with a less specific error.
+func validateGRPCRouteRules(rules []GRPCRouteRuleReq) error {
+	for i, rule := range rules {
+		for j, m := range rule.Matches {
+			if m.Method == nil {
+				continue
+			}
+			switch m.Method.Type {
+			case "", string(gatewayv1.GRPCMethodMatchExact), string(gatewayv1.GRPCMethodMatchRegularExpression):
+			default:
+				return fmt.Errorf("rules[%d].matches[%d].method.type must be Exact or RegularExpression", i, j)
+			}
+			if m.Method.Service == "" && m.Method.Method == "" {
+				return fmt.Errorf("rules[%d].matches[%d].method requires service or method", i, j)
+			}
+		}
+		for j, br := range rule.BackendRefs {
+			if br.Name == "" {
+				return fmt.Errorf("rules[%d].backendRefs[%d].name is required", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func toGRPCRouteObject(req CreateGRPCRouteRequest) *gatewayv1.GRPCRoute {
+	gr := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+		Spec: gatewayv1.GRPCRouteSpec{},
+	}
+	gr.Spec.ParentRefs = convertParentRefRequests(req.ParentRefs)
+	for _, h := range req.Hostnames {
+		gr.Spec.Hostnames = append(gr.Spec.Hostnames, gatewayv1.Hostname(h))
+	}
+	gr.Spec.Rules = convertGRPCRouteRuleRequests(req.Rules)
+	return gr
+}
+
+func applyUpdateToGRPCRoute(gr *gatewayv1.GRPCRoute, req UpdateGRPCRouteRequest) {
+	gr.Spec.ParentRefs = convertParentRefRequests(req.ParentRefs)
+	gr.Spec.Hostnames = nil
+	for _, h := range req.Hostnames {
+		gr.Spec.Hostnames = append(gr.Spec.Hostnames, gatewayv1.Hostname(h))
+	}
+	gr.Spec.Rules = convertGRPCRouteRuleRequests(req.Rules)
+}
+
+func convertGRPCRouteRuleRequests(rules []GRPCRouteRuleReq) []gatewayv1.GRPCRouteRule {
+	result := make([]gatewayv1.GRPCRouteRule, 0, len(rules))
+	for _, rule := range rules {
+		r := gatewayv1.GRPCRouteRule{}
+		for _, m := range rule.Matches {
+			match := gatewayv1.GRPCRouteMatch{}
+			if m.Method != nil {
+				matchType := gatewayv1.GRPCMethodMatchExact
+				if m.Method.Type != "" {
+					matchType = gatewayv1.GRPCMethodMatchType(m.Method.Type)
+				}
+				method := &gatewayv1.GRPCMethodMatch{Type: &matchType}
+				if m.Method.Service != "" {
+					service := m.Method.Service
+					method.Service = &service
+				}
+				if m.Method.Method != "" {
+					name := m.Method.Method
+					method.Method = &name
+				}
+				match.Method = method
+			}
+			for _, h := range m.Headers {
+				headerType := gatewayv1.GRPCHeaderMatchType(h.Type)
+				match.Headers = append(match.Headers, gatewayv1.GRPCHeaderMatch{
+					Type:  &headerType,
+					Name:  gatewayv1.GRPCHeaderName(h.Name),
+					Value: h.Value,
+				})
+			}
+			r.Matches = append(r.Matches, match)
+		}
+		for _, br := range rule.BackendRefs {
+			r.BackendRefs = append(r.BackendRefs, gatewayv1.GRPCBackendRef{BackendRef: toBackendRef(br)})
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func toGRPCRouteResponse(gr *gatewayv1.GRPCRoute) GRPCRouteResponse {
+	resp := GRPCRouteResponse{
+		Name:       gr.Name,
+		Namespace:  gr.Namespace,
+		ParentRefs: toParentRefResponses(gr.Spec.ParentRefs),
+		CreatedAt:  gr.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+
+	for _, h := range gr.Spec.Hostnames {
+		resp.Hostnames = append(resp.Hostnames, string(h))
+	}
+
+	for _, rule := range gr.Spec.Rules {
+		rr := GRPCRouteRuleResponse{}
+		for _, m := range rule.Matches {
+			mr := GRPCRouteMatchResponse{}
+			if m.Method != nil {
+				mm := &GRPCMethodMatchResp{}
+				if m.Method.Type != nil {
+					mm.Type = string(*m.Method.Type)
+				}
+				if m.Method.Service != nil {
+					mm.Service = *m.Method.Service
+				}
+				if m.Method.Method != nil {
+					mm.Method = *m.Method.Method
+				}
+				mr.Method = mm
+			}
+			for _, h := range m.Headers {
+				hm := HeaderMatchResp{Name: string(h.Name), Value: h.Value}
+				if h.Type != nil {
+					hm.Type = string(*h.Type)
+				}
+				mr.Headers = append(mr.Headers, hm)
+			}
+			rr.Matches = append(rr.Matches, mr)
+		}
+		for _, br := range rule.BackendRefs {
+			rr.BackendRefs = append(rr.BackendRefs, toBackendRefResponse(br.BackendRef))
+		}
+		resp.Rules = append(resp.Rules, rr)
+	}
+
+	if gr.Status.Parents != nil {
+		resp.Status = &GRPCRouteStatusResponse{Parents: toRouteParentStatusResponses(gr.Status.Parents)}
+	}
+
+	return resp
+}
+
+func toRouteParentStatusResponses(parents []gatewayv1.RouteParentStatus) []RouteParentStatusResponse {
+	result := make([]RouteParentStatusResponse, 0, len(parents))
+	for _, ps := range parents {
+		psr := RouteParentStatusResponse{
+			ControllerName: string(ps.ControllerName),
+			Conditions:     convertConditions(ps.Conditions),
+		}
+		if refs := toParentRefResponses([]gatewayv1.ParentReference{ps.ParentRef}); len(refs) == 1 {
+			psr.ParentRef = refs[0]
+		}
+		result = append(result, psr)
+	}
+	return result
+}
+
+func toParentRefResponses(refs []gatewayv1.ParentReference) []ParentRefResponse {
+	result := make([]ParentRefResponse, 0, len(refs))
+	for _, pr := range refs {
+		pResp := ParentRefResponse{Name: string(pr.Name)}
+		if pr.Group != nil {
+			pResp.Group = string(*pr.Group)
+		}
+		if pr.Kind != nil {
+			pResp.Kind = string(*pr.Kind)
+		}
+		if pr.Namespace != nil {
+			ns := string(*pr.Namespace)
+			pResp.Namespace = &ns
+		}
+		if pr.SectionName != nil {
+			sn := string(*pr.SectionName)
+			pResp.SectionName = &sn
+		}
+		if pr.Port != nil {
+			p := int32(*pr.Port)
+			pResp.Port = &p
+		}
+		result = append(result, pResp)
+	}
+	return result
+}
+
+func toBackendRefResponse(br gatewayv1.BackendRef) BackendRefResponse {
+	brr := BackendRefResponse{Name: string(br.Name)}
+	if br.Group != nil {
+		brr.Group = string(*br.Group)
+	}
+	if br.Kind != nil {
+		brr.Kind = string(*br.Kind)
+	}
+	if br.Namespace != nil {
+		ns := string(*br.Namespace)
+		brr.Namespace = &ns
+	}
+	if br.Port != nil {
+		p := int32(*br.Port)
+		brr.Port = &p
+	}
+	if br.Weight != nil {
+		w := *br.Weight
+		brr.Weight = &w
+	}
+	return brr
+}
+
+// L4 route (TCPRoute, TLSRoute, UDPRoute) types. The three kinds share the
+// same shape apart from hostnames, which only TLSRoute supports.
+
+type L4RouteRuleResponse struct {
+	BackendRefs []BackendRefResponse `json:"backendRefs"`
+}
+
+type L4RouteStatusResponse struct {
+	Parents []RouteParentStatusResponse `json:"parents"`
+}
+
+type L4RouteResponse struct {
+	Kind       string                 `json:"kind"`
+	Name       string                 `json:"name"`
+	Namespace  string                 `json:"namespace"`
+	ParentRefs []ParentRefResponse    `json:"parentRefs"`
+	Hostnames  []string               `json:"hostnames,omitempty"`
+	Rules      []L4RouteRuleResponse  `json:"rules"`
+	Status     *L4RouteStatusResponse `json:"status,omitempty"`
+	CreatedAt  string                 `json:"createdAt"`
+	Warnings   []string               `json:"warnings,omitempty"`
+}
+
+type CreateL4RouteRequest struct {
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace"`
+	ParentRefs []ParentRefRequest `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []L4RouteRuleReq   `json:"rules"`
+}
+
+type UpdateL4RouteRequest struct {
+	ParentRefs []ParentRefRequest `json:"parentRefs"`
+	Hostnames  []string           `json:"hostnames,omitempty"`
+	Rules      []L4RouteRuleReq   `json:"rules"`
+}
+
+type L4RouteRuleReq struct {
+	BackendRefs []BackendRefRequest `json:"backendRefs"`
+}
+
+// validateL4Route checks the fields the v1alpha2 L4 route CRDs require.
+func validateL4Route(kind string, parentRefs []ParentRefRequest, hostnames []string, rules []L4RouteRuleReq) error {
+	if len(parentRefs) == 0 {
+		return errors.New("at least one parentRef is required")
+	}
+	if len(hostnames) > 0 && kind != "TLSRoute" {
+		return errors.New("hostnames are only supported on TLSRoute")
+	}
+	if len(rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+	for i, rule := range rules {
+		if len(rule.BackendRefs) == 0 {
+			return fmt.Errorf("rules[%d] requires at least one backendRef", i)
+		}
+		for j, br := range rule.BackendRefs {
+			if br.Name == "" || (br.Port == nil && br.isService()) {
+				return fmt.Errorf("rules[%d].backendRefs[%d] requires name and port", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func convertHostnames(hostnames []string) []gatewayv1.Hostname {
+	var result []gatewayv1.Hostname
+	for _, h := range hostnames {
+		result = append(result, gatewayv1.Hostname(h))
+	}
+	return result
+}
+
+func convertL4BackendRefs(refs []BackendRefRequest) []gatewayv1.BackendRef {
+	result := make([]gatewayv1.BackendRef, 0, len(refs))
+	for _, br := range refs {
+		result = append(result, toBackendRef(br))
+	}
+	return result
+}
+
+func toTCPRouteObject(req CreateL4RouteRequest) *gatewayv1alpha2.TCPRoute {
+	tr := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+	applyUpdateToTCPRoute(tr, UpdateL4RouteRequest{ParentRefs: req.ParentRefs, Rules: req.Rules})
+	return tr
+}
+
+func applyUpdateToTCPRoute(tr *gatewayv1alpha2.TCPRoute, req UpdateL4RouteRequest) {
+	tr.Spec.ParentRefs = convertParentRefRequests(req.ParentRefs)
+	tr.Spec.Rules = make([]gatewayv1alpha2.TCPRouteRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		tr.Spec.Rules = append(tr.Spec.Rules, gatewayv1alpha2.TCPRouteRule{BackendRefs: convertL4BackendRefs(rule.BackendRefs)})
+	}
+}
+
+func toTLSRouteObject(req CreateL4RouteRequest) *gatewayv1alpha2.TLSRoute {
+	tr := &gatewayv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+	applyUpdateToTLSRoute(tr, UpdateL4RouteRequest{ParentRefs: req.ParentRefs, Hostnames: req.Hostnames, Rules: req.Rules})
+	return tr
+}
+
+func applyUpdateToTLSRoute(tr *gatewayv1alpha2.TLSRoute, req UpdateL4RouteRequest) {
+	tr.Spec.ParentRefs = convertParentRefRequests(req.ParentRefs)
+	tr.Spec.Hostnames = convertHostnames(req.Hostnames)
+	tr.Spec.Rules = make([]gatewayv1alpha2.TLSRouteRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		tr.Spec.Rules = append(tr.Spec.Rules, gatewayv1alpha2.TLSRouteRule{BackendRefs: convertL4BackendRefs(rule.BackendRefs)})
+	}
+}
+
+func toUDPRouteObject(req CreateL4RouteRequest) *gatewayv1alpha2.UDPRoute {
+	ur := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+	applyUpdateToUDPRoute(ur, UpdateL4RouteRequest{ParentRefs: req.ParentRefs, Rules: req.Rules})
+	return ur
+}
+
+func applyUpdateToUDPRoute(ur *gatewayv1alpha2.UDPRoute, req UpdateL4RouteRequest) {
+	ur.Spec.ParentRefs = convertParentRefRequests(req.ParentRefs)
+	ur.Spec.Rules = make([]gatewayv1alpha2.UDPRouteRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		ur.Spec.Rules = append(ur.Spec.Rules, gatewayv1alpha2.UDPRouteRule{BackendRefs: convertL4BackendRefs(rule.BackendRefs)})
+	}
+}
+
+// newL4RouteResponse fills the fields common to all L4 route kinds.
+func newL4RouteResponse(kind string, meta metav1.ObjectMeta, spec gatewayv1.CommonRouteSpec, status gatewayv1.RouteStatus) L4RouteResponse {
+	resp := L4RouteResponse{
+		Kind:       kind,
+		Name:       meta.Name,
+		Namespace:  meta.Namespace,
+		ParentRefs: toParentRefResponses(spec.ParentRefs),
+		Rules:      []L4RouteRuleResponse{},
+		CreatedAt:  meta.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if status.Parents != nil {
+		resp.Status = &L4RouteStatusResponse{Parents: toRouteParentStatusResponses(status.Parents)}
+	}
+	return resp
+}
+
+func toL4RuleResponse(refs []gatewayv1.BackendRef) L4RouteRuleResponse {
+	rr := L4RouteRuleResponse{BackendRefs: make([]BackendRefResponse, 0, len(refs))}
+	for _, br := range refs {
+		rr.BackendRefs = append(rr.BackendRefs, toBackendRefResponse(br))
+	}
+	return rr
+}
+
+func toTCPRouteResponse(tr *gatewayv1alpha2.TCPRoute) L4RouteResponse {
+	resp := newL4RouteResponse("TCPRoute", tr.ObjectMeta, tr.Spec.CommonRouteSpec, tr.Status.RouteStatus)
+	for _, rule := range tr.Spec.Rules {
+		resp.Rules = append(resp.Rules, toL4RuleResponse(rule.BackendRefs))
+	}
+	return resp
+}
+
+func toTLSRouteResponse(tr *gatewayv1alpha2.TLSRoute) L4RouteResponse {
+	resp := newL4RouteResponse("TLSRoute", tr.ObjectMeta, tr.Spec.CommonRouteSpec, tr.Status.RouteStatus)
+	for _, h := range tr.Spec.Hostnames {
+		resp.Hostnames = append(resp.Hostnames, string(h))
+	}
+	for _, rule := range tr.Spec.Rules {
+		resp.Rules = append(resp.Rules, toL4RuleResponse(rule.BackendRefs))
+	}
+	return resp
+}
+
+func toUDPRouteResponse(ur *gatewayv1alpha2.UDPRoute) L4RouteResponse {
+	resp := newL4RouteResponse("UDPRoute", ur.ObjectMeta, ur.Spec.CommonRouteSpec, ur.Status.RouteStatus)
+	for _, rule := range ur.Spec.Rules {
+		resp.Rules = append(resp.Rules, toL4RuleResponse(rule.BackendRefs))
+	}
+	return resp
+}
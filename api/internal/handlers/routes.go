@@ -34,9 +34,12 @@ func (h *RouteHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	getGateway := cachedGatewayGetter(r.Context(), k8s)
 	resp := make([]HTTPRouteResponse, 0, len(routes))
 	for i := range routes {
-		resp = append(resp, toHTTPRouteResponse(&routes[i]))
+		hrResp := toHTTPRouteResponse(&routes[i])
+		hrResp.Attachments = routeAttachments(&routes[i], getGateway)
+		resp = append(resp, hrResp)
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
@@ -57,7 +60,9 @@ func (h *RouteHandler) Get(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, toHTTPRouteResponse(hr))
+	resp := toHTTPRouteResponse(hr)
+	resp.Attachments = routeAttachments(hr, cachedGatewayGetter(r.Context(), k8s))
+	writeJSON(w, http.StatusOK, resp)
 }
 
 // Create creates a new HTTPRoute.
@@ -79,6 +84,15 @@ func (h *RouteHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var refs []BackendRefRequest
+	for _, rule := range req.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+	warnings, ok := validateRouteBackends(w, r, k8s, req.Namespace, refs)
+	if !ok {
+		return
+	}
+
 	hr := toHTTPRouteObject(req)
 	created, err := k8s.CreateHTTPRoute(r.Context(), hr)
 	if err != nil {
@@ -87,6 +101,7 @@ func (h *RouteHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 	resp := toHTTPRouteResponse(created)
 	auditLog(h.Store, r.Context(), "create", "HTTPRoute", req.Name, req.Namespace, nil, resp)
+	resp.Warnings = warnings
 	writeJSON(w, http.StatusCreated, resp)
 }
 
@@ -208,26 +223,7 @@ func (h *RouteHandler) Simulate(w http.ResponseWriter, r *http.Request) {
 			resp.Matched = true
 			resp.MatchedRule = ruleIdx
 			for _, br := range rule.BackendRefs {
-				brr := BackendRefResponse{Name: string(br.Name)}
-				if br.Group != nil {
-					brr.Group = string(*br.Group)
-				}
-				if br.Kind != nil {
-					brr.Kind = string(*br.Kind)
-				}
-				if br.Namespace != nil {
-					ns := string(*br.Namespace)
-					brr.Namespace = &ns
-				}
-				if br.Port != nil {
-					p := int32(*br.Port)
-					brr.Port = &p
-				}
-				if br.Weight != nil {
-					w := *br.Weight
-					brr.Weight = &w
-				}
-				resp.Backends = append(resp.Backends, brr)
+				resp.Backends = append(resp.Backends, toBackendRefResponse(br.BackendRef))
 			}
 		}
 	}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	ch "github.com/kubenetlabs/ngc/api/internal/clickhouse"
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
+	"github.com/kubenetlabs/ngc/api/internal/xc"
+)
+
+// selfTestTimeout bounds each self-test check, so one unreachable integration
+// doesn't hold up the report.
+const selfTestTimeout = 5 * time.Second
+
+// selfTestUserID owns the saved view the config database check writes.
+const selfTestUserID = "ngf-console-selftest"
+
+// Self-test check outcomes.
+const (
+	SelfTestPass    = "pass"
+	SelfTestFail    = "fail"
+	SelfTestSkipped = "skipped" // the integration is not configured
+)
+
+// SelfTestHandler verifies that each configured integration is reachable, so
+// operators can check an install with a single request.
+type SelfTestHandler struct {
+	Store      database.Store
+	CHClient   *ch.Client
+	PromClient *prom.Client
+}
+
+// SelfTestCheck is the result of one self-test check.
+type SelfTestCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // pass, fail, or skipped
+	Message    string `json:"message,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// SelfTestResponse is the body returned by GET /selftest.
+type SelfTestResponse struct {
+	Status string          `json:"status"` // pass if no check failed, fail otherwise
+	Checks []SelfTestCheck `json:"checks"`
+}
+
+// errSelfTestSkipped is returned by a check whose integration is not configured.
+type errSelfTestSkipped string
+
+func (e errSelfTestSkipped) Error() string { return string(e) }
+
+// Run executes every check and returns 200 when none failed and 503 otherwise.
+// The checks are read-only except for the config database check, which
+// deletes the row it writes.
+func (h *SelfTestHandler) Run(w http.ResponseWriter, r *http.Request) {
+	checks := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"kubernetes", h.checkKubernetes},
+		{"clickhouse", h.checkClickHouse},
+		{"prometheus", h.checkPrometheus},
+		{"xc", h.checkXC},
+		{"configDB", h.checkConfigDB},
+	}
+
+	resp := SelfTestResponse{Status: SelfTestPass, Checks: make([]SelfTestCheck, 0, len(checks))}
+	for _, c := range checks {
+		result := runSelfTestCheck(r.Context(), c.name, c.run)
+		if result.Status == SelfTestFail {
+			resp.Status = SelfTestFail
+		}
+		resp.Checks = append(resp.Checks, result)
+	}
+
+	status := http.StatusOK
+	if resp.Status == SelfTestFail {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+// runSelfTestCheck runs one check under selfTestTimeout and records its outcome.
+func runSelfTestCheck(ctx context.Context, name string, run func(ctx context.Context) error) SelfTestCheck {
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := run(ctx)
+	result := SelfTestCheck{Name: name, Status: SelfTestPass, DurationMs: time.Since(start).Milliseconds()}
+	if skipped, ok := err.(errSelfTestSkipped); ok {
+		result.Status = SelfTestSkipped
+		result.Message = string(skipped)
+	} else if err != nil {
+		result.Status = SelfTestFail
+		result.Message = err.Error()
+	}
+	return result
+}
+
+// checkKubernetes lists GatewayClasses from the resolved cluster.
+func (h *SelfTestHandler) checkKubernetes(ctx context.Context) error {
+	k8s := cluster.ClientFromContext(ctx)
+	if k8s == nil {
+		return fmt.Errorf("no cluster client")
+	}
+	if _, err := k8s.ListGatewayClasses(ctx); err != nil {
+		return fmt.Errorf("list gatewayclasses: %w", err)
+	}
+	return nil
+}
+
+func (h *SelfTestHandler) checkClickHouse(ctx context.Context) error {
+	if h.CHClient == nil {
+		return errSelfTestSkipped("ClickHouse not configured")
+	}
+	return h.CHClient.Ping(ctx)
+}
+
+func (h *SelfTestHandler) checkPrometheus(ctx context.Context) error {
+	if h.PromClient == nil {
+		return errSelfTestSkipped("Prometheus not configured")
+	}
+	return h.PromClient.Ping(ctx)
+}
+
+// checkXC tests the XC API with the global credentials.
+func (h *SelfTestHandler) checkXC(ctx context.Context) error {
+	if h.Store == nil {
+		return errSelfTestSkipped("config database not configured")
+	}
+	creds, err := h.Store.GetXCCredentials(ctx, "")
+	if err != nil {
+		return fmt.Errorf("read XC credentials: %w", err)
+	}
+	if creds == nil {
+		return errSelfTestSkipped("XC credentials not configured")
+	}
+	return xc.New(creds.Tenant, creds.APIToken).TestConnection(ctx)
+}
+
+// checkConfigDB writes a saved view, reads it back, and deletes it.
+func (h *SelfTestHandler) checkConfigDB(ctx context.Context) (err error) {
+	if h.Store == nil {
+		return errSelfTestSkipped("config database not configured")
+	}
+
+	id := "selftest-" + uuid.NewString()
+	if err := h.Store.CreateSavedView(ctx, database.SavedView{
+		ID: id, UserID: selfTestUserID, Name: id, ViewType: "selftest", Config: "{}",
+	}); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	defer func() {
+		// Clean up even when the read failed or the request was cancelled.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), selfTestTimeout)
+		defer cancel()
+		if delErr := h.Store.DeleteSavedView(cleanupCtx, id); delErr != nil && err == nil {
+			err = fmt.Errorf("delete: %w", delErr)
+		}
+	}()
+
+	views, err := h.Store.ListSavedViews(ctx, selfTestUserID)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	for _, v := range views {
+		if v.ID == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("read: row %s not found after write", id)
+}
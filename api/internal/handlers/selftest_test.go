@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func TestSelfTestHandler_Run(t *testing.T) {
+	scheme := setupScheme(t)
+	k8s := kubernetes.NewForTest(fake.NewClientBuilder().WithScheme(scheme).Build())
+	store := newMigrationTestStore(t)
+
+	h := &SelfTestHandler{Store: store}
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(k8s))
+	r.Get("/api/v1/selftest", h.Run)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/selftest", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp SelfTestResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != SelfTestPass {
+		t.Errorf("expected overall pass, got %q", resp.Status)
+	}
+	want := map[string]string{
+		"kubernetes": SelfTestPass,
+		"clickhouse": SelfTestSkipped,
+		"prometheus": SelfTestSkipped,
+		"xc":         SelfTestSkipped,
+		"configDB":   SelfTestPass,
+	}
+	if len(resp.Checks) != len(want) {
+		t.Fatalf("expected %d checks, got %+v", len(want), resp.Checks)
+	}
+	for _, c := range resp.Checks {
+		if c.Status != want[c.Name] {
+			t.Errorf("check %s = %s (%s), want %s", c.Name, c.Status, c.Message, want[c.Name])
+		}
+	}
+
+	// The config database check cleans up the row it wrote.
+	views, err := store.ListSavedViews(context.Background(), selfTestUserID)
+	if err != nil {
+		t.Fatalf("ListSavedViews: %v", err)
+	}
+	if len(views) != 0 {
+		t.Errorf("expected the self-test row to be deleted, got %+v", views)
+	}
+}
+
+func TestSelfTestHandler_NoCluster(t *testing.T) {
+	h := &SelfTestHandler{}
+
+	w := httptest.NewRecorder()
+	h.Run(w, httptest.NewRequest(http.MethodGet, "/api/v1/selftest", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp SelfTestResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != SelfTestFail || resp.Checks[0].Name != "kubernetes" || resp.Checks[0].Status != SelfTestFail {
+		t.Errorf("expected the kubernetes check to fail, got %+v", resp)
+	}
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/inference"
+)
+
+// maxSupportBundleEvents caps the events included in a support bundle. The
+// most recent events are kept.
+const maxSupportBundleEvents = 1000
+
+// redactedValue replaces secret-looking string values in a support bundle.
+const redactedValue = "REDACTED"
+
+// SupportHandler builds support bundles: a snapshot of the Gateway API and
+// console resources in a cluster, for diagnosing issues offline.
+type SupportHandler struct {
+	DynamicClient dynamic.Interface
+}
+
+// getDynamicClient returns the dynamic client from the handler field or falls back
+// to the cluster context's dynamic client.
+func (h *SupportHandler) getDynamicClient(r *http.Request) dynamic.Interface {
+	if h.DynamicClient != nil {
+		return h.DynamicClient
+	}
+	k8s := cluster.ClientFromContext(r.Context())
+	if k8s == nil {
+		return nil
+	}
+	return k8s.DynamicClient()
+}
+
+// SupportBundleManifest describes a support bundle. It is stored in the
+// archive as manifest.json.
+type SupportBundleManifest struct {
+	GeneratedAt string              `json:"generatedAt"`
+	Cluster     string              `json:"cluster,omitempty"`
+	Namespaces  []string            `json:"namespaces"` // empty means all namespaces
+	Files       []SupportBundleFile `json:"files"`
+}
+
+// SupportBundleFile is one file in a support bundle. Error is set when the
+// resource could not be listed, for example because its CRD is not installed.
+type SupportBundleFile struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Error string `json:"error,omitempty"`
+}
+
+// supportBundleResource is a resource type collected into a support bundle.
+type supportBundleResource struct {
+	file          string
+	gvr           schema.GroupVersionResource
+	clusterScoped bool
+}
+
+// supportBundleResources lists the resource types in a support bundle. Secrets
+// are never collected.
+func supportBundleResources() []supportBundleResource {
+	return []supportBundleResource{
+		{file: "gatewayclasses.json", gvr: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}, clusterScoped: true},
+		{file: "gateways.json", gvr: gatewayAPIGVR("Gateway")},
+		{file: "httproutes.json", gvr: gatewayAPIGVR("HTTPRoute")},
+		{file: "grpcroutes.json", gvr: gatewayAPIGVR("GRPCRoute")},
+		{file: "tlsroutes.json", gvr: gatewayAPIGVR("TLSRoute")},
+		{file: "tcproutes.json", gvr: gatewayAPIGVR("TCPRoute")},
+		{file: "udproutes.json", gvr: gatewayAPIGVR("UDPRoute")},
+		{file: "referencegrants.json", gvr: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "referencegrants"}},
+		{file: "gatewaybundles.json", gvr: gatewayBundleGVR},
+		{file: "inferencestacks.json", gvr: inferenceStackGVR},
+		{file: "inferencepools.json", gvr: inference.InferencePoolGVR()},
+		{file: "distributedcloudpublishes.json", gvr: distributedCloudPublishGVR},
+	}
+}
+
+// Bundle streams a gzipped tar archive of the cluster's gateways, routes,
+// gateway classes, console CRDs, inference pools, and recent events, each with
+// its status. ?namespaces=a,b limits namespaced resources to those namespaces.
+// Secret-looking values are redacted before anything is written.
+func (h *SupportHandler) Bundle(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
+
+	namespaces, err := parseSupportBundleNamespaces(r.URL.Query().Get("namespaces"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	manifest := SupportBundleManifest{
+		GeneratedAt: now.Format(time.RFC3339),
+		Cluster:     cluster.ClusterNameFromContext(r.Context()),
+		Namespaces:  namespaces,
+	}
+	files := make(map[string][]map[string]any)
+
+	for _, res := range supportBundleResources() {
+		items, err := listSupportBundleItems(r.Context(), dc, res.gvr, res.clusterScoped, namespaces)
+		manifest.Files = append(manifest.Files, supportBundleFile(res.file, len(items), err))
+		files[res.file] = items
+	}
+
+	events, err := listSupportBundleItems(r.Context(), dc, eventGVR, false, namespaces)
+	events = recentSupportBundleEvents(events, maxSupportBundleEvents)
+	manifest.Files = append(manifest.Files, supportBundleFile("events.json", len(events), err))
+	files["events.json"] = events
+
+	archive, err := writeSupportBundle(manifest, files)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("building support bundle: %v", err))
+		return
+	}
+
+	name := "ngf-console-support"
+	if manifest.Cluster != "" {
+		name += "-" + manifest.Cluster
+	}
+	name += "-" + now.Format("20060102T150405Z") + ".tar.gz"
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
+
+// parseSupportBundleNamespaces parses a comma-separated namespace list. An
+// empty list selects all namespaces.
+func parseSupportBundleNamespaces(raw string) ([]string, error) {
+	namespaces := []string{}
+	for _, ns := range strings.Split(raw, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || slices.Contains(namespaces, ns) {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", ns, strings.Join(errs, "; "))
+		}
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// listSupportBundleItems lists gvr across the given namespaces (all when
+// empty) and returns the redacted objects sorted by namespace and name.
+func listSupportBundleItems(ctx context.Context, dc dynamic.Interface, gvr schema.GroupVersionResource, clusterScoped bool, namespaces []string) ([]map[string]any, error) {
+	scopes := namespaces
+	if clusterScoped || len(scopes) == 0 {
+		scopes = []string{metav1.NamespaceAll}
+	}
+
+	var objs []unstructured.Unstructured
+	for _, ns := range scopes {
+		var list *unstructured.UnstructuredList
+		var err error
+		if clusterScoped {
+			list, err = dc.Resource(gvr).List(ctx, metav1.ListOptions{})
+		} else {
+			list, err = dc.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", gvr.Resource, err)
+		}
+		objs = append(objs, list.Items...)
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].GetNamespace() != objs[j].GetNamespace() {
+			return objs[i].GetNamespace() < objs[j].GetNamespace()
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+
+	items := make([]map[string]any, 0, len(objs))
+	for _, obj := range objs {
+		items = append(items, redactSupportBundleObject(obj.Object))
+	}
+	return items, nil
+}
+
+// recentSupportBundleEvents returns at most limit events, newest first.
+func recentSupportBundleEvents(events []map[string]any, limit int) []map[string]any {
+	type timed struct {
+		at  time.Time
+		obj map[string]any
+	}
+	all := make([]timed, 0, len(events))
+	for _, obj := range events {
+		var ev corev1.Event
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &ev)
+		all = append(all, timed{at: eventTime(ev), obj: obj})
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].at.After(all[j].at) })
+	if len(all) > limit {
+		all = all[:limit]
+	}
+
+	recent := make([]map[string]any, 0, len(all))
+	for _, t := range all {
+		recent = append(recent, t.obj)
+	}
+	return recent
+}
+
+func supportBundleFile(name string, count int, err error) SupportBundleFile {
+	f := SupportBundleFile{Name: name, Count: count}
+	if err != nil {
+		f.Error = err.Error()
+	}
+	return f
+}
+
+// redactSupportBundleObject returns a copy of obj without managed fields or
+// the last-applied-configuration annotation, with secret-looking string
+// values replaced by redactedValue.
+func redactSupportBundleObject(obj map[string]any) map[string]any {
+	out := runtime.DeepCopyJSON(obj)
+	unstructured.RemoveNestedField(out, "metadata", "managedFields")
+	unstructured.RemoveNestedField(out, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	return redactSecretValues(out).(map[string]any)
+}
+
+// redactSecretValues replaces string values whose key looks like it holds a
+// credential. Keys that name or reference a Secret (e.g. secretName,
+// tokenSecretRef) are kept, since they are needed to diagnose missing Secrets.
+func redactSecretValues(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, child := range val {
+			if _, ok := child.(string); ok && isSecretKey(k) {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactSecretValues(child)
+		}
+		return val
+	case []any:
+		for i, child := range val {
+			val[i] = redactSecretValues(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func isSecretKey(key string) bool {
+	k := strings.ToLower(key)
+	if strings.HasSuffix(k, "name") || strings.HasSuffix(k, "ref") {
+		return false
+	}
+	for _, marker := range []string{"password", "passwd", "token", "secret", "apikey", "api_key", "credential", "privatekey", "private_key"} {
+		if strings.Contains(k, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSupportBundle writes manifest.json and one JSON file per resource type
+// into a gzipped tar archive.
+func writeSupportBundle(manifest SupportBundleManifest, files map[string][]map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	modTime, _ := time.Parse(time.RFC3339, manifest.GeneratedAt)
+
+	add := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := add("manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	for _, f := range manifest.Files {
+		items := files[f.Name]
+		if items == nil {
+			items = []map[string]any{}
+		}
+		if err := add(f.Name, items); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("closing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("closing archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
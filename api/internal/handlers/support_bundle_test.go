@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func newSupportBundleClient() *fakedynamic.FakeDynamicClient {
+	kinds := map[string]string{
+		"gatewayclasses.json":            "GatewayClass",
+		"gateways.json":                  "Gateway",
+		"httproutes.json":                "HTTPRoute",
+		"grpcroutes.json":                "GRPCRoute",
+		"tlsroutes.json":                 "TLSRoute",
+		"tcproutes.json":                 "TCPRoute",
+		"udproutes.json":                 "UDPRoute",
+		"referencegrants.json":           "ReferenceGrant",
+		"gatewaybundles.json":            "GatewayBundle",
+		"inferencestacks.json":           "InferenceStack",
+		"inferencepools.json":            "InferencePool",
+		"distributedcloudpublishes.json": "DistributedCloudPublish",
+	}
+	listKinds := map[schema.GroupVersionResource]string{eventGVR: "EventList"}
+	for _, res := range supportBundleResources() {
+		listKinds[res.gvr] = kinds[res.file] + "List"
+	}
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+}
+
+// readSupportBundle decodes a support bundle archive into its files.
+func readSupportBundle(t *testing.T, body io.Reader) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading archive: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("reading %s: %v", hdr.Name, err)
+		}
+		files[hdr.Name] = data
+	}
+	return files
+}
+
+func TestSupportHandler_Bundle(t *testing.T) {
+	gateway := func(ns, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "Gateway",
+			"metadata": map[string]any{
+				"name":          name,
+				"namespace":     ns,
+				"managedFields": []any{map[string]any{"manager": "kubectl"}},
+			},
+			"spec": map[string]any{
+				"gatewayClassName": "nginx",
+				"listeners": []any{map[string]any{
+					"name":     "https",
+					"port":     int64(443),
+					"protocol": "HTTPS",
+					"tls":      map[string]any{"certificateRefs": []any{map[string]any{"name": "edge-cert"}}},
+				}},
+			},
+			"status": map[string]any{"conditions": []any{map[string]any{"type": "Programmed", "status": "True"}}},
+		}}
+	}
+	publish := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": distributedCloudPublishGVR.GroupVersion().String(),
+		"kind":       "DistributedCloudPublish",
+		"metadata":   map[string]any{"name": "edge", "namespace": "team-a"},
+		"spec":       map[string]any{"apiToken": "s3cr3t", "tokenSecretRef": "xc-credentials"},
+	}}
+
+	// Seed through Create: the fake tracker would guess "gatewaies" for Gateway.
+	dc := newSupportBundleClient()
+	for _, seed := range []struct {
+		gvr schema.GroupVersionResource
+		obj *unstructured.Unstructured
+	}{
+		{gatewayAPIGVR("Gateway"), gateway("team-a", "edge")},
+		{gatewayAPIGVR("Gateway"), gateway("team-b", "internal")},
+		{distributedCloudPublishGVR, publish},
+	} {
+		if _, err := dc.Resource(seed.gvr).Namespace(seed.obj.GetNamespace()).Create(context.Background(), seed.obj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("seeding %s: %v", seed.obj.GetName(), err)
+		}
+	}
+	handler := &SupportHandler{DynamicClient: dc}
+
+	t.Run("collects every resource type", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/support/bundle", nil)
+		w := httptest.NewRecorder()
+		handler.Bundle(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/gzip" {
+			t.Errorf("Content-Type = %q, want application/gzip", ct)
+		}
+		if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, "ngf-console-support-") {
+			t.Errorf("unexpected Content-Disposition %q", cd)
+		}
+
+		files := readSupportBundle(t, w.Body)
+		var manifest SupportBundleManifest
+		if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+			t.Fatalf("decode manifest: %v", err)
+		}
+		if len(manifest.Files) != len(supportBundleResources())+1 {
+			t.Errorf("expected %d files in manifest, got %d", len(supportBundleResources())+1, len(manifest.Files))
+		}
+		for _, f := range manifest.Files {
+			if _, ok := files[f.Name]; !ok {
+				t.Errorf("manifest lists %s but it is not in the archive", f.Name)
+			}
+			if f.Name == "gateways.json" && f.Count != 2 {
+				t.Errorf("gateways count = %d, want 2", f.Count)
+			}
+		}
+
+		var gateways []map[string]any
+		if err := json.Unmarshal(files["gateways.json"], &gateways); err != nil {
+			t.Fatalf("decode gateways: %v", err)
+		}
+		if _, found, _ := unstructured.NestedSlice(gateways[0], "metadata", "managedFields"); found {
+			t.Error("expected managedFields to be removed")
+		}
+		if _, found, _ := unstructured.NestedSlice(gateways[0], "status", "conditions"); !found {
+			t.Error("expected status to be kept")
+		}
+
+		var publishes []map[string]any
+		if err := json.Unmarshal(files["distributedcloudpublishes.json"], &publishes); err != nil {
+			t.Fatalf("decode publishes: %v", err)
+		}
+		spec := publishes[0]["spec"].(map[string]any)
+		if spec["apiToken"] != redactedValue {
+			t.Errorf("apiToken = %v, want redacted", spec["apiToken"])
+		}
+		if spec["tokenSecretRef"] != "xc-credentials" {
+			t.Errorf("tokenSecretRef = %v, want it kept", spec["tokenSecretRef"])
+		}
+	})
+
+	t.Run("filters by namespace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/support/bundle?namespaces=team-b", nil)
+		w := httptest.NewRecorder()
+		handler.Bundle(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var gateways []map[string]any
+		if err := json.Unmarshal(readSupportBundle(t, w.Body)["gateways.json"], &gateways); err != nil {
+			t.Fatalf("decode gateways: %v", err)
+		}
+		if len(gateways) != 1 {
+			t.Fatalf("expected 1 gateway, got %d", len(gateways))
+		}
+		if ns, _, _ := unstructured.NestedString(gateways[0], "metadata", "namespace"); ns != "team-b" {
+			t.Errorf("namespace = %q, want team-b", ns)
+		}
+	})
+
+	t.Run("rejects invalid namespaces", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/support/bundle?namespaces=Team_A", nil)
+		w := httptest.NewRecorder()
+		handler.Bundle(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestIsSecretKey(t *testing.T) {
+	tests := map[string]bool{
+		"password":       true,
+		"apiToken":       true,
+		"clientSecret":   true,
+		"private_key":    true,
+		"secretName":     false,
+		"tokenSecretRef": false,
+		"hostname":       false,
+	}
+	for key, want := range tests {
+		if got := isSecretKey(key); got != want {
+			t.Errorf("isSecretKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	mc "github.com/kubenetlabs/ngc/api/internal/multicluster"
+	"github.com/kubenetlabs/ngc/api/pkg/version"
+)
+
+var deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// Image repository basenames used to identify components from their Deployments.
+const (
+	operatorImageName   = "ngf-console-operator"
+	controllerImageName = "nginx-gateway-fabric"
+)
+
+// VersionHandler serves the version matrix of all deployed components.
+type VersionHandler struct {
+	Manager cluster.Provider
+}
+
+// BuildInfo describes the API server build.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// ComponentVersion describes a component discovered from its Deployment image.
+type ComponentVersion struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	Version   string `json:"version"`
+}
+
+// VersionResponse is the consolidated version matrix.
+type VersionResponse struct {
+	API        BuildInfo         `json:"api"`
+	Cluster    string            `json:"cluster,omitempty"`
+	Operator   *ComponentVersion `json:"operator,omitempty"`
+	Controller *ComponentVersion `json:"controller,omitempty"`
+	NGFVersion string            `json:"ngfVersion,omitempty"`
+}
+
+// Get returns the API build info plus the operator, controller, and NGF
+// versions detected on the default cluster. Cluster lookups are best-effort;
+// the API build info is always returned.
+func (h *VersionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	resp := VersionResponse{
+		API: BuildInfo{Version: version.Version, Commit: version.Commit, Date: version.Date},
+	}
+
+	if h.Manager == nil {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	k8s, err := h.Manager.Default()
+	if err != nil || k8s == nil {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	resp.Cluster = h.Manager.DefaultName()
+
+	dc := k8s.DynamicClient()
+	if dc == nil {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	list, err := dc.Resource(deploymentGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.WarnContext(r.Context(), "version: failed to list deployments", "error", err)
+	} else {
+		for _, item := range list.Items {
+			containers, _, _ := unstructured.NestedSlice(item.Object, "spec", "template", "spec", "containers")
+			for _, c := range containers {
+				cm, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				image, _ := cm["image"].(string)
+				repo, tag := splitImage(image)
+				cv := &ComponentVersion{Name: item.GetName(), Namespace: item.GetNamespace(), Image: image, Version: tag}
+				switch imageBaseName(repo) {
+				case operatorImageName:
+					if resp.Operator == nil {
+						resp.Operator = cv
+					}
+				case controllerImageName:
+					if resp.Controller == nil {
+						resp.Controller = cv
+					}
+				}
+			}
+		}
+	}
+
+	resp.NGFVersion = mc.DiscoverNGFVersion(ctx, dc)
+	if (resp.NGFVersion == "" || resp.NGFVersion == "installed") && resp.Controller != nil && resp.Controller.Version != "" {
+		resp.NGFVersion = resp.Controller.Version
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// splitImage splits a container image reference into its repository and tag.
+// Digests are stripped; an image without a tag returns an empty tag.
+func splitImage(image string) (repo, tag string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	slash := strings.LastIndex(image, "/")
+	if colon := strings.LastIndex(image, ":"); colon > slash {
+		return image[:colon], image[colon+1:]
+	}
+	return image, ""
+}
+
+// imageBaseName returns the last path element of an image repository.
+func imageBaseName(repo string) string {
+	return repo[strings.LastIndex(repo, "/")+1:]
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import "testing"
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image    string
+		wantRepo string
+		wantTag  string
+	}{
+		{"ghcr.io/nginx/nginx-gateway-fabric:2.1.0", "ghcr.io/nginx/nginx-gateway-fabric", "2.1.0"},
+		{"localhost:5000/ngf-console-operator:0.1.0", "localhost:5000/ngf-console-operator", "0.1.0"},
+		{"localhost:5000/ngf-console-operator", "localhost:5000/ngf-console-operator", ""},
+		{"nginx-gateway-fabric:edge@sha256:abc123", "nginx-gateway-fabric", "edge"},
+	}
+
+	for _, tt := range tests {
+		repo, tag := splitImage(tt.image)
+		if repo != tt.wantRepo || tag != tt.wantTag {
+			t.Errorf("splitImage(%q) = (%q, %q), want (%q, %q)", tt.image, repo, tag, tt.wantRepo, tt.wantTag)
+		}
+	}
+}
+
+func TestImageBaseName(t *testing.T) {
+	if got := imageBaseName("ghcr.io/nginx/nginx-gateway-fabric"); got != controllerImageName {
+		t.Errorf("expected %s, got %s", controllerImageName, got)
+	}
+	if got := imageBaseName("ngf-console-operator"); got != operatorImageName {
+		t.Errorf("expected %s, got %s", operatorImageName, got)
+	}
+}
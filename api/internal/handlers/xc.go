@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -13,12 +14,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 	"github.com/kubenetlabs/ngc/api/internal/xc"
 )
 
@@ -28,6 +32,53 @@ var distributedCloudPublishGVR = schema.GroupVersionResource{
 	Resource: "distributedcloudpublishes",
 }
 
+// reconcileRequestedAnnotation is set to the current time to make the
+// operator reconcile a resource whose spec has not changed.
+const reconcileRequestedAnnotation = "ngf-console.f5.com/reconcile-requested"
+
+// xcCredentialsSecretName is the Secret the API server writes to a publish's
+// namespace with the XC credentials the operator publishes with.
+const xcCredentialsSecretName = "ngf-console-xc-credentials"
+
+var secretGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+// Route kinds a DistributedCloudPublish can publish. httpRouteRef names a
+// route of this kind; an empty routeKind means HTTPRoute.
+const (
+	xcRouteKindHTTP = "HTTPRoute"
+	xcRouteKindGRPC = "GRPCRoute"
+)
+
+// validateXCRouteKind checks the routeKind of a preview or publish request.
+// WebSocket upgrades do not apply to gRPC, so they are rejected for GRPCRoutes.
+func validateXCRouteKind(routeKind string, webSocketEnabled bool) error {
+	switch routeKind {
+	case "", xcRouteKindHTTP:
+		return nil
+	case xcRouteKindGRPC:
+		if webSocketEnabled {
+			return fmt.Errorf("webSocketEnabled is not supported for GRPCRoutes")
+		}
+		return nil
+	}
+	return fmt.Errorf("routeKind must be %s or %s, got %q", xcRouteKindHTTP, xcRouteKindGRPC, routeKind)
+}
+
+// validateXCDomains checks the custom domains of a preview or publish request.
+// Each must be a DNS-1123 subdomain, optionally with a leading "*." wildcard.
+func validateXCDomains(domains []string) error {
+	for _, d := range domains {
+		errs := validation.IsDNS1123Subdomain(d)
+		if strings.HasPrefix(d, "*.") {
+			errs = validation.IsWildcardDNS1123Subdomain(d)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("invalid domain %q: %s", d, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 // XC request/response types
 
 // XCStatusResponse represents XC connectivity status.
@@ -43,8 +94,10 @@ type XCPublishRequest struct {
 	Name               string                 `json:"name"`
 	Namespace          string                 `json:"namespace"`
 	HTTPRouteRef       string                 `json:"httpRouteRef"`
+	RouteKind          string                 `json:"routeKind,omitempty"`
 	InferencePoolRef   string                 `json:"inferencePoolRef,omitempty"`
 	PublicHostname     string                 `json:"publicHostname,omitempty"`
+	Domains            []string               `json:"domains,omitempty"`
 	OriginAddress      string                 `json:"originAddress,omitempty"`
 	WAFEnabled         bool                   `json:"wafEnabled,omitempty"`
 	WAFPolicyName      string                 `json:"wafPolicyName,omitempty"`
@@ -55,19 +108,40 @@ type XCPublishRequest struct {
 
 // XCPublishResponse represents a DistributedCloudPublish resource response.
 type XCPublishResponse struct {
-	Name               string   `json:"name"`
-	Namespace          string   `json:"namespace"`
-	HTTPRouteRef       string   `json:"httpRouteRef"`
-	InferencePoolRef   string   `json:"inferencePoolRef,omitempty"`
-	Phase              string   `json:"phase"`
-	XCLoadBalancerName string   `json:"xcLoadBalancerName,omitempty"`
-	XCOriginPoolName   string   `json:"xcOriginPoolName,omitempty"`
-	XCVirtualIP        string   `json:"xcVirtualIP,omitempty"`
-	XCDNS              string   `json:"xcDNS,omitempty"`
-	WAFPolicyAttached  string   `json:"wafPolicyAttached,omitempty"`
-	LastSyncedAt       string   `json:"lastSyncedAt,omitempty"`
-	CreatedAt          string   `json:"createdAt"`
-	Errors             []string `json:"errors,omitempty"`
+	Name               string      `json:"name"`
+	Namespace          string      `json:"namespace"`
+	HTTPRouteRef       string      `json:"httpRouteRef"`
+	RouteKind          string      `json:"routeKind,omitempty"`
+	InferencePoolRef   string      `json:"inferencePoolRef,omitempty"`
+	Phase              string      `json:"phase"`
+	XCLoadBalancerName string      `json:"xcLoadBalancerName,omitempty"`
+	XCOriginPoolName   string      `json:"xcOriginPoolName,omitempty"`
+	XCVirtualIP        string      `json:"xcVirtualIP,omitempty"`
+	XCDNS              string      `json:"xcDNS,omitempty"`
+	WAFPolicyAttached  string      `json:"wafPolicyAttached,omitempty"`
+	LastSyncedAt       string      `json:"lastSyncedAt,omitempty"`
+	RateLimited        bool        `json:"rateLimited,omitempty"`
+	CreatedAt          string      `json:"createdAt"`
+	Warnings           []XCWarning `json:"warnings,omitempty"`
+}
+
+// XCWarning is a machine-readable warning about a preview or publish.
+type XCWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// XCWarningGatewayAddressPending is reported when the route's Gateway has no
+// status address yet, so the XC origin pool has nothing to point at.
+const XCWarningGatewayAddressPending = "GatewayAddressPending"
+
+// XCResyncResponse reports which publishes were queued for the operator to
+// re-sync to XC.
+type XCResyncResponse struct {
+	Total     int      `json:"total"`
+	Requested int      `json:"requested"`
+	Failed    int      `json:"failed"`
+	Errors    []string `json:"errors,omitempty"`
 }
 
 // XCMetricsResponse represents cross-cluster traffic metrics.
@@ -85,17 +159,21 @@ type XCRegion struct {
 	LatencyMs float64 `json:"latencyMs"`
 }
 
-// XCCredentialsRequest represents a request to save XC credentials.
+// XCCredentialsRequest represents a request to save XC credentials. Scope is
+// the Kubernetes namespace whose publishes use them; empty saves the global set.
 type XCCredentialsRequest struct {
 	Tenant    string `json:"tenant"`
 	APIToken  string `json:"apiToken"`
 	Namespace string `json:"namespace"`
+	Scope     string `json:"scope,omitempty"`
 }
 
 // XCCredentialsResponse represents XC credentials (token masked).
 type XCCredentialsResponse struct {
 	Tenant     string `json:"tenant"`
 	Namespace  string `json:"namespace"`
+	Scope      string `json:"scope,omitempty"`
+	APIToken   string `json:"apiToken,omitempty"` // masked
 	Configured bool   `json:"configured"`
 }
 
@@ -107,21 +185,25 @@ type XCTestConnectionResponse struct {
 
 // XCPreviewRequest represents a request to preview an XC publish configuration.
 type XCPreviewRequest struct {
-	Namespace          string `json:"namespace"`
-	HTTPRouteRef       string `json:"httpRouteRef"`
-	PublicHostname     string `json:"publicHostname,omitempty"`
-	OriginAddress      string `json:"originAddress,omitempty"`
-	WAFEnabled         bool   `json:"wafEnabled,omitempty"`
-	WAFPolicyName      string `json:"wafPolicyName,omitempty"`
-	WAFPolicyNamespace string `json:"wafPolicyNamespace,omitempty"`
-	WebSocketEnabled   bool   `json:"webSocketEnabled,omitempty"`
+	Namespace          string   `json:"namespace"`
+	HTTPRouteRef       string   `json:"httpRouteRef"`
+	RouteKind          string   `json:"routeKind,omitempty"`
+	PublicHostname     string   `json:"publicHostname,omitempty"`
+	Domains            []string `json:"domains,omitempty"`
+	OriginAddress      string   `json:"originAddress,omitempty"`
+	WAFEnabled         bool     `json:"wafEnabled,omitempty"`
+	WAFPolicyName      string   `json:"wafPolicyName,omitempty"`
+	WAFPolicyNamespace string   `json:"wafPolicyNamespace,omitempty"`
+	WebSocketEnabled   bool     `json:"webSocketEnabled,omitempty"`
 }
 
 // XCPreviewResponse represents the derived XC configuration for review.
 type XCPreviewResponse struct {
-	LoadBalancer *xc.HTTPLoadBalancer `json:"loadBalancer"`
-	OriginPool   *xc.OriginPoolConfig `json:"originPool"`
-	WAFPolicy    *string              `json:"wafPolicy,omitempty"`
+	LoadBalancer *xc.HTTPLoadBalancer  `json:"loadBalancer"`
+	OriginPool   *xc.OriginPoolConfig  `json:"originPool"`
+	HealthCheck  *xc.HealthCheckConfig `json:"healthCheck,omitempty"`
+	WAFPolicy    *string               `json:"wafPolicy,omitempty"`
+	Warnings     []XCWarning           `json:"warnings,omitempty"`
 }
 
 // WAFPolicyResponse represents a WAF policy available in XC.
@@ -134,11 +216,21 @@ type WAFPolicyResponse struct {
 
 // XCHandler handles F5 Distributed Cloud API requests.
 type XCHandler struct {
-	Store database.Store
+	DynamicClient dynamic.Interface
+	Store         database.Store
+	// WAFPolicyCacheTTL is how long WAF policy listings are reused.
+	// Zero uses DefaultWAFPolicyCacheTTL.
+	WAFPolicyCacheTTL time.Duration
+
+	wafPolicies wafPolicyCache
 }
 
-// getDynamicClient returns the dynamic client from the cluster context.
+// getDynamicClient returns the dynamic client from the handler field or falls back
+// to the cluster context's dynamic client.
 func (h *XCHandler) getDynamicClient(r *http.Request) dynamic.Interface {
+	if h.DynamicClient != nil {
+		return h.DynamicClient
+	}
 	k8s := cluster.ClientFromContext(r.Context())
 	if k8s == nil {
 		return nil
@@ -146,9 +238,42 @@ func (h *XCHandler) getDynamicClient(r *http.Request) dynamic.Interface {
 	return k8s.DynamicClient()
 }
 
-// getXCClient creates an XC API client from stored credentials.
-func (h *XCHandler) getXCClient(r *http.Request) (*xc.Client, error) {
-	creds, err := h.Store.GetXCCredentials(r.Context())
+// xcCredentials returns the XC credentials for the Kubernetes namespace
+// namespace: the set scoped to it, or the global set when it has none.
+func (h *XCHandler) xcCredentials(ctx context.Context, namespace string) (*database.XCCredentials, error) {
+	if namespace != "" {
+		creds, err := h.Store.GetXCCredentials(ctx, namespace)
+		if err != nil || creds != nil {
+			return creds, err
+		}
+	}
+	return h.Store.GetXCCredentials(ctx, "")
+}
+
+// maskToken hides all but the last four characters of an XC API token.
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return strings.Repeat("*", 8) + token[len(token)-4:]
+}
+
+// toXCCredentialsResponse converts stored credentials to a response with the
+// token masked.
+func toXCCredentialsResponse(creds *database.XCCredentials) XCCredentialsResponse {
+	return XCCredentialsResponse{
+		Tenant:     creds.Tenant,
+		Namespace:  creds.Namespace,
+		Scope:      creds.Scope,
+		APIToken:   maskToken(creds.APIToken),
+		Configured: true,
+	}
+}
+
+// getXCClient creates an XC API client from the stored credentials for the
+// Kubernetes namespace namespace.
+func (h *XCHandler) getXCClient(r *http.Request, namespace string) (*xc.Client, error) {
+	creds, err := h.xcCredentials(r.Context(), namespace)
 	if err != nil {
 		return nil, fmt.Errorf("reading XC credentials: %w", err)
 	}
@@ -158,6 +283,41 @@ func (h *XCHandler) getXCClient(r *http.Request) (*xc.Client, error) {
 	return xc.New(creds.Tenant, creds.APIToken), nil
 }
 
+// writeXCCredentialsSecret stores creds in the namespace's XC credentials
+// Secret, creating it or replacing its data. The operator reads it through
+// spec.distributedCloud.credentialsSecretRef.
+func writeXCCredentialsSecret(ctx context.Context, dc dynamic.Interface, namespace string, creds *database.XCCredentials) error {
+	data := map[string]interface{}{
+		"tenant":   base64.StdEncoding.EncodeToString([]byte(creds.Tenant)),
+		"apiToken": base64.StdEncoding.EncodeToString([]byte(creds.APIToken)),
+	}
+	client := dc.Resource(secretGVR).Namespace(namespace)
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      xcCredentialsSecretName,
+			"namespace": namespace,
+			"labels":    map[string]interface{}{"app.kubernetes.io/managed-by": "ngf-console"},
+		},
+		"type": "Opaque",
+		"data": data,
+	}}
+	_, err := client.Create(ctx, secret, metav1.CreateOptions{})
+	if !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(ctx, xcCredentialsSecretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing.Object["data"] = data
+		_, err = client.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
+
 // --- Credential Management ---
 
 // SaveCredentials stores XC connection credentials.
@@ -176,11 +336,18 @@ func (h *XCHandler) SaveCredentials(w http.ResponseWriter, r *http.Request) {
 	if req.Namespace == "" {
 		req.Namespace = "default"
 	}
+	if req.Scope != "" {
+		if errs := validation.IsDNS1123Label(req.Scope); len(errs) > 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid scope %q: must be a Kubernetes namespace name", req.Scope))
+			return
+		}
+	}
 
 	creds := database.XCCredentials{
 		Tenant:    req.Tenant,
 		APIToken:  req.APIToken,
 		Namespace: req.Namespace,
+		Scope:     req.Scope,
 	}
 
 	if err := h.Store.SaveXCCredentials(r.Context(), creds); err != nil {
@@ -188,17 +355,15 @@ func (h *XCHandler) SaveCredentials(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("XC credentials saved", "tenant", req.Tenant, "namespace", req.Namespace)
-	writeJSON(w, http.StatusOK, XCCredentialsResponse{
-		Tenant:     req.Tenant,
-		Namespace:  req.Namespace,
-		Configured: true,
-	})
+	slog.InfoContext(r.Context(), "XC credentials saved", "tenant", req.Tenant, "namespace", req.Namespace, "scope", req.Scope)
+	writeJSON(w, http.StatusOK, toXCCredentialsResponse(&creds))
 }
 
-// GetCredentials returns stored XC credentials (token masked).
+// GetCredentials returns the XC credentials used for the Kubernetes namespace
+// in ?scope= (token masked), or the global set when scope is omitted. The
+// response's scope is empty when a namespace falls back to the global set.
 func (h *XCHandler) GetCredentials(w http.ResponseWriter, r *http.Request) {
-	creds, err := h.Store.GetXCCredentials(r.Context())
+	creds, err := h.xcCredentials(r.Context(), r.URL.Query().Get("scope"))
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("reading credentials: %v", err))
 		return
@@ -209,27 +374,42 @@ func (h *XCHandler) GetCredentials(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, XCCredentialsResponse{
-		Tenant:     creds.Tenant,
-		Namespace:  creds.Namespace,
-		Configured: true,
-	})
+	writeJSON(w, http.StatusOK, toXCCredentialsResponse(creds))
+}
+
+// ListCredentials returns every stored credential set (tokens masked), the
+// global set first.
+func (h *XCHandler) ListCredentials(w http.ResponseWriter, r *http.Request) {
+	list, err := h.Store.ListXCCredentials(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing credentials: %v", err))
+		return
+	}
+
+	resp := make([]XCCredentialsResponse, 0, len(list))
+	for i := range list {
+		resp = append(resp, toXCCredentialsResponse(&list[i]))
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
 
-// DeleteCredentials removes stored XC credentials.
+// DeleteCredentials removes the XC credentials scoped to the Kubernetes
+// namespace in ?scope=, or the global set when scope is omitted.
 func (h *XCHandler) DeleteCredentials(w http.ResponseWriter, r *http.Request) {
-	if err := h.Store.DeleteXCCredentials(r.Context()); err != nil {
+	scope := r.URL.Query().Get("scope")
+	if err := h.Store.DeleteXCCredentials(r.Context(), scope); err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("deleting credentials: %v", err))
 		return
 	}
 
-	slog.Info("XC credentials deleted")
+	slog.InfoContext(r.Context(), "XC credentials deleted", "scope", scope)
 	writeJSON(w, http.StatusOK, map[string]string{"message": "XC credentials deleted"})
 }
 
-// TestConnection verifies XC connectivity using stored credentials.
+// TestConnection verifies XC connectivity using the stored credentials for
+// the Kubernetes namespace in ?scope=.
 func (h *XCHandler) TestConnection(w http.ResponseWriter, r *http.Request) {
-	client, err := h.getXCClient(r)
+	client, err := h.getXCClient(r, r.URL.Query().Get("scope"))
 	if err != nil {
 		writeJSON(w, http.StatusOK, XCTestConnectionResponse{
 			Connected: false,
@@ -254,12 +434,13 @@ func (h *XCHandler) TestConnection(w http.ResponseWriter, r *http.Request) {
 
 // --- Status & Metrics ---
 
-// Status returns the cross-cluster connectivity status.
+// Status returns the cross-cluster connectivity status, checking XC with the
+// credentials for the Kubernetes namespace in ?scope=.
 func (h *XCHandler) Status(w http.ResponseWriter, r *http.Request) {
 	resp := XCStatusResponse{}
 
 	// Check XC API connectivity.
-	creds, _ := h.Store.GetXCCredentials(r.Context())
+	creds, _ := h.xcCredentials(r.Context(), r.URL.Query().Get("scope"))
 	if creds != nil {
 		resp.Tenant = creds.Tenant
 		client := xc.New(creds.Tenant, creds.APIToken)
@@ -281,23 +462,112 @@ func (h *XCHandler) Status(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
-// Metrics returns cross-cluster traffic metrics (mock data for now).
+// Metrics returns XC traffic metrics for the configured namespace, aggregated
+// per region. Query parameters: window (duration, default 1h), loadBalancer
+// (restrict to a single HTTP load balancer), scope (Kubernetes namespace
+// whose credentials to use).
 func (h *XCHandler) Metrics(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, XCMetricsResponse{
-		TotalRequests: 45230,
-		AvgLatencyMs:  12.5,
-		ErrorRate:     0.02,
-		Regions: []XCRegion{
-			{Name: "us-east-1", Requests: 15000, LatencyMs: 10.2},
-			{Name: "eu-west-1", Requests: 20000, LatencyMs: 15.8},
-			{Name: "ap-southeast-1", Requests: 10230, LatencyMs: 11.5},
-		},
-	})
+	window := time.Hour
+	if v := r.URL.Query().Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid window: must be a positive duration such as 1h")
+			return
+		}
+		window = d
+	}
+
+	creds, err := h.xcCredentials(r.Context(), r.URL.Query().Get("scope"))
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("reading XC credentials: %v", err))
+		return
+	}
+	if creds == nil {
+		writeError(w, http.StatusServiceUnavailable, "XC credentials not configured")
+		return
+	}
+
+	var vhost string
+	if lb := r.URL.Query().Get("loadBalancer"); lb != "" {
+		vhost = xc.LoadBalancerVHost(lb)
+	}
+
+	xcClient := xc.New(creds.Tenant, creds.APIToken)
+	graph, err := xcClient.GetServiceGraphMetrics(r.Context(), creds.Namespace, xc.NewServiceGraphRequest(window, vhost))
+	if err != nil {
+		slog.WarnContext(r.Context(), "failed to query XC metrics", "error", err)
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("XC metrics unavailable: %v", err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, aggregateXCMetrics(graph.Data.Nodes, window))
+}
+
+// aggregateXCMetrics converts service graph nodes into per-region and total
+// request counts, request-weighted latency, and error rate over window.
+func aggregateXCMetrics(nodes []xc.ServiceGraphNode, window time.Duration) XCMetricsResponse {
+	type regionTotals struct {
+		requests, errors, latencyWeighted float64
+	}
+	byRegion := make(map[string]*regionTotals)
+	var order []string
+
+	for _, node := range nodes {
+		var reqRate, errRate, latency float64
+		for _, series := range node.Data.Metric.Downstream {
+			switch series.Type {
+			case xc.MetricRequestRate:
+				reqRate = series.Average()
+			case xc.MetricErrorRate:
+				errRate = series.Average()
+			case xc.MetricResponseLatency:
+				latency = series.Average()
+			}
+		}
+
+		region := node.ID.Site
+		if region == "" {
+			region = "unknown"
+		}
+		t, ok := byRegion[region]
+		if !ok {
+			t = &regionTotals{}
+			byRegion[region] = t
+			order = append(order, region)
+		}
+		requests := reqRate * window.Seconds()
+		t.requests += requests
+		t.errors += errRate * window.Seconds()
+		// XC reports latency in seconds.
+		t.latencyWeighted += latency * 1000 * requests
+	}
+
+	resp := XCMetricsResponse{Regions: make([]XCRegion, 0, len(order))}
+	var totalRequests, totalErrors, totalLatencyWeighted float64
+	for _, name := range order {
+		t := byRegion[name]
+		region := XCRegion{Name: name, Requests: int64(t.requests)}
+		if t.requests > 0 {
+			region.LatencyMs = t.latencyWeighted / t.requests
+		}
+		resp.Regions = append(resp.Regions, region)
+		totalRequests += t.requests
+		totalErrors += t.errors
+		totalLatencyWeighted += t.latencyWeighted
+	}
+
+	resp.TotalRequests = int64(totalRequests)
+	if totalRequests > 0 {
+		resp.AvgLatencyMs = totalLatencyWeighted / totalRequests
+		resp.ErrorRate = totalErrors / totalRequests
+	}
+	return resp
 }
 
 // --- Publish CRUD ---
 
-// Preview generates a preview of the XC HTTP LB config derived from an HTTPRoute.
+// Preview generates a preview of the XC HTTP LB config derived from an
+// HTTPRoute or GRPCRoute. A GRPCRoute's preview includes its gRPC health check.
 func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 	k8s := cluster.ClientFromContext(r.Context())
 	if k8s == nil {
@@ -315,21 +585,45 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "httpRouteRef is required")
 		return
 	}
+	if err := validateXCRouteKind(req.RouteKind, req.WebSocketEnabled); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateXCDomains(req.Domains); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if req.Namespace == "" {
 		req.Namespace = "default"
 	}
 
-	// Fetch the HTTPRoute.
-	route, err := k8s.GetHTTPRoute(r.Context(), req.Namespace, req.HTTPRouteRef)
-	if err != nil {
-		writeError(w, http.StatusNotFound, fmt.Sprintf("HTTPRoute %s/%s not found: %v", req.Namespace, req.HTTPRouteRef, err))
-		return
+	// Fetch the route.
+	var (
+		route      *gatewayv1.HTTPRoute
+		grpcRoute  *gatewayv1.GRPCRoute
+		parentRefs []gatewayv1.ParentReference
+		err        error
+	)
+	if req.RouteKind == xcRouteKindGRPC {
+		grpcRoute, err = k8s.GetGRPCRoute(r.Context(), req.Namespace, req.HTTPRouteRef)
+		if err != nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("GRPCRoute %s/%s not found: %v", req.Namespace, req.HTTPRouteRef, err))
+			return
+		}
+		parentRefs = grpcRoute.Spec.ParentRefs
+	} else {
+		route, err = k8s.GetHTTPRoute(r.Context(), req.Namespace, req.HTTPRouteRef)
+		if err != nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("HTTPRoute %s/%s not found: %v", req.Namespace, req.HTTPRouteRef, err))
+			return
+		}
+		parentRefs = route.Spec.ParentRefs
 	}
 
 	// Determine the Gateway's external address.
 	gatewayAddress := "pending"
-	if len(route.Spec.ParentRefs) > 0 {
-		parentRef := route.Spec.ParentRefs[0]
+	if len(parentRefs) > 0 {
+		parentRef := parentRefs[0]
 		gwNs := req.Namespace
 		if parentRef.Namespace != nil {
 			gwNs = string(*parentRef.Namespace)
@@ -343,9 +637,9 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get XC namespace from stored credentials.
+	// Get XC namespace from the credentials for the route's namespace.
 	xcNamespace := "default"
-	creds, _ := h.Store.GetXCCredentials(r.Context())
+	creds, _ := h.xcCredentials(r.Context(), req.Namespace)
 	if creds != nil {
 		xcNamespace = creds.Namespace
 	}
@@ -360,6 +654,7 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 		XCNamespace:        xcNamespace,
 		Tenant:             xcTenant,
 		PublicHostname:     req.PublicHostname,
+		Domains:            req.Domains,
 		WAFEnabled:         req.WAFEnabled,
 		WAFPolicyName:      req.WAFPolicyName,
 		WAFPolicyNamespace: req.WAFPolicyNamespace,
@@ -369,8 +664,9 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Detect port and TLS from Gateway listeners.
-	if len(route.Spec.ParentRefs) > 0 {
-		parentRef := route.Spec.ParentRefs[0]
+	var listener gatewayv1.Listener
+	if len(parentRefs) > 0 {
+		parentRef := parentRefs[0]
 		gwNs := req.Namespace
 		if parentRef.Namespace != nil {
 			gwNs = string(*parentRef.Namespace)
@@ -381,6 +677,7 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 				if parentRef.SectionName != nil && string(*parentRef.SectionName) != string(l.Name) {
 					continue
 				}
+				listener = l
 				opts.OriginPort = int32(l.Port)
 				if l.Protocol == "HTTPS" || l.Protocol == "TLS" {
 					opts.OriginTLS = true
@@ -396,12 +693,18 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 		originAddr = req.OriginAddress
 	}
 
-	lb := xc.MapHTTPRouteToLoadBalancer(route, originAddr, opts)
-	pool := xc.BuildOriginPool(req.HTTPRouteRef, originAddr, opts.OriginPort, opts.OriginTLS)
-
-	preview := XCPreviewResponse{
-		LoadBalancer: lb,
-		OriginPool:   pool,
+	var preview XCPreviewResponse
+	if grpcRoute != nil {
+		if err := xc.ValidateGRPCOrigin(string(listener.Name), listener.Protocol); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		preview.LoadBalancer = xc.MapGRPCRouteToLoadBalancer(grpcRoute, originAddr, opts)
+		preview.OriginPool = xc.BuildGRPCOriginPool(req.HTTPRouteRef, xcNamespace, originAddr, opts.OriginPort, opts.OriginTLS)
+		preview.HealthCheck = xc.BuildGRPCHealthCheck(req.HTTPRouteRef, xcNamespace)
+	} else {
+		preview.LoadBalancer = xc.MapHTTPRouteToLoadBalancer(route, originAddr, opts)
+		preview.OriginPool = xc.BuildOriginPool(req.HTTPRouteRef, originAddr, opts.OriginPort, opts.OriginTLS)
 	}
 	if req.WAFEnabled {
 		policyName := req.WAFPolicyName
@@ -414,19 +717,62 @@ func (h *XCHandler) Preview(w http.ResponseWriter, r *http.Request) {
 		}
 		preview.WAFPolicy = &wafDisplay
 	}
+	if req.OriginAddress == "" {
+		if warning := gatewayAddressWarning(r.Context(), k8s, req.Namespace, parentRefs); warning != nil {
+			preview.Warnings = append(preview.Warnings, *warning)
+		}
+	}
 
 	writeJSON(w, http.StatusOK, preview)
 }
 
-// Publish creates a new cross-cluster service publication.
-// It creates the CRD in K8s and also calls the XC API to create the HTTP LB and origin pool.
-func (h *XCHandler) Publish(w http.ResponseWriter, r *http.Request) {
-	k8s := cluster.ClientFromContext(r.Context())
-	if k8s == nil {
-		writeError(w, http.StatusServiceUnavailable, "no cluster context")
-		return
+// gatewayAddressWarning returns a GatewayAddressPending warning when the
+// Gateway of the route's first parentRef exists but has no status address
+// yet, and nil otherwise. A Gateway that cannot be read is left to the
+// operator to report.
+func gatewayAddressWarning(ctx context.Context, k8s *kubernetes.Client, namespace string, parentRefs []gatewayv1.ParentReference) *XCWarning {
+	if len(parentRefs) == 0 {
+		return nil
+	}
+	gwNs := namespace
+	if parentRefs[0].Namespace != nil {
+		gwNs = string(*parentRefs[0].Namespace)
+	}
+	gw, err := k8s.GetGateway(ctx, gwNs, string(parentRefs[0].Name))
+	if err != nil || len(gw.Status.Addresses) > 0 {
+		return nil
+	}
+	return &XCWarning{
+		Code:    XCWarningGatewayAddressPending,
+		Message: fmt.Sprintf("Gateway %s/%s has no address yet, so the XC origin pool would have no origin. Wait for the Gateway to be provisioned or set originAddress.", gwNs, gw.Name),
 	}
+}
 
+// routeParentRefs returns the parentRefs of the published route, or nil if
+// the route cannot be read.
+func routeParentRefs(ctx context.Context, k8s *kubernetes.Client, routeKind, namespace, name string) []gatewayv1.ParentReference {
+	if routeKind == xcRouteKindGRPC {
+		if route, err := k8s.GetGRPCRoute(ctx, namespace, name); err == nil {
+			return route.Spec.ParentRefs
+		}
+		return nil
+	}
+	if route, err := k8s.GetHTTPRoute(ctx, namespace, name); err == nil {
+		return route.Spec.ParentRefs
+	}
+	return nil
+}
+
+// Publish creates or updates the DistributedCloudPublish for a route and
+// returns 202 Accepted. The operator's XCPublishReconciler creates the XC
+// origin pool and HTTP LB, reporting progress in status.phase and errors in
+// status conditions.
+//
+// When no originAddress is set and the route's Gateway has no address yet,
+// Publish returns 409 with a GatewayAddressPending warning. With
+// ?allowPending=true it publishes anyway and returns the warning; the
+// operator then waits for the address.
+func (h *XCHandler) Publish(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
 	if dc == nil {
 		writeError(w, http.StatusServiceUnavailable, "no cluster context")
@@ -443,25 +789,69 @@ func (h *XCHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "name and httpRouteRef are required")
 		return
 	}
+	if err := validateXCRouteKind(req.RouteKind, req.WebSocketEnabled); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateXCDomains(req.Domains); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if req.Namespace == "" {
 		req.Namespace = "default"
 	}
 
+	var warnings []XCWarning
+	if k8s := cluster.ClientFromContext(r.Context()); k8s != nil && req.OriginAddress == "" {
+		parentRefs := routeParentRefs(r.Context(), k8s, req.RouteKind, req.Namespace, req.HTTPRouteRef)
+		if warning := gatewayAddressWarning(r.Context(), k8s, req.Namespace, parentRefs); warning != nil {
+			if r.URL.Query().Get("allowPending") != "true" {
+				writeJSON(w, http.StatusConflict, map[string]interface{}{
+					"error":     warning.Message + " Pass ?allowPending=true to publish anyway.",
+					"warnings":  []XCWarning{*warning},
+					"requestId": responseRequestID(w),
+				})
+				return
+			}
+			warnings = append(warnings, *warning)
+		}
+	}
+
 	// Build the distributedCloud spec for the CRD.
 	if req.DistributedCloud == nil {
 		req.DistributedCloud = map[string]interface{}{}
 	}
 
-	// Get XC credentials for tenant/namespace info.
-	creds, _ := h.Store.GetXCCredentials(r.Context())
+	// Get XC credentials for tenant/namespace info, selected by the
+	// publish's namespace. The operator publishes with them from the
+	// namespace's credentials Secret.
+	creds, _ := h.xcCredentials(r.Context(), req.Namespace)
 	if creds != nil {
+		if err := writeXCCredentialsSecret(r.Context(), dc, req.Namespace, creds); err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("writing XC credentials secret: %v", err))
+			return
+		}
 		req.DistributedCloud["tenant"] = creds.Tenant
 		req.DistributedCloud["namespace"] = creds.Namespace
+		req.DistributedCloud["credentialsSecretRef"] = xcCredentialsSecretName
 	}
 	if req.PublicHostname != "" {
 		req.DistributedCloud["publicHostname"] = req.PublicHostname
 	}
+	if len(req.Domains) > 0 {
+		domains := make([]interface{}, 0, len(req.Domains))
+		for _, d := range xc.MergeDomains(req.Domains...) {
+			domains = append(domains, d)
+		}
+		req.DistributedCloud["domains"] = domains
+	}
+	if req.OriginAddress != "" {
+		req.DistributedCloud["originAddress"] = req.OriginAddress
+	}
+	if req.WebSocketEnabled {
+		req.DistributedCloud["webSocketEnabled"] = true
+	}
 	if req.WAFEnabled {
 		policyName := req.WAFPolicyName
 		if policyName == "" {
@@ -475,169 +865,122 @@ func (h *XCHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Create or update the CRD object in K8s.
+	// Create the CRD object in K8s, or update its spec if it already exists.
 	obj := toXCPublishUnstructured(req)
-	created, err := dc.Resource(distributedCloudPublishGVR).Namespace(req.Namespace).Create(r.Context(), obj, metav1.CreateOptions{})
+	client := dc.Resource(distributedCloudPublishGVR).Namespace(req.Namespace)
+	action := "create"
+	published, err := client.Create(r.Context(), obj, metav1.CreateOptions{})
 	if k8serrors.IsAlreadyExists(err) {
-		// CRD already exists — fetch the existing one and re-publish XC resources.
-		slog.Info("CRD already exists, re-publishing XC resources", "name", req.Name, "namespace", req.Namespace)
-		existing, getErr := dc.Resource(distributedCloudPublishGVR).Namespace(req.Namespace).Get(r.Context(), req.Name, metav1.GetOptions{})
-		if getErr != nil {
-			writeError(w, http.StatusInternalServerError, fmt.Sprintf("fetching existing distributedcloudpublish: %v", getErr))
+		action = "update"
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			existing, getErr := client.Get(r.Context(), req.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+			existing.Object["spec"] = obj.Object["spec"]
+			published, getErr = client.Update(r.Context(), existing, metav1.UpdateOptions{})
+			return getErr
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("updating distributedcloudpublish: %v", err))
 			return
 		}
-		created = existing
 	} else if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("creating distributedcloudpublish: %v", err))
 		return
 	}
 
-	resp := toXCPublishResponse(created)
-
-	// Attempt to create XC resources via the XC API.
-	var xcErrors []string
-	if creds != nil {
-		xcClient := xc.New(creds.Tenant, creds.APIToken)
+	resp := toXCPublishResponse(published)
+	if resp.Phase == "" {
+		resp.Phase = "Pending"
+	}
+	resp.Warnings = warnings
 
-		// Fetch the HTTPRoute to derive config.
-		route, routeErr := k8s.GetHTTPRoute(r.Context(), req.Namespace, req.HTTPRouteRef)
-		if routeErr != nil {
-			xcErrors = append(xcErrors, fmt.Sprintf("Could not fetch HTTPRoute: %v", routeErr))
-			slog.Warn("could not fetch HTTPRoute for XC publish", "error", routeErr)
-		} else {
-			// Determine gateway address.
-			gatewayAddress := "pending"
-			var originPort int32 = 80
-			originTLS := false
-			if len(route.Spec.ParentRefs) > 0 {
-				parentRef := route.Spec.ParentRefs[0]
-				gwNs := req.Namespace
-				if parentRef.Namespace != nil {
-					gwNs = string(*parentRef.Namespace)
-				}
-				gw, gwErr := k8s.GetGateway(r.Context(), gwNs, string(parentRef.Name))
-				if gwErr == nil {
-					for _, addr := range gw.Status.Addresses {
-						gatewayAddress = addr.Value
-						break
-					}
-					for _, l := range gw.Spec.Listeners {
-						if parentRef.SectionName != nil && string(*parentRef.SectionName) != string(l.Name) {
-							continue
-						}
-						originPort = int32(l.Port)
-						if l.Protocol == "HTTPS" || l.Protocol == "TLS" {
-							originTLS = true
-						}
-						break
-					}
-				}
-			}
+	auditLog(h.Store, r.Context(), action, "DistributedCloudPublish", req.Name, req.Namespace, nil, resp)
+	writeJSON(w, http.StatusAccepted, resp)
+}
 
-			xcNs := creds.Namespace
-			opts := xc.MapOptions{
-				XCNamespace:        xcNs,
-				Tenant:             creds.Tenant,
-				PublicHostname:     req.PublicHostname,
-				WAFEnabled:         req.WAFEnabled,
-				WAFPolicyName:      req.WAFPolicyName,
-				WAFPolicyNamespace: req.WAFPolicyNamespace,
-				WebSocketEnabled:   req.WebSocketEnabled,
-				OriginPort:         originPort,
-				OriginTLS:          originTLS,
-			}
+// ResyncPublishes asks the operator to re-apply the XC resources of every
+// DistributedCloudPublish, e.g. after changing credentials or an XC outage.
+// The credentials Secret of each publish namespace is rewritten from the
+// current credentials first. Each publish is annotated with the request time,
+// which makes the operator re-sync it even though its spec is unchanged. The
+// results land in each publish's status, so the request returns 202 without
+// waiting for XC.
+func (h *XCHandler) ResyncPublishes(w http.ResponseWriter, r *http.Request) {
+	dc := h.getDynamicClient(r)
+	if dc == nil {
+		writeError(w, http.StatusServiceUnavailable, "no cluster context")
+		return
+	}
 
-			// Allow origin address override (e.g. when local hostname differs from public IP).
-			publishOriginAddr := gatewayAddress
-			if req.OriginAddress != "" {
-				publishOriginAddr = req.OriginAddress
-			}
+	list, err := dc.Resource(distributedCloudPublishGVR).Namespace("").List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("listing distributedcloudpublishes: %v", err))
+		return
+	}
 
-			// Create or replace origin pool.
-			pool := xc.BuildOriginPool(req.HTTPRouteRef, publishOriginAddr, originPort, originTLS)
-			_, poolErr := xcClient.CreateOriginPool(r.Context(), xcNs, *pool)
-			if poolErr != nil {
-				// Try replace if create failed (likely already exists).
-				_, replaceErr := xcClient.ReplaceOriginPool(r.Context(), xcNs, *pool)
-				if replaceErr != nil {
-					xcErrors = append(xcErrors, fmt.Sprintf("Origin pool: %v (replace also failed: %v)", poolErr, replaceErr))
-					slog.Warn("failed to create/replace XC origin pool", "createErr", poolErr, "replaceErr", replaceErr)
-				} else {
-					resp.XCOriginPoolName = pool.Metadata.Name
-					slog.Info("replaced existing XC origin pool", "name", pool.Metadata.Name)
-				}
-			} else {
-				resp.XCOriginPoolName = pool.Metadata.Name
-				slog.Info("created XC origin pool", "name", pool.Metadata.Name)
-			}
+	// Refresh each namespace's credentials Secret once. A namespace without
+	// credentials keeps the operator's own.
+	secretWritten := map[string]bool{}
+	secretErrs := map[string]error{}
+	for i := range list.Items {
+		ns := list.Items[i].GetNamespace()
+		if _, done := secretWritten[ns]; done {
+			continue
+		}
+		creds, err := h.xcCredentials(r.Context(), ns)
+		if err == nil && creds != nil {
+			err = writeXCCredentialsSecret(r.Context(), dc, ns, creds)
+		}
+		secretWritten[ns] = err == nil && creds != nil
+		if err != nil {
+			secretErrs[ns] = err
+		}
+	}
 
-			// Create or replace HTTP LB.
-			lb := xc.MapHTTPRouteToLoadBalancer(route, publishOriginAddr, opts)
-			_, lbErr := xcClient.CreateHTTPLoadBalancer(r.Context(), xcNs, *lb)
-			if lbErr != nil {
-				// Try replace if create failed (likely already exists).
-				_, replaceErr := xcClient.ReplaceHTTPLoadBalancer(r.Context(), xcNs, *lb)
-				if replaceErr != nil {
-					xcErrors = append(xcErrors, fmt.Sprintf("HTTP Load Balancer: %v (replace also failed: %v)", lbErr, replaceErr))
-					slog.Warn("failed to create/replace XC HTTP load balancer", "createErr", lbErr, "replaceErr", replaceErr)
-				} else {
-					resp.XCLoadBalancerName = lb.Metadata.Name
-					slog.Info("replaced existing XC HTTP load balancer", "name", lb.Metadata.Name)
-				}
-			} else {
-				resp.XCLoadBalancerName = lb.Metadata.Name
-				slog.Info("created XC HTTP load balancer", "name", lb.Metadata.Name)
+	requestedAt := time.Now().UTC().Format(time.RFC3339)
+	resp := XCResyncResponse{Total: len(list.Items)}
+	for i := range list.Items {
+		ns, name := list.Items[i].GetNamespace(), list.Items[i].GetName()
+		if err := secretErrs[ns]; err != nil {
+			resp.Failed++
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s/%s: writing XC credentials secret: %v", ns, name, err))
+			continue
+		}
+		client := dc.Resource(distributedCloudPublishGVR).Namespace(ns)
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			obj, err := client.Get(r.Context(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
 			}
-
-			// After creating/replacing the LB, fetch it back from XC to discover
-			// the auto-generated CNAME (ves-io-*.ac.vh.ves.io) and add it to the
-			// domains list so the LB responds on that hostname.
-			if resp.XCLoadBalancerName != "" {
-				h.addXCAutoDomain(r.Context(), xcClient, xcNs, lb)
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
 			}
-
-			if req.WAFEnabled {
-				policyName := req.WAFPolicyName
-				if policyName == "" {
-					policyName = "default"
-				}
-				if req.WAFPolicyNamespace != "" {
-					resp.WAFPolicyAttached = req.WAFPolicyNamespace + "/" + policyName
-				} else {
-					resp.WAFPolicyAttached = policyName
+			annotations[reconcileRequestedAnnotation] = requestedAt
+			obj.SetAnnotations(annotations)
+			if secretWritten[ns] {
+				if err := unstructured.SetNestedField(obj.Object, xcCredentialsSecretName, "spec", "distributedCloud", "credentialsSecretRef"); err != nil {
+					return err
 				}
 			}
+			_, err = client.Update(r.Context(), obj, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			resp.Failed++
+			resp.Errors = append(resp.Errors, fmt.Sprintf("%s/%s: %v", ns, name, err))
+			continue
 		}
+		resp.Requested++
 	}
 
-	// Update CRD status based on XC API results.
-	phase := "Published"
-	if len(xcErrors) > 0 {
-		phase = "Error"
-		resp.Errors = xcErrors
-	}
-	resp.Phase = phase
-	resp.LastSyncedAt = time.Now().UTC().Format(time.RFC3339)
-
-	statusPatch := map[string]any{
-		"status": map[string]any{
-			"phase":              phase,
-			"xcLoadBalancerName": resp.XCLoadBalancerName,
-			"xcOriginPoolName":   resp.XCOriginPoolName,
-			"wafPolicyAttached":  resp.WAFPolicyAttached,
-			"lastSyncedAt":       resp.LastSyncedAt,
-		},
-	}
-	patchBytes, _ := json.Marshal(statusPatch)
-	_, patchErr := dc.Resource(distributedCloudPublishGVR).Namespace(req.Namespace).Patch(
-		r.Context(), req.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}, "status",
-	)
-	if patchErr != nil {
-		slog.Warn("failed to patch CRD status", "error", patchErr)
-	}
-
-	auditLog(h.Store, r.Context(), "create", "DistributedCloudPublish", req.Name, req.Namespace, nil, resp)
-	writeJSON(w, http.StatusCreated, resp)
+	slog.InfoContext(r.Context(), "requested XC publish resync", "total", resp.Total, "requested", resp.Requested, "failed", resp.Failed)
+	auditLog(h.Store, r.Context(), "resync", "DistributedCloudPublish", "*", "", nil, map[string]int{
+		"total": resp.Total, "requested": resp.Requested, "failed": resp.Failed,
+	})
+	writeJSON(w, http.StatusAccepted, resp)
 }
 
 // ListPublishes returns all DistributedCloudPublish resources.
@@ -681,7 +1024,8 @@ func (h *XCHandler) GetPublish(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toXCPublishResponse(obj))
 }
 
-// DeletePublish removes a cross-cluster service publication and its XC resources.
+// DeletePublish deletes a DistributedCloudPublish. The operator's finalizer
+// deletes its XC resources before the resource goes away.
 func (h *XCHandler) DeletePublish(w http.ResponseWriter, r *http.Request) {
 	dc := h.getDynamicClient(r)
 	if dc == nil {
@@ -692,13 +1036,6 @@ func (h *XCHandler) DeletePublish(w http.ResponseWriter, r *http.Request) {
 	ns := chi.URLParam(r, "namespace")
 	name := chi.URLParam(r, "name")
 
-	// Attempt to clean up XC resources before deleting the CRD.
-	var warnings []string
-	obj, getErr := dc.Resource(distributedCloudPublishGVR).Namespace(ns).Get(r.Context(), name, metav1.GetOptions{})
-	if getErr == nil {
-		warnings = h.cleanupXCResources(r, obj)
-	}
-
 	err := dc.Resource(distributedCloudPublishGVR).Namespace(ns).Delete(r.Context(), name, metav1.DeleteOptions{})
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("deleting distributedcloudpublish %s/%s: %v", ns, name, err))
@@ -707,108 +1044,59 @@ func (h *XCHandler) DeletePublish(w http.ResponseWriter, r *http.Request) {
 
 	auditLog(h.Store, r.Context(), "delete", "DistributedCloudPublish", name, ns, map[string]string{"name": name, "namespace": ns}, nil)
 
-	resp := map[string]any{
+	writeJSON(w, http.StatusOK, map[string]any{
 		"message":   "distributedcloudpublish deleted",
 		"name":      name,
 		"namespace": ns,
-	}
-	if len(warnings) > 0 {
-		resp["warnings"] = warnings
-	}
-	writeJSON(w, http.StatusOK, resp)
-}
-
-// cleanupXCResources deletes XC HTTP LB and origin pool resources for a publish.
-// Returns a list of warnings for any cleanup failures.
-func (h *XCHandler) cleanupXCResources(r *http.Request, obj *unstructured.Unstructured) []string {
-	var warnings []string
-
-	creds, err := h.Store.GetXCCredentials(r.Context())
-	if err != nil || creds == nil {
-		return warnings
-	}
-
-	xcClient := xc.New(creds.Tenant, creds.APIToken)
-	xcNs := creds.Namespace
-
-	deletedLB := false
-	deletedPool := false
-
-	// Read the XC resource names from status.
-	status, _, _ := unstructured.NestedMap(obj.Object, "status")
-	if status != nil {
-		lbName, _, _ := unstructured.NestedString(status, "xcLoadBalancerName")
-		if lbName != "" {
-			if err := xcClient.DeleteHTTPLoadBalancer(r.Context(), xcNs, lbName); err != nil {
-				warnings = append(warnings, fmt.Sprintf("Failed to delete XC HTTP LB %q: %v", lbName, err))
-				slog.Warn("failed to delete XC HTTP LB on cleanup", "name", lbName, "error", err)
-			} else {
-				deletedLB = true
-				slog.Info("deleted XC HTTP LB", "name", lbName)
-			}
-		}
-
-		poolName, _, _ := unstructured.NestedString(status, "xcOriginPoolName")
-		if poolName != "" {
-			if err := xcClient.DeleteOriginPool(r.Context(), xcNs, poolName); err != nil {
-				warnings = append(warnings, fmt.Sprintf("Failed to delete XC origin pool %q: %v", poolName, err))
-				slog.Warn("failed to delete XC origin pool on cleanup", "name", poolName, "error", err)
-			} else {
-				deletedPool = true
-				slog.Info("deleted XC origin pool", "name", poolName)
-			}
-		}
-	}
-
-	// Fall back to name-based convention if status fields not set.
-	if !deletedLB || !deletedPool {
-		spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
-		if spec != nil {
-			httpRouteRef, _, _ := unstructured.NestedString(spec, "httpRouteRef")
-			if httpRouteRef != "" {
-				if !deletedLB {
-					lbName := "ngf-" + httpRouteRef
-					if err := xcClient.DeleteHTTPLoadBalancer(r.Context(), xcNs, lbName); err != nil {
-						slog.Warn("failed to delete XC HTTP LB by convention", "name", lbName, "error", err)
-					}
-				}
-				if !deletedPool {
-					poolName := "ngf-" + httpRouteRef + "-pool"
-					if err := xcClient.DeleteOriginPool(r.Context(), xcNs, poolName); err != nil {
-						slog.Warn("failed to delete XC origin pool by convention", "name", poolName, "error", err)
-					}
-				}
-			}
-		}
-	}
-
-	return warnings
+	})
 }
 
 // --- WAF ---
 
-// ListWAFPolicies returns available WAF policies from the XC tenant.
-// Queries both the user's namespace and the "shared" namespace.
+// ListWAFPolicies returns available WAF policies from the XC tenant of the
+// credentials for the Kubernetes namespace in ?scope=. Listings are cached
+// per tenant and XC namespace for WAFPolicyCacheTTL; ?refresh=true bypasses
+// the cache.
 func (h *XCHandler) ListWAFPolicies(w http.ResponseWriter, r *http.Request) {
-	xcClient, err := h.getXCClient(r)
+	scope := r.URL.Query().Get("scope")
+	xcClient, err := h.getXCClient(r, scope)
 	if err != nil {
 		writeJSON(w, http.StatusOK, []WAFPolicyResponse{})
 		return
 	}
 
-	creds, _ := h.Store.GetXCCredentials(r.Context())
+	creds, _ := h.xcCredentials(r.Context(), scope)
 	xcNs := "default"
 	if creds != nil {
 		xcNs = creds.Namespace
 	}
 
-	resp := make([]WAFPolicyResponse, 0)
+	ttl := h.WAFPolicyCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultWAFPolicyCacheTTL
+	}
+	refresh := r.URL.Query().Get("refresh") == "true"
+	key := xcClient.Tenant() + "/" + xcNs
+
+	resp := h.wafPolicies.get(r.Context(), key, ttl, refresh, func(ctx context.Context) ([]WAFPolicyResponse, bool) {
+		return listWAFPolicies(ctx, xcClient, xcNs)
+	})
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// listWAFPolicies lists app firewalls from the shared namespace and xcNs,
+// de-duplicated by namespace and name. ok is false when either listing
+// failed, in which case the result is partial.
+func listWAFPolicies(ctx context.Context, xcClient *xc.Client, xcNs string) (resp []WAFPolicyResponse, ok bool) {
+	resp = make([]WAFPolicyResponse, 0)
+	ok = true
 	seen := make(map[string]bool)
 
 	// List from shared namespace first (most WAF policies live here).
-	sharedFW, err := xcClient.ListAppFirewalls(r.Context(), "shared")
+	sharedFW, err := xcClient.ListAppFirewalls(ctx, "shared")
 	if err != nil {
-		slog.Warn("failed to list shared XC WAF policies", "error", err)
+		slog.WarnContext(ctx, "failed to list shared XC WAF policies", "error", err)
+		ok = false
 	}
 	for _, fw := range sharedFW {
 		ns := fw.Namespace
@@ -829,9 +1117,10 @@ func (h *XCHandler) ListWAFPolicies(w http.ResponseWriter, r *http.Request) {
 
 	// Also list from user's namespace if different from shared.
 	if xcNs != "shared" {
-		userFW, err := xcClient.ListAppFirewalls(r.Context(), xcNs)
+		userFW, err := xcClient.ListAppFirewalls(ctx, xcNs)
 		if err != nil {
-			slog.Warn("failed to list user XC WAF policies", "namespace", xcNs, "error", err)
+			slog.WarnContext(ctx, "failed to list user XC WAF policies", "namespace", xcNs, "error", err)
+			ok = false
 		}
 		for _, fw := range userFW {
 			ns := fw.Namespace
@@ -851,73 +1140,11 @@ func (h *XCHandler) ListWAFPolicies(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	writeJSON(w, http.StatusOK, resp)
-}
-
-// addXCAutoDomain fetches the LB from XC, looks for the auto-generated CNAME
-// (e.g. ves-io-{uuid}.ac.vh.ves.io), and adds it to the LB's domains list
-// so the LB responds on that hostname. This is a best-effort operation.
-func (h *XCHandler) addXCAutoDomain(ctx context.Context, xcClient *xc.Client, xcNs string, lb *xc.HTTPLoadBalancer) {
-	raw, err := xcClient.GetHTTPLoadBalancerRaw(ctx, xcNs, lb.Metadata.Name)
-	if err != nil {
-		slog.Warn("could not fetch LB to discover auto CNAME", "error", err)
-		return
-	}
-
-	// The auto-generated CNAME is typically in spec.auto_cert_info.dns_records
-	// or in the status/system_metadata. Search the raw JSON for ves.io hostnames.
-	autoDomain := findVesDomain(raw)
-	if autoDomain == "" {
-		slog.Info("no auto-generated ves.io domain found in LB response")
-		return
-	}
-
-	// Check if already in domains list.
-	for _, d := range lb.Spec.Domains {
-		if d == autoDomain {
-			return
-		}
-	}
-
-	// Add the auto domain and update the LB.
-	lb.Spec.Domains = append(lb.Spec.Domains, autoDomain)
-	_, err = xcClient.ReplaceHTTPLoadBalancer(ctx, xcNs, *lb)
-	if err != nil {
-		slog.Warn("could not update LB with auto CNAME domain", "domain", autoDomain, "error", err)
-	} else {
-		slog.Info("added XC auto CNAME to LB domains", "domain", autoDomain)
-	}
+	return resp, ok
 }
 
-// findVesDomain recursively searches a map for a string value containing ".vh.ves.io".
-func findVesDomain(data map[string]any) string {
-	for _, v := range data {
-		switch val := v.(type) {
-		case string:
-			if strings.Contains(val, ".vh.ves.io") {
-				return val
-			}
-		case map[string]any:
-			if result := findVesDomain(val); result != "" {
-				return result
-			}
-		case []any:
-			for _, item := range val {
-				if m, ok := item.(map[string]any); ok {
-					if result := findVesDomain(m); result != "" {
-						return result
-					}
-				}
-				if s, ok := item.(string); ok {
-					if strings.Contains(s, ".vh.ves.io") {
-						return s
-					}
-				}
-			}
-		}
-	}
-	return ""
-}
+// vesDomainSuffix is the DNS zone XC allocates auto-generated LB hostnames in.
+const vesDomainSuffix = ".vh.ves.io"
 
 // --- Helpers ---
 
@@ -936,6 +1163,10 @@ func toXCPublishUnstructured(req XCPublishRequest) *unstructured.Unstructured {
 		"httpRouteRef": req.HTTPRouteRef,
 	}
 
+	if req.RouteKind != "" {
+		spec["routeKind"] = req.RouteKind
+	}
+
 	if req.InferencePoolRef != "" {
 		spec["inferencePoolRef"] = req.InferencePoolRef
 	}
@@ -970,6 +1201,7 @@ func toXCPublishResponse(obj *unstructured.Unstructured) XCPublishResponse {
 	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
 	if spec != nil {
 		resp.HTTPRouteRef, _, _ = unstructured.NestedString(spec, "httpRouteRef")
+		resp.RouteKind, _, _ = unstructured.NestedString(spec, "routeKind")
 		resp.InferencePoolRef, _, _ = unstructured.NestedString(spec, "inferencePoolRef")
 	}
 
@@ -983,6 +1215,16 @@ func toXCPublishResponse(obj *unstructured.Unstructured) XCPublishResponse {
 		resp.WAFPolicyAttached, _, _ = unstructured.NestedString(status, "wafPolicyAttached")
 		lastSynced, _, _ := unstructured.NestedString(status, "lastSyncedAt")
 		resp.LastSyncedAt = lastSynced
+
+		// The operator retries a throttled publish itself; the flag only
+		// tells the UI why it is still pending.
+		conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+		for _, c := range conditions {
+			cond, ok := c.(map[string]any)
+			if ok && cond["type"] == "Ready" && cond["reason"] == "XCRateLimited" {
+				resp.RateLimited = true
+			}
+		}
 	}
 
 	return resp
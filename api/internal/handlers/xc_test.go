@@ -0,0 +1,473 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+	"github.com/kubenetlabs/ngc/api/internal/xc"
+)
+
+func TestAggregateXCMetrics(t *testing.T) {
+	series := func(metric string, values ...string) xc.MetricSeries {
+		s := xc.MetricSeries{Type: metric}
+		for _, v := range values {
+			s.Value.Raw = append(s.Value.Raw, xc.MetricSample{Value: v})
+		}
+		return s
+	}
+	node := func(site string, reqRate, errRate, latencySec string) xc.ServiceGraphNode {
+		n := xc.ServiceGraphNode{ID: xc.ServiceGraphNodeID{Site: site}}
+		n.Data.Metric.Downstream = []xc.MetricSeries{
+			series(xc.MetricRequestRate, reqRate),
+			series(xc.MetricErrorRate, errRate),
+			series(xc.MetricResponseLatency, latencySec),
+		}
+		return n
+	}
+
+	// 10 req/s at 20ms in one region, 30 req/s at 40ms in another, over 100s.
+	got := aggregateXCMetrics([]xc.ServiceGraphNode{
+		node("us-east", "10", "1", "0.02"),
+		node("eu-west", "30", "1", "0.04"),
+	}, 100*time.Second)
+
+	if got.TotalRequests != 4000 {
+		t.Errorf("expected 4000 total requests, got %d", got.TotalRequests)
+	}
+	if len(got.Regions) != 2 || got.Regions[0].Name != "us-east" || got.Regions[0].Requests != 1000 {
+		t.Errorf("unexpected regions: %+v", got.Regions)
+	}
+	if math.Abs(got.AvgLatencyMs-35) > 0.001 {
+		t.Errorf("expected request-weighted latency 35ms, got %f", got.AvgLatencyMs)
+	}
+	if math.Abs(got.ErrorRate-0.05) > 0.0001 {
+		t.Errorf("expected error rate 0.05, got %f", got.ErrorRate)
+	}
+}
+
+func TestAggregateXCMetrics_Empty(t *testing.T) {
+	got := aggregateXCMetrics(nil, time.Hour)
+	if got.TotalRequests != 0 || got.ErrorRate != 0 || len(got.Regions) != 0 {
+		t.Errorf("expected zero metrics, got %+v", got)
+	}
+}
+
+func TestXCHandler_PublishIsAsync(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	store := newMigrationTestStore(t)
+	if err := store.SaveXCCredentials(context.Background(), database.XCCredentials{Tenant: "acme", APIToken: "token", Namespace: "prod"}); err != nil {
+		t.Fatalf("save credentials: %v", err)
+	}
+	handler := &XCHandler{DynamicClient: dc, Store: store}
+
+	publish := func(body string) (int, XCPublishResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/xc/publish", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.Publish(w, req)
+		var resp XCPublishResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		return w.Code, resp
+	}
+
+	// The CRD is created and accepted without calling XC; the operator
+	// creates the XC resources and reports progress in status.
+	code, resp := publish(`{"name":"shop","namespace":"default","httpRouteRef":"shop","publicHostname":"shop.example.com"}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", code)
+	}
+	if resp.Phase != "Pending" || resp.XCLoadBalancerName != "" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	obj, err := dc.Resource(distributedCloudPublishGVR).Namespace("default").Get(context.Background(), "shop", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if ns, _, _ := unstructured.NestedString(obj.Object, "spec", "distributedCloud", "namespace"); ns != "prod" {
+		t.Errorf("expected XC namespace from credentials, got %q", ns)
+	}
+
+	// Publishing again updates the spec of the existing CRD.
+	code, _ = publish(`{"name":"shop","namespace":"default","httpRouteRef":"shop","publicHostname":"shop.example.com","wafEnabled":true,"wafPolicyNamespace":"shared","wafPolicyName":"fw"}`)
+	if code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", code)
+	}
+	obj, err = dc.Resource(distributedCloudPublishGVR).Namespace("default").Get(context.Background(), "shop", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if waf, _, _ := unstructured.NestedString(obj.Object, "spec", "distributedCloud", "wafPolicy"); waf != "shared/fw" {
+		t.Errorf("expected the spec to be updated, got wafPolicy %q", waf)
+	}
+}
+
+// seedXCPublishes creates publishes through the fake client. Objects passed
+// to the constructor are tracked under a guessed plural that the handler's
+// GVR never reads.
+func seedXCPublishes(t *testing.T, dc *fakedynamic.FakeDynamicClient, reqs ...XCPublishRequest) {
+	t.Helper()
+	for _, req := range reqs {
+		obj := toXCPublishUnstructured(req)
+		if _, err := dc.Resource(distributedCloudPublishGVR).Namespace(req.Namespace).Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("seed publish %s/%s: %v", req.Namespace, req.Name, err)
+		}
+	}
+}
+
+func TestXCHandler_ResyncPublishes(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	seedXCPublishes(t, dc,
+		XCPublishRequest{Name: "shop", Namespace: "default", HTTPRouteRef: "shop"},
+		XCPublishRequest{Name: "api", Namespace: "team-a", HTTPRouteRef: "api"},
+	)
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+
+	w := httptest.NewRecorder()
+	handler.ResyncPublishes(w, httptest.NewRequest(http.MethodPost, "/xc/publishes/resync", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", w.Code)
+	}
+	var resp XCResyncResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Total != 2 || resp.Requested != 2 || resp.Failed != 0 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	// The operator, not the API, talks to XC: each publish only gets the
+	// reconcile annotation.
+	for _, id := range [][2]string{{"default", "shop"}, {"team-a", "api"}} {
+		obj, err := dc.Resource(distributedCloudPublishGVR).Namespace(id[0]).Get(context.Background(), id[1], metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get publish: %v", err)
+		}
+		if obj.GetAnnotations()[reconcileRequestedAnnotation] == "" {
+			t.Errorf("%s/%s: expected the reconcile annotation, got %v", id[0], id[1], obj.GetAnnotations())
+		}
+	}
+}
+
+func TestToXCPublishResponse_RateLimited(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "shop", "namespace": "default"},
+		"status": map[string]any{
+			"phase": "Pending",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "reason": "XCRateLimited"},
+			},
+		},
+	}}
+	if resp := toXCPublishResponse(obj); !resp.RateLimited || resp.Phase != "Pending" {
+		t.Errorf("expected a rate-limited pending publish, got %+v", resp)
+	}
+
+	unstructured.SetNestedSlice(obj.Object, []any{
+		map[string]any{"type": "Ready", "status": "True", "reason": "Published"},
+	}, "status", "conditions")
+	if resp := toXCPublishResponse(obj); resp.RateLimited {
+		t.Errorf("expected rateLimited to clear once published, got %+v", resp)
+	}
+}
+
+func TestXCHandler_DeletePublish(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	seedXCPublishes(t, dc, XCPublishRequest{Name: "shop", Namespace: "default", HTTPRouteRef: "shop"})
+	dc.ClearActions()
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+
+	r := chi.NewRouter()
+	r.Delete("/xc/publish/{namespace}/{name}", handler.DeletePublish)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/xc/publish/default/shop", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+
+	// Only the CR is deleted; the operator's finalizer cleans up XC.
+	for _, a := range dc.Actions() {
+		if a.GetVerb() != "delete" {
+			t.Errorf("unexpected %s on %s", a.GetVerb(), a.GetResource().Resource)
+		}
+	}
+	if _, err := dc.Resource(distributedCloudPublishGVR).Namespace("default").Get(context.Background(), "shop", metav1.GetOptions{}); err == nil {
+		t.Error("expected the publish to be deleted")
+	}
+}
+
+func TestXCHandler_PublishValidatesRouteKind(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+
+	publish := func(body string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/xc/publish", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.Publish(w, req)
+		return w.Code
+	}
+
+	if code := publish(`{"name":"cart","httpRouteRef":"cart","routeKind":"TCPRoute"}`); code != http.StatusBadRequest {
+		t.Errorf("unknown routeKind: expected 400, got %d", code)
+	}
+	if code := publish(`{"name":"cart","httpRouteRef":"cart","routeKind":"GRPCRoute","webSocketEnabled":true}`); code != http.StatusBadRequest {
+		t.Errorf("WebSocket on a GRPCRoute: expected 400, got %d", code)
+	}
+
+	if code := publish(`{"name":"cart","httpRouteRef":"cart","routeKind":"GRPCRoute"}`); code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", code)
+	}
+	obj, err := dc.Resource(distributedCloudPublishGVR).Namespace("default").Get(context.Background(), "cart", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if kind, _, _ := unstructured.NestedString(obj.Object, "spec", "routeKind"); kind != "GRPCRoute" {
+		t.Errorf("expected spec.routeKind GRPCRoute, got %q", kind)
+	}
+}
+
+func TestXCHandler_PublishDomains(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+
+	publish := func(body string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/xc/publish", bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		handler.Publish(w, req)
+		return w.Code
+	}
+
+	for _, domain := range []string{"Shop.example.com", "shop_example.com", "*", "shop.*.example.com"} {
+		if code := publish(`{"name":"shop","httpRouteRef":"shop","domains":["` + domain + `"]}`); code != http.StatusBadRequest {
+			t.Errorf("domain %q: expected 400, got %d", domain, code)
+		}
+	}
+
+	if code := publish(`{"name":"shop","httpRouteRef":"shop","domains":["shop.example.com","*.shop.example.com","shop.example.com"]}`); code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", code)
+	}
+	obj, err := dc.Resource(distributedCloudPublishGVR).Namespace("default").Get(context.Background(), "shop", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	domains, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "distributedCloud", "domains")
+	if len(domains) != 2 || domains[0] != "shop.example.com" || domains[1] != "*.shop.example.com" {
+		t.Errorf("expected de-duplicated spec.distributedCloud.domains, got %v", domains)
+	}
+}
+
+func TestXCHandler_PublishPendingGatewayAddress(t *testing.T) {
+	scheme := setupScheme(t)
+	gwNamespace := gatewayv1.Namespace("infra")
+	route := func(name, gateway string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gateway), Namespace: &gwNamespace}},
+			}},
+		}
+	}
+	provisioning := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "provisioning", Namespace: "infra"}}
+	ready := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "infra"},
+		Status:     gatewayv1.GatewayStatus{Addresses: []gatewayv1.GatewayStatusAddress{{Value: "203.0.113.10"}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(route("new", "provisioning"), route("live", "ready"), provisioning, ready).Build()
+	k8s := kubernetes.NewForTest(fakeClient)
+
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+	r := chi.NewRouter()
+	r.Use(contextMiddleware(k8s))
+	r.Post("/xc/publish", handler.Publish)
+	r.Post("/xc/preview", handler.Preview)
+
+	post := func(url, body string) (int, []XCWarning) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(body)))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var resp struct {
+			Warnings []XCWarning `json:"warnings"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		return w.Code, resp.Warnings
+	}
+	pending := func(warnings []XCWarning) bool {
+		return len(warnings) == 1 && warnings[0].Code == XCWarningGatewayAddressPending
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		body        string
+		wantStatus  int
+		wantPending bool
+	}{
+		{name: "refused while the gateway has no address", url: "/xc/publish", body: `{"name":"new","httpRouteRef":"new"}`, wantStatus: http.StatusConflict, wantPending: true},
+		{name: "allowPending publishes with a warning", url: "/xc/publish?allowPending=true", body: `{"name":"new","httpRouteRef":"new"}`, wantStatus: http.StatusAccepted, wantPending: true},
+		{name: "originAddress skips the check", url: "/xc/publish", body: `{"name":"new-origin","httpRouteRef":"new","originAddress":"198.51.100.7"}`, wantStatus: http.StatusAccepted},
+		{name: "gateway with an address", url: "/xc/publish", body: `{"name":"live","httpRouteRef":"live"}`, wantStatus: http.StatusAccepted},
+		{name: "preview warns", url: "/xc/preview", body: `{"httpRouteRef":"new"}`, wantStatus: http.StatusOK, wantPending: true},
+		{name: "preview of a gateway with an address", url: "/xc/preview", body: `{"httpRouteRef":"live"}`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, warnings := post(tt.url, tt.body)
+			if code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, code)
+			}
+			if pending(warnings) != tt.wantPending {
+				t.Errorf("expected GatewayAddressPending warning: %v, got %+v", tt.wantPending, warnings)
+			}
+		})
+	}
+
+	list, err := dc.Resource(distributedCloudPublishGVR).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list publishes: %v", err)
+	}
+	if len(list.Items) != 3 {
+		t.Errorf("expected 3 publishes (refused publish not created), got %d", len(list.Items))
+	}
+}
+
+func TestXCHandler_NamespaceScopedCredentials(t *testing.T) {
+	dc := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		distributedCloudPublishGVR: "DistributedCloudPublishList",
+	})
+	handler := &XCHandler{DynamicClient: dc, Store: newMigrationTestStore(t)}
+
+	save := func(body string) (int, XCCredentialsResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.SaveCredentials(w, httptest.NewRequest(http.MethodPost, "/xc/credentials", bytes.NewReader([]byte(body))))
+		var resp XCCredentialsResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		return w.Code, resp
+	}
+	if code, resp := save(`{"tenant":"shared","apiToken":"global-token-1234","namespace":"prod"}`); code != http.StatusOK || resp.APIToken != "********1234" {
+		t.Fatalf("save global: code %d, resp %+v", code, resp)
+	}
+	if code, _ := save(`{"tenant":"team-a","apiToken":"team-a-token-5678","namespace":"team-a-xc","scope":"team-a"}`); code != http.StatusOK {
+		t.Fatalf("save scoped: expected 200, got %d", code)
+	}
+	if code, _ := save(`{"tenant":"bad","apiToken":"t","scope":"Not_A_Namespace"}`); code != http.StatusBadRequest {
+		t.Errorf("invalid scope: expected 400, got %d", code)
+	}
+
+	get := func(query string) XCCredentialsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetCredentials(w, httptest.NewRequest(http.MethodGet, "/xc/credentials"+query, nil))
+		var resp XCCredentialsResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		return resp
+	}
+	if resp := get("?scope=team-a"); resp.Tenant != "team-a" || resp.Scope != "team-a" || resp.APIToken != "********5678" {
+		t.Errorf("scoped credentials: %+v", resp)
+	}
+	if resp := get("?scope=team-b"); resp.Tenant != "shared" || resp.Scope != "" {
+		t.Errorf("expected team-b to fall back to the global set, got %+v", resp)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ListCredentials(w, httptest.NewRequest(http.MethodGet, "/xc/credentials/scopes", nil))
+	var list []XCCredentialsResponse
+	json.NewDecoder(w.Body).Decode(&list)
+	if len(list) != 2 || list[0].Scope != "" || list[1].Scope != "team-a" {
+		t.Fatalf("unexpected credential list: %+v", list)
+	}
+	for _, c := range list {
+		if c.APIToken == "global-token-1234" || c.APIToken == "team-a-token-5678" {
+			t.Errorf("token not masked: %+v", c)
+		}
+	}
+
+	// secretToken returns the API token in ns's XC credentials Secret.
+	secretToken := func(ns string) string {
+		t.Helper()
+		secret, err := dc.Resource(secretGVR).Namespace(ns).Get(context.Background(), xcCredentialsSecretName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get credentials secret in %s: %v", ns, err)
+		}
+		encoded, _, _ := unstructured.NestedString(secret.Object, "data", "apiToken")
+		token, _ := base64.StdEncoding.DecodeString(encoded)
+		return string(token)
+	}
+
+	// A publish takes the tenant and XC namespace of its namespace's set,
+	// and the operator gets the set's token through the credentials Secret.
+	tokens := map[string]string{"team-a": "team-a-token-5678", "team-b": "global-token-1234"}
+	for ns, want := range map[string]string{"team-a": "team-a", "team-b": "shared"} {
+		body := `{"name":"shop","namespace":"` + ns + `","httpRouteRef":"shop"}`
+		w := httptest.NewRecorder()
+		handler.Publish(w, httptest.NewRequest(http.MethodPost, "/xc/publish", bytes.NewReader([]byte(body))))
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("publish in %s: expected 202, got %d", ns, w.Code)
+		}
+		obj, err := dc.Resource(distributedCloudPublishGVR).Namespace(ns).Get(context.Background(), "shop", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get publish: %v", err)
+		}
+		if tenant, _, _ := unstructured.NestedString(obj.Object, "spec", "distributedCloud", "tenant"); tenant != want {
+			t.Errorf("publish in %s: tenant = %q, want %q", ns, tenant, want)
+		}
+		if ref, _, _ := unstructured.NestedString(obj.Object, "spec", "distributedCloud", "credentialsSecretRef"); ref != xcCredentialsSecretName {
+			t.Errorf("publish in %s: credentialsSecretRef = %q", ns, ref)
+		}
+		if got := secretToken(ns); got != tokens[ns] {
+			t.Errorf("publish in %s: secret token = %q, want %q", ns, got, tokens[ns])
+		}
+	}
+
+	// Deleting the scoped set makes team-a fall back to the global set.
+	w = httptest.NewRecorder()
+	handler.DeleteCredentials(w, httptest.NewRequest(http.MethodDelete, "/xc/credentials?scope=team-a", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete scoped: expected 200, got %d", w.Code)
+	}
+	if resp := get("?scope=team-a"); resp.Tenant != "shared" {
+		t.Errorf("expected fallback after delete, got %+v", resp)
+	}
+
+	// A resync hands the operator the credentials team-a now uses.
+	w = httptest.NewRecorder()
+	handler.ResyncPublishes(w, httptest.NewRequest(http.MethodPost, "/xc/publishes/resync", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("resync: expected 202, got %d", w.Code)
+	}
+	if got := secretToken("team-a"); got != "global-token-1234" {
+		t.Errorf("expected resync to rewrite team-a's secret, got %q", got)
+	}
+}
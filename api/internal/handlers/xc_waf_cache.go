@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultWAFPolicyCacheTTL is how long WAF policy listings are reused when
+// XCHandler.WAFPolicyCacheTTL is unset.
+const DefaultWAFPolicyCacheTTL = 60 * time.Second
+
+// wafPolicyCache holds recent WAF policy listings keyed by tenant and XC
+// namespace. Concurrent misses for the same key share one XC fetch.
+type wafPolicyCache struct {
+	mu      sync.Mutex
+	entries map[string]wafPolicyCacheEntry
+	group   singleflight.Group
+}
+
+type wafPolicyCacheEntry struct {
+	policies  []WAFPolicyResponse
+	fetchedAt time.Time
+}
+
+// get returns the cached listing for key if it is younger than ttl and
+// refresh is false. Otherwise it calls fetch, sharing the call with any
+// concurrent caller for the same key. Only complete listings (fetch returns
+// ok) are cached, so a failed XC call is retried on the next request.
+func (c *wafPolicyCache) get(ctx context.Context, key string, ttl time.Duration, refresh bool, fetch func(ctx context.Context) ([]WAFPolicyResponse, bool)) []WAFPolicyResponse {
+	if !refresh {
+		c.mu.Lock()
+		entry, found := c.entries[key]
+		c.mu.Unlock()
+		if found && time.Since(entry.fetchedAt) < ttl {
+			return entry.policies
+		}
+	}
+
+	v, _, _ := c.group.Do(key, func() (any, error) {
+		// The fetch is shared, so it must outlive the request that started it.
+		policies, ok := fetch(context.WithoutCancel(ctx))
+		if ok {
+			c.mu.Lock()
+			if c.entries == nil {
+				c.entries = make(map[string]wafPolicyCacheEntry)
+			}
+			c.entries[key] = wafPolicyCacheEntry{policies: policies, fetchedAt: time.Now()}
+			c.mu.Unlock()
+		}
+		return policies, nil
+	})
+	return v.([]WAFPolicyResponse)
+}
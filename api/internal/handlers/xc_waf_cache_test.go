@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWAFPolicyCache(t *testing.T) {
+	var c wafPolicyCache
+	var calls atomic.Int32
+	ok := true
+	fetch := func(ctx context.Context) ([]WAFPolicyResponse, bool) {
+		n := calls.Add(1)
+		return []WAFPolicyResponse{{Name: "fw", Namespace: "shared", Mode: strconv.Itoa(int(n))}}, ok
+	}
+	ctx := context.Background()
+
+	first := c.get(ctx, "acme/default", time.Minute, false, fetch)
+	second := c.get(ctx, "acme/default", time.Minute, false, fetch)
+	if calls.Load() != 1 || second[0].Mode != first[0].Mode {
+		t.Fatalf("expected a cache hit, got %d fetches", calls.Load())
+	}
+
+	// Keys are independent.
+	c.get(ctx, "acme/team-a", time.Minute, false, fetch)
+	if calls.Load() != 2 {
+		t.Errorf("expected a fetch for a new key, got %d fetches", calls.Load())
+	}
+
+	// refresh bypasses the cache and replaces the entry.
+	refreshed := c.get(ctx, "acme/default", time.Minute, true, fetch)
+	if calls.Load() != 3 || refreshed[0].Mode == first[0].Mode {
+		t.Errorf("expected refresh to refetch, got %d fetches", calls.Load())
+	}
+	if got := c.get(ctx, "acme/default", time.Minute, false, fetch); got[0].Mode != refreshed[0].Mode {
+		t.Errorf("expected the refreshed listing to be cached, got %+v", got)
+	}
+
+	// Expired entries are refetched.
+	c.get(ctx, "acme/default", 0, false, fetch)
+	if calls.Load() != 4 {
+		t.Errorf("expected an expired entry to be refetched, got %d fetches", calls.Load())
+	}
+
+	// Partial listings are returned but not cached.
+	ok = false
+	c.get(ctx, "acme/partial", time.Minute, false, fetch)
+	c.get(ctx, "acme/partial", time.Minute, false, fetch)
+	if calls.Load() != 6 {
+		t.Errorf("expected failed listings to be refetched, got %d fetches", calls.Load())
+	}
+}
+
+func TestWAFPolicyCache_SingleFlight(t *testing.T) {
+	var c wafPolicyCache
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) ([]WAFPolicyResponse, bool) {
+		calls.Add(1)
+		<-release
+		return []WAFPolicyResponse{{Name: "fw", Namespace: "shared"}}, true
+	}
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	for range callers {
+		go func() {
+			defer done.Done()
+			started.Done()
+			if got := c.get(context.Background(), "acme/default", time.Minute, false, fetch); len(got) != 1 {
+				t.Errorf("expected 1 policy, got %+v", got)
+			}
+		}()
+	}
+	started.Wait()
+	// Give the callers time to join the in-flight fetch before releasing it.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected concurrent misses to share 1 fetch, got %d", n)
+	}
+}
@@ -141,6 +141,18 @@ func (m *MockProvider) GetMetricsSummary(_ context.Context, _ string) (*MetricsS
 	}, nil
 }
 
+func (m *MockProvider) GetLiveMetrics(_ context.Context, pool string) (*LiveMetrics, error) {
+	if m.findPool(pool) == nil {
+		return nil, fmt.Errorf("pool %q not found", pool)
+	}
+	return &LiveMetrics{
+		Timestamp:  time.Now().UTC(),
+		QueueDepth: m.varyFloat(4.5, 2),
+		KVCachePct: m.varyFloat(62, 15),
+		TPS:        m.varyFloat(85, 20),
+	}, nil
+}
+
 func (m *MockProvider) GetPodMetrics(_ context.Context, pool string) ([]PodMetrics, error) {
 	p := m.findPool(pool)
 	if p == nil {
@@ -210,20 +222,24 @@ func (m *MockProvider) GetTTFTHistogram(_ context.Context, _ string) ([]Histogra
 	return buckets, nil
 }
 
-func (m *MockProvider) GetTPSThroughput(_ context.Context, _ string) ([]TimeseriesPoint, error) {
-	return m.generateTimeseries(60, 85, 25), nil
+func (m *MockProvider) GetTPSThroughput(_ context.Context, _ string, window time.Duration) ([]TimeseriesPoint, error) {
+	return m.generateTimeseries(window, 85, 25), nil
+}
+
+func (m *MockProvider) GetModelTPSThroughput(_ context.Context, _, _ string, window time.Duration) ([]TimeseriesPoint, error) {
+	return m.generateTimeseries(window, 85, 25), nil
 }
 
-func (m *MockProvider) GetQueueDepthSeries(_ context.Context, _ string) ([]TimeseriesPoint, error) {
-	return m.generateTimeseries(60, 5, 4), nil
+func (m *MockProvider) GetQueueDepthSeries(_ context.Context, _ string, window time.Duration) ([]TimeseriesPoint, error) {
+	return m.generateTimeseries(window, 5, 4), nil
 }
 
-func (m *MockProvider) GetGPUUtilSeries(_ context.Context, _ string) ([]TimeseriesPoint, error) {
-	return m.generateTimeseries(60, 72, 15), nil
+func (m *MockProvider) GetGPUUtilSeries(_ context.Context, _ string, window time.Duration) ([]TimeseriesPoint, error) {
+	return m.generateTimeseries(window, 72, 15), nil
 }
 
-func (m *MockProvider) GetKVCacheSeries(_ context.Context, _ string) ([]TimeseriesPoint, error) {
-	return m.generateTimeseries(60, 60, 18), nil
+func (m *MockProvider) GetKVCacheSeries(_ context.Context, _ string, window time.Duration) ([]TimeseriesPoint, error) {
+	return m.generateTimeseries(window, 60, 18), nil
 }
 
 func (m *MockProvider) GetCostEstimate(_ context.Context, pool string) (*CostEstimate, error) {
@@ -279,12 +295,14 @@ func (m *MockProvider) varyInt(base, spread int) int {
 	return v
 }
 
-func (m *MockProvider) generateTimeseries(points int, base, spread float64) []TimeseriesPoint {
+func (m *MockProvider) generateTimeseries(window time.Duration, base, spread float64) []TimeseriesPoint {
 	now := time.Now()
+	step := SeriesResolution(window)
+	points := int(window / step)
 	ts := make([]TimeseriesPoint, points)
 	for i := range ts {
 		ts[i] = TimeseriesPoint{
-			Timestamp: now.Add(-time.Duration(points-i) * time.Minute),
+			Timestamp: now.Add(-time.Duration(points-i) * step),
 			Value:     m.varyFloat(base, spread),
 		}
 	}
@@ -3,6 +3,7 @@ package inference
 import (
 	"context"
 	"testing"
+	"time"
 )
 
 func TestListPools(t *testing.T) {
@@ -116,7 +117,7 @@ func TestTimeseries(t *testing.T) {
 	p := NewMockProvider()
 	for _, tc := range []struct {
 		name string
-		fn   func(context.Context, string) ([]TimeseriesPoint, error)
+		fn   func(context.Context, string, time.Duration) ([]TimeseriesPoint, error)
 	}{
 		{"TPSThroughput", p.GetTPSThroughput},
 		{"QueueDepthSeries", p.GetQueueDepthSeries},
@@ -124,7 +125,7 @@ func TestTimeseries(t *testing.T) {
 		{"KVCacheSeries", p.GetKVCacheSeries},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			points, err := tc.fn(context.Background(), "llama3-70b-prod")
+			points, err := tc.fn(context.Background(), "llama3-70b-prod", DefaultSeriesWindow)
 			if err != nil {
 				t.Fatalf("returned error: %v", err)
 			}
@@ -141,6 +142,35 @@ func TestTimeseries(t *testing.T) {
 	}
 }
 
+func TestTimeseries_Resolution(t *testing.T) {
+	p := NewMockProvider()
+	for _, tc := range []struct {
+		window     time.Duration
+		wantPoints int
+		wantStep   time.Duration
+	}{
+		{window: 6 * time.Hour, wantPoints: 360, wantStep: time.Minute},
+		{window: 24 * time.Hour, wantPoints: 1440, wantStep: time.Minute},
+		{window: 7 * 24 * time.Hour, wantPoints: 168, wantStep: time.Hour},
+	} {
+		t.Run(tc.window.String(), func(t *testing.T) {
+			if got := SeriesResolution(tc.window); got != tc.wantStep {
+				t.Errorf("SeriesResolution(%s) = %s, want %s", tc.window, got, tc.wantStep)
+			}
+			points, err := p.GetTPSThroughput(context.Background(), "llama3-70b-prod", tc.window)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if len(points) != tc.wantPoints {
+				t.Fatalf("expected %d points, got %d", tc.wantPoints, len(points))
+			}
+			if step := points[1].Timestamp.Sub(points[0].Timestamp); step != tc.wantStep {
+				t.Errorf("step = %s, want %s", step, tc.wantStep)
+			}
+		})
+	}
+}
+
 func TestGetCostEstimate(t *testing.T) {
 	p := NewMockProvider()
 	cost, err := p.GetCostEstimate(context.Background(), "llama3-70b-prod")
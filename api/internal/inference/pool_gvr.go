@@ -0,0 +1,142 @@
+package inference
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// inferencePoolCandidates lists the InferencePool group/versions probed during
+// auto-detection, in order of preference.
+var inferencePoolCandidates = []schema.GroupVersion{
+	{Group: "inference.networking.k8s.io", Version: "v1"},
+	{Group: "inference.networking.x-k8s.io", Version: "v1alpha2"},
+}
+
+var (
+	inferencePoolMu       sync.RWMutex
+	inferencePoolGV       = inferencePoolCandidates[0]
+	inferencePoolPinned   bool
+	inferencePoolResolved bool
+)
+
+// InferencePoolGVR returns the GroupVersionResource used for InferencePool resources.
+func InferencePoolGVR() schema.GroupVersionResource {
+	inferencePoolMu.RLock()
+	defer inferencePoolMu.RUnlock()
+	return inferencePoolGV.WithResource("inferencepools")
+}
+
+// SetInferencePoolGroupVersion pins the InferencePool group/version
+// (e.g. "inference.networking.x-k8s.io/v1alpha2"), overriding auto-detection.
+func SetInferencePoolGroupVersion(gv string) error {
+	parsed, err := schema.ParseGroupVersion(gv)
+	if err != nil {
+		return fmt.Errorf("parsing inference pool group/version %q: %w", gv, err)
+	}
+	if parsed.Group == "" || parsed.Version == "" {
+		return fmt.Errorf("inference pool group/version %q must be of the form <group>/<version>", gv)
+	}
+
+	inferencePoolMu.Lock()
+	defer inferencePoolMu.Unlock()
+	inferencePoolGV = parsed
+	inferencePoolPinned = true
+	return nil
+}
+
+// ResolveInferencePoolGVR validates the pinned InferencePool group/version
+// against discovery, or auto-detects the first served candidate when none is
+// pinned. A pinned group/version that is not served is an error. If discovery
+// fails or no candidate is served, the current group/version is kept and
+// RunInferencePoolResolver retries.
+func ResolveInferencePoolGVR(disco discovery.DiscoveryInterface) error {
+	inferencePoolMu.RLock()
+	pinned, current := inferencePoolPinned, inferencePoolGV
+	inferencePoolMu.RUnlock()
+
+	if pinned {
+		served, err := servesInferencePools(disco, current)
+		if err != nil {
+			slog.Warn("failed to discover InferencePool group/version", "groupVersion", current.String(), "error", err)
+			return nil
+		}
+		if !served {
+			return fmt.Errorf("inferencepools not served at %s", current.String())
+		}
+		markInferencePoolResolved(current)
+		slog.Info("using pinned InferencePool group/version", "groupVersion", current.String())
+		return nil
+	}
+
+	for _, gv := range inferencePoolCandidates {
+		served, err := servesInferencePools(disco, gv)
+		if err != nil {
+			slog.Warn("failed to discover InferencePool group/version", "groupVersion", gv.String(), "error", err)
+			return nil
+		}
+		if served {
+			markInferencePoolResolved(gv)
+			slog.Info("detected InferencePool group/version", "groupVersion", gv.String())
+			return nil
+		}
+	}
+	slog.Warn("InferencePool CRD not found, using default group/version", "groupVersion", current.String())
+	return nil
+}
+
+// RunInferencePoolResolver re-runs ResolveInferencePoolGVR every interval
+// until it succeeds or ctx is cancelled, so a cluster that is unreachable at
+// startup or gains the CRD afterwards is picked up. It returns immediately if
+// the group/version is already resolved.
+func RunInferencePoolResolver(ctx context.Context, disco discovery.DiscoveryInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for !inferencePoolIsResolved() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := ResolveInferencePoolGVR(disco); err != nil {
+			slog.Error("InferencePool group/version unresolved", "error", err)
+		}
+	}
+}
+
+func markInferencePoolResolved(gv schema.GroupVersion) {
+	inferencePoolMu.Lock()
+	defer inferencePoolMu.Unlock()
+	inferencePoolGV = gv
+	inferencePoolResolved = true
+}
+
+func inferencePoolIsResolved() bool {
+	inferencePoolMu.RLock()
+	defer inferencePoolMu.RUnlock()
+	return inferencePoolResolved
+}
+
+// servesInferencePools reports whether the API server serves inferencepools
+// at gv. An unknown group/version is not an error.
+func servesInferencePools(disco discovery.DiscoveryInterface, gv schema.GroupVersion) (bool, error) {
+	resources, err := disco.ServerResourcesForGroupVersion(gv.String())
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == "inferencepools" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func resetInferencePoolGVR(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		inferencePoolGV = inferencePoolCandidates[0]
+		inferencePoolPinned = false
+		inferencePoolResolved = false
+	})
+}
+
+func fakeDiscoveryWithPools(groupVersion string) *fakediscovery.FakeDiscovery {
+	return &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{
+			{
+				GroupVersion: groupVersion,
+				APIResources: []metav1.APIResource{{Name: "inferencepools", Kind: "InferencePool", Namespaced: true}},
+			},
+		},
+	}}
+}
+
+func TestSetInferencePoolGroupVersion_Invalid(t *testing.T) {
+	resetInferencePoolGVR(t)
+
+	for _, gv := range []string{"", "v1", "a/b/c"} {
+		if err := SetInferencePoolGroupVersion(gv); err == nil {
+			t.Errorf("expected error for %q", gv)
+		}
+	}
+}
+
+func TestResolveInferencePoolGVR_AutoDetect(t *testing.T) {
+	resetInferencePoolGVR(t)
+
+	disco := fakeDiscoveryWithPools("inference.networking.x-k8s.io/v1alpha2")
+	if err := ResolveInferencePoolGVR(disco); err != nil {
+		t.Fatalf("ResolveInferencePoolGVR returned error: %v", err)
+	}
+	if got := InferencePoolGVR().Group; got != "inference.networking.x-k8s.io" {
+		t.Errorf("expected x-k8s.io group, got %s", got)
+	}
+}
+
+func TestResolveInferencePoolGVR_PinnedMissing(t *testing.T) {
+	resetInferencePoolGVR(t)
+
+	if err := SetInferencePoolGroupVersion("inference.networking.x-k8s.io/v1alpha2"); err != nil {
+		t.Fatalf("SetInferencePoolGroupVersion returned error: %v", err)
+	}
+	disco := fakeDiscoveryWithPools("inference.networking.k8s.io/v1")
+	if err := ResolveInferencePoolGVR(disco); err == nil {
+		t.Error("expected error when pinned group/version is not served")
+	}
+}
+
+func TestRunInferencePoolResolver_RetriesUnresolved(t *testing.T) {
+	resetInferencePoolGVR(t)
+
+	disco := fakeDiscoveryWithPools("example.com/v1")
+	if err := ResolveInferencePoolGVR(disco); err != nil {
+		t.Fatalf("ResolveInferencePoolGVR returned error: %v", err)
+	}
+	if got := InferencePoolGVR().GroupVersion(); got != inferencePoolCandidates[0] {
+		t.Fatalf("expected the default group/version, got %s", got)
+	}
+
+	// The CRD appears later; the background resolver picks it up and stops.
+	disco.Lock()
+	disco.Resources = fakeDiscoveryWithPools("inference.networking.x-k8s.io/v1alpha2").Resources
+	disco.Unlock()
+	done := make(chan struct{})
+	go func() {
+		RunInferencePoolResolver(context.Background(), disco, time.Millisecond)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolver did not stop after resolving")
+	}
+	if got := InferencePoolGVR().Group; got != "inference.networking.x-k8s.io" {
+		t.Errorf("expected the resolver to detect x-k8s.io, got %s", got)
+	}
+}
@@ -1,10 +1,16 @@
 package inference
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // MetricsProvider abstracts the data source for inference metrics.
 // The mock implementation generates synthetic data; the ClickHouse
 // implementation queries real telemetry tables.
+//
+// Timeseries methods return points covering the trailing window at the
+// resolution given by SeriesResolution.
 type MetricsProvider interface {
 	ListPools(ctx context.Context) ([]PoolStatus, error)
 	GetPool(ctx context.Context, name string) (*PoolStatus, error)
@@ -14,9 +20,17 @@ type MetricsProvider interface {
 	GetPodMetrics(ctx context.Context, pool string) ([]PodMetrics, error)
 	GetRecentEPPDecisions(ctx context.Context, pool string, limit int) ([]EPPDecision, error)
 	GetTTFTHistogram(ctx context.Context, pool string) ([]HistogramBucket, error)
-	GetTPSThroughput(ctx context.Context, pool string) ([]TimeseriesPoint, error)
-	GetQueueDepthSeries(ctx context.Context, pool string) ([]TimeseriesPoint, error)
-	GetGPUUtilSeries(ctx context.Context, pool string) ([]TimeseriesPoint, error)
-	GetKVCacheSeries(ctx context.Context, pool string) ([]TimeseriesPoint, error)
+	GetTPSThroughput(ctx context.Context, pool string, window time.Duration) ([]TimeseriesPoint, error)
+	GetModelTPSThroughput(ctx context.Context, pool, model string, window time.Duration) ([]TimeseriesPoint, error)
+	GetQueueDepthSeries(ctx context.Context, pool string, window time.Duration) ([]TimeseriesPoint, error)
+	GetGPUUtilSeries(ctx context.Context, pool string, window time.Duration) ([]TimeseriesPoint, error)
+	GetKVCacheSeries(ctx context.Context, pool string, window time.Duration) ([]TimeseriesPoint, error)
 	GetCostEstimate(ctx context.Context, pool string) (*CostEstimate, error)
 }
+
+// LiveMetricsProvider is implemented by providers that can report a pool's
+// latest metrics, which the live metrics stream pushes to the UI.
+// GetLiveMetrics returns nil when no recent sample exists.
+type LiveMetricsProvider interface {
+	GetLiveMetrics(ctx context.Context, pool string) (*LiveMetrics, error)
+}
@@ -30,9 +30,9 @@ const vllmMetricsPort = 8000
 
 // ClickHouse insert queries for scraper-produced data.
 const insertMetrics1m = `INSERT INTO ngf_inference_metrics_1m (
-	timestamp, cluster_name, pool_name, ttft_ms, tps, total_tokens,
+	timestamp, cluster_name, pool_name, model_name, ttft_ms, tps, total_tokens,
 	queue_depth, kv_cache_pct, prefix_cache_hit, gpu_util_pct
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 const insertPodMetrics = `INSERT INTO ngf_pod_metrics (
 	timestamp, cluster_name, pool_name, pod_name, node_name, gpu_id, gpu_type,
@@ -171,15 +171,9 @@ func (s *metricsScraper) scrapePoolInCluster(ctx context.Context, cc *mc.Cluster
 	}
 
 	now := time.Now().UTC()
-	var (
-		totalTTFT    float64
-		totalTPS     float64
-		totalTokens  uint64
-		totalQueue   float64
-		totalKV      float64
-		totalGPUUtil float64
-		podCount     int
-	)
+	// Pods are aggregated per served model, so a pool hosting several
+	// models gets one ngf_inference_metrics_1m row per model.
+	models := make(map[string]*modelAggregate)
 
 	for i := range podList.Items {
 		pod := &podList.Items[i]
@@ -202,7 +196,6 @@ func (s *metricsScraper) scrapePoolInCluster(ctx context.Context, cc *mc.Cluster
 
 		pm := s.parseVLLMMetrics(body, cc.Name, pool.Name, podName, nodeName)
 		s.applyDCGMMetrics(ctx, nodeName, &pm)
-		podCount++
 
 		// Compute counter deltas.
 		key := fmt.Sprintf("%s/%s/%s", cc.Name, ns, podName)
@@ -214,13 +207,19 @@ func (s *metricsScraper) scrapePoolInCluster(ctx context.Context, cc *mc.Cluster
 			podTPS = pm.tps
 		}
 
-		// Accumulate for pool-level aggregation.
-		totalTTFT += ttftAvg
-		totalTPS += podTPS
-		totalTokens += tokensDelta
-		totalQueue += float64(pm.queueDepth)
-		totalKV += pm.kvCachePct
-		totalGPUUtil += pm.gpuUtilPct
+		// Accumulate for per-model aggregation.
+		agg, ok := models[pm.modelName]
+		if !ok {
+			agg = &modelAggregate{}
+			models[pm.modelName] = agg
+		}
+		agg.ttft += ttftAvg
+		agg.tps += podTPS
+		agg.tokens += tokensDelta
+		agg.queue += float64(pm.queueDepth)
+		agg.kv += pm.kvCachePct
+		agg.gpuUtil += pm.gpuUtilPct
+		agg.pods++
 
 		// Write per-pod snapshot to ngf_pod_metrics.
 		if err := s.conn.Exec(ctx, insertPodMetrics,
@@ -233,26 +232,35 @@ func (s *metricsScraper) scrapePoolInCluster(ctx context.Context, cc *mc.Cluster
 		}
 	}
 
-	if podCount == 0 {
-		return
+	// Write one aggregated row per model to ngf_inference_metrics_1m.
+	for model, agg := range models {
+		n := float64(agg.pods)
+		if err := s.conn.Exec(ctx, insertMetrics1m,
+			now, cc.Name, pool.Name, model,
+			roundTo(agg.ttft/n, 2),
+			roundTo(agg.tps/n, 2),
+			agg.tokens,
+			uint32(math.Round(agg.queue/n)),
+			roundTo(agg.kv/n, 2),
+			uint8(0),
+			roundTo(agg.gpuUtil/n, 2),
+		); err != nil {
+			slog.Warn("scraper: failed to insert metrics_1m", "pool", pool.Name, "model", model, "error", err)
+		} else {
+			slog.Debug("scraper: wrote metrics", "pool", pool.Name, "model", model, "cluster", cc.Name, "pods", agg.pods)
+		}
 	}
+}
 
-	// Write aggregated pool-level row to ngf_inference_metrics_1m.
-	n := float64(podCount)
-	if err := s.conn.Exec(ctx, insertMetrics1m,
-		now, cc.Name, pool.Name,
-		roundTo(totalTTFT/n, 2),
-		roundTo(totalTPS/n, 2),
-		totalTokens,
-		uint32(math.Round(totalQueue/n)),
-		roundTo(totalKV/n, 2),
-		uint8(0),
-		roundTo(totalGPUUtil/n, 2),
-	); err != nil {
-		slog.Warn("scraper: failed to insert metrics_1m", "pool", pool.Name, "error", err)
-	} else {
-		slog.Debug("scraper: wrote metrics", "pool", pool.Name, "cluster", cc.Name, "pods", podCount)
-	}
+// modelAggregate sums the per-pod metrics of the pods serving one model.
+type modelAggregate struct {
+	ttft    float64
+	tps     float64
+	tokens  uint64
+	queue   float64
+	kv      float64
+	gpuUtil float64
+	pods    int
 }
 
 func (s *metricsScraper) fetchMetrics(ctx context.Context, podIP string) (string, error) {
@@ -374,6 +382,7 @@ func (s *metricsScraper) applyDCGMMetrics(ctx context.Context, nodeName string,
 
 // parsedPodMetrics holds raw scraped values for a single pod.
 type parsedPodMetrics struct {
+	modelName        string // model_name label of the vLLM metrics, "" if absent
 	tps              float64
 	queueDepth       int
 	requestsInFlight int
@@ -394,6 +403,13 @@ type parsedPodMetrics struct {
 func (s *metricsScraper) parseVLLMMetrics(body, clusterName, poolName, podName, nodeName string) parsedPodMetrics {
 	pm := parsedPodMetrics{}
 
+	pm.modelName = firstLabel(body, "model_name",
+		"vllm:num_requests_running",
+		"vllm_num_requests_running",
+		"vllm:generation_tokens_total",
+		"vllm_generation_tokens_total",
+	)
+
 	// Gauges — try vllm: prefix (newer) then vllm_ prefix (older).
 	pm.tps = firstFound(body,
 		"vllm:avg_generation_throughput_toks_per_s",
@@ -501,6 +517,68 @@ func firstFound(body string, names ...string) float64 {
 	return 0
 }
 
+// firstLabel returns the value of label on the first metric name found in
+// the body that carries it.
+func firstLabel(body, label string, names ...string) string {
+	for _, name := range names {
+		if v, ok := parsePrometheusLabel(body, name, label); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// parsePrometheusLabel extracts the value of label from the first sample of
+// a metric name in Prometheus text exposition format that carries it.
+func parsePrometheusLabel(body, name, label string) (string, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		open := strings.IndexByte(line, '{')
+		if open <= 0 || line[:open] != name {
+			continue
+		}
+		labels := line[open+1:]
+		for labels != "" {
+			eq := strings.Index(labels, "=\"")
+			if eq < 0 {
+				break
+			}
+			key := strings.TrimSpace(labels[:eq])
+			value, rest, ok := readLabelValue(labels[eq+2:])
+			if !ok {
+				break
+			}
+			if key == label {
+				return value, true
+			}
+			labels = strings.TrimLeft(rest, ", ")
+		}
+	}
+	return "", false
+}
+
+// readLabelValue reads a quoted label value up to its closing quote,
+// unescaping \", \\ and \n, and returns the value and the text after it.
+func readLabelValue(s string) (string, string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			return b.String(), s[i+1:], true
+		case c == '\\' && i+1 < len(s):
+			i++
+			if s[i] == 'n' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
 // parsePrometheusValue extracts a numeric value for a metric name from
 // Prometheus text exposition format. Handles both bare metrics and those
 // with labels (e.g., metric_name{label="val"} 123.45).
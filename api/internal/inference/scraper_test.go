@@ -156,6 +156,51 @@ vllm:num_requests_running 5
 	}
 }
 
+func TestParsePrometheusLabel(t *testing.T) {
+	body := `# HELP vllm:num_requests_running Number of requests currently running on GPU.
+vllm:num_requests_running{engine="0",model_name="meta-llama/Llama-3.1-8B"} 5
+vllm:num_requests_waiting{model_name="say \"hi\", then \\ go"} 1
+vllm:num_requests_swapped 0
+`
+	tests := []struct {
+		name      string
+		metric    string
+		label     string
+		wantVal   string
+		wantFound bool
+	}{
+		{name: "label after another label", metric: "vllm:num_requests_running", label: "model_name", wantVal: "meta-llama/Llama-3.1-8B", wantFound: true},
+		{name: "first label", metric: "vllm:num_requests_running", label: "engine", wantVal: "0", wantFound: true},
+		{name: "escaped quotes and commas", metric: "vllm:num_requests_waiting", label: "model_name", wantVal: `say "hi", then \ go`, wantFound: true},
+		{name: "label missing", metric: "vllm:num_requests_running", label: "pod", wantFound: false},
+		{name: "metric without labels", metric: "vllm:num_requests_swapped", label: "model_name", wantFound: false},
+		{name: "metric missing", metric: "vllm:nonexistent", label: "model_name", wantFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, found := parsePrometheusLabel(body, tt.metric, tt.label)
+			if found != tt.wantFound || val != tt.wantVal {
+				t.Errorf("parsePrometheusLabel(%q, %q) = (%q, %v), want (%q, %v)", tt.metric, tt.label, val, found, tt.wantVal, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestParseVLLMMetrics_ModelName(t *testing.T) {
+	body := `vllm:num_requests_running{model_name="mistral-7b"} 2
+vllm:generation_tokens_total{model_name="mistral-7b"} 100
+`
+	s := &metricsScraper{counters: make(map[string]*podCounters)}
+	if pm := s.parseVLLMMetrics(body, "c", "p", "pod", "node"); pm.modelName != "mistral-7b" {
+		t.Errorf("modelName = %q, want mistral-7b", pm.modelName)
+	}
+
+	// Unlabelled metrics leave the model empty.
+	if pm := s.parseVLLMMetrics("vllm_num_requests_running 8\n", "c", "p", "pod", "node"); pm.modelName != "" {
+		t.Errorf("modelName = %q, want empty", pm.modelName)
+	}
+}
+
 func TestUpdateCounters_FirstObservation(t *testing.T) {
 	s := &metricsScraper{counters: make(map[string]*podCounters)}
 
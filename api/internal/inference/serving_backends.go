@@ -0,0 +1,45 @@
+package inference
+
+import "strings"
+
+// ServingBackend describes a model server the operator can deploy for an
+// InferenceStack.
+type ServingBackend struct {
+	Name         string // servingBackend value, e.g. "vllm"
+	DefaultImage string // image used when a stack does not set serving.image
+	Port         int32  // port the InferencePool targets
+	Overridden   bool   // DefaultImage was configured rather than built in
+}
+
+// ServingDefaultsLabel marks the ConfigMap in which the operator publishes
+// its default model server image per serving backend. It matches the
+// operator's v1alpha1.ServingDefaultsLabel.
+const ServingDefaultsLabel = "ngf-console.f5.com/serving-defaults"
+
+// ServingBackends returns the serving backends and the port the InferencePool
+// targets for each. Default images are owned by the operator; fill them in
+// with WithServingDefaults.
+func ServingBackends() []ServingBackend {
+	return []ServingBackend{
+		{Name: "vllm", Port: 8000},
+		{Name: "tgi", Port: 80},
+		{Name: "triton", Port: 8001},
+		{Name: "ollama", Port: 11434},
+	}
+}
+
+// WithServingDefaults sets each backend's default image from the data of the
+// operator's serving defaults ConfigMap: one key per backend, plus
+// "overridden" listing the backends configured with --serving-images.
+func WithServingDefaults(backends []ServingBackend, data map[string]string) []ServingBackend {
+	overridden := strings.Split(data["overridden"], ",")
+	for i := range backends {
+		backends[i].DefaultImage = data[backends[i].Name]
+		for _, name := range overridden {
+			if name == backends[i].Name {
+				backends[i].Overridden = true
+			}
+		}
+	}
+	return backends
+}
@@ -0,0 +1,31 @@
+package inference
+
+import "testing"
+
+func TestWithServingDefaults(t *testing.T) {
+	backends := WithServingDefaults(ServingBackends(), map[string]string{
+		"vllm":       "registry.example.com/vllm-openai:v0.6.3",
+		"tgi":        "ghcr.io/huggingface/text-generation-inference:2.4.0",
+		"overridden": "vllm",
+	})
+	for _, b := range backends {
+		switch b.Name {
+		case "vllm":
+			if b.DefaultImage != "registry.example.com/vllm-openai:v0.6.3" || !b.Overridden {
+				t.Errorf("vllm = %+v, want the override", b)
+			}
+		case "tgi":
+			if b.DefaultImage != "ghcr.io/huggingface/text-generation-inference:2.4.0" || b.Overridden {
+				t.Errorf("tgi = %+v, want the published default", b)
+			}
+		default:
+			if b.DefaultImage != "" || b.Overridden {
+				t.Errorf("%s was not published, got %+v", b.Name, b)
+			}
+		}
+	}
+
+	if backends := WithServingDefaults(ServingBackends(), nil); backends[0].DefaultImage != "" || backends[0].Overridden {
+		t.Errorf("expected no images without published defaults, got %+v", backends[0])
+	}
+}
@@ -63,6 +63,14 @@ type MetricsSummary struct {
 	AvgGPUUtil        float64 `json:"avgGPUUtil"`
 }
 
+// LiveMetrics is the latest scraped snapshot of a pool's load.
+type LiveMetrics struct {
+	Timestamp  time.Time `json:"timestamp"`
+	QueueDepth float64   `json:"queueDepth"`
+	KVCachePct float64   `json:"kvCachePct"`
+	TPS        float64   `json:"tps"`
+}
+
 // HistogramBucket is one bar in a TTFT distribution histogram.
 type HistogramBucket struct {
 	RangeStart float64 `json:"rangeStart"`
@@ -76,6 +84,23 @@ type TimeseriesPoint struct {
 	Value     float64   `json:"value"`
 }
 
+// Timeseries windows and resolutions. Windows up to DefaultSeriesWindow are
+// served at per-minute resolution from raw samples; longer windows use rollups.
+const (
+	DefaultSeriesWindow = time.Hour
+	MaxSeriesWindow     = 90 * 24 * time.Hour
+	hourlyRollupWindow  = 24 * time.Hour
+)
+
+// SeriesResolution returns the bucket size used for a timeseries covering
+// window: one minute up to a day, one hour beyond that.
+func SeriesResolution(window time.Duration) time.Duration {
+	if window > hourlyRollupWindow {
+		return time.Hour
+	}
+	return time.Minute
+}
+
 // CostEstimate provides GPU cost projections.
 type CostEstimate struct {
 	GPUType      string  `json:"gpuType"`
@@ -1,6 +1,9 @@
 package kubernetes
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -8,11 +11,14 @@ import (
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -23,6 +29,7 @@ type Client struct {
 	dynamicClient dynamic.Interface
 	restConfig    *rest.Config
 	edition       editionCache // cached edition detection result
+	crds          crdCache     // cached CRD presence results
 }
 
 // RestConfig returns the underlying REST configuration.
@@ -35,6 +42,27 @@ func (c *Client) DynamicClient() dynamic.Interface {
 	return c.dynamicClient
 }
 
+// ServerVersion fetches the API server's /version. It is a lightweight
+// request suitable for checking that the cluster is reachable.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	if c.restConfig == nil {
+		return "", errors.New("no REST config available")
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(c.restConfig)
+	if err != nil {
+		return "", fmt.Errorf("creating discovery client: %w", err)
+	}
+	body, err := dc.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return "", fmt.Errorf("fetching server version: %w", err)
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("decoding server version: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
 // New creates a new Kubernetes client.
 // It tries in-cluster config first, then falls back to the provided kubeconfig path,
 // KUBECONFIG env, or ~/.kube/config.
@@ -46,6 +74,9 @@ func New(kubeconfig string) (*Client, error) {
 	if err := gatewayv1.Install(scheme); err != nil {
 		return nil, fmt.Errorf("adding gateway-api scheme: %w", err)
 	}
+	if err := gatewayv1alpha2.Install(scheme); err != nil {
+		return nil, fmt.Errorf("adding gateway-api v1alpha2 scheme: %w", err)
+	}
 	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("adding apiextensions scheme: %w", err)
 	}
@@ -80,6 +111,9 @@ func NewFromContext(kubeconfigPath, contextName string) (*Client, error) {
 	if err := gatewayv1.Install(scheme); err != nil {
 		return nil, fmt.Errorf("adding gateway-api scheme: %w", err)
 	}
+	if err := gatewayv1alpha2.Install(scheme); err != nil {
+		return nil, fmt.Errorf("adding gateway-api v1alpha2 scheme: %w", err)
+	}
 	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("adding apiextensions scheme: %w", err)
 	}
@@ -119,6 +153,9 @@ func NewFromRestConfig(cfg *rest.Config) (*Client, error) {
 	if err := gatewayv1.Install(scheme); err != nil {
 		return nil, fmt.Errorf("adding gateway-api scheme: %w", err)
 	}
+	if err := gatewayv1alpha2.Install(scheme); err != nil {
+		return nil, fmt.Errorf("adding gateway-api v1alpha2 scheme: %w", err)
+	}
 	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("adding apiextensions scheme: %w", err)
 	}
@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// crdCacheTTL controls how long a CRD presence result is reused, so a CRD
+// installed after startup is picked up within this window.
+const crdCacheTTL = 30 * time.Second
+
+// crdCache stores CRD presence results per Client, keyed by CRD name.
+type crdCache struct {
+	mu      sync.Mutex
+	entries map[string]crdCacheEntry
+}
+
+type crdCacheEntry struct {
+	installed bool
+	expires   time.Time
+}
+
+// CRDInstalled reports whether the CustomResourceDefinition with the given
+// name (e.g. "inferencestacks.ngf-console.f5.com") exists. Results are cached
+// for crdCacheTTL. Errors other than NotFound are returned and not cached, so
+// callers can tell an absent CRD from an unreachable API server.
+func (c *Client) CRDInstalled(ctx context.Context, name string) (bool, error) {
+	c.crds.mu.Lock()
+	entry, found := c.crds.entries[name]
+	c.crds.mu.Unlock()
+	if found && time.Now().Before(entry.expires) {
+		return entry.installed, nil
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	err := c.client.Get(ctx, client.ObjectKey{Name: name}, &crd)
+	installed := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("getting CRD %s: %w", name, err)
+	}
+
+	c.crds.mu.Lock()
+	if c.crds.entries == nil {
+		c.crds.entries = make(map[string]crdCacheEntry)
+	}
+	c.crds.entries[name] = crdCacheEntry{installed: installed, expires: time.Now().Add(crdCacheTTL)}
+	c.crds.mu.Unlock()
+	return installed, nil
+}
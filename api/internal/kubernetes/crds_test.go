@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCRDInstalled(t *testing.T) {
+	scheme := setupScheme(t)
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "inferencestacks.ngf-console.f5.com"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+	k8sClient := NewForTest(fakeClient)
+	ctx := context.Background()
+
+	installed, err := k8sClient.CRDInstalled(ctx, "inferencestacks.ngf-console.f5.com")
+	if err != nil || !installed {
+		t.Errorf("expected installed CRD, got %v, %v", installed, err)
+	}
+	installed, err = k8sClient.CRDInstalled(ctx, "gatewaybundles.ngf-console.f5.com")
+	if err != nil || installed {
+		t.Errorf("expected missing CRD, got %v, %v", installed, err)
+	}
+
+	// Results are cached, so deleting the CRD is not seen until the TTL expires.
+	if err := fakeClient.Delete(ctx, crd); err != nil {
+		t.Fatalf("deleting CRD: %v", err)
+	}
+	if installed, _ := k8sClient.CRDInstalled(ctx, "inferencestacks.ngf-console.f5.com"); !installed {
+		t.Error("expected cached result within TTL")
+	}
+}
+
+func TestCRDInstalled_LookupError(t *testing.T) {
+	// Without apiextensions in the scheme the lookup fails with a non-NotFound error.
+	fakeClient := fake.NewClientBuilder().Build()
+	k8sClient := NewForTest(fakeClient)
+
+	if _, err := k8sClient.CRDInstalled(context.Background(), "inferencestacks.ngf-console.f5.com"); err == nil {
+		t.Error("expected lookup error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -131,6 +132,57 @@ func (c *Client) DeleteHTTPRoute(ctx context.Context, namespace, name string) er
 	return nil
 }
 
+// ListGRPCRoutes returns all GRPCRoutes, optionally filtered by namespace.
+func (c *Client) ListGRPCRoutes(ctx context.Context, namespace string) ([]gatewayv1.GRPCRoute, error) {
+	var list gatewayv1.GRPCRouteList
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("listing grpcroutes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetGRPCRoute returns a single GRPCRoute by namespace and name.
+func (c *Client) GetGRPCRoute(ctx context.Context, namespace, name string) (*gatewayv1.GRPCRoute, error) {
+	var gr gatewayv1.GRPCRoute
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := c.client.Get(ctx, key, &gr); err != nil {
+		return nil, fmt.Errorf("getting grpcroute %s/%s: %w", namespace, name, err)
+	}
+	return &gr, nil
+}
+
+// CreateGRPCRoute creates a new GRPCRoute and returns the server-populated object.
+func (c *Client) CreateGRPCRoute(ctx context.Context, gr *gatewayv1.GRPCRoute) (*gatewayv1.GRPCRoute, error) {
+	if err := c.client.Create(ctx, gr); err != nil {
+		return nil, fmt.Errorf("creating grpcroute %s/%s: %w", gr.Namespace, gr.Name, err)
+	}
+	return gr, nil
+}
+
+// UpdateGRPCRoute updates an existing GRPCRoute and returns the server-populated object.
+func (c *Client) UpdateGRPCRoute(ctx context.Context, gr *gatewayv1.GRPCRoute) (*gatewayv1.GRPCRoute, error) {
+	if err := c.client.Update(ctx, gr); err != nil {
+		return nil, fmt.Errorf("updating grpcroute %s/%s: %w", gr.Namespace, gr.Name, err)
+	}
+	return gr, nil
+}
+
+// DeleteGRPCRoute deletes a GRPCRoute by namespace and name.
+func (c *Client) DeleteGRPCRoute(ctx context.Context, namespace, name string) error {
+	gr, err := c.GetGRPCRoute(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("fetching grpcroute for deletion %s/%s: %w", namespace, name, err)
+	}
+	if err := c.client.Delete(ctx, gr); err != nil {
+		return fmt.Errorf("deleting grpcroute %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
 // ListServices returns all Services, optionally filtered by namespace.
 func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.Service, error) {
 	var list corev1.ServiceList
@@ -144,6 +196,19 @@ func (c *Client) ListServices(ctx context.Context, namespace string) ([]corev1.S
 	return list.Items, nil
 }
 
+// ListEndpointSlices returns all EndpointSlices, optionally filtered by namespace.
+func (c *Client) ListEndpointSlices(ctx context.Context, namespace string) ([]discoveryv1.EndpointSlice, error) {
+	var list discoveryv1.EndpointSliceList
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("listing endpointslices: %w", err)
+	}
+	return list.Items, nil
+}
+
 // ListSecrets returns all Secrets, optionally filtered by namespace.
 func (c *Client) ListSecrets(ctx context.Context, namespace string) ([]corev1.Secret, error) {
 	var list corev1.SecretList
@@ -0,0 +1,163 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListTCPRoutes returns all TCPRoutes, optionally filtered by namespace.
+func (c *Client) ListTCPRoutes(ctx context.Context, namespace string) ([]gatewayv1alpha2.TCPRoute, error) {
+	var list gatewayv1alpha2.TCPRouteList
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("listing tcproutes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetTCPRoute returns a single TCPRoute by namespace and name.
+func (c *Client) GetTCPRoute(ctx context.Context, namespace, name string) (*gatewayv1alpha2.TCPRoute, error) {
+	var tr gatewayv1alpha2.TCPRoute
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := c.client.Get(ctx, key, &tr); err != nil {
+		return nil, fmt.Errorf("getting tcproute %s/%s: %w", namespace, name, err)
+	}
+	return &tr, nil
+}
+
+// CreateTCPRoute creates a new TCPRoute and returns the server-populated object.
+func (c *Client) CreateTCPRoute(ctx context.Context, tr *gatewayv1alpha2.TCPRoute) (*gatewayv1alpha2.TCPRoute, error) {
+	if err := c.client.Create(ctx, tr); err != nil {
+		return nil, fmt.Errorf("creating tcproute %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+	return tr, nil
+}
+
+// UpdateTCPRoute updates an existing TCPRoute and returns the server-populated object.
+func (c *Client) UpdateTCPRoute(ctx context.Context, tr *gatewayv1alpha2.TCPRoute) (*gatewayv1alpha2.TCPRoute, error) {
+	if err := c.client.Update(ctx, tr); err != nil {
+		return nil, fmt.Errorf("updating tcproute %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+	return tr, nil
+}
+
+// DeleteTCPRoute deletes a TCPRoute by namespace and name.
+func (c *Client) DeleteTCPRoute(ctx context.Context, namespace, name string) error {
+	tr, err := c.GetTCPRoute(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("fetching tcproute for deletion %s/%s: %w", namespace, name, err)
+	}
+	if err := c.client.Delete(ctx, tr); err != nil {
+		return fmt.Errorf("deleting tcproute %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// ListTLSRoutes returns all TLSRoutes, optionally filtered by namespace.
+func (c *Client) ListTLSRoutes(ctx context.Context, namespace string) ([]gatewayv1alpha2.TLSRoute, error) {
+	var list gatewayv1alpha2.TLSRouteList
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("listing tlsroutes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetTLSRoute returns a single TLSRoute by namespace and name.
+func (c *Client) GetTLSRoute(ctx context.Context, namespace, name string) (*gatewayv1alpha2.TLSRoute, error) {
+	var tr gatewayv1alpha2.TLSRoute
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := c.client.Get(ctx, key, &tr); err != nil {
+		return nil, fmt.Errorf("getting tlsroute %s/%s: %w", namespace, name, err)
+	}
+	return &tr, nil
+}
+
+// CreateTLSRoute creates a new TLSRoute and returns the server-populated object.
+func (c *Client) CreateTLSRoute(ctx context.Context, tr *gatewayv1alpha2.TLSRoute) (*gatewayv1alpha2.TLSRoute, error) {
+	if err := c.client.Create(ctx, tr); err != nil {
+		return nil, fmt.Errorf("creating tlsroute %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+	return tr, nil
+}
+
+// UpdateTLSRoute updates an existing TLSRoute and returns the server-populated object.
+func (c *Client) UpdateTLSRoute(ctx context.Context, tr *gatewayv1alpha2.TLSRoute) (*gatewayv1alpha2.TLSRoute, error) {
+	if err := c.client.Update(ctx, tr); err != nil {
+		return nil, fmt.Errorf("updating tlsroute %s/%s: %w", tr.Namespace, tr.Name, err)
+	}
+	return tr, nil
+}
+
+// DeleteTLSRoute deletes a TLSRoute by namespace and name.
+func (c *Client) DeleteTLSRoute(ctx context.Context, namespace, name string) error {
+	tr, err := c.GetTLSRoute(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("fetching tlsroute for deletion %s/%s: %w", namespace, name, err)
+	}
+	if err := c.client.Delete(ctx, tr); err != nil {
+		return fmt.Errorf("deleting tlsroute %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// ListUDPRoutes returns all UDPRoutes, optionally filtered by namespace.
+func (c *Client) ListUDPRoutes(ctx context.Context, namespace string) ([]gatewayv1alpha2.UDPRoute, error) {
+	var list gatewayv1alpha2.UDPRouteList
+	opts := []client.ListOption{}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := c.client.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("listing udproutes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetUDPRoute returns a single UDPRoute by namespace and name.
+func (c *Client) GetUDPRoute(ctx context.Context, namespace, name string) (*gatewayv1alpha2.UDPRoute, error) {
+	var ur gatewayv1alpha2.UDPRoute
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := c.client.Get(ctx, key, &ur); err != nil {
+		return nil, fmt.Errorf("getting udproute %s/%s: %w", namespace, name, err)
+	}
+	return &ur, nil
+}
+
+// CreateUDPRoute creates a new UDPRoute and returns the server-populated object.
+func (c *Client) CreateUDPRoute(ctx context.Context, ur *gatewayv1alpha2.UDPRoute) (*gatewayv1alpha2.UDPRoute, error) {
+	if err := c.client.Create(ctx, ur); err != nil {
+		return nil, fmt.Errorf("creating udproute %s/%s: %w", ur.Namespace, ur.Name, err)
+	}
+	return ur, nil
+}
+
+// UpdateUDPRoute updates an existing UDPRoute and returns the server-populated object.
+func (c *Client) UpdateUDPRoute(ctx context.Context, ur *gatewayv1alpha2.UDPRoute) (*gatewayv1alpha2.UDPRoute, error) {
+	if err := c.client.Update(ctx, ur); err != nil {
+		return nil, fmt.Errorf("updating udproute %s/%s: %w", ur.Namespace, ur.Name, err)
+	}
+	return ur, nil
+}
+
+// DeleteUDPRoute deletes a UDPRoute by namespace and name.
+func (c *Client) DeleteUDPRoute(ctx context.Context, namespace, name string) error {
+	ur, err := c.GetUDPRoute(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("fetching udproute for deletion %s/%s: %w", namespace, name, err)
+	}
+	if err := c.client.Delete(ctx, ur); err != nil {
+		return fmt.Errorf("deleting udproute %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
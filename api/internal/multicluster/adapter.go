@@ -2,10 +2,13 @@ package multicluster
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 )
@@ -23,16 +26,33 @@ type PoolAdapter struct {
 	pool           *ClientPool
 	defaultCluster string
 	editionCache   sync.Map // map[string]editionCacheEntry
+	failoverLabel  string   // label grouping replica clusters; empty disables failover
+}
+
+// AdapterOption configures optional PoolAdapter behavior.
+type AdapterOption func(*PoolAdapter)
+
+// WithFailover enables failover between replica clusters: clusters whose
+// ManagedCluster labels have the same value for groupLabel can stand in for
+// each other when one of them is marked unhealthy.
+func WithFailover(groupLabel string) AdapterOption {
+	return func(a *PoolAdapter) {
+		a.failoverLabel = groupLabel
+	}
 }
 
 // NewPoolAdapter creates an adapter that bridges the ClientPool to the
 // cluster.Manager interface. The defaultCluster name is used for
 // legacy (non-cluster-scoped) API routes.
-func NewPoolAdapter(pool *ClientPool, defaultCluster string) *PoolAdapter {
-	return &PoolAdapter{
+func NewPoolAdapter(pool *ClientPool, defaultCluster string, opts ...AdapterOption) *PoolAdapter {
+	a := &PoolAdapter{
 		pool:           pool,
 		defaultCluster: defaultCluster,
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // Pool returns the underlying ClientPool for direct access.
@@ -92,6 +112,65 @@ func (a *PoolAdapter) Names() []string {
 	return names
 }
 
+// SelectNames returns the sorted names of the clusters whose ManagedCluster
+// labels match selector, a Kubernetes label selector such as
+// "region=us-east,env!=dev".
+func (a *PoolAdapter) SelectNames(selector string) ([]string, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector %q: %w", selector, err)
+	}
+	clients := a.pool.Select(sel)
+	names := make([]string, 0, len(clients))
+	for _, cc := range clients {
+		names = append(names, cc.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Unhealthy reports whether the named cluster failed its last health check.
+// It is always false when failover is disabled, so requests behave as they
+// did before failover existed.
+func (a *PoolAdapter) Unhealthy(name string) bool {
+	if a.failoverLabel == "" {
+		return false
+	}
+	cc, ok := a.pool.Lookup(name)
+	return ok && cc.Unhealthy()
+}
+
+// Replica returns a healthy cluster in the same failover group as the named
+// cluster. When several qualify, the first by name is used so repeated
+// requests land on the same replica. ok is false when failover is disabled,
+// the cluster has no group label, or no other member is healthy.
+func (a *PoolAdapter) Replica(name string) (replica string, client *kubernetes.Client, ok bool) {
+	if a.failoverLabel == "" {
+		return "", nil, false
+	}
+	cc, found := a.pool.Lookup(name)
+	if !found {
+		return "", nil, false
+	}
+	group := cc.Label(a.failoverLabel)
+	if group == "" {
+		return "", nil, false
+	}
+
+	members := a.pool.Select(labels.SelectorFromSet(labels.Set{a.failoverLabel: group}))
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	for _, m := range members {
+		if m.Name == name || m.K8sClient == nil || !m.IsHealthy() {
+			continue
+		}
+		if m.CircuitBreaker != nil && m.CircuitBreaker.State() == StateOpen {
+			continue
+		}
+		return m.Name, m.K8sClient, true
+	}
+	return "", nil, false
+}
+
 // cachedEdition returns the cached edition for a cluster, refreshing if expired.
 func (a *PoolAdapter) cachedEdition(ctx context.Context, cc *ClusterClient) kubernetes.Edition {
 	if entry, ok := a.editionCache.Load(cc.Name); ok {
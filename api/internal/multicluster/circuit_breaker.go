@@ -14,6 +14,20 @@ const (
 	StateHalfOpen                      // Testing — one request allowed
 )
 
+// String returns the state's name as reported by the API.
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
 // CircuitBreaker implements a simple per-cluster circuit breaker.
 type CircuitBreaker struct {
 	mu               sync.Mutex
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/clientcmd"
@@ -20,22 +21,25 @@ import (
 
 // ClusterClient holds connection state for a single managed cluster.
 type ClusterClient struct {
-	mu             sync.RWMutex
-	Name           string
-	DisplayName    string
-	Region         string
-	Environment    string
-	K8sClient      *kubernetes.Client
-	PrometheusURL  string
-	IsLocal        bool
-	Healthy        bool
+	mu              sync.RWMutex
+	Name            string
+	DisplayName     string
+	Region          string
+	Environment     string
+	Labels          map[string]string // ManagedCluster labels, e.g. region or env
+	K8sClient       *kubernetes.Client
+	PrometheusURL   string
+	IsLocal         bool
+	Healthy         bool
 	LastHealthCheck time.Time
-	K8sVersion     string
-	NGFVersion     string
-	AgentInstalled bool
-	ResourceCounts *ResourceCounts
-	GPUCapacity    *GPUCapacitySummary
-	CircuitBreaker *CircuitBreaker
+	LastHeartbeat   time.Time // zero until the agent's first heartbeat
+	Stale           bool      // the agent stopped heartbeating; see RunStaleDetector
+	K8sVersion      string
+	NGFVersion      string
+	AgentInstalled  bool
+	ResourceCounts  *ResourceCounts
+	GPUCapacity     *GPUCapacitySummary
+	CircuitBreaker  *CircuitBreaker
 }
 
 // SetHealthy updates the health status and last check time (thread-safe).
@@ -46,6 +50,73 @@ func (cc *ClusterClient) SetHealthy(healthy bool) {
 	cc.LastHealthCheck = time.Now()
 }
 
+// IsHealthy reports whether the cluster passed its last health check
+// (thread-safe).
+func (cc *ClusterClient) IsHealthy() bool {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return cc.Healthy
+}
+
+// Unhealthy reports whether the cluster failed its last health check
+// (thread-safe). It is false until the first check has run.
+func (cc *ClusterClient) Unhealthy() bool {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return !cc.Healthy && !cc.LastHealthCheck.IsZero()
+}
+
+// HealthSnapshot is a consistent view of a cluster's health-check state.
+type HealthSnapshot struct {
+	Healthy         bool
+	LastHealthCheck time.Time // zero until the first check or heartbeat
+	LastHeartbeat   time.Time // zero until the agent's first heartbeat
+	Stale           bool
+	K8sVersion      string
+}
+
+// Health returns the cluster's current health-check state (thread-safe).
+func (cc *ClusterClient) Health() HealthSnapshot {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return HealthSnapshot{
+		Healthy:         cc.Healthy,
+		LastHealthCheck: cc.LastHealthCheck,
+		LastHeartbeat:   cc.LastHeartbeat,
+		Stale:           cc.Stale,
+		K8sVersion:      cc.K8sVersion,
+	}
+}
+
+// GPU returns the GPU capacity from the cluster's last agent heartbeat, or nil
+// if no agent has reported one (thread-safe).
+func (cc *ClusterClient) GPU() *GPUCapacitySummary {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return cc.GPUCapacity
+}
+
+// Label returns the value of the named ManagedCluster label (thread-safe).
+func (cc *ClusterClient) Label(key string) string {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return cc.Labels[key]
+}
+
+// SetLabels replaces the cluster's labels (thread-safe).
+func (cc *ClusterClient) SetLabels(l map[string]string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.Labels = l
+}
+
+// matches reports whether the cluster's labels match selector (thread-safe).
+func (cc *ClusterClient) matches(selector labels.Selector) bool {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return selector.Matches(labels.Set(cc.Labels))
+}
+
 // SetHeartbeat updates fields from an agent heartbeat (thread-safe).
 func (cc *ClusterClient) SetHeartbeat(k8sVersion, ngfVersion string, rc *ResourceCounts, gpu *GPUCapacitySummary) {
 	cc.mu.Lock()
@@ -57,14 +128,34 @@ func (cc *ClusterClient) SetHeartbeat(k8sVersion, ngfVersion string, rc *Resourc
 	cc.GPUCapacity = gpu
 	cc.Healthy = true
 	cc.LastHealthCheck = time.Now()
+	cc.LastHeartbeat = cc.LastHealthCheck
+	cc.Stale = false
 	cc.CircuitBreaker.RecordSuccess()
 }
 
+// seedHeartbeat sets the last heartbeat time from stored history unless the
+// agent has heartbeated since (thread-safe).
+func (cc *ClusterClient) seedHeartbeat(t time.Time) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.LastHeartbeat.IsZero() {
+		cc.LastHeartbeat = t
+	}
+}
+
+// SetStale marks whether the cluster's agent has stopped heartbeating
+// (thread-safe).
+func (cc *ClusterClient) SetStale(stale bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.Stale = stale
+}
+
 // ClientPool maintains a thread-safe pool of K8s clients built from
 // ManagedCluster CRDs on the hub cluster.
 type ClientPool struct {
-	mu        sync.RWMutex
-	clients   map[string]*ClusterClient
+	mu         sync.RWMutex
+	clients    map[string]*ClusterClient
 	hubDynamic dynamic.Interface
 	namespace  string
 }
@@ -98,10 +189,12 @@ func (p *ClientPool) Sync(ctx context.Context) error {
 		desired[name] = true
 
 		p.mu.RLock()
-		_, exists := p.clients[name]
+		existing, exists := p.clients[name]
 		p.mu.RUnlock()
 
 		if exists {
+			// Labels can change without the client needing a rebuild.
+			existing.SetLabels(item.GetLabels())
 			continue
 		}
 
@@ -148,6 +241,15 @@ func (p *ClientPool) Get(name string) (*ClusterClient, error) {
 	return cc, nil
 }
 
+// Lookup returns the ClusterClient by name regardless of its circuit breaker,
+// for callers that report on a cluster rather than send requests to it.
+func (p *ClientPool) Lookup(name string) (*ClusterClient, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	cc, ok := p.clients[name]
+	return cc, ok
+}
+
 // List returns all registered ClusterClients.
 func (p *ClientPool) List() []*ClusterClient {
 	p.mu.RLock()
@@ -160,6 +262,21 @@ func (p *ClientPool) List() []*ClusterClient {
 	return result
 }
 
+// Select returns the registered ClusterClients whose ManagedCluster labels
+// match selector.
+func (p *ClientPool) Select(selector labels.Selector) []*ClusterClient {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := make([]*ClusterClient, 0, len(p.clients))
+	for _, cc := range p.clients {
+		if cc.matches(selector) {
+			result = append(result, cc)
+		}
+	}
+	return result
+}
+
 // Names returns the names of all registered clusters.
 func (p *ClientPool) Names() []string {
 	p.mu.RLock()
@@ -244,6 +361,7 @@ func (p *ClientPool) buildClient(ctx context.Context, item *unstructured.Unstruc
 		DisplayName:    displayName,
 		Region:         region,
 		Environment:    environment,
+		Labels:         item.GetLabels(),
 		IsLocal:        isLocal,
 		CircuitBreaker: NewCircuitBreaker(3, 30*time.Second),
 	}
@@ -315,4 +433,3 @@ func newClientFromKubeconfigBytes(kubeconfig []byte) (*kubernetes.Client, error)
 	}
 	return kubernetes.NewFromRestConfig(cfg)
 }
-
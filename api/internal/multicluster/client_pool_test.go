@@ -1,8 +1,11 @@
 package multicluster
 
 import (
+	"slices"
 	"testing"
 	"time"
+
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
 )
 
 func TestClientPool_GetMissing(t *testing.T) {
@@ -74,3 +77,97 @@ func TestClientPool_ListAndNames(t *testing.T) {
 		t.Fatalf("expected 2 names, got %d", len(names))
 	}
 }
+
+func TestClientPool_Select(t *testing.T) {
+	pool := &ClientPool{
+		clients: map[string]*ClusterClient{
+			"us-prod": {Name: "us-prod", Labels: map[string]string{"region": "us", "env": "prod"}},
+			"us-dev":  {Name: "us-dev", Labels: map[string]string{"region": "us", "env": "dev"}},
+			"eu-prod": {Name: "eu-prod", Labels: map[string]string{"region": "eu", "env": "prod"}},
+			"bare":    {Name: "bare"},
+		},
+	}
+	adapter := NewPoolAdapter(pool, "us-prod")
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: "region=us", want: []string{"us-dev", "us-prod"}},
+		{selector: "region=us,env!=dev", want: []string{"us-prod"}},
+		{selector: "env in (prod)", want: []string{"eu-prod", "us-prod"}},
+		{selector: "!region", want: []string{"bare"}},
+		{selector: "region=ap", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := adapter.SelectNames(tt.selector)
+			if err != nil {
+				t.Fatalf("SelectNames(%q): %v", tt.selector, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SelectNames(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := adapter.SelectNames("region in ("); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+}
+
+func TestPoolAdapter_Failover(t *testing.T) {
+	checked := time.Now()
+	openBreaker := NewCircuitBreaker(1, 30*time.Second)
+	openBreaker.RecordFailure()
+	client := &kubernetes.Client{}
+
+	pool := &ClientPool{
+		clients: map[string]*ClusterClient{
+			"east-a":  {Name: "east-a", Labels: map[string]string{"group": "east"}, K8sClient: client, LastHealthCheck: checked},
+			"east-b":  {Name: "east-b", Labels: map[string]string{"group": "east"}, K8sClient: client, Healthy: true, LastHealthCheck: checked},
+			"east-c":  {Name: "east-c", Labels: map[string]string{"group": "east"}, K8sClient: client, Healthy: true, LastHealthCheck: checked},
+			"west-a":  {Name: "west-a", Labels: map[string]string{"group": "west"}, K8sClient: client, LastHealthCheck: checked},
+			"west-b":  {Name: "west-b", Labels: map[string]string{"group": "west"}, K8sClient: client, Healthy: true, LastHealthCheck: checked, CircuitBreaker: openBreaker},
+			"loner":   {Name: "loner", K8sClient: client, LastHealthCheck: checked},
+			"pending": {Name: "pending", Labels: map[string]string{"group": "east"}, K8sClient: client},
+		},
+	}
+
+	disabled := NewPoolAdapter(pool, "east-a")
+	if disabled.Unhealthy("east-a") {
+		t.Error("Unhealthy should be false when failover is disabled")
+	}
+	if _, _, ok := disabled.Replica("east-a"); ok {
+		t.Error("Replica should not fail over when failover is disabled")
+	}
+
+	adapter := NewPoolAdapter(pool, "east-a", WithFailover("group"))
+	tests := []struct {
+		name          string
+		wantUnhealthy bool
+		wantReplica   string
+	}{
+		// The first healthy group member by name is picked.
+		{name: "east-a", wantUnhealthy: true, wantReplica: "east-b"},
+		// The only other member's circuit breaker is open.
+		{name: "west-a", wantUnhealthy: true},
+		// No group label, so no replicas.
+		{name: "loner", wantUnhealthy: true},
+		// Not checked yet, so not reported unhealthy.
+		{name: "pending", wantReplica: "east-b"},
+		{name: "east-b", wantReplica: "east-c"},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adapter.Unhealthy(tt.name); got != tt.wantUnhealthy {
+				t.Errorf("Unhealthy(%q) = %v, want %v", tt.name, got, tt.wantUnhealthy)
+			}
+			replica, _, ok := adapter.Replica(tt.name)
+			if ok != (tt.wantReplica != "") || replica != tt.wantReplica {
+				t.Errorf("Replica(%q) = %q, %v; want %q", tt.name, replica, ok, tt.wantReplica)
+			}
+		})
+	}
+}
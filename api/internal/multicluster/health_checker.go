@@ -115,7 +115,7 @@ func checkCluster(ctx context.Context, cc *ClusterClient) bool {
 	})
 
 	// Discover NGF version from GatewayClass controller name.
-	ngfVersion := discoverNGFVersion(ctx, dc)
+	ngfVersion := DiscoverNGFVersion(ctx, dc)
 
 	// Update the ClusterClient fields.
 	cc.mu.Lock()
@@ -162,9 +162,9 @@ func countResources(ctx context.Context, dc dynamic.Interface, gvr schema.GroupV
 	return int32(len(list.Items))
 }
 
-// discoverNGFVersion finds the NGF version from the GatewayClass description or
+// DiscoverNGFVersion finds the NGF version from the GatewayClass description or
 // controller name. Looks for a GatewayClass with controller containing "nginx".
-func discoverNGFVersion(ctx context.Context, dc dynamic.Interface) string {
+func DiscoverNGFVersion(ctx context.Context, dc dynamic.Interface) string {
 	gcGVR := schema.GroupVersionResource{
 		Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses",
 	}
@@ -0,0 +1,117 @@
+package multicluster
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StaleNotifier is told when a cluster's agent stops heartbeating and when it
+// starts again.
+type StaleNotifier interface {
+	ClusterStale(name string, lastHeartbeat time.Time, staleAfter time.Duration)
+	ClusterRecovered(name string)
+}
+
+// LastHeartbeatFunc returns when a cluster's newest stored heartbeat was
+// received, or the zero time if none is stored.
+type LastHeartbeatFunc func(ctx context.Context, cluster string) (time.Time, error)
+
+// staleDetector remembers which clusters it has notified about, so each
+// cluster going stale or recovering is notified once.
+type staleDetector struct {
+	pool       *ClientPool
+	staleAfter time.Duration
+	notifier   StaleNotifier
+	lastStored LastHeartbeatFunc
+
+	notified map[string]bool // cluster -> reported stale
+	seeded   map[string]bool // cluster -> stored heartbeat looked up
+}
+
+// RunStaleDetector marks a cluster stale, and notifies notifier, once its
+// agent has not heartbeated for staleAfter. It checks every staleAfter/3
+// until ctx is cancelled. Clusters without an agent never go stale.
+//
+// Heartbeat times are kept in memory, so after a restart a cluster is only
+// checked once its agent heartbeats again. When lastStored is non-nil it
+// seeds those times from the stored heartbeat history instead, so an agent
+// that went silent before the restart is still caught.
+func RunStaleDetector(ctx context.Context, pool *ClientPool, staleAfter time.Duration, notifier StaleNotifier, lastStored LastHeartbeatFunc) {
+	d := &staleDetector{
+		pool:       pool,
+		staleAfter: staleAfter,
+		notifier:   notifier,
+		lastStored: lastStored,
+		notified:   make(map[string]bool),
+		seeded:     make(map[string]bool),
+	}
+
+	ticker := time.NewTicker(staleAfter / 3)
+	defer ticker.Stop()
+
+	d.check(ctx, time.Now())
+	for {
+		select {
+		case <-ticker.C:
+			d.check(ctx, time.Now())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check compares each cluster's last heartbeat with now.
+func (d *staleDetector) check(ctx context.Context, now time.Time) {
+	present := make(map[string]bool)
+	for _, cc := range d.pool.List() {
+		present[cc.Name] = true
+
+		last := cc.Health().LastHeartbeat
+		if last.IsZero() {
+			last = d.storedHeartbeat(ctx, cc)
+		}
+		if last.IsZero() {
+			continue
+		}
+
+		stale := now.Sub(last) > d.staleAfter
+		cc.SetStale(stale)
+		switch {
+		case stale && !d.notified[cc.Name]:
+			slog.Warn("cluster heartbeats stopped", "cluster", cc.Name, "lastHeartbeat", last, "staleAfter", d.staleAfter)
+			d.notified[cc.Name] = true
+			d.notifier.ClusterStale(cc.Name, last, d.staleAfter)
+		case !stale && d.notified[cc.Name]:
+			slog.Info("cluster heartbeats resumed", "cluster", cc.Name)
+			delete(d.notified, cc.Name)
+			d.notifier.ClusterRecovered(cc.Name)
+		}
+	}
+
+	// Unregistered clusters can no longer recover, so resolve them.
+	for name := range d.notified {
+		if !present[name] {
+			delete(d.notified, name)
+			d.notifier.ClusterRecovered(name)
+		}
+	}
+}
+
+// storedHeartbeat looks up a cluster's newest stored heartbeat, once per
+// cluster. A lookup error is retried on the next check.
+func (d *staleDetector) storedHeartbeat(ctx context.Context, cc *ClusterClient) time.Time {
+	if d.lastStored == nil || d.seeded[cc.Name] {
+		return time.Time{}
+	}
+	last, err := d.lastStored(ctx, cc.Name)
+	if err != nil {
+		slog.Warn("failed to read stored heartbeat", "cluster", cc.Name, "error", err)
+		return time.Time{}
+	}
+	d.seeded[cc.Name] = true
+	if !last.IsZero() {
+		cc.seedHeartbeat(last)
+	}
+	return last
+}
@@ -0,0 +1,81 @@
+package multicluster
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recordingNotifier records stale and recovered notifications.
+type recordingNotifier struct {
+	events []string
+}
+
+func (n *recordingNotifier) ClusterStale(name string, _ time.Time, _ time.Duration) {
+	n.events = append(n.events, "stale:"+name)
+}
+
+func (n *recordingNotifier) ClusterRecovered(name string) {
+	n.events = append(n.events, "recovered:"+name)
+}
+
+func TestStaleDetector(t *testing.T) {
+	now := time.Now()
+	west := &ClusterClient{Name: "west", LastHeartbeat: now.Add(-5 * time.Minute), CircuitBreaker: NewCircuitBreaker(3, time.Minute)}
+	east := &ClusterClient{Name: "east", LastHeartbeat: now.Add(-10 * time.Second)}
+	noAgent := &ClusterClient{Name: "no-agent"}
+	restarted := &ClusterClient{Name: "restarted"}
+	pool := NewPoolForTest(west, east, noAgent, restarted)
+
+	notifier := &recordingNotifier{}
+	lookups := 0
+	d := &staleDetector{
+		pool:       pool,
+		staleAfter: 90 * time.Second,
+		notifier:   notifier,
+		lastStored: func(_ context.Context, cluster string) (time.Time, error) {
+			lookups++
+			if cluster == "restarted" {
+				return now.Add(-time.Hour), nil
+			}
+			return time.Time{}, nil
+		},
+		notified: make(map[string]bool),
+		seeded:   make(map[string]bool),
+	}
+
+	d.check(context.Background(), now)
+	slices.Sort(notifier.events)
+	if want := []string{"stale:restarted", "stale:west"}; !slices.Equal(notifier.events, want) {
+		t.Fatalf("events = %v, want %v", notifier.events, want)
+	}
+	if !west.Health().Stale || east.Health().Stale || noAgent.Health().Stale {
+		t.Errorf("unexpected stale flags: west=%v east=%v no-agent=%v", west.Stale, east.Stale, noAgent.Stale)
+	}
+
+	// A second check neither notifies again nor repeats the stored lookups.
+	notifier.events = nil
+	d.check(context.Background(), now)
+	if len(notifier.events) != 0 {
+		t.Errorf("expected no new events, got %v", notifier.events)
+	}
+	if lookups != 2 {
+		t.Errorf("expected one stored lookup per cluster without heartbeats, got %d", lookups)
+	}
+
+	// A heartbeat clears the flag at once, and the next check resolves the
+	// alert. Unregistering a stale cluster resolves its alert too.
+	west.SetHeartbeat("v1.31.2", "", nil, nil)
+	if west.Health().Stale {
+		t.Error("a heartbeat should clear the stale flag")
+	}
+	pool.mu.Lock()
+	delete(pool.clients, "restarted")
+	pool.mu.Unlock()
+	d.check(context.Background(), time.Now())
+	slices.Sort(notifier.events)
+	if want := []string{"recovered:restarted", "recovered:west"}; !slices.Equal(notifier.events, want) {
+		t.Errorf("events = %v, want %v", notifier.events, want)
+	}
+}
@@ -0,0 +1,11 @@
+package multicluster
+
+// NewPoolForTest creates a ClientPool holding pre-built clients for testing.
+// It has no hub client, so Sync and the status methods cannot be used.
+func NewPoolForTest(clients ...*ClusterClient) *ClientPool {
+	p := &ClientPool{clients: make(map[string]*ClusterClient, len(clients))}
+	for _, cc := range clients {
+		p.clients[cc.Name] = cc
+	}
+	return p
+}
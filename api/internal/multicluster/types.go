@@ -33,6 +33,12 @@ type ResourceCounts struct {
 type GPUCapacitySummary struct {
 	TotalGPUs     int32             `json:"totalGPUs"`
 	AllocatedGPUs int32             `json:"allocatedGPUs"`
+	// TotalMIGSlices and AllocatedMIGSlices count nvidia.com/mig-* resources
+	// on nodes partitioned with Multi-Instance GPU.
+	TotalMIGSlices     int32 `json:"totalMIGSlices,omitempty"`
+	AllocatedMIGSlices int32 `json:"allocatedMIGSlices,omitempty"`
+	// GPUTypes maps GPU product, or "<product>/<MIG profile>" for MIG slices,
+	// to a count.
 	GPUTypes      map[string]int32  `json:"gpuTypes,omitempty"`
 }
 
@@ -0,0 +1,234 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Version is the OpenAPI version of the documents built here.
+const Version = "3.0.3"
+
+// Operation describes one endpoint. Request and Response are zero values of
+// the Go types encoded as the JSON bodies, so the schemas follow the handler
+// types instead of being maintained by hand.
+type Operation struct {
+	Method  string // e.g. http.MethodGet
+	Path    string // chi pattern, e.g. /api/v1/gateways/{namespace}/{name}
+	Tag     string
+	Summary string
+	Query   []string // optional query parameters
+
+	Request     any    // request body; nil if the endpoint takes none
+	Response    any    // success body; nil for untyped JSON
+	Status      int    // success status; zero means 200, 1xx means no body
+	ContentType string // success content type; empty means application/json
+}
+
+// Document is an OpenAPI 3.0 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info is the document's metadata.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps a lower-case HTTP method to its operation.
+type PathItem map[string]*OperationObject
+
+// OperationObject is one operation in a PathItem.
+type OperationObject struct {
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	OperationID string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is an operation's request body.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is one response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the named schemas operations refer to.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// errorSchema is the body written by writeError.
+const errorSchema = "Error"
+
+// pathParam matches a chi path parameter, e.g. {namespace}.
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// Build returns the document describing ops. It fails if two operations share
+// a method and path.
+func Build(info Info, ops []Operation) (*Document, error) {
+	g := newGenerator()
+	g.schemas[errorSchema] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":     {Type: "string"},
+			"requestId": {Type: "string"},
+		},
+	}
+
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: g.schemas},
+	}
+	for _, op := range ops {
+		item, ok := doc.Paths[op.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[op.Path] = item
+		}
+		method := strings.ToLower(op.Method)
+		if _, dup := item[method]; dup {
+			return nil, fmt.Errorf("duplicate operation %s %s", op.Method, op.Path)
+		}
+		item[method] = g.operation(op)
+	}
+	return doc, nil
+}
+
+// operation converts op, registering the schemas of its bodies.
+func (g *generator) operation(op Operation) *OperationObject {
+	o := &OperationObject{
+		Summary:     op.Summary,
+		OperationID: operationID(op.Method, op.Path),
+		Responses: map[string]Response{
+			"default": {
+				Description: "Error",
+				Content:     jsonContent(&Schema{Ref: schemaRef(errorSchema)}),
+			},
+		},
+	}
+	if op.Tag != "" {
+		o.Tags = []string{op.Tag}
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+		o.Parameters = append(o.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	for _, q := range op.Query {
+		o.Parameters = append(o.Parameters, Parameter{Name: q, In: "query", Schema: &Schema{Type: "string"}})
+	}
+
+	if op.Request != nil {
+		o.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.schemaFor(op.Request))}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp := Response{Description: http.StatusText(status)}
+	switch {
+	case status < http.StatusOK:
+	case op.ContentType != "":
+		resp.Content = map[string]MediaType{op.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+	case op.Response != nil:
+		resp.Content = jsonContent(g.schemaFor(op.Response))
+	default:
+		resp.Content = jsonContent(&Schema{})
+	}
+	o.Responses[fmt.Sprint(status)] = resp
+	return o
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// operationID derives a unique ID from the method and path, e.g.
+// get_api_v1_gateways_namespace_name.
+func operationID(method, path string) string {
+	return strings.ToLower(method) + strings.Map(func(r rune) rune {
+		switch r {
+		case '{', '}':
+			return -1
+		case '/', '-':
+			return '_'
+		}
+		return r
+	}, strings.TrimSuffix(path, "/"))
+}
+
+// Refs returns every $ref in the document, sorted, so callers can check they
+// all resolve.
+func (d *Document) Refs() []string {
+	seen := make(map[string]bool)
+	var walk func(s *Schema)
+	walk = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			seen[s.Ref] = true
+		}
+		walk(s.Items)
+		walk(s.AdditionalProperties)
+		for _, p := range s.Properties {
+			walk(p)
+		}
+	}
+	walkContent := func(c map[string]MediaType) {
+		for _, mt := range c {
+			walk(mt.Schema)
+		}
+	}
+
+	for _, item := range d.Paths {
+		for _, op := range item {
+			if op.RequestBody != nil {
+				walkContent(op.RequestBody.Content)
+			}
+			for _, r := range op.Responses {
+				walkContent(r.Content)
+			}
+		}
+	}
+	for _, s := range d.Components.Schemas {
+		walk(s)
+	}
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
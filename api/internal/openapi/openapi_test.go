@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testItem struct {
+	testBase
+	Name     string            `json:"name"`
+	Count    *int              `json:"count,omitempty"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels"`
+	Children []testItem        `json:"children"`
+	Spec     any               `json:"spec"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+func TestBuild_Schemas(t *testing.T) {
+	doc, err := Build(Info{Title: "test", Version: "v1"}, []Operation{
+		{Method: http.MethodPost, Path: "/items", Request: testItem{}, Response: testItem{}, Status: http.StatusCreated},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	s, ok := doc.Components.Schemas["testItem"]
+	if !ok {
+		t.Fatalf("testItem not registered; schemas: %v", doc.Components.Schemas)
+	}
+	want := map[string]Schema{
+		"id":      {Type: "string"},
+		"name":    {Type: "string"},
+		"count":   {Type: "integer", Format: "int32", Nullable: true},
+		"created": {Type: "string", Format: "date-time"},
+		"spec":    {},
+	}
+	for name, w := range want {
+		got, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("property %q missing", name)
+			continue
+		}
+		if got.Type != w.Type || got.Format != w.Format || got.Nullable != w.Nullable {
+			t.Errorf("property %q = %+v, want %+v", name, *got, w)
+		}
+	}
+	if got := s.Properties["labels"]; got == nil || got.AdditionalProperties == nil || got.AdditionalProperties.Type != "string" {
+		t.Errorf("labels = %+v, want a string map", got)
+	}
+	if got := s.Properties["children"]; got == nil || got.Items == nil || got.Items.Ref != "#/components/schemas/testItem" {
+		t.Errorf("children = %+v, want an array of testItem refs", got)
+	}
+	for _, name := range []string{"Secret", "-", "internal", "testBase"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("property %q should be omitted", name)
+		}
+	}
+
+	op := doc.Paths["/items"]["post"]
+	if op == nil {
+		t.Fatal("POST /items missing")
+	}
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/testItem" {
+		t.Errorf("request body = %+v, want a testItem ref", op.RequestBody)
+	}
+	if _, ok := op.Responses["201"]; !ok {
+		t.Errorf("responses = %v, want 201", op.Responses)
+	}
+	if _, ok := op.Responses["default"]; !ok {
+		t.Errorf("responses = %v, want a default error", op.Responses)
+	}
+
+	for _, ref := range doc.Refs() {
+		if _, ok := doc.Components.Schemas[ref[len(refPrefix):]]; !ok {
+			t.Errorf("unresolved $ref %s", ref)
+		}
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Errorf("marshal: %v", err)
+	}
+}
+
+func TestBuild_Parameters(t *testing.T) {
+	doc, err := Build(Info{}, []Operation{
+		{Method: http.MethodGet, Path: "/clusters/{cluster}/gateways/{namespace}/{name}", Query: []string{"watch"}},
+		{Method: http.MethodGet, Path: "/ws", Status: http.StatusSwitchingProtocols},
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	op := doc.Paths["/clusters/{cluster}/gateways/{namespace}/{name}"]["get"]
+	if op.OperationID != "get_clusters_cluster_gateways_namespace_name" {
+		t.Errorf("operationId = %q", op.OperationID)
+	}
+	var names []string
+	for _, p := range op.Parameters {
+		names = append(names, p.In+":"+p.Name)
+		if p.In == "path" && !p.Required {
+			t.Errorf("path parameter %q not required", p.Name)
+		}
+	}
+	if got, want := len(names), 4; got != want {
+		t.Fatalf("parameters = %v, want %d", names, want)
+	}
+	if names[0] != "path:cluster" || names[3] != "query:watch" {
+		t.Errorf("parameters = %v", names)
+	}
+
+	ws := doc.Paths["/ws"]["get"].Responses["101"]
+	if ws.Content != nil {
+		t.Errorf("101 response has content %v", ws.Content)
+	}
+}
+
+func TestBuild_DuplicateOperation(t *testing.T) {
+	_, err := Build(Info{}, []Operation{
+		{Method: http.MethodGet, Path: "/a"},
+		{Method: http.MethodGet, Path: "/a"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a duplicate operation")
+	}
+}
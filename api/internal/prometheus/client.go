@@ -13,12 +13,14 @@ import (
 
 // Client wraps a Prometheus HTTP API client.
 type Client struct {
-	api promv1.API
-	url string
+	api   promv1.API
+	url   string
+	names MetricNames
 }
 
-// New creates a new Prometheus client pointing at the given URL.
-func New(url string) (*Client, error) {
+// New creates a new Prometheus client pointing at the given URL that queries
+// NGF metrics under the given names.
+func New(url string, names MetricNames) (*Client, error) {
 	client, err := promapi.NewClient(promapi.Config{
 		Address: url,
 		Client:  &http.Client{Timeout: 15 * time.Second},
@@ -26,7 +28,20 @@ func New(url string) (*Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("create prometheus client: %w", err)
 	}
-	return &Client{api: promv1.NewAPI(client), url: url}, nil
+	return &Client{api: promv1.NewAPI(client), url: url, names: names}, nil
+}
+
+// MetricNames returns the NGF metric names the client queries.
+func (c *Client) MetricNames() MetricNames {
+	return c.names
+}
+
+// Ping runs a trivial instant query to check that Prometheus is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	if _, _, err := c.api.Query(ctx, "vector(1)", time.Now()); err != nil {
+		return fmt.Errorf("query prometheus at %s: %w", c.url, err)
+	}
+	return nil
 }
 
 // MetricsSummary holds aggregated RED metrics.
@@ -62,6 +77,19 @@ type GatewayMetrics struct {
 	ActiveConnections float64 `json:"activeConnections"`
 }
 
+// DefaultREDWindow is the rate() window used when a RED query does not set one.
+const DefaultREDWindow = 5 * time.Minute
+
+// REDWindows are the rate() windows, as Go durations, that RED queries
+// accept. Each is at least a few scrape intervals long so that rate() has
+// enough samples.
+var REDWindows = []string{"1m", "5m", "15m", "1h", "6h", "24h"}
+
+// rangeSelector formats window as a PromQL range duration, e.g. "5m" or "1d".
+func rangeSelector(window time.Duration) string {
+	return model.Duration(window).String()
+}
+
 // clusterSelector returns a PromQL label selector for the given cluster.
 // Returns "" when clusterName is empty (matches all clusters).
 func clusterSelector(clusterName string) string {
@@ -71,52 +99,55 @@ func clusterSelector(clusterName string) string {
 	return fmt.Sprintf(`cluster_name="%s",`, clusterName)
 }
 
-// Summary returns aggregated RED metrics, optionally filtered by cluster.
+// Summary returns aggregated RED metrics over the rate() window, optionally
+// filtered by cluster.
 //
 // Supports two metric naming schemes:
 //   - nginx_gateway_fabric_* (NGF with observability policy / rich labels)
 //   - nginx_http_* (NGF basic nginx receiver metrics)
 //
 // The method tries the richer scheme first and falls back to basic metrics.
-func (c *Client) Summary(ctx context.Context, end time.Time, clusterName string) (*MetricsSummary, error) {
+func (c *Client) Summary(ctx context.Context, end time.Time, window time.Duration, clusterName string) (*MetricsSummary, error) {
 	summary := &MetricsSummary{}
 	cs := clusterSelector(clusterName)
+	rw := rangeSelector(window)
+	reqs, dur := c.names.RequestsTotal, c.names.RequestDuration+"_bucket"
 
 	// Requests per second — try rich labels first, fall back to basic nginx metrics
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(rate(nginx_gateway_fabric_http_requests_total{%s}[5m]))`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(rate(%s{%s}[%s]))`, reqs, cs, rw), end); err == nil {
 		summary.RequestsPerSec = val
-	} else if val, err := c.queryScalar(ctx, `sum(rate(nginx_http_requests_total[5m]))`, end); err == nil {
+	} else if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(rate(nginx_http_requests_total[%s]))`, rw), end); err == nil {
 		summary.RequestsPerSec = val
 	}
 
 	// Error rate — only available with status labels (rich scheme)
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(rate(nginx_gateway_fabric_http_requests_total{%sstatus=~"5.."}[5m])) / sum(rate(nginx_gateway_fabric_http_requests_total{%s}[5m]))`, cs, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(rate(%s{%sstatus=~"5.."}[%s])) / sum(rate(%s{%s}[%s]))`, reqs, cs, rw, reqs, cs, rw), end); err == nil {
 		summary.ErrorRate = val
 	}
 
 	// Latency percentiles — try rich histogram, fall back unavailable
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.50, sum(rate(nginx_gateway_fabric_http_request_duration_seconds_bucket{%s}[5m])) by (le)) * 1000`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.50, sum(rate(%s{%s}[%s])) by (le)) * 1000`, dur, cs, rw), end); err == nil {
 		summary.P50LatencyMs = val
 		summary.AvgLatencyMs = val
 	}
 
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.95, sum(rate(nginx_gateway_fabric_http_request_duration_seconds_bucket{%s}[5m])) by (le)) * 1000`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.95, sum(rate(%s{%s}[%s])) by (le)) * 1000`, dur, cs, rw), end); err == nil {
 		summary.P95LatencyMs = val
 	}
 
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.99, sum(rate(nginx_gateway_fabric_http_request_duration_seconds_bucket{%s}[5m])) by (le)) * 1000`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`histogram_quantile(0.99, sum(rate(%s{%s}[%s])) by (le)) * 1000`, dur, cs, rw), end); err == nil {
 		summary.P99LatencyMs = val
 	}
 
 	// Active connections — try rich labels first, fall back to basic
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(nginx_gateway_fabric_connections_active{%s})`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(%s{%s})`, c.names.ConnectionsActive, cs), end); err == nil {
 		summary.ActiveConnections = val
 	} else if val, err := c.queryScalar(ctx, `sum(nginx_http_connection_count_connections)`, end); err == nil {
 		summary.ActiveConnections = val
 	}
 
 	// Total requests counter — try rich labels, fall back to basic
-	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(nginx_gateway_fabric_http_requests_total{%s})`, cs), end); err == nil {
+	if val, err := c.queryScalar(ctx, fmt.Sprintf(`sum(%s{%s})`, reqs, cs), end); err == nil {
 		summary.TotalRequests = val
 	} else if val, err := c.queryScalar(ctx, `sum(nginx_http_requests_total)`, end); err == nil {
 		summary.TotalRequests = val
@@ -125,18 +156,20 @@ func (c *Client) Summary(ctx context.Context, end time.Time, clusterName string)
 	return summary, nil
 }
 
-// ByRoute returns per-route RED metrics, optionally filtered by cluster.
+// ByRoute returns per-route RED metrics over the rate() window, optionally
+// filtered by cluster.
 //
 // With rich NGF metrics (nginx_gateway_fabric_*), routes are broken out by
 // httproute_namespace/httproute_name/hostname labels. With basic nginx metrics
 // (nginx_http_*), per-route breakdown is not available from Prometheus, so we
 // return a single "all-routes" aggregate entry to still show some data.
-func (c *Client) ByRoute(ctx context.Context, end time.Time, clusterName string) ([]RouteMetrics, error) {
+func (c *Client) ByRoute(ctx context.Context, end time.Time, window time.Duration, clusterName string) ([]RouteMetrics, error) {
 	cs := clusterSelector(clusterName)
+	rw := rangeSelector(window)
 
 	// Try rich labels first
 	result, _, err := c.api.Query(ctx,
-		fmt.Sprintf(`sum by (httproute_namespace, httproute_name, hostname) (rate(nginx_gateway_fabric_http_requests_total{%s}[5m]))`, cs),
+		fmt.Sprintf(`sum by (httproute_namespace, httproute_name, hostname) (rate(%s{%s}[%s]))`, c.names.RequestsTotal, cs, rw),
 		end,
 	)
 	if err == nil {
@@ -156,7 +189,7 @@ func (c *Client) ByRoute(ctx context.Context, end time.Time, clusterName string)
 
 	// Fall back to basic nginx metrics — aggregate by pod (best available grouping)
 	result, _, err = c.api.Query(ctx,
-		`sum by (pod) (rate(nginx_http_requests_total[5m]))`,
+		fmt.Sprintf(`sum by (pod) (rate(nginx_http_requests_total[%s]))`, rw),
 		end,
 	)
 	if err != nil {
@@ -180,17 +213,19 @@ func (c *Client) ByRoute(ctx context.Context, end time.Time, clusterName string)
 	return routes, nil
 }
 
-// ByGateway returns per-gateway metrics, optionally filtered by cluster.
+// ByGateway returns per-gateway metrics over the rate() window, optionally
+// filtered by cluster.
 //
 // With rich NGF metrics, gateways are broken out by gateway_namespace/gateway_name
 // labels. With basic nginx metrics, we group by the pod's app label to identify
 // which gateway instance the traffic flows through.
-func (c *Client) ByGateway(ctx context.Context, end time.Time, clusterName string) ([]GatewayMetrics, error) {
+func (c *Client) ByGateway(ctx context.Context, end time.Time, window time.Duration, clusterName string) ([]GatewayMetrics, error) {
 	cs := clusterSelector(clusterName)
+	rw := rangeSelector(window)
 
 	// Try rich labels first
 	result, _, err := c.api.Query(ctx,
-		fmt.Sprintf(`sum by (gateway_namespace, gateway_name) (rate(nginx_gateway_fabric_http_requests_total{%s}[5m]))`, cs),
+		fmt.Sprintf(`sum by (gateway_namespace, gateway_name) (rate(%s{%s}[%s]))`, c.names.RequestsTotal, cs, rw),
 		end,
 	)
 	if err == nil {
@@ -209,7 +244,7 @@ func (c *Client) ByGateway(ctx context.Context, end time.Time, clusterName strin
 
 	// Fall back to basic nginx metrics — group by app label
 	result, _, err = c.api.Query(ctx,
-		`sum by (app) (rate(nginx_http_requests_total[5m]))`,
+		fmt.Sprintf(`sum by (app) (rate(nginx_http_requests_total[%s]))`, rw),
 		end,
 	)
 	if err != nil {
@@ -0,0 +1,56 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MetricNames maps the NGF metrics used for RED queries to the names they
+// are exported under. Exporters and relabeling rules sometimes rename them.
+type MetricNames struct {
+	RequestsTotal     string // counter with status, gateway, and httproute labels
+	RequestDuration   string // histogram base name, without the _bucket suffix
+	ConnectionsActive string // gauge of active client connections
+}
+
+// DefaultMetricNames returns the names used by NGF's Prometheus exporter.
+func DefaultMetricNames() MetricNames {
+	return MetricNames{
+		RequestsTotal:     "nginx_gateway_fabric_http_requests_total",
+		RequestDuration:   "nginx_gateway_fabric_http_request_duration_seconds",
+		ConnectionsActive: "nginx_gateway_fabric_connections_active",
+	}
+}
+
+var metricNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// ParseMetricNames applies comma-separated key=value overrides to the
+// default names. Keys are "requests", "duration", and "connections", e.g.
+// "requests=ngf_requests_total,duration=ngf_request_seconds".
+func ParseMetricNames(s string) (MetricNames, error) {
+	names := DefaultMetricNames()
+	if strings.TrimSpace(s) == "" {
+		return names, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return MetricNames{}, fmt.Errorf("metric name override %q must be key=value", pair)
+		}
+		if !metricNameRe.MatchString(value) {
+			return MetricNames{}, fmt.Errorf("invalid metric name %q for %s", value, key)
+		}
+		switch key {
+		case "requests":
+			names.RequestsTotal = value
+		case "duration":
+			names.RequestDuration = value
+		case "connections":
+			names.ConnectionsActive = value
+		default:
+			return MetricNames{}, fmt.Errorf("unknown metric name key %q (want requests, duration, or connections)", key)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,80 @@
+package prometheus
+
+import "fmt"
+
+// Names of the series recorded by RecordingRules.
+const (
+	RecordRequestsRate     = "ngf:http_requests:rate5m"
+	RecordErrorsRate       = "ngf:http_requests_5xx:rate5m"
+	RecordDurationRate     = "ngf:http_request_duration_seconds_bucket:rate5m"
+	RecordConnectionsTotal = "ngf:connections_active:sum"
+)
+
+// redLabels are kept by the recording rules so that the per-cluster,
+// per-gateway, and per-route queries can all be answered from them.
+const redLabels = "cluster_name, gateway_namespace, gateway_name, httproute_namespace, httproute_name, hostname"
+
+// RuleFile is a Prometheus rule file.
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups" json:"groups"`
+}
+
+// RuleGroup is a named group of rules evaluated together.
+type RuleGroup struct {
+	Name     string `yaml:"name" json:"name"`
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
+	Rules    []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule is a recording rule (Record set) or an alerting rule (Alert set).
+type Rule struct {
+	Record      string            `yaml:"record,omitempty" json:"record,omitempty"`
+	Alert       string            `yaml:"alert,omitempty" json:"alert,omitempty"`
+	Expr        string            `yaml:"expr" json:"expr"`
+	For         string            `yaml:"for,omitempty" json:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// RecordingRules returns recording rules that precompute the RED series the
+// console queries, using the given metric names. With alerts, a second group
+// adds error-rate and latency alerts built on the recorded series.
+func RecordingRules(names MetricNames, alerts bool) RuleFile {
+	file := RuleFile{Groups: []RuleGroup{{
+		Name:     "ngf-console-red",
+		Interval: "30s",
+		Rules: []Rule{
+			{Record: RecordRequestsRate, Expr: fmt.Sprintf("sum by (%s) (rate(%s[5m]))", redLabels, names.RequestsTotal)},
+			{Record: RecordErrorsRate, Expr: fmt.Sprintf(`sum by (%s) (rate(%s{status=~"5.."}[5m]))`, redLabels, names.RequestsTotal)},
+			{Record: RecordDurationRate, Expr: fmt.Sprintf("sum by (%s, le) (rate(%s_bucket[5m]))", redLabels, names.RequestDuration)},
+			{Record: RecordConnectionsTotal, Expr: fmt.Sprintf("sum by (cluster_name, gateway_namespace, gateway_name) (%s)", names.ConnectionsActive)},
+		},
+	}}}
+	if !alerts {
+		return file
+	}
+	file.Groups = append(file.Groups, RuleGroup{
+		Name: "ngf-console-red-alerts",
+		Rules: []Rule{
+			{
+				Alert:  "NGFHighErrorRate",
+				Expr:   fmt.Sprintf("sum by (cluster_name, gateway_namespace, gateway_name) (%s) / sum by (cluster_name, gateway_namespace, gateway_name) (%s) > 0.05", RecordErrorsRate, RecordRequestsRate),
+				For:    "5m",
+				Labels: map[string]string{"severity": "warning"},
+				Annotations: map[string]string{
+					"summary": "Gateway {{ $labels.gateway_namespace }}/{{ $labels.gateway_name }} is returning more than 5% 5xx responses",
+				},
+			},
+			{
+				Alert:  "NGFHighLatencyP95",
+				Expr:   fmt.Sprintf("histogram_quantile(0.95, sum by (cluster_name, gateway_namespace, gateway_name, le) (%s)) > 1", RecordDurationRate),
+				For:    "10m",
+				Labels: map[string]string{"severity": "warning"},
+				Annotations: map[string]string{
+					"summary": "Gateway {{ $labels.gateway_namespace }}/{{ $labels.gateway_name }} p95 latency is above 1s",
+				},
+			},
+		},
+	})
+	return file
+}
@@ -0,0 +1,65 @@
+package requestid
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+)
+
+// Header carries the request ID on requests and responses.
+const Header = "X-Request-ID"
+
+// LogKey is the attribute a request ID is logged under.
+const LogKey = "request_id"
+
+// validID limits an accepted incoming ID to characters that are safe to log
+// and echo, and to a length that can't bloat every log line.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:/+=-]{1,128}$`)
+
+type contextKey struct{}
+
+// Valid reports whether a client-supplied ID can be used as is.
+func Valid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// WithID stores a request ID in the context.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in the context, or "".
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// LogHandler adds the request ID of the record's context to each record, so
+// slog.InfoContext(r.Context(), ...) and friends are tagged without every
+// call site passing the ID.
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps h.
+func NewLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{Handler: h}
+}
+
+// Handle implements slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if id := FromContext(ctx); id != "" {
+		rec.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.Handler.Handle(ctx, rec)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,51 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	for id, want := range map[string]bool{
+		"":                                     false,
+		"3f1c9a2e-5b7d-4e8f-9a0b-1c2d3e4f5a6b": true,
+		"trace:abc/def+1=":                     true,
+		"has space":                            false,
+		"line\nbreak":                          false,
+		strings.Repeat("a", 129):               false,
+	} {
+		if got := Valid(id); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	logger.InfoContext(WithID(context.Background(), "req-1"), "with id")
+	logger.InfoContext(context.Background(), "without id")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2: %s", len(lines), buf.String())
+	}
+	for i, want := range []string{"req-1", ""} {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &rec); err != nil {
+			t.Fatalf("line %d: %v", i, err)
+		}
+		got, _ := rec[LogKey].(string)
+		if got != want {
+			t.Errorf("line %d %s = %q, want %q", i, LogKey, got, want)
+		}
+		if rec["component"] != "test" {
+			t.Errorf("line %d lost the logger's attrs: %v", i, rec)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/kubenetlabs/ngc/api/internal/audit"
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// auditTarget describes what a mutating route changes.
+type auditTarget struct {
+	Action    string
+	Resource  string
+	NameParam string // URL parameter naming the target; "" means "name"
+}
+
+// hubAuditTargets are the audited hub-level routes, keyed by method and full
+// route pattern.
+var hubAuditTargets = map[string]auditTarget{
+	"POST /api/v1/clusters":                        {Action: "register", Resource: "ManagedCluster"},
+	"DELETE /api/v1/clusters/{cluster}":            {Action: "unregister", Resource: "ManagedCluster", NameParam: "cluster"},
+	"POST /api/v1/clusters/{cluster}/rotate-token": {Action: "rotate-token", Resource: "ManagedCluster", NameParam: "cluster"},
+}
+
+// resourceAuditTargets are the audited resource routes, keyed by method and
+// route pattern relative to /api/v1 or /api/v1/clusters/{cluster}.
+var resourceAuditTargets = map[string]auditTarget{
+	"POST /gateways":                              {Action: "create", Resource: "Gateway"},
+	"PUT /gateways/{namespace}/{name}":            {Action: "update", Resource: "Gateway"},
+	"DELETE /gateways/{namespace}/{name}":         {Action: "delete", Resource: "Gateway"},
+	"POST /gateways/{namespace}/{name}/deploy":    {Action: "deploy", Resource: "Gateway"},
+	"POST /gatewaybundles":                        {Action: "create", Resource: "GatewayBundle"},
+	"PUT /gatewaybundles/{namespace}/{name}":      {Action: "update", Resource: "GatewayBundle"},
+	"PATCH /gatewaybundles/{namespace}/{name}":    {Action: "patch", Resource: "GatewayBundle"},
+	"DELETE /gatewaybundles/{namespace}/{name}":   {Action: "delete", Resource: "GatewayBundle"},
+	"POST /httproutes":                            {Action: "create", Resource: "HTTPRoute"},
+	"PUT /httproutes/{namespace}/{name}":          {Action: "update", Resource: "HTTPRoute"},
+	"DELETE /httproutes/{namespace}/{name}":       {Action: "delete", Resource: "HTTPRoute"},
+	"POST /grpcroutes":                            {Action: "create", Resource: "GRPCRoute"},
+	"PUT /grpcroutes/{namespace}/{name}":          {Action: "update", Resource: "GRPCRoute"},
+	"DELETE /grpcroutes/{namespace}/{name}":       {Action: "delete", Resource: "GRPCRoute"},
+	"POST /tlsroutes":                             {Action: "create", Resource: "TLSRoute"},
+	"PUT /tlsroutes/{namespace}/{name}":           {Action: "update", Resource: "TLSRoute"},
+	"DELETE /tlsroutes/{namespace}/{name}":        {Action: "delete", Resource: "TLSRoute"},
+	"POST /tcproutes":                             {Action: "create", Resource: "TCPRoute"},
+	"PUT /tcproutes/{namespace}/{name}":           {Action: "update", Resource: "TCPRoute"},
+	"DELETE /tcproutes/{namespace}/{name}":        {Action: "delete", Resource: "TCPRoute"},
+	"POST /udproutes":                             {Action: "create", Resource: "UDPRoute"},
+	"PUT /udproutes/{namespace}/{name}":           {Action: "update", Resource: "UDPRoute"},
+	"DELETE /udproutes/{namespace}/{name}":        {Action: "delete", Resource: "UDPRoute"},
+	"POST /policies/{type}":                       {Action: "create", Resource: "Policy"},
+	"PUT /policies/{type}/{name}":                 {Action: "update", Resource: "Policy"},
+	"DELETE /policies/{type}/{name}":              {Action: "delete", Resource: "Policy"},
+	"POST /certificates":                          {Action: "create", Resource: "Certificate"},
+	"DELETE /certificates/{name}":                 {Action: "delete", Resource: "Certificate"},
+	"POST /inference/pools":                       {Action: "create", Resource: "InferencePool"},
+	"PUT /inference/pools/{name}":                 {Action: "update", Resource: "InferencePool"},
+	"DELETE /inference/pools/{name}":              {Action: "delete", Resource: "InferencePool"},
+	"POST /inference/pools/{name}/deploy":         {Action: "deploy", Resource: "InferencePool"},
+	"PUT /inference/epp":                          {Action: "update", Resource: "EPPConfig"},
+	"PUT /inference/autoscaling":                  {Action: "update", Resource: "Autoscaling"},
+	"POST /inference/stacks":                      {Action: "create", Resource: "InferenceStack"},
+	"PUT /inference/stacks/{namespace}/{name}":    {Action: "update", Resource: "InferenceStack"},
+	"DELETE /inference/stacks/{namespace}/{name}": {Action: "delete", Resource: "InferenceStack"},
+	"POST /xc/credentials":                        {Action: "save", Resource: "XCCredentials"},
+	"DELETE /xc/credentials":                      {Action: "delete", Resource: "XCCredentials"},
+	"POST /xc/publish":                            {Action: "publish", Resource: "DistributedCloudPublish"},
+	"POST /xc/publishes/resync":                   {Action: "resync", Resource: "DistributedCloudPublish"},
+	"DELETE /xc/publish/{namespace}/{name}":       {Action: "delete", Resource: "DistributedCloudPublish"},
+	"POST /migration/import":                      {Action: "import", Resource: "Migration"},
+	"POST /migration/apply":                       {Action: "apply", Resource: "Migration"},
+	"POST /resources/label":                       {Action: "label", Resource: "Resource"},
+	"POST /alerts":                                {Action: "create", Resource: "AlertRule"},
+	"PUT /alerts/{id}":                            {Action: "update", Resource: "AlertRule", NameParam: "id"},
+	"DELETE /alerts/{id}":                         {Action: "delete", Resource: "AlertRule", NameParam: "id"},
+	"POST /alerts/{id}/toggle":                    {Action: "toggle", Resource: "AlertRule", NameParam: "id"},
+}
+
+// unauditedRoutes are the POST routes that change nothing, plus agent
+// heartbeats, which are machine traffic sent every few seconds. Every other
+// POST, PUT, PATCH or DELETE route must have an audit target; the server
+// tests enforce this.
+var unauditedRoutes = map[string]bool{
+	"POST /api/v1/clusters/{cluster}/test":          true,
+	"POST /api/v1/clusters/{cluster}/install-agent": true,
+	"POST /api/v1/clusters/{cluster}/heartbeat":     true,
+	"POST /gatewaybundles/validate":                 true,
+	"POST /httproutes/{namespace}/{name}/simulate":  true,
+	"POST /logs/query":                              true,
+	"POST /diagnostics/route-check":                 true,
+	"POST /diagnostics/trace":                       true,
+	"POST /inference/diagnostics/replay":            true,
+	"POST /inference/diagnostics/benchmark":         true,
+	"POST /xc/test-connection":                      true,
+	"POST /xc/preview":                              true,
+	"POST /migration/analysis":                      true,
+	"POST /migration/generate":                      true,
+	"POST /migration/validate":                      true,
+}
+
+// auditTargetFor looks up the audit target of a matched route pattern.
+func auditTargetFor(method, pattern string) (auditTarget, bool) {
+	key, ok := routeKey(method, pattern, hubAuditTargets)
+	if ok {
+		return hubAuditTargets[key], true
+	}
+	key, ok = routeKey(method, pattern, resourceAuditTargets)
+	if ok {
+		return resourceAuditTargets[key], true
+	}
+	return auditTarget{}, false
+}
+
+// routeKey returns the key under which table holds a route, trying the full
+// pattern first and then the pattern relative to each resource route mount.
+func routeKey[V any](method, pattern string, table map[string]V) (string, bool) {
+	pattern = normalizeRoute(pattern)
+	if _, ok := table[method+" "+pattern]; ok {
+		return method + " " + pattern, true
+	}
+	for _, prefix := range []string{clusterPrefix, "/api/v1"} {
+		if rel, ok := strings.CutPrefix(pattern, prefix); ok && rel != "" {
+			if _, ok := table[method+" "+rel]; ok {
+				return method + " " + rel, true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalizeRoute drops the trailing slash chi keeps on sub-router roots, so
+// /api/v1/gateways/ matches /api/v1/gateways.
+func normalizeRoute(route string) string {
+	if len(route) > 1 {
+		return strings.TrimSuffix(route, "/")
+	}
+	return route
+}
+
+// maxAuditedErrorBody caps how much of a failed response is kept to read
+// its error message.
+const maxAuditedErrorBody = 4096
+
+// AuditMutations records an audit entry for every mutating request whose
+// handler did not record one itself: operations rejected by validation,
+// authorization or the cluster, and successful operations on routes whose
+// handlers don't audit. Entries for failures carry the error message from
+// the response body.
+func AuditMutations(store database.Store, clusters cluster.Provider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if store == nil || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, tracker := audit.WithTracker(r.Context())
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			body := &limitedBuffer{max: maxAuditedErrorBody}
+			ww.Tee(body)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if tracker.Recorded() {
+				return
+			}
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				return
+			}
+			target, ok := auditTargetFor(r.Method, rctx.RoutePattern())
+			if !ok {
+				return
+			}
+
+			nameParam := target.NameParam
+			if nameParam == "" {
+				nameParam = "name"
+			}
+			namespace := rctx.URLParam("namespace")
+			if namespace == "" {
+				namespace = r.URL.Query().Get("namespace")
+			}
+			entry := audit.NewEntry(ctx, target.Action, target.Resource, rctx.URLParam(nameParam), namespace)
+			entry.Cluster = rctx.URLParam("cluster")
+			if entry.Cluster == "" && clusters != nil {
+				entry.Cluster = clusters.DefaultName()
+			}
+			if status := ww.Status(); status >= http.StatusBadRequest {
+				entry.Outcome = database.AuditFailure
+				entry.Error = responseError(status, body.Bytes())
+			}
+			audit.Record(ctx, store, entry)
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// responseError returns the error message of a failed response body, or
+// the status text when the body is not a JSON error.
+func responseError(status int, body []byte) string {
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &resp) == nil && resp.Error != "" {
+		return resp.Error
+	}
+	return http.StatusText(status)
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the
+// rest, so teeing a large response costs bounded memory.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
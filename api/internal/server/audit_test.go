@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kubenetlabs/ngc/api/internal/audit"
+	"github.com/kubenetlabs/ngc/api/internal/auth"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+)
+
+// TestAuditMutations_AllRoutesClassified checks that every mutating route is
+// either audited or explicitly listed as changing nothing.
+func TestAuditMutations_AllRoutesClassified(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+	router := ts.Config.Handler.(chi.Router)
+
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !isMutating(method) {
+			return nil
+		}
+		_, audited := auditTargetFor(method, route)
+		_, unaudited := routeKey(method, route, unauditedRoutes)
+		switch {
+		case audited && unaudited:
+			t.Errorf("%s %s is both audited and listed as unaudited", method, route)
+		case !audited && !unaudited:
+			t.Errorf("%s %s has no audit target", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+}
+
+func TestAuditMutations(t *testing.T) {
+	store, err := database.NewSQLite(filepath.Join(t.TempDir(), "audit.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	defer store.Close()
+	if err := store.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(RequestID)
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := auth.WithUser(r.Context(), &auth.User{Subject: "u-1", Email: "alice@example.com", Role: "Viewer"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Use(AuditMutations(store, nil))
+	r.Route("/api/v1/clusters/{cluster}", func(r chi.Router) {
+		r.Delete("/gateways/{namespace}/{name}", func(w http.ResponseWriter, _ *http.Request) {
+			writeMiddlewareError(w, http.StatusForbidden, `role "Operator" required, you have "Viewer"`)
+		})
+		r.Post("/gateways", func(w http.ResponseWriter, r *http.Request) {
+			entry := audit.NewEntry(r.Context(), "create", "Gateway", "gw", "default")
+			audit.Record(r.Context(), store, entry)
+			w.WriteHeader(http.StatusCreated)
+		})
+		r.Post("/xc/preview", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodDelete, "/api/v1/clusters/west/gateways/default/gw", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/clusters/west/gateways", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/clusters/west/xc/preview", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	entries, total, err := store.ListAuditEntries(context.Background(), database.AuditListOptions{})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("expected one entry per audited request, got %d: %+v", total, entries)
+	}
+
+	var denied database.AuditEntry
+	for _, e := range entries {
+		if e.Action == "delete" {
+			denied = e
+		}
+	}
+	if denied.Outcome != database.AuditFailure || denied.Error != `role "Operator" required, you have "Viewer"` {
+		t.Errorf("expected a failure with the response error, got %+v", denied)
+	}
+	if denied.User != "alice@example.com" || denied.Cluster != "west" || denied.Resource != "Gateway" ||
+		denied.Namespace != "default" || denied.Name != "gw" || denied.RequestID == "" {
+		t.Errorf("expected who, target and request ID to be recorded, got %+v", denied)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubenetlabs/ngc/api/internal/auth"
+)
+
+// roleToken authenticates "Bearer <role>" as a caller with that role.
+type roleToken struct{}
+
+func (roleToken) Authenticate(r *http.Request) (*auth.User, error) {
+	if r.Header.Get("Authorization") == "" {
+		return nil, auth.ErrNoCredentials
+	}
+	return &auth.User{Subject: "tester", Role: r.Header.Get("Authorization")[len("Bearer "):]}, nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	var seen *auth.User
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = auth.UserFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	h := AuthMiddleware(auth.NewStaticToken("s3cret"))(RequireWriteRole(ok))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"health is public", http.MethodGet, "/api/v1/health", "", http.StatusOK},
+		{"readiness is public", http.MethodGet, "/readyz", "", http.StatusOK},
+		{"heartbeat uses its cluster token", http.MethodPost, "/api/v1/clusters/west/heartbeat", "", http.StatusOK},
+		{"heartbeat history is not public", http.MethodGet, "/api/v1/clusters/west/heartbeats", "", http.StatusUnauthorized},
+		{"no token", http.MethodGet, "/api/v1/xc/credentials", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, "/api/v1/gateways", "nope", http.StatusUnauthorized},
+		{"valid token", http.MethodPost, "/api/v1/gateways", "s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Error("401 without a WWW-Authenticate challenge")
+			}
+			if tt.token == "s3cret" && (seen == nil || seen.Subject != auth.StaticTokenSubject) {
+				t.Errorf("identity = %+v, want the static token user", seen)
+			}
+		})
+	}
+}
+
+func TestRequireWriteRole(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	h := AuthMiddleware(roleToken{})(RequireWriteRole(ok))
+
+	for _, tt := range []struct {
+		method, role string
+		want         int
+	}{
+		{http.MethodGet, auth.RoleViewer, http.StatusOK},
+		{http.MethodDelete, auth.RoleViewer, http.StatusForbidden},
+		{http.MethodDelete, auth.RoleOperator, http.StatusOK},
+		{http.MethodPut, auth.RoleAdmin, http.StatusOK},
+	} {
+		req := httptest.NewRequest(tt.method, "/api/v1/gateways/default/gw", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.role)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s as %s: status = %d, want %d", tt.method, tt.role, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRBACMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	admin := RBACMiddleware(auth.RoleAdmin)(ok)
+
+	for _, tt := range []struct {
+		role string
+		want int
+	}{
+		{auth.RoleViewer, http.StatusForbidden},
+		{auth.RoleOperator, http.StatusForbidden},
+		{auth.RoleAdmin, http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/clusters/west/rotate-token", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.role)
+		rec := httptest.NewRecorder()
+		AuthMiddleware(roleToken{})(admin).ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("as %s: status = %d, want %d", tt.role, rec.Code, tt.want)
+		}
+	}
+
+	// With authentication off there is no caller to check.
+	rec := httptest.NewRecorder()
+	AuthMiddleware(nil)(admin).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/clusters/west/rotate-token", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("auth off: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -2,21 +2,51 @@ package server
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
 )
 
+// FailoverHeader is set on responses served by a replica because the
+// requested cluster was unhealthy. Its value is the replica's name.
+const FailoverHeader = "X-Cluster-Failover"
+
 // ClusterResolver is middleware that extracts the cluster from the URL or
 // falls back to the default cluster. It stores the resolved client and
 // cluster name in the request context.
+//
+// When mgr supports failover and the cluster is marked unhealthy, read-only
+// requests are served by a healthy replica and marked with FailoverHeader,
+// while writes fail fast with 503 instead of waiting on the cluster.
 func ClusterResolver(mgr cluster.Provider) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			clusterName := chi.URLParam(r, "cluster")
 
+			target := clusterName
+			if target == "" {
+				target = mgr.DefaultName()
+			}
+			if fo, ok := mgr.(cluster.Failover); ok && fo.Unhealthy(target) {
+				if !readOnlyMethod(r.Method) {
+					writeMiddlewareError(w, http.StatusServiceUnavailable, "cluster unhealthy: "+target)
+					return
+				}
+				if replica, k8sClient, ok := fo.Replica(target); ok {
+					slog.DebugContext(r.Context(), "failing over read to replica cluster", "cluster", target, "replica", replica)
+					w.Header().Set(FailoverHeader, replica)
+					ctx := cluster.WithClient(r.Context(), k8sClient)
+					ctx = cluster.WithClusterName(ctx, replica)
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+				// No healthy replica: try the cluster itself.
+			}
+
 			if clusterName != "" {
 				k8sClient, err := mgr.Get(clusterName)
 				if err != nil {
@@ -41,8 +71,50 @@ func ClusterResolver(mgr cluster.Provider) func(http.Handler) http.Handler {
 	}
 }
 
+// readOnlyMethod reports whether method is safe to serve from a replica.
+func readOnlyMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequireCRD is middleware that responds 503 with msg when the resolved
+// cluster does not have the named CRD installed, instead of letting handlers
+// fail with an opaque "resource not found" error. It must run after
+// ClusterResolver. If the CRD lookup itself fails, the request proceeds so
+// the handler reports its own error.
+func RequireCRD(name, msg string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k8s := cluster.ClientFromContext(r.Context())
+			if k8s == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			installed, err := k8s.CRDInstalled(r.Context(), name)
+			if err != nil {
+				slog.WarnContext(r.Context(), "checking CRD", "crd", name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !installed {
+				writeMiddlewareError(w, http.StatusServiceUnavailable, msg)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func writeMiddlewareError(w http.ResponseWriter, status int, msg string) {
+	body := map[string]string{"error": msg}
+	if id := w.Header().Get(requestid.Header); id != "" {
+		body["requestId"] = id
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+	json.NewEncoder(w).Encode(body)
 }
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubenetlabs/ngc/api/internal/cluster"
+	"github.com/kubenetlabs/ngc/api/internal/kubernetes"
+)
+
+func TestRequireCRD(t *testing.T) {
+	const crdName = "inferencestacks.ngf-console.f5.com"
+
+	withApiextensions := setupScheme(t)
+	if err := apiextensionsv1.AddToScheme(withApiextensions); err != nil {
+		t.Fatalf("failed to add apiextensions scheme: %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}}
+
+	tests := []struct {
+		name       string
+		k8s        *kubernetes.Client
+		wantStatus int
+	}{
+		{
+			name:       "installed",
+			k8s:        kubernetes.NewForTest(fake.NewClientBuilder().WithScheme(withApiextensions).WithObjects(crd).Build()),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not installed",
+			k8s:        kubernetes.NewForTest(fake.NewClientBuilder().WithScheme(withApiextensions).Build()),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			// The CRD type is not in the scheme, so the lookup fails and the
+			// request proceeds.
+			name:       "lookup error",
+			k8s:        kubernetes.NewForTest(fake.NewClientBuilder().WithScheme(setupScheme(t)).Build()),
+			wantStatus: http.StatusOK,
+		},
+		{name: "no cluster client", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := RequireCRD(crdName, "CRD not installed")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.k8s != nil {
+				req = req.WithContext(cluster.WithClient(req.Context(), tt.k8s))
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// failoverProvider adds fixed failover answers to a test cluster.Manager.
+type failoverProvider struct {
+	*cluster.Manager
+	unhealthy map[string]bool
+	replica   string
+}
+
+func (p failoverProvider) Unhealthy(name string) bool { return p.unhealthy[name] }
+
+func (p failoverProvider) Replica(string) (string, *kubernetes.Client, bool) {
+	if p.replica == "" {
+		return "", nil, false
+	}
+	c, err := p.Get(p.replica)
+	return p.replica, c, err == nil
+}
+
+func TestClusterResolver_Failover(t *testing.T) {
+	newClient := func() *kubernetes.Client {
+		return kubernetes.NewForTest(fake.NewClientBuilder().WithScheme(setupScheme(t)).Build())
+	}
+	mgr := cluster.NewForTest(map[string]*kubernetes.Client{"east-a": newClient(), "east-b": newClient()}, "east-a")
+
+	tests := []struct {
+		name        string
+		provider    cluster.Provider
+		method      string
+		wantStatus  int
+		wantCluster string
+		wantHeader  string
+	}{
+		{name: "healthy", provider: failoverProvider{Manager: mgr, replica: "east-b"}, method: http.MethodGet, wantStatus: http.StatusOK, wantCluster: "east-a"},
+		{name: "read fails over", provider: failoverProvider{Manager: mgr, unhealthy: map[string]bool{"east-a": true}, replica: "east-b"}, method: http.MethodGet, wantStatus: http.StatusOK, wantCluster: "east-b", wantHeader: "east-b"},
+		{name: "read without replica", provider: failoverProvider{Manager: mgr, unhealthy: map[string]bool{"east-a": true}}, method: http.MethodGet, wantStatus: http.StatusOK, wantCluster: "east-a"},
+		{name: "write fails fast", provider: failoverProvider{Manager: mgr, unhealthy: map[string]bool{"east-a": true}, replica: "east-b"}, method: http.MethodPost, wantStatus: http.StatusServiceUnavailable},
+		{name: "no failover support", provider: mgr, method: http.MethodPost, wantStatus: http.StatusOK, wantCluster: "east-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCluster string
+			h := ClusterResolver(tt.provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotCluster = cluster.ClusterNameFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if gotCluster != tt.wantCluster {
+				t.Errorf("expected cluster %q, got %q", tt.wantCluster, gotCluster)
+			}
+			if got := w.Header().Get(FailoverHeader); got != tt.wantHeader {
+				t.Errorf("expected %s %q, got %q", FailoverHeader, tt.wantHeader, got)
+			}
+		})
+	}
+}
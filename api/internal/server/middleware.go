@@ -8,8 +8,26 @@ import (
 	"time"
 
 	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	ch "github.com/kubenetlabs/ngc/api/internal/clickhouse"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
 )
 
+// RequestID accepts the caller's X-Request-ID, or generates one if it is
+// missing or unsafe to log, stores it in the request context, and echoes it
+// in the response header so errors can be matched to log lines.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+	})
+}
+
 // MaxBodySize limits the size of request bodies to prevent abuse.
 func MaxBodySize(maxBytes int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -22,6 +40,21 @@ func MaxBodySize(maxBytes int64) func(http.Handler) http.Handler {
 	}
 }
 
+// RequireClickHouse returns 503 while the ClickHouse client's health check
+// is failing, so routes that query ClickHouse fail fast instead of timing
+// out. Requests pass through when ClickHouse is not configured.
+func RequireClickHouse(c *ch.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c != nil && !c.Healthy() {
+				writeMiddlewareError(w, http.StatusServiceUnavailable, "ClickHouse unavailable; retry once it is reachable again")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // CORSMiddleware adds CORS headers. In production, set CORS_ALLOWED_ORIGINS
 // to a comma-separated list of allowed origins. Defaults to "*" for development.
 func CORSMiddleware(next http.Handler) http.Handler {
@@ -46,6 +79,7 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
 		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-Request-ID, X-Cluster")
+		w.Header().Set("Access-Control-Expose-Headers", requestid.Header)
 		w.Header().Set("Access-Control-Max-Age", "3600")
 
 		if r.Method == http.MethodOptions {
@@ -70,7 +104,7 @@ func RequestLogger(next http.Handler) http.Handler {
 				"status", ww.Status(),
 				"bytes", ww.BytesWritten(),
 				"duration_ms", time.Since(start).Milliseconds(),
-				"request_id", chimw.GetReqID(r.Context()),
+				requestid.LogKey, requestid.FromContext(r.Context()),
 				"remote_addr", r.RemoteAddr,
 			)
 		}()
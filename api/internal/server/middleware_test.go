@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ch "github.com/kubenetlabs/ngc/api/internal/clickhouse"
+	"github.com/kubenetlabs/ngc/api/internal/requestid"
+)
+
+func TestRequireClickHouse(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     *ch.Client
+		wantStatus int
+	}{
+		{name: "healthy", client: ch.NewForTest(true), wantStatus: http.StatusOK},
+		{name: "unhealthy", client: ch.NewForTest(false), wantStatus: http.StatusServiceUnavailable},
+		{name: "not configured", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := RequireClickHouse(tt.client)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "accepts caller ID", incoming: "trace-abc.123", wantSame: true},
+		{name: "generates when missing"},
+		{name: "replaces unsafe ID", incoming: "bad id\nwith newline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = requestid.FromContext(r.Context())
+				writeMiddlewareError(w, http.StatusBadGateway, "upstream failed")
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestid.Header)
+			if got == "" {
+				t.Fatal("response has no X-Request-ID")
+			}
+			if got != seen {
+				t.Errorf("context ID %q != response ID %q", seen, got)
+			}
+			if tt.wantSame && got != tt.incoming {
+				t.Errorf("ID = %q, want caller's %q", got, tt.incoming)
+			}
+			if !tt.wantSame && got == tt.incoming {
+				t.Errorf("ID %q should have been replaced", got)
+			}
+
+			var body map[string]string
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding body: %v", err)
+			}
+			if body["requestId"] != got {
+				t.Errorf("body requestId = %q, want %q", body["requestId"], got)
+			}
+		})
+	}
+}
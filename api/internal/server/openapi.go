@@ -0,0 +1,265 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/kubenetlabs/ngc/api/internal/alerting"
+	"github.com/kubenetlabs/ngc/api/internal/database"
+	"github.com/kubenetlabs/ngc/api/internal/handlers"
+	"github.com/kubenetlabs/ngc/api/internal/openapi"
+	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
+	"github.com/kubenetlabs/ngc/api/pkg/version"
+)
+
+// OpenAPIPath is where the API's OpenAPI document is served.
+const OpenAPIPath = "/openapi.json"
+
+// clusterPrefix scopes resource routes to one cluster.
+const clusterPrefix = "/api/v1/clusters/{cluster}"
+
+// untypedBody documents a JSON object request body with no fixed schema.
+var untypedBody = map[string]any{}
+
+// hubOperations lists the routes registered outside mountResourceRoutes.
+// TestOpenAPI_MatchesRouter fails when this drifts from registerRoutes.
+func hubOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: "/api/v1/health", Tag: "system", Summary: "Liveness check"},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "system", Summary: "Readiness check", Response: handlers.ReadinessResponse{}},
+		{Method: http.MethodGet, Path: "/version", Tag: "system", Summary: "Component version matrix", Response: handlers.VersionResponse{}},
+		{Method: http.MethodGet, Path: OpenAPIPath, Tag: "system", Summary: "This OpenAPI document"},
+
+		{Method: http.MethodGet, Path: "/api/v1/clusters", Tag: "clusters", Summary: "List clusters", Response: []handlers.ClusterDetailResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/clusters", Tag: "clusters", Summary: "Register a cluster", Request: handlers.RegisterClusterRequest{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/api/v1/clusters/summary", Tag: "clusters", Summary: "Fleet summary", Response: handlers.ClusterSummaryResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/retention", Tag: "logs", Summary: "ClickHouse retention settings", Response: handlers.RetentionResponse{}},
+
+		{Method: http.MethodGet, Path: "/api/v1/global/gateways", Tag: "global", Summary: "Gateways across clusters", Query: []string{"namespace", "clusterSelector"}},
+		{Method: http.MethodGet, Path: "/api/v1/global/routes", Tag: "global", Summary: "HTTPRoutes across clusters", Query: []string{"clusterSelector"}},
+		{Method: http.MethodGet, Path: "/api/v1/global/gpu-capacity", Tag: "global", Summary: "GPU capacity across clusters", Query: []string{"clusterSelector"}},
+
+		{Method: http.MethodGet, Path: clusterPrefix + "/detail", Tag: "clusters", Summary: "Get a cluster", Response: handlers.ClusterDetailResponse{}},
+		{Method: http.MethodGet, Path: clusterPrefix + "/health", Tag: "clusters", Summary: "Cluster health", Response: handlers.ClusterHealthResponse{}},
+		{Method: http.MethodDelete, Path: clusterPrefix, Tag: "clusters", Summary: "Unregister a cluster"},
+		{Method: http.MethodPost, Path: clusterPrefix + "/test", Tag: "clusters", Summary: "Test connectivity to a cluster"},
+		{Method: http.MethodPost, Path: clusterPrefix + "/install-agent", Tag: "clusters", Summary: "Agent install command"},
+		{Method: http.MethodPost, Path: clusterPrefix + "/heartbeat", Tag: "clusters", Summary: "Agent heartbeat", Request: handlers.HeartbeatRequest{}},
+		{Method: http.MethodPost, Path: clusterPrefix + "/rotate-token", Tag: "clusters", Summary: "Rotate the heartbeat token", Response: handlers.ClusterTokenResponse{}},
+		{Method: http.MethodGet, Path: clusterPrefix + "/heartbeats", Tag: "clusters", Summary: "Heartbeat history", Query: []string{"since", "limit"}, Response: []handlers.HeartbeatRecordResponse{}},
+
+		{Method: http.MethodGet, Path: "/api/v1/ws", Tag: "events", Summary: "Resource event WebSocket", Status: http.StatusSwitchingProtocols},
+		{Method: http.MethodGet, Path: "/api/v1/events", Tag: "events", Summary: "Resource event WebSocket", Status: http.StatusSwitchingProtocols},
+		{Method: http.MethodGet, Path: "/api/v1/ws/inference/epp-decisions", Tag: "events", Summary: "EPP decision stream", Status: http.StatusSwitchingProtocols},
+		{Method: http.MethodGet, Path: "/api/v1/ws/inference/gpu-metrics", Tag: "events", Summary: "GPU metrics stream", Status: http.StatusSwitchingProtocols},
+		{Method: http.MethodGet, Path: "/api/v1/ws/inference/scaling-events", Tag: "events", Summary: "Scaling event stream", Status: http.StatusSwitchingProtocols},
+	}
+}
+
+// resourceOperations lists the routes registered by mountResourceRoutes,
+// relative to where they are mounted.
+func resourceOperations() []openapi.Operation {
+	const nsName = "/{namespace}/{name}"
+	ops := []openapi.Operation{
+		{Method: http.MethodGet, Path: "/config", Tag: "system", Summary: "Edition and connection status"},
+
+		{Method: http.MethodGet, Path: "/gatewayclasses", Tag: "gateways", Summary: "List GatewayClasses", Response: []handlers.GatewayClassResponse{}},
+		{Method: http.MethodGet, Path: "/gatewayclasses/{name}", Tag: "gateways", Summary: "Get a GatewayClass", Response: handlers.GatewayClassResponse{}},
+
+		{Method: http.MethodGet, Path: "/gateways", Tag: "gateways", Summary: "List Gateways", Query: []string{"namespace"}, Response: []handlers.GatewayResponse{}},
+		{Method: http.MethodPost, Path: "/gateways", Tag: "gateways", Summary: "Create a Gateway", Request: handlers.CreateGatewayRequest{}, Response: handlers.GatewayBundleResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/gateways" + nsName, Tag: "gateways", Summary: "Get a Gateway", Response: handlers.GatewayResponse{}},
+		{Method: http.MethodPut, Path: "/gateways" + nsName, Tag: "gateways", Summary: "Update a Gateway", Request: handlers.UpdateGatewayRequest{}, Response: handlers.GatewayBundleResponse{}},
+		{Method: http.MethodDelete, Path: "/gateways" + nsName, Tag: "gateways", Summary: "Delete a Gateway"},
+		{Method: http.MethodPost, Path: "/gateways" + nsName + "/deploy", Tag: "gateways", Summary: "Deploy a Gateway (not implemented)"},
+
+		{Method: http.MethodGet, Path: "/gatewaybundles", Tag: "gateways", Summary: "List GatewayBundles", Response: handlers.GatewayBundleListResponse{}},
+		{Method: http.MethodPost, Path: "/gatewaybundles", Tag: "gateways", Summary: "Create a GatewayBundle", Request: handlers.CreateGatewayBundleRequest{}, Response: handlers.GatewayBundleResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPost, Path: "/gatewaybundles/validate", Tag: "gateways", Summary: "Validate a GatewayBundle", Request: handlers.CreateGatewayBundleRequest{}, Response: handlers.GatewayBundleValidationResponse{}},
+		{Method: http.MethodGet, Path: "/gatewaybundles" + nsName, Tag: "gateways", Summary: "Get a GatewayBundle", Response: handlers.GatewayBundleResponse{}},
+		{Method: http.MethodPut, Path: "/gatewaybundles" + nsName, Tag: "gateways", Summary: "Update a GatewayBundle", Request: handlers.UpdateGatewayBundleRequest{}, Response: handlers.GatewayBundleResponse{}},
+		{Method: http.MethodPatch, Path: "/gatewaybundles" + nsName, Tag: "gateways", Summary: "Merge-patch a GatewayBundle", Request: untypedBody, Response: handlers.GatewayBundleResponse{}},
+		{Method: http.MethodDelete, Path: "/gatewaybundles" + nsName, Tag: "gateways", Summary: "Delete a GatewayBundle"},
+		{Method: http.MethodGet, Path: "/gatewaybundles" + nsName + "/status", Tag: "gateways", Summary: "GatewayBundle status"},
+		{Method: http.MethodGet, Path: "/gatewaybundles" + nsName + "/history", Tag: "gateways", Summary: "GatewayBundle reconcile history", Query: []string{"limit"}, Response: handlers.ReconcileHistoryResponse{}},
+
+		{Method: http.MethodGet, Path: "/httproutes", Tag: "routes", Summary: "List HTTPRoutes", Query: []string{"namespace"}, Response: []handlers.HTTPRouteResponse{}},
+		{Method: http.MethodPost, Path: "/httproutes", Tag: "routes", Summary: "Create an HTTPRoute", Query: []string{"validateBackends"}, Request: handlers.CreateHTTPRouteRequest{}, Response: handlers.HTTPRouteResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/httproutes" + nsName, Tag: "routes", Summary: "Get an HTTPRoute", Response: handlers.HTTPRouteResponse{}},
+		{Method: http.MethodPut, Path: "/httproutes" + nsName, Tag: "routes", Summary: "Update an HTTPRoute", Query: []string{"validateBackends"}, Request: handlers.UpdateHTTPRouteRequest{}, Response: handlers.HTTPRouteResponse{}},
+		{Method: http.MethodDelete, Path: "/httproutes" + nsName, Tag: "routes", Summary: "Delete an HTTPRoute"},
+		{Method: http.MethodPost, Path: "/httproutes" + nsName + "/simulate", Tag: "routes", Summary: "Simulate a request against an HTTPRoute", Request: handlers.SimulateRequest{}, Response: handlers.SimulateResponse{}},
+
+		{Method: http.MethodGet, Path: "/grpcroutes", Tag: "routes", Summary: "List GRPCRoutes", Query: []string{"namespace"}, Response: []handlers.GRPCRouteResponse{}},
+		{Method: http.MethodPost, Path: "/grpcroutes", Tag: "routes", Summary: "Create a GRPCRoute", Request: handlers.CreateGRPCRouteRequest{}, Response: handlers.GRPCRouteResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/grpcroutes" + nsName, Tag: "routes", Summary: "Get a GRPCRoute", Response: handlers.GRPCRouteResponse{}},
+		{Method: http.MethodPut, Path: "/grpcroutes" + nsName, Tag: "routes", Summary: "Update a GRPCRoute", Request: handlers.UpdateGRPCRouteRequest{}, Response: handlers.GRPCRouteResponse{}},
+		{Method: http.MethodDelete, Path: "/grpcroutes" + nsName, Tag: "routes", Summary: "Delete a GRPCRoute"},
+	}
+
+	for _, l4 := range []struct{ path, kind string }{
+		{"/tlsroutes", "TLSRoute"},
+		{"/tcproutes", "TCPRoute"},
+		{"/udproutes", "UDPRoute"},
+	} {
+		ops = append(ops,
+			openapi.Operation{Method: http.MethodGet, Path: l4.path, Tag: "routes", Summary: "List " + l4.kind + "s", Query: []string{"namespace"}, Response: []handlers.L4RouteResponse{}},
+			openapi.Operation{Method: http.MethodPost, Path: l4.path, Tag: "routes", Summary: "Create a " + l4.kind, Request: handlers.CreateL4RouteRequest{}, Response: handlers.L4RouteResponse{}, Status: http.StatusCreated},
+			openapi.Operation{Method: http.MethodGet, Path: l4.path + nsName, Tag: "routes", Summary: "Get a " + l4.kind, Response: handlers.L4RouteResponse{}},
+			openapi.Operation{Method: http.MethodPut, Path: l4.path + nsName, Tag: "routes", Summary: "Update a " + l4.kind, Request: handlers.UpdateL4RouteRequest{}, Response: handlers.L4RouteResponse{}},
+			openapi.Operation{Method: http.MethodDelete, Path: l4.path + nsName, Tag: "routes", Summary: "Delete a " + l4.kind},
+		)
+	}
+
+	return append(ops, []openapi.Operation{
+		{Method: http.MethodGet, Path: "/policies/{type}", Tag: "policies", Summary: "List policies of a type", Query: []string{"namespace"}, Response: []handlers.PolicyResponse{}},
+		{Method: http.MethodPost, Path: "/policies/{type}", Tag: "policies", Summary: "Create a policy", Request: handlers.CreatePolicyRequest{}, Response: handlers.PolicyResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/policies/{type}/{name}", Tag: "policies", Summary: "Get a policy", Query: []string{"namespace"}, Response: handlers.PolicyResponse{}},
+		{Method: http.MethodPut, Path: "/policies/{type}/{name}", Tag: "policies", Summary: "Update a policy", Query: []string{"namespace"}, Request: handlers.UpdatePolicyRequest{}, Response: handlers.PolicyResponse{}},
+		{Method: http.MethodDelete, Path: "/policies/{type}/{name}", Tag: "policies", Summary: "Delete a policy", Query: []string{"namespace"}},
+		{Method: http.MethodGet, Path: "/policies/{type}/conflicts", Tag: "policies", Summary: "Policy conflicts (not implemented)"},
+
+		{Method: http.MethodGet, Path: "/certificates", Tag: "certificates", Summary: "List certificates", Query: []string{"namespace"}, Response: []handlers.CertificateResponse{}},
+		{Method: http.MethodPost, Path: "/certificates", Tag: "certificates", Summary: "Create a certificate (not implemented)"},
+		{Method: http.MethodGet, Path: "/certificates/expiring", Tag: "certificates", Summary: "Certificates close to expiry", Response: []handlers.CertificateResponse{}},
+		{Method: http.MethodGet, Path: "/certificates/{name}", Tag: "certificates", Summary: "Get a certificate", Query: []string{"namespace"}, Response: handlers.CertificateResponse{}},
+		{Method: http.MethodDelete, Path: "/certificates/{name}", Tag: "certificates", Summary: "Delete a certificate", Query: []string{"namespace"}},
+
+		{Method: http.MethodGet, Path: "/metrics/summary", Tag: "metrics", Summary: "RED metrics summary", Query: []string{"window"}, Response: prom.MetricsSummary{}},
+		{Method: http.MethodGet, Path: "/metrics/by-route", Tag: "metrics", Summary: "RED metrics by route", Query: []string{"window"}, Response: []prom.RouteMetrics{}},
+		{Method: http.MethodGet, Path: "/metrics/by-gateway", Tag: "metrics", Summary: "RED metrics by gateway", Query: []string{"window"}, Response: []prom.GatewayMetrics{}},
+		{Method: http.MethodGet, Path: "/metrics/recording-rules", Tag: "metrics", Summary: "Prometheus recording rules", Query: []string{"format", "alerts"}, Response: prom.RuleFile{}},
+
+		{Method: http.MethodPost, Path: "/logs/query", Tag: "logs", Summary: "Query access logs", Request: handlers.LogQueryRequest{}, Response: []handlers.AccessLogEntry{}},
+		{Method: http.MethodGet, Path: "/logs/topn", Tag: "logs", Summary: "Top values of a log field", Query: []string{"field", "n"}, Response: []handlers.TopNLogEntry{}},
+
+		{Method: http.MethodGet, Path: "/topology/full", Tag: "topology", Summary: "Full topology graph", Response: handlers.TopologyResponse{}},
+		{Method: http.MethodGet, Path: "/topology/by-gateway/{name}", Tag: "topology", Summary: "Topology of one gateway (not implemented)"},
+
+		{Method: http.MethodPost, Path: "/diagnostics/route-check", Tag: "diagnostics", Summary: "Check a route's configuration", Request: handlers.RouteCheckRequest{}, Response: handlers.RouteCheckResponse{}},
+		{Method: http.MethodPost, Path: "/diagnostics/trace", Tag: "diagnostics", Summary: "Trace a request through the data plane", Request: handlers.TraceRequest{}, Response: handlers.TraceResponse{}},
+
+		{Method: http.MethodGet, Path: "/gpu/nodes", Tag: "gpu", Summary: "GPU node inventory", Response: []handlers.GPUNodeResponse{}},
+
+		{Method: http.MethodGet, Path: "/inference/backends", Tag: "inference", Summary: "Serving backends and default images", Response: []handlers.ServingBackendResponse{}},
+		{Method: http.MethodGet, Path: "/inference/pools", Tag: "inference", Summary: "List InferencePools", Response: []handlers.InferencePoolResponse{}},
+		{Method: http.MethodGet, Path: "/inference/pools/{name}", Tag: "inference", Summary: "Get an InferencePool", Response: handlers.InferencePoolResponse{}},
+		{Method: http.MethodGet, Path: "/inference/pools/{name}/wait", Tag: "inference", Summary: "Wait for an InferencePool to become ready", Query: []string{"timeout"}, Response: handlers.InferencePoolWaitResponse{}},
+		{Method: http.MethodGet, Path: "/inference/pools/{name}/metrics/stream", Tag: "inference", Summary: "Live pool metrics (server-sent events)", ContentType: "text/event-stream"},
+		{Method: http.MethodPost, Path: "/inference/pools", Tag: "inference", Summary: "Create an InferencePool", Request: handlers.CreatePoolRequest{}, Response: handlers.InferenceStackResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodPut, Path: "/inference/pools/{name}", Tag: "inference", Summary: "Update an InferencePool", Request: handlers.UpdatePoolRequest{}, Response: handlers.InferenceStackResponse{}},
+		{Method: http.MethodDelete, Path: "/inference/pools/{name}", Tag: "inference", Summary: "Delete an InferencePool"},
+		{Method: http.MethodPost, Path: "/inference/pools/{name}/deploy", Tag: "inference", Summary: "Redeploy an InferencePool"},
+		{Method: http.MethodGet, Path: "/inference/pools/{name}/history", Tag: "inference", Summary: "InferencePool reconcile history", Query: []string{"limit"}, Response: handlers.ReconcileHistoryResponse{}},
+		{Method: http.MethodGet, Path: "/inference/epp", Tag: "inference", Summary: "Get a pool's EPP config", Query: []string{"pool"}, Response: handlers.EPPConfigResponse{}},
+		{Method: http.MethodPut, Path: "/inference/epp", Tag: "inference", Summary: "Update a pool's EPP config", Request: handlers.UpdateEPPRequest{}, Response: handlers.EPPConfigResponse{}},
+		{Method: http.MethodGet, Path: "/inference/autoscaling", Tag: "inference", Summary: "Get a pool's autoscaling config", Query: []string{"pool"}, Response: handlers.AutoscalingConfigResponse{}},
+		{Method: http.MethodPut, Path: "/inference/autoscaling", Tag: "inference", Summary: "Update a pool's autoscaling config", Request: handlers.UpdateAutoscalingRequest{}, Response: handlers.AutoscalingConfigResponse{}},
+
+		{Method: http.MethodGet, Path: "/inference/metrics/summary", Tag: "inference", Summary: "Inference metrics summary", Query: []string{"pool"}, Response: handlers.InferenceMetricsSummaryResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/by-pool", Tag: "inference", Summary: "Inference metrics by pool (not implemented)"},
+		{Method: http.MethodGet, Path: "/inference/metrics/pods", Tag: "inference", Summary: "Per-pod GPU metrics", Query: []string{"pool"}, Response: []handlers.PodGPUMetricsResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/cost", Tag: "inference", Summary: "Cost estimate", Query: []string{"pool"}, Response: handlers.CostEstimateResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/epp-decisions", Tag: "inference", Summary: "Recent EPP routing decisions", Query: []string{"pool", "limit"}, Response: []handlers.EPPDecisionResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/ttft-histogram/{pool}", Tag: "inference", Summary: "Time-to-first-token histogram", Response: []handlers.HistogramBucketResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/tps-throughput/{pool}", Tag: "inference", Summary: "Tokens-per-second series", Query: []string{"window", "model"}, Response: []handlers.TimeseriesPointResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/queue-depth/{pool}", Tag: "inference", Summary: "Queue depth series", Query: []string{"window"}, Response: []handlers.TimeseriesPointResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/gpu-util/{pool}", Tag: "inference", Summary: "GPU utilization series", Query: []string{"window"}, Response: []handlers.TimeseriesPointResponse{}},
+		{Method: http.MethodGet, Path: "/inference/metrics/kv-cache/{pool}", Tag: "inference", Summary: "KV cache utilization series", Query: []string{"window"}, Response: []handlers.TimeseriesPointResponse{}},
+
+		{Method: http.MethodGet, Path: "/inference/diagnostics/slow", Tag: "inference", Summary: "Slow inference requests", Query: []string{"pool", "timeRange"}, Response: handlers.SlowInferenceResponse{}},
+		{Method: http.MethodPost, Path: "/inference/diagnostics/replay", Tag: "inference", Summary: "Replay an inference request", Request: handlers.ReplayRequest{}, Response: handlers.ReplayResponse{}},
+		{Method: http.MethodPost, Path: "/inference/diagnostics/benchmark", Tag: "inference", Summary: "Benchmark a pool", Request: handlers.BenchmarkRequest{}, Response: handlers.BenchmarkResponse{}},
+
+		{Method: http.MethodGet, Path: "/inference/stacks", Tag: "inference", Summary: "List InferenceStacks", Response: []handlers.InferenceStackResponse{}},
+		{Method: http.MethodPost, Path: "/inference/stacks", Tag: "inference", Summary: "Create an InferenceStack", Request: handlers.CreateInferenceStackRequest{}, Response: handlers.InferenceStackResponse{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/inference/stacks" + nsName, Tag: "inference", Summary: "Get an InferenceStack", Response: handlers.InferenceStackResponse{}},
+		{Method: http.MethodPut, Path: "/inference/stacks" + nsName, Tag: "inference", Summary: "Update an InferenceStack", Request: handlers.CreateInferenceStackRequest{}, Response: handlers.InferenceStackResponse{}},
+		{Method: http.MethodDelete, Path: "/inference/stacks" + nsName, Tag: "inference", Summary: "Delete an InferenceStack"},
+		{Method: http.MethodGet, Path: "/inference/stacks" + nsName + "/status", Tag: "inference", Summary: "InferenceStack status"},
+
+		{Method: http.MethodGet, Path: "/coexistence/overview", Tag: "coexistence", Summary: "NGINX Ingress and Gateway API coexistence overview", Query: []string{"namespace", "includeSystem", "allClusters", "clusterSelector"}, Response: handlers.CoexistenceOverview{}},
+		{Method: http.MethodGet, Path: "/coexistence/migration-readiness", Tag: "coexistence", Summary: "Migration readiness score", Query: []string{"namespace", "includeSystem"}, Response: handlers.MigrationReadinessResponse{}},
+
+		{Method: http.MethodGet, Path: "/xc/status", Tag: "xc", Summary: "F5 Distributed Cloud connection status", Query: []string{"scope"}, Response: handlers.XCStatusResponse{}},
+		{Method: http.MethodGet, Path: "/xc/metrics", Tag: "xc", Summary: "Distributed Cloud traffic metrics", Query: []string{"scope", "loadBalancer", "window"}, Response: handlers.XCMetricsResponse{}},
+		{Method: http.MethodPost, Path: "/xc/credentials", Tag: "xc", Summary: "Save Distributed Cloud credentials", Request: handlers.XCCredentialsRequest{}, Response: handlers.XCCredentialsResponse{}},
+		{Method: http.MethodGet, Path: "/xc/credentials", Tag: "xc", Summary: "Get Distributed Cloud credentials", Query: []string{"scope"}, Response: handlers.XCCredentialsResponse{}},
+		{Method: http.MethodGet, Path: "/xc/credentials/scopes", Tag: "xc", Summary: "List credential scopes", Response: []handlers.XCCredentialsResponse{}},
+		{Method: http.MethodDelete, Path: "/xc/credentials", Tag: "xc", Summary: "Delete Distributed Cloud credentials", Query: []string{"scope"}},
+		{Method: http.MethodPost, Path: "/xc/test-connection", Tag: "xc", Summary: "Test the Distributed Cloud credentials", Query: []string{"scope"}, Response: handlers.XCTestConnectionResponse{}},
+		{Method: http.MethodGet, Path: "/xc/publishes", Tag: "xc", Summary: "List publishes", Response: []handlers.XCPublishResponse{}},
+		{Method: http.MethodPost, Path: "/xc/publishes/resync", Tag: "xc", Summary: "Resync all publishes", Response: handlers.XCResyncResponse{}},
+		{Method: http.MethodPost, Path: "/xc/publish", Tag: "xc", Summary: "Publish a route to Distributed Cloud", Query: []string{"allowPending"}, Request: handlers.XCPublishRequest{}, Response: handlers.XCPublishResponse{}, Status: http.StatusAccepted},
+		{Method: http.MethodPost, Path: "/xc/preview", Tag: "xc", Summary: "Preview the objects a publish creates", Request: handlers.XCPreviewRequest{}, Response: handlers.XCPreviewResponse{}},
+		{Method: http.MethodGet, Path: "/xc/publish" + nsName, Tag: "xc", Summary: "Get a publish", Response: handlers.XCPublishResponse{}},
+		{Method: http.MethodDelete, Path: "/xc/publish" + nsName, Tag: "xc", Summary: "Delete a publish"},
+		{Method: http.MethodGet, Path: "/xc/waf-policies", Tag: "xc", Summary: "List WAF policies", Query: []string{"scope", "refresh"}, Response: []handlers.WAFPolicyResponse{}},
+
+		{Method: http.MethodPost, Path: "/migration/import", Tag: "migration", Summary: "Import NGINX Ingress resources", Request: handlers.ImportRequest{}, Response: handlers.ImportResponse{}},
+		{Method: http.MethodPost, Path: "/migration/analysis", Tag: "migration", Summary: "Analyze an import", Request: handlers.AnalysisRequest{}, Response: handlers.AnalysisResponse{}},
+		{Method: http.MethodPost, Path: "/migration/generate", Tag: "migration", Summary: "Generate Gateway API resources", Request: handlers.GenerateRequest{}, Response: handlers.GenerateResponse{}},
+		{Method: http.MethodPost, Path: "/migration/apply", Tag: "migration", Summary: "Apply generated resources", Request: handlers.ApplyRequest{}, Response: handlers.ApplyResponse{}},
+		{Method: http.MethodGet, Path: "/migration/apply-status", Tag: "migration", Summary: "Apply progress", Query: []string{"importId"}, Response: handlers.ApplyStatusResponse{}},
+		{Method: http.MethodPost, Path: "/migration/validate", Tag: "migration", Summary: "Validate applied resources", Request: handlers.ValidateRequest{}, Response: handlers.ValidateResponse{}},
+		{Method: http.MethodGet, Path: "/migration/provenance", Tag: "migration", Summary: "Migration provenance of a resource", Query: []string{"kind", "resource"}, Response: handlers.ProvenanceResponse{}},
+
+		{Method: http.MethodPost, Path: "/resources/label", Tag: "resources", Summary: "Bulk add or remove labels", Request: handlers.BulkLabelRequest{}, Response: handlers.BulkLabelResponse{}},
+
+		{Method: http.MethodGet, Path: "/audit", Tag: "audit", Summary: "List audit entries", Query: []string{"resource", "action", "namespace", "user", "cluster", "outcome", "since", "until", "limit", "offset"}},
+		{Method: http.MethodGet, Path: "/audit/diff/{id}", Tag: "audit", Summary: "Before and after of an audit entry"},
+
+		{Method: http.MethodGet, Path: "/alerts", Tag: "alerts", Summary: "List alert rules", Response: []database.AlertRule{}},
+		{Method: http.MethodPost, Path: "/alerts", Tag: "alerts", Summary: "Create an alert rule", Request: untypedBody, Response: database.AlertRule{}, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: "/alerts/firing", Tag: "alerts", Summary: "Firing alerts", Response: []alerting.FiringAlert{}},
+		{Method: http.MethodGet, Path: "/alerts/{id}", Tag: "alerts", Summary: "Get an alert rule", Response: database.AlertRule{}},
+		{Method: http.MethodPut, Path: "/alerts/{id}", Tag: "alerts", Summary: "Update an alert rule", Request: untypedBody, Response: database.AlertRule{}},
+		{Method: http.MethodDelete, Path: "/alerts/{id}", Tag: "alerts", Summary: "Delete an alert rule"},
+		{Method: http.MethodPost, Path: "/alerts/{id}/toggle", Tag: "alerts", Summary: "Enable or disable an alert rule", Response: database.AlertRule{}},
+
+		{Method: http.MethodGet, Path: "/support/bundle", Tag: "support", Summary: "Download a support bundle", Query: []string{"namespaces"}, ContentType: "application/gzip"},
+		{Method: http.MethodGet, Path: "/selftest", Tag: "support", Summary: "Check every configured integration", Response: handlers.SelfTestResponse{}},
+	}...)
+}
+
+// apiOperations lists every route: hub routes, plus resource routes both on
+// the legacy /api/v1 paths and scoped to a cluster.
+func apiOperations() []openapi.Operation {
+	ops := hubOperations()
+	for _, prefix := range []string{"/api/v1", clusterPrefix} {
+		for _, op := range resourceOperations() {
+			op.Path = prefix + op.Path
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// openAPIDocument builds the document served at OpenAPIPath.
+func openAPIDocument() (*openapi.Document, error) {
+	return openapi.Build(openapi.Info{
+		Title:       "NGF Console API",
+		Version:     version.Version,
+		Description: "Resource routes are served both under /api/v1, against the default cluster, and under /api/v1/clusters/{cluster}.",
+	}, apiOperations())
+}
+
+// handleOpenAPI serves the OpenAPI document, encoded once up front.
+func handleOpenAPI() http.HandlerFunc {
+	doc, err := openAPIDocument()
+	var body []byte
+	if err == nil {
+		body, err = json.Marshal(doc)
+	}
+	if err != nil {
+		slog.Error("failed to build OpenAPI document", "error", err)
+		return func(w http.ResponseWriter, r *http.Request) {
+			writeMiddlewareError(w, http.StatusInternalServerError, "OpenAPI document unavailable")
+		}
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
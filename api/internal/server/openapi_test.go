@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kubenetlabs/ngc/api/internal/openapi"
+)
+
+// TestOpenAPI_MatchesRouter checks that every registered route is documented
+// and every documented route is registered.
+func TestOpenAPI_MatchesRouter(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+	router := ts.Config.Handler.(chi.Router)
+
+	registered := make(map[string]bool)
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered[method+" "+normalizeRoute(route)] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+
+	documented := make(map[string]bool)
+	for _, op := range apiOperations() {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	for _, route := range sortedKeys(registered) {
+		if !documented[route] {
+			t.Errorf("route %s is not in the OpenAPI document", route)
+		}
+	}
+	for _, route := range sortedKeys(documented) {
+		if !registered[route] {
+			t.Errorf("documented route %s is not registered", route)
+		}
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + OpenAPIPath)
+	if err != nil {
+		t.Fatalf("GET %s: %v", OpenAPIPath, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var doc openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("decoding document: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", doc.OpenAPI)
+	}
+
+	for _, ref := range doc.Refs() {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("unresolved $ref %s", ref)
+		}
+	}
+
+	// Spot-check that the request and response types are described.
+	publish := doc.Paths["/api/v1/clusters/{cluster}/xc/publish"]["post"]
+	if publish == nil || publish.RequestBody == nil {
+		t.Fatal("POST /xc/publish has no request body")
+	}
+	if ref := publish.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/XCPublishRequest" {
+		t.Errorf("publish request $ref = %q", ref)
+	}
+	for _, name := range []string{"CreatePoolRequest", "GatewayResponse", "XCPublishResponse"} {
+		s, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s missing", name)
+			continue
+		}
+		if len(s.Properties) == 0 {
+			t.Errorf("schema %s has no properties", name)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handleOpenAPI()(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("handler status = %d, want 200", rec.Code)
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
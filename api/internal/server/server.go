@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -15,6 +14,7 @@ import (
 	chimw "github.com/go-chi/chi/v5/middleware"
 
 	"github.com/kubenetlabs/ngc/api/internal/alerting"
+	"github.com/kubenetlabs/ngc/api/internal/auth"
 	ch "github.com/kubenetlabs/ngc/api/internal/clickhouse"
 	"github.com/kubenetlabs/ngc/api/internal/cluster"
 	"github.com/kubenetlabs/ngc/api/internal/database"
@@ -24,22 +24,24 @@ import (
 	prom "github.com/kubenetlabs/ngc/api/internal/prometheus"
 )
 
-// writeNotImpl sends a 501 Not Implemented JSON response.
-func writeNotImpl(w http.ResponseWriter) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotImplemented)
-	json.NewEncoder(w).Encode(map[string]string{"error": "not implemented"})
-}
-
 // Config holds server dependencies.
 type Config struct {
-	ClusterManager  cluster.Provider
-	MetricsProvider inference.MetricsProvider
-	Store           database.Store
-	PromClient      *prom.Client
-	CHClient        *ch.Client
-	Webhooks        []alerting.WebhookConfig
-	Pool            *mc.ClientPool // non-nil when using CRD-based multi-cluster
+	ClusterManager    cluster.Provider
+	MetricsProvider   inference.MetricsProvider
+	Store             database.Store
+	PromClient        *prom.Client
+	MetricNames       prom.MetricNames // NGF metric names used for recording rules
+	CHClient          *ch.Client
+	Webhooks          []alerting.WebhookConfig
+	SlackChannels     []alerting.SlackConfig
+	PagerDuty         []alerting.PagerDutyConfig
+	Pool              *mc.ClientPool                  // non-nil when using CRD-based multi-cluster
+	ClusterCheck      func(ctx context.Context) error // API server connectivity check for /readyz; nil means always ready
+	WAFPolicyTTL      time.Duration                   // XC WAF policy listing cache TTL; zero uses the handler default
+	ExcludeNamespaces []string                        // namespaces coexistence discovery skips unless ?includeSystem=true
+
+	RequireHeartbeatToken bool          // reject heartbeats from clusters with no token issued
+	Auth                  auth.Provider // authenticates API callers; nil disables authentication
 }
 
 // Server is the main HTTP server for the NGF Console API.
@@ -55,18 +57,21 @@ func New(cfg Config) *Server {
 	r := chi.NewRouter()
 
 	// Global middleware
-	r.Use(chimw.RequestID)
+	r.Use(RequestID)
 	r.Use(RequestLogger)
 	r.Use(CORSMiddleware)
 	r.Use(chimw.Recoverer)
 	r.Use(MaxBodySize(1 << 20)) // 1MB max body size
+	r.Use(AuthMiddleware(cfg.Auth))
+	r.Use(AuditMutations(cfg.Store, cfg.ClusterManager))
+	r.Use(RequireWriteRole) // after auditing, so rejected writes are recorded
 
 	hub := NewHub()
 	RegisterInferenceTopics(hub, cfg.MetricsProvider)
 	hub.Start()
 
 	// Create and start the alert evaluator.
-	eval := alerting.New(cfg.Store, cfg.Webhooks)
+	eval := alerting.New(cfg.Store, cfg.Webhooks, cfg.SlackChannels, cfg.PagerDuty)
 	eval.Start(context.Background())
 
 	s := &Server{Router: r, Config: cfg, Hub: hub, Evaluator: eval}
@@ -115,36 +120,64 @@ func (s *Server) Run(addr string) error {
 func (s *Server) registerRoutes() {
 	gw := &handlers.GatewayHandler{Store: s.Config.Store}
 	rt := &handlers.RouteHandler{Store: s.Config.Store}
+	grpcRt := &handlers.GRPCRouteHandler{Store: s.Config.Store}
+	tlsRt := &handlers.L4RouteHandler{Store: s.Config.Store, Kind: "TLSRoute"}
+	tcpRt := &handlers.L4RouteHandler{Store: s.Config.Store, Kind: "TCPRoute"}
+	udpRt := &handlers.L4RouteHandler{Store: s.Config.Store, Kind: "UDPRoute"}
 	cfgHandler := &handlers.ConfigHandler{}
-	clusterHandler := &handlers.ClusterHandler{Manager: s.Config.ClusterManager, Pool: s.Config.Pool}
+	clusterHandler := &handlers.ClusterHandler{
+		Manager:               s.Config.ClusterManager,
+		Pool:                  s.Config.Pool,
+		Store:                 s.Config.Store,
+		RequireHeartbeatToken: s.Config.RequireHeartbeatToken,
+	}
 	pol := &handlers.PolicyHandler{Store: s.Config.Store}
 	cert := &handlers.CertificateHandler{Store: s.Config.Store}
-	met := &handlers.MetricsHandler{Prom: s.Config.PromClient}
+	met := &handlers.MetricsHandler{Prom: s.Config.PromClient, Names: s.Config.MetricNames}
 	lg := &handlers.LogHandler{CH: s.Config.CHClient}
+	ret := &handlers.RetentionHandler{CH: s.Config.CHClient}
 	topo := &handlers.TopologyHandler{}
 	diag := &handlers.DiagnosticsHandler{}
+	gpu := &handlers.GPUHandler{}
 	inf := &handlers.InferenceHandler{Provider: s.Config.MetricsProvider, Store: s.Config.Store}
 	infMet := &handlers.InferenceMetricsHandler{Provider: s.Config.MetricsProvider}
 	infDiag := &handlers.InferenceDiagHandler{}
 	infStack := &handlers.InferenceStackHandler{MetricsProvider: s.Config.MetricsProvider, Store: s.Config.Store}
 	gwBundle := &handlers.GatewayBundleHandler{Store: s.Config.Store}
-	coex := &handlers.CoexistenceHandler{}
-	xc := &handlers.XCHandler{Store: s.Config.Store}
-	mig := &handlers.MigrationHandler{}
+	coex := &handlers.CoexistenceHandler{ExcludeNamespaces: s.Config.ExcludeNamespaces, Manager: s.Config.ClusterManager}
+	xc := &handlers.XCHandler{Store: s.Config.Store, WAFPolicyCacheTTL: s.Config.WAFPolicyTTL}
+	mig := &handlers.MigrationHandler{Store: s.Config.Store}
 	aud := &handlers.AuditHandler{Store: s.Config.Store}
 	alert := &handlers.AlertHandler{Store: s.Config.Store, Evaluator: s.Evaluator}
+	res := &handlers.ResourceHandler{Store: s.Config.Store}
+	sup := &handlers.SupportHandler{}
+	st := &handlers.SelfTestHandler{Store: s.Config.Store, CHClient: s.Config.CHClient, PromClient: s.Config.PromClient}
 
 	globalHandler := &handlers.GlobalHandler{Pool: s.Config.Pool, Manager: s.Config.ClusterManager}
+	versionHandler := &handlers.VersionHandler{Manager: s.Config.ClusterManager}
 
 	// Health check endpoint (outside /api/v1 for simplicity with probes)
 	s.Router.Get("/api/v1/health", handlers.HealthCheck)
 
+	// Readiness reflects whether the cluster API server is reachable.
+	readiness := &handlers.ReadinessHandler{Check: s.Config.ClusterCheck}
+	s.Router.Get("/readyz", readiness.Readyz)
+
+	// Component version matrix
+	s.Router.Get("/version", versionHandler.Get)
+
+	// OpenAPI document describing every route below
+	s.Router.Get(OpenAPIPath, handleOpenAPI())
+
 	s.Router.Route("/api/v1", func(r chi.Router) {
 		// Cluster management (hub-level, no cluster middleware)
 		r.Get("/clusters", clusterHandler.List)
 		r.Post("/clusters", clusterHandler.Register)
 		r.Get("/clusters/summary", clusterHandler.Summary)
 
+		// ClickHouse data retention (hub-level)
+		r.With(RequireClickHouse(s.Config.CHClient)).Get("/retention", ret.Get)
+
 		// Global cross-cluster aggregation endpoints
 		r.Route("/global", func(r chi.Router) {
 			r.Get("/gateways", globalHandler.Gateways)
@@ -156,22 +189,25 @@ func (s *Server) registerRoutes() {
 		r.Route("/clusters/{cluster}", func(r chi.Router) {
 			// Management endpoints (matched by specific sub-paths first)
 			r.Get("/detail", clusterHandler.Get)
+			r.Get("/health", clusterHandler.Health)
 			r.Delete("/", clusterHandler.Unregister)
 			r.Post("/test", clusterHandler.TestConnection)
 			r.Post("/install-agent", clusterHandler.InstallAgent)
 			r.Post("/heartbeat", clusterHandler.Heartbeat)
+			r.With(RBACMiddleware(auth.RoleAdmin)).Post("/rotate-token", clusterHandler.RotateToken)
+			r.Get("/heartbeats", clusterHandler.Heartbeats)
 
 			// Cluster-scoped resource routes
 			r.Group(func(r chi.Router) {
 				r.Use(ClusterResolver(s.Config.ClusterManager))
-				s.mountResourceRoutes(r, gw, rt, cfgHandler, pol, cert, met, lg, topo, diag, inf, infMet, infDiag, infStack, gwBundle, coex, xc, mig, aud, alert)
+				s.mountResourceRoutes(r, gw, rt, grpcRt, tlsRt, tcpRt, udpRt, cfgHandler, pol, cert, met, lg, topo, diag, gpu, inf, infMet, infDiag, infStack, gwBundle, coex, xc, mig, aud, alert, res, sup, st)
 			})
 		})
 
 		// Legacy routes (backward compat — uses default cluster)
 		r.Group(func(r chi.Router) {
 			r.Use(ClusterResolver(s.Config.ClusterManager))
-			s.mountResourceRoutes(r, gw, rt, cfgHandler, pol, cert, met, lg, topo, diag, inf, infMet, infDiag, infStack, gwBundle, coex, xc, mig, aud, alert)
+			s.mountResourceRoutes(r, gw, rt, grpcRt, tlsRt, tcpRt, udpRt, cfgHandler, pol, cert, met, lg, topo, diag, gpu, inf, infMet, infDiag, infStack, gwBundle, coex, xc, mig, aud, alert, res, sup, st)
 		})
 
 		// WebSocket
@@ -187,11 +223,18 @@ func (s *Server) registerRoutes() {
 	})
 }
 
+// requireInferenceStackCRD gates routes backed by InferenceStack CRs on the
+// CRD being installed in the resolved cluster.
+var requireInferenceStackCRD = RequireCRD("inferencestacks.ngf-console.f5.com",
+	"InferenceStack CRD not installed; install the ngf-console operator")
+
 // mountResourceRoutes registers all resource routes on the given router.
 func (s *Server) mountResourceRoutes(
 	r chi.Router,
 	gw *handlers.GatewayHandler,
 	rt *handlers.RouteHandler,
+	grpcRt *handlers.GRPCRouteHandler,
+	tlsRt, tcpRt, udpRt *handlers.L4RouteHandler,
 	cfgHandler *handlers.ConfigHandler,
 	pol *handlers.PolicyHandler,
 	cert *handlers.CertificateHandler,
@@ -199,6 +242,7 @@ func (s *Server) mountResourceRoutes(
 	lg *handlers.LogHandler,
 	topo *handlers.TopologyHandler,
 	diag *handlers.DiagnosticsHandler,
+	gpu *handlers.GPUHandler,
 	inf *handlers.InferenceHandler,
 	infMet *handlers.InferenceMetricsHandler,
 	infDiag *handlers.InferenceDiagHandler,
@@ -209,6 +253,9 @@ func (s *Server) mountResourceRoutes(
 	mig *handlers.MigrationHandler,
 	aud *handlers.AuditHandler,
 	alert *handlers.AlertHandler,
+	res *handlers.ResourceHandler,
+	sup *handlers.SupportHandler,
+	st *handlers.SelfTestHandler,
 ) {
 	// Config
 	r.Get("/config", cfgHandler.GetConfig)
@@ -233,10 +280,13 @@ func (s *Server) mountResourceRoutes(
 	r.Route("/gatewaybundles", func(r chi.Router) {
 		r.Get("/", gwBundle.List)
 		r.Post("/", gwBundle.Create)
+		r.Post("/validate", gwBundle.Validate)
 		r.Get("/{namespace}/{name}", gwBundle.Get)
 		r.Put("/{namespace}/{name}", gwBundle.Update)
+		r.Patch("/{namespace}/{name}", gwBundle.Patch)
 		r.Delete("/{namespace}/{name}", gwBundle.Delete)
 		r.Get("/{namespace}/{name}/status", gwBundle.GetStatus)
+		r.Get("/{namespace}/{name}/history", gwBundle.History)
 	})
 
 	// HTTP Routes (namespace-aware)
@@ -249,40 +299,40 @@ func (s *Server) mountResourceRoutes(
 		r.Post("/{namespace}/{name}/simulate", rt.Simulate)
 	})
 
-	// gRPC Routes (not yet implemented — return 501)
+	// gRPC Routes (namespace-aware)
 	r.Route("/grpcroutes", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Get("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Put("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Delete("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
+		r.Get("/", grpcRt.List)
+		r.Post("/", grpcRt.Create)
+		r.Get("/{namespace}/{name}", grpcRt.Get)
+		r.Put("/{namespace}/{name}", grpcRt.Update)
+		r.Delete("/{namespace}/{name}", grpcRt.Delete)
 	})
 
-	// TLS Routes (not yet implemented — return 501)
+	// TLS Routes (gateway.networking.k8s.io/v1alpha2)
 	r.Route("/tlsroutes", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Get("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Put("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Delete("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
+		r.Get("/", tlsRt.List)
+		r.Post("/", tlsRt.Create)
+		r.Get("/{namespace}/{name}", tlsRt.Get)
+		r.Put("/{namespace}/{name}", tlsRt.Update)
+		r.Delete("/{namespace}/{name}", tlsRt.Delete)
 	})
 
-	// TCP Routes (not yet implemented — return 501)
+	// TCP Routes (gateway.networking.k8s.io/v1alpha2)
 	r.Route("/tcproutes", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Get("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Put("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Delete("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
+		r.Get("/", tcpRt.List)
+		r.Post("/", tcpRt.Create)
+		r.Get("/{namespace}/{name}", tcpRt.Get)
+		r.Put("/{namespace}/{name}", tcpRt.Update)
+		r.Delete("/{namespace}/{name}", tcpRt.Delete)
 	})
 
-	// UDP Routes (not yet implemented — return 501)
+	// UDP Routes (gateway.networking.k8s.io/v1alpha2)
 	r.Route("/udproutes", func(r chi.Router) {
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Get("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Put("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
-		r.Delete("/{namespace}/{name}", func(w http.ResponseWriter, r *http.Request) { writeNotImpl(w) })
+		r.Get("/", udpRt.List)
+		r.Post("/", udpRt.Create)
+		r.Get("/{namespace}/{name}", udpRt.Get)
+		r.Put("/{namespace}/{name}", udpRt.Update)
+		r.Delete("/{namespace}/{name}", udpRt.Delete)
 	})
 
 	// Policies
@@ -309,10 +359,12 @@ func (s *Server) mountResourceRoutes(
 		r.Get("/summary", met.Summary)
 		r.Get("/by-route", met.ByRoute)
 		r.Get("/by-gateway", met.ByGateway)
+		r.Get("/recording-rules", met.RecordingRules)
 	})
 
 	// Logs
 	r.Route("/logs", func(r chi.Router) {
+		r.Use(RequireClickHouse(s.Config.CHClient))
 		r.Post("/query", lg.Query)
 		r.Get("/topn", lg.TopN)
 	})
@@ -329,28 +381,49 @@ func (s *Server) mountResourceRoutes(
 		r.Post("/trace", diag.Trace)
 	})
 
+	// GPU inventory
+	r.Route("/gpu", func(r chi.Router) {
+		r.Get("/nodes", gpu.ListNodes)
+	})
+
 	// Inference
 	r.Route("/inference", func(r chi.Router) {
+		// Serving backends and their default model server images
+		r.Get("/backends", inf.ListBackends)
+
 		// Pools
 		r.Route("/pools", func(r chi.Router) {
-			r.Get("/", inf.ListPools)
-			r.Post("/", inf.CreatePool)
-			r.Get("/{name}", inf.GetPool)
-			r.Put("/{name}", inf.UpdatePool)
-			r.Delete("/{name}", inf.DeletePool)
-			r.Post("/{name}/deploy", inf.DeployPool)
+			// Pool reads come from the metrics provider.
+			r.Group(func(r chi.Router) {
+				r.Use(RequireClickHouse(s.Config.CHClient))
+				r.Get("/", inf.ListPools)
+				r.Get("/{name}", inf.GetPool)
+				r.Get("/{name}/wait", inf.WaitPool)
+				r.Get("/{name}/metrics/stream", inf.StreamPoolMetrics)
+			})
+
+			// Pool management reads and writes InferenceStack CRs.
+			r.Group(func(r chi.Router) {
+				r.Use(requireInferenceStackCRD)
+				r.Post("/", inf.CreatePool)
+				r.Put("/{name}", inf.UpdatePool)
+				r.Delete("/{name}", inf.DeletePool)
+				r.Post("/{name}/deploy", inf.DeployPool)
+				r.Get("/{name}/history", inf.PoolHistory)
+			})
 		})
 
 		// EPP
-		r.Get("/epp", inf.GetEPP)
-		r.Put("/epp", inf.UpdateEPP)
+		r.With(requireInferenceStackCRD).Get("/epp", inf.GetEPP)
+		r.With(requireInferenceStackCRD).Put("/epp", inf.UpdateEPP)
 
 		// Autoscaling
-		r.Get("/autoscaling", inf.GetAutoscaling)
-		r.Put("/autoscaling", inf.UpdateAutoscaling)
+		r.With(requireInferenceStackCRD).Get("/autoscaling", inf.GetAutoscaling)
+		r.With(requireInferenceStackCRD).Put("/autoscaling", inf.UpdateAutoscaling)
 
 		// Inference Metrics
 		r.Route("/metrics", func(r chi.Router) {
+			r.Use(RequireClickHouse(s.Config.CHClient))
 			r.Get("/summary", infMet.Summary)
 			r.Get("/by-pool", infMet.ByPool)
 			r.Get("/pods", infMet.PodMetrics)
@@ -372,6 +445,7 @@ func (s *Server) mountResourceRoutes(
 
 		// InferenceStacks (CRD-backed via dynamic client)
 		r.Route("/stacks", func(r chi.Router) {
+			r.Use(requireInferenceStackCRD)
 			r.Get("/", infStack.List)
 			r.Post("/", infStack.Create)
 			r.Get("/{namespace}/{name}", infStack.Get)
@@ -395,11 +469,13 @@ func (s *Server) mountResourceRoutes(
 		// Credential management
 		r.Post("/credentials", xc.SaveCredentials)
 		r.Get("/credentials", xc.GetCredentials)
+		r.Get("/credentials/scopes", xc.ListCredentials)
 		r.Delete("/credentials", xc.DeleteCredentials)
 		r.Post("/test-connection", xc.TestConnection)
 
 		// Publish lifecycle
 		r.Get("/publishes", xc.ListPublishes)
+		r.Post("/publishes/resync", xc.ResyncPublishes)
 		r.Post("/publish", xc.Publish)
 		r.Post("/preview", xc.Preview)
 		r.Get("/publish/{namespace}/{name}", xc.GetPublish)
@@ -415,9 +491,14 @@ func (s *Server) mountResourceRoutes(
 		r.Post("/analysis", mig.Analysis)
 		r.Post("/generate", mig.Generate)
 		r.Post("/apply", mig.Apply)
+		r.Get("/apply-status", mig.ApplyStatus)
 		r.Post("/validate", mig.Validate)
+		r.Get("/provenance", mig.Provenance)
 	})
 
+	// Bulk resource operations
+	r.Post("/resources/label", res.Label)
+
 	// Audit
 	r.Route("/audit", func(r chi.Router) {
 		r.Get("/", aud.List)
@@ -434,4 +515,12 @@ func (s *Server) mountResourceRoutes(
 		r.Delete("/{id}", alert.Delete)
 		r.Post("/{id}/toggle", alert.Toggle)
 	})
+
+	// Support bundles
+	r.Route("/support", func(r chi.Router) {
+		r.Get("/bundle", sup.Bundle)
+	})
+
+	// Self-test of every configured integration
+	r.Get("/selftest", st.Run)
 }
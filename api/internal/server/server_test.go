@@ -274,6 +274,34 @@ func TestServer_ConfigEndpoint(t *testing.T) {
 	}
 }
 
+func TestServer_VersionEndpoint(t *testing.T) {
+	ts := setupTestServer(t)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/version")
+	if err != nil {
+		t.Fatalf("failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	api, ok := body["api"].(map[string]interface{})
+	if !ok || api["version"] == "" {
+		t.Errorf("expected api build info, got %v", body["api"])
+	}
+	if body["cluster"] != "default" {
+		t.Errorf("expected cluster default, got %v", body["cluster"])
+	}
+}
+
 func TestServer_CORSMiddleware(t *testing.T) {
 	ts := setupTestServer(t)
 	defer ts.Close()
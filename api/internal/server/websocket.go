@@ -28,12 +28,12 @@ var upgrader = websocket.Upgrader{
 func HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		slog.Error("websocket upgrade failed", "error", err)
+		slog.ErrorContext(r.Context(), "websocket upgrade failed", "error", err)
 		return
 	}
 	defer conn.Close()
 
-	slog.Info("websocket connected", "remote_addr", r.RemoteAddr)
+	slog.InfoContext(r.Context(), "websocket connected", "remote_addr", r.RemoteAddr)
 
 	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
 	conn.SetPongHandler(func(string) error {
@@ -151,7 +151,7 @@ func (h *Hub) ServeWS(topic string) func(w http.ResponseWriter, r *http.Request)
 	return func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			slog.Error("websocket upgrade failed", "error", err)
+			slog.ErrorContext(r.Context(), "websocket upgrade failed", "error", err)
 			return
 		}
 
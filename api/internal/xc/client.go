@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -16,6 +17,8 @@ type Client struct {
 	apiToken string
 	baseURL  string
 	http     *http.Client
+	limiter  *tenantLimiter
+	retry    RetryPolicy
 }
 
 // New creates a new XC API client for the given tenant.
@@ -27,6 +30,8 @@ func New(tenant, apiToken string) *Client {
 		http: &http.Client{
 			Timeout: 60 * time.Second,
 		},
+		limiter: limiterFor(tenant),
+		retry:   currentRetryPolicy(),
 	}
 }
 
@@ -36,14 +41,50 @@ func (c *Client) Tenant() string {
 }
 
 // do executes an HTTP request against the XC API with Bearer token auth.
+// Requests draw from the tenant's shared rate budget; a 429 response is
+// converted to a RateLimitedError and backs off all clients of the tenant.
+// Throttled and transiently failed requests are retried per the client's
+// RetryPolicy; once retries are exhausted the last outcome is returned.
 func (c *Client) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
-	var bodyReader io.Reader
+	var payload []byte
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(b)
+		payload = b
+	}
+
+	deadline := time.Now().Add(c.retry.MaxElapsed)
+	backoff := c.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doOnce(ctx, method, path, payload)
+		if c.retry.MaxElapsed <= 0 {
+			return resp, err
+		}
+		delay, retryable := retryDelay(ctx, resp, err, backoff)
+		if !retryable || time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		slog.Info("retrying XC request", "method", method, "path", path, "attempt", attempt, "status", status, "error", err, "delay", delay)
+		discard(resp)
+		if err := sleepCtx(ctx, delay); err != nil {
+			return nil, err
+		}
+		backoff = min(backoff*2, c.retry.MaxBackoff)
+	}
+}
+
+// doOnce makes a single attempt at a request.
+func (c *Client) doOnce(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
@@ -55,11 +96,22 @@ func (c *Client) do(ctx context.Context, method, path string, body any) (*http.R
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		resp.Body.Close()
+		c.limiter.backoff(retryAfter)
+		return nil, &RateLimitedError{RetryAfter: retryAfter}
+	}
+
 	return resp, nil
 }
 
@@ -105,102 +157,6 @@ func (c *Client) TestConnection(ctx context.Context) error {
 	return nil
 }
 
-// CreateHTTPLoadBalancer creates an HTTP Load Balancer in the given XC namespace.
-func (c *Client) CreateHTTPLoadBalancer(ctx context.Context, namespace string, lb HTTPLoadBalancer) (*HTTPLoadBalancer, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers", namespace)
-	resp, err := c.do(ctx, http.MethodPost, path, lb)
-	if err != nil {
-		return nil, fmt.Errorf("creating HTTP load balancer: %w", err)
-	}
-	return decodeResponse[HTTPLoadBalancer](resp)
-}
-
-// ReplaceHTTPLoadBalancer replaces (updates) an existing HTTP Load Balancer.
-func (c *Client) ReplaceHTTPLoadBalancer(ctx context.Context, namespace string, lb HTTPLoadBalancer) (*HTTPLoadBalancer, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, lb.Metadata.Name)
-	resp, err := c.do(ctx, http.MethodPut, path, lb)
-	if err != nil {
-		return nil, fmt.Errorf("replacing HTTP load balancer: %w", err)
-	}
-	return decodeResponse[HTTPLoadBalancer](resp)
-}
-
-// GetHTTPLoadBalancer retrieves an HTTP Load Balancer by name.
-func (c *Client) GetHTTPLoadBalancer(ctx context.Context, namespace, name string) (*HTTPLoadBalancer, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, fmt.Errorf("getting HTTP load balancer: %w", err)
-	}
-	return decodeResponse[HTTPLoadBalancer](resp)
-}
-
-// GetHTTPLoadBalancerRaw retrieves an HTTP Load Balancer as raw JSON to inspect all fields.
-func (c *Client) GetHTTPLoadBalancerRaw(ctx context.Context, namespace, name string) (map[string]any, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, fmt.Errorf("getting HTTP load balancer raw: %w", err)
-	}
-	result, err := decodeResponse[map[string]any](resp)
-	if err != nil {
-		return nil, err
-	}
-	return *result, nil
-}
-
-// DeleteHTTPLoadBalancer deletes an HTTP Load Balancer by name.
-func (c *Client) DeleteHTTPLoadBalancer(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return fmt.Errorf("deleting HTTP load balancer: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("deleting HTTP LB (HTTP %d): %s", resp.StatusCode, string(body))
-	}
-	return nil
-}
-
-// CreateOriginPool creates an origin pool in the given XC namespace.
-func (c *Client) CreateOriginPool(ctx context.Context, namespace string, pool OriginPoolConfig) (*OriginPoolConfig, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/origin_pools", namespace)
-	resp, err := c.do(ctx, http.MethodPost, path, pool)
-	if err != nil {
-		return nil, fmt.Errorf("creating origin pool: %w", err)
-	}
-	return decodeResponse[OriginPoolConfig](resp)
-}
-
-// ReplaceOriginPool replaces (updates) an existing origin pool.
-func (c *Client) ReplaceOriginPool(ctx context.Context, namespace string, pool OriginPoolConfig) (*OriginPoolConfig, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/origin_pools/%s", namespace, pool.Metadata.Name)
-	resp, err := c.do(ctx, http.MethodPut, path, pool)
-	if err != nil {
-		return nil, fmt.Errorf("replacing origin pool: %w", err)
-	}
-	return decodeResponse[OriginPoolConfig](resp)
-}
-
-// DeleteOriginPool deletes an origin pool by name.
-func (c *Client) DeleteOriginPool(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("/config/namespaces/%s/origin_pools/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return fmt.Errorf("deleting origin pool: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("deleting origin pool (HTTP %d): %s", resp.StatusCode, string(body))
-	}
-	return nil
-}
-
 // ListAppFirewalls returns available WAF policies in the given XC namespace.
 func (c *Client) ListAppFirewalls(ctx context.Context, namespace string) ([]AppFirewall, error) {
 	path := fmt.Sprintf("/config/namespaces/%s/app_firewalls", namespace)
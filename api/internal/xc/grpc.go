@@ -0,0 +1,120 @@
+package xc
+
+import (
+	"fmt"
+	"regexp"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GRPCHealthCheckPath is the standard gRPC health checking service method.
+const GRPCHealthCheckPath = "/grpc.health.v1.Health/Check"
+
+// GRPCHealthCheckName returns the name of the health check published for a
+// GRPCRoute's origin pool.
+func GRPCHealthCheckName(routeName string) string {
+	return fmt.Sprintf("ngf-%s-grpc-hc", routeName)
+}
+
+// ValidateGRPCOrigin checks that the Gateway listener a GRPCRoute attaches to
+// can carry gRPC. NGINX Gateway serves GRPCRoutes over HTTP/2 on HTTP (h2c)
+// and HTTPS listeners only. An empty protocol means the listener is unknown
+// and is accepted.
+func ValidateGRPCOrigin(listener string, protocol gatewayv1.ProtocolType) error {
+	switch protocol {
+	case "", gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return nil
+	}
+	return fmt.Errorf("origin does not support HTTP/2: listener %q serves %s; a GRPCRoute needs an HTTP or HTTPS listener", listener, protocol)
+}
+
+// BuildGRPCHealthCheck creates the health check for a GRPCRoute's origin
+// pool. It calls the gRPC health service over HTTP/2.
+func BuildGRPCHealthCheck(routeName, xcNamespace string) *HealthCheckConfig {
+	return &HealthCheckConfig{
+		Metadata: ObjectMeta{
+			Name:      GRPCHealthCheckName(routeName),
+			Namespace: xcNamespace,
+		},
+		Spec: HealthCheckSpec{
+			HTTPHealthCheck: &HTTPHealthCheck{
+				Path:                GRPCHealthCheckPath,
+				UseHTTP2:            true,
+				UseOriginServerName: &EmptyObject{},
+				ExpectedStatusCodes: []string{"200"},
+			},
+			Timeout:            3,
+			Interval:           15,
+			UnhealthyThreshold: 1,
+			HealthyThreshold:   3,
+		},
+	}
+}
+
+// BuildGRPCOriginPool creates an origin pool for a GRPCRoute: the Gateway
+// origin of BuildOriginPool, reached over HTTP/2 and health checked with the
+// route's gRPC health check.
+func BuildGRPCOriginPool(routeName, xcNamespace, gatewayAddress string, port int32, useTLS bool) *OriginPoolConfig {
+	pool := BuildOriginPool(routeName, gatewayAddress, port, useTLS)
+	pool.Spec.AdvancedOptions = &OriginPoolAdvancedOptions{
+		HTTP2Options: &HTTP2Options{Enabled: true},
+	}
+	pool.Spec.HealthCheck = []HealthCheck{{
+		Namespace: xcNamespace,
+		Name:      GRPCHealthCheckName(routeName),
+	}}
+	return pool
+}
+
+// MapGRPCRouteToLoadBalancer derives an XC HTTP Load Balancer configuration
+// from a Gateway API GRPCRoute. gRPC calls are HTTP/2 POSTs to
+// /<service>/<method>, so each method match becomes the equivalent path match
+// and the route is mapped as an HTTPRoute. WebSocket upgrades do not apply to
+// gRPC and are left off.
+func MapGRPCRouteToLoadBalancer(route *gatewayv1.GRPCRoute, gatewayAddress string, opts MapOptions) *HTTPLoadBalancer {
+	httpRoute := &gatewayv1.HTTPRoute{ObjectMeta: route.ObjectMeta}
+	httpRoute.Spec.Hostnames = route.Spec.Hostnames
+	for _, rule := range route.Spec.Rules {
+		var httpRule gatewayv1.HTTPRouteRule
+		for _, match := range rule.Matches {
+			httpRule.Matches = append(httpRule.Matches, gatewayv1.HTTPRouteMatch{Path: grpcMethodPathMatch(match.Method)})
+		}
+		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, httpRule)
+	}
+	opts.WebSocketEnabled = false
+	return MapHTTPRouteToLoadBalancer(httpRoute, gatewayAddress, opts)
+}
+
+// grpcMethodPathMatch returns the HTTP path match equivalent to a GRPCRoute
+// method match, or nil to match every path.
+func grpcMethodPathMatch(m *gatewayv1.GRPCMethodMatch) *gatewayv1.HTTPPathMatch {
+	if m == nil || (m.Service == nil && m.Method == nil) {
+		return nil
+	}
+	service, method := "", ""
+	if m.Service != nil {
+		service = *m.Service
+	}
+	if m.Method != nil {
+		method = *m.Method
+	}
+
+	pathType, value := gatewayv1.PathMatchRegularExpression, ""
+	switch {
+	case m.Type != nil && *m.Type == gatewayv1.GRPCMethodMatchRegularExpression:
+		if service == "" {
+			service = "[^/]+"
+		}
+		if method == "" {
+			method = "[^/]+"
+		}
+		value = "/" + service + "/" + method
+	case service != "" && method != "":
+		pathType, value = gatewayv1.PathMatchExact, "/"+service+"/"+method
+	case service != "":
+		pathType, value = gatewayv1.PathMatchPathPrefix, "/"+service+"/"
+	default:
+		value = "/[^/]+/" + regexp.QuoteMeta(method)
+	}
+	return &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+}
@@ -0,0 +1,90 @@
+package xc
+
+import (
+	"encoding/json"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestBuildGRPCOriginPool(t *testing.T) {
+	pool := BuildGRPCOriginPool("cart", "prod", "203.0.113.10", 80, false)
+	b, err := json.Marshal(pool.Spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var spec map[string]any
+	json.Unmarshal(b, &spec)
+
+	http2, _ := json.Marshal(spec["advanced_options"])
+	refs, _ := json.Marshal(spec["healthcheck"])
+	if string(http2) != `{"http2_options":{"enabled":true}}` || string(refs) != `[{"name":"ngf-cart-grpc-hc","namespace":"prod"}]` {
+		t.Errorf("origin pool should use HTTP/2 and the gRPC health check: %s", b)
+	}
+	if pool.Metadata.Name != "ngf-cart-pool" || spec["no_tls"] == nil {
+		t.Errorf("unexpected origin pool: %s", b)
+	}
+
+	hc := BuildGRPCHealthCheck("cart", "prod")
+	if hc.Metadata.Name != "ngf-cart-grpc-hc" || hc.Spec.HTTPHealthCheck.Path != GRPCHealthCheckPath || !hc.Spec.HTTPHealthCheck.UseHTTP2 {
+		t.Errorf("unexpected health check: %+v", hc)
+	}
+}
+
+func TestMapGRPCRouteToLoadBalancer(t *testing.T) {
+	service, method := "shop.v1.Cart", "Add"
+	regex := gatewayv1.GRPCMethodMatchRegularExpression
+	pattern := `shop\.v[0-9]+\.Cart`
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"cart.internal"},
+			Rules: []gatewayv1.GRPCRouteRule{
+				{Matches: []gatewayv1.GRPCRouteMatch{
+					{Method: &gatewayv1.GRPCMethodMatch{Service: &service, Method: &method}},
+					{Method: &gatewayv1.GRPCMethodMatch{Service: &service}},
+					{Method: &gatewayv1.GRPCMethodMatch{Method: &method}},
+					{Method: &gatewayv1.GRPCMethodMatch{Type: &regex, Service: &pattern}},
+				}},
+				{},
+			},
+		},
+	}
+
+	lb := MapGRPCRouteToLoadBalancer(route, "203.0.113.10", MapOptions{XCNamespace: "prod", WebSocketEnabled: true})
+	if lb.Metadata.Name != "ngf-cart" || len(lb.Spec.Domains) != 1 || lb.Spec.Domains[0] != "cart.internal" {
+		t.Errorf("unexpected LB metadata or domains: %+v", lb)
+	}
+	want := []PathMatch{
+		{Exact: "/shop.v1.Cart/Add"},
+		{Prefix: "/shop.v1.Cart/"},
+		{Regex: "/[^/]+/Add"},
+		{Regex: `/shop\.v[0-9]+\.Cart/[^/]+`},
+		{Prefix: "/"},
+	}
+	if len(lb.Spec.Routes) != len(want) {
+		t.Fatalf("expected %d routes, got %d", len(want), len(lb.Spec.Routes))
+	}
+	for i, rt := range lb.Spec.Routes {
+		if rt.SimpleRoute.Path != want[i] {
+			t.Errorf("route %d: path = %+v, want %+v", i, rt.SimpleRoute.Path, want[i])
+		}
+		if rt.SimpleRoute.AdvancedOptions != nil {
+			t.Errorf("route %d: WebSocket should not be enabled for gRPC", i)
+		}
+	}
+}
+
+func TestValidateGRPCOrigin(t *testing.T) {
+	for _, p := range []gatewayv1.ProtocolType{"", gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType} {
+		if err := ValidateGRPCOrigin("web", p); err != nil {
+			t.Errorf("protocol %q: unexpected error %v", p, err)
+		}
+	}
+	for _, p := range []gatewayv1.ProtocolType{gatewayv1.TLSProtocolType, gatewayv1.TCPProtocolType} {
+		if err := ValidateGRPCOrigin("passthrough", p); err == nil {
+			t.Errorf("protocol %q: expected an error", p)
+		}
+	}
+}
@@ -9,46 +9,38 @@ import (
 
 // MapOptions controls how an HTTPRoute is mapped to an XC HTTP Load Balancer.
 type MapOptions struct {
-	XCNamespace       string // target XC namespace
-	Tenant            string // XC tenant name for resource references
-	PublicHostname    string // override hostname for edge (optional)
-	WAFEnabled        bool   // whether to attach WAF
-	WAFPolicyName     string // specific WAF policy to use (or empty for default)
-	WAFPolicyNamespace string // XC namespace where the WAF policy lives (e.g. "shared")
-	OriginPort        int32  // port to use for origin pool
-	OriginTLS         bool   // whether origin uses TLS
-	OriginHostRewrite string // hostname to set as Host header when forwarding to origin
-	WebSocketEnabled  bool   // whether to enable WebSocket protocol upgrade on routes
+	XCNamespace        string   // target XC namespace
+	Tenant             string   // XC tenant name for resource references
+	PublicHostname     string   // override hostname for edge (optional)
+	Domains            []string // additional domains served alongside the route hostnames
+	WAFEnabled         bool     // whether to attach WAF
+	WAFPolicyName      string   // specific WAF policy to use (or empty for default)
+	WAFPolicyNamespace string   // XC namespace where the WAF policy lives (e.g. "shared")
+	OriginPort         int32    // port to use for origin pool
+	OriginTLS          bool     // whether origin uses TLS
+	OriginHostRewrite  string   // hostname to set as Host header when forwarding to origin
+	WebSocketEnabled   bool     // whether to enable WebSocket protocol upgrade on routes
 }
 
 // MapHTTPRouteToLoadBalancer derives an XC HTTP Load Balancer configuration from a Gateway API HTTPRoute.
+//
+// The API only uses it for previews; the operator builds what is actually
+// published (buildXCHTTPLoadBalancer in operator/internal/controller). The
+// modules share no code, so a change to one mapping must be made to both.
 func MapHTTPRouteToLoadBalancer(route *gatewayv1.HTTPRoute, gatewayAddress string, opts MapOptions) *HTTPLoadBalancer {
 	name := "ngf-" + route.Name
 
-	// Derive domains from HTTPRoute hostnames.
-	domains := make([]string, 0)
+	// Derive domains from the public hostname, the HTTPRoute hostnames, and
+	// the custom domains, in that order.
+	domains := []string{opts.PublicHostname}
 	for _, h := range route.Spec.Hostnames {
 		domains = append(domains, string(h))
 	}
-	if opts.PublicHostname != "" {
-		// Add or replace with the public hostname.
-		found := false
-		for _, d := range domains {
-			if d == opts.PublicHostname {
-				found = true
-				break
-			}
-		}
-		if !found {
-			domains = append([]string{opts.PublicHostname}, domains...)
-		}
-	}
+	domains = MergeDomains(append(domains, opts.Domains...)...)
 	if len(domains) == 0 {
 		domains = append(domains, name+".example.com")
 	}
 
-
-
 	// Build origin pool reference.
 	// Note: tenant is omitted — XC resolves references within the same tenant context.
 	poolName := name + "-pool"
@@ -247,3 +239,19 @@ func isIPAddress(s string) bool {
 	}
 	return true
 }
+
+// MergeDomains returns domains in order without blanks or duplicates.
+// Domains are compared case-insensitively; the first spelling is kept.
+func MergeDomains(domains ...string) []string {
+	merged := make([]string, 0, len(domains))
+	seen := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		key := strings.ToLower(d)
+		if d == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, d)
+	}
+	return merged
+}
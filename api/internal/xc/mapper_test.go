@@ -0,0 +1,40 @@
+package xc
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestMapHTTPRouteToLoadBalancer_Domains(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"shop.internal", "www.example.com"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts MapOptions
+		want []string
+	}{
+		{name: "route hostnames", want: []string{"shop.internal", "www.example.com"}},
+		{name: "public hostname leads", opts: MapOptions{PublicHostname: "www.example.com"}, want: []string{"www.example.com", "shop.internal"}},
+		{
+			name: "custom domains are appended without duplicates",
+			opts: MapOptions{PublicHostname: "shop.example.com", Domains: []string{"WWW.example.com", "*.shop.example.com", "shop.example.com"}},
+			want: []string{"shop.example.com", "shop.internal", "www.example.com", "*.shop.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := MapHTTPRouteToLoadBalancer(route, "203.0.113.10", tt.opts)
+			if !slices.Equal(lb.Spec.Domains, tt.want) {
+				t.Errorf("domains = %v, want %v", lb.Spec.Domains, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package xc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Service graph metric types requested for HTTP load balancers.
+const (
+	MetricRequestRate     = "HTTP_REQUEST_RATE"
+	MetricErrorRate       = "HTTP_ERROR_RATE"
+	MetricResponseLatency = "HTTP_RESPONSE_LATENCY"
+)
+
+// ServiceGraphRequest queries per-node traffic metrics from the XC service graph.
+type ServiceGraphRequest struct {
+	FieldSelector ServiceGraphFieldSelector `json:"field_selector"`
+	GroupBy       []string                  `json:"group_by,omitempty"`
+	LabelFilter   []LabelFilter             `json:"label_filter,omitempty"`
+	StartTime     string                    `json:"start_time"`
+	EndTime       string                    `json:"end_time"`
+	Step          string                    `json:"step,omitempty"`
+}
+
+// ServiceGraphFieldSelector selects which node metrics to return.
+type ServiceGraphFieldSelector struct {
+	Node ServiceGraphNodeSelector `json:"node"`
+}
+
+// ServiceGraphNodeSelector lists downstream metric types to return per node.
+type ServiceGraphNodeSelector struct {
+	Metric ServiceGraphMetricSelector `json:"metric"`
+}
+
+// ServiceGraphMetricSelector lists the downstream metric types.
+type ServiceGraphMetricSelector struct {
+	Downstream []string `json:"downstream"`
+}
+
+// LabelFilter restricts a service graph query to matching nodes.
+type LabelFilter struct {
+	Label string `json:"label"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+// ServiceGraphResponse is the service graph query result.
+type ServiceGraphResponse struct {
+	Data ServiceGraphData `json:"data"`
+}
+
+// ServiceGraphData holds the nodes of the service graph.
+type ServiceGraphData struct {
+	Nodes []ServiceGraphNode `json:"nodes"`
+}
+
+// ServiceGraphNode is one node (e.g. a site/vhost pair) with its metrics.
+type ServiceGraphNode struct {
+	ID   ServiceGraphNodeID   `json:"id"`
+	Data ServiceGraphNodeData `json:"data"`
+}
+
+// ServiceGraphNodeID identifies a service graph node.
+type ServiceGraphNodeID struct {
+	Site  string `json:"site,omitempty"`
+	VHost string `json:"vhost,omitempty"`
+}
+
+// ServiceGraphNodeData holds a node's metric series.
+type ServiceGraphNodeData struct {
+	Metric ServiceGraphNodeMetrics `json:"metric"`
+}
+
+// ServiceGraphNodeMetrics holds the downstream metric series of a node.
+type ServiceGraphNodeMetrics struct {
+	Downstream []MetricSeries `json:"downstream"`
+}
+
+// MetricSeries is a single metric type's time series.
+type MetricSeries struct {
+	Type  string      `json:"type"`
+	Value MetricValue `json:"value"`
+}
+
+// MetricValue holds raw samples of a metric series.
+type MetricValue struct {
+	Raw []MetricSample `json:"raw"`
+}
+
+// MetricSample is a single timestamped sample. XC encodes values as strings.
+type MetricSample struct {
+	Timestamp float64 `json:"timestamp"`
+	Value     string  `json:"value"`
+}
+
+// Average returns the mean of the series' samples, skipping unparsable values.
+func (s MetricSeries) Average() float64 {
+	var sum float64
+	var n int
+	for _, sample := range s.Value.Raw {
+		v, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil {
+			continue
+		}
+		sum += v
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// NewServiceGraphRequest builds a request for HTTP request rate, error rate,
+// and latency over the window ending now, grouped by site. If vhost is set,
+// only that load balancer's virtual host is included.
+func NewServiceGraphRequest(window time.Duration, vhost string) ServiceGraphRequest {
+	end := time.Now().UTC()
+	req := ServiceGraphRequest{
+		FieldSelector: ServiceGraphFieldSelector{Node: ServiceGraphNodeSelector{Metric: ServiceGraphMetricSelector{
+			Downstream: []string{MetricRequestRate, MetricErrorRate, MetricResponseLatency},
+		}}},
+		GroupBy:   []string{"SITE"},
+		StartTime: strconv.FormatInt(end.Add(-window).Unix(), 10),
+		EndTime:   strconv.FormatInt(end.Unix(), 10),
+		Step:      "5m",
+	}
+	if vhost != "" {
+		req.GroupBy = append(req.GroupBy, "VHOST")
+		req.LabelFilter = []LabelFilter{{Label: "LABEL_VHOST", Op: "EQ", Value: vhost}}
+	}
+	return req
+}
+
+// LoadBalancerVHost returns the service graph virtual host name XC assigns to
+// an HTTP load balancer.
+func LoadBalancerVHost(lbName string) string {
+	return "ves-io-http-loadbalancer-" + lbName
+}
+
+// GetServiceGraphMetrics queries the service graph for traffic metrics in the
+// given XC namespace.
+func (c *Client) GetServiceGraphMetrics(ctx context.Context, namespace string, req ServiceGraphRequest) (*ServiceGraphResponse, error) {
+	path := fmt.Sprintf("/data/namespaces/%s/graph/service", namespace)
+	resp, err := c.do(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return nil, fmt.Errorf("querying service graph: %w", err)
+	}
+	return decodeResponse[ServiceGraphResponse](resp)
+}
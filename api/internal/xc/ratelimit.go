@@ -0,0 +1,108 @@
+package xc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Request budget shared by all clients of a tenant. XC enforces per-tenant
+// limits, so concurrent publishes must draw from the same bucket.
+const (
+	requestsPerSecond = 5
+	requestBurst      = 10
+
+	// defaultRetryAfter is used when a 429 carries no usable Retry-After header.
+	defaultRetryAfter = 30 * time.Second
+)
+
+// RateLimitedError is returned when XC throttles the tenant (HTTP 429) or
+// while the tenant is still backing off from an earlier 429.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited by XC API, will retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// isRateLimited reports whether err is (or wraps) a RateLimitedError and, if
+// so, how long to wait before retrying.
+func isRateLimited(err error) (time.Duration, bool) {
+	var rl *RateLimitedError
+	if errors.As(err, &rl) {
+		return rl.RetryAfter, true
+	}
+	return 0, false
+}
+
+// tenantLimiter paces requests for one tenant and tracks any Retry-After
+// backoff requested by XC.
+type tenantLimiter struct {
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = make(map[string]*tenantLimiter)
+)
+
+// limiterFor returns the shared limiter for tenant, creating it on first use.
+func limiterFor(tenant string) *tenantLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[tenant]
+	if !ok {
+		l = &tenantLimiter{limiter: rate.NewLimiter(requestsPerSecond, requestBurst)}
+		limiters[tenant] = l
+	}
+	return l
+}
+
+// wait blocks until the tenant's budget allows another request. It fails fast
+// with a RateLimitedError while a Retry-After backoff is in effect.
+func (l *tenantLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	remaining := time.Until(l.blockedUntil)
+	l.mu.Unlock()
+	if remaining > 0 {
+		return &RateLimitedError{RetryAfter: remaining}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// backoff records that XC asked the tenant to wait d before retrying.
+func (l *tenantLimiter) backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given as delay-seconds or an
+// HTTP date. Falls back to defaultRetryAfter when absent or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return defaultRetryAfter
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
@@ -0,0 +1,69 @@
+package xc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{name: "empty", header: "", want: defaultRetryAfter},
+		{name: "seconds", header: "120", want: 2 * time.Minute},
+		{name: "http date", header: now.Add(45 * time.Second).Format(http.TimeFormat), want: 45 * time.Second},
+		{name: "date in the past", header: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "garbage", header: "soon", want: defaultRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.header, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		tenant:  "test",
+		baseURL: srv.URL,
+		http:    srv.Client(),
+		limiter: &tenantLimiter{limiter: limiterFor("ratelimit-test").limiter},
+	}
+
+	_, err := c.ListAppFirewalls(context.Background(), "default")
+	retryAfter, limited := isRateLimited(err)
+	if !limited {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if retryAfter != 30*time.Second {
+		t.Errorf("expected 30s retry-after, got %s", retryAfter)
+	}
+
+	// While backing off, further requests fail fast without reaching XC.
+	if _, err := c.ListAppFirewalls(context.Background(), "default"); err == nil {
+		t.Fatal("expected error during backoff")
+	} else if _, limited := isRateLimited(err); !limited {
+		t.Fatalf("expected rate limited error during backoff, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call to XC, got %d", calls)
+	}
+}
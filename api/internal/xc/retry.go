@@ -0,0 +1,114 @@
+package xc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls how requests are retried after XC throttles the
+// tenant (HTTP 429) or fails transiently (HTTP 502, 503, 504, or a transport
+// error). Retries stop once the next wait would exceed MaxElapsed, counted
+// from the first attempt. A zero MaxElapsed disables retries.
+type RetryPolicy struct {
+	MaxElapsed     time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used unless SetRetryPolicy is called.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxElapsed:     30 * time.Second,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+	}
+}
+
+var (
+	retryPolicyMu sync.RWMutex
+	retryPolicy   = DefaultRetryPolicy()
+)
+
+// SetRetryPolicy sets the retry policy for clients created by New afterwards.
+// Unset backoff bounds take their DefaultRetryPolicy values.
+func SetRetryPolicy(p RetryPolicy) {
+	def := DefaultRetryPolicy()
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = def.InitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(def.MaxBackoff, p.InitialBackoff)
+	}
+	retryPolicyMu.Lock()
+	retryPolicy = p
+	retryPolicyMu.Unlock()
+}
+
+func currentRetryPolicy() RetryPolicy {
+	retryPolicyMu.RLock()
+	defer retryPolicyMu.RUnlock()
+	return retryPolicy
+}
+
+// retryDelay reports whether the outcome of an attempt is worth retrying and
+// how long to wait first. Throttling waits for the Retry-After XC asked for;
+// other transient failures use backoff.
+func retryDelay(ctx context.Context, resp *http.Response, err error, backoff time.Duration) (time.Duration, bool) {
+	if err != nil {
+		if retryAfter, limited := isRateLimited(err); limited {
+			return retryAfter, true
+		}
+		// A canceled or expired context is final.
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return jitter(backoff), true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if h := resp.Header.Get("Retry-After"); h != "" {
+			return parseRetryAfter(h, time.Now()), true
+		}
+		return jitter(backoff), true
+	default:
+		return 0, false
+	}
+}
+
+// jitter spreads d over [d/2, d) so clients retrying together don't
+// hit XC in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(half)
+}
+
+// sleepCtx waits for d or until ctx is done, returning ctx.Err() in the
+// latter case.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// discard drains and closes a response that is being retried, so the
+// connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
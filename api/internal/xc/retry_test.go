@@ -0,0 +1,99 @@
+package xc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, policy RetryPolicy) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &Client{
+		tenant:  "test",
+		baseURL: srv.URL,
+		http:    srv.Client(),
+		limiter: &tenantLimiter{limiter: limiterFor("retry-test").limiter},
+		retry:   policy,
+	}
+}
+
+var fastRetry = RetryPolicy{MaxElapsed: 5 * time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+func TestClient_RetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"items":[{"name":"waf"}]}`))
+		}
+	}, fastRetry)
+
+	waf, err := c.ListAppFirewalls(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if len(waf) != 1 || waf[0].Name != "waf" || calls.Load() != 3 {
+		t.Errorf("got %+v after %d calls", waf, calls.Load())
+	}
+}
+
+func TestClient_RetryStopsAtMaxElapsed(t *testing.T) {
+	var calls atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, fastRetry)
+
+	// Waiting 60s would exceed the 5s budget, so the 503 is returned as is.
+	_, err := c.ListAppFirewalls(context.Background(), "default")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}, fastRetry)
+
+	if _, err := c.ListAppFirewalls(context.Background(), "default"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestClient_RetryHonorsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	}, RetryPolicy{MaxElapsed: time.Minute, InitialBackoff: time.Second, MaxBackoff: time.Second})
+
+	start := time.Now()
+	_, err := c.ListAppFirewalls(ctx, "default")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("expected cancellation to interrupt the backoff, took %s", time.Since(start))
+	}
+}
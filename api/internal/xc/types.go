@@ -107,12 +107,23 @@ type OriginPoolConfig struct {
 
 // OriginPoolSpec defines the origin pool configuration.
 type OriginPoolSpec struct {
-	OriginServers    []OriginServer `json:"origin_servers"`
-	Port             uint32         `json:"port"`
-	NoTLS            *EmptyObject   `json:"no_tls,omitempty"`
-	UseTLS           *OriginTLS     `json:"use_tls,omitempty"`
-	LoadbalancerAlgo string         `json:"loadbalancer_algorithm,omitempty"`
-	HealthCheck      []HealthCheck  `json:"healthcheck,omitempty"`
+	OriginServers    []OriginServer             `json:"origin_servers"`
+	Port             uint32                     `json:"port"`
+	NoTLS            *EmptyObject               `json:"no_tls,omitempty"`
+	UseTLS           *OriginTLS                 `json:"use_tls,omitempty"`
+	LoadbalancerAlgo string                     `json:"loadbalancer_algorithm,omitempty"`
+	HealthCheck      []HealthCheck              `json:"healthcheck,omitempty"`
+	AdvancedOptions  *OriginPoolAdvancedOptions `json:"advanced_options,omitempty"`
+}
+
+// OriginPoolAdvancedOptions holds less common origin pool settings.
+type OriginPoolAdvancedOptions struct {
+	HTTP2Options *HTTP2Options `json:"http2_options,omitempty"`
+}
+
+// HTTP2Options enables HTTP/2 to the origin servers.
+type HTTP2Options struct {
+	Enabled bool `json:"enabled"`
 }
 
 // OriginServer defines a single origin server in a pool.
@@ -143,6 +154,31 @@ type HealthCheck struct {
 	Name      string `json:"name,omitempty"`
 }
 
+// HealthCheckConfig represents an F5 Distributed Cloud health check that
+// origin pools reference by name.
+type HealthCheckConfig struct {
+	Metadata ObjectMeta      `json:"metadata"`
+	Spec     HealthCheckSpec `json:"spec"`
+}
+
+// HealthCheckSpec defines the health check configuration. Timeout and
+// interval are in seconds.
+type HealthCheckSpec struct {
+	HTTPHealthCheck    *HTTPHealthCheck `json:"http_health_check,omitempty"`
+	Timeout            uint32           `json:"timeout,omitempty"`
+	Interval           uint32           `json:"interval,omitempty"`
+	UnhealthyThreshold uint32           `json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   uint32           `json:"healthy_threshold,omitempty"`
+}
+
+// HTTPHealthCheck checks origin servers with an HTTP request.
+type HTTPHealthCheck struct {
+	Path                string       `json:"path"`
+	UseHTTP2            bool         `json:"use_http2,omitempty"`
+	UseOriginServerName *EmptyObject `json:"use_origin_server_name,omitempty"`
+	ExpectedStatusCodes []string     `json:"expected_status_codes,omitempty"`
+}
+
 // AppFirewall represents an XC WAF policy.
 type AppFirewall struct {
 	Name        string `json:"name"`
@@ -115,6 +115,7 @@ CREATE TABLE IF NOT EXISTS ngf_inference_metrics_1m (
     timestamp DateTime64(3),
     cluster_name LowCardinality(String) DEFAULT '',
     pool_name String,
+    model_name LowCardinality(String) DEFAULT '',
     ttft_ms Float64,
     tps Float64,
     total_tokens UInt64,
@@ -39,6 +39,17 @@ app.kubernetes.io/name: {{ include "ngf-console.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
 
+{{/*
+Serving image overrides as backend=image pairs, for --serving-images
+*/}}
+{{- define "ngf-console.servingImages" -}}
+{{- $pairs := list }}
+{{- range $backend, $image := .Values.inference.servingImages }}
+{{- $pairs = append $pairs (printf "%s=%s" $backend $image) }}
+{{- end }}
+{{- join "," $pairs }}
+{{- end }}
+
 {{/*
 Service account name
 */}}
@@ -6,11 +6,13 @@ export interface ResourceCount {
 }
 
 export interface ControllerSummary {
+  name?: string; // "istio" | "envoy" | "traefik" | "other" for otherControllers entries
   installed: boolean;
   version?: string;
   resourceCount: number;
   namespaces: string[];
   resources: ResourceCount[];
+  controllerNames?: string[];
 }
 
 export interface SharedResource {
@@ -28,8 +30,10 @@ export interface Conflict {
 }
 
 export interface CoexistenceOverview {
+  namespace?: string;
   kic: ControllerSummary;
   ngf: ControllerSummary;
+  otherControllers: ControllerSummary[];
   sharedResources: SharedResource[];
   conflicts: Conflict[];
 }
@@ -42,6 +46,7 @@ export interface ReadinessCategory {
 }
 
 export interface MigrationReadiness {
+  namespace?: string;
   score: number;
   status: "ready" | "partial" | "not-ready";
   categories: ReadinessCategory[];
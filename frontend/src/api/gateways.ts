@@ -1,5 +1,5 @@
 import apiClient from "./client";
-import type { Gateway, GatewayClass, CreateGatewayPayload, UpdateGatewayPayload, GatewayBundle, CreateGatewayBundlePayload } from "@/types/gateway";
+import type { Gateway, GatewayClass, CreateGatewayPayload, UpdateGatewayPayload, GatewayBundle, GatewayBundleList, GatewayBundleListParams, CreateGatewayBundlePayload } from "@/types/gateway";
 
 export async function fetchGateways(namespace?: string): Promise<Gateway[]> {
   const params = namespace ? { namespace } : {};
@@ -38,9 +38,8 @@ export async function deleteGateway(namespace: string, name: string): Promise<vo
 
 // --- GatewayBundle API ---
 
-export async function fetchGatewayBundles(namespace?: string): Promise<GatewayBundle[]> {
-  const params = namespace ? { namespace } : {};
-  const { data } = await apiClient.get<GatewayBundle[]>("/gatewaybundles", { params });
+export async function fetchGatewayBundles(params: GatewayBundleListParams = {}): Promise<GatewayBundleList> {
+  const { data } = await apiClient.get<GatewayBundleList>("/gatewaybundles", { params });
   return data;
 }
 
@@ -11,8 +11,14 @@ import type {
   UpdatePoolPayload,
   EPPConfigPayload,
   AutoscalingPayload,
+  ServingBackend,
 } from "@/types/inference";
 
+export async function fetchServingBackends(): Promise<ServingBackend[]> {
+  const { data } = await apiClient.get<ServingBackend[]>("/inference/backends");
+  return data;
+}
+
 export async function fetchInferencePools(): Promise<InferencePoolWithGPU[]> {
   const { data } = await apiClient.get<InferencePoolWithGPU[]>("/inference/pools");
   return data;
@@ -2,10 +2,14 @@ import apiClient from "./client";
 
 // --- Publish Types ---
 
+// Kind of route named by httpRouteRef; HTTPRoute when omitted.
+export type XCRouteKind = "HTTPRoute" | "GRPCRoute";
+
 export interface XCPublish {
   name: string;
   namespace: string;
   httpRouteRef: string;
+  routeKind?: XCRouteKind;
   inferencePoolRef?: string;
   phase: string;
   xcLoadBalancerName?: string;
@@ -42,6 +46,7 @@ export interface XCPublishRequest {
   name: string;
   namespace: string;
   httpRouteRef: string;
+  routeKind?: XCRouteKind;
   inferencePoolRef?: string;
   publicHostname?: string;
   originAddress?: string;
@@ -76,6 +81,7 @@ export interface XCTestConnectionResponse {
 export interface XCPreviewRequest {
   namespace: string;
   httpRouteRef: string;
+  routeKind?: XCRouteKind;
   publicHostname?: string;
   originAddress?: string;
   wafEnabled?: boolean;
@@ -87,6 +93,7 @@ export interface XCPreviewRequest {
 export interface XCPreviewResponse {
   loadBalancer: Record<string, unknown>;
   originPool: Record<string, unknown>;
+  healthCheck?: Record<string, unknown>;
   wafPolicy?: string;
 }
 
@@ -11,6 +11,8 @@ export interface ResourceCounts {
 export interface GPUCapacitySummary {
   totalGPUs: number;
   allocatedGPUs: number;
+  totalMIGSlices?: number;
+  allocatedMIGSlices?: number;
   gpuTypes?: Record<string, number>;
 }
 
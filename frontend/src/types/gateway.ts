@@ -169,6 +169,17 @@ export interface GatewayBundle {
   createdAt: string;
 }
 
+export interface GatewayBundleList {
+  items: GatewayBundle[];
+  continue?: string;
+}
+
+export interface GatewayBundleListParams {
+  limit?: number;
+  continue?: string;
+  labelSelector?: string;
+}
+
 export interface CreateGatewayBundlePayload {
   name: string;
   namespace: string;
@@ -1,13 +1,21 @@
 export type GPUType = "A100" | "H100" | "L40S" | "T4";
 export type EPPStrategy = "least_queue" | "kv_cache" | "prefix_affinity" | "composite";
 export type ScalingBackend = "hpa" | "keda";
+export type ServingBackendName = "triton" | "vllm" | "tgi" | "ollama";
+
+export interface ServingBackend {
+  name: ServingBackendName;
+  defaultImage: string;
+  port: number;
+  overridden: boolean;
+}
 
 export interface InferencePool {
   name: string;
   namespace: string;
   modelName: string;
   modelVersion?: string;
-  servingBackend: "triton" | "vllm" | "tgi";
+  servingBackend: ServingBackendName;
   gpuType: GPUType;
   gpuCount: number;
   replicas: number;
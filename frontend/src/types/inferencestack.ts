@@ -5,7 +5,7 @@ export interface InferenceStack {
   namespace: string;
   modelName: string;
   modelVersion?: string;
-  servingBackend: "triton" | "vllm" | "tgi";
+  servingBackend: "triton" | "vllm" | "tgi" | "ollama";
   pool: InferenceStackPool;
   epp?: InferenceStackEPP;
   phase?: string;
@@ -45,7 +45,7 @@ export interface CreateInferenceStackPayload {
   namespace: string;
   modelName: string;
   modelVersion?: string;
-  servingBackend: "triton" | "vllm" | "tgi";
+  servingBackend: "triton" | "vllm" | "tgi" | "ollama";
   pool: InferenceStackPool;
   epp?: InferenceStackEPP;
 }
@@ -54,7 +54,23 @@ export interface HTTPRoute {
   hostnames?: string[];
   rules: HTTPRouteRule[];
   status?: { parents: { parentRef: ParentRef; controllerName: string; conditions: Condition[] }[] };
+  attachments?: RouteAttachments;
   createdAt: string;
+  warnings?: string[];
+}
+
+export interface RouteAttachments {
+  total: number;
+  notReady: number;
+  summary: string;
+  parents: {
+    gateway: string;
+    sectionName?: string;
+    gatewayProgrammed: boolean;
+    routeAccepted: boolean;
+    ready: boolean;
+    reason?: string;
+  }[];
 }
 
 // --- CRUD payload types ---
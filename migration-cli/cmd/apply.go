@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -10,8 +11,9 @@ import (
 )
 
 var (
-	applyPlan string
-	dryRun    bool
+	applyPlan   string
+	dryRun      bool
+	applyOutput string
 )
 
 var applyCmd = &cobra.Command{
@@ -20,6 +22,9 @@ var applyCmd = &cobra.Command{
 	Long: `Apply the generated migration plan to create Gateway API resources
 in the target cluster. Use --dry-run to preview changes without applying them.
 
+Use --output json or --output yaml to print the result in a machine-readable
+form on stdout; notes and warnings are then written to stderr.
+
 Note: Actual cluster operations are performed through the NGF Console API.
 This command reads the plan file and displays what would be applied.`,
 	RunE: runApply,
@@ -28,6 +33,7 @@ This command reads the plan file and displays what would be applied.`,
 func init() {
 	applyCmd.Flags().StringVarP(&applyPlan, "plan", "p", "migration-plan.yaml", "migration plan file to apply")
 	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "preview changes without applying them")
+	applyCmd.Flags().StringVarP(&applyOutput, "output", "o", outputTable, "output format: table, json, or yaml")
 }
 
 // applyMapping represents a source-to-target migration mapping parsed from
@@ -42,12 +48,42 @@ type applyMapping struct {
 	confidence      string
 }
 
-func runApply(_ *cobra.Command, _ []string) error {
+// applyResult is the structured result of the apply command for --output
+// json and yaml.
+type applyResult struct {
+	Plan     string          `json:"plan" yaml:"plan"`
+	DryRun   bool            `json:"dryRun" yaml:"dryRun"`
+	Gateway  string          `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	Mappings []resultMapping `json:"mappings" yaml:"mappings"`
+}
+
+type resultMapping struct {
+	Source     resourceRef `json:"source" yaml:"source"`
+	Target     resourceRef `json:"target" yaml:"target"`
+	Confidence string      `json:"confidence" yaml:"confidence"`
+}
+
+type resourceRef struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+}
+
+func runApply(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(applyOutput); err != nil {
+		return err
+	}
+
 	mappings, gatewayName, err := parsePlanFile(applyPlan)
 	if err != nil {
 		return fmt.Errorf("failed to read migration plan: %w", err)
 	}
 
+	if applyOutput != outputTable {
+		cmd.SilenceUsage = true
+		return writeApplyResult(mappings, gatewayName)
+	}
+
 	if len(mappings) == 0 {
 		fmt.Println("No mappings found in migration plan. Nothing to apply.")
 		return nil
@@ -76,22 +112,53 @@ func runApply(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	fmt.Println("WARNING: Direct cluster operations require the NGF Console API server.")
-	fmt.Println("To apply this plan to a live cluster, use one of the following methods:")
-	fmt.Println()
-	fmt.Println("  1. NGF Console UI:  Upload the plan file in the Migration section")
-	fmt.Println("  2. NGF Console API: POST the plan to /api/v1/migration/apply")
-	fmt.Println()
-	fmt.Printf("  Example:\n")
-	fmt.Printf("    curl -X POST http://localhost:8080/api/v1/migration/apply \\\n")
-	fmt.Printf("      -H 'Content-Type: application/json' \\\n")
-	fmt.Printf("      -d '{\"importId\": \"<id>\", \"dryRun\": false, \"resources\": [...]}'\n")
-	fmt.Println()
-	fmt.Println("Plan file:", applyPlan)
-
+	printAPIInstructions(os.Stdout)
 	return nil
 }
 
+// writeApplyResult prints the plan's mappings as JSON or YAML on stdout. Any
+// notes for the user go to stderr so the structured output can be piped.
+func writeApplyResult(mappings []applyMapping, gatewayName string) error {
+	result := applyResult{
+		Plan:     applyPlan,
+		DryRun:   dryRun,
+		Gateway:  gatewayName,
+		Mappings: make([]resultMapping, 0, len(mappings)),
+	}
+	for _, m := range mappings {
+		result.Mappings = append(result.Mappings, resultMapping{
+			Source:     resourceRef{Kind: m.sourceKind, Name: m.sourceName, Namespace: m.sourceNamespace},
+			Target:     resourceRef{Kind: m.targetKind, Name: m.targetName, Namespace: m.targetNamespace},
+			Confidence: m.confidence,
+		})
+	}
+
+	if len(mappings) == 0 {
+		fmt.Fprintln(os.Stderr, "No mappings found in migration plan. Nothing to apply.")
+	} else if !dryRun {
+		printAPIInstructions(os.Stderr)
+	}
+
+	return writeStructured(os.Stdout, applyOutput, result)
+}
+
+// printAPIInstructions explains how to apply the plan through the NGF Console,
+// since the CLI does not perform cluster operations directly.
+func printAPIInstructions(w io.Writer) {
+	fmt.Fprintln(w, "WARNING: Direct cluster operations require the NGF Console API server.")
+	fmt.Fprintln(w, "To apply this plan to a live cluster, use one of the following methods:")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  1. NGF Console UI:  Upload the plan file in the Migration section")
+	fmt.Fprintln(w, "  2. NGF Console API: POST the plan to /api/v1/migration/apply")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  Example:\n")
+	fmt.Fprintf(w, "    curl -X POST http://localhost:8080/api/v1/migration/apply \\\n")
+	fmt.Fprintf(w, "      -H 'Content-Type: application/json' \\\n")
+	fmt.Fprintf(w, "      -d '{\"importId\": \"<id>\", \"dryRun\": false, \"resources\": [...]}'\n")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Plan file:", applyPlan)
+}
+
 // parsePlanFile reads a migration plan YAML and extracts mappings and the
 // gateway name. Uses simple line-based parsing (no YAML library).
 func parsePlanFile(path string) ([]applyMapping, string, error) {
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, f := range []string{"table", "json", "yaml"} {
+		if err := validateOutputFormat(f); err != nil {
+			t.Errorf("validateOutputFormat(%q) = %v, want nil", f, err)
+		}
+	}
+	if err := validateOutputFormat("xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestWriteStructured(t *testing.T) {
+	result := applyResult{
+		Plan:    "migration-plan.yaml",
+		DryRun:  true,
+		Gateway: "migrated-gateway",
+		Mappings: []resultMapping{{
+			Source:     resourceRef{Kind: "Ingress", Name: "cafe", Namespace: "default"},
+			Target:     resourceRef{Kind: "HTTPRoute", Name: "cafe", Namespace: "default"},
+			Confidence: "high",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeStructured(&buf, outputJSON, result); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded applyResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(decoded.Mappings) != 1 || decoded.Mappings[0].Target.Kind != "HTTPRoute" {
+		t.Errorf("unexpected decoded result: %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := writeStructured(&buf, outputYAML, result); err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if !strings.Contains(buf.String(), "dryRun: true") || !strings.Contains(buf.String(), "kind: HTTPRoute") {
+		t.Errorf("unexpected YAML output:\n%s", buf.String())
+	}
+}
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func() error) []byte {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := fn()
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("command failed: %v", runErr)
+	}
+	return out
+}
+
+func TestScanAndPlan_JSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	ingress := "apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata:\n  name: cafe\n  namespace: shop\n"
+	if err := os.WriteFile(filepath.Join(dir, "cafe.yaml"), []byte(ingress), 0o600); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	scanInput, scanResults, scanOutput = dir, filepath.Join(dir, "scan-results.yaml"), outputJSON
+	planInput, planFile, planOutput = scanResults, filepath.Join(dir, "migration-plan.yaml"), outputJSON
+	t.Cleanup(func() { scanOutput, planOutput = outputTable, outputTable })
+
+	var scanned scanResult
+	if err := json.Unmarshal(captureStdout(t, func() error { return runScan(scanCmd, nil) }), &scanned); err != nil {
+		t.Fatalf("scan printed invalid JSON: %v", err)
+	}
+	if scanned.TotalResources != 1 || scanned.ByKind["Ingress"] != 1 || scanned.Resources[0].Namespace != "shop" {
+		t.Errorf("unexpected scan result: %+v", scanned)
+	}
+
+	var planned planResult
+	if err := json.Unmarshal(captureStdout(t, func() error { return runPlan(planCmd, nil) }), &planned); err != nil {
+		t.Fatalf("plan printed invalid JSON: %v", err)
+	}
+	if planned.Gateway != planGatewayName || len(planned.Mappings) != 1 || planned.Mappings[0].Target.Kind != "HTTPRoute" {
+		t.Errorf("unexpected plan result: %+v", planned)
+	}
+	if _, err := os.Stat(planFile); err != nil {
+		t.Errorf("expected the plan file to be written: %v", err)
+	}
+}
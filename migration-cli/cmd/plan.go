@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 
 var (
 	planInput  string
+	planFile   string
 	planOutput string
 )
 
@@ -19,13 +21,18 @@ var planCmd = &cobra.Command{
 	Short: "Generate a migration plan from scan output",
 	Long: `Generate a migration plan that maps NGINX Ingress Controller resources
 to their Gateway API equivalents. The plan can be reviewed and then applied
-using the apply command or submitted to the NGF Console API.`,
+using the apply command or submitted to the NGF Console API.
+
+The plan is written to the file given by -p. Use --output json or --output
+yaml to also print the mappings in a machine-readable form on stdout;
+progress messages are then written to stderr.`,
 	RunE: runPlan,
 }
 
 func init() {
 	planCmd.Flags().StringVarP(&planInput, "input", "i", "scan-results.yaml", "input file from scan results")
-	planCmd.Flags().StringVarP(&planOutput, "output", "o", "migration-plan.yaml", "output file for migration plan")
+	planCmd.Flags().StringVarP(&planFile, "plan", "p", "migration-plan.yaml", "file to write the migration plan to")
+	planCmd.Flags().StringVarP(&planOutput, "output", "o", outputTable, "output format: table, json, or yaml")
 }
 
 // planEntry is a lightweight representation of a scanned resource line from
@@ -38,6 +45,9 @@ type planEntry struct {
 	sourceFile string
 }
 
+// planGatewayName is the Gateway the planned routes attach to.
+const planGatewayName = "migrated-gateway"
+
 // targetForKind returns the target Gateway API kind for a given source kind.
 func targetForKind(kind string) string {
 	switch kind {
@@ -82,22 +92,44 @@ func confidenceForKind(kind string) string {
 	}
 }
 
-func runPlan(_ *cobra.Command, _ []string) error {
+// planResult is the structured result of the plan command for --output json
+// and yaml.
+type planResult struct {
+	Input    string          `json:"input" yaml:"input"`
+	Plan     string          `json:"plan,omitempty" yaml:"plan,omitempty"`
+	Gateway  string          `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	Mappings []resultMapping `json:"mappings" yaml:"mappings"`
+}
+
+func runPlan(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(planOutput); err != nil {
+		return err
+	}
+	// Progress goes to stderr when stdout carries the structured result.
+	progress := io.Writer(os.Stdout)
+	if planOutput != outputTable {
+		cmd.SilenceUsage = true
+		progress = os.Stderr
+	}
+
 	entries, err := parseScanResults(planInput)
 	if err != nil {
 		return fmt.Errorf("failed to read scan results: %w", err)
 	}
 
 	if len(entries) == 0 {
-		fmt.Println("No resources found in scan results. Nothing to plan.")
+		fmt.Fprintln(progress, "No resources found in scan results. Nothing to plan.")
+		if planOutput != outputTable {
+			return writePlanResult(nil, "")
+		}
 		return nil
 	}
 
-	fmt.Printf("Generating migration plan for %d resource(s)...\n", len(entries))
+	fmt.Fprintf(progress, "Generating migration plan for %d resource(s)...\n", len(entries))
 
-	out, err := os.Create(planOutput)
+	out, err := os.Create(planFile)
 	if err != nil {
-		return fmt.Errorf("cannot create output file %q: %w", planOutput, err)
+		return fmt.Errorf("cannot create plan file %q: %w", planFile, err)
 	}
 	defer out.Close()
 
@@ -109,7 +141,7 @@ func runPlan(_ *cobra.Command, _ []string) error {
 
 	// Write a Gateway resource that the routes will attach to.
 	fmt.Fprintln(out, "gateway:")
-	fmt.Fprintln(out, "  name: migrated-gateway")
+	fmt.Fprintln(out, "  name:", planGatewayName)
 	fmt.Fprintln(out, "  namespace: default")
 	fmt.Fprintln(out, "  gatewayClassName: nginx")
 	fmt.Fprintln(out, "  listeners:")
@@ -156,13 +188,36 @@ func runPlan(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	fmt.Printf("\nMigration plan generated with %d mapping(s).\n", len(entries))
-	fmt.Printf("Plan written to %s\n", planOutput)
-	fmt.Println("\nNext step: review the plan, then run 'ngf-migrate apply -p", planOutput+"'")
+	fmt.Fprintf(progress, "\nMigration plan generated with %d mapping(s).\n", len(entries))
+	fmt.Fprintf(progress, "Plan written to %s\n", planFile)
+	fmt.Fprintln(progress, "\nNext step: review the plan, then run 'ngf-migrate apply -p", planFile+"'")
 
+	if planOutput != outputTable {
+		return writePlanResult(entries, planFile)
+	}
 	return nil
 }
 
+// writePlanResult prints the plan's mappings as JSON or YAML on stdout.
+func writePlanResult(entries []planEntry, plan string) error {
+	result := planResult{
+		Input:    planInput,
+		Plan:     plan,
+		Mappings: make([]resultMapping, 0, len(entries)),
+	}
+	if plan != "" {
+		result.Gateway = planGatewayName
+	}
+	for _, e := range entries {
+		result.Mappings = append(result.Mappings, resultMapping{
+			Source:     resourceRef{Kind: e.kind, Name: e.name, Namespace: e.namespace},
+			Target:     resourceRef{Kind: targetForKind(e.kind), Name: e.name, Namespace: e.namespace},
+			Confidence: confidenceForKind(e.kind),
+		})
+	}
+	return writeStructured(os.Stdout, planOutput, result)
+}
+
 // parseScanResults reads the scan results file and extracts resource entries.
 // It uses simple line-based parsing to avoid external YAML dependencies.
 func parseScanResults(path string) ([]planEntry, error) {
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,25 +12,30 @@ import (
 )
 
 var (
-	scanInput  string
-	scanOutput string
+	scanInput   string
+	scanResults string
+	scanOutput  string
 )
 
 var scanCmd = &cobra.Command{
 	Use:   "scan",
 	Short: "Discover NGINX Ingress Controller resources in YAML files",
 	Long: `Scan YAML files for Ingress, VirtualServer, VirtualServerRoute, and
-TransportServer resources. The discovered resources are written to an output
-file for use by the plan command.
+TransportServer resources. The discovered resources are written to a results
+file (-r) for use by the plan command.
 
 Provide a single YAML file or a directory of YAML files via the -i flag.
-If no input is specified, the current directory is scanned.`,
+If no input is specified, the current directory is scanned.
+
+Use --output json or --output yaml to also print the discovered resources in a
+machine-readable form on stdout; progress messages are then written to stderr.`,
 	RunE: runScan,
 }
 
 func init() {
 	scanCmd.Flags().StringVarP(&scanInput, "input", "i", ".", "input YAML file or directory to scan")
-	scanCmd.Flags().StringVarP(&scanOutput, "output", "o", "scan-results.yaml", "output file for scan results")
+	scanCmd.Flags().StringVarP(&scanResults, "results", "r", "scan-results.yaml", "file to write scan results to")
+	scanCmd.Flags().StringVarP(&scanOutput, "output", "o", outputTable, "output format: table, json, or yaml")
 }
 
 // resourceEntry holds discovered resource metadata from a YAML document.
@@ -41,7 +47,35 @@ type resourceEntry struct {
 	file       string
 }
 
-func runScan(_ *cobra.Command, _ []string) error {
+// scanResult is the structured result of the scan command for --output json
+// and yaml.
+type scanResult struct {
+	Input          string               `json:"input" yaml:"input"`
+	Results        string               `json:"results,omitempty" yaml:"results,omitempty"`
+	TotalResources int                  `json:"totalResources" yaml:"totalResources"`
+	ByKind         map[string]int       `json:"byKind" yaml:"byKind"`
+	Resources      []scanResultResource `json:"resources" yaml:"resources"`
+}
+
+type scanResultResource struct {
+	Kind       string `json:"kind" yaml:"kind"`
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Name       string `json:"name" yaml:"name"`
+	Namespace  string `json:"namespace" yaml:"namespace"`
+	SourceFile string `json:"sourceFile" yaml:"sourceFile"`
+}
+
+func runScan(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(scanOutput); err != nil {
+		return err
+	}
+	// Progress goes to stderr when stdout carries the structured result.
+	progress := io.Writer(os.Stdout)
+	if scanOutput != outputTable {
+		cmd.SilenceUsage = true
+		progress = os.Stderr
+	}
+
 	info, err := os.Stat(scanInput)
 	if err != nil {
 		return fmt.Errorf("cannot access input path %q: %w", scanInput, err)
@@ -63,14 +97,17 @@ func runScan(_ *cobra.Command, _ []string) error {
 			}
 		}
 		if len(files) == 0 {
-			fmt.Println("No YAML files found in", scanInput)
+			fmt.Fprintln(progress, "No YAML files found in", scanInput)
+			if scanOutput != outputTable {
+				return writeScanResult(nil, nil, "")
+			}
 			return nil
 		}
 	} else {
 		files = []string{scanInput}
 	}
 
-	fmt.Printf("Scanning %d file(s) for NGINX Ingress Controller resources...\n", len(files))
+	fmt.Fprintf(progress, "Scanning %d file(s) for NGINX Ingress Controller resources...\n", len(files))
 
 	var resources []resourceEntry
 	for _, f := range files {
@@ -89,9 +126,9 @@ func runScan(_ *cobra.Command, _ []string) error {
 	}
 
 	// Write results.
-	out, err := os.Create(scanOutput)
+	out, err := os.Create(scanResults)
 	if err != nil {
-		return fmt.Errorf("cannot create output file %q: %w", scanOutput, err)
+		return fmt.Errorf("cannot create results file %q: %w", scanResults, err)
 	}
 	defer out.Close()
 
@@ -116,15 +153,42 @@ func runScan(_ *cobra.Command, _ []string) error {
 		fmt.Fprintln(out, "    sourceFile:", r.file)
 	}
 
-	fmt.Printf("\nScan complete. Found %d resource(s):\n", len(resources))
+	fmt.Fprintf(progress, "\nScan complete. Found %d resource(s):\n", len(resources))
 	for kind, count := range counts {
-		fmt.Printf("  %s: %d\n", kind, count)
+		fmt.Fprintf(progress, "  %s: %d\n", kind, count)
 	}
-	fmt.Printf("Results written to %s\n", scanOutput)
+	fmt.Fprintf(progress, "Results written to %s\n", scanResults)
 
+	if scanOutput != outputTable {
+		return writeScanResult(resources, counts, scanResults)
+	}
 	return nil
 }
 
+// writeScanResult prints the discovered resources as JSON or YAML on stdout.
+func writeScanResult(resources []resourceEntry, counts map[string]int, results string) error {
+	result := scanResult{
+		Input:          scanInput,
+		Results:        results,
+		TotalResources: len(resources),
+		ByKind:         counts,
+		Resources:      make([]scanResultResource, 0, len(resources)),
+	}
+	if result.ByKind == nil {
+		result.ByKind = map[string]int{}
+	}
+	for _, r := range resources {
+		result.Resources = append(result.Resources, scanResultResource{
+			Kind:       r.kind,
+			APIVersion: r.apiVersion,
+			Name:       r.name,
+			Namespace:  r.namespace,
+			SourceFile: r.file,
+		})
+	}
+	return writeStructured(os.Stdout, scanOutput, result)
+}
+
 // scanFile reads a YAML file and extracts resource metadata by looking for
 // kind: and apiVersion: lines. It splits on "---" document separators.
 func scanFile(path string) ([]resourceEntry, error) {
@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// gatewayAPIGroup is the API group of all Gateway API resources.
+const gatewayAPIGroup = "gateway.networking.k8s.io"
+
+// gatewayAPIVersions lists the served versions of each Gateway API kind, as
+// defined by the standard and experimental channel CRDs.
+var gatewayAPIVersions = map[string][]string{
+	"GatewayClass":   {"v1", "v1beta1"},
+	"Gateway":        {"v1", "v1beta1"},
+	"HTTPRoute":      {"v1", "v1beta1"},
+	"GRPCRoute":      {"v1"},
+	"ReferenceGrant": {"v1beta1"},
+	"TCPRoute":       {"v1alpha2"},
+	"TLSRoute":       {"v1alpha2"},
+	"UDPRoute":       {"v1alpha2"},
+}
+
+// Patterns and limits copied from the Gateway API CRD schemas.
+var (
+	dns1123SubdomainRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	dns1123LabelRe     = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	hostnameRe         = regexp.MustCompile(`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	protocolRe         = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?$|[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*\/[A-Za-z0-9]+$`)
+	controllerNameRe   = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*\/[A-Za-z0-9\/\-._~%!$&'()*+,;=:]+$`)
+
+	httpPathMatchTypes  = []string{"Exact", "PathPrefix", "RegularExpression"}
+	headerMatchTypes    = []string{"Exact", "RegularExpression"}
+	httpMethods         = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+	httpRouteFilters    = []string{"RequestHeaderModifier", "ResponseHeaderModifier", "RequestMirror", "RequestRedirect", "URLRewrite", "ExtensionRef"}
+	grpcRouteFilters    = []string{"RequestHeaderModifier", "ResponseHeaderModifier", "RequestMirror", "ExtensionRef"}
+	grpcMethodMatchType = []string{"Exact", "RegularExpression"}
+	tlsModes            = []string{"Terminate", "Passthrough"}
+)
+
+const (
+	maxListeners   = 64
+	maxParentRefs  = 32
+	maxHostnames   = 16
+	maxRouteRules  = 16
+	maxBackendRefs = 16
+	maxWeight      = 1000000
+)
+
+// fieldErrors collects schema violations keyed by field path.
+type fieldErrors []string
+
+func (e *fieldErrors) add(path, format string, args ...any) {
+	*e = append(*e, path+": "+fmt.Sprintf(format, args...))
+}
+
+// validateResource checks a decoded Gateway API object against the schema
+// rules of its kind. It returns nil if the object is valid.
+func validateResource(obj map[string]any) []string {
+	var errs fieldErrors
+
+	kind := str(obj["kind"])
+	apiVersion := str(obj["apiVersion"])
+	if kind == "" {
+		errs.add("kind", "required")
+	}
+	if apiVersion == "" {
+		errs.add("apiVersion", "required")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	group, version, _ := strings.Cut(apiVersion, "/")
+	versions, known := gatewayAPIVersions[kind]
+	switch {
+	case group != gatewayAPIGroup:
+		errs.add("apiVersion", "%q is not a Gateway API group version", apiVersion)
+		return errs
+	case !known:
+		errs.add("kind", "unknown Gateway API kind %q", kind)
+		return errs
+	case !contains(versions, version):
+		errs.add("apiVersion", "%s is not served for %s (supported: %s)", version, kind, strings.Join(versions, ", "))
+	}
+
+	meta := mapOf(obj["metadata"])
+	name := str(meta["name"])
+	switch {
+	case name == "":
+		errs.add("metadata.name", "required")
+	case len(name) > 253 || !dns1123SubdomainRe.MatchString(name):
+		errs.add("metadata.name", "%q must be a lowercase RFC 1123 subdomain", name)
+	}
+	if ns := str(meta["namespace"]); ns != "" && (len(ns) > 63 || !dns1123LabelRe.MatchString(ns)) {
+		errs.add("metadata.namespace", "%q must be a lowercase RFC 1123 label", ns)
+	}
+
+	spec, ok := obj["spec"].(map[string]any)
+	if !ok {
+		errs.add("spec", "required")
+		return errs
+	}
+
+	switch kind {
+	case "GatewayClass":
+		validateGatewayClassSpec(spec, &errs)
+	case "Gateway":
+		validateGatewaySpec(spec, &errs)
+	case "HTTPRoute":
+		validateHTTPRouteSpec(spec, &errs)
+	case "GRPCRoute":
+		validateGRPCRouteSpec(spec, &errs)
+	case "TCPRoute", "UDPRoute":
+		validateL4RouteSpec(spec, &errs)
+	case "TLSRoute":
+		validateHostnames("spec.hostnames", spec["hostnames"], &errs)
+		validateL4RouteSpec(spec, &errs)
+	case "ReferenceGrant":
+		validateReferenceGrantSpec(spec, &errs)
+	}
+	return errs
+}
+
+func validateGatewayClassSpec(spec map[string]any, errs *fieldErrors) {
+	controller := str(spec["controllerName"])
+	switch {
+	case controller == "":
+		errs.add("spec.controllerName", "required")
+	case !controllerNameRe.MatchString(controller):
+		errs.add("spec.controllerName", "%q must be a domain-prefixed path (e.g. example.com/controller)", controller)
+	}
+}
+
+func validateGatewaySpec(spec map[string]any, errs *fieldErrors) {
+	if str(spec["gatewayClassName"]) == "" {
+		errs.add("spec.gatewayClassName", "required")
+	}
+
+	listeners := sliceOf(spec["listeners"])
+	switch {
+	case len(listeners) == 0:
+		errs.add("spec.listeners", "at least one listener is required")
+	case len(listeners) > maxListeners:
+		errs.add("spec.listeners", "must have at most %d items", maxListeners)
+	}
+
+	seen := make(map[string]bool, len(listeners))
+	for i, item := range listeners {
+		path := fmt.Sprintf("spec.listeners[%d]", i)
+		l := mapOf(item)
+
+		name := str(l["name"])
+		switch {
+		case name == "":
+			errs.add(path+".name", "required")
+		case !dns1123SubdomainRe.MatchString(name):
+			errs.add(path+".name", "%q must be a lowercase RFC 1123 subdomain", name)
+		case seen[name]:
+			errs.add(path+".name", "duplicate listener name %q", name)
+		}
+		seen[name] = true
+
+		validatePort(path+".port", l["port"], errs)
+
+		protocol := str(l["protocol"])
+		switch {
+		case protocol == "":
+			errs.add(path+".protocol", "required")
+		case !protocolRe.MatchString(protocol):
+			errs.add(path+".protocol", "invalid protocol %q", protocol)
+		}
+
+		if h, ok := l["hostname"]; ok {
+			validateHostname(path+".hostname", str(h), errs)
+		}
+
+		tls := mapOf(l["tls"])
+		mode := str(tls["mode"])
+		if mode != "" && !contains(tlsModes, mode) {
+			errs.add(path+".tls.mode", "must be one of %s", strings.Join(tlsModes, ", "))
+		}
+		if protocol == "HTTPS" || (protocol == "TLS" && mode != "Passthrough") {
+			if l["tls"] == nil {
+				errs.add(path+".tls", "required for protocol %s", protocol)
+			} else if mode != "Passthrough" && len(sliceOf(tls["certificateRefs"])) == 0 {
+				errs.add(path+".tls.certificateRefs", "at least one certificate is required when tls mode is Terminate")
+			}
+		}
+	}
+}
+
+func validateHTTPRouteSpec(spec map[string]any, errs *fieldErrors) {
+	validateParentRefs(spec, errs)
+	validateHostnames("spec.hostnames", spec["hostnames"], errs)
+
+	rules := sliceOf(spec["rules"])
+	if len(rules) > maxRouteRules {
+		errs.add("spec.rules", "must have at most %d items", maxRouteRules)
+	}
+	for i, item := range rules {
+		path := fmt.Sprintf("spec.rules[%d]", i)
+		rule := mapOf(item)
+
+		for j, m := range sliceOf(rule["matches"]) {
+			mpath := fmt.Sprintf("%s.matches[%d]", path, j)
+			match := mapOf(m)
+			if p, ok := match["path"]; ok {
+				pm := mapOf(p)
+				typ := str(pm["type"])
+				if typ != "" && !contains(httpPathMatchTypes, typ) {
+					errs.add(mpath+".path.type", "must be one of %s", strings.Join(httpPathMatchTypes, ", "))
+				}
+				if v := str(pm["value"]); v != "" && typ != "RegularExpression" && !strings.HasPrefix(v, "/") {
+					errs.add(mpath+".path.value", "%q must start with /", v)
+				}
+			}
+			if method := str(match["method"]); method != "" && !contains(httpMethods, method) {
+				errs.add(mpath+".method", "unsupported HTTP method %q", method)
+			}
+			validateHeaderMatches(mpath+".headers", match["headers"], errs)
+			validateHeaderMatches(mpath+".queryParams", match["queryParams"], errs)
+		}
+
+		validateFilters(path+".filters", rule["filters"], httpRouteFilters, errs)
+		validateBackendRefs(path+".backendRefs", rule["backendRefs"], false, errs)
+	}
+}
+
+func validateGRPCRouteSpec(spec map[string]any, errs *fieldErrors) {
+	validateParentRefs(spec, errs)
+	validateHostnames("spec.hostnames", spec["hostnames"], errs)
+
+	rules := sliceOf(spec["rules"])
+	if len(rules) > maxRouteRules {
+		errs.add("spec.rules", "must have at most %d items", maxRouteRules)
+	}
+	for i, item := range rules {
+		path := fmt.Sprintf("spec.rules[%d]", i)
+		rule := mapOf(item)
+
+		for j, m := range sliceOf(rule["matches"]) {
+			mpath := fmt.Sprintf("%s.matches[%d]", path, j)
+			method := mapOf(mapOf(m)["method"])
+			if typ := str(method["type"]); typ != "" && !contains(grpcMethodMatchType, typ) {
+				errs.add(mpath+".method.type", "must be one of %s", strings.Join(grpcMethodMatchType, ", "))
+			}
+			if mapOf(m)["method"] != nil && str(method["service"]) == "" && str(method["method"]) == "" {
+				errs.add(mpath+".method", "one or both of service or method must be specified")
+			}
+			validateHeaderMatches(mpath+".headers", mapOf(m)["headers"], errs)
+		}
+
+		validateFilters(path+".filters", rule["filters"], grpcRouteFilters, errs)
+		validateBackendRefs(path+".backendRefs", rule["backendRefs"], false, errs)
+	}
+}
+
+// validateL4RouteSpec validates TCPRoute, TLSRoute, and UDPRoute specs, which
+// require at least one rule with at least one backend.
+func validateL4RouteSpec(spec map[string]any, errs *fieldErrors) {
+	validateParentRefs(spec, errs)
+
+	rules := sliceOf(spec["rules"])
+	switch {
+	case len(rules) == 0:
+		errs.add("spec.rules", "at least one rule is required")
+	case len(rules) > maxRouteRules:
+		errs.add("spec.rules", "must have at most %d items", maxRouteRules)
+	}
+	for i, item := range rules {
+		validateBackendRefs(fmt.Sprintf("spec.rules[%d].backendRefs", i), mapOf(item)["backendRefs"], true, errs)
+	}
+}
+
+func validateReferenceGrantSpec(spec map[string]any, errs *fieldErrors) {
+	from := sliceOf(spec["from"])
+	if len(from) == 0 {
+		errs.add("spec.from", "at least one entry is required")
+	}
+	for i, item := range from {
+		f := mapOf(item)
+		path := fmt.Sprintf("spec.from[%d]", i)
+		if _, ok := f["group"]; !ok {
+			errs.add(path+".group", "required")
+		}
+		if str(f["kind"]) == "" {
+			errs.add(path+".kind", "required")
+		}
+		if str(f["namespace"]) == "" {
+			errs.add(path+".namespace", "required")
+		}
+	}
+
+	to := sliceOf(spec["to"])
+	if len(to) == 0 {
+		errs.add("spec.to", "at least one entry is required")
+	}
+	for i, item := range to {
+		t := mapOf(item)
+		path := fmt.Sprintf("spec.to[%d]", i)
+		if _, ok := t["group"]; !ok {
+			errs.add(path+".group", "required")
+		}
+		if str(t["kind"]) == "" {
+			errs.add(path+".kind", "required")
+		}
+	}
+}
+
+func validateParentRefs(spec map[string]any, errs *fieldErrors) {
+	refs := sliceOf(spec["parentRefs"])
+	if len(refs) > maxParentRefs {
+		errs.add("spec.parentRefs", "must have at most %d items", maxParentRefs)
+	}
+	for i, item := range refs {
+		path := fmt.Sprintf("spec.parentRefs[%d]", i)
+		ref := mapOf(item)
+		if str(ref["name"]) == "" {
+			errs.add(path+".name", "required")
+		}
+		if p, ok := ref["port"]; ok {
+			validatePort(path+".port", p, errs)
+		}
+	}
+}
+
+func validateHostnames(path string, v any, errs *fieldErrors) {
+	hostnames := sliceOf(v)
+	if len(hostnames) > maxHostnames {
+		errs.add(path, "must have at most %d items", maxHostnames)
+	}
+	for i, h := range hostnames {
+		validateHostname(fmt.Sprintf("%s[%d]", path, i), str(h), errs)
+	}
+}
+
+func validateHostname(path, hostname string, errs *fieldErrors) {
+	if hostname == "" || len(hostname) > 253 || !hostnameRe.MatchString(hostname) {
+		errs.add(path, "%q is not a valid hostname", hostname)
+	}
+}
+
+func validateHeaderMatches(path string, v any, errs *fieldErrors) {
+	for i, item := range sliceOf(v) {
+		h := mapOf(item)
+		hpath := fmt.Sprintf("%s[%d]", path, i)
+		if str(h["name"]) == "" {
+			errs.add(hpath+".name", "required")
+		}
+		if _, ok := h["value"]; !ok {
+			errs.add(hpath+".value", "required")
+		}
+		if typ := str(h["type"]); typ != "" && !contains(headerMatchTypes, typ) {
+			errs.add(hpath+".type", "must be one of %s", strings.Join(headerMatchTypes, ", "))
+		}
+	}
+}
+
+func validateFilters(path string, v any, allowed []string, errs *fieldErrors) {
+	for i, item := range sliceOf(v) {
+		f := mapOf(item)
+		fpath := fmt.Sprintf("%s[%d]", path, i)
+		typ := str(f["type"])
+		switch {
+		case typ == "":
+			errs.add(fpath+".type", "required")
+		case !contains(allowed, typ):
+			errs.add(fpath+".type", "must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+}
+
+// validateBackendRefs checks route backends. Service backends (the default
+// kind) must set a port. L4 routes require at least one backend per rule.
+func validateBackendRefs(path string, v any, required bool, errs *fieldErrors) {
+	refs := sliceOf(v)
+	switch {
+	case required && len(refs) == 0:
+		errs.add(path, "at least one backend is required")
+	case len(refs) > maxBackendRefs:
+		errs.add(path, "must have at most %d items", maxBackendRefs)
+	}
+	for i, item := range refs {
+		bpath := fmt.Sprintf("%s[%d]", path, i)
+		ref := mapOf(item)
+		if str(ref["name"]) == "" {
+			errs.add(bpath+".name", "required")
+		}
+
+		kind := str(ref["kind"])
+		group := str(ref["group"])
+		if (kind == "" || kind == "Service") && group == "" {
+			validatePort(bpath+".port", ref["port"], errs)
+		} else if p, ok := ref["port"]; ok {
+			validatePort(bpath+".port", p, errs)
+		}
+
+		if w, ok := ref["weight"]; ok {
+			weight, isInt := w.(int)
+			if !isInt || weight < 0 || weight > maxWeight {
+				errs.add(bpath+".weight", "must be an integer between 0 and %d", maxWeight)
+			}
+		}
+	}
+}
+
+func validatePort(path string, v any, errs *fieldErrors) {
+	if v == nil {
+		errs.add(path, "required")
+		return
+	}
+	port, ok := v.(int)
+	if !ok || port < 1 || port > 65535 {
+		errs.add(path, "must be an integer between 1 and 65535")
+	}
+}
+
+// Helpers for walking decoded YAML.
+
+func mapOf(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func sliceOf(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
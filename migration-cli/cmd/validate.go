@@ -1,12 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
-var validatePlan string
+var (
+	validatePlan   string
+	validateFile   string
+	validateOutput string
+)
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
@@ -14,65 +22,114 @@ var validateCmd = &cobra.Command{
 	Long: `Validate that the migrated Gateway API resources are correctly configured
 and functioning in the target cluster.
 
-This command prints a validation checklist and instructions for verifying
-the migration. Full automated validation requires the NGF Console API.`,
+With --file, validate a bundle of Gateway API resources offline against the
+Gateway API schema without contacting a cluster. The command exits non-zero
+if any resource fails validation, so it can be used to check migration
+output in CI.
+
+Without --file, this command prints a validation checklist and instructions
+for verifying the migration. Full automated validation requires the NGF
+Console API.
+
+Use --output json or --output yaml to print the per-resource results (or the
+checklist) in a machine-readable form on stdout; progress messages are then
+written to stderr.`,
 	RunE: runValidate,
 }
 
 func init() {
 	validateCmd.Flags().StringVarP(&validatePlan, "plan", "p", "migration-plan.yaml", "migration plan file to validate against")
+	validateCmd.Flags().StringVarP(&validateFile, "file", "f", "", "resource bundle YAML to validate offline against the Gateway API schema")
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", outputTable, "output format: table, json, or yaml")
 }
 
-func runValidate(_ *cobra.Command, _ []string) error {
-	fmt.Println("=== Migration Validation Checklist ===")
-	fmt.Println()
+// validateCheck is one manual step of the validation checklist.
+type validateCheck struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Command     string `json:"command" yaml:"command"`
+}
+
+// validateChecklist is the structured result of validate without --file.
+type validateChecklist struct {
+	Checks []validateCheck `json:"checks" yaml:"checks"`
+}
+
+// validateResult is the structured result of validate --file for --output
+// json and yaml.
+type validateResult struct {
+	File      string                   `json:"file" yaml:"file"`
+	Total     int                      `json:"total" yaml:"total"`
+	Failed    int                      `json:"failed" yaml:"failed"`
+	Resources []validateResultResource `json:"resources" yaml:"resources"`
+}
+
+type validateResultResource struct {
+	Resource string   `json:"resource" yaml:"resource"`
+	Document int      `json:"document" yaml:"document"`
+	Valid    bool     `json:"valid" yaml:"valid"`
+	Errors   []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
 
-	checks := []struct {
-		name        string
-		description string
-		command     string
-	}{
+func runValidate(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(validateOutput); err != nil {
+		return err
+	}
+	if validateFile != "" {
+		// Validation failures are reported per resource; skip the usage dump.
+		cmd.SilenceUsage = true
+		return runValidateFile(validateFile, validateOutput)
+	}
+
+	checks := []validateCheck{
 		{
-			name:        "Gateway Status",
-			description: "Verify the Gateway resource is programmed and has an address",
-			command:     "kubectl get gateway migrated-gateway -o wide",
+			Name:        "Gateway Status",
+			Description: "Verify the Gateway resource is programmed and has an address",
+			Command:     "kubectl get gateway migrated-gateway -o wide",
 		},
 		{
-			name:        "Route Status",
-			description: "Verify HTTPRoutes are accepted by the Gateway",
-			command:     "kubectl get httproutes -o wide",
+			Name:        "Route Status",
+			Description: "Verify HTTPRoutes are accepted by the Gateway",
+			Command:     "kubectl get httproutes -o wide",
 		},
 		{
-			name:        "Backend Health",
-			description: "Verify backend services are running and have ready endpoints",
-			command:     "kubectl get endpoints -l app.kubernetes.io/managed-by=ngf-migrate",
+			Name:        "Backend Health",
+			Description: "Verify backend services are running and have ready endpoints",
+			Command:     "kubectl get endpoints -l app.kubernetes.io/managed-by=ngf-migrate",
 		},
 		{
-			name:        "TLS Certificates",
-			description: "Verify TLS secrets exist and are not expired",
-			command:     "kubectl get secrets -l migration.ngf.io/tls=true",
+			Name:        "TLS Certificates",
+			Description: "Verify TLS secrets exist and are not expired",
+			Command:     "kubectl get secrets -l migration.ngf.io/tls=true",
 		},
 		{
-			name:        "DNS Resolution",
-			description: "Verify hostnames resolve to the Gateway address",
-			command:     "kubectl get gateway migrated-gateway -o jsonpath='{.status.addresses[0].value}'",
+			Name:        "DNS Resolution",
+			Description: "Verify hostnames resolve to the Gateway address",
+			Command:     "kubectl get gateway migrated-gateway -o jsonpath='{.status.addresses[0].value}'",
 		},
 		{
-			name:        "Traffic Test",
-			description: "Send a test request through the migrated routes",
-			command:     "curl -v http://<gateway-address>/",
+			Name:        "Traffic Test",
+			Description: "Send a test request through the migrated routes",
+			Command:     "curl -v http://<gateway-address>/",
 		},
 		{
-			name:        "Old Resources",
-			description: "Check that old Ingress/VirtualServer resources can be safely removed",
-			command:     "kubectl get ingress,virtualservers --all-namespaces",
+			Name:        "Old Resources",
+			Description: "Check that old Ingress/VirtualServer resources can be safely removed",
+			Command:     "kubectl get ingress,virtualservers --all-namespaces",
 		},
 	}
 
+	if validateOutput != outputTable {
+		cmd.SilenceUsage = true
+		return writeStructured(os.Stdout, validateOutput, validateChecklist{Checks: checks})
+	}
+
+	fmt.Println("=== Migration Validation Checklist ===")
+	fmt.Println()
 	for i, c := range checks {
-		fmt.Printf("  %d. [  ] %s\n", i+1, c.name)
-		fmt.Printf("       %s\n", c.description)
-		fmt.Printf("       $ %s\n", c.command)
+		fmt.Printf("  %d. [  ] %s\n", i+1, c.Name)
+		fmt.Printf("       %s\n", c.Description)
+		fmt.Printf("       $ %s\n", c.Command)
 		fmt.Println()
 	}
 
@@ -88,3 +145,118 @@ func runValidate(_ *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// bundleResource is one YAML document from a resource bundle.
+type bundleResource struct {
+	index int // 1-based document position in the bundle
+	obj   map[string]any
+}
+
+// runValidateFile validates every Gateway API resource in a multi-document
+// YAML bundle and reports each failure in the given output format. It returns
+// an error if any resource is invalid.
+func runValidateFile(path, format string) error {
+	// Progress goes to stderr when stdout carries the structured result.
+	progress := io.Writer(os.Stdout)
+	if format != outputTable {
+		progress = os.Stderr
+	}
+
+	resources, err := readBundle(path)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	result := validateResult{File: path, Total: len(resources), Resources: []validateResultResource{}}
+	if len(resources) == 0 {
+		fmt.Fprintln(progress, "No resources found in", path)
+		if format != outputTable {
+			return writeStructured(os.Stdout, format, result)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(progress, "Validating %d resource(s) in %s against the Gateway API schema...\n\n", len(resources), path)
+
+	for _, r := range resources {
+		label := describeResource(r)
+		errs := validateResource(r.obj)
+		result.Resources = append(result.Resources, validateResultResource{
+			Resource: label,
+			Document: r.index,
+			Valid:    len(errs) == 0,
+			Errors:   errs,
+		})
+		if format != outputTable {
+			if len(errs) > 0 {
+				result.Failed++
+			}
+			continue
+		}
+		if len(errs) == 0 {
+			fmt.Printf("  ok    %s\n", label)
+			continue
+		}
+		result.Failed++
+		fmt.Printf("  FAIL  %s\n", label)
+		for _, e := range errs {
+			fmt.Printf("          - %s\n", e)
+		}
+	}
+
+	if format != outputTable {
+		if err := writeStructured(os.Stdout, format, result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d resource(s) failed validation", result.Failed, len(resources))
+	}
+	fmt.Fprintf(progress, "All %d resource(s) are valid.\n", len(resources))
+	return nil
+}
+
+// readBundle decodes all non-empty YAML documents in path.
+func readBundle(path string) ([]bundleResource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var resources []bundleResource
+	dec := yaml.NewDecoder(f)
+	for i := 1; ; i++ {
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		resources = append(resources, bundleResource{index: i, obj: obj})
+	}
+	return resources, nil
+}
+
+// describeResource returns a "Kind namespace/name" label for output.
+func describeResource(r bundleResource) string {
+	kind := str(r.obj["kind"])
+	if kind == "" {
+		kind = "<unknown kind>"
+	}
+	meta := mapOf(r.obj["metadata"])
+	name := str(meta["name"])
+	if name == "" {
+		return fmt.Sprintf("%s (document %d)", kind, r.index)
+	}
+	if ns := str(meta["namespace"]); ns != "" {
+		name = ns + "/" + name
+	}
+	return kind + " " + name
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validBundle = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: migrated-gateway
+  namespace: default
+spec:
+  gatewayClassName: nginx
+  listeners:
+    - name: http
+      port: 80
+      protocol: HTTP
+    - name: https
+      port: 443
+      protocol: HTTPS
+      tls:
+        certificateRefs:
+          - name: tls-secret
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: cafe
+  namespace: default
+spec:
+  parentRefs:
+    - name: migrated-gateway
+  hostnames:
+    - cafe.example.com
+  rules:
+    - matches:
+        - path:
+            type: PathPrefix
+            value: /coffee
+      backendRefs:
+        - name: coffee
+          port: 80
+---
+apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: TCPRoute
+metadata:
+  name: db
+spec:
+  parentRefs:
+    - name: migrated-gateway
+  rules:
+    - backendRefs:
+        - name: postgres
+          port: 5432
+`
+
+func TestValidateResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string // substring of an expected error; empty means valid
+	}{
+		{
+			name:    "wrong group",
+			yaml:    "apiVersion: networking.k8s.io/v1\nkind: Ingress\nmetadata: {name: a}\nspec: {}\n",
+			wantErr: "not a Gateway API group version",
+		},
+		{
+			name:    "unserved version",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: TCPRoute\nmetadata: {name: a}\nspec: {rules: [{backendRefs: [{name: b, port: 1}]}]}\n",
+			wantErr: "apiVersion: v1 is not served for TCPRoute",
+		},
+		{
+			name:    "missing gateway class",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nmetadata: {name: a}\nspec: {listeners: [{name: http, port: 80, protocol: HTTP}]}\n",
+			wantErr: "spec.gatewayClassName: required",
+		},
+		{
+			name:    "listener port out of range",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nmetadata: {name: a}\nspec: {gatewayClassName: nginx, listeners: [{name: http, port: 70000, protocol: HTTP}]}\n",
+			wantErr: "spec.listeners[0].port",
+		},
+		{
+			name:    "duplicate listener",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nmetadata: {name: a}\nspec: {gatewayClassName: nginx, listeners: [{name: http, port: 80, protocol: HTTP}, {name: http, port: 8080, protocol: HTTP}]}\n",
+			wantErr: "duplicate listener name",
+		},
+		{
+			name:    "https without tls",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nmetadata: {name: a}\nspec: {gatewayClassName: nginx, listeners: [{name: https, port: 443, protocol: HTTPS}]}\n",
+			wantErr: "spec.listeners[0].tls: required",
+		},
+		{
+			name:    "bad path match",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nmetadata: {name: a}\nspec: {rules: [{matches: [{path: {type: Prefix, value: coffee}}]}]}\n",
+			wantErr: "spec.rules[0].matches[0].path.type",
+		},
+		{
+			name:    "service backend without port",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nmetadata: {name: a}\nspec: {rules: [{backendRefs: [{name: coffee}]}]}\n",
+			wantErr: "spec.rules[0].backendRefs[0].port: required",
+		},
+		{
+			name:    "invalid name",
+			yaml:    "apiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nmetadata: {name: Cafe_Route}\nspec: {}\n",
+			wantErr: "metadata.name",
+		},
+		{
+			name: "valid grpc route",
+			yaml: "apiVersion: gateway.networking.k8s.io/v1\nkind: GRPCRoute\nmetadata: {name: a}\nspec: {parentRefs: [{name: gw}], rules: [{matches: [{method: {service: helloworld.Greeter}}], backendRefs: [{name: grpc, port: 50051}]}]}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "bundle.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			resources, err := readBundle(path)
+			if err != nil || len(resources) != 1 {
+				t.Fatalf("readBundle: %v (%d resources)", err, len(resources))
+			}
+
+			errs := validateResource(resources[0].obj)
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected valid, got %v", errs)
+				}
+				return
+			}
+			joined := strings.Join(errs, "\n")
+			if !strings.Contains(joined, tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestRunValidateFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte(validBundle), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runValidateFile(valid, outputTable); err != nil {
+		t.Fatalf("expected valid bundle to pass, got %v", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	bundle := validBundle + "---\napiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nmetadata: {name: broken}\nspec: {rules: [{backendRefs: [{name: tea}]}]}\n"
+	if err := os.WriteFile(invalid, []byte(bundle), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := runValidateFile(invalid, outputTable)
+	if err == nil || !strings.Contains(err.Error(), "1 of 4 resource(s) failed validation") {
+		t.Fatalf("expected one failure, got %v", err)
+	}
+}
+
+func TestRunValidateFile_JSONOutput(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	bundle := validBundle + "---\napiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nmetadata: {name: broken}\nspec: {rules: [{backendRefs: [{name: tea}]}]}\n"
+	if err := os.WriteFile(invalid, []byte(bundle), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The results are printed even though the command fails.
+	var runErr error
+	out := captureStdout(t, func() error {
+		runErr = runValidateFile(invalid, outputJSON)
+		return nil
+	})
+	if runErr == nil {
+		t.Error("expected an error for the invalid bundle")
+	}
+	var result validateResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("validate printed invalid JSON: %v\n%s", err, out)
+	}
+	if result.Total != 4 || result.Failed != 1 || len(result.Resources) != 4 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	broken := result.Resources[3]
+	if broken.Valid || broken.Resource != "HTTPRoute broken" || len(broken.Errors) == 0 {
+		t.Errorf("unexpected result for the broken route: %+v", broken)
+	}
+}
@@ -2,7 +2,10 @@ module github.com/kubenetlabs/ngc/migration-cli
 
 go 1.23
 
-require github.com/spf13/cobra v1.8.1
+require (
+	github.com/spf13/cobra v1.8.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
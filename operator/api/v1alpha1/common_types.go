@@ -10,18 +10,36 @@ type ChildStatus struct {
 	Name string `json:"name"`
 	// Ready indicates whether the child resource is in a ready state.
 	Ready bool `json:"ready"`
+	// Reason is a stable, machine-readable code for the child's state (one of
+	// the ChildReason constants). Message carries the human-readable detail.
+	Reason string `json:"reason,omitempty"`
 	// Message provides additional context about the child's state.
 	Message string `json:"message,omitempty"`
 }
 
 // ThresholdSpec defines a scaling threshold with metric and target value.
 type ThresholdSpec struct {
+	// Type selects the KEDA scaler: "prometheus" (the default) queries Metric,
+	// "cpu" and "memory" use KEDA's resource scalers, and "gpu" queries DCGM
+	// GPU utilization through Prometheus. With the hpa backend, cpu and memory
+	// become Resource metrics and prometheus and gpu become Pods metrics.
+	Type string `json:"type,omitempty"`
 	// Metric is the metric name to watch (e.g., "queue_depth", "kv_cache_pct").
-	Metric string `json:"metric"`
-	// Target is the threshold value that triggers scaling.
+	// It is only used by prometheus thresholds.
+	Metric string `json:"metric,omitempty"`
+	// Target is the threshold value that triggers scaling. For cpu, memory,
+	// and gpu thresholds it is a utilization percentage.
 	Target int32 `json:"target"`
 }
 
+// Threshold type constants for ThresholdSpec.
+const (
+	ThresholdTypePrometheus = "prometheus"
+	ThresholdTypeCPU        = "cpu"
+	ThresholdTypeMemory     = "memory"
+	ThresholdTypeGPU        = "gpu"
+)
+
 // Condition type constants for InferenceStack.
 const (
 	// ConditionReady indicates the overall readiness of the resource.
@@ -41,12 +59,38 @@ const (
 	PhaseTerminating = "Terminating"
 )
 
+// Child status reasons, set on ChildStatus.Reason.
+const (
+	ChildReasonCreated              = "Created"
+	ChildReasonUpdated              = "Updated"
+	ChildReasonInSync               = "InSync"
+	ChildReasonCreateFailed         = "CreateFailed"
+	ChildReasonUpdateFailed         = "UpdateFailed"
+	ChildReasonGetFailed            = "GetFailed"
+	ChildReasonWaitingForPods       = "WaitingForPods"
+	ChildReasonWaitingForController = "WaitingForController"
+	ChildReasonDriftIgnored         = "DriftIgnored"
+	ChildReasonNotConfigured        = "NotConfigured"
+	ChildReasonDisabled             = "Disabled"
+	ChildReasonCRDNotInstalled      = "CRDNotInstalled"
+)
+
 // Finalizer constants.
 const (
 	InferenceStackFinalizer        = "ngf-console.f5.com/inferencestack-finalizer"
 	DistributedCloudPublishFinalizer = "ngf-console.f5.com/xc-publish-finalizer"
 )
 
+// ReconcileRequestedAnnotation is set by the API to the RFC 3339 time a
+// reconcile was requested. A DistributedCloudPublish re-applies its XC
+// resources when the request is newer than its last sync.
+const ReconcileRequestedAnnotation = "ngf-console.f5.com/reconcile-requested"
+
+// ServingDefaultsLabel marks the ConfigMap in which the operator publishes its
+// default model server image per serving backend. The API reads it to report
+// the images the operator deploys.
+const ServingDefaultsLabel = "ngf-console.f5.com/serving-defaults"
+
 // SetCondition updates or appends a condition on the given slice.
 func SetCondition(conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus, reason, message string) {
 	now := metav1.Now()
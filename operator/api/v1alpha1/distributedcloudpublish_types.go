@@ -2,10 +2,20 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+// Route kinds a DistributedCloudPublish can publish.
+const (
+	RouteKindHTTPRoute = "HTTPRoute"
+	RouteKindGRPCRoute = "GRPCRoute"
+)
+
 // DistributedCloudPublishSpec defines the desired state of DistributedCloudPublish.
 type DistributedCloudPublishSpec struct {
-	// HTTPRouteRef is the name of the HTTPRoute to publish.
+	// HTTPRouteRef is the name of the route to publish.
 	HTTPRouteRef string `json:"httpRouteRef"`
+	// RouteKind is the kind of route HTTPRouteRef names: HTTPRoute (the
+	// default) or GRPCRoute. A GRPCRoute is published with an HTTP/2 origin
+	// pool and a gRPC health check.
+	RouteKind string `json:"routeKind,omitempty"`
 	// InferencePoolRef is the optional name of an InferencePool to publish.
 	InferencePoolRef string `json:"inferencePoolRef,omitempty"`
 	// DistributedCloud holds the F5 Distributed Cloud configuration.
@@ -24,6 +34,20 @@ type DistributedCloudConfig struct {
 	OriginPool     OriginPool     `json:"originPool,omitempty"`
 	RateLimiting   RateLimiting   `json:"rateLimiting,omitempty"`
 	MultiRegion    MultiRegion    `json:"multiRegion,omitempty"`
+
+	// Domains are additional domains the XC load balancer serves, after the
+	// public hostname and the route hostnames.
+	Domains []string `json:"domains,omitempty"`
+
+	// OriginAddress overrides the Gateway address XC forwards traffic to,
+	// e.g. when the Gateway's status address is not reachable from XC.
+	OriginAddress string `json:"originAddress,omitempty"`
+	// WebSocketEnabled enables WebSocket upgrades on the XC routes.
+	WebSocketEnabled bool `json:"webSocketEnabled,omitempty"`
+	// CredentialsSecretRef names a Secret in the publish's namespace whose
+	// "tenant" and "apiToken" keys are the XC credentials to publish with.
+	// Without it the operator uses XC_TENANT and XC_API_TOKEN.
+	CredentialsSecretRef string `json:"credentialsSecretRef,omitempty"`
 }
 
 // BotDefense configures bot defense settings.
@@ -77,6 +101,8 @@ type DistributedCloudPublishStatus struct {
 	WAFPolicyAttached string `json:"wafPolicyAttached,omitempty"`
 	// LastSyncedAt is the last time the XC resources were verified.
 	LastSyncedAt *metav1.Time `json:"lastSyncedAt,omitempty"`
+	// ObservedGeneration is the spec generation last applied to XC.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -12,6 +12,11 @@ type GatewayBundleSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// Annotations to apply to the Gateway child.
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// ExtraLabels are added to every child resource. Labels and the
+	// operator-managed labels take precedence.
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+	// ExtraAnnotations are added to every child resource. Annotations take precedence.
+	ExtraAnnotations map[string]string `json:"extraAnnotations,omitempty"`
 
 	// NginxProxy configures the NginxProxy child (Enterprise only).
 	NginxProxy *NginxProxySpec `json:"nginxProxy,omitempty"`
@@ -59,6 +64,17 @@ type CertRefSpec struct {
 type AllowedRoutesSpec struct {
 	// Namespaces controls which namespaces' routes can attach.
 	Namespaces *RouteNamespacesSpec `json:"namespaces,omitempty"`
+	// Kinds restricts which route kinds can attach. Passthrough listeners
+	// default to TLSRoute when unset.
+	Kinds []RouteGroupKindSpec `json:"kinds,omitempty"`
+}
+
+// RouteGroupKindSpec identifies a route kind that may attach to a listener.
+type RouteGroupKindSpec struct {
+	// Group is the API group of the route; defaults to gateway.networking.k8s.io.
+	Group string `json:"group,omitempty"`
+	// Kind is the route kind, e.g. HTTPRoute or TLSRoute.
+	Kind string `json:"kind"`
 }
 
 // RouteNamespacesSpec defines namespace selection for routes.
@@ -178,3 +194,12 @@ type GatewayBundleList struct {
 
 // Finalizer constant for GatewayBundle.
 const GatewayBundleFinalizer = "ngf-console.f5.com/gatewaybundle-finalizer"
+
+// DriftCorrectionAnnotation, when set to "disabled" on a GatewayBundle, stops
+// the operator from reverting manual edits to existing children. Missing
+// children are still created.
+const DriftCorrectionAnnotation = "ngf-console.f5.com/drift-correction"
+
+// ConditionDriftCorrectionDisabled is set on a GatewayBundle while
+// DriftCorrectionAnnotation disables drift correction.
+const ConditionDriftCorrectionDisabled = "DriftCorrectionDisabled"
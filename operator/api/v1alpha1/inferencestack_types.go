@@ -1,6 +1,9 @@
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // InferenceStackSpec defines the desired state of an InferenceStack.
 type InferenceStackSpec struct {
@@ -8,29 +11,62 @@ type InferenceStackSpec struct {
 	ModelName string `json:"modelName"`
 	// ModelVersion is an optional version tag for the model.
 	ModelVersion string `json:"modelVersion,omitempty"`
-	// ServingBackend is the inference server type: "vllm", "triton", or "tgi".
+	// ServingBackend is the inference server type: "vllm", "triton", "tgi", or "ollama".
 	ServingBackend string `json:"servingBackend"`
 
 	// Pool configures the InferencePool child resource.
 	Pool InferencePoolSpec `json:"pool"`
+	// Serving configures the model server Deployment.
+	Serving *ServingSpec `json:"serving,omitempty"`
 	// EPP configures the Endpoint Picker Plugin.
 	EPP EPPSpec `json:"epp,omitempty"`
-	// Autoscaling configures KEDA-based autoscaling (Phase 2).
+	// Autoscaling configures autoscaling of the model server Deployment.
 	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
 	// HTTPRoute configures the HTTPRoute child resource (Phase 2).
 	HTTPRoute *HTTPRouteSpec `json:"httpRoute,omitempty"`
 	// DCGM configures the DCGM GPU metrics exporter (Phase 2).
 	DCGM *DCGMSpec `json:"dcgm,omitempty"`
+	// ServiceMonitor configures a Prometheus Operator ServiceMonitor for the
+	// stack's metrics.
+	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
 	// DistributedCloud configures XC publishing (Phase 3).
 	DistributedCloud *DistributedCloudConfig `json:"distributedCloud,omitempty"`
+
+	// ManageHTTPRoute controls whether the operator creates the HTTPRoute.
+	// Set to false when the route is managed elsewhere (e.g. GitOps). Defaults to true.
+	ManageHTTPRoute *bool `json:"manageHTTPRoute,omitempty"`
+	// ManageAutoscaler controls whether the operator creates the autoscaler. Defaults to true.
+	ManageAutoscaler *bool `json:"manageAutoscaler,omitempty"`
+	// ManageDCGM controls whether the operator deploys the DCGM exporter. Defaults to true.
+	ManageDCGM *bool `json:"manageDCGM,omitempty"`
+	// ManageEPP controls whether the operator deploys the EPP Deployment and
+	// Service. Set to false when the endpoint picker runs elsewhere. Defaults to true.
+	ManageEPP *bool `json:"manageEPP,omitempty"`
+	// ManageServing controls whether the operator deploys the model server
+	// pods. Set to false when they are deployed separately. Defaults to true.
+	ManageServing *bool `json:"manageServing,omitempty"`
+
+	// ExtraLabels are added to every child resource (e.g. for cost allocation
+	// or team ownership). Operator-managed labels take precedence.
+	ExtraLabels map[string]string `json:"extraLabels,omitempty"`
+	// ExtraAnnotations are added to every child resource.
+	ExtraAnnotations map[string]string `json:"extraAnnotations,omitempty"`
+	// ImagePullSecrets are added to every pod template the operator creates
+	// (model server, EPP, and DCGM exporter), for images in authenticated
+	// registries.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 // InferencePoolSpec defines the pool parameters.
 type InferencePoolSpec struct {
 	// GPUType is the GPU accelerator type (e.g., "H100", "A100", "L40S", "T4").
 	GPUType string `json:"gpuType"`
-	// GPUCount is the number of GPUs per replica.
+	// GPUCount is the number of GPUs per replica, or the number of MIG slices
+	// per replica when MIGProfile is set.
 	GPUCount int32 `json:"gpuCount"`
+	// MIGProfile requests Multi-Instance GPU slices of the given profile
+	// (e.g., "mig-1g.10gb") instead of whole GPUs.
+	MIGProfile string `json:"migProfile,omitempty"`
 	// Replicas is the desired number of replicas.
 	Replicas int32 `json:"replicas"`
 	// MinReplicas is the minimum replica count for autoscaling.
@@ -41,14 +77,50 @@ type InferencePoolSpec struct {
 	Selector map[string]string `json:"selector,omitempty"`
 }
 
+// ServingSpec configures the model server pods. The image and arguments
+// default to the ServingBackend's upstream server.
+type ServingSpec struct {
+	// Image overrides the backend's default model server image.
+	Image string `json:"image,omitempty"`
+	// ExtraArgs are appended to the backend's default arguments
+	// (e.g. "--max-model-len=8192" for vLLM).
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Env is added to the model server container, e.g. HF_TOKEN from a Secret.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources are the container's CPU and memory. The GPU limit is always
+	// set from the pool's gpuCount and migProfile.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // EPPSpec configures the Endpoint Picker Plugin (EPP).
 type EPPSpec struct {
 	// Strategy is the routing strategy: "least_queue", "kv_cache", "prefix_affinity", "composite".
+	// Defaults to DefaultEPPStrategy.
 	Strategy string `json:"strategy,omitempty"`
 	// Weights configures per-strategy weights when using composite strategy.
 	Weights *EPPWeights `json:"weights,omitempty"`
+	// Image is the EPP container image. Defaults to a pinned upstream release.
+	Image string `json:"image,omitempty"`
+	// Replicas is the number of EPP replicas. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources are the EPP container's compute resources.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// ServiceAccountName is the service account the EPP pods run as. It needs
+	// read access to pods and InferencePools in the stack's namespace.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
+// EPP routing strategy constants for EPPSpec. They match the CRD enum.
+const (
+	EPPStrategyLeastQueue     = "least_queue"
+	EPPStrategyKVCache        = "kv_cache"
+	EPPStrategyPrefixAffinity = "prefix_affinity"
+	EPPStrategyComposite      = "composite"
+
+	// DefaultEPPStrategy is used when EPPSpec.Strategy is empty.
+	DefaultEPPStrategy = EPPStrategyLeastQueue
+)
+
 // EPPWeights defines the strategy weights for composite routing.
 type EPPWeights struct {
 	QueueDepth     int32 `json:"queueDepth,omitempty"`
@@ -56,16 +128,26 @@ type EPPWeights struct {
 	PrefixAffinity int32 `json:"prefixAffinity,omitempty"`
 }
 
-// AutoscalingSpec configures KEDA-based autoscaling.
+// AutoscalingSpec configures KEDA- or HPA-based autoscaling.
 type AutoscalingSpec struct {
-	// Backend is the autoscaler backend: "keda" or "hpa".
+	// Backend is the autoscaler backend: "keda" (the default) reconciles a
+	// KEDA ScaledObject, "hpa" a native HorizontalPodAutoscaler.
 	Backend string `json:"backend,omitempty"`
 	// Thresholds are the scaling thresholds.
 	Thresholds []ThresholdSpec `json:"thresholds,omitempty"`
 	// CooldownSeconds is the cooldown period after a scaling event.
 	CooldownSeconds int32 `json:"cooldownSeconds,omitempty"`
+	// PrometheusAddress is the Prometheus server that prometheus and gpu
+	// thresholds query. Defaults to http://prometheus.monitoring:9090.
+	PrometheusAddress string `json:"prometheusAddress,omitempty"`
 }
 
+// Autoscaler backend constants for AutoscalingSpec.
+const (
+	AutoscalerBackendKEDA = "keda"
+	AutoscalerBackendHPA  = "hpa"
+)
+
 // HTTPRouteSpec configures the HTTPRoute child resource.
 type HTTPRouteSpec struct {
 	// Hostnames are the hostnames to match.
@@ -82,6 +164,27 @@ type DCGMSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// Image is the DCGM exporter container image.
 	Image string `json:"image,omitempty"`
+	// NodeSelector restricts the exporter pods to matching nodes. Defaults to
+	// nodes labeled nvidia.com/gpu.present=true; set it to {} to run on
+	// every node.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations let the exporter pods run on tainted nodes. Defaults to
+	// tolerating the nvidia.com/gpu taint; set it to [] to tolerate nothing.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ServiceMonitorSpec configures the ServiceMonitor child and the headless
+// metrics Service it selects.
+type ServiceMonitorSpec struct {
+	// Enabled controls whether the ServiceMonitor is created. It is skipped
+	// when the monitoring.coreos.com CRDs are not installed.
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval is the scrape interval (e.g. "30s"). Defaults to Prometheus's
+	// global scrape interval.
+	Interval string `json:"interval,omitempty"`
+	// Labels are added to the ServiceMonitor so it matches the Prometheus
+	// serviceMonitorSelector (e.g. release: kube-prometheus-stack).
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // InferenceStackStatus defines the observed state of an InferenceStack.
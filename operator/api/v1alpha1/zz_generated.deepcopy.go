@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -72,13 +73,12 @@ func (in *InferenceStackList) DeepCopyObject() runtime.Object {
 func (in *InferenceStackSpec) DeepCopyInto(out *InferenceStackSpec) {
 	*out = *in
 	in.Pool.DeepCopyInto(&out.Pool)
-	if in.EPP.Weights != nil {
-		in, out := &in.EPP.Weights, &out.EPP.Weights
-		*out = new(EPPWeights)
-		**out = **in
-	} else {
-		out.EPP = in.EPP
+	if in.Serving != nil {
+		in, out := &in.Serving, &out.Serving
+		*out = new(ServingSpec)
+		(*in).DeepCopyInto(*out)
 	}
+	in.EPP.DeepCopyInto(&out.EPP)
 	if in.Autoscaling != nil {
 		in, out := &in.Autoscaling, &out.Autoscaling
 		*out = new(AutoscalingSpec)
@@ -92,13 +92,62 @@ func (in *InferenceStackSpec) DeepCopyInto(out *InferenceStackSpec) {
 	if in.DCGM != nil {
 		in, out := &in.DCGM, &out.DCGM
 		*out = new(DCGMSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitorSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.DistributedCloud != nil {
 		in, out := &in.DistributedCloud, &out.DistributedCloud
 		*out = new(DistributedCloudConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ManageHTTPRoute != nil {
+		in, out := &in.ManageHTTPRoute, &out.ManageHTTPRoute
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageAutoscaler != nil {
+		in, out := &in.ManageAutoscaler, &out.ManageAutoscaler
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageDCGM != nil {
+		in, out := &in.ManageDCGM, &out.ManageDCGM
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageEPP != nil {
+		in, out := &in.ManageEPP, &out.ManageEPP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageServing != nil {
+		in, out := &in.ManageServing, &out.ManageServing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraAnnotations != nil {
+		in, out := &in.ExtraAnnotations, &out.ExtraAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function.
@@ -133,6 +182,38 @@ func (in *InferencePoolSpec) DeepCopy() *InferencePoolSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function.
+func (in *ServingSpec) DeepCopyInto(out *ServingSpec) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function.
+func (in *ServingSpec) DeepCopy() *ServingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function.
 func (in *EPPSpec) DeepCopyInto(out *EPPSpec) {
 	*out = *in
@@ -141,6 +222,16 @@ func (in *EPPSpec) DeepCopyInto(out *EPPSpec) {
 		*out = new(EPPWeights)
 		**out = **in
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function.
@@ -193,6 +284,57 @@ func (in *HTTPRouteSpec) DeepCopy() *HTTPRouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function.
+func (in *DCGMSpec) DeepCopyInto(out *DCGMSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function.
+func (in *DCGMSpec) DeepCopy() *DCGMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DCGMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function.
+func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function.
+func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function.
 func (in *InferenceStackStatus) DeepCopyInto(out *InferenceStackStatus) {
 	*out = *in
@@ -309,6 +451,20 @@ func (in *GatewayBundleSpec) DeepCopyInto(out *GatewayBundleSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExtraLabels != nil {
+		in, out := &in.ExtraLabels, &out.ExtraLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraAnnotations != nil {
+		in, out := &in.ExtraAnnotations, &out.ExtraAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.NginxProxy != nil {
 		in, out := &in.NginxProxy, &out.NginxProxy
 		*out = new(NginxProxySpec)
@@ -425,6 +581,11 @@ func (in *AllowedRoutesSpec) DeepCopyInto(out *AllowedRoutesSpec) {
 		*out = new(RouteNamespacesSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]RouteGroupKindSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function.
@@ -437,6 +598,21 @@ func (in *AllowedRoutesSpec) DeepCopy() *AllowedRoutesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function.
+func (in *RouteGroupKindSpec) DeepCopyInto(out *RouteGroupKindSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function.
+func (in *RouteGroupKindSpec) DeepCopy() *RouteGroupKindSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteGroupKindSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function.
 func (in *RouteNamespacesSpec) DeepCopyInto(out *RouteNamespacesSpec) {
 	*out = *in
@@ -631,6 +807,11 @@ func (in *DistributedCloudConfig) DeepCopyInto(out *DistributedCloudConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function.
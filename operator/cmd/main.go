@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -32,11 +36,15 @@ func main() {
 		metricsAddr          string
 		healthProbeAddr      string
 		enableLeaderElection bool
+		inferencePoolGV      string
+		servingImages        string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8081", "The address the metric endpoint binds to.")
 	flag.StringVar(&healthProbeAddr, "health-probe-bind-address", ":8082", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	flag.StringVar(&inferencePoolGV, "inference-pool-group-version", "", "Pin the InferencePool group/version (e.g. inference.networking.x-k8s.io/v1alpha2); auto-detected if empty.")
+	flag.StringVar(&servingImages, "serving-images", "", "Comma-separated default model server images as backend=image (backends: vllm, tgi, triton, ollama); unset backends use pinned upstream images.")
 	flag.Parse()
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -47,6 +55,17 @@ func main() {
 
 	slog.Info("starting ngf-console operator")
 
+	if inferencePoolGV != "" {
+		if err := controller.SetInferencePoolGroupVersion(inferencePoolGV); err != nil {
+			slog.Error("invalid inference pool group/version", "error", err)
+			os.Exit(1)
+		}
+	}
+	if err := controller.SetServingImages(servingImages); err != nil {
+		slog.Error("invalid serving images", "error", err)
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
@@ -63,16 +82,18 @@ func main() {
 
 	// Register controllers
 	if err := (&controller.InferenceStackReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("inferencestack-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		slog.Error("unable to create InferenceStackReconciler", "error", err)
 		os.Exit(1)
 	}
 
 	if err := (&controller.GatewayBundleReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("gatewaybundle-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		slog.Error("unable to create GatewayBundleReconciler", "error", err)
 		os.Exit(1)
@@ -94,6 +115,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Publish the default serving images for the API to report. Retried until
+	// it succeeds, so a missing permission or a slow API server only delays it.
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			_ = wait.PollUntilContextCancel(ctx, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+				if err := controller.PublishServingDefaults(ctx, mgr.GetClient(), ns); err != nil {
+					slog.Warn("failed to publish serving defaults, will retry", "error", err)
+					return false, nil
+				}
+				return true, nil
+			})
+			return nil
+		})); err != nil {
+			slog.Error("unable to add serving defaults publisher", "error", err)
+			os.Exit(1)
+		}
+	} else {
+		slog.Warn("POD_NAMESPACE not set, not publishing serving defaults")
+	}
+
 	// Health probes
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		slog.Error("unable to set up health check", "error", err)
@@ -1,11 +1,17 @@
 package controller
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
@@ -32,6 +38,120 @@ func specDrifted(a, b any) bool {
 	return ha != hb
 }
 
+// fieldsDrifted reports whether existing differs from desired in any field
+// desired sets. Fields only existing has, such as API server defaults or
+// fields owned by other managers, are ignored, as are empty desired values
+// missing from existing. Both sides are compared after a JSON round trip, so
+// typed and unstructured numbers compare equal. On marshal error, returns
+// true (assume drift) so the caller reconciles.
+func fieldsDrifted(desired, existing any) bool {
+	want, err1 := jsonValue(desired)
+	have, err2 := jsonValue(existing)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	return !jsonSubset(want, have)
+}
+
+// jsonValue round-trips v through JSON into maps, slices, and scalars.
+func jsonValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
+
+// jsonSubset reports whether every field set in want has the same value in
+// have. Lists must match element by element.
+func jsonSubset(want, have any) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		h, ok := have.(map[string]any)
+		if !ok {
+			return len(w) == 0 && have == nil
+		}
+		for k, v := range w {
+			hv, ok := h[k]
+			if !ok {
+				if jsonEmpty(v) {
+					continue
+				}
+				return false
+			}
+			if !jsonSubset(v, hv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		h, ok := have.([]any)
+		if !ok {
+			return len(w) == 0 && have == nil
+		}
+		if len(h) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !jsonSubset(w[i], h[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(want, have)
+	}
+}
+
+// jsonEmpty reports whether v is a JSON null, zero scalar, or empty
+// collection, which the API server omits when the field is optional.
+func jsonEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	}
+	return false
+}
+
+// fieldOwner is the server-side apply field manager of the children the
+// operator applies.
+const fieldOwner = "ngf-console-operator"
+
+// applyChild server-side applies obj as fieldOwner, forcing ownership of the
+// fields it sets. Fields obj leaves out, such as API server defaults or
+// fields other managers set, are preserved. Typed objects are applied
+// without their status and creation timestamp.
+func applyChild(ctx context.Context, c client.Client, obj client.Object) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		gvk, err := c.GroupVersionKindFor(obj)
+		if err != nil {
+			return err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		u = &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+		delete(u.Object, "status")
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	}
+	return c.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), client.FieldOwner(fieldOwner), client.ForceOwnership)
+}
+
 // setOwnerReference sets the owner reference on the child object.
 func setOwnerReference(owner *v1alpha1.InferenceStack, child client.Object) {
 	isController := true
@@ -74,3 +194,107 @@ func computePhase(children []v1alpha1.ChildStatus) string {
 	}
 	return v1alpha1.PhaseDegraded
 }
+
+// metadataDrifted reports whether existing is missing any of the desired
+// labels or annotations. Keys set by other controllers are ignored.
+func metadataDrifted(existing, desired metav1.Object) bool {
+	return !containsAll(existing.GetLabels(), desired.GetLabels()) ||
+		!containsAll(existing.GetAnnotations(), desired.GetAnnotations())
+}
+
+// mergeMetadata copies the desired labels and annotations onto existing,
+// preserving keys set by other controllers.
+func mergeMetadata(existing, desired metav1.Object) {
+	existing.SetLabels(mergeLabels(existing.GetLabels(), desired.GetLabels()))
+	existing.SetAnnotations(mergeLabels(existing.GetAnnotations(), desired.GetAnnotations()))
+}
+
+// containsAll reports whether every key/value in want is present in have.
+func containsAll(have, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := have[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Event reasons recorded on the parent for child reconcile outcomes and
+// phase changes.
+const (
+	eventReasonChildCreated   = "ChildCreated"
+	eventReasonChildUpdated   = "ChildUpdated"
+	eventReasonChildFailed    = "ChildFailed"
+	eventReasonChildRecovered = "ChildRecovered"
+	eventReasonPhaseChanged   = "PhaseChanged"
+)
+
+// Event annotations identifying the child an event is about, so consumers
+// do not have to parse the message.
+const (
+	eventAnnotationChildKind = "ngf-console.f5.com/child-kind"
+	eventAnnotationChildName = "ngf-console.f5.com/child-name"
+)
+
+// recordChildEvents records an event on owner for every child that was
+// created or updated, that started failing or failed differently, or that
+// recovered from a failure. previous is the owner's child statuses from the
+// last reconcile. In-sync children and repeated failures are not recorded,
+// since every resync would add an event. recorder may be nil.
+func recordChildEvents(recorder record.EventRecorder, owner runtime.Object, previous, children []v1alpha1.ChildStatus) {
+	if recorder == nil {
+		return
+	}
+	before := make(map[string]v1alpha1.ChildStatus, len(previous))
+	for _, c := range previous {
+		before[c.Kind+"/"+c.Name] = c
+	}
+	for _, c := range children {
+		annotations := map[string]string{
+			eventAnnotationChildKind: c.Kind,
+			eventAnnotationChildName: c.Name,
+		}
+		last, seen := before[c.Kind+"/"+c.Name]
+		wasFailed := seen && childFailed(last)
+		switch {
+		case c.Reason == v1alpha1.ChildReasonCreated:
+			recorder.AnnotatedEventf(owner, annotations, corev1.EventTypeNormal, eventReasonChildCreated, "Created %s %s", c.Kind, c.Name)
+		case c.Reason == v1alpha1.ChildReasonUpdated:
+			recorder.AnnotatedEventf(owner, annotations, corev1.EventTypeNormal, eventReasonChildUpdated, "Updated %s %s", c.Kind, c.Name)
+		case childFailed(c):
+			if wasFailed && last.Reason == c.Reason && last.Message == c.Message {
+				continue
+			}
+			recorder.AnnotatedEventf(owner, annotations, corev1.EventTypeWarning, eventReasonChildFailed, "%s %s: %s", c.Kind, c.Name, c.Message)
+		case wasFailed:
+			recorder.AnnotatedEventf(owner, annotations, corev1.EventTypeNormal, eventReasonChildRecovered, "%s %s recovered: %s", c.Kind, c.Name, c.Message)
+		}
+	}
+}
+
+// childFailed reports whether c failed to reconcile.
+func childFailed(c v1alpha1.ChildStatus) bool {
+	switch c.Reason {
+	case v1alpha1.ChildReasonCreateFailed, v1alpha1.ChildReasonUpdateFailed, v1alpha1.ChildReasonGetFailed:
+		return true
+	}
+	return false
+}
+
+// recordPhaseEvent records an event on owner when its phase changes from
+// previous to phase. Entering the Error phase is a warning. recorder may be
+// nil.
+func recordPhaseEvent(recorder record.EventRecorder, owner runtime.Object, previous, phase string) {
+	if recorder == nil || previous == phase {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if phase == v1alpha1.PhaseError {
+		eventType = corev1.EventTypeWarning
+	}
+	if previous == "" {
+		recorder.Eventf(owner, eventType, eventReasonPhaseChanged, "Phase is %s", phase)
+		return
+	}
+	recorder.Eventf(owner, eventType, eventReasonPhaseChanged, "Phase changed from %s to %s", previous, phase)
+}
@@ -1,8 +1,26 @@
 package controller
 
 import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
 	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
 )
 
@@ -62,6 +80,63 @@ func TestHashSpec_ChangesOnDiff(t *testing.T) {
 	}
 }
 
+func TestFieldsDrifted(t *testing.T) {
+	desired := map[string]interface{}{
+		"minReplicaCount": int64(1),
+		"scaleTargetRef":  map[string]interface{}{"name": "llama-pool"},
+		"triggers":        []interface{}{map[string]interface{}{"type": "cpu"}},
+		"advanced":        map[string]interface{}{},
+	}
+	tests := []struct {
+		name     string
+		existing map[string]interface{}
+		want     bool
+	}{
+		{
+			name: "defaulted and foreign fields are ignored",
+			existing: map[string]interface{}{
+				"minReplicaCount": float64(1),
+				"scaleTargetRef":  map[string]interface{}{"name": "llama-pool", "kind": "Deployment"},
+				"triggers":        []interface{}{map[string]interface{}{"type": "cpu", "metricType": "Utilization"}},
+				"fallback":        map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+		{
+			name: "changed value",
+			existing: map[string]interface{}{
+				"minReplicaCount": int64(2),
+				"scaleTargetRef":  map[string]interface{}{"name": "llama-pool"},
+				"triggers":        []interface{}{map[string]interface{}{"type": "cpu"}},
+			},
+			want: true,
+		},
+		{
+			name: "missing field",
+			existing: map[string]interface{}{
+				"minReplicaCount": int64(1),
+				"triggers":        []interface{}{map[string]interface{}{"type": "cpu"}},
+			},
+			want: true,
+		},
+		{
+			name: "extra list element",
+			existing: map[string]interface{}{
+				"minReplicaCount": int64(1),
+				"scaleTargetRef":  map[string]interface{}{"name": "llama-pool"},
+				"triggers":        []interface{}{map[string]interface{}{"type": "cpu"}, map[string]interface{}{"type": "memory"}},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldsDrifted(desired, tt.existing); got != tt.want {
+				t.Errorf("fieldsDrifted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestComputePhase_AllReady(t *testing.T) {
 	children := []v1alpha1.ChildStatus{
 		{Kind: "InferencePool", Name: "test-pool", Ready: true},
@@ -120,3 +195,1143 @@ func TestComputePhase_NotConfiguredIsReady(t *testing.T) {
 		t.Errorf("expected Ready (stubs marked ready), got %s", phase)
 	}
 }
+
+func TestChildManaged(t *testing.T) {
+	if !childManaged(nil) {
+		t.Error("unset flag should default to managed")
+	}
+	if !childManaged(boolPtr(true)) {
+		t.Error("true flag should be managed")
+	}
+	if childManaged(boolPtr(false)) {
+		t.Error("false flag should not be managed")
+	}
+}
+
+func TestReconcileChildren_Disabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add apps scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:        "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend:   "vllm",
+			Autoscaling:      &v1alpha1.AutoscalingSpec{Backend: "keda"},
+			HTTPRoute:        &v1alpha1.HTTPRouteSpec{GatewayRef: "gw"},
+			DCGM:             &v1alpha1.DCGMSpec{Enabled: true},
+			ManageHTTPRoute:  boolPtr(false),
+			ManageAutoscaler: boolPtr(false),
+			ManageDCGM:       boolPtr(false),
+			ManageEPP:        boolPtr(false),
+			ManageServing:    boolPtr(false),
+		},
+	}
+
+	ctx := context.Background()
+	for _, status := range []v1alpha1.ChildStatus{
+		r.reconcileServingDeployment(ctx, stack),
+		r.reconcileAutoscaler(ctx, stack),
+		r.reconcileHTTPRoute(ctx, stack),
+		r.reconcileDCGMExporter(ctx, stack),
+		r.reconcileEPPDeployment(ctx, stack),
+		r.reconcileEPPService(ctx, stack),
+	} {
+		if status.Message != childDisabledMessage || !status.Ready {
+			t.Errorf("%s: expected ready disabled status, got %+v", status.Kind, status)
+		}
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := c.List(ctx, &daemonSets, client.InNamespace("default")); err != nil {
+		t.Fatalf("list daemonsets: %v", err)
+	}
+	if len(daemonSets.Items) != 0 {
+		t.Errorf("expected no DCGM DaemonSet when disabled, got %d", len(daemonSets.Items))
+	}
+}
+
+func TestStackChildLabels_ManagedLabelsWin(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ExtraLabels: map[string]string{
+				"team":                         "ml-platform",
+				"app.kubernetes.io/managed-by": "helm",
+				"app":                          "spoofed",
+			},
+		},
+	}
+
+	labels := stackChildLabels(stack, map[string]string{"app": "llama-dcgm"})
+	want := map[string]string{
+		"team":                         "ml-platform",
+		"app.kubernetes.io/managed-by": "ngf-console",
+		"ngf-console.f5.com/stack":     "llama",
+		"app":                          "llama-dcgm",
+	}
+	for k, v := range want {
+		if labels[k] != v {
+			t.Errorf("label %s = %q, want %q", k, labels[k], v)
+		}
+	}
+}
+
+func TestReconcileEPPConfig_PropagatesExtraMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:        "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend:   "vllm",
+			ExtraLabels:      map[string]string{"cost-center": "1234"},
+			ExtraAnnotations: map[string]string{"owner": "ml-platform"},
+		},
+	}
+
+	ctx := context.Background()
+	if status := r.reconcileEPPConfig(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected created, got %+v", status)
+	}
+
+	// Changing the extra labels updates the existing child and keeps labels
+	// added by other controllers.
+	var cm corev1.ConfigMap
+	key := types.NamespacedName{Name: "llama-epp-config", Namespace: "default"}
+	if err := c.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("get configmap: %v", err)
+	}
+	cm.Labels["external"] = "kept"
+	if err := c.Update(ctx, &cm); err != nil {
+		t.Fatalf("update configmap: %v", err)
+	}
+	stack.Spec.ExtraLabels["cost-center"] = "5678"
+	if status := r.reconcileEPPConfig(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+
+	if err := c.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("get configmap: %v", err)
+	}
+	if cm.Labels["cost-center"] != "5678" || cm.Labels["external"] != "kept" ||
+		cm.Labels["app.kubernetes.io/managed-by"] != "ngf-console" {
+		t.Errorf("unexpected labels: %v", cm.Labels)
+	}
+	if cm.Annotations["owner"] != "ml-platform" {
+		t.Errorf("unexpected annotations: %v", cm.Annotations)
+	}
+
+	if status := r.reconcileEPPConfig(ctx, stack); status.Message != "in sync" {
+		t.Errorf("expected in sync, got %+v", status)
+	}
+}
+
+func TestBuildDesiredEPPConfigMap_Strategy(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			EPP: v1alpha1.EPPSpec{
+				Strategy: v1alpha1.EPPStrategyComposite,
+				Weights:  &v1alpha1.EPPWeights{QueueDepth: 4, KVCache: 3},
+			},
+		},
+	}
+
+	config := buildDesiredEPPConfigMap(stack, "llama-epp-config").Data[eppConfigFile]
+	for _, want := range []string{
+		`"kind": "EndpointPickerConfig"`,
+		`"pluginRef": "queue-scorer",
+          "weight": 4`,
+		`"pluginRef": "kv-cache-utilization-scorer",
+          "weight": 3`,
+		`"pluginRef": "prefix-cache-scorer",
+          "weight": 1`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config missing %s:\n%s", want, config)
+		}
+	}
+
+	stack.Spec.EPP = v1alpha1.EPPSpec{}
+	config = buildDesiredEPPConfigMap(stack, "llama-epp-config").Data[eppConfigFile]
+	if !strings.Contains(config, `"queue-scorer"`) || strings.Contains(config, "prefix-cache-scorer") {
+		t.Errorf("expected only the default least_queue scorer:\n%s", config)
+	}
+}
+
+func TestReconcileEPPDeployment_ImageAndResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add apps scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend: "vllm",
+		},
+	}
+
+	ctx := context.Background()
+	for _, status := range []v1alpha1.ChildStatus{
+		r.reconcileEPPDeployment(ctx, stack),
+		r.reconcileEPPService(ctx, stack),
+	} {
+		if status.Message != "created" {
+			t.Fatalf("%s: expected created, got %+v", status.Kind, status)
+		}
+	}
+
+	key := types.NamespacedName{Name: "llama-epp", Namespace: "default"}
+	var dep appsv1.Deployment
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if got := dep.Spec.Template.Spec.Containers[0].Image; got != defaultEPPImage {
+		t.Errorf("image = %q, want default %q", got, defaultEPPImage)
+	}
+	if dep.Spec.Replicas == nil || *dep.Spec.Replicas != 1 {
+		t.Errorf("replicas = %v, want 1", dep.Spec.Replicas)
+	}
+	if args := dep.Spec.Template.Spec.Containers[0].Args; !slices.Contains(args, "--config-file=/etc/epp/epp-config.json") {
+		t.Errorf("args = %v, want the mounted EPP config file", args)
+	}
+	var svc corev1.Service
+	if err := c.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("get service: %v", err)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != eppGRPCPort || svc.Spec.Selector["app"] != "llama-epp" {
+		t.Errorf("unexpected service spec: %+v", svc.Spec)
+	}
+
+	// Overriding the image, replicas, and resources updates the Deployment.
+	replicas := int32(2)
+	stack.Spec.EPP.Image = "registry.example.com/epp:custom"
+	stack.Spec.EPP.Replicas = &replicas
+	stack.Spec.EPP.Resources = &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	if status := r.reconcileEPPDeployment(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	container := dep.Spec.Template.Spec.Containers[0]
+	if container.Image != "registry.example.com/epp:custom" || *dep.Spec.Replicas != 2 {
+		t.Errorf("image = %q, replicas = %d", container.Image, *dep.Spec.Replicas)
+	}
+	if mem := container.Resources.Requests[corev1.ResourceMemory]; mem.String() != "1Gi" || len(container.Resources.Limits) != 0 {
+		t.Errorf("unexpected resources: %+v", container.Resources)
+	}
+
+	// No pods are available in the fake client, so the in-sync Deployment is not ready.
+	status := r.reconcileEPPDeployment(ctx, stack)
+	if status.Ready || status.Reason != v1alpha1.ChildReasonWaitingForPods || status.Message != "waiting for pods (0/2 available)" {
+		t.Errorf("expected waiting status, got %+v", status)
+	}
+}
+
+func TestBuildDesiredServingDeployment_Backends(t *testing.T) {
+	tests := []struct {
+		backend   string
+		image     string
+		args      []string
+		port      int32
+		probe     string
+		probePort int32
+	}{
+		{"vllm", defaultVLLMImage, []string{"--model=meta-llama/Llama-3-70B-Instruct", "--port=8000", "--tensor-parallel-size=4", "--revision=v2"}, 8000, "/health", 8000},
+		{"tgi", defaultTGIImage, []string{"--model-id=meta-llama/Llama-3-70B-Instruct", "--port=80", "--num-shard=4", "--revision=v2"}, 80, "/health", 80},
+		{"triton", defaultTritonImage, []string{"--model-repository=meta-llama/Llama-3-70B-Instruct", "--grpc-port=8001", "--http-port=8000", "--metrics-port=8002"}, 8001, "/v2/health/ready", 8000},
+		{"ollama", defaultOllamaImage, nil, 11434, "/", 11434},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			stack := &v1alpha1.InferenceStack{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+				Spec: v1alpha1.InferenceStackSpec{
+					ModelName:      "meta-llama/Llama-3-70B-Instruct",
+					ModelVersion:   "v2",
+					ServingBackend: tt.backend,
+					Pool:           v1alpha1.InferencePoolSpec{GPUType: "H100", GPUCount: 4, Replicas: 2},
+				},
+			}
+			dep := buildDesiredServingDeployment(stack, "llama-pool")
+			pod := dep.Spec.Template.Spec
+			c := pod.Containers[0]
+			if c.Image != tt.image {
+				t.Errorf("image = %q, want %q", c.Image, tt.image)
+			}
+			if !slices.Equal(c.Args, tt.args) {
+				t.Errorf("args = %q, want %q", c.Args, tt.args)
+			}
+			if c.Ports[0].ContainerPort != tt.port || int64(tt.port) != servingPort(tt.backend) {
+				t.Errorf("serving port = %d, want %d", c.Ports[0].ContainerPort, tt.port)
+			}
+			if get := c.ReadinessProbe.HTTPGet; get.Path != tt.probe || get.Port.IntVal != tt.probePort {
+				t.Errorf("readiness probe = %s:%d, want %s:%d", get.Path, get.Port.IntVal, tt.probe, tt.probePort)
+			}
+			if gpus := c.Resources.Limits[gpuResource]; gpus.Value() != 4 {
+				t.Errorf("%s limit = %s, want 4", gpuResource, gpus.String())
+			}
+			if *dep.Spec.Replicas != 2 || dep.Spec.Selector.MatchLabels["app"] != "llama" || pod.Containers[0].Name != "server" {
+				t.Errorf("unexpected deployment: replicas %d, selector %v", *dep.Spec.Replicas, dep.Spec.Selector.MatchLabels)
+			}
+			products := pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+			if products.Key != gpuProductLabel || !slices.Contains(products.Values, "NVIDIA-H100-80GB-HBM3") {
+				t.Errorf("unexpected node affinity: %+v", products)
+			}
+		})
+	}
+}
+
+func TestBuildDesiredServingDeployment_MIGAndOverrides(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-8B-Instruct",
+			ServingBackend: "vllm",
+			Pool:           v1alpha1.InferencePoolSpec{GPUType: "RTX-6000", GPUCount: 2, MIGProfile: "mig-1g.10gb", Replicas: 1, Selector: map[string]string{"model": "llama3"}},
+			Serving: &v1alpha1.ServingSpec{
+				Image:     "registry.example.com/vllm:custom",
+				ExtraArgs: []string{"--max-model-len=8192"},
+				Env:       []corev1.EnvVar{{Name: "HF_TOKEN", Value: "token"}},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")},
+				},
+			},
+		},
+	}
+	dep := buildDesiredServingDeployment(stack, "llama-pool")
+	c := dep.Spec.Template.Spec.Containers[0]
+	if c.Image != "registry.example.com/vllm:custom" {
+		t.Errorf("image = %q, want override", c.Image)
+	}
+	// MIG slices are not sharded across, so no tensor parallelism.
+	if want := []string{"--model=meta-llama/Llama-3-8B-Instruct", "--port=8000", "--max-model-len=8192"}; !slices.Equal(c.Args, want) {
+		t.Errorf("args = %q, want %q", c.Args, want)
+	}
+	if len(c.Env) != 1 || c.Env[0].Name != "HF_TOKEN" {
+		t.Errorf("env = %+v, want HF_TOKEN", c.Env)
+	}
+	mig := c.Resources.Limits["nvidia.com/mig-1g.10gb"]
+	mem := c.Resources.Limits[corev1.ResourceMemory]
+	if mig.Value() != 2 || mem.String() != "64Gi" {
+		t.Errorf("unexpected limits: %v", c.Resources.Limits)
+	}
+	if _, ok := c.Resources.Limits[gpuResource]; ok {
+		t.Errorf("expected no whole-GPU limit with a MIG profile")
+	}
+	if stack.Spec.Serving.Resources.Limits[gpuResource] != (resource.Quantity{}) || len(stack.Spec.Serving.Resources.Limits) != 1 {
+		t.Errorf("building the Deployment modified the spec: %v", stack.Spec.Serving.Resources.Limits)
+	}
+	if dep.Spec.Selector.MatchLabels["model"] != "llama3" || dep.Spec.Template.Labels["model"] != "llama3" {
+		t.Errorf("expected pool selector on pods, got %v", dep.Spec.Template.Labels)
+	}
+	products := dep.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+	if !slices.Equal(products.Values, []string{"RTX-6000"}) {
+		t.Errorf("unknown GPU type should match as-is, got %q", products.Values)
+	}
+}
+
+func TestSetServingImages(t *testing.T) {
+	defaults := maps.Clone(servingImages)
+	t.Cleanup(func() { servingImages = defaults })
+
+	if err := SetServingImages("vllm=registry.example.com/vllm-openai:v0.6.3, tgi=registry.example.com/tgi:2.4.0"); err != nil {
+		t.Fatalf("SetServingImages: %v", err)
+	}
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       v1alpha1.InferenceStackSpec{ModelName: "meta-llama/Llama-3-8B-Instruct", ServingBackend: "vllm"},
+	}
+	if got := servingContainer(stack).Image; got != "registry.example.com/vllm-openai:v0.6.3" {
+		t.Errorf("vllm image = %q, want the configured default", got)
+	}
+	stack.Spec.ServingBackend = "triton"
+	if got := servingContainer(stack).Image; got != defaultTritonImage {
+		t.Errorf("triton image = %q, want the built-in default", got)
+	}
+
+	// A per-stack image wins over the configured default.
+	stack.Spec.ServingBackend = "vllm"
+	stack.Spec.Serving = &v1alpha1.ServingSpec{Image: "vllm/vllm-openai:nightly"}
+	if got := buildDesiredServingDeployment(stack, "llama-pool").Spec.Template.Spec.Containers[0].Image; got != "vllm/vllm-openai:nightly" {
+		t.Errorf("image = %q, want the stack override", got)
+	}
+
+	for _, bad := range []string{"vllm", "vllm=", "sglang=lmsys/sglang:latest"} {
+		if err := SetServingImages(bad); err == nil {
+			t.Errorf("SetServingImages(%q): expected error", bad)
+		}
+	}
+	if got := servingImages["tgi"]; got != "registry.example.com/tgi:2.4.0" {
+		t.Errorf("a rejected override changed the images: tgi = %q", got)
+	}
+}
+
+func TestPublishServingDefaults(t *testing.T) {
+	defaults := maps.Clone(servingImages)
+	t.Cleanup(func() { servingImages = defaults })
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+	key := types.NamespacedName{Name: ServingDefaultsConfigMap, Namespace: "ngf-console"}
+
+	if err := PublishServingDefaults(ctx, c, "ngf-console"); err != nil {
+		t.Fatalf("PublishServingDefaults: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("get configmap: %v", err)
+	}
+	if cm.Data["vllm"] != defaultVLLMImage || cm.Data["overridden"] != "" || cm.Labels[v1alpha1.ServingDefaultsLabel] != "true" {
+		t.Errorf("expected the built-in images, got labels %v data %v", cm.Labels, cm.Data)
+	}
+
+	// A configured default is published on the next run.
+	if err := SetServingImages("tgi=registry.example.com/tgi:2.4.0"); err != nil {
+		t.Fatalf("SetServingImages: %v", err)
+	}
+	if err := PublishServingDefaults(ctx, c, "ngf-console"); err != nil {
+		t.Fatalf("PublishServingDefaults: %v", err)
+	}
+	if err := c.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("get configmap: %v", err)
+	}
+	if cm.Data["tgi"] != "registry.example.com/tgi:2.4.0" || cm.Data["overridden"] != "tgi" {
+		t.Errorf("expected the tgi override, got %v", cm.Data)
+	}
+}
+
+func TestReconcileServingDeployment_AutoscalerOwnsReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add apps scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend: "vllm",
+			Pool:           v1alpha1.InferencePoolSpec{GPUType: "A100", GPUCount: 1, Replicas: 1, MinReplicas: 1, MaxReplicas: 4},
+			Autoscaling:    &v1alpha1.AutoscalingSpec{Backend: "keda"},
+		},
+	}
+
+	ctx := context.Background()
+	if status := r.reconcileServingDeployment(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected created, got %+v", status)
+	}
+
+	// The autoscaler scales the Deployment; the operator must not undo it.
+	key := types.NamespacedName{Name: "llama-pool", Namespace: "default"}
+	var dep appsv1.Deployment
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	scaled := int32(3)
+	dep.Spec.Replicas = &scaled
+	if err := c.Update(ctx, &dep); err != nil {
+		t.Fatalf("scale deployment: %v", err)
+	}
+	if status := r.reconcileServingDeployment(ctx, stack); status.Reason != v1alpha1.ChildReasonWaitingForPods || status.Message != "waiting for pods (0/3 available)" {
+		t.Errorf("expected waiting status for the scaled Deployment, got %+v", status)
+	}
+
+	// A user-managed autoscaler owns the replicas too.
+	stack.Spec.ManageAutoscaler = boolPtr(false)
+	if status := r.reconcileServingDeployment(ctx, stack); status.Message != "waiting for pods (0/3 available)" {
+		t.Errorf("expected the user-managed autoscaler's replicas to be kept, got %+v", status)
+	}
+
+	// Without an autoscaler, the pool's replica count is restored.
+	stack.Spec.Autoscaling = nil
+	if status := r.reconcileServingDeployment(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	if err := c.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("get deployment: %v", err)
+	}
+	if *dep.Spec.Replicas != 1 {
+		t.Errorf("replicas = %d, want 1", *dep.Spec.Replicas)
+	}
+}
+
+func TestReconcileImagePullSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add apps scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:        "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend:   "vllm",
+			DCGM:             &v1alpha1.DCGMSpec{Enabled: true},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "ngc-registry"}},
+		},
+	}
+
+	ctx := context.Background()
+	podSpecs := func() map[string]corev1.PodSpec {
+		t.Helper()
+		var dep appsv1.Deployment
+		if err := c.Get(ctx, types.NamespacedName{Name: "llama-epp", Namespace: "default"}, &dep); err != nil {
+			t.Fatalf("get deployment: %v", err)
+		}
+		var ds appsv1.DaemonSet
+		if err := c.Get(ctx, types.NamespacedName{Name: "llama-dcgm", Namespace: "default"}, &ds); err != nil {
+			t.Fatalf("get daemonset: %v", err)
+		}
+		return map[string]corev1.PodSpec{"Deployment": dep.Spec.Template.Spec, "DaemonSet": ds.Spec.Template.Spec}
+	}
+
+	for _, status := range []v1alpha1.ChildStatus{r.reconcileEPPDeployment(ctx, stack), r.reconcileDCGMExporter(ctx, stack)} {
+		if status.Message != "created" {
+			t.Fatalf("%s: expected created, got %+v", status.Kind, status)
+		}
+	}
+	for kind, spec := range podSpecs() {
+		if len(spec.ImagePullSecrets) != 1 || spec.ImagePullSecrets[0].Name != "ngc-registry" {
+			t.Errorf("%s: unexpected pull secrets %+v", kind, spec.ImagePullSecrets)
+		}
+	}
+
+	// Changing the pull secrets on the stack updates both pod templates.
+	stack.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "internal-registry"}}
+	for _, status := range []v1alpha1.ChildStatus{r.reconcileEPPDeployment(ctx, stack), r.reconcileDCGMExporter(ctx, stack)} {
+		if status.Message != "updated" {
+			t.Fatalf("%s: expected updated, got %+v", status.Kind, status)
+		}
+	}
+	for kind, spec := range podSpecs() {
+		if len(spec.ImagePullSecrets) != 1 || spec.ImagePullSecrets[0].Name != "internal-registry" {
+			t.Errorf("%s: unexpected pull secrets %+v", kind, spec.ImagePullSecrets)
+		}
+	}
+}
+
+func TestReconcileDCGMExporter_Scheduling(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add apps scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend: "vllm",
+			DCGM:           &v1alpha1.DCGMSpec{Enabled: true},
+		},
+	}
+
+	ctx := context.Background()
+	podSpec := func() corev1.PodSpec {
+		t.Helper()
+		var ds appsv1.DaemonSet
+		if err := c.Get(ctx, types.NamespacedName{Name: "llama-dcgm", Namespace: "default"}, &ds); err != nil {
+			t.Fatalf("get daemonset: %v", err)
+		}
+		return ds.Spec.Template.Spec
+	}
+
+	// By default the exporter runs only on GPU nodes and tolerates their taint.
+	if status := r.reconcileDCGMExporter(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected created, got %+v", status)
+	}
+	spec := podSpec()
+	if len(spec.NodeSelector) != 1 || spec.NodeSelector[gpuPresentLabel] != "true" {
+		t.Errorf("default node selector = %v", spec.NodeSelector)
+	}
+	if len(spec.Tolerations) != 1 || spec.Tolerations[0].Key != gpuResource || spec.Tolerations[0].Operator != corev1.TolerationOpExists {
+		t.Errorf("default tolerations = %+v", spec.Tolerations)
+	}
+	if status := r.reconcileDCGMExporter(ctx, stack); status.Reason != v1alpha1.ChildReasonWaitingForPods {
+		t.Fatalf("expected no drift on the defaults, got %+v", status)
+	}
+
+	// Explicit values replace the defaults.
+	stack.Spec.DCGM.NodeSelector = map[string]string{"pool": "gpu"}
+	stack.Spec.DCGM.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "ml", Effect: corev1.TaintEffectNoSchedule}}
+	if status := r.reconcileDCGMExporter(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	spec = podSpec()
+	if len(spec.NodeSelector) != 1 || spec.NodeSelector["pool"] != "gpu" {
+		t.Errorf("node selector = %v, want pool=gpu", spec.NodeSelector)
+	}
+	if len(spec.Tolerations) != 1 || spec.Tolerations[0].Key != "dedicated" {
+		t.Errorf("tolerations = %+v, want dedicated", spec.Tolerations)
+	}
+
+	// Empty values clear the defaults.
+	stack.Spec.DCGM.NodeSelector = map[string]string{}
+	stack.Spec.DCGM.Tolerations = []corev1.Toleration{}
+	if status := r.reconcileDCGMExporter(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	if spec = podSpec(); len(spec.NodeSelector) != 0 || len(spec.Tolerations) != 0 {
+		t.Errorf("expected no node selector or tolerations, got %v and %+v", spec.NodeSelector, spec.Tolerations)
+	}
+}
+
+func TestReconcileServiceMonitor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+	gvk := serviceMonitorGVK()
+	scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind("ServiceMonitorList"), &unstructured.UnstructuredList{})
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend: "vllm",
+		},
+	}
+
+	ctx := context.Background()
+	if status := r.reconcileServiceMonitor(ctx, stack); status.Reason != v1alpha1.ChildReasonNotConfigured {
+		t.Fatalf("expected not configured without serviceMonitor, got %+v", status)
+	}
+
+	stack.Spec.ServiceMonitor = &v1alpha1.ServiceMonitorSpec{Enabled: true, Interval: "30s", Labels: map[string]string{"release": "prom"}}
+	if status := r.reconcileMetricsService(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected metrics service created, got %+v", status)
+	}
+	if status := r.reconcileServiceMonitor(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected servicemonitor created, got %+v", status)
+	}
+
+	var svc corev1.Service
+	if err := c.Get(ctx, types.NamespacedName{Name: "llama-metrics", Namespace: "default"}, &svc); err != nil {
+		t.Fatalf("get metrics service: %v", err)
+	}
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone || svc.Spec.Selector["ngf-console.f5.com/stack"] != "llama" {
+		t.Errorf("unexpected metrics service spec: %+v", svc.Spec)
+	}
+	if len(svc.OwnerReferences) != 1 || svc.OwnerReferences[0].Name != "llama" {
+		t.Errorf("expected owner reference to the stack, got %+v", svc.OwnerReferences)
+	}
+
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, types.NamespacedName{Name: "llama-monitor", Namespace: "default"}, sm); err != nil {
+		t.Fatalf("get servicemonitor: %v", err)
+	}
+	if labels := sm.GetLabels(); labels["release"] != "prom" || labels["app.kubernetes.io/managed-by"] != "ngf-console" {
+		t.Errorf("unexpected servicemonitor labels: %v", labels)
+	}
+	if len(sm.GetOwnerReferences()) != 1 {
+		t.Errorf("expected owner reference, got %+v", sm.GetOwnerReferences())
+	}
+	endpoints, _, _ := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+	var ports []string
+	for _, e := range endpoints {
+		endpoint := e.(map[string]interface{})
+		ports = append(ports, endpoint["port"].(string))
+		if endpoint["interval"] != "30s" {
+			t.Errorf("expected interval 30s, got %v", endpoint["interval"])
+		}
+	}
+	if !slices.Equal(ports, []string{"metrics", "serving"}) {
+		t.Errorf("endpoint ports = %v, want metrics and serving", ports)
+	}
+
+	if status := r.reconcileMetricsService(ctx, stack); status.Message != "in sync" {
+		t.Errorf("expected metrics service in sync, got %+v", status)
+	}
+	if status := r.reconcileServiceMonitor(ctx, stack); status.Message != "in sync" {
+		t.Errorf("expected servicemonitor in sync, got %+v", status)
+	}
+}
+
+func TestReconcileInferencePool_RestoresTargetPortAndSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	gvk := inferencePoolGVK()
+	scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind("InferencePoolList"), &unstructured.UnstructuredList{})
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1alpha1.InferenceStackSpec{
+			ModelName:      "meta-llama/Llama-3-70B-Instruct",
+			ServingBackend: "vllm",
+			Pool:           v1alpha1.InferencePoolSpec{Selector: map[string]string{"app": "llama-vllm"}},
+		},
+	}
+
+	ctx := context.Background()
+	if status := r.reconcileInferencePool(ctx, stack); status.Message != "created" {
+		t.Fatalf("expected created, got %+v", status)
+	}
+
+	// Simulate a manual edit of the routing fields.
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(gvk)
+	key := types.NamespacedName{Name: "llama-pool", Namespace: "default"}
+	if err := c.Get(ctx, key, pool); err != nil {
+		t.Fatalf("get pool: %v", err)
+	}
+	if err := unstructured.SetNestedSlice(pool.Object, []interface{}{
+		map[string]interface{}{"number": int64(9000)},
+	}, "spec", "targetPorts"); err != nil {
+		t.Fatalf("set target ports: %v", err)
+	}
+	if err := unstructured.SetNestedStringMap(pool.Object, map[string]string{"app": "other"}, "spec", "selector", "matchLabels"); err != nil {
+		t.Fatalf("set selector: %v", err)
+	}
+	if err := c.Update(ctx, pool); err != nil {
+		t.Fatalf("update pool: %v", err)
+	}
+
+	if status := r.reconcileInferencePool(ctx, stack); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	if err := c.Get(ctx, key, pool); err != nil {
+		t.Fatalf("get pool: %v", err)
+	}
+	spec, _, _ := unstructured.NestedMap(pool.Object, "spec")
+	if ports := poolTargetPorts(spec); len(ports) != 1 || ports[0] != 8000 {
+		t.Errorf("expected target port 8000 to be restored, got %v", ports)
+	}
+	if labels, _, _ := unstructured.NestedStringMap(spec, "selector", "matchLabels"); len(labels) != 1 || labels["app"] != "llama-vllm" {
+		t.Errorf("expected selector to be restored, got %v", labels)
+	}
+
+	if status := r.reconcileInferencePool(ctx, stack); status.Message != "in sync" {
+		t.Errorf("expected in sync, got %+v", status)
+	}
+}
+
+func TestBuildDesiredScaledObject_Triggers(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama3", Namespace: "models"},
+		Spec: v1alpha1.InferenceStackSpec{
+			Autoscaling: &v1alpha1.AutoscalingSpec{
+				Backend: "keda",
+				Thresholds: []v1alpha1.ThresholdSpec{
+					{Metric: "queue_depth", Target: 5},
+					{Type: v1alpha1.ThresholdTypeCPU, Target: 70},
+					{Type: v1alpha1.ThresholdTypeMemory, Target: 80},
+					{Type: v1alpha1.ThresholdTypeGPU, Target: 90},
+				},
+			},
+		},
+	}
+
+	triggers, _, _ := unstructured.NestedSlice(buildDesiredScaledObject(stack, "llama3-scaler").Object, "spec", "triggers")
+	if len(triggers) != 4 {
+		t.Fatalf("expected 4 triggers, got %v", triggers)
+	}
+	prom := triggers[0].(map[string]interface{})
+	promMeta := prom["metadata"].(map[string]interface{})
+	if prom["type"] != "prometheus" || promMeta["serverAddress"] != defaultPrometheusAddress ||
+		promMeta["query"] != `avg(queue_depth{pool="llama3-pool"})` || promMeta["threshold"] != "5" {
+		t.Errorf("unexpected prometheus trigger: %v", prom)
+	}
+	for i, want := range []string{"cpu", "memory"} {
+		trig := triggers[i+1].(map[string]interface{})
+		if trig["type"] != want || trig["metricType"] != "Utilization" || trig["metadata"].(map[string]interface{})["value"] == nil {
+			t.Errorf("unexpected %s trigger: %v", want, trig)
+		}
+	}
+	gpu := triggers[3].(map[string]interface{})
+	if q := gpu["metadata"].(map[string]interface{})["query"]; gpu["type"] != "prometheus" ||
+		q != `avg(DCGM_FI_DEV_GPU_UTIL{namespace="models",pod=~"llama3-pool-.*"})` {
+		t.Errorf("unexpected gpu trigger: %v", gpu)
+	}
+
+	stack.Spec.Autoscaling.PrometheusAddress = "http://kube-prometheus.observability:9090"
+	triggers, _, _ = unstructured.NestedSlice(buildDesiredScaledObject(stack, "llama3-scaler").Object, "spec", "triggers")
+	for _, i := range []int{0, 3} {
+		if addr := triggers[i].(map[string]interface{})["metadata"].(map[string]interface{})["serverAddress"]; addr != "http://kube-prometheus.observability:9090" {
+			t.Errorf("trigger %d: expected the configured Prometheus address, got %v", i, addr)
+		}
+	}
+}
+
+func TestBuildDesiredHPA(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama3", Namespace: "models"},
+		Spec: v1alpha1.InferenceStackSpec{
+			Pool: v1alpha1.InferencePoolSpec{Replicas: 2, MinReplicas: 0, MaxReplicas: 6},
+			Autoscaling: &v1alpha1.AutoscalingSpec{
+				Backend:         v1alpha1.AutoscalerBackendHPA,
+				CooldownSeconds: 120,
+				Thresholds: []v1alpha1.ThresholdSpec{
+					{Metric: "queue_depth", Target: 5},
+					{Type: v1alpha1.ThresholdTypeCPU, Target: 70},
+					{Type: v1alpha1.ThresholdTypeGPU, Target: 90},
+				},
+			},
+		},
+	}
+
+	hpa := buildDesiredHPA(stack, "llama3-scaler")
+	ref := hpa.Spec.ScaleTargetRef
+	if ref.APIVersion != "apps/v1" || ref.Kind != "Deployment" || ref.Name != "llama3-pool" {
+		t.Errorf("unexpected scale target: %+v", ref)
+	}
+	if *hpa.Spec.MinReplicas != 1 || hpa.Spec.MaxReplicas != 6 {
+		t.Errorf("replicas = %d..%d, want 1..6", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if w := hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds; *w != 120 {
+		t.Errorf("scale-down stabilization = %d, want 120", *w)
+	}
+	if len(hpa.Spec.Metrics) != 3 {
+		t.Fatalf("expected 3 metrics, got %+v", hpa.Spec.Metrics)
+	}
+	if m := hpa.Spec.Metrics[0]; m.Pods == nil || m.Pods.Metric.Name != "queue_depth" || m.Pods.Target.AverageValue.Value() != 5 {
+		t.Errorf("unexpected prometheus metric: %+v", m)
+	}
+	if m := hpa.Spec.Metrics[1]; m.Resource == nil || m.Resource.Name != corev1.ResourceCPU || *m.Resource.Target.AverageUtilization != 70 {
+		t.Errorf("unexpected cpu metric: %+v", m)
+	}
+	if m := hpa.Spec.Metrics[2]; m.Pods == nil || m.Pods.Metric.Name != "DCGM_FI_DEV_GPU_UTIL" || m.Pods.Target.AverageValue.Value() != 90 {
+		t.Errorf("unexpected gpu metric: %+v", m)
+	}
+
+	// A maximum below the minimum falls back to the pool's replica count.
+	stack.Spec.Pool.MinReplicas, stack.Spec.Pool.MaxReplicas = 1, 0
+	if hpa := buildDesiredHPA(stack, "llama3-scaler"); hpa.Spec.MaxReplicas != 2 {
+		t.Errorf("maxReplicas = %d, want 2", hpa.Spec.MaxReplicas)
+	}
+}
+
+func TestReconcileAutoscaler_HPA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := autoscalingv2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add autoscaling scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &InferenceStackReconciler{Client: c, Scheme: scheme}
+
+	stack := &v1alpha1.InferenceStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "stack-uid"},
+		Spec: v1alpha1.InferenceStackSpec{
+			Pool: v1alpha1.InferencePoolSpec{Replicas: 1, MinReplicas: 1, MaxReplicas: 4},
+			Autoscaling: &v1alpha1.AutoscalingSpec{
+				Backend:    v1alpha1.AutoscalerBackendHPA,
+				Thresholds: []v1alpha1.ThresholdSpec{{Type: v1alpha1.ThresholdTypeCPU, Target: 70}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	want := v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: "llama-scaler", Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	if status := r.reconcileAutoscaler(ctx, stack); status != want {
+		t.Fatalf("expected %+v, got %+v", want, status)
+	}
+	if status := r.reconcileAutoscaler(ctx, stack); status.Reason != v1alpha1.ChildReasonInSync {
+		t.Fatalf("expected in sync, got %+v", status)
+	}
+
+	stack.Spec.Pool.MaxReplicas = 8
+	if status := r.reconcileAutoscaler(ctx, stack); status.Reason != v1alpha1.ChildReasonUpdated {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	key := types.NamespacedName{Name: "llama-scaler", Namespace: "default"}
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	if err := c.Get(ctx, key, &hpa); err != nil {
+		t.Fatalf("get hpa: %v", err)
+	}
+	if hpa.Spec.MaxReplicas != 8 {
+		t.Errorf("maxReplicas = %d, want 8", hpa.Spec.MaxReplicas)
+	}
+
+	// Switching back to KEDA removes the HPA the stack owns.
+	stale := &autoscalingv2.HorizontalPodAutoscaler{}
+	stale.SetName("llama-scaler")
+	r.deleteStaleAutoscaler(ctx, stack, "HorizontalPodAutoscaler", stale)
+	if err := c.Get(ctx, key, &hpa); !errors.IsNotFound(err) {
+		t.Errorf("expected the HPA to be deleted, got %v", err)
+	}
+
+	// An HPA the stack does not control is left alone.
+	foreign := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: "llama-scaler", Namespace: "default"}}
+	if err := c.Create(ctx, foreign); err != nil {
+		t.Fatalf("create hpa: %v", err)
+	}
+	stale = &autoscalingv2.HorizontalPodAutoscaler{}
+	stale.SetName("llama-scaler")
+	r.deleteStaleAutoscaler(ctx, stack, "HorizontalPodAutoscaler", stale)
+	if err := c.Get(ctx, key, &hpa); err != nil {
+		t.Errorf("expected the unowned HPA to remain, got %v", err)
+	}
+}
+
+func TestPoolRoutingDrift(t *testing.T) {
+	spec := func(port interface{}, app string) map[string]interface{} {
+		return map[string]interface{}{
+			"targetPorts": []interface{}{map[string]interface{}{"number": port}},
+			"selector":    map[string]interface{}{"matchLabels": map[string]interface{}{"app": app}},
+		}
+	}
+	desired := spec(int64(8000), "web")
+
+	if got := poolRoutingDrift(desired, spec(float64(8000), "web")); len(got) != 0 {
+		t.Errorf("expected no drift for equal numeric types, got %v", got)
+	}
+	if got := poolRoutingDrift(desired, spec(int64(9000), "web")); !slices.Equal(got, []string{"targetPorts"}) {
+		t.Errorf("expected targetPorts drift, got %v", got)
+	}
+	if got := poolRoutingDrift(desired, spec(int64(8000), "api")); !slices.Equal(got, []string{"selector"}) {
+		t.Errorf("expected selector drift, got %v", got)
+	}
+	if got := poolRoutingDrift(desired, map[string]interface{}{}); len(got) != 2 {
+		t.Errorf("expected both fields to drift when missing, got %v", got)
+	}
+}
+
+func TestReconcileGateway_DriftCorrectionDisabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := gatewayv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add gateway scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &GatewayBundleReconciler{Client: c, Scheme: scheme}
+
+	bundle := &v1alpha1.GatewayBundle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "edge",
+			Namespace:   "default",
+			Annotations: map[string]string{v1alpha1.DriftCorrectionAnnotation: "disabled"},
+		},
+		Spec: v1alpha1.GatewayBundleSpec{
+			GatewayClassName: "nginx",
+			Listeners:        []v1alpha1.GatewayListenerSpec{{Name: "http", Port: 80, Protocol: "HTTP"}},
+		},
+	}
+
+	// Missing children are still created.
+	ctx := context.Background()
+	if status := r.reconcileGateway(ctx, bundle); status.Message != "created" {
+		t.Fatalf("expected created, got %+v", status)
+	}
+
+	var gw gatewayv1.Gateway
+	key := types.NamespacedName{Name: "edge", Namespace: "default"}
+	if err := c.Get(ctx, key, &gw); err != nil {
+		t.Fatalf("get gateway: %v", err)
+	}
+	gw.Spec.Listeners[0].Port = 8080
+	if err := c.Update(ctx, &gw); err != nil {
+		t.Fatalf("update gateway: %v", err)
+	}
+
+	if status := r.reconcileGateway(ctx, bundle); status.Message != "drifted (correction disabled)" {
+		t.Fatalf("expected drift to be reported, got %+v", status)
+	}
+	if err := c.Get(ctx, key, &gw); err != nil {
+		t.Fatalf("get gateway: %v", err)
+	}
+	if gw.Spec.Listeners[0].Port != 8080 {
+		t.Errorf("manual edit was reverted: port = %d", gw.Spec.Listeners[0].Port)
+	}
+
+	// Removing the annotation restores drift correction.
+	delete(bundle.Annotations, v1alpha1.DriftCorrectionAnnotation)
+	if status := r.reconcileGateway(ctx, bundle); status.Message != "updated" {
+		t.Fatalf("expected updated, got %+v", status)
+	}
+	if err := c.Get(ctx, key, &gw); err != nil {
+		t.Fatalf("get gateway: %v", err)
+	}
+	if gw.Spec.Listeners[0].Port != 80 {
+		t.Errorf("expected port to be restored to 80, got %d", gw.Spec.Listeners[0].Port)
+	}
+}
+
+func TestBuildDesiredGateway_TLSPassthrough(t *testing.T) {
+	bundle := &v1alpha1.GatewayBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"},
+		Spec: v1alpha1.GatewayBundleSpec{
+			GatewayClassName: "nginx",
+			Listeners: []v1alpha1.GatewayListenerSpec{
+				{
+					Name:     "tls",
+					Port:     443,
+					Protocol: "TLS",
+					Hostname: "*.example.com",
+					TLS: &v1alpha1.ListenerTLSSpec{
+						Mode:            "Passthrough",
+						CertificateRefs: []v1alpha1.CertRefSpec{{Name: "ignored"}},
+					},
+				},
+				{
+					Name:     "https",
+					Port:     8443,
+					Protocol: "HTTPS",
+					TLS: &v1alpha1.ListenerTLSSpec{
+						Mode:            "Terminate",
+						CertificateRefs: []v1alpha1.CertRefSpec{{Name: "edge-cert"}},
+					},
+				},
+			},
+		},
+	}
+
+	gw := buildDesiredGateway(bundle)
+	passthrough, terminate := gw.Spec.Listeners[0], gw.Spec.Listeners[1]
+
+	if passthrough.TLS == nil || passthrough.TLS.Mode == nil || *passthrough.TLS.Mode != gatewayv1.TLSModePassthrough {
+		t.Fatalf("expected passthrough TLS mode, got %+v", passthrough.TLS)
+	}
+	if len(passthrough.TLS.CertificateRefs) != 0 {
+		t.Errorf("expected no certificate refs on passthrough listener, got %d", len(passthrough.TLS.CertificateRefs))
+	}
+	if passthrough.AllowedRoutes == nil || len(passthrough.AllowedRoutes.Kinds) != 1 {
+		t.Fatalf("expected passthrough listener to default to one route kind, got %+v", passthrough.AllowedRoutes)
+	}
+	if kind := passthrough.AllowedRoutes.Kinds[0]; kind.Kind != "TLSRoute" || kind.Group == nil || *kind.Group != gatewayv1.GroupName {
+		t.Errorf("expected TLSRoute kind, got %+v", kind)
+	}
+
+	if len(terminate.TLS.CertificateRefs) != 1 {
+		t.Errorf("expected terminate listener to keep its certificate ref, got %d", len(terminate.TLS.CertificateRefs))
+	}
+	if terminate.AllowedRoutes != nil {
+		t.Errorf("expected no allowedRoutes on terminate listener, got %+v", terminate.AllowedRoutes)
+	}
+}
+
+func TestRecordChildEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	stack := &v1alpha1.InferenceStack{ObjectMeta: metav1.ObjectMeta{Name: "llama3", Namespace: "default"}}
+	children := []v1alpha1.ChildStatus{
+		{Kind: "InferencePool", Name: "llama3", Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"},
+		{Kind: "ConfigMap", Name: "llama3-epp-config", Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"},
+		{Kind: "ScaledObject", Name: "llama3-scaler", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"},
+		{Kind: "HTTPRoute", Name: "llama3-route", Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"},
+		{Kind: "Deployment", Name: "llama3-epp", Ready: false, Reason: v1alpha1.ChildReasonWaitingForPods, Message: "waiting for pods (0/1 available)"},
+		{Kind: "DaemonSet", Name: "llama3-dcgm", Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: "update failed: conflict"},
+	}
+
+	recordChildEvents(recorder, stack, nil, children)
+	close(recorder.Events)
+
+	var got []string
+	for e := range recorder.Events {
+		got = append(got, e)
+	}
+	want := []string{
+		"Normal ChildCreated Created InferencePool llama3",
+		"Normal ChildUpdated Updated ConfigMap llama3-epp-config",
+		"Warning ChildFailed DaemonSet llama3-dcgm: update failed: conflict",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events = %q, want %q", got, want)
+	}
+	for i := range want {
+		// The fake recorder appends annotations; match on the event itself.
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// A nil recorder is a no-op.
+	recordChildEvents(nil, stack, nil, children)
+}
+
+func TestRecordChildEvents_Transitions(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{ObjectMeta: metav1.ObjectMeta{Name: "llama3", Namespace: "default"}}
+	previous := []v1alpha1.ChildStatus{
+		{Kind: "Deployment", Name: "llama3-epp", Reason: v1alpha1.ChildReasonUpdateFailed, Message: "update failed: conflict"},
+		{Kind: "DaemonSet", Name: "llama3-dcgm", Reason: v1alpha1.ChildReasonUpdateFailed, Message: "update failed: conflict"},
+		{Kind: "Service", Name: "llama3-epp", Reason: v1alpha1.ChildReasonGetFailed, Message: "get failed: timeout"},
+		{Kind: "HTTPRoute", Name: "llama3-route", Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"},
+	}
+	children := []v1alpha1.ChildStatus{
+		// Same failure as last time: not recorded again.
+		{Kind: "Deployment", Name: "llama3-epp", Reason: v1alpha1.ChildReasonUpdateFailed, Message: "update failed: conflict"},
+		// Different failure.
+		{Kind: "DaemonSet", Name: "llama3-dcgm", Reason: v1alpha1.ChildReasonUpdateFailed, Message: "update failed: forbidden"},
+		{Kind: "Service", Name: "llama3-epp", Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"},
+		{Kind: "HTTPRoute", Name: "llama3-route", Reason: v1alpha1.ChildReasonCreateFailed, Message: "create failed: denied"},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	recordChildEvents(recorder, stack, previous, children)
+	close(recorder.Events)
+
+	var got []string
+	for e := range recorder.Events {
+		got = append(got, e)
+	}
+	want := []string{
+		"Warning ChildFailed DaemonSet llama3-dcgm: update failed: forbidden",
+		"Normal ChildRecovered Service llama3-epp recovered: in sync",
+		"Warning ChildFailed HTTPRoute llama3-route: create failed: denied",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events = %q, want %q", got, want)
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRecordPhaseEvent(t *testing.T) {
+	stack := &v1alpha1.InferenceStack{ObjectMeta: metav1.ObjectMeta{Name: "llama3", Namespace: "default"}}
+	tests := []struct {
+		previous, phase string
+		want            string
+	}{
+		{"", v1alpha1.PhaseDegraded, "Normal PhaseChanged Phase is Degraded"},
+		{v1alpha1.PhaseDegraded, v1alpha1.PhaseReady, "Normal PhaseChanged Phase changed from Degraded to Ready"},
+		{v1alpha1.PhaseReady, v1alpha1.PhaseError, "Warning PhaseChanged Phase changed from Ready to Error"},
+		{v1alpha1.PhaseReady, v1alpha1.PhaseReady, ""},
+	}
+	for _, tt := range tests {
+		recorder := record.NewFakeRecorder(1)
+		recordPhaseEvent(recorder, stack, tt.previous, tt.phase)
+		close(recorder.Events)
+		got := <-recorder.Events
+		if got != tt.want {
+			t.Errorf("recordPhaseEvent(%q, %q) = %q, want %q", tt.previous, tt.phase, got, tt.want)
+		}
+	}
+
+	// A nil recorder is a no-op.
+	recordPhaseEvent(nil, stack, "", v1alpha1.PhaseReady)
+}
@@ -27,26 +27,28 @@ func (r *GatewayBundleReconciler) reconcileGateway(ctx context.Context, bundle *
 		log.Info("creating Gateway")
 		if err := r.Create(ctx, desired); err != nil {
 			log.Error("failed to create Gateway", "error", err)
-			return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get Gateway", "error", err)
-		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
 	// Update spec if drifted
-	if specDrifted(existing.Spec, desired.Spec) {
-		log.Info("Gateway spec drifted, updating")
+	drifted := specDrifted(existing.Spec, desired.Spec) || metadataDrifted(existing, desired)
+	if drifted && driftCorrectionDisabled(bundle) {
+		log.Info("Gateway drifted, drift correction disabled")
+	} else if drifted {
+		log.Info("Gateway drifted, updating")
 		existing.Spec = desired.Spec
-		existing.Labels = desired.Labels
-		existing.Annotations = desired.Annotations
+		mergeMetadata(existing, desired)
 		if err := r.Update(ctx, existing); err != nil {
 			log.Error("failed to update Gateway", "error", err)
-			return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
 	}
 
 	// Check Gateway status conditions for readiness
@@ -60,12 +62,14 @@ func (r *GatewayBundleReconciler) reconcileGateway(ctx context.Context, bundle *
 		}
 	}
 
-	msg := "in sync"
-	if !ready {
-		msg = "waiting for gateway controller"
+	reason, msg := v1alpha1.ChildReasonInSync, "in sync"
+	if drifted {
+		reason, msg = v1alpha1.ChildReasonDriftIgnored, "drifted (correction disabled)"
+	} else if !ready {
+		reason, msg = v1alpha1.ChildReasonWaitingForController, "waiting for gateway controller"
 	}
 
-	return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: ready, Message: msg}
+	return v1alpha1.ChildStatus{Kind: "Gateway", Name: name, Ready: ready, Reason: reason, Message: msg}
 }
 
 // buildDesiredGateway constructs the desired Gateway resource from the GatewayBundle spec.
@@ -74,11 +78,11 @@ func buildDesiredGateway(bundle *v1alpha1.GatewayBundle) *gatewayv1.Gateway {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      bundle.Name,
 			Namespace: bundle.Namespace,
-			Labels: mergeLabels(bundle.Spec.Labels, map[string]string{
+			Labels: mergeLabels(mergeLabels(bundle.Spec.ExtraLabels, bundle.Spec.Labels), map[string]string{
 				"app.kubernetes.io/managed-by": "ngf-console",
 				"ngf-console.f5.com/bundle":    bundle.Name,
 			}),
-			Annotations: bundle.Spec.Annotations,
+			Annotations: mergeLabels(bundle.Spec.ExtraAnnotations, bundle.Spec.Annotations),
 		},
 		Spec: gatewayv1.GatewaySpec{
 			GatewayClassName: gatewayv1.ObjectName(bundle.Spec.GatewayClassName),
@@ -119,7 +123,13 @@ func buildDesiredGateway(bundle *v1alpha1.GatewayBundle) *gatewayv1.Gateway {
 				mode := gatewayv1.TLSModeType(l.TLS.Mode)
 				tlsCfg.Mode = &mode
 			}
-			for _, ref := range l.TLS.CertificateRefs {
+			// Passthrough listeners forward the raw TLS stream by SNI, so
+			// certificate refs are not valid on them.
+			var certRefs []v1alpha1.CertRefSpec
+			if l.TLS.Mode != string(gatewayv1.TLSModePassthrough) {
+				certRefs = l.TLS.CertificateRefs
+			}
+			for _, ref := range certRefs {
 				certRef := gatewayv1.SecretObjectReference{
 					Name: gatewayv1.ObjectName(ref.Name),
 				}
@@ -143,12 +153,45 @@ func buildDesiredGateway(bundle *v1alpha1.GatewayBundle) *gatewayv1.Gateway {
 			listener.AllowedRoutes = ar
 		}
 
+		if kinds := listenerRouteKinds(l); len(kinds) > 0 {
+			if listener.AllowedRoutes == nil {
+				listener.AllowedRoutes = &gatewayv1.AllowedRoutes{}
+			}
+			listener.AllowedRoutes.Kinds = kinds
+		}
+
 		gw.Spec.Listeners = append(gw.Spec.Listeners, listener)
 	}
 
 	return gw
 }
 
+// listenerRouteKinds returns the route kinds allowed on a listener. Explicit
+// kinds win; passthrough listeners otherwise default to TLSRoute, the only
+// route kind that can match on SNI without terminating TLS.
+func listenerRouteKinds(l v1alpha1.GatewayListenerSpec) []gatewayv1.RouteGroupKind {
+	var specs []v1alpha1.RouteGroupKindSpec
+	if l.AllowedRoutes != nil {
+		specs = l.AllowedRoutes.Kinds
+	}
+	if len(specs) == 0 && l.TLS != nil && l.TLS.Mode == string(gatewayv1.TLSModePassthrough) {
+		specs = []v1alpha1.RouteGroupKindSpec{{Kind: "TLSRoute"}}
+	}
+
+	kinds := make([]gatewayv1.RouteGroupKind, 0, len(specs))
+	for _, k := range specs {
+		group := gatewayv1.Group(gatewayv1.GroupName)
+		if k.Group != "" {
+			group = gatewayv1.Group(k.Group)
+		}
+		kinds = append(kinds, gatewayv1.RouteGroupKind{
+			Group: &group,
+			Kind:  gatewayv1.Kind(k.Kind),
+		})
+	}
+	return kinds
+}
+
 // mergeLabels merges two label maps, with overrides taking precedence.
 func mergeLabels(base, overrides map[string]string) map[string]string {
 	result := make(map[string]string)
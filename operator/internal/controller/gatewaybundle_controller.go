@@ -5,8 +5,10 @@ import (
 	"log/slog"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -18,7 +20,8 @@ import (
 // GatewayBundleReconciler reconciles GatewayBundle objects.
 type GatewayBundleReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // Reconcile handles reconciliation of GatewayBundle resources.
@@ -88,8 +91,11 @@ func (r *GatewayBundleReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	tlsStatus := r.reconcileTLSSecrets(ctx, &bundle)
 	children = append(children, tlsStatus)
 
+	recordChildEvents(r.Recorder, &bundle, bundle.Status.Children, children)
+
 	// 6. Compute aggregate phase
 	phase := computePhase(children)
+	recordPhaseEvent(r.Recorder, &bundle, bundle.Status.Phase, phase)
 
 	// 7. Update parent status
 	now := metav1.Now()
@@ -109,6 +115,13 @@ func (r *GatewayBundleReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		v1alpha1.SetCondition(&bundle.Status.Conditions, v1alpha1.ConditionReconciled, metav1.ConditionTrue, "ReconcileSucceeded", "Reconciliation completed with degraded children")
 	}
 
+	if driftCorrectionDisabled(&bundle) {
+		v1alpha1.SetCondition(&bundle.Status.Conditions, v1alpha1.ConditionDriftCorrectionDisabled, metav1.ConditionTrue, "AnnotationSet",
+			"Manual edits to child resources are not reverted while "+v1alpha1.DriftCorrectionAnnotation+" is disabled")
+	} else {
+		meta.RemoveStatusCondition(&bundle.Status.Conditions, v1alpha1.ConditionDriftCorrectionDisabled)
+	}
+
 	if err := r.Status().Update(ctx, &bundle); err != nil {
 		log.Error("unable to update GatewayBundle status", "error", err)
 		return ctrl.Result{}, err
@@ -119,6 +132,12 @@ func (r *GatewayBundleReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{RequeueAfter: reconcileInterval}, nil
 }
 
+// driftCorrectionDisabled reports whether the bundle opts out of reverting
+// manual edits to its children.
+func driftCorrectionDisabled(bundle *v1alpha1.GatewayBundle) bool {
+	return bundle.Annotations[v1alpha1.DriftCorrectionAnnotation] == "disabled"
+}
+
 // getGatewayAddress reads the address from the Gateway child status.
 func (r *GatewayBundleReconciler) getGatewayAddress(ctx context.Context, bundle *v1alpha1.GatewayBundle) string {
 	var gw gatewayv1.Gateway
@@ -135,33 +154,33 @@ func (r *GatewayBundleReconciler) getGatewayAddress(ctx context.Context, bundle
 // reconcileNginxProxy is a stub for Enterprise NginxProxy reconciliation.
 func (r *GatewayBundleReconciler) reconcileNginxProxy(_ context.Context, bundle *v1alpha1.GatewayBundle) v1alpha1.ChildStatus {
 	if bundle.Spec.NginxProxy == nil || !bundle.Spec.NginxProxy.Enabled {
-		return v1alpha1.ChildStatus{Kind: "NginxProxy", Name: bundle.Name + "-proxy", Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "NginxProxy", Name: bundle.Name + "-proxy", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
-	return v1alpha1.ChildStatus{Kind: "NginxProxy", Name: bundle.Name + "-proxy", Ready: true, Message: "not configured (enterprise)"}
+	return v1alpha1.ChildStatus{Kind: "NginxProxy", Name: bundle.Name + "-proxy", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured (enterprise)"}
 }
 
 // reconcileWAF is a stub for Enterprise WAF reconciliation.
 func (r *GatewayBundleReconciler) reconcileWAF(_ context.Context, bundle *v1alpha1.GatewayBundle) v1alpha1.ChildStatus {
 	if bundle.Spec.WAF == nil || !bundle.Spec.WAF.Enabled {
-		return v1alpha1.ChildStatus{Kind: "WAFPolicy", Name: bundle.Name + "-waf", Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "WAFPolicy", Name: bundle.Name + "-waf", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
-	return v1alpha1.ChildStatus{Kind: "WAFPolicy", Name: bundle.Name + "-waf", Ready: true, Message: "not configured (enterprise)"}
+	return v1alpha1.ChildStatus{Kind: "WAFPolicy", Name: bundle.Name + "-waf", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured (enterprise)"}
 }
 
 // reconcileSnippetsFilter is a stub for Enterprise SnippetsFilter reconciliation.
 func (r *GatewayBundleReconciler) reconcileSnippetsFilter(_ context.Context, bundle *v1alpha1.GatewayBundle) v1alpha1.ChildStatus {
 	if bundle.Spec.SnippetsFilter == nil || !bundle.Spec.SnippetsFilter.Enabled {
-		return v1alpha1.ChildStatus{Kind: "SnippetsFilter", Name: bundle.Name + "-snippets", Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "SnippetsFilter", Name: bundle.Name + "-snippets", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
-	return v1alpha1.ChildStatus{Kind: "SnippetsFilter", Name: bundle.Name + "-snippets", Ready: true, Message: "not configured (enterprise)"}
+	return v1alpha1.ChildStatus{Kind: "SnippetsFilter", Name: bundle.Name + "-snippets", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured (enterprise)"}
 }
 
 // reconcileTLSSecrets is a stub for TLS secret management.
 func (r *GatewayBundleReconciler) reconcileTLSSecrets(_ context.Context, bundle *v1alpha1.GatewayBundle) v1alpha1.ChildStatus {
 	if bundle.Spec.TLS == nil || len(bundle.Spec.TLS.SecretRefs) == 0 {
-		return v1alpha1.ChildStatus{Kind: "Secret", Name: bundle.Name + "-tls", Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "Secret", Name: bundle.Name + "-tls", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
-	return v1alpha1.ChildStatus{Kind: "Secret", Name: bundle.Name + "-tls", Ready: true, Message: "not configured"}
+	return v1alpha1.ChildStatus{Kind: "Secret", Name: bundle.Name + "-tls", Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 }
 
 // SetupWithManager sets up the controller with the Manager.
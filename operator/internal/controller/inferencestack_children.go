@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -15,11 +18,13 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
 )
 
-// reconcileInferencePool creates or updates the InferencePool child resource.
+// reconcileInferencePool creates or updates the InferencePool child resource
+// with server-side apply.
 func (r *InferenceStackReconciler) reconcileInferencePool(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
 	name := stack.Name + "-pool"
 	log := slog.With("child", "InferencePool", "name", name)
@@ -32,32 +37,73 @@ func (r *InferenceStackReconciler) reconcileInferencePool(ctx context.Context, s
 
 	if errors.IsNotFound(err) {
 		log.Info("creating InferencePool")
-		if err := r.Create(ctx, desired); err != nil {
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to create InferencePool", "error", err)
-			return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get InferencePool", "error", err)
-		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
-	// Update spec if drifted
+	// Apply the spec if a field the operator sets drifted
 	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
 	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
 
-	if specDrifted(desiredSpec, existingSpec) {
-		log.Info("InferencePool spec drifted, updating")
-		existing.Object["spec"] = desired.Object["spec"]
-		if err := r.Update(ctx, existing); err != nil {
+	// Target ports and selector decide which pods receive traffic, so they
+	// are checked field by field and logged by name.
+	routingDrift := poolRoutingDrift(desiredSpec, existingSpec)
+
+	if len(routingDrift) > 0 || fieldsDrifted(desiredSpec, existingSpec) || metadataDrifted(existing, desired) {
+		log.Info("InferencePool drifted, applying", "routingFields", routingDrift)
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to update InferencePool", "error", err)
-			return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// poolRoutingDrift returns the InferencePool spec fields that route traffic
+// ("targetPorts", "selector") whose values differ between desired and existing.
+func poolRoutingDrift(desired, existing map[string]interface{}) []string {
+	var drifted []string
+	if !slices.Equal(poolTargetPorts(desired), poolTargetPorts(existing)) {
+		drifted = append(drifted, "targetPorts")
+	}
+	want, _, _ := unstructured.NestedStringMap(desired, "selector", "matchLabels")
+	got, _, _ := unstructured.NestedStringMap(existing, "selector", "matchLabels")
+	if !maps.Equal(want, got) {
+		drifted = append(drifted, "selector")
 	}
+	return drifted
+}
 
-	return v1alpha1.ChildStatus{Kind: "InferencePool", Name: name, Ready: true, Message: "in sync"}
+// poolTargetPorts returns the port numbers in an InferencePool spec's
+// targetPorts, in order. Entries without a numeric port yield 0.
+func poolTargetPorts(spec map[string]interface{}) []int64 {
+	ports, _, _ := unstructured.NestedSlice(spec, "targetPorts")
+	numbers := make([]int64, 0, len(ports))
+	for _, p := range ports {
+		port, _ := p.(map[string]interface{})
+		switch n := port["number"].(type) {
+		case int64:
+			numbers = append(numbers, n)
+		case int32:
+			numbers = append(numbers, int64(n))
+		case int:
+			numbers = append(numbers, int64(n))
+		case float64:
+			numbers = append(numbers, int64(n))
+		default:
+			numbers = append(numbers, 0)
+		}
+	}
+	return numbers
 }
 
 // servingPort returns the default target port for a given serving backend.
@@ -84,12 +130,7 @@ func buildDesiredInferencePool(stack *v1alpha1.InferenceStack, name string) *uns
 	setOwnerRef(pool, stack)
 
 	// Build selector.matchLabels
-	selector := stack.Spec.Pool.Selector
-	if selector == nil {
-		selector = map[string]string{
-			"app": stack.Name,
-		}
-	}
+	selector := poolSelector(stack)
 	matchLabels := make(map[string]interface{}, len(selector))
 	for k, v := range selector {
 		matchLabels[k] = v
@@ -113,17 +154,15 @@ func buildDesiredInferencePool(stack *v1alpha1.InferenceStack, name string) *uns
 			"name":        eppServiceName,
 			"failureMode": "FailClose",
 			"port": map[string]interface{}{
-				"number": int64(9002),
+				"number": int64(eppGRPCPort),
 			},
 		},
 	}
 
 	pool.Object["spec"] = spec
 
-	pool.SetLabels(map[string]string{
-		"app.kubernetes.io/managed-by": "ngf-console",
-		"ngf-console.f5.com/stack":     stack.Name,
-	})
+	pool.SetLabels(stackChildLabels(stack, nil))
+	pool.SetAnnotations(stack.Spec.ExtraAnnotations)
 
 	return pool
 }
@@ -142,63 +181,109 @@ func (r *InferenceStackReconciler) reconcileEPPConfig(ctx context.Context, stack
 		log.Info("creating EPP ConfigMap")
 		if err := r.Create(ctx, desired); err != nil {
 			log.Error("failed to create EPP ConfigMap", "error", err)
-			return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get EPP ConfigMap", "error", err)
-		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
 	// Update data if drifted
-	if specDrifted(existing.Data, desired.Data) {
+	if specDrifted(existing.Data, desired.Data) || metadataDrifted(existing, desired) {
 		log.Info("EPP ConfigMap drifted, updating")
 		existing.Data = desired.Data
+		mergeMetadata(existing, desired)
 		if err := r.Update(ctx, existing); err != nil {
 			log.Error("failed to update EPP ConfigMap", "error", err)
-			return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
 	}
 
-	return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Message: "in sync"}
+	return v1alpha1.ChildStatus{Kind: "ConfigMap", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// eppConfigFile is the EndpointPickerConfig key in the <stack>-epp-config
+// ConfigMap. The EPP reads it through --config-file; JSON is valid YAML.
+const eppConfigFile = "epp-config.json"
+
+// eppStrategyScorers maps each EPPSpec strategy to the EPP scorer plugins it
+// enables.
+var eppStrategyScorers = map[string][]string{
+	v1alpha1.EPPStrategyLeastQueue:     {"queue-scorer"},
+	v1alpha1.EPPStrategyKVCache:        {"kv-cache-utilization-scorer"},
+	v1alpha1.EPPStrategyPrefixAffinity: {"prefix-cache-scorer"},
+	v1alpha1.EPPStrategyComposite:      {"queue-scorer", "kv-cache-utilization-scorer", "prefix-cache-scorer"},
+}
+
+// eppScorerWeight returns the composite weight for a scorer. Unset weights
+// count as 1.
+func eppScorerWeight(weights *v1alpha1.EPPWeights, scorer string) int32 {
+	var w int32
+	if weights != nil {
+		switch scorer {
+		case "queue-scorer":
+			w = weights.QueueDepth
+		case "kv-cache-utilization-scorer":
+			w = weights.KVCache
+		case "prefix-cache-scorer":
+			w = weights.PrefixAffinity
+		}
+	}
+	if w <= 0 {
+		w = 1
+	}
+	return w
 }
 
-// buildDesiredEPPConfigMap constructs the desired EPP ConfigMap.
+// buildDesiredEPPConfigMap constructs the desired EPP ConfigMap. It holds an
+// EndpointPickerConfig with one scheduling profile built from the stack's
+// strategy and weights.
 func buildDesiredEPPConfigMap(stack *v1alpha1.InferenceStack, name string) *corev1.ConfigMap {
 	strategy := stack.Spec.EPP.Strategy
 	if strategy == "" {
-		strategy = "least_queue"
+		strategy = v1alpha1.DefaultEPPStrategy
 	}
-
-	eppConfig := map[string]interface{}{
-		"strategy":  strategy,
-		"poolName":  stack.Name + "-pool",
-		"modelName": stack.Spec.ModelName,
+	scorers, ok := eppStrategyScorers[strategy]
+	if !ok {
+		scorers = eppStrategyScorers[v1alpha1.DefaultEPPStrategy]
 	}
 
-	if stack.Spec.EPP.Weights != nil {
-		eppConfig["weights"] = map[string]interface{}{
-			"queueDepth":     stack.Spec.EPP.Weights.QueueDepth,
-			"kvCache":        stack.Spec.EPP.Weights.KVCache,
-			"prefixAffinity": stack.Spec.EPP.Weights.PrefixAffinity,
+	plugins := []map[string]interface{}{{"type": "single-profile-handler"}}
+	profilePlugins := []map[string]interface{}{}
+	for _, scorer := range scorers {
+		plugins = append(plugins, map[string]interface{}{"type": scorer})
+		weight := int32(1)
+		if strategy == v1alpha1.EPPStrategyComposite {
+			weight = eppScorerWeight(stack.Spec.EPP.Weights, scorer)
 		}
+		profilePlugins = append(profilePlugins, map[string]interface{}{"pluginRef": scorer, "weight": weight})
+	}
+	plugins = append(plugins, map[string]interface{}{"type": "max-score-picker"})
+	profilePlugins = append(profilePlugins, map[string]interface{}{"pluginRef": "max-score-picker"})
+
+	eppConfig := map[string]interface{}{
+		"apiVersion": "inference.networking.x-k8s.io/v1alpha1",
+		"kind":       "EndpointPickerConfig",
+		"plugins":    plugins,
+		"schedulingProfiles": []map[string]interface{}{
+			{"name": "default", "plugins": profilePlugins},
+		},
 	}
 
 	configJSON, _ := json.MarshalIndent(eppConfig, "", "  ")
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: stack.Namespace,
-			Labels: map[string]string{
-				"app.kubernetes.io/managed-by": "ngf-console",
-				"ngf-console.f5.com/stack":     stack.Name,
-			},
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      stackChildLabels(stack, nil),
+			Annotations: stack.Spec.ExtraAnnotations,
 		},
 		Data: map[string]string{
-			"epp-config.json": string(configJSON),
+			eppConfigFile: string(configJSON),
 		},
 	}
 
@@ -219,13 +304,293 @@ func buildDesiredEPPConfigMap(stack *v1alpha1.InferenceStack, name string) *core
 	return cm
 }
 
-// reconcileAutoscaler creates or updates the KEDA ScaledObject child resource.
+// defaultEPPImage is the endpoint picker image used when the spec does not
+// set one. It is pinned so operator upgrades control EPP upgrades.
+const defaultEPPImage = "registry.k8s.io/gateway-api-inference-extension/epp:v1.0.0"
+
+// EPP container ports. The InferencePool's endpointPickerRef targets the
+// gRPC ext-proc port on the <stack>-epp Service.
+const (
+	eppGRPCPort    = 9002
+	eppHealthPort  = 9003
+	eppMetricsPort = 9090
+)
+
+// reconcileEPPDeployment creates or updates the EPP Deployment child resource.
+func (r *InferenceStackReconciler) reconcileEPPDeployment(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
+	name := stack.Name + "-epp"
+	if !childManaged(stack.Spec.ManageEPP) {
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
+
+	log := slog.With("child", "Deployment", "name", name)
+
+	desired := buildDesiredEPPDeployment(stack, name)
+
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if errors.IsNotFound(err) {
+		log.Info("creating EPP Deployment")
+		if err := r.Create(ctx, desired); err != nil {
+			log.Error("failed to create EPP Deployment", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get EPP Deployment", "error", err)
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	// Check if replicas, the pod's service account or pull secrets, the container, or metadata drifted
+	containerDrifted := len(existing.Spec.Template.Spec.Containers) == 0 ||
+		specDrifted(eppContainerFields(existing.Spec.Template.Spec.Containers[0]), eppContainerFields(desired.Spec.Template.Spec.Containers[0]))
+	if containerDrifted || specDrifted(existing.Spec.Replicas, desired.Spec.Replicas) ||
+		existing.Spec.Template.Spec.ServiceAccountName != desired.Spec.Template.Spec.ServiceAccountName ||
+		specDrifted(existing.Spec.Template.Spec.ImagePullSecrets, desired.Spec.Template.Spec.ImagePullSecrets) ||
+		metadataDrifted(existing, desired) || metadataDrifted(&existing.Spec.Template, &desired.Spec.Template) {
+		log.Info("EPP Deployment drifted, updating")
+		existing.Spec.Replicas = desired.Spec.Replicas
+		existing.Spec.Template.Spec.ServiceAccountName = desired.Spec.Template.Spec.ServiceAccountName
+		existing.Spec.Template.Spec.ImagePullSecrets = desired.Spec.Template.Spec.ImagePullSecrets
+		if containerDrifted {
+			existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
+		}
+		mergeMetadata(existing, desired)
+		mergeMetadata(&existing.Spec.Template, &desired.Spec.Template)
+		if err := r.Update(ctx, existing); err != nil {
+			log.Error("failed to update EPP Deployment", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	want := *desired.Spec.Replicas
+	ready := want == 0 || existing.Status.AvailableReplicas > 0
+	reason, msg := v1alpha1.ChildReasonInSync, "in sync"
+	if !ready {
+		reason, msg = v1alpha1.ChildReasonWaitingForPods, fmt.Sprintf("waiting for pods (%d/%d available)", existing.Status.AvailableReplicas, want)
+	}
+	return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: ready, Reason: reason, Message: msg}
+}
+
+// eppContainerFields returns the operator-owned fields of the EPP container,
+// ignoring fields the API server defaults.
+func eppContainerFields(c corev1.Container) map[string]any {
+	return map[string]any{
+		"image":     c.Image,
+		"args":      c.Args,
+		"ports":     c.Ports,
+		"resources": c.Resources,
+	}
+}
+
+// eppConfigDir is where the EPP container mounts the <stack>-epp-config
+// ConfigMap.
+const eppConfigDir = "/etc/epp"
+
+// buildDesiredEPPDeployment constructs the EPP Deployment. The EPP watches
+// the stack's InferencePool, schedules with the mounted EndpointPickerConfig
+// and serves ext-proc on eppGRPCPort.
+func buildDesiredEPPDeployment(stack *v1alpha1.InferenceStack, name string) *appsv1.Deployment {
+	epp := stack.Spec.EPP
+	image := defaultEPPImage
+	if epp.Image != "" {
+		image = epp.Image
+	}
+	replicas := int32(1)
+	if epp.Replicas != nil {
+		replicas = *epp.Replicas
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	if epp.Resources != nil {
+		resources = *epp.Resources.DeepCopy()
+	}
+
+	labels := stackChildLabels(stack, map[string]string{"app": name})
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      labels,
+			Annotations: stack.Spec.ExtraAnnotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: stack.Spec.ExtraAnnotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: epp.ServiceAccountName,
+					ImagePullSecrets:   stack.Spec.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:  "epp",
+							Image: image,
+							Args: []string{
+								"--pool-name=" + stack.Name + "-pool",
+								"--pool-namespace=" + stack.Namespace,
+								"--pool-group=" + inferencePoolGV.Group,
+								"--grpc-port=" + strconv.Itoa(eppGRPCPort),
+								"--grpc-health-port=" + strconv.Itoa(eppHealthPort),
+								"--metrics-port=" + strconv.Itoa(eppMetricsPort),
+								"--config-file=" + eppConfigDir + "/" + eppConfigFile,
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: "grpc", ContainerPort: eppGRPCPort, Protocol: corev1.ProtocolTCP},
+								{Name: "grpc-health", ContainerPort: eppHealthPort, Protocol: corev1.ProtocolTCP},
+								{Name: "metrics", ContainerPort: eppMetricsPort, Protocol: corev1.ProtocolTCP},
+							},
+							Resources: resources,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									GRPC: &corev1.GRPCAction{Port: eppHealthPort, Service: stringPtr("inference-extension")},
+								},
+								PeriodSeconds: 2,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "epp-config", MountPath: eppConfigDir, ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "epp-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: stack.Name + "-epp-config"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	setOwnerReference(stack, dep)
+
+	return dep
+}
+
+// reconcileEPPService creates or updates the <stack>-epp Service that the
+// InferencePool's endpointPickerRef points at.
+func (r *InferenceStackReconciler) reconcileEPPService(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
+	name := stack.Name + "-epp"
+	if !childManaged(stack.Spec.ManageEPP) {
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
+
+	log := slog.With("child", "Service", "name", name)
+
+	desired := buildDesiredEPPService(stack, name)
+
+	existing := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if errors.IsNotFound(err) {
+		log.Info("creating EPP Service")
+		if err := r.Create(ctx, desired); err != nil {
+			log.Error("failed to create EPP Service", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get EPP Service", "error", err)
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	// Update ports and selector if drifted; the cluster IP is left as allocated.
+	if specDrifted(existing.Spec.Ports, desired.Spec.Ports) || specDrifted(existing.Spec.Selector, desired.Spec.Selector) ||
+		metadataDrifted(existing, desired) {
+		log.Info("EPP Service drifted, updating")
+		existing.Spec.Ports = desired.Spec.Ports
+		existing.Spec.Selector = desired.Spec.Selector
+		mergeMetadata(existing, desired)
+		if err := r.Update(ctx, existing); err != nil {
+			log.Error("failed to update EPP Service", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// buildDesiredEPPService constructs the Service fronting the EPP pods.
+func buildDesiredEPPService(stack *v1alpha1.InferenceStack, name string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      stackChildLabels(stack, map[string]string{"app": name}),
+			Annotations: stack.Spec.ExtraAnnotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app": name},
+			Ports: []corev1.ServicePort{
+				{
+					Name:        "grpc-ext-proc",
+					Port:        eppGRPCPort,
+					TargetPort:  intstr.FromInt32(eppGRPCPort),
+					Protocol:    corev1.ProtocolTCP,
+					AppProtocol: stringPtr("http2"),
+				},
+			},
+		},
+	}
+
+	setOwnerReference(stack, svc)
+
+	return svc
+}
+
+// reconcileAutoscaler creates or updates the autoscaler child resource with
+// server-side apply: a KEDA ScaledObject, or a HorizontalPodAutoscaler when
+// autoscaling.backend is "hpa". The autoscaler of the other backend is removed.
 func (r *InferenceStackReconciler) reconcileAutoscaler(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
 	name := stack.Name + "-scaler"
+	useHPA := stack.Spec.Autoscaling != nil && stack.Spec.Autoscaling.Backend == v1alpha1.AutoscalerBackendHPA
+	kind := "ScaledObject"
+	if useHPA {
+		kind = "HorizontalPodAutoscaler"
+	}
+	if !childManaged(stack.Spec.ManageAutoscaler) {
+		return v1alpha1.ChildStatus{Kind: kind, Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
 	if stack.Spec.Autoscaling == nil {
-		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: kind, Name: name, Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
 
+	if useHPA {
+		stale := &unstructured.Unstructured{}
+		stale.SetGroupVersionKind(kedaScaledObjectGVK())
+		stale.SetName(name)
+		r.deleteStaleAutoscaler(ctx, stack, "ScaledObject", stale)
+		return r.reconcileHPA(ctx, stack, name)
+	}
+	stale := &autoscalingv2.HorizontalPodAutoscaler{}
+	stale.SetName(name)
+	r.deleteStaleAutoscaler(ctx, stack, "HorizontalPodAutoscaler", stale)
+
 	log := slog.With("child", "ScaledObject", "name", name)
 
 	desired := buildDesiredScaledObject(stack, name)
@@ -236,31 +601,30 @@ func (r *InferenceStackReconciler) reconcileAutoscaler(ctx context.Context, stac
 
 	if errors.IsNotFound(err) {
 		log.Info("creating ScaledObject")
-		if err := r.Create(ctx, desired); err != nil {
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to create ScaledObject", "error", err)
-			return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get ScaledObject", "error", err)
-		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
 	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
 	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
 
-	if specDrifted(desiredSpec, existingSpec) {
-		log.Info("ScaledObject spec drifted, updating")
-		existing.Object["spec"] = desired.Object["spec"]
-		if err := r.Update(ctx, existing); err != nil {
+	if fieldsDrifted(desiredSpec, existingSpec) || metadataDrifted(existing, desired) {
+		log.Info("ScaledObject drifted, applying")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to update ScaledObject", "error", err)
-			return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
 	}
 
-	return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Message: "in sync"}
+	return v1alpha1.ChildStatus{Kind: "ScaledObject", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
 }
 
 // buildDesiredScaledObject constructs the KEDA ScaledObject unstructured object.
@@ -269,10 +633,8 @@ func buildDesiredScaledObject(stack *v1alpha1.InferenceStack, name string) *unst
 	so.SetGroupVersionKind(kedaScaledObjectGVK())
 	so.SetName(name)
 	so.SetNamespace(stack.Namespace)
-	so.SetLabels(map[string]string{
-		"app.kubernetes.io/managed-by": "ngf-console",
-		"ngf-console.f5.com/stack":     stack.Name,
-	})
+	so.SetLabels(stackChildLabels(stack, nil))
+	so.SetAnnotations(stack.Spec.ExtraAnnotations)
 
 	setOwnerRef(so, stack)
 
@@ -283,36 +645,72 @@ func buildDesiredScaledObject(stack *v1alpha1.InferenceStack, name string) *unst
 
 	triggers := make([]interface{}, 0, len(stack.Spec.Autoscaling.Thresholds))
 	for _, t := range stack.Spec.Autoscaling.Thresholds {
-		triggers = append(triggers, map[string]interface{}{
-			"type": "prometheus",
-			"metadata": map[string]interface{}{
-				"serverAddress": "http://prometheus.monitoring:9090",
-				"metricName":    t.Metric,
-				"threshold":     strconv.Itoa(int(t.Target)),
-				"query":         fmt.Sprintf(`avg(%s{pool="%s"})`, t.Metric, stack.Name+"-pool"),
-			},
-		})
+		triggers = append(triggers, buildScaledObjectTrigger(stack, t))
 	}
 
 	so.Object["spec"] = map[string]interface{}{
 		"scaleTargetRef": map[string]interface{}{
 			"name": stack.Name + "-pool",
 		},
-		"minReplicaCount":  int64(stack.Spec.Pool.MinReplicas),
-		"maxReplicaCount":  int64(stack.Spec.Pool.MaxReplicas),
-		"cooldownPeriod":   cooldown,
-		"pollingInterval":  int64(15),
-		"triggers":         triggers,
+		"minReplicaCount": int64(stack.Spec.Pool.MinReplicas),
+		"maxReplicaCount": int64(stack.Spec.Pool.MaxReplicas),
+		"cooldownPeriod":  cooldown,
+		"pollingInterval": int64(15),
+		"triggers":        triggers,
 	}
 
 	return so
 }
 
-// reconcileHTTPRoute creates or updates the HTTPRoute child resource.
+// defaultPrometheusAddress is the Prometheus server KEDA queries when
+// autoscaling.prometheusAddress is not set.
+const defaultPrometheusAddress = "http://prometheus.monitoring:9090"
+
+// buildScaledObjectTrigger maps a scaling threshold to a KEDA trigger. cpu and
+// memory thresholds use KEDA's resource scalers on utilization, gpu
+// thresholds query the DCGM exporter's GPU utilization for the pool's pods,
+// and all others query the threshold's metric for the pool.
+func buildScaledObjectTrigger(stack *v1alpha1.InferenceStack, t v1alpha1.ThresholdSpec) map[string]interface{} {
+	target := strconv.Itoa(int(t.Target))
+	switch t.Type {
+	case v1alpha1.ThresholdTypeCPU, v1alpha1.ThresholdTypeMemory:
+		return map[string]interface{}{
+			"type":       t.Type,
+			"metricType": "Utilization",
+			"metadata":   map[string]interface{}{"value": target},
+		}
+	}
+
+	serverAddress := defaultPrometheusAddress
+	if stack.Spec.Autoscaling.PrometheusAddress != "" {
+		serverAddress = stack.Spec.Autoscaling.PrometheusAddress
+	}
+	metricName := t.Metric
+	query := fmt.Sprintf(`avg(%s{pool="%s"})`, t.Metric, stack.Name+"-pool")
+	if t.Type == v1alpha1.ThresholdTypeGPU {
+		metricName = "DCGM_FI_DEV_GPU_UTIL"
+		query = fmt.Sprintf(`avg(DCGM_FI_DEV_GPU_UTIL{namespace="%s",pod=~"%s-pool-.*"})`, stack.Namespace, stack.Name)
+	}
+	return map[string]interface{}{
+		"type": "prometheus",
+		"metadata": map[string]interface{}{
+			"serverAddress": serverAddress,
+			"metricName":    metricName,
+			"threshold":     target,
+			"query":         query,
+		},
+	}
+}
+
+// reconcileHTTPRoute creates or updates the HTTPRoute child resource with
+// server-side apply.
 func (r *InferenceStackReconciler) reconcileHTTPRoute(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
 	name := stack.Name + "-route"
+	if !childManaged(stack.Spec.ManageHTTPRoute) {
+		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
 	if stack.Spec.HTTPRoute == nil {
-		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
 
 	log := slog.With("child", "HTTPRoute", "name", name)
@@ -325,31 +723,30 @@ func (r *InferenceStackReconciler) reconcileHTTPRoute(ctx context.Context, stack
 
 	if errors.IsNotFound(err) {
 		log.Info("creating HTTPRoute")
-		if err := r.Create(ctx, desired); err != nil {
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to create HTTPRoute", "error", err)
-			return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get HTTPRoute", "error", err)
-		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
 	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
 	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
 
-	if specDrifted(desiredSpec, existingSpec) {
-		log.Info("HTTPRoute spec drifted, updating")
-		existing.Object["spec"] = desired.Object["spec"]
-		if err := r.Update(ctx, existing); err != nil {
+	if fieldsDrifted(desiredSpec, existingSpec) || metadataDrifted(existing, desired) {
+		log.Info("HTTPRoute drifted, applying")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
 			log.Error("failed to update HTTPRoute", "error", err)
-			return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
 	}
 
-	return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Message: "in sync"}
+	return v1alpha1.ChildStatus{Kind: "HTTPRoute", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
 }
 
 // buildDesiredHTTPRoute constructs the HTTPRoute unstructured object.
@@ -358,10 +755,8 @@ func buildDesiredHTTPRoute(stack *v1alpha1.InferenceStack, name string) *unstruc
 	hr.SetGroupVersionKind(httpRouteGVK())
 	hr.SetName(name)
 	hr.SetNamespace(stack.Namespace)
-	hr.SetLabels(map[string]string{
-		"app.kubernetes.io/managed-by": "ngf-console",
-		"ngf-console.f5.com/stack":     stack.Name,
-	})
+	hr.SetLabels(stackChildLabels(stack, nil))
+	hr.SetAnnotations(stack.Spec.ExtraAnnotations)
 
 	setOwnerRef(hr, stack)
 
@@ -390,7 +785,7 @@ func buildDesiredHTTPRoute(stack *v1alpha1.InferenceStack, name string) *unstruc
 			map[string]interface{}{
 				"backendRefs": []interface{}{
 					map[string]interface{}{
-						"group": "inference.networking.x-k8s.io",
+						"group": inferencePoolGVK().Group,
 						"kind":  "InferencePool",
 						"name":  stack.Name + "-pool",
 					},
@@ -410,8 +805,11 @@ func buildDesiredHTTPRoute(stack *v1alpha1.InferenceStack, name string) *unstruc
 // reconcileDCGMExporter creates or updates the DCGM DaemonSet child resource.
 func (r *InferenceStackReconciler) reconcileDCGMExporter(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
 	name := stack.Name + "-dcgm"
+	if !childManaged(stack.Spec.ManageDCGM) {
+		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
 	if stack.Spec.DCGM == nil || !stack.Spec.DCGM.Enabled {
-		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Message: "not configured"}
+		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
 	}
 
 	log := slog.With("child", "DaemonSet", "name", name)
@@ -425,53 +823,80 @@ func (r *InferenceStackReconciler) reconcileDCGMExporter(ctx context.Context, st
 		log.Info("creating DCGM DaemonSet")
 		if err := r.Create(ctx, desired); err != nil {
 			log.Error("failed to create DCGM DaemonSet", "error", err)
-			return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Message: fmt.Sprintf("create failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Message: "created"}
+		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
 	}
 	if err != nil {
 		log.Error("failed to get DCGM DaemonSet", "error", err)
-		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Message: fmt.Sprintf("get failed: %v", err)}
+		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
 	}
 
-	// Check if image drifted
-	if len(existing.Spec.Template.Spec.Containers) > 0 &&
-		existing.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image {
-		log.Info("DCGM DaemonSet image drifted, updating")
-		existing.Spec.Template.Spec.Containers[0].Image = desired.Spec.Template.Spec.Containers[0].Image
+	// Check if image, pull secrets, scheduling, or metadata drifted
+	imageDrifted := len(existing.Spec.Template.Spec.Containers) > 0 &&
+		existing.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image
+	existingPod, desiredPod := &existing.Spec.Template.Spec, &desired.Spec.Template.Spec
+	pullSecretsDrifted := specDrifted(existingPod.ImagePullSecrets, desiredPod.ImagePullSecrets)
+	schedulingDrifted := specDrifted(existingPod.NodeSelector, desiredPod.NodeSelector) ||
+		specDrifted(existingPod.Tolerations, desiredPod.Tolerations)
+	if imageDrifted || pullSecretsDrifted || schedulingDrifted || metadataDrifted(existing, desired) ||
+		metadataDrifted(&existing.Spec.Template, &desired.Spec.Template) {
+		log.Info("DCGM DaemonSet drifted, updating")
+		if imageDrifted {
+			existingPod.Containers[0].Image = desiredPod.Containers[0].Image
+		}
+		existingPod.ImagePullSecrets = desiredPod.ImagePullSecrets
+		existingPod.NodeSelector = desiredPod.NodeSelector
+		existingPod.Tolerations = desiredPod.Tolerations
+		mergeMetadata(existing, desired)
+		mergeMetadata(&existing.Spec.Template, &desired.Spec.Template)
 		if err := r.Update(ctx, existing); err != nil {
 			log.Error("failed to update DCGM DaemonSet", "error", err)
-			return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Message: fmt.Sprintf("update failed: %v", err)}
+			return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
 		}
-		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Message: "updated"}
+		return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
 	}
 
 	ready := existing.Status.NumberReady > 0
-	msg := "in sync"
+	reason, msg := v1alpha1.ChildReasonInSync, "in sync"
 	if !ready {
-		msg = fmt.Sprintf("waiting for pods (%d ready)", existing.Status.NumberReady)
+		reason, msg = v1alpha1.ChildReasonWaitingForPods, fmt.Sprintf("waiting for pods (%d ready)", existing.Status.NumberReady)
 	}
-	return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: ready, Message: msg}
+	return v1alpha1.ChildStatus{Kind: "DaemonSet", Name: name, Ready: ready, Reason: reason, Message: msg}
 }
 
-// buildDesiredDCGMDaemonSet constructs the DCGM exporter DaemonSet.
+// buildDesiredDCGMDaemonSet constructs the DCGM exporter DaemonSet. Unless
+// the spec sets them, the pods are scheduled only on GPU nodes and tolerate
+// the nvidia.com/gpu taint.
 func buildDesiredDCGMDaemonSet(stack *v1alpha1.InferenceStack, name string) *appsv1.DaemonSet {
 	image := "nvcr.io/nvidia/k8s/dcgm-exporter:3.3.5-3.4.1-ubuntu22.04"
 	if stack.Spec.DCGM.Image != "" {
 		image = stack.Spec.DCGM.Image
 	}
 
-	labels := map[string]string{
-		"app.kubernetes.io/managed-by": "ngf-console",
-		"ngf-console.f5.com/stack":     stack.Name,
-		"app":                          name,
+	nodeSelector := stack.Spec.DCGM.NodeSelector
+	if nodeSelector == nil {
+		nodeSelector = map[string]string{gpuPresentLabel: "true"}
+	} else if len(nodeSelector) == 0 {
+		nodeSelector = nil
 	}
+	tolerations := stack.Spec.DCGM.Tolerations
+	if tolerations == nil {
+		tolerations = []corev1.Toleration{
+			{Key: gpuResource, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		}
+	} else if len(tolerations) == 0 {
+		tolerations = nil
+	}
+
+	labels := stackChildLabels(stack, map[string]string{"app": name})
 
 	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: stack.Namespace,
-			Labels:    labels,
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      labels,
+			Annotations: stack.Spec.ExtraAnnotations,
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{
@@ -479,9 +904,13 @@ func buildDesiredDCGMDaemonSet(stack *v1alpha1.InferenceStack, name string) *app
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: stack.Spec.ExtraAnnotations,
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: stack.Spec.ImagePullSecrets,
+					NodeSelector:     nodeSelector,
+					Tolerations:      tolerations,
 					Containers: []corev1.Container{
 						{
 							Name:  "dcgm-exporter",
@@ -547,14 +976,61 @@ func setOwnerRef(obj *unstructured.Unstructured, stack *v1alpha1.InferenceStack)
 
 func boolPtr(b bool) *bool { return &b }
 
+func stringPtr(s string) *string { return &s }
+
+// stackChildLabels returns the labels for a child of stack: the user's
+// extraLabels overlaid with the operator-managed labels and any child-specific
+// labels, so users cannot override managed-by or selector labels.
+func stackChildLabels(stack *v1alpha1.InferenceStack, childLabels map[string]string) map[string]string {
+	managed := mergeLabels(map[string]string{
+		"app.kubernetes.io/managed-by": "ngf-console",
+		"ngf-console.f5.com/stack":     stack.Name,
+	}, childLabels)
+	return mergeLabels(stack.Spec.ExtraLabels, managed)
+}
+
+// childDisabledMessage is the status message for children the user has opted
+// out of via the spec's manage* flags.
+const childDisabledMessage = "disabled"
+
+// childManaged reports whether the operator should manage a child given its
+// optional manage* flag. Unset flags default to managed.
+func childManaged(flag *bool) bool {
+	return flag == nil || *flag
+}
+
 // GVK helpers
 
-func inferencePoolGVK() schema.GroupVersionKind {
-	return schema.GroupVersionKind{
-		Group:   "inference.networking.k8s.io",
-		Version: "v1",
-		Kind:    "InferencePool",
+// inferencePoolCandidates lists the InferencePool group/versions probed during
+// auto-detection, in order of preference.
+var inferencePoolCandidates = []schema.GroupVersion{
+	{Group: "inference.networking.k8s.io", Version: "v1"},
+	{Group: "inference.networking.x-k8s.io", Version: "v1alpha2"},
+}
+
+var (
+	inferencePoolGV     = inferencePoolCandidates[0]
+	inferencePoolPinned bool
+)
+
+// SetInferencePoolGroupVersion pins the InferencePool group/version
+// (e.g. "inference.networking.x-k8s.io/v1alpha2"), overriding auto-detection.
+// Must be called before SetupWithManager.
+func SetInferencePoolGroupVersion(gv string) error {
+	parsed, err := schema.ParseGroupVersion(gv)
+	if err != nil {
+		return fmt.Errorf("parsing inference pool group/version %q: %w", gv, err)
+	}
+	if parsed.Group == "" || parsed.Version == "" {
+		return fmt.Errorf("inference pool group/version %q must be of the form <group>/<version>", gv)
 	}
+	inferencePoolGV = parsed
+	inferencePoolPinned = true
+	return nil
+}
+
+func inferencePoolGVK() schema.GroupVersionKind {
+	return inferencePoolGV.WithKind("InferencePool")
 }
 
 func kedaScaledObjectGVK() schema.GroupVersionKind {
@@ -2,16 +2,19 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -27,7 +30,8 @@ const (
 // InferenceStackReconciler reconciles InferenceStack objects.
 type InferenceStackReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // Reconcile handles reconciliation of InferenceStack resources.
@@ -86,13 +90,21 @@ func (r *InferenceStackReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	var children []v1alpha1.ChildStatus
 
 	children = append(children, r.reconcileInferencePool(ctx, &stack))
+	children = append(children, r.reconcileServingDeployment(ctx, &stack))
 	children = append(children, r.reconcileEPPConfig(ctx, &stack))
+	children = append(children, r.reconcileEPPDeployment(ctx, &stack))
+	children = append(children, r.reconcileEPPService(ctx, &stack))
 	children = append(children, r.reconcileAutoscaler(ctx, &stack))
 	children = append(children, r.reconcileHTTPRoute(ctx, &stack))
 	children = append(children, r.reconcileDCGMExporter(ctx, &stack))
+	children = append(children, r.reconcileMetricsService(ctx, &stack))
+	children = append(children, r.reconcileServiceMonitor(ctx, &stack))
+
+	recordChildEvents(r.Recorder, &stack, stack.Status.Children, children)
 
 	// 8. Compute aggregate phase
 	phase := computePhase(children)
+	recordPhaseEvent(r.Recorder, &stack, stack.Status.Phase, phase)
 
 	// 9. Update parent status
 	now := metav1.Now()
@@ -133,15 +145,30 @@ func (r *InferenceStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.InferenceStack{}).
 		Owns(&corev1.ConfigMap{}).
-		Owns(&appsv1.DaemonSet{})
-
-	// Conditionally watch InferencePool if the CRD is installed.
-	inferencePoolGVK := schema.GroupVersionKind{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferencePool"}
-	if crdExists(mgr, inferencePoolGVK) {
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.DaemonSet{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{})
+
+	// Conditionally watch InferencePool if the CRD is installed. A pinned
+	// group/version must be served; otherwise the first served candidate wins.
+	if inferencePoolPinned {
+		if !crdExists(mgr, inferencePoolGVK()) {
+			return fmt.Errorf("pinned InferencePool group/version %s is not served by the API server", inferencePoolGV.String())
+		}
+	} else {
+		for _, gv := range inferencePoolCandidates {
+			if crdExists(mgr, gv.WithKind("InferencePool")) {
+				inferencePoolGV = gv
+				break
+			}
+		}
+	}
+	if crdExists(mgr, inferencePoolGVK()) {
 		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(inferencePoolGVK)
+		obj.SetGroupVersionKind(inferencePoolGVK())
 		builder = builder.Watches(obj, ownerHandler)
-		slog.Info("watching InferencePool CRD")
+		slog.Info("watching InferencePool CRD", "groupVersion", inferencePoolGV.String())
 	} else {
 		slog.Warn("InferencePool CRD not found, skipping watch")
 	}
@@ -168,5 +195,15 @@ func (r *InferenceStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		slog.Warn("HTTPRoute CRD not found, skipping watch")
 	}
 
+	// Conditionally watch Prometheus Operator ServiceMonitor if the CRD is installed.
+	if crdExists(mgr, serviceMonitorGVK()) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(serviceMonitorGVK())
+		builder = builder.Watches(obj, ownerHandler)
+		slog.Info("watching ServiceMonitor CRD")
+	} else {
+		slog.Warn("ServiceMonitor CRD not found, skipping watch")
+	}
+
 	return builder.Complete(r)
 }
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// reconcileHPA creates or updates, with server-side apply, the
+// HorizontalPodAutoscaler that scales the model server Deployment when
+// autoscaling.backend is "hpa".
+func (r *InferenceStackReconciler) reconcileHPA(ctx context.Context, stack *v1alpha1.InferenceStack, name string) v1alpha1.ChildStatus {
+	log := slog.With("child", "HorizontalPodAutoscaler", "name", name)
+
+	desired := buildDesiredHPA(stack, name)
+
+	existing := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if errors.IsNotFound(err) {
+		log.Info("creating HorizontalPodAutoscaler")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to create HorizontalPodAutoscaler", "error", err)
+			return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get HorizontalPodAutoscaler", "error", err)
+		return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	if specDrifted(hpaSpecFields(existing.Spec), hpaSpecFields(desired.Spec)) || metadataDrifted(existing, desired) {
+		log.Info("HorizontalPodAutoscaler drifted, applying")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to update HorizontalPodAutoscaler", "error", err)
+			return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	return v1alpha1.ChildStatus{Kind: "HorizontalPodAutoscaler", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// buildDesiredHPA constructs the HorizontalPodAutoscaler for the model server
+// Deployment. Each threshold becomes one metric: cpu and memory thresholds
+// target average utilization of the pods' requests, and prometheus and gpu
+// thresholds target a per-pod average served by a custom metrics adapter
+// (e.g. prometheus-adapter). The cooldown is the scale-down stabilization
+// window.
+func buildDesiredHPA(stack *v1alpha1.InferenceStack, name string) *autoscalingv2.HorizontalPodAutoscaler {
+	// HPA needs at least one replica and a maximum no lower than the minimum.
+	minReplicas := max(stack.Spec.Pool.MinReplicas, 1)
+	maxReplicas := stack.Spec.Pool.MaxReplicas
+	if maxReplicas < minReplicas {
+		maxReplicas = max(minReplicas, stack.Spec.Pool.Replicas)
+	}
+
+	cooldown := int32(300)
+	if stack.Spec.Autoscaling.CooldownSeconds > 0 {
+		cooldown = stack.Spec.Autoscaling.CooldownSeconds
+	}
+
+	metrics := make([]autoscalingv2.MetricSpec, 0, len(stack.Spec.Autoscaling.Thresholds))
+	for _, t := range stack.Spec.Autoscaling.Thresholds {
+		metrics = append(metrics, buildHPAMetric(t))
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      stackChildLabels(stack, nil),
+			Annotations: stack.Spec.ExtraAnnotations,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       stack.Name + "-pool",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics:     metrics,
+			Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &cooldown},
+			},
+		},
+	}
+
+	setOwnerReference(stack, hpa)
+
+	return hpa
+}
+
+// buildHPAMetric maps a scaling threshold to an HPA metric.
+func buildHPAMetric(t v1alpha1.ThresholdSpec) autoscalingv2.MetricSpec {
+	switch t.Type {
+	case v1alpha1.ThresholdTypeCPU, v1alpha1.ThresholdTypeMemory:
+		target := t.Target
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceName(t.Type),
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &target,
+				},
+			},
+		}
+	}
+
+	metricName := t.Metric
+	if t.Type == v1alpha1.ThresholdTypeGPU {
+		metricName = "DCGM_FI_DEV_GPU_UTIL"
+	}
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: metricName},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: resource.NewQuantity(int64(t.Target), resource.DecimalSI),
+			},
+		},
+	}
+}
+
+// hpaSpecFields returns the operator-owned fields of an HPA spec, ignoring
+// the scaling policies the API server defaults into the behavior.
+func hpaSpecFields(s autoscalingv2.HorizontalPodAutoscalerSpec) map[string]any {
+	var window *int32
+	if s.Behavior != nil && s.Behavior.ScaleDown != nil {
+		window = s.Behavior.ScaleDown.StabilizationWindowSeconds
+	}
+	return map[string]any{
+		"scaleTargetRef":         s.ScaleTargetRef,
+		"minReplicas":            s.MinReplicas,
+		"maxReplicas":            s.MaxReplicas,
+		"metrics":                s.Metrics,
+		"scaleDownStabilization": window,
+	}
+}
+
+// deleteStaleAutoscaler removes the stack's autoscaler of the backend not in
+// use, so a ScaledObject and a HorizontalPodAutoscaler never scale the pool
+// together after the backend changes. Objects the stack does not control are
+// left alone, as are kinds the API server does not serve.
+func (r *InferenceStackReconciler) deleteStaleAutoscaler(ctx context.Context, stack *v1alpha1.InferenceStack, kind string, obj client.Object) {
+	log := slog.With("child", kind, "name", obj.GetName())
+	err := r.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: stack.Namespace}, obj)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return
+	}
+	if err != nil {
+		log.Warn("failed to get stale autoscaler", "error", err)
+		return
+	}
+	if !metav1.IsControlledBy(obj, stack) {
+		return
+	}
+	log.Info("deleting autoscaler of previous backend")
+	if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		log.Warn("failed to delete stale autoscaler", "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// Named container ports the metrics Service exposes. Every stack pod that
+// serves Prometheus metrics on a separate port names it "metrics" (EPP, DCGM
+// exporter, Triton); vLLM and TGI serve /metrics on their "serving" port.
+const (
+	metricsPortName = "metrics"
+	servingPortName = "serving"
+)
+
+func serviceMonitorGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+}
+
+// serviceMonitorEnabled reports whether the stack asks for a ServiceMonitor.
+func serviceMonitorEnabled(stack *v1alpha1.InferenceStack) bool {
+	return stack.Spec.ServiceMonitor != nil && stack.Spec.ServiceMonitor.Enabled
+}
+
+// scrapesServingPort reports whether the stack's model server serves
+// Prometheus metrics on its serving port.
+func scrapesServingPort(stack *v1alpha1.InferenceStack) bool {
+	switch stack.Spec.ServingBackend {
+	case "triton", "ollama":
+		return false
+	default: // vllm, tgi
+		return true
+	}
+}
+
+// reconcileMetricsService creates or updates, with server-side apply, the
+// headless Service the ServiceMonitor discovers the stack's pods through.
+func (r *InferenceStackReconciler) reconcileMetricsService(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
+	name := stack.Name + "-metrics"
+	if !serviceMonitorEnabled(stack) {
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
+	}
+
+	log := slog.With("child", "Service", "name", name)
+
+	desired := buildDesiredMetricsService(stack, name)
+
+	existing := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if errors.IsNotFound(err) {
+		log.Info("creating metrics Service")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to create metrics Service", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get metrics Service", "error", err)
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	if fieldsDrifted(desired.Spec, existing.Spec) || metadataDrifted(existing, desired) {
+		log.Info("metrics Service drifted, applying")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to update metrics Service", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	return v1alpha1.ChildStatus{Kind: "Service", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// buildDesiredMetricsService constructs a headless Service selecting every
+// pod of the stack (model server, EPP, and DCGM exporter) by the stack label.
+// Its ports target container ports by name, so each pod is listed only for
+// the ports it has.
+func buildDesiredMetricsService(stack *v1alpha1.InferenceStack, name string) *corev1.Service {
+	ports := []corev1.ServicePort{
+		{Name: metricsPortName, Port: eppMetricsPort, TargetPort: intstr.FromString(metricsPortName), Protocol: corev1.ProtocolTCP},
+	}
+	if scrapesServingPort(stack) {
+		port := int32(servingPort(stack.Spec.ServingBackend))
+		ports = append(ports, corev1.ServicePort{Name: servingPortName, Port: port, TargetPort: intstr.FromString(servingPortName), Protocol: corev1.ProtocolTCP})
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      stackChildLabels(stack, map[string]string{"app": name}),
+			Annotations: stack.Spec.ExtraAnnotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+			Selector: map[string]string{
+				"app.kubernetes.io/managed-by": "ngf-console",
+				"ngf-console.f5.com/stack":     stack.Name,
+			},
+			Ports: ports,
+		},
+	}
+
+	setOwnerReference(stack, svc)
+
+	return svc
+}
+
+// reconcileServiceMonitor creates or updates, with server-side apply, the
+// Prometheus Operator ServiceMonitor that scrapes the metrics Service. When
+// the ServiceMonitor CRD is not installed the child is skipped.
+func (r *InferenceStackReconciler) reconcileServiceMonitor(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
+	name := stack.Name + "-monitor"
+	if !serviceMonitorEnabled(stack) {
+		return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: true, Reason: v1alpha1.ChildReasonNotConfigured, Message: "not configured"}
+	}
+
+	log := slog.With("child", "ServiceMonitor", "name", name)
+
+	desired := buildDesiredServiceMonitor(stack, name)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(serviceMonitorGVK())
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if meta.IsNoMatchError(err) {
+		return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCRDNotInstalled, Message: "ServiceMonitor CRD not installed"}
+	}
+	if errors.IsNotFound(err) {
+		log.Info("creating ServiceMonitor")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to create ServiceMonitor", "error", err)
+			return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get ServiceMonitor", "error", err)
+		return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	desiredSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+
+	if fieldsDrifted(desiredSpec, existingSpec) || metadataDrifted(existing, desired) {
+		log.Info("ServiceMonitor drifted, applying")
+		if err := applyChild(ctx, r.Client, desired); err != nil {
+			log.Error("failed to update ServiceMonitor", "error", err)
+			return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	return v1alpha1.ChildStatus{Kind: "ServiceMonitor", Name: name, Ready: true, Reason: v1alpha1.ChildReasonInSync, Message: "in sync"}
+}
+
+// buildDesiredServiceMonitor constructs the ServiceMonitor selecting the
+// stack's metrics Service. It scrapes the "metrics" port, plus the "serving"
+// port for backends that serve metrics there. serviceMonitor.labels are added
+// so a Prometheus that selects ServiceMonitors by label picks it up.
+func buildDesiredServiceMonitor(stack *v1alpha1.InferenceStack, name string) *unstructured.Unstructured {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(serviceMonitorGVK())
+	sm.SetName(name)
+	sm.SetNamespace(stack.Namespace)
+	sm.SetLabels(mergeLabels(stack.Spec.ServiceMonitor.Labels, stackChildLabels(stack, nil)))
+	sm.SetAnnotations(stack.Spec.ExtraAnnotations)
+
+	setOwnerRef(sm, stack)
+
+	portNames := []string{metricsPortName}
+	if scrapesServingPort(stack) {
+		portNames = append(portNames, servingPortName)
+	}
+	endpoints := make([]interface{}, 0, len(portNames))
+	for _, port := range portNames {
+		endpoint := map[string]interface{}{"port": port, "path": "/metrics"}
+		if stack.Spec.ServiceMonitor.Interval != "" {
+			endpoint["interval"] = stack.Spec.ServiceMonitor.Interval
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	sm.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"app":                      stack.Name + "-metrics",
+				"ngf-console.f5.com/stack": stack.Name,
+			},
+		},
+		"endpoints": endpoints,
+	}
+
+	return sm
+}
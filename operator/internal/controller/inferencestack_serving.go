@@ -0,0 +1,369 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// Default model server images per serving backend, used when neither the
+// spec's serving.image nor SetServingImages sets one. They are pinned so
+// operator upgrades control model server upgrades.
+const (
+	defaultVLLMImage   = "vllm/vllm-openai:v0.6.3"
+	defaultTGIImage    = "ghcr.io/huggingface/text-generation-inference:2.4.0"
+	defaultTritonImage = "nvcr.io/nvidia/tritonserver:24.10-py3"
+	defaultOllamaImage = "ollama/ollama:0.3.14"
+)
+
+// builtinServingImages is the pinned model server image per serving backend.
+var builtinServingImages = map[string]string{
+	"vllm":   defaultVLLMImage,
+	"tgi":    defaultTGIImage,
+	"triton": defaultTritonImage,
+	"ollama": defaultOllamaImage,
+}
+
+// servingImages is the model server image per serving backend for stacks
+// that do not set serving.image.
+var servingImages = maps.Clone(builtinServingImages)
+
+// SetServingImages overrides the default model server images from
+// comma-separated backend=image pairs (e.g.
+// "vllm=registry.example.com/vllm-openai:v0.6.3,tgi=registry.example.com/tgi:2.4.0").
+// Backends not listed keep their defaults. Must be called before
+// SetupWithManager.
+func SetServingImages(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	images := maps.Clone(servingImages)
+	for _, pair := range strings.Split(s, ",") {
+		backend, image, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || image == "" {
+			return fmt.Errorf("serving image override %q must be backend=image", pair)
+		}
+		if _, known := images[backend]; !known {
+			return fmt.Errorf("unknown serving backend %q (want vllm, tgi, triton, or ollama)", backend)
+		}
+		images[backend] = image
+	}
+	servingImages = images
+	return nil
+}
+
+// Triton serves HTTP, gRPC, and metrics on separate ports. The InferencePool
+// targets the gRPC port (see servingPort); the HTTP port serves health checks.
+const (
+	tritonHTTPPort    = 8000
+	tritonMetricsPort = 8002
+)
+
+const (
+	// gpuResource is the extended resource for whole NVIDIA GPUs.
+	gpuResource = "nvidia.com/gpu"
+	// gpuProductLabel is set on GPU nodes by GPU feature discovery.
+	gpuProductLabel = "nvidia.com/gpu.product"
+	// gpuPresentLabel is set to "true" on GPU nodes by GPU feature discovery.
+	gpuPresentLabel = "nvidia.com/gpu.present"
+)
+
+// gpuProducts maps the spec's GPU types to the nvidia.com/gpu.product label
+// values GPU feature discovery sets for them. Types not listed are matched
+// against the label as-is.
+var gpuProducts = map[string][]string{
+	"A100": {"NVIDIA-A100-SXM4-40GB", "NVIDIA-A100-SXM4-80GB", "NVIDIA-A100-PCIE-40GB", "NVIDIA-A100-80GB-PCIe"},
+	"H100": {"NVIDIA-H100-80GB-HBM3", "NVIDIA-H100-PCIe", "NVIDIA-H100-NVL"},
+	"L40S": {"NVIDIA-L40S"},
+	"T4":   {"Tesla-T4"},
+}
+
+// reconcileServingDeployment creates or updates the model server Deployment.
+// It is named <stack>-pool, the scale target of the stack's autoscaler, and
+// its pods carry the pool selector so the InferencePool routes to them.
+func (r *InferenceStackReconciler) reconcileServingDeployment(ctx context.Context, stack *v1alpha1.InferenceStack) v1alpha1.ChildStatus {
+	name := stack.Name + "-pool"
+	if !childManaged(stack.Spec.ManageServing) {
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonDisabled, Message: childDisabledMessage}
+	}
+
+	log := slog.With("child", "Deployment", "name", name)
+
+	desired := buildDesiredServingDeployment(stack, name)
+
+	existing := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: stack.Namespace}, existing)
+
+	if errors.IsNotFound(err) {
+		log.Info("creating model server Deployment")
+		if err := r.Create(ctx, desired); err != nil {
+			log.Error("failed to create model server Deployment", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonCreateFailed, Message: fmt.Sprintf("create failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonCreated, Message: "created"}
+	}
+	if err != nil {
+		log.Error("failed to get model server Deployment", "error", err)
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonGetFailed, Message: fmt.Sprintf("get failed: %v", err)}
+	}
+
+	// An autoscaler owns the replica count whenever autoscaling is configured,
+	// including one the user deploys with manageAutoscaler: false.
+	ownReplicas := stack.Spec.Autoscaling == nil
+
+	existingPod, desiredPod := &existing.Spec.Template.Spec, &desired.Spec.Template.Spec
+	containerDrifted := len(existingPod.Containers) == 0 ||
+		specDrifted(servingContainerFields(existingPod.Containers[0]), servingContainerFields(desiredPod.Containers[0]))
+	if containerDrifted || (ownReplicas && specDrifted(existing.Spec.Replicas, desired.Spec.Replicas)) ||
+		specDrifted(existingPod.Affinity, desiredPod.Affinity) ||
+		specDrifted(existingPod.Tolerations, desiredPod.Tolerations) ||
+		specDrifted(existingPod.ImagePullSecrets, desiredPod.ImagePullSecrets) ||
+		metadataDrifted(existing, desired) || metadataDrifted(&existing.Spec.Template, &desired.Spec.Template) {
+		log.Info("model server Deployment drifted, updating")
+		if ownReplicas {
+			existing.Spec.Replicas = desired.Spec.Replicas
+		}
+		existingPod.Affinity = desiredPod.Affinity
+		existingPod.Tolerations = desiredPod.Tolerations
+		existingPod.ImagePullSecrets = desiredPod.ImagePullSecrets
+		if containerDrifted {
+			existingPod.Containers = desiredPod.Containers
+		}
+		mergeMetadata(existing, desired)
+		mergeMetadata(&existing.Spec.Template, &desired.Spec.Template)
+		if err := r.Update(ctx, existing); err != nil {
+			log.Error("failed to update model server Deployment", "error", err)
+			return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: false, Reason: v1alpha1.ChildReasonUpdateFailed, Message: fmt.Sprintf("update failed: %v", err)}
+		}
+		return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: true, Reason: v1alpha1.ChildReasonUpdated, Message: "updated"}
+	}
+
+	want := int32(1)
+	if existing.Spec.Replicas != nil {
+		want = *existing.Spec.Replicas
+	}
+	ready := want == 0 || existing.Status.AvailableReplicas > 0
+	reason, msg := v1alpha1.ChildReasonInSync, "in sync"
+	if !ready {
+		reason, msg = v1alpha1.ChildReasonWaitingForPods, fmt.Sprintf("waiting for pods (%d/%d available)", existing.Status.AvailableReplicas, want)
+	}
+	return v1alpha1.ChildStatus{Kind: "Deployment", Name: name, Ready: ready, Reason: reason, Message: msg}
+}
+
+// servingContainerFields returns the operator-owned fields of the model
+// server container, ignoring fields the API server defaults.
+func servingContainerFields(c corev1.Container) map[string]any {
+	return map[string]any{
+		"image":     c.Image,
+		"command":   c.Command,
+		"args":      c.Args,
+		"env":       c.Env,
+		"ports":     c.Ports,
+		"resources": c.Resources,
+	}
+}
+
+// poolSelector returns the pod labels the InferencePool selects.
+func poolSelector(stack *v1alpha1.InferenceStack) map[string]string {
+	if stack.Spec.Pool.Selector != nil {
+		return stack.Spec.Pool.Selector
+	}
+	return map[string]string{"app": stack.Name}
+}
+
+// buildDesiredServingDeployment constructs the model server Deployment for
+// the stack's serving backend. Pods request the pool's GPUs (or MIG slices),
+// are scheduled onto nodes of the pool's GPU type, and serve on servingPort.
+func buildDesiredServingDeployment(stack *v1alpha1.InferenceStack, name string) *appsv1.Deployment {
+	pool := stack.Spec.Pool
+	replicas := pool.Replicas
+	selector := poolSelector(stack)
+	labels := stackChildLabels(stack, selector)
+
+	container := servingContainer(stack)
+	var serving v1alpha1.ServingSpec
+	if stack.Spec.Serving != nil {
+		serving = *stack.Spec.Serving.DeepCopy()
+	}
+	if serving.Image != "" {
+		container.Image = serving.Image
+	}
+	container.Args = append(container.Args, serving.ExtraArgs...)
+	container.Env = append(container.Env, serving.Env...)
+	if serving.Resources != nil {
+		container.Resources = *serving.Resources
+	}
+	if resourceName, count := gpuRequest(pool); count > 0 {
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[resourceName] = *resource.NewQuantity(int64(count), resource.DecimalSI)
+	}
+
+	podSpec := corev1.PodSpec{
+		ImagePullSecrets: stack.Spec.ImagePullSecrets,
+		Affinity:         gpuAffinity(pool.GPUType),
+		Containers:       []corev1.Container{container},
+	}
+	if pool.GPUCount > 0 {
+		podSpec.Tolerations = []corev1.Toleration{
+			{Key: gpuResource, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		}
+	}
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   stack.Namespace,
+			Labels:      labels,
+			Annotations: stack.Spec.ExtraAnnotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: maps.Clone(selector),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: stack.Spec.ExtraAnnotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	setOwnerReference(stack, dep)
+
+	return dep
+}
+
+// servingContainer returns the backend's default model server container:
+// image, arguments, and ports for serving stack.Spec.ModelName.
+func servingContainer(stack *v1alpha1.InferenceStack) corev1.Container {
+	port := int32(servingPort(stack.Spec.ServingBackend))
+	pool := stack.Spec.Pool
+	// Shard across whole GPUs only; a MIG slice cannot be split further.
+	shards := int32(1)
+	if pool.MIGProfile == "" && pool.GPUCount > 1 {
+		shards = pool.GPUCount
+	}
+
+	c := corev1.Container{
+		Name:  "server",
+		Ports: []corev1.ContainerPort{{Name: "serving", ContainerPort: port, Protocol: corev1.ProtocolTCP}},
+	}
+	httpProbe := func(path string, port int32) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: path, Port: intstr.FromInt32(port)},
+			},
+			PeriodSeconds:    10,
+			FailureThreshold: 3,
+		}
+	}
+
+	switch stack.Spec.ServingBackend {
+	case "tgi":
+		c.Image = servingImages["tgi"]
+		c.Args = []string{"--model-id=" + stack.Spec.ModelName, "--port=" + strconv.Itoa(int(port))}
+		if shards > 1 {
+			c.Args = append(c.Args, "--num-shard="+strconv.Itoa(int(shards)))
+		}
+		if stack.Spec.ModelVersion != "" {
+			c.Args = append(c.Args, "--revision="+stack.Spec.ModelVersion)
+		}
+		c.ReadinessProbe = httpProbe("/health", port)
+	case "triton":
+		// ModelName is the model repository path for Triton.
+		c.Image = servingImages["triton"]
+		c.Command = []string{"tritonserver"}
+		c.Args = []string{
+			"--model-repository=" + stack.Spec.ModelName,
+			"--grpc-port=" + strconv.Itoa(int(port)),
+			"--http-port=" + strconv.Itoa(tritonHTTPPort),
+			"--metrics-port=" + strconv.Itoa(tritonMetricsPort),
+		}
+		c.Ports = append(c.Ports,
+			corev1.ContainerPort{Name: "http", ContainerPort: tritonHTTPPort, Protocol: corev1.ProtocolTCP},
+			corev1.ContainerPort{Name: "metrics", ContainerPort: tritonMetricsPort, Protocol: corev1.ProtocolTCP},
+		)
+		c.ReadinessProbe = httpProbe("/v2/health/ready", tritonHTTPPort)
+	case "ollama":
+		// Ollama pulls models at runtime rather than taking one as an argument.
+		model := stack.Spec.ModelName
+		if stack.Spec.ModelVersion != "" {
+			model += ":" + stack.Spec.ModelVersion
+		}
+		c.Image = servingImages["ollama"]
+		c.Env = []corev1.EnvVar{{Name: "OLLAMA_HOST", Value: "0.0.0.0:" + strconv.Itoa(int(port))}}
+		c.Lifecycle = &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c",
+					"until ollama list >/dev/null 2>&1; do sleep 1; done; ollama pull " + shellQuote(model)}},
+			},
+		}
+		c.ReadinessProbe = httpProbe("/", port)
+	default: // vllm
+		c.Image = servingImages["vllm"]
+		c.Args = []string{"--model=" + stack.Spec.ModelName, "--port=" + strconv.Itoa(int(port))}
+		if shards > 1 {
+			c.Args = append(c.Args, "--tensor-parallel-size="+strconv.Itoa(int(shards)))
+		}
+		if stack.Spec.ModelVersion != "" {
+			c.Args = append(c.Args, "--revision="+stack.Spec.ModelVersion)
+		}
+		c.ReadinessProbe = httpProbe("/health", port)
+	}
+	return c
+}
+
+// gpuRequest returns the extended resource and count each model server pod
+// requests: MIG slices of the pool's profile, or whole GPUs.
+func gpuRequest(pool v1alpha1.InferencePoolSpec) (corev1.ResourceName, int32) {
+	if pool.MIGProfile != "" {
+		return corev1.ResourceName("nvidia.com/" + pool.MIGProfile), pool.GPUCount
+	}
+	return gpuResource, pool.GPUCount
+}
+
+// gpuAffinity requires nodes whose nvidia.com/gpu.product label matches
+// gpuType. Returns nil when no GPU type is set.
+func gpuAffinity(gpuType string) *corev1.Affinity {
+	if gpuType == "" {
+		return nil
+	}
+	products, ok := gpuProducts[strings.ToUpper(gpuType)]
+	if !ok {
+		products = []string{gpuType}
+	}
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: gpuProductLabel, Operator: corev1.NodeSelectorOpIn, Values: products},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// ServingDefaultsConfigMap is the name of the ConfigMap the operator
+// publishes its default model server images in.
+const ServingDefaultsConfigMap = "ngf-console-serving-defaults"
+
+// servingDefaultsOverriddenKey lists, comma-separated, the backends whose
+// image comes from SetServingImages rather than the pinned default.
+const servingDefaultsOverriddenKey = "overridden"
+
+// buildServingDefaultsConfigMap constructs the ConfigMap holding one key per
+// serving backend with the image the operator deploys for it.
+func buildServingDefaultsConfigMap(namespace string) *corev1.ConfigMap {
+	data := maps.Clone(servingImages)
+	var overridden []string
+	for backend, image := range servingImages {
+		if image != builtinServingImages[backend] {
+			overridden = append(overridden, backend)
+		}
+	}
+	slices.Sort(overridden)
+	data[servingDefaultsOverriddenKey] = strings.Join(overridden, ",")
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServingDefaultsConfigMap,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "ngf-console",
+				v1alpha1.ServingDefaultsLabel:  "true",
+			},
+		},
+		Data: data,
+	}
+}
+
+// PublishServingDefaults creates or updates ServingDefaultsConfigMap in
+// namespace with the current default model server images, so the API
+// reports what the operator actually deploys.
+func PublishServingDefaults(ctx context.Context, c client.Client, namespace string) error {
+	desired := buildServingDefaultsConfigMap(namespace)
+
+	existing := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: namespace}, existing)
+	if errors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("creating ConfigMap %s/%s: %w", namespace, desired.Name, err)
+		}
+		slog.Info("published serving defaults", "namespace", namespace, "name", desired.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting ConfigMap %s/%s: %w", namespace, desired.Name, err)
+	}
+
+	if maps.Equal(existing.Data, desired.Data) && existing.Labels[v1alpha1.ServingDefaultsLabel] == "true" {
+		return nil
+	}
+	existing.Data = desired.Data
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	maps.Copy(existing.Labels, desired.Labels)
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("updating ConfigMap %s/%s: %w", namespace, desired.Name, err)
+	}
+	slog.Info("updated serving defaults", "namespace", namespace, "name", desired.Name)
+	return nil
+}
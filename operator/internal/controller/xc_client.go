@@ -0,0 +1,368 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Request budget shared by all publishes of a tenant. XC enforces per-tenant
+// limits, so concurrent reconciles must draw from the same bucket.
+const (
+	xcRequestsPerSecond = 5
+	xcRequestBurst      = 10
+
+	// xcDefaultRetryAfter is used when a 429 carries no usable Retry-After header.
+	xcDefaultRetryAfter = 30 * time.Second
+)
+
+// xcHTTPClient is shared by all XC clients so connections are reused across
+// reconciles.
+var xcHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// xcAPIClient is a minimal XC API client for the operator controller.
+type xcAPIClient struct {
+	tenant   string
+	apiToken string
+	baseURL  string
+	http     *http.Client
+	limiter  *xcTenantLimiter
+	retry    xcRetryPolicy
+}
+
+// xcAPIError is a non-2xx response from the XC API.
+type xcAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *xcAPIError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// newXCAPIError reads the body of a failed response into an xcAPIError.
+func newXCAPIError(resp *http.Response) *xcAPIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	return &xcAPIError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+// xcRateLimitedError is returned when XC throttles the tenant (HTTP 429) or
+// while the tenant is still backing off from an earlier 429.
+type xcRateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *xcRateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited by XC API, will retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// xcRateLimited reports whether err is XC throttling the tenant, and how long
+// to wait before retrying.
+func xcRateLimited(err error) (time.Duration, bool) {
+	var rl *xcRateLimitedError
+	if stderrors.As(err, &rl) {
+		return rl.RetryAfter, true
+	}
+	return 0, false
+}
+
+// xcRetryPolicy controls how requests are retried after XC throttles the
+// tenant (HTTP 429) or fails transiently (HTTP 502, 503, 504, or a transport
+// error). Retries stop once the next wait would exceed MaxElapsed, counted
+// from the first attempt. A zero MaxElapsed disables retries, leaving the
+// reconcile to requeue.
+type xcRetryPolicy struct {
+	MaxElapsed     time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// defaultXCRetryPolicy returns the retry policy the operator uses.
+func defaultXCRetryPolicy() xcRetryPolicy {
+	return xcRetryPolicy{
+		MaxElapsed:     30 * time.Second,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     8 * time.Second,
+	}
+}
+
+// xcTenantLimiter paces requests for one tenant and tracks any Retry-After
+// backoff requested by XC.
+type xcTenantLimiter struct {
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// xcLimiters hands out one xcTenantLimiter per tenant. The zero value is
+// ready to use.
+type xcLimiters struct {
+	mu       sync.Mutex
+	byTenant map[string]*xcTenantLimiter
+}
+
+// forTenant returns the limiter for tenant, creating it on first use.
+func (l *xcLimiters) forTenant(tenant string) *xcTenantLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.byTenant == nil {
+		l.byTenant = make(map[string]*xcTenantLimiter)
+	}
+	tl, ok := l.byTenant[tenant]
+	if !ok {
+		tl = &xcTenantLimiter{limiter: rate.NewLimiter(xcRequestsPerSecond, xcRequestBurst)}
+		l.byTenant[tenant] = tl
+	}
+	return tl
+}
+
+// wait blocks until the tenant's budget allows another request. It fails fast
+// with an xcRateLimitedError while a Retry-After backoff is in effect.
+func (l *xcTenantLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	remaining := time.Until(l.blockedUntil)
+	l.mu.Unlock()
+	if remaining > 0 {
+		return &xcRateLimitedError{RetryAfter: remaining}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// backoff records that XC asked the tenant to wait d before retrying.
+func (l *xcTenantLimiter) backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.blockedUntil) {
+		l.blockedUntil = until
+	}
+}
+
+// do executes a request against the XC API. Requests draw from the tenant's
+// rate budget; a 429 response is converted to an xcRateLimitedError and backs
+// off every publish of the tenant. Throttled and transiently failed requests
+// are retried per the client's retry policy; once retries are exhausted the
+// last outcome is returned.
+func (c *xcAPIClient) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling request body: %w", err)
+		}
+		payload = b
+	}
+
+	deadline := time.Now().Add(c.retry.MaxElapsed)
+	backoff := c.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doOnce(ctx, method, path, payload)
+		if c.retry.MaxElapsed <= 0 {
+			return resp, err
+		}
+		delay, retryable := xcRetryDelay(ctx, resp, err, backoff)
+		if !retryable || time.Now().Add(delay).After(deadline) {
+			return resp, err
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		slog.Info("retrying XC request", "method", method, "path", path, "attempt", attempt, "status", status, "error", err, "delay", delay)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		backoff = min(backoff*2, c.retry.MaxBackoff)
+	}
+}
+
+// doOnce makes a single attempt at a request.
+func (c *xcAPIClient) doOnce(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "APIToken "+c.apiToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseXCRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		resp.Body.Close()
+		c.limiter.backoff(retryAfter)
+		return nil, &xcRateLimitedError{RetryAfter: retryAfter}
+	}
+	return resp, nil
+}
+
+// xcRetryDelay reports whether the outcome of an attempt is worth retrying
+// and how long to wait first. Throttling waits for the Retry-After XC asked
+// for; other transient failures use backoff, spread over [backoff/2, backoff)
+// so reconciles retrying together don't hit XC in lockstep.
+func xcRetryDelay(ctx context.Context, resp *http.Response, err error, backoff time.Duration) (time.Duration, bool) {
+	jittered := backoff
+	if backoff > 1 {
+		jittered = backoff/2 + rand.N(backoff/2)
+	}
+	if err != nil {
+		if retryAfter, limited := xcRateLimited(err); limited {
+			return retryAfter, true
+		}
+		// A canceled or expired context is final.
+		if ctx.Err() != nil || stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return jittered, true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if h := resp.Header.Get("Retry-After"); h != "" {
+			return parseXCRetryAfter(h, time.Now()), true
+		}
+		return jittered, true
+	default:
+		return 0, false
+	}
+}
+
+// parseXCRetryAfter parses a Retry-After header given as delay-seconds or an
+// HTTP date. Falls back to xcDefaultRetryAfter when absent or invalid.
+func parseXCRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return xcDefaultRetryAfter
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return xcDefaultRetryAfter
+}
+
+func (c *xcAPIClient) getHTTPLoadBalancer(ctx context.Context, namespace, name string) (map[string]any, error) {
+	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newXCAPIError(resp)
+	}
+
+	var result map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// apply creates an XC config object and replaces it when it already exists,
+// so applying the same object repeatedly converges on it.
+func (c *xcAPIClient) apply(ctx context.Context, collection, namespace string, obj map[string]any) error {
+	name, _ := obj["metadata"].(map[string]any)["name"].(string)
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/config/namespaces/%s/%s", namespace, collection), obj)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode != http.StatusConflict {
+		return newXCAPIError(resp)
+	}
+
+	resp, err = c.do(ctx, http.MethodPut, fmt.Sprintf("/config/namespaces/%s/%s/%s", namespace, collection, name), obj)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newXCAPIError(resp)
+	}
+	return nil
+}
+
+func (c *xcAPIClient) applyOriginPool(ctx context.Context, namespace string, pool map[string]any) error {
+	return c.apply(ctx, "origin_pools", namespace, pool)
+}
+
+func (c *xcAPIClient) applyHealthCheck(ctx context.Context, namespace string, hc map[string]any) error {
+	return c.apply(ctx, "healthchecks", namespace, hc)
+}
+
+func (c *xcAPIClient) applyHTTPLoadBalancer(ctx context.Context, namespace string, lb map[string]any) error {
+	return c.apply(ctx, "http_loadbalancers", namespace, lb)
+}
+
+// delete deletes the named object; one that is already gone counts as deleted.
+func (c *xcAPIClient) delete(ctx context.Context, collection, namespace, name string) error {
+	resp, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/config/namespaces/%s/%s/%s", namespace, collection, name), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newXCAPIError(resp)
+	}
+	return nil
+}
+
+func (c *xcAPIClient) deleteHTTPLoadBalancer(ctx context.Context, namespace, name string) error {
+	return c.delete(ctx, "http_loadbalancers", namespace, name)
+}
+
+func (c *xcAPIClient) deleteHealthCheck(ctx context.Context, namespace, name string) error {
+	return c.delete(ctx, "healthchecks", namespace, name)
+}
+
+func (c *xcAPIClient) deleteOriginPool(ctx context.Context, namespace, name string) error {
+	return c.delete(ctx, "origin_pools", namespace, name)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestXCAPIClient_RetriesTransientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	r := &XCPublishReconciler{xcBaseURL: srv.URL, xcRetry: xcRetryPolicy{MaxElapsed: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+	c := r.newXCClient("acme", "token")
+	if err := c.applyOriginPool(context.Background(), "prod", map[string]any{"metadata": map[string]any{"name": "pool"}}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected the 503 to be retried once, got %d attempts", got)
+	}
+}
+
+func TestXCAPIClient_RateLimitSharedPerTenant(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	// Retries are off, so the 429 is returned to the caller.
+	r := &XCPublishReconciler{xcBaseURL: srv.URL}
+	_, err := r.newXCClient("acme", "token").getHTTPLoadBalancer(context.Background(), "prod", "lb")
+	if retryAfter, limited := xcRateLimited(err); !limited || retryAfter != time.Minute {
+		t.Fatalf("expected a 60s rate limit, got %v", err)
+	}
+
+	// Another client of the tenant backs off without calling XC; other
+	// tenants are unaffected.
+	_, err = r.newXCClient("acme", "token").getHTTPLoadBalancer(context.Background(), "prod", "lb")
+	if _, limited := xcRateLimited(err); !limited || attempts.Load() != 1 {
+		t.Errorf("expected the tenant to back off without a request, got %v after %d attempts", err, attempts.Load())
+	}
+	r.newXCClient("globex", "token").getHTTPLoadBalancer(context.Background(), "prod", "lb")
+	if attempts.Load() != 2 {
+		t.Errorf("expected another tenant to reach XC, got %d attempts", attempts.Load())
+	}
+}
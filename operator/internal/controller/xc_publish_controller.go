@@ -1,20 +1,19 @@
 package controller
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	stderrors "errors"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
 	"os"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,101 +25,90 @@ import (
 // httpRouteGVK is already defined as a function in inferencestack_children.go,
 // so the XC controller reuses it via httpRouteGVK() calls.
 
-// xcAPIClient is a minimal XC API client for the operator controller.
-type xcAPIClient struct {
-	tenant   string
-	apiToken string
-	baseURL  string
-	http     *http.Client
+func grpcRouteGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "gateway.networking.k8s.io",
+		Version: "v1",
+		Kind:    "GRPCRoute",
+	}
+}
+
+// XCPublishReconciler reconciles DistributedCloudPublish objects.
+type XCPublishReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// apiReader reads credential Secrets straight from the API server, so the
+	// manager does not cache every Secret in the cluster. Nil in tests.
+	apiReader client.Reader
+
+	// xcTenant and xcAPIToken are the operator's XC credentials, read from
+	// XC_TENANT and XC_API_TOKEN.
+	xcTenant   string
+	xcAPIToken string
+	// xcBaseURL overrides the tenant's XC API URL in tests.
+	xcBaseURL  string
+	xcRetry    xcRetryPolicy
+	xcLimiters xcLimiters
 }
 
-func newXCAPIClient(tenant, apiToken string) *xcAPIClient {
+// newXCClient returns a client for tenant that draws from the tenant's
+// request budget.
+func (r *XCPublishReconciler) newXCClient(tenant, apiToken string) *xcAPIClient {
+	baseURL := r.xcBaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s.console.ves.volterra.io/api", tenant)
+	}
 	return &xcAPIClient{
 		tenant:   tenant,
 		apiToken: apiToken,
-		baseURL:  fmt.Sprintf("https://%s.console.ves.volterra.io/api", tenant),
-		http:     &http.Client{Timeout: 30 * time.Second},
+		baseURL:  baseURL,
+		http:     xcHTTPClient,
+		limiter:  r.xcLimiters.forTenant(tenant),
+		retry:    r.xcRetry,
 	}
 }
 
-func (c *xcAPIClient) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
-	var bodyReader io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("marshaling request body: %w", err)
+// xcClientFor returns an XC client for the publish's tenant. The Secret named
+// by spec.distributedCloud.credentialsSecretRef comes first. Without it, the
+// operator's credentials serve a publish whose spec.distributedCloud.tenant is
+// empty or names the operator's tenant.
+func (r *XCPublishReconciler) xcClientFor(ctx context.Context, publish *v1alpha1.DistributedCloudPublish) (*xcAPIClient, error) {
+	if ref := publish.Spec.DistributedCloud.CredentialsSecretRef; ref != "" {
+		tenant, apiToken, err := r.xcSecretCredentials(ctx, publish.Namespace, ref)
+		if err == nil {
+			return r.newXCClient(tenant, apiToken), nil
+		}
+		if !errors.IsNotFound(err) {
+			return nil, err
 		}
-		bodyReader = bytes.NewReader(b)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", "APIToken "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	return c.http.Do(req)
-}
-
-func (c *xcAPIClient) getHTTPLoadBalancer(ctx context.Context, namespace, name string) (map[string]any, error) {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, nil
-	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	if r.xcTenant == "" || r.xcAPIToken == "" {
+		return nil, stderrors.New("XC_TENANT and XC_API_TOKEN are not set on the operator, so XC resources cannot be created")
 	}
-
-	var result map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if tenant := publish.Spec.DistributedCloud.Tenant; tenant != "" && tenant != r.xcTenant {
+		return nil, fmt.Errorf("the operator has no XC credentials for tenant %q", tenant)
 	}
-	return result, nil
+	return r.newXCClient(r.xcTenant, r.xcAPIToken), nil
 }
 
-func (c *xcAPIClient) deleteHTTPLoadBalancer(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("/config/namespaces/%s/http_loadbalancers/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return err
+// xcSecretCredentials reads the tenant and API token from a credentials
+// Secret written by the API server.
+func (r *XCPublishReconciler) xcSecretCredentials(ctx context.Context, namespace, name string) (string, string, error) {
+	var reader client.Reader = r.Client
+	if r.apiReader != nil {
+		reader = r.apiReader
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		return "", "", err
 	}
-	return nil
-}
-
-func (c *xcAPIClient) deleteOriginPool(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("/config/namespaces/%s/origin_pools/%s", namespace, name)
-	resp, err := c.do(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return err
+	tenant, apiToken := string(secret.Data["tenant"]), string(secret.Data["apiToken"])
+	if tenant == "" || apiToken == "" {
+		return "", "", fmt.Errorf("XC credentials secret %s/%s has no tenant or apiToken", namespace, name)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
-	}
-	return nil
-}
-
-// XCPublishReconciler reconciles DistributedCloudPublish objects.
-type XCPublishReconciler struct {
-	client.Client
-	Scheme   *runtime.Scheme
-	xcClient *xcAPIClient
+	return tenant, apiToken, nil
 }
 
 // Reconcile handles reconciliation of DistributedCloudPublish resources.
@@ -132,18 +120,41 @@ func (r *XCPublishReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	routeKind := publish.Spec.RouteKind
+	if routeKind == "" {
+		routeKind = v1alpha1.RouteKindHTTPRoute
+	}
+
 	log.Info("reconciling DistributedCloudPublish",
 		"httpRouteRef", publish.Spec.HTTPRouteRef,
+		"routeKind", routeKind,
 		"tenant", publish.Spec.DistributedCloud.Tenant,
 	)
 
-	// Handle deletion via finalizer.
+	// Handle deletion via finalizer. The XC resources are deleted before the
+	// finalizer is removed, so deleting the CRD by any path cleans up XC.
 	if !publish.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(&publish, v1alpha1.DistributedCloudPublishFinalizer) {
 			log.Info("handling deletion, cleaning up XC resources")
 
-			// Clean up XC resources if we have a client.
-			r.cleanupXCResources(ctx, &publish)
+			xcClient, credErr := r.xcClientFor(ctx, &publish)
+			if credErr != nil {
+				// Without credentials there is nothing the operator can delete.
+				log.Warn("skipping XC cleanup", "error", credErr)
+			} else if err := r.cleanupXCResources(ctx, xcClient, &publish); err != nil {
+				log.Warn("XC cleanup failed, keeping finalizer", "error", err)
+				retryAfter := 30 * time.Second
+				if after, limited := xcRateLimited(err); limited && after > 0 {
+					retryAfter = after
+				}
+				publish.Status.Phase = v1alpha1.PhaseTerminating
+				v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
+					metav1.ConditionFalse, "XCCleanupFailed", err.Error())
+				if err := r.Status().Update(ctx, &publish); err != nil {
+					log.Warn("failed to record cleanup failure", "error", err)
+				}
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
 
 			// Remove the finalizer to allow Kubernetes to delete the resource.
 			controllerutil.RemoveFinalizer(&publish, v1alpha1.DistributedCloudPublishFinalizer)
@@ -166,58 +177,63 @@ func (r *XCPublishReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
-	// Validate that the referenced HTTPRoute exists.
-	httpRouteFound := r.httpRouteExists(ctx, publish.Namespace, publish.Spec.HTTPRouteRef)
-
-	// Check XC resource status if client is available.
-	xcSynced := false
-	if r.xcClient != nil && httpRouteFound {
-		xcNs := publish.Spec.DistributedCloud.Namespace
-		if xcNs == "" {
-			xcNs = "default"
-		}
-		lbName := "ngf-" + publish.Spec.HTTPRouteRef
-
-		lb, err := r.xcClient.getHTTPLoadBalancer(ctx, xcNs, lbName)
-		if err != nil {
-			log.Warn("failed to check XC HTTP LB", "name", lbName, "error", err)
-		} else if lb != nil {
-			xcSynced = true
-			publish.Status.XCLoadBalancerName = lbName
-			publish.Status.XCOriginPoolName = lbName + "-pool"
-			now := metav1.Now()
-			publish.Status.LastSyncedAt = &now
-
-			if publish.Spec.DistributedCloud.WAFPolicy != "" {
-				publish.Status.WAFPolicyAttached = publish.Spec.DistributedCloud.WAFPolicy
-			}
-		} else {
-			// LB doesn't exist in XC — mark as drift.
-			log.Warn("XC HTTP LB not found, possible drift", "name", lbName)
-		}
+	// Validate that the referenced route exists.
+	gvk := httpRouteGVK()
+	if routeKind == v1alpha1.RouteKindGRPCRoute {
+		gvk = grpcRouteGVK()
 	}
+	routeFound := r.routeExists(ctx, gvk, publish.Namespace, publish.Spec.HTTPRouteRef)
+	xcClient, credErr := r.xcClientFor(ctx, &publish)
 
-	// Update status based on validation results.
-	if httpRouteFound && xcSynced {
-		publish.Status.Phase = "Published"
+	// Requeue for drift detection.
+	requeueAfter := 120 * time.Second
+	switch {
+	case !routeFound:
+		publish.Status.Phase = v1alpha1.PhasePending
 		v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
-			metav1.ConditionTrue, "Published",
-			fmt.Sprintf("HTTPRoute %q found and XC resources are synced", publish.Spec.HTTPRouteRef))
-	} else if httpRouteFound && r.xcClient != nil {
-		publish.Status.Phase = v1alpha1.PhaseDegraded
+			metav1.ConditionFalse, routeKind+"NotFound",
+			fmt.Sprintf("%s %q not found in namespace %q", routeKind, publish.Spec.HTTPRouteRef, publish.Namespace))
+	case credErr != nil:
+		publish.Status.Phase = v1alpha1.PhasePending
 		v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
-			metav1.ConditionFalse, "XCResourceMissing",
-			fmt.Sprintf("HTTPRoute %q found but XC resources may not be in sync", publish.Spec.HTTPRouteRef))
-	} else if httpRouteFound {
+			metav1.ConditionFalse, "XCNotConfigured", credErr.Error())
+	case publish.Status.Phase == "Published" && publish.Status.ObservedGeneration == publish.Generation &&
+		!resyncRequested(&publish) && xcLoadBalancerExists(ctx, xcClient, &publish):
+		// Already applied for this generation and still present in XC.
+	default:
+		if err := r.syncXCResources(ctx, xcClient, &publish); err != nil {
+			log.Warn("failed to sync XC resources", "error", err)
+			requeueAfter = 30 * time.Second
+			if retryAfter, limited := xcRateLimited(err); limited {
+				// Throttled by XC rather than rejected; retry once XC allows it.
+				publish.Status.Phase = v1alpha1.PhasePending
+				v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
+					metav1.ConditionFalse, "XCRateLimited", err.Error())
+				if retryAfter > 0 {
+					requeueAfter = retryAfter
+				}
+			} else if stderrors.Is(err, errOriginPending) {
+				publish.Status.Phase = v1alpha1.PhasePending
+				v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
+					metav1.ConditionFalse, "OriginPending", err.Error())
+			} else if stderrors.Is(err, errOriginNoHTTP2) {
+				publish.Status.Phase = v1alpha1.PhaseError
+				v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
+					metav1.ConditionFalse, "OriginHTTP2Unsupported", err.Error())
+			} else {
+				publish.Status.Phase = v1alpha1.PhaseError
+				v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
+					metav1.ConditionFalse, "XCSyncFailed", err.Error())
+			}
+			break
+		}
+		now := metav1.Now()
+		publish.Status.LastSyncedAt = &now
+		publish.Status.ObservedGeneration = publish.Generation
 		publish.Status.Phase = "Published"
 		v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
 			metav1.ConditionTrue, "Published",
-			fmt.Sprintf("HTTPRoute %q found and configuration is valid", publish.Spec.HTTPRouteRef))
-	} else {
-		publish.Status.Phase = v1alpha1.PhasePending
-		v1alpha1.SetCondition(&publish.Status.Conditions, v1alpha1.ConditionReady,
-			metav1.ConditionFalse, "HTTPRouteNotFound",
-			fmt.Sprintf("HTTPRoute %q not found in namespace %q", publish.Spec.HTTPRouteRef, publish.Namespace))
+			fmt.Sprintf("%s %q is published to XC as %q", routeKind, publish.Spec.HTTPRouteRef, publish.Status.XCLoadBalancerName))
 	}
 
 	if err := r.Status().Update(ctx, &publish); err != nil {
@@ -226,60 +242,74 @@ func (r *XCPublishReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	log.Info("reconciliation complete",
 		"phase", publish.Status.Phase,
-		"httpRouteFound", httpRouteFound,
-		"xcSynced", xcSynced,
+		"routeFound", routeFound,
+		"xcLoadBalancer", publish.Status.XCLoadBalancerName,
 	)
 
-	// Requeue for drift detection.
-	return ctrl.Result{RequeueAfter: 120 * time.Second}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
-// cleanupXCResources deletes XC HTTP LB and origin pool for a publish being deleted.
-func (r *XCPublishReconciler) cleanupXCResources(ctx context.Context, publish *v1alpha1.DistributedCloudPublish) {
-	if r.xcClient == nil {
-		return
-	}
-
+// cleanupXCResources deletes the XC HTTP LB, then its origin pool, then a
+// GRPCRoute's health check for a publish being deleted, using the names
+// recorded in status or, if unset, the ngf-<route> naming convention. Objects
+// already gone from XC count as deleted.
+func (r *XCPublishReconciler) cleanupXCResources(ctx context.Context, xcClient *xcAPIClient, publish *v1alpha1.DistributedCloudPublish) error {
 	xcNs := publish.Spec.DistributedCloud.Namespace
 	if xcNs == "" {
 		xcNs = "default"
 	}
 
-	// Delete HTTP LB.
+	// The LB references the pool, so it has to go first.
 	lbName := publish.Status.XCLoadBalancerName
 	if lbName == "" {
-		lbName = "ngf-" + publish.Spec.HTTPRouteRef
+		lbName = xcResourceName(publish.Spec.HTTPRouteRef)
 	}
-	if err := r.xcClient.deleteHTTPLoadBalancer(ctx, xcNs, lbName); err != nil {
-		slog.Warn("failed to delete XC HTTP LB on cleanup", "name", lbName, "error", err)
-	} else {
-		slog.Info("deleted XC HTTP LB", "name", lbName)
+	if err := xcClient.deleteHTTPLoadBalancer(ctx, xcNs, lbName); err != nil {
+		return fmt.Errorf("deleting XC HTTP LB %q: %w", lbName, err)
 	}
+	slog.Info("deleted XC HTTP LB", "name", lbName)
 
-	// Delete origin pool.
 	poolName := publish.Status.XCOriginPoolName
 	if poolName == "" {
-		poolName = "ngf-" + publish.Spec.HTTPRouteRef + "-pool"
+		poolName = xcResourceName(publish.Spec.HTTPRouteRef) + "-pool"
 	}
-	if err := r.xcClient.deleteOriginPool(ctx, xcNs, poolName); err != nil {
-		slog.Warn("failed to delete XC origin pool on cleanup", "name", poolName, "error", err)
-	} else {
-		slog.Info("deleted XC origin pool", "name", poolName)
+	if err := xcClient.deleteOriginPool(ctx, xcNs, poolName); err != nil {
+		return fmt.Errorf("deleting XC origin pool %q: %w", poolName, err)
+	}
+	slog.Info("deleted XC origin pool", "name", poolName)
+
+	if publish.Spec.RouteKind == v1alpha1.RouteKindGRPCRoute {
+		hcName := xcGRPCHealthCheckName(publish.Spec.HTTPRouteRef)
+		if err := xcClient.deleteHealthCheck(ctx, xcNs, hcName); err != nil {
+			return fmt.Errorf("deleting XC health check %q: %w", hcName, err)
+		}
+		slog.Info("deleted XC health check", "name", hcName)
+	}
+	return nil
+}
+
+// resyncRequested reports whether the publish carries a reconcile request
+// made after its last sync.
+func resyncRequested(publish *v1alpha1.DistributedCloudPublish) bool {
+	requested, err := time.Parse(time.RFC3339, publish.Annotations[v1alpha1.ReconcileRequestedAnnotation])
+	if err != nil {
+		return false
 	}
+	return publish.Status.LastSyncedAt == nil || requested.After(publish.Status.LastSyncedAt.Time)
 }
 
-// httpRouteExists checks whether an HTTPRoute with the given name exists in the specified namespace
+// routeExists checks whether a route of the given kind and name exists in the specified namespace
 // using an unstructured client lookup.
-func (r *XCPublishReconciler) httpRouteExists(ctx context.Context, namespace, name string) bool {
+func (r *XCPublishReconciler) routeExists(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) bool {
 	route := &unstructured.Unstructured{}
-	route.SetGroupVersionKind(httpRouteGVK())
+	route.SetGroupVersionKind(gvk)
 
 	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, route)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false
 		}
-		slog.Warn("error checking HTTPRoute existence", "name", name, "namespace", namespace, "error", err)
+		slog.Warn("error checking route existence", "kind", gvk.Kind, "name", name, "namespace", namespace, "error", err)
 		return false
 	}
 	return true
@@ -287,14 +317,16 @@ func (r *XCPublishReconciler) httpRouteExists(ctx context.Context, namespace, na
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *XCPublishReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Initialize XC client from environment if configured.
-	xcTenant := os.Getenv("XC_TENANT")
-	xcToken := os.Getenv("XC_API_TOKEN")
-	if xcTenant != "" && xcToken != "" {
-		r.xcClient = newXCAPIClient(xcTenant, xcToken)
-		slog.Info("XC API client configured for operator", "tenant", xcTenant)
+	r.apiReader = mgr.GetAPIReader()
+	// The operator's own XC credentials, used for publishes of its tenant
+	// that have no credentials Secret.
+	r.xcTenant = os.Getenv("XC_TENANT")
+	r.xcAPIToken = os.Getenv("XC_API_TOKEN")
+	r.xcRetry = defaultXCRetryPolicy()
+	if r.xcTenant != "" && r.xcAPIToken != "" {
+		slog.Info("XC API credentials configured for operator", "tenant", r.xcTenant)
 	} else {
-		slog.Info("XC API client not configured (XC_TENANT/XC_API_TOKEN not set)")
+		slog.Info("XC API credentials not configured (XC_TENANT/XC_API_TOKEN not set)")
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
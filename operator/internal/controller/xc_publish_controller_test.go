@@ -0,0 +1,695 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// fakeXC records XC API calls and serves canned responses.
+type fakeXC struct {
+	mu          sync.Mutex
+	calls       []string
+	objects     map[string]map[string]any // keyed by request path of the object
+	rateLimit   bool
+	failDeletes bool
+	// tokens records the API token of each call.
+	tokens []string
+}
+
+func (f *fakeXC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	f.calls = append(f.calls, r.Method+" "+path)
+	f.tokens = append(f.tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "APIToken "))
+
+	if f.rateLimit {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		var obj map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		key := path
+		if r.Method == http.MethodPost {
+			key += "/" + obj["metadata"].(map[string]any)["name"].(string)
+			if _, ok := f.objects[key]; ok {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		// XC assigns the auto hostname to new load balancers.
+		if strings.Contains(key, "/http_loadbalancers/") {
+			obj["spec"].(map[string]any)["host_name"] = "ves-io-1234.ac.vh.ves.io"
+		}
+		f.objects[key] = obj
+		w.Write([]byte("{}"))
+	case http.MethodDelete:
+		if f.failDeletes {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if _, ok := f.objects[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, path)
+		w.Write([]byte("{}"))
+	case http.MethodGet:
+		obj, ok := f.objects[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(obj)
+	}
+}
+
+// object returns the stored XC object at path, or nil.
+func (f *fakeXC) object(path string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.objects[path]
+}
+
+func (f *fakeXC) objectCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.objects)
+}
+
+func (f *fakeXC) setFailDeletes(fail bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failDeletes = fail
+}
+
+func (f *fakeXC) takeCalls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := f.calls
+	f.calls = nil
+	return calls
+}
+
+func newXCPublishTestReconciler(t *testing.T, xcServer *httptest.Server) *XCPublishReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add v1alpha1 scheme: %v", err)
+	}
+	if err := gatewayv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add gateway scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+
+	pathPrefix := gatewayv1.PathMatchPathPrefix
+	apiPath := "/v1"
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "edge"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"shop.internal"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: &apiPath}}},
+			}},
+		},
+	}
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginx",
+			Listeners:        []gatewayv1.Listener{{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType}},
+		},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{{Value: "203.0.113.10"}},
+		},
+	}
+	publish := &v1alpha1.DistributedCloudPublish{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", Generation: 1},
+		Spec: v1alpha1.DistributedCloudPublishSpec{
+			HTTPRouteRef: "shop",
+			DistributedCloud: v1alpha1.DistributedCloudConfig{
+				Tenant:         "acme",
+				Namespace:      "prod",
+				PublicHostname: "shop.example.com",
+				WAFPolicy:      "shared/default-waf",
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(route, gw, publish).
+		WithStatusSubresource(&v1alpha1.DistributedCloudPublish{}).
+		Build()
+	r := &XCPublishReconciler{Client: c, Scheme: scheme}
+	if xcServer != nil {
+		r.xcTenant, r.xcAPIToken = "acme", "token"
+		r.xcBaseURL = xcServer.URL + "/api"
+	}
+	return r
+}
+
+func TestXCPublishReconciler_CreatesXCResources(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+
+	want := []string{
+		"POST /config/namespaces/prod/origin_pools",
+		"POST /config/namespaces/prod/http_loadbalancers",
+		"GET /config/namespaces/prod/http_loadbalancers/ngf-shop",
+		"POST /config/namespaces/prod/http_loadbalancers",
+		"PUT /config/namespaces/prod/http_loadbalancers/ngf-shop",
+	}
+	if got := xcAPI.takeCalls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected XC calls:\n%s", strings.Join(got, "\n"))
+	}
+
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	st := publish.Status
+	if st.Phase != "Published" || st.XCLoadBalancerName != "ngf-shop" || st.XCOriginPoolName != "ngf-shop-pool" ||
+		st.XCDNS != "ves-io-1234.ac.vh.ves.io" || st.WAFPolicyAttached != "shared/default-waf" ||
+		st.ObservedGeneration != publish.Generation || st.LastSyncedAt == nil {
+		t.Errorf("unexpected status: %+v", st)
+	}
+
+	pool := xcAPI.object("/config/namespaces/prod/origin_pools/ngf-shop-pool")["spec"].(map[string]any)
+	if pool["port"] != float64(443) || pool["use_tls"] == nil {
+		t.Errorf("origin pool should target the HTTPS listener: %v", pool)
+	}
+	lb := xcAPI.object("/config/namespaces/prod/http_loadbalancers/ngf-shop")["spec"].(map[string]any)
+	domains, _ := json.Marshal(lb["domains"])
+	if string(domains) != `["shop.example.com","shop.internal","ves-io-1234.ac.vh.ves.io"]` {
+		t.Errorf("unexpected LB domains: %s", domains)
+	}
+
+	// A published, unchanged publish is only checked for drift.
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if got := xcAPI.takeCalls(); len(got) != 1 || got[0] != "GET /config/namespaces/prod/http_loadbalancers/ngf-shop" {
+		t.Errorf("expected only a drift check, got %v", got)
+	}
+
+	// A reconcile request newer than the last sync re-applies it.
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	publish.Annotations = map[string]string{
+		v1alpha1.ReconcileRequestedAnnotation: publish.Status.LastSyncedAt.Add(time.Second).UTC().Format(time.RFC3339),
+	}
+	if err := r.Update(ctx, &publish); err != nil {
+		t.Fatalf("annotate publish: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if got := xcAPI.takeCalls(); len(got) < 2 || got[0] != "POST /config/namespaces/prod/origin_pools" {
+		t.Errorf("expected the XC resources to be re-applied, got %v", got)
+	}
+}
+
+func TestXCPublishReconciler_RateLimited(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}, rateLimit: true}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if result.RequeueAfter != 7*time.Second {
+		t.Errorf("expected requeue after Retry-After, got %v", result.RequeueAfter)
+	}
+
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != v1alpha1.PhasePending || len(publish.Status.Conditions) != 1 ||
+		publish.Status.Conditions[0].Reason != "XCRateLimited" {
+		t.Errorf("unexpected status: %+v", publish.Status)
+	}
+}
+
+func TestXCPublishReconciler_NotConfigured(t *testing.T) {
+	r := newXCPublishTestReconciler(t, nil)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != v1alpha1.PhasePending || publish.Status.Conditions[0].Reason != "XCNotConfigured" {
+		t.Errorf("unexpected status: %+v", publish.Status)
+	}
+}
+
+func TestXCPublishReconciler_OtherTenantNotConfigured(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	publish.Spec.DistributedCloud.Tenant = "globex"
+	if err := r.Update(ctx, &publish); err != nil {
+		t.Fatalf("update publish: %v", err)
+	}
+
+	// The operator's credentials are for acme, so nothing is sent to XC.
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if calls := xcAPI.takeCalls(); len(calls) != 0 {
+		t.Errorf("expected no XC calls, got %v", calls)
+	}
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != v1alpha1.PhasePending || publish.Status.Conditions[0].Reason != "XCNotConfigured" ||
+		!strings.Contains(publish.Status.Conditions[0].Message, `"globex"`) {
+		t.Errorf("unexpected status: %+v", publish.Status)
+	}
+}
+
+func TestXCPublishReconciler_UsesCredentialsSecret(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	publish.Spec.DistributedCloud.Tenant = "globex"
+	publish.Spec.DistributedCloud.CredentialsSecretRef = "ngf-console-xc-credentials"
+	if err := r.Update(ctx, &publish); err != nil {
+		t.Fatalf("update publish: %v", err)
+	}
+
+	// Until the Secret exists, only the operator's acme credentials are
+	// available, and they are not globex's.
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Conditions[0].Reason != "XCNotConfigured" {
+		t.Fatalf("expected XCNotConfigured, got %+v", publish.Status)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ngf-console-xc-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"tenant": []byte("globex"), "apiToken": []byte("globex-token")},
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		t.Fatalf("create secret: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != "Published" {
+		t.Fatalf("expected Published, got %+v", publish.Status)
+	}
+	for _, token := range xcAPI.tokens {
+		if token != "globex-token" {
+			t.Errorf("expected every XC call to use the Secret's token, got %q", token)
+		}
+	}
+}
+
+func TestXCPublishReconciler_DeleteCleansUpXC(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "shop", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if n := xcAPI.objectCount(); n != 2 {
+		t.Fatalf("expected the pool and LB to exist in XC, got %d objects", n)
+	}
+	xcAPI.takeCalls()
+
+	// Deleting the CRD directly leaves it in place until XC is cleaned up.
+	var publish v1alpha1.DistributedCloudPublish
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if err := r.Delete(ctx, &publish); err != nil {
+		t.Fatalf("delete publish: %v", err)
+	}
+
+	xcAPI.setFailDeletes(true)
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("expected a failed cleanup to be retried")
+	}
+	if err := r.Get(ctx, key, &publish); err != nil {
+		t.Fatalf("expected the publish to be kept while cleanup fails: %v", err)
+	}
+	if publish.Status.Phase != v1alpha1.PhaseTerminating {
+		t.Errorf("expected phase Terminating, got %q", publish.Status.Phase)
+	}
+
+	xcAPI.setFailDeletes(false)
+	xcAPI.takeCalls()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	want := []string{
+		"DELETE /config/namespaces/prod/http_loadbalancers/ngf-shop",
+		"DELETE /config/namespaces/prod/origin_pools/ngf-shop-pool",
+	}
+	if got := xcAPI.takeCalls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected XC calls: %v", got)
+	}
+	if n := xcAPI.objectCount(); n != 0 {
+		t.Errorf("expected XC resources to be deleted, %d remain", n)
+	}
+	if err := r.Get(ctx, key, &publish); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the publish to be gone, got %v", err)
+	}
+}
+
+func TestXCPublishReconciler_CleanupUsesNameConvention(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	// Without recorded status names, the ngf-<route> names are deleted; XC
+	// objects that don't exist count as already deleted.
+	publish := &v1alpha1.DistributedCloudPublish{
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "default"},
+		Spec: v1alpha1.DistributedCloudPublishSpec{
+			HTTPRouteRef:     "orphan",
+			DistributedCloud: v1alpha1.DistributedCloudConfig{Namespace: "prod"},
+		},
+	}
+	if err := r.cleanupXCResources(context.Background(), r.newXCClient("acme", "token"), publish); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+	want := []string{
+		"DELETE /config/namespaces/prod/http_loadbalancers/ngf-orphan",
+		"DELETE /config/namespaces/prod/origin_pools/ngf-orphan-pool",
+	}
+	if got := xcAPI.takeCalls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected XC calls: %v", got)
+	}
+}
+
+func TestXCPublishReconciler_PublishesGRPCRoute(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	service := "shop.v1.Cart"
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "edge"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"cart.internal"},
+			Rules: []gatewayv1.GRPCRouteRule{{
+				Matches: []gatewayv1.GRPCRouteMatch{{Method: &gatewayv1.GRPCMethodMatch{Service: &service}}},
+			}},
+		},
+	}
+	if err := r.Create(ctx, grpcRoute); err != nil {
+		t.Fatalf("create GRPCRoute: %v", err)
+	}
+	publish := &v1alpha1.DistributedCloudPublish{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default"},
+		Spec: v1alpha1.DistributedCloudPublishSpec{
+			HTTPRouteRef: "cart",
+			RouteKind:    v1alpha1.RouteKindGRPCRoute,
+			DistributedCloud: v1alpha1.DistributedCloudConfig{
+				Tenant:           "acme",
+				Namespace:        "prod",
+				PublicHostname:   "cart.example.com",
+				WebSocketEnabled: true,
+			},
+		},
+	}
+	if err := r.Create(ctx, publish); err != nil {
+		t.Fatalf("create publish: %v", err)
+	}
+
+	key := types.NamespacedName{Name: "cart", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if err := r.Get(ctx, key, publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != "Published" {
+		t.Fatalf("expected Published, got %+v", publish.Status)
+	}
+
+	hc := xcAPI.object("/config/namespaces/prod/healthchecks/ngf-cart-grpc-hc")
+	if hc == nil {
+		t.Fatal("expected a gRPC health check in XC")
+	}
+	check := hc["spec"].(map[string]any)["http_health_check"].(map[string]any)
+	if check["path"] != "/grpc.health.v1.Health/Check" || check["use_http2"] != true {
+		t.Errorf("unexpected health check: %v", check)
+	}
+	pool := xcAPI.object("/config/namespaces/prod/origin_pools/ngf-cart-pool")["spec"].(map[string]any)
+	http2, _ := json.Marshal(pool["advanced_options"])
+	refs, _ := json.Marshal(pool["healthcheck"])
+	if string(http2) != `{"http2_options":{"enabled":true}}` || string(refs) != `[{"name":"ngf-cart-grpc-hc","namespace":"prod"}]` {
+		t.Errorf("origin pool should use HTTP/2 and the health check: %v", pool)
+	}
+	routes := xcAPI.object("/config/namespaces/prod/http_loadbalancers/ngf-cart")["spec"].(map[string]any)["routes"].([]any)
+	sr := routes[0].(map[string]any)["simple_route"].(map[string]any)
+	if sr["path"].(map[string]any)["prefix"] != "/shop.v1.Cart/" || sr["advanced_options"] != nil {
+		t.Errorf("unexpected gRPC route: %v", sr)
+	}
+
+	// Deleting the publish removes the health check after the pool.
+	if err := r.Delete(ctx, publish); err != nil {
+		t.Fatalf("delete publish: %v", err)
+	}
+	xcAPI.takeCalls()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	want := []string{
+		"DELETE /config/namespaces/prod/http_loadbalancers/ngf-cart",
+		"DELETE /config/namespaces/prod/origin_pools/ngf-cart-pool",
+		"DELETE /config/namespaces/prod/healthchecks/ngf-cart-grpc-hc",
+	}
+	if got := xcAPI.takeCalls(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected XC calls: %v", got)
+	}
+}
+
+func TestXCPublishReconciler_GRPCRouteNeedsHTTP2Listener(t *testing.T) {
+	xcAPI := &fakeXC{objects: map[string]map[string]any{}}
+	srv := httptest.NewServer(xcAPI)
+	defer srv.Close()
+	r := newXCPublishTestReconciler(t, srv)
+
+	ctx := context.Background()
+	section := gatewayv1.SectionName("passthrough")
+	var gw gatewayv1.Gateway
+	if err := r.Get(ctx, types.NamespacedName{Name: "edge", Namespace: "default"}, &gw); err != nil {
+		t.Fatalf("get gateway: %v", err)
+	}
+	gw.Spec.Listeners = append(gw.Spec.Listeners, gatewayv1.Listener{Name: section, Port: 8443, Protocol: gatewayv1.TLSProtocolType})
+	if err := r.Update(ctx, &gw); err != nil {
+		t.Fatalf("update gateway: %v", err)
+	}
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "edge", SectionName: &section}},
+			},
+		},
+	}
+	if err := r.Create(ctx, grpcRoute); err != nil {
+		t.Fatalf("create GRPCRoute: %v", err)
+	}
+	publish := &v1alpha1.DistributedCloudPublish{
+		ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "default"},
+		Spec: v1alpha1.DistributedCloudPublishSpec{
+			HTTPRouteRef:     "cart",
+			RouteKind:        v1alpha1.RouteKindGRPCRoute,
+			DistributedCloud: v1alpha1.DistributedCloudConfig{Tenant: "acme", Namespace: "prod"},
+		},
+	}
+	if err := r.Create(ctx, publish); err != nil {
+		t.Fatalf("create publish: %v", err)
+	}
+
+	key := types.NamespacedName{Name: "cart", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if err := r.Get(ctx, key, publish); err != nil {
+		t.Fatalf("get publish: %v", err)
+	}
+	if publish.Status.Phase != v1alpha1.PhaseError || publish.Status.Conditions[0].Reason != "OriginHTTP2Unsupported" {
+		t.Errorf("unexpected status: %+v", publish.Status)
+	}
+	if xcAPI.objectCount() != 0 {
+		t.Errorf("nothing should be created in XC for an invalid origin, got %d objects", xcAPI.objectCount())
+	}
+}
+
+func TestGRPCMethodPathMatch(t *testing.T) {
+	regex := gatewayv1.GRPCMethodMatchRegularExpression
+	cart, add, cartPattern := "shop.v1.Cart", "Add", `shop\.v[0-9]+\.Cart`
+	tests := []struct {
+		name     string
+		match    *gatewayv1.GRPCMethodMatch
+		wantType gatewayv1.PathMatchType
+		want     string
+	}{
+		{"service and method", &gatewayv1.GRPCMethodMatch{Service: &cart, Method: &add}, gatewayv1.PathMatchExact, "/shop.v1.Cart/Add"},
+		{"service only", &gatewayv1.GRPCMethodMatch{Service: &cart}, gatewayv1.PathMatchPathPrefix, "/shop.v1.Cart/"},
+		{"method only", &gatewayv1.GRPCMethodMatch{Method: &add}, gatewayv1.PathMatchRegularExpression, "/[^/]+/Add"},
+		{"regex", &gatewayv1.GRPCMethodMatch{Type: &regex, Service: &cartPattern}, gatewayv1.PathMatchRegularExpression, `/shop\.v[0-9]+\.Cart/[^/]+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grpcMethodPathMatch(tt.match)
+			if got == nil || *got.Type != tt.wantType || *got.Value != tt.want {
+				t.Errorf("grpcMethodPathMatch() = %+v, want %s %q", got, tt.wantType, tt.want)
+			}
+		})
+	}
+	if got := grpcMethodPathMatch(nil); got != nil {
+		t.Errorf("a match without a method should match every path, got %+v", got)
+	}
+}
+
+func TestBuildXCHTTPLoadBalancer(t *testing.T) {
+	exact := gatewayv1.PathMatchExact
+	path := "/healthz"
+	get := gatewayv1.HTTPMethodGet
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &exact, Value: &path}, Method: &get}}},
+			},
+		},
+	}
+
+	lb := buildXCHTTPLoadBalancer(route, "prod", v1alpha1.DistributedCloudConfig{WAFPolicy: "default-waf", WebSocketEnabled: true})
+	spec := lb["spec"].(map[string]any)
+
+	if domains := spec["domains"].([]any); len(domains) != 1 || domains[0] != "ngf-api.example.com" {
+		t.Errorf("unexpected domains: %v", domains)
+	}
+	if waf := spec["app_firewall"].(map[string]any); waf["namespace"] != "prod" || waf["name"] != "default-waf" {
+		t.Errorf("unexpected app_firewall: %v", waf)
+	}
+	routes := spec["routes"].([]any)
+	if len(routes) != 1 {
+		t.Fatalf("expected 1 route, got %v", routes)
+	}
+	sr := routes[0].(map[string]any)["simple_route"].(map[string]any)
+	if sr["http_method"] != "GET" || sr["path"].(map[string]any)["exact"] != "/healthz" || sr["advanced_options"] == nil {
+		t.Errorf("unexpected route: %v", sr)
+	}
+
+	pool := buildXCOriginPool("api", "prod", xcOrigin{Address: "gw.example.net", Port: 80})
+	poolSpec := pool["spec"].(map[string]any)
+	if servers := poolSpec["origin_servers"].([]any); servers[0].(map[string]any)["public_name"] == nil || poolSpec["no_tls"] == nil {
+		t.Errorf("unexpected origin pool: %v", poolSpec)
+	}
+}
+
+func TestBuildXCHTTPLoadBalancer_Domains(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default"},
+		Spec:       gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{"shop.internal", "www.example.com"}},
+	}
+	cfg := v1alpha1.DistributedCloudConfig{
+		PublicHostname: "shop.example.com",
+		Domains:        []string{"WWW.example.com", "*.shop.example.com", "shop.example.com"},
+	}
+
+	spec := buildXCHTTPLoadBalancer(route, "prod", cfg)["spec"].(map[string]any)
+	domains, _ := json.Marshal(spec["domains"])
+	if string(domains) != `["shop.example.com","shop.internal","www.example.com","*.shop.example.com"]` {
+		t.Errorf("unexpected domains: %s", domains)
+	}
+
+	// The XC auto hostname is not added twice when a custom domain names it.
+	merged := appendXCDomains(spec["domains"].([]any), "ves-io-1234.ac.vh.ves.io", "VES-IO-1234.ac.vh.ves.io")
+	if len(merged) != 5 {
+		t.Errorf("expected the auto hostname once, got %v", merged)
+	}
+}
@@ -0,0 +1,436 @@
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kubenetlabs/ngc/operator/api/v1alpha1"
+)
+
+// errOriginPending is returned while the published route's Gateway has no
+// address for XC to forward to.
+var errOriginPending = stderrors.New("no origin address: the Gateway has no status address and spec.distributedCloud.originAddress is not set")
+
+// errOriginNoHTTP2 is returned when a GRPCRoute attaches to a listener that
+// cannot serve HTTP/2.
+var errOriginNoHTTP2 = stderrors.New("origin does not support HTTP/2")
+
+// xcOrigin is where XC forwards traffic for a published route.
+type xcOrigin struct {
+	Address  string
+	Port     int32
+	TLS      bool
+	Listener string                 // listener the route attaches to, if found
+	Protocol gatewayv1.ProtocolType // that listener's protocol
+	GRPC     bool                   // forward over HTTP/2 and health check the gRPC service
+}
+
+// resolveXCOrigin derives the origin from the route's first parent Gateway:
+// its first status address, and the port and protocol of the listener the
+// route attaches to. spec.distributedCloud.originAddress overrides the address.
+func (r *XCPublishReconciler) resolveXCOrigin(ctx context.Context, publish *v1alpha1.DistributedCloudPublish, routeNs string, parentRefs []gatewayv1.ParentReference) (xcOrigin, error) {
+	origin := xcOrigin{Address: publish.Spec.DistributedCloud.OriginAddress, Port: 80}
+	if len(parentRefs) > 0 {
+		parentRef := parentRefs[0]
+		gwNs := routeNs
+		if parentRef.Namespace != nil {
+			gwNs = string(*parentRef.Namespace)
+		}
+		var gw gatewayv1.Gateway
+		if err := r.Get(ctx, types.NamespacedName{Namespace: gwNs, Name: string(parentRef.Name)}, &gw); err != nil {
+			return origin, fmt.Errorf("getting gateway %s/%s: %w", gwNs, parentRef.Name, err)
+		}
+		if origin.Address == "" && len(gw.Status.Addresses) > 0 {
+			origin.Address = gw.Status.Addresses[0].Value
+		}
+		for _, l := range gw.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != l.Name {
+				continue
+			}
+			origin.Port = int32(l.Port)
+			origin.TLS = l.Protocol == gatewayv1.HTTPSProtocolType || l.Protocol == gatewayv1.TLSProtocolType
+			origin.Listener, origin.Protocol = string(l.Name), l.Protocol
+			break
+		}
+	}
+	if origin.Address == "" {
+		return origin, errOriginPending
+	}
+	return origin, nil
+}
+
+// validateGRPCOrigin checks that the listener a GRPCRoute attaches to can
+// carry gRPC. NGINX Gateway serves GRPCRoutes over HTTP/2 on HTTP (h2c) and
+// HTTPS listeners only.
+func validateGRPCOrigin(origin xcOrigin) error {
+	switch origin.Protocol {
+	case "", gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return nil
+	}
+	return fmt.Errorf("%w: listener %q serves %s; a GRPCRoute needs an HTTP or HTTPS listener", errOriginNoHTTP2, origin.Listener, origin.Protocol)
+}
+
+// xcResourceName is the name of the XC HTTP load balancer published for a
+// route; its origin pool adds a "-pool" suffix.
+func xcResourceName(routeName string) string {
+	return "ngf-" + routeName
+}
+
+// xcGRPCHealthCheckName is the name of the XC health check published for a
+// GRPCRoute's origin pool.
+func xcGRPCHealthCheckName(routeName string) string {
+	return xcResourceName(routeName) + "-grpc-hc"
+}
+
+// grpcHealthCheckPath is the standard gRPC health checking service method.
+const grpcHealthCheckPath = "/grpc.health.v1.Health/Check"
+
+// buildXCGRPCHealthCheck constructs the XC health check for a GRPCRoute's
+// origin pool. It calls the gRPC health service over HTTP/2.
+func buildXCGRPCHealthCheck(routeName, xcNs string) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{"name": xcGRPCHealthCheckName(routeName), "namespace": xcNs},
+		"spec": map[string]any{
+			"http_health_check": map[string]any{
+				"path":                   grpcHealthCheckPath,
+				"use_http2":              true,
+				"use_origin_server_name": map[string]any{},
+				"expected_status_codes":  []any{"200"},
+			},
+			"timeout":             3,
+			"interval":            15,
+			"unhealthy_threshold": 1,
+			"healthy_threshold":   3,
+		},
+	}
+}
+
+// buildXCOriginPool constructs the XC origin pool that points back at the
+// Gateway. A gRPC origin is reached over HTTP/2 and health checked with the
+// route's gRPC health check.
+func buildXCOriginPool(routeName, xcNs string, origin xcOrigin) map[string]any {
+	server := map[string]any{"public_name": map[string]any{"dns_name": origin.Address}}
+	if ip := net.ParseIP(origin.Address); ip != nil && ip.To4() != nil {
+		server = map[string]any{"public_ip": map[string]any{"ip": origin.Address}}
+	}
+
+	spec := map[string]any{
+		"origin_servers":         []any{server},
+		"port":                   origin.Port,
+		"loadbalancer_algorithm": "ROUND_ROBIN",
+	}
+	if origin.TLS {
+		spec["use_tls"] = map[string]any{"use_host_header_as_sni": true}
+	} else {
+		spec["no_tls"] = map[string]any{}
+	}
+	if origin.GRPC {
+		spec["advanced_options"] = map[string]any{
+			"http2_options": map[string]any{"enabled": true},
+		}
+		spec["healthcheck"] = []any{
+			map[string]any{"namespace": xcNs, "name": xcGRPCHealthCheckName(routeName)},
+		}
+	}
+
+	return map[string]any{
+		"metadata": map[string]any{"name": xcResourceName(routeName) + "-pool"},
+		"spec":     spec,
+	}
+}
+
+// buildXCHTTPLoadBalancer constructs the XC HTTP load balancer for an
+// HTTPRoute. It mirrors the API server's HTTPRoute mapping: domains come from
+// the route hostnames (led by the public hostname, followed by the custom
+// domains), each rule match becomes
+// a simple route to the origin pool, and the WAF policy is attached or WAF
+// disabled explicitly.
+func buildXCHTTPLoadBalancer(route *gatewayv1.HTTPRoute, xcNs string, cfg v1alpha1.DistributedCloudConfig) map[string]any {
+	name := xcResourceName(route.Name)
+
+	domains := make([]any, 0, len(route.Spec.Hostnames)+len(cfg.Domains)+1)
+	domains = appendXCDomains(domains, cfg.PublicHostname)
+	for _, h := range route.Spec.Hostnames {
+		domains = appendXCDomains(domains, string(h))
+	}
+	domains = appendXCDomains(domains, cfg.Domains...)
+	if len(domains) == 0 {
+		domains = append(domains, name+".example.com")
+	}
+
+	poolRef := map[string]any{
+		"pool":   map[string]any{"namespace": xcNs, "name": name + "-pool"},
+		"weight": 1,
+	}
+
+	// Send the route's first hostname as the Host header so NGINX Gateway
+	// matches the route.
+	hostRewrite := ""
+	if len(route.Spec.Hostnames) > 0 {
+		hostRewrite = string(route.Spec.Hostnames[0])
+	}
+
+	simpleRoute := func(method string, path map[string]any) map[string]any {
+		sr := map[string]any{
+			"path":         path,
+			"origin_pools": []any{poolRef},
+		}
+		if method != "" {
+			sr["http_method"] = method
+		}
+		if hostRewrite != "" {
+			sr["host_rewrite"] = hostRewrite
+		}
+		if cfg.WebSocketEnabled {
+			sr["advanced_options"] = map[string]any{
+				"web_socket_config": map[string]any{"use_websocket": true},
+			}
+		}
+		return map[string]any{"simple_route": sr}
+	}
+
+	var routes []any
+	hasNonDefault := false
+	for _, rule := range route.Spec.Rules {
+		if len(rule.Matches) == 0 {
+			routes = append(routes, simpleRoute("", map[string]any{"prefix": "/"}))
+			continue
+		}
+		for _, match := range rule.Matches {
+			path := map[string]any{"prefix": "/"}
+			if match.Path != nil && match.Path.Value != nil {
+				pathType := gatewayv1.PathMatchPathPrefix
+				if match.Path.Type != nil {
+					pathType = *match.Path.Type
+				}
+				switch pathType {
+				case gatewayv1.PathMatchExact:
+					path = map[string]any{"exact": *match.Path.Value}
+				case gatewayv1.PathMatchRegularExpression:
+					path = map[string]any{"regex": *match.Path.Value}
+				default:
+					path = map[string]any{"prefix": *match.Path.Value}
+				}
+			}
+			method := ""
+			if match.Method != nil {
+				method = string(*match.Method)
+			}
+			if method != "" || path["prefix"] != "/" {
+				hasNonDefault = true
+			}
+			routes = append(routes, simpleRoute(method, path))
+		}
+	}
+
+	spec := map[string]any{
+		"domains":                         domains,
+		"advertise_on_public_default_vip": map[string]any{},
+		"default_route_pools":             []any{poolRef},
+		"http_listen_port":                80,
+		"http":                            map[string]any{"port": 80},
+	}
+	// Host rewrite and WebSocket are route-level settings in XC, so explicit
+	// routes are needed whenever either is set.
+	if len(routes) > 0 && (hostRewrite != "" || cfg.WebSocketEnabled || hasNonDefault) {
+		spec["routes"] = routes
+	}
+
+	if cfg.WAFPolicy != "" {
+		wafNs, wafName, ok := strings.Cut(cfg.WAFPolicy, "/")
+		if !ok {
+			wafNs, wafName = xcNs, cfg.WAFPolicy
+		}
+		spec["app_firewall"] = map[string]any{"namespace": wafNs, "name": wafName}
+	} else {
+		spec["disable_waf"] = map[string]any{}
+	}
+
+	return map[string]any{
+		"metadata": map[string]any{"name": name, "namespace": xcNs},
+		"spec":     spec,
+	}
+}
+
+// buildXCGRPCLoadBalancer constructs the XC HTTP load balancer for a
+// GRPCRoute. gRPC calls are HTTP/2 POSTs to /<service>/<method>, so each
+// method match becomes the equivalent path match and the route is mapped as
+// an HTTPRoute. WebSocket upgrades do not apply to gRPC and are left off.
+func buildXCGRPCLoadBalancer(route *gatewayv1.GRPCRoute, xcNs string, cfg v1alpha1.DistributedCloudConfig) map[string]any {
+	httpRoute := &gatewayv1.HTTPRoute{ObjectMeta: route.ObjectMeta}
+	httpRoute.Spec.Hostnames = route.Spec.Hostnames
+	for _, rule := range route.Spec.Rules {
+		var httpRule gatewayv1.HTTPRouteRule
+		for _, match := range rule.Matches {
+			httpRule.Matches = append(httpRule.Matches, gatewayv1.HTTPRouteMatch{Path: grpcMethodPathMatch(match.Method)})
+		}
+		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, httpRule)
+	}
+	cfg.WebSocketEnabled = false
+	return buildXCHTTPLoadBalancer(httpRoute, xcNs, cfg)
+}
+
+// grpcMethodPathMatch returns the HTTP path match equivalent to a GRPCRoute
+// method match, or nil to match every path.
+func grpcMethodPathMatch(m *gatewayv1.GRPCMethodMatch) *gatewayv1.HTTPPathMatch {
+	if m == nil || (m.Service == nil && m.Method == nil) {
+		return nil
+	}
+	service, method := "", ""
+	if m.Service != nil {
+		service = *m.Service
+	}
+	if m.Method != nil {
+		method = *m.Method
+	}
+
+	pathType, value := gatewayv1.PathMatchRegularExpression, ""
+	switch {
+	case m.Type != nil && *m.Type == gatewayv1.GRPCMethodMatchRegularExpression:
+		if service == "" {
+			service = "[^/]+"
+		}
+		if method == "" {
+			method = "[^/]+"
+		}
+		value = "/" + service + "/" + method
+	case service != "" && method != "":
+		pathType, value = gatewayv1.PathMatchExact, "/"+service+"/"+method
+	case service != "":
+		pathType, value = gatewayv1.PathMatchPathPrefix, "/"+service+"/"
+	default:
+		value = "/[^/]+/" + regexp.QuoteMeta(method)
+	}
+	return &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+}
+
+// appendXCDomains appends each non-empty domain that is not already in
+// domains, compared case-insensitively.
+func appendXCDomains(domains []any, add ...string) []any {
+	for _, d := range add {
+		if d == "" || slices.ContainsFunc(domains, func(existing any) bool {
+			s, _ := existing.(string)
+			return strings.EqualFold(s, d)
+		}) {
+			continue
+		}
+		domains = append(domains, d)
+	}
+	return domains
+}
+
+// xcAutoHostname returns the hostname XC generated for a load balancer
+// (spec.host_name, e.g. ves-io-{uuid}.ac.vh.ves.io), or "" if it has none yet.
+func xcAutoHostname(lb map[string]any) string {
+	spec, _ := lb["spec"].(map[string]any)
+	host, _ := spec["host_name"].(string)
+	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+	if !strings.HasSuffix(host, ".vh.ves.io") {
+		return ""
+	}
+	return host
+}
+
+// syncXCResources creates or replaces the origin pool and HTTP load balancer
+// for a publish, preceded by the gRPC health check for a GRPCRoute, and
+// records their names on its status. The LB's auto-generated hostname is
+// added to its domains and recorded as status.xcDNS.
+func (r *XCPublishReconciler) syncXCResources(ctx context.Context, xcClient *xcAPIClient, publish *v1alpha1.DistributedCloudPublish) error {
+	xcNs := publish.Spec.DistributedCloud.Namespace
+	if xcNs == "" {
+		xcNs = "default"
+	}
+
+	routeName := publish.Spec.HTTPRouteRef
+	key := types.NamespacedName{Namespace: publish.Namespace, Name: routeName}
+	var (
+		origin xcOrigin
+		lb     map[string]any
+		err    error
+	)
+	if publish.Spec.RouteKind == v1alpha1.RouteKindGRPCRoute {
+		var route gatewayv1.GRPCRoute
+		if err := r.Get(ctx, key, &route); err != nil {
+			return fmt.Errorf("getting GRPCRoute %q: %w", routeName, err)
+		}
+		if origin, err = r.resolveXCOrigin(ctx, publish, route.Namespace, route.Spec.ParentRefs); err != nil {
+			return err
+		}
+		if err := validateGRPCOrigin(origin); err != nil {
+			return err
+		}
+		origin.GRPC = true
+		if err := xcClient.applyHealthCheck(ctx, xcNs, buildXCGRPCHealthCheck(routeName, xcNs)); err != nil {
+			return fmt.Errorf("health check: %w", err)
+		}
+		lb = buildXCGRPCLoadBalancer(&route, xcNs, publish.Spec.DistributedCloud)
+	} else {
+		var route gatewayv1.HTTPRoute
+		if err := r.Get(ctx, key, &route); err != nil {
+			return fmt.Errorf("getting HTTPRoute %q: %w", routeName, err)
+		}
+		if origin, err = r.resolveXCOrigin(ctx, publish, route.Namespace, route.Spec.ParentRefs); err != nil {
+			return err
+		}
+		lb = buildXCHTTPLoadBalancer(&route, xcNs, publish.Spec.DistributedCloud)
+	}
+
+	pool := buildXCOriginPool(routeName, xcNs, origin)
+	if err := xcClient.applyOriginPool(ctx, xcNs, pool); err != nil {
+		return fmt.Errorf("origin pool: %w", err)
+	}
+	publish.Status.XCOriginPoolName = xcResourceName(routeName) + "-pool"
+
+	spec := lb["spec"].(map[string]any)
+	if publish.Status.XCDNS != "" {
+		spec["domains"] = appendXCDomains(spec["domains"].([]any), publish.Status.XCDNS)
+	}
+	if err := xcClient.applyHTTPLoadBalancer(ctx, xcNs, lb); err != nil {
+		return fmt.Errorf("HTTP load balancer: %w", err)
+	}
+	publish.Status.XCLoadBalancerName = xcResourceName(routeName)
+	publish.Status.WAFPolicyAttached = publish.Spec.DistributedCloud.WAFPolicy
+
+	// XC assigns the auto hostname on first create. Adding it is best effort:
+	// the LB still serves its configured domains without it.
+	if publish.Status.XCDNS == "" {
+		current, err := xcClient.getHTTPLoadBalancer(ctx, xcNs, publish.Status.XCLoadBalancerName)
+		if err != nil {
+			slog.Warn("could not fetch XC HTTP LB to discover its auto hostname", "name", publish.Status.XCLoadBalancerName, "error", err)
+		} else if host := xcAutoHostname(current); host != "" {
+			spec["domains"] = appendXCDomains(spec["domains"].([]any), host)
+			if err := xcClient.applyHTTPLoadBalancer(ctx, xcNs, lb); err != nil {
+				slog.Warn("could not add XC auto hostname to LB domains", "domain", host, "error", err)
+			} else {
+				publish.Status.XCDNS = host
+			}
+		}
+	}
+
+	return nil
+}
+
+// xcLoadBalancerExists reports whether the publish's HTTP load balancer is
+// still present in XC. Lookup errors are treated as present so a flaky XC
+// API does not trigger a re-apply.
+func xcLoadBalancerExists(ctx context.Context, xcClient *xcAPIClient, publish *v1alpha1.DistributedCloudPublish) bool {
+	xcNs := publish.Spec.DistributedCloud.Namespace
+	if xcNs == "" {
+		xcNs = "default"
+	}
+	lb, err := xcClient.getHTTPLoadBalancer(ctx, xcNs, publish.Status.XCLoadBalancerName)
+	if err != nil {
+		slog.Warn("failed to check XC HTTP LB", "name", publish.Status.XCLoadBalancerName, "error", err)
+		return true
+	}
+	return lb != nil
+}